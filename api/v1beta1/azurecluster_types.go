@@ -54,6 +54,11 @@ type AzureClusterSpec struct {
 	// this when creating an AzureCluster as CAPZ will set this for you. However, if it is set, CAPZ will not change it.
 	// +optional
 	ControlPlaneEndpoint clusterv1beta1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+
+	// DriftDetection enables the periodic detection of changes made outside of CAPZ to the Azure resources of the
+	// cluster and its machines. Drift is not detected when it is not set.
+	// +optional
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
 }
 
 // AzureClusterStatus defines the observed state of AzureCluster.
@@ -79,6 +84,37 @@ type AzureClusterStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// PlannedChanges lists the changes that reconciliation would make to the Azure resources of the
+	// cluster. It is only populated when the AzureCluster is annotated to run in plan mode.
+	// +optional
+	PlannedChanges []ResourceChange `json:"plannedChanges,omitempty"`
+
+	// EstimatedMonthlyCost is the estimated monthly cost of the network infrastructure of the cluster. It is only
+	// populated when cost estimation is enabled in the controller manager.
+	// +optional
+	EstimatedMonthlyCost *CostEstimate `json:"estimatedMonthlyCost,omitempty"`
+
+	// Drift reports the Azure resources of the cluster which had drifted from their desired state at the last
+	// drift scan. It is only populated when drift detection is enabled.
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
+
+	// ApplicationGatewayID is the resource ID of the Application Gateway created for ingress, to be used to configure
+	// the ingress controller. It is only populated when ingress is configured in the network spec.
+	// +optional
+	ApplicationGatewayID string `json:"applicationGatewayID,omitempty"`
+
+	// FirewallPrivateIP is the private IP of the Azure Firewall, which the default route of the node subnets points
+	// at. It is only populated when a firewall is configured in the network spec.
+	// +optional
+	FirewallPrivateIP string `json:"firewallPrivateIP,omitempty"`
+
+	// PrivateLinkServiceID is the resource ID of the private link service of the API server load balancer, to be used
+	// in the private link service connections of private endpoints. It is only populated when the API server load
+	// balancer has a private link service.
+	// +optional
+	PrivateLinkServiceID string `json:"privateLinkServiceID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
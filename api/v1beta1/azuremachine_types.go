@@ -119,6 +119,16 @@ type AzureMachineSpec struct {
 	// +optional
 	SpotVMOptions *SpotVMOptions `json:"spotVMOptions,omitempty"`
 
+	// SpotEvictionRecovery configures how an evicted Spot VM is brought back. When unset, an evicted Spot VM is only
+	// reported by the SpotVMRunning condition. Requires SpotVMOptions with the Deallocate eviction policy.
+	// +optional
+	SpotEvictionRecovery *SpotEvictionRecovery `json:"spotEvictionRecovery,omitempty"`
+
+	// CapacityFallback configures how the VM is retried when Azure fails to allocate it because of a lack of capacity
+	// for its size in its failure domain. When unset, the allocation is retried with the same failure domain and size.
+	// +optional
+	CapacityFallback *CapacityFallback `json:"capacityFallback,omitempty"`
+
 	// SecurityProfile specifies the Security profile settings for a virtual machine.
 	// +optional
 	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
@@ -185,6 +195,90 @@ type SpotVMOptions struct {
 	EvictionPolicy *SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
 }
 
+// SpotEvictionRecovery defines how an evicted Spot VM is recovered.
+type SpotEvictionRecovery struct {
+	// FallbackVMSizes are the VM sizes an evicted VM is resized to before it is restarted, one per eviction and in
+	// order. Once the list is exhausted the VM keeps the last size. When empty the VM is restarted with its size.
+	// +optional
+	FallbackVMSizes []string `json:"fallbackVMSizes,omitempty"`
+
+	// RegularPriorityAfterEvictions is the number of evictions after which the VM is recreated with regular
+	// priority from its existing disks instead of being restarted as a Spot VM. Not supported with ephemeral OS disks
+	// or with CapacityFallback.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RegularPriorityAfterEvictions *int32 `json:"regularPriorityAfterEvictions,omitempty"`
+}
+
+// SpotEvictionStatus reports the evictions of a Spot VM and how it is recovered.
+type SpotEvictionStatus struct {
+	// Evictions is the number of times the VM was evicted.
+	// +optional
+	Evictions int32 `json:"evictions,omitempty"`
+
+	// LastEvictionTime is when the last eviction was observed.
+	// +optional
+	LastEvictionTime *metav1.Time `json:"lastEvictionTime,omitempty"`
+
+	// VMSize is the fallback VM size the VM is recovered with, if any.
+	// +optional
+	VMSize string `json:"vmSize,omitempty"`
+
+	// RegularPriority is true once the VM is recovered with regular priority.
+	// +optional
+	RegularPriority bool `json:"regularPriority,omitempty"`
+}
+
+// CapacityFallback defines the failure domains and VM sizes a VM falls back to when Azure fails to allocate it.
+type CapacityFallback struct {
+	// AcrossFailureDomains allows retrying the allocation in the other failure domains of the cluster which can host
+	// the VM size, in the order of their names.
+	// +optional
+	AcrossFailureDomains bool `json:"acrossFailureDomains,omitempty"`
+
+	// VMSizes is a prioritized list of VM sizes to retry the allocation with once it failed with VMSize, in each
+	// allowed failure domain.
+	// +optional
+	VMSizes []string `json:"vmSizes,omitempty"`
+}
+
+// CapacityFallbackStatus reports the failed attempts to allocate a VM and the failure domain and VM size it falls
+// back to.
+type CapacityFallbackStatus struct {
+	// FailureDomain is the failure domain the VM is allocated in.
+	// +optional
+	FailureDomain string `json:"failureDomain,omitempty"`
+
+	// VMSize is the VM size the VM is allocated with.
+	// +optional
+	VMSize string `json:"vmSize,omitempty"`
+
+	// Attempts are the failed attempts to allocate the VM, in order.
+	// +optional
+	Attempts []AllocationAttempt `json:"attempts,omitempty"`
+
+	// RetryPending is true while the VM which failed to be allocated and its disks are deleted before the
+	// allocation is retried.
+	// +optional
+	RetryPending bool `json:"retryPending,omitempty"`
+}
+
+// AllocationAttempt is a failed attempt to allocate a VM.
+type AllocationAttempt struct {
+	// FailureDomain is the failure domain the allocation was attempted in.
+	// +optional
+	FailureDomain string `json:"failureDomain,omitempty"`
+
+	// VMSize is the VM size the allocation was attempted with.
+	VMSize string `json:"vmSize"`
+
+	// Reason is the error code Azure returned, e.g. ZonalAllocationFailed or SkuNotAvailable.
+	Reason string `json:"reason"`
+
+	// LastAttemptTime is when the allocation last failed.
+	LastAttemptTime metav1.Time `json:"lastAttemptTime"`
+}
+
 // SystemAssignedIdentityRole defines the role and scope to assign to the system assigned identity.
 type SystemAssignedIdentityRole struct {
 	// Name is the name of the role assignment to create for a system assigned identity. It can be any valid UUID.
@@ -263,6 +357,34 @@ type AzureMachineStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// EstimatedMonthlyCost is the estimated monthly cost of the VM, its disks and its public IP. It is only
+	// populated when cost estimation is enabled in the controller manager.
+	// +optional
+	EstimatedMonthlyCost *CostEstimate `json:"estimatedMonthlyCost,omitempty"`
+
+	// SpotEviction reports the evictions of a Spot VM and how it is recovered.
+	// +optional
+	SpotEviction *SpotEvictionStatus `json:"spotEviction,omitempty"`
+
+	// CapacityFallback reports the failed attempts to allocate the VM and how it falls back from them.
+	// +optional
+	CapacityFallback *CapacityFallbackStatus `json:"capacityFallback,omitempty"`
+
+	// FailureDomain is the failure domain the VM is allocated in, when it differs from the failure domain of the
+	// Machine because of a capacity fallback.
+	// +optional
+	FailureDomain string `json:"failureDomain,omitempty"`
+
+	// Drift reports the Azure resources of the machine which had drifted from their desired state at the last
+	// drift scan. It is only populated when drift detection is enabled on the AzureCluster.
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
+
+	// Bastion is the metadata needed to open a session to the VM through the Azure Bastion Host of the cluster. It is
+	// only populated when the AzureCluster has an Azure Bastion Host.
+	// +optional
+	Bastion *BastionConnection `json:"bastion,omitempty"`
 }
 
 // AdditionalCapabilities enables or disables a capability on the virtual machine.
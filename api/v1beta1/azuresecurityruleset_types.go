@@ -0,0 +1,56 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AzureSecurityRuleSetSpec defines the desired state of AzureSecurityRuleSet.
+type AzureSecurityRuleSetSpec struct {
+	// SecurityRules are the security rules added to the security group of each subnet referencing the rule set.
+	// Their names and priorities must not conflict with the other rules of the security group.
+	// +optional
+	SecurityRules SecurityRules `json:"securityRules,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of this AzureSecurityRuleSet"
+// +kubebuilder:resource:path=azuresecurityrulesets,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+
+// AzureSecurityRuleSet is the Schema for the azuresecurityrulesets API. It defines security rules which can be shared
+// by the subnets of several AzureClusters in the same namespace.
+type AzureSecurityRuleSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AzureSecurityRuleSetSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureSecurityRuleSetList contains a list of AzureSecurityRuleSet.
+type AzureSecurityRuleSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureSecurityRuleSet `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &AzureSecurityRuleSet{}, &AzureSecurityRuleSetList{})
+}
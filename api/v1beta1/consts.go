@@ -24,6 +24,9 @@ const (
 	NetworkInfrastructureReadyCondition clusterv1beta1.ConditionType = "NetworkInfrastructureReady"
 	// NamespaceNotAllowedByIdentity used to indicate cluster in a namespace not allowed by identity.
 	NamespaceNotAllowedByIdentity = "NamespaceNotAllowedByIdentity"
+	// WaitingForIPAMAllocationReason used when the cluster is waiting for the CIDR blocks of its virtual network or
+	// subnets to be allocated from an IPAM pool.
+	WaitingForIPAMAllocationReason = "WaitingForIPAMAllocation"
 )
 
 // AzureMachine Conditions and Reasons.
@@ -46,12 +49,19 @@ const (
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// WaitingForIPAddressAllocationReason used when machine is waiting for the private IP addresses of its network
+	// interfaces to be allocated from an IPAM pool before proceeding.
+	WaitingForIPAddressAllocationReason = "WaitingForIPAddressAllocation"
 	// BootstrapSucceededCondition reports the result of the execution of the bootstrap data on the machine.
 	BootstrapSucceededCondition clusterv1beta1.ConditionType = "BootstrapSucceeded"
 	// BootstrapInProgressReason is used to indicate the bootstrap data has not finished executing.
 	BootstrapInProgressReason = "BootstrapInProgress"
 	// BootstrapFailedReason is used to indicate the bootstrap process ran into an error.
 	BootstrapFailedReason = "BootstrapFailed"
+	// SpotVMRunningCondition reports whether a Spot VM is running or was evicted.
+	SpotVMRunningCondition clusterv1beta1.ConditionType = "SpotVMRunning"
+	// SpotVMEvictedReason used when a Spot VM was evicted by Azure.
+	SpotVMEvictedReason = "SpotVMEvicted"
 )
 
 // AzureMachinePool Conditions and Reasons.
@@ -78,6 +88,20 @@ const (
 	ScaleSetModelUpdatedCondition clusterv1beta1.ConditionType = "ScaleSetModelUpdated"
 	// ScaleSetModelOutOfDateReason describes the machine pool model being out of date.
 	ScaleSetModelOutOfDateReason = "ScaleSetModelOutOfDate"
+	// ScaleSetBlueGreenRolloutReason describes a new scale set being brought up with the latest model.
+	ScaleSetBlueGreenRolloutReason = "ScaleSetBlueGreenRollout"
+	// ScaleSetBlueGreenRolledBackReason describes a new scale set that did not become healthy in time and was rolled back.
+	ScaleSetBlueGreenRolledBackReason = "ScaleSetBlueGreenRolledBack"
+	// ScaleSetRolloutWaitingForHealthGateReason describes a rolling update held back until the latest model passes its health gate.
+	ScaleSetRolloutWaitingForHealthGateReason = "ScaleSetRolloutWaitingForHealthGate"
+	// ScaleSetRolloutPausedReason describes a rolling update paused because the latest model failed its health gate.
+	ScaleSetRolloutPausedReason = "ScaleSetRolloutPaused"
+	// ScaleSetRolloutRolledBackReason describes a rolling update reverted to the last model that passed its health gate.
+	ScaleSetRolloutRolledBackReason = "ScaleSetRolloutRolledBack"
+	// NodeHealthGatePassedCondition reports whether the node of a machine pool machine passes the rollout health gate.
+	NodeHealthGatePassedCondition clusterv1beta1.ConditionType = "NodeHealthGatePassed"
+	// NodeHealthGateFailedReason describes a node failing the rollout health gate.
+	NodeHealthGateFailedReason = "NodeHealthGateFailed"
 )
 
 // AzureManagedCluster Conditions and Reasons.
@@ -102,6 +126,8 @@ const (
 	SecurityGroupsReadyCondition clusterv1beta1.ConditionType = "SecurityGroupsReady"
 	// RouteTablesReadyCondition means the route tables exist and are ready to be used.
 	RouteTablesReadyCondition clusterv1beta1.ConditionType = "RouteTablesReady"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1beta1.ConditionType = "ApplicationSecurityGroupsReady"
 	// PublicIPsReadyCondition means the public IPs exist and are ready to be used.
 	PublicIPsReadyCondition clusterv1beta1.ConditionType = "PublicIPsReady"
 	// NATGatewaysReadyCondition means the NAT gateways exist and are ready to be used.
@@ -110,6 +136,9 @@ const (
 	SubnetsReadyCondition clusterv1beta1.ConditionType = "SubnetsReady"
 	// LoadBalancersReadyCondition means the load balancers exist and are ready to be used.
 	LoadBalancersReadyCondition clusterv1beta1.ConditionType = "LoadBalancersReady"
+	// PrivateLinkServiceReadyCondition means the private link service of the API server load balancer exists and is
+	// ready to be used.
+	PrivateLinkServiceReadyCondition clusterv1beta1.ConditionType = "PrivateLinkServiceReady"
 	// PrivateDNSZoneReadyCondition means the private DNS zone exists and is ready to be used.
 	PrivateDNSZoneReadyCondition clusterv1beta1.ConditionType = "PrivateDNSZoneReady"
 	// PrivateDNSLinkReadyCondition means the private DNS links exist and are ready to be used.
@@ -118,6 +147,10 @@ const (
 	PrivateDNSRecordReadyCondition clusterv1beta1.ConditionType = "PrivateDNSRecordReady"
 	// BastionHostReadyCondition means the bastion host exists and is ready to be used.
 	BastionHostReadyCondition clusterv1beta1.ConditionType = "BastionHostReady"
+	// ApplicationGatewayReadyCondition means the ingress application gateway exists and is ready to be used.
+	ApplicationGatewayReadyCondition clusterv1beta1.ConditionType = "ApplicationGatewayReady"
+	// AzureFirewallReadyCondition means the Azure Firewall and its firewall policy exist and are ready to be used.
+	AzureFirewallReadyCondition clusterv1beta1.ConditionType = "AzureFirewallReady"
 	// InboundNATRulesReadyCondition means the inbound NAT rules exist and are ready to be used.
 	InboundNATRulesReadyCondition clusterv1beta1.ConditionType = "InboundNATRulesReady"
 	// AvailabilitySetReadyCondition means the availability set exists and is ready to be used.
@@ -193,4 +226,6 @@ const (
 	AzureManagedMachinePoolTemplateKind = "AzureManagedMachinePoolTemplate"
 	// AzureClusterIdentityKind indicates the kind of an AzureClusterIdentity.
 	AzureClusterIdentityKind = "AzureClusterIdentity"
+	// AzureSecurityRuleSetKind indicates the kind of an AzureSecurityRuleSet.
+	AzureSecurityRuleSetKind = "AzureSecurityRuleSet"
 )
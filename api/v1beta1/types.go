@@ -20,7 +20,9 @@ import (
 	"slices"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/net"
 )
 
@@ -31,6 +33,10 @@ const (
 	Node string = "node"
 	// Bastion subnet label.
 	Bastion string = "bastion"
+	// Ingress subnet label.
+	Ingress string = "ingress"
+	// Firewall subnet label.
+	Firewall string = "firewall"
 	// Cluster subnet label.
 	Cluster string = "cluster"
 )
@@ -60,6 +66,114 @@ const (
 // Futures is a slice of Future.
 type Futures []Future
 
+// ResourceChangeAction describes what a reconciliation would do to an Azure resource.
+type ResourceChangeAction string
+
+const (
+	// ResourceChangeActionCreate means the resource does not exist and would be created.
+	ResourceChangeActionCreate ResourceChangeAction = "Create"
+	// ResourceChangeActionUpdate means the resource exists and would be updated.
+	ResourceChangeActionUpdate ResourceChangeAction = "Update"
+	// ResourceChangeActionNone means the resource exists and is up to date.
+	ResourceChangeActionNone ResourceChangeAction = "None"
+)
+
+// ResourceChange describes a change that a reconciliation would make to an Azure resource.
+type ResourceChange struct {
+	// ServiceName is the name of the Azure service that owns the resource.
+	ServiceName string `json:"serviceName"`
+
+	// ResourceName is the name of the Azure resource.
+	ResourceName string `json:"resourceName"`
+
+	// ResourceGroup is the name of the Azure resource group of the resource.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	// Action is the change that would be made to the resource.
+	// +kubebuilder:validation:Enum=Create;Update;None
+	Action ResourceChangeAction `json:"action"`
+
+	// Diff is a human-readable description of the fields that would change.
+	// It is empty when the resource would be created or left as is.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
+
+// DriftPolicy describes what is done when an Azure resource has drifted from its desired state.
+type DriftPolicy string
+
+const (
+	// DriftPolicyReportOnly reports drifted resources without changing them.
+	DriftPolicyReportOnly DriftPolicy = "ReportOnly"
+	// DriftPolicyAutoCorrect reports drifted resources and updates them to their desired state.
+	DriftPolicyAutoCorrect DriftPolicy = "AutoCorrect"
+)
+
+// DriftDetection configures the periodic detection of changes made to Azure resources outside of CAPZ.
+type DriftDetection struct {
+	// Policy is what is done when a resource has drifted. ReportOnly reports it, AutoCorrect also updates the
+	// resource to its desired state.
+	// +kubebuilder:validation:Enum=ReportOnly;AutoCorrect
+	// +kubebuilder:default=ReportOnly
+	// +optional
+	Policy DriftPolicy `json:"policy,omitempty"`
+
+	// Interval is the time between two drift scans. Defaults to 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ResourceDrift describes an Azure resource whose live state differs from its desired state.
+type ResourceDrift struct {
+	// ServiceName is the name of the Azure service that owns the resource.
+	ServiceName string `json:"serviceName"`
+
+	// ResourceName is the name of the Azure resource.
+	ResourceName string `json:"resourceName"`
+
+	// ResourceGroup is the name of the Azure resource group of the resource.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	// Diff is a human-readable description of the fields that differ from the desired state.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+
+	// Corrected is true when the resource was updated to its desired state.
+	// +optional
+	Corrected bool `json:"corrected,omitempty"`
+}
+
+// DriftStatus reports the result of the last drift scan.
+type DriftStatus struct {
+	// LastScanTime is when the Azure resources were last scanned for drift.
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+
+	// Resources are the resources which had drifted at the last scan.
+	// +optional
+	Resources []ResourceDrift `json:"resources,omitempty"`
+
+	// UnscannedServices are the names of the Azure services whose resources are not scanned for drift.
+	// +optional
+	UnscannedServices []string `json:"unscannedServices,omitempty"`
+}
+
+// CostEstimate is the estimated monthly cost of the Azure resources described by a spec, based on the price sheet
+// configured in the controller manager.
+type CostEstimate struct {
+	// MonthlyCost is the estimated cost per month as a decimal number, e.g. 123.45.
+	MonthlyCost string `json:"monthlyCost"`
+
+	// Currency is the currency of MonthlyCost, e.g. USD.
+	Currency string `json:"currency"`
+
+	// UnpricedResources lists the resources missing from the price sheet, which are excluded from MonthlyCost.
+	// +optional
+	UnpricedResources []string `json:"unpricedResources,omitempty"`
+}
+
 const (
 	// PatchFuture is a future that was derived from a PATCH request.
 	PatchFuture string = "PATCH"
@@ -67,6 +181,8 @@ const (
 	PutFuture string = "PUT"
 	// DeleteFuture is a future that was derived from a DELETE request.
 	DeleteFuture string = "DELETE"
+	// PostFuture is a future that was derived from a POST request.
+	PostFuture string = "POST"
 )
 
 // Future contains the data needed for an Azure long-running operation to continue across reconcile loops.
@@ -124,6 +240,16 @@ type NetworkSpec struct {
 	// +optional
 	PrivateDNSZone *PrivateDNSZoneMode `json:"privateDNSZone,omitempty"`
 
+	// Ingress is the configuration for an Application Gateway in a dedicated subnet of the virtual network which can
+	// be used by an ingress controller such as the Application Gateway Ingress Controller (AGIC).
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+
+	// Firewall is the configuration for an Azure Firewall in a dedicated subnet of the virtual network. When set, the
+	// default route of the node subnets points at the Azure Firewall so that their egress traffic is filtered by its policy.
+	// +optional
+	Firewall *FirewallSpec `json:"firewall,omitempty"`
+
 	NetworkClassSpec `json:",inline"`
 }
 
@@ -155,9 +281,64 @@ type VnetSpec struct {
 	// +optional
 	Peerings VnetPeerings `json:"peerings,omitempty"`
 
+	// Hub references the hub virtual network of a hub-and-spoke network topology. When set, the virtual network of the
+	// cluster is peered with the hub virtual network in both directions and the private DNS zone of the cluster is
+	// linked to the hub virtual network.
+	// +optional
+	Hub *HubVnetSpec `json:"hub,omitempty"`
+
+	// SubscriptionID is the ID of the subscription of the virtual network, when it is not in the subscription of the
+	// cluster. The subnets, network security groups, route tables and application security groups of the virtual
+	// network and its peerings to the remote virtual networks are managed in this subscription too.
+	// Defaults to the subscription of the cluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to an AzureClusterIdentity used to manage the virtual network, its subnets, network
+	// security groups, route tables, application security groups and peerings in SubscriptionID. It can only be set
+	// together with SubscriptionID. Defaults to the identity of the cluster.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+
+	// IPAMPoolRef is a reference to a Cluster API IPAM pool from which the CIDR block of the virtual network is
+	// allocated when CIDRBlocks is empty. The pool must allocate network prefixes: the address and prefix of the
+	// IPAddress allocated for the claim of the virtual network are used as its CIDR block.
+	// +optional
+	IPAMPoolRef *corev1.TypedLocalObjectReference `json:"ipamPoolRef,omitempty"`
+
 	VnetClassSpec `json:",inline"`
 }
 
+// HubVnetSpec specifies the existing hub virtual network of a hub-and-spoke network topology.
+type HubVnetSpec struct {
+	// Name is the name of the hub virtual network.
+	Name string `json:"name"`
+
+	// ResourceGroup is the name of the resource group of the hub virtual network.
+	ResourceGroup string `json:"resourceGroup"`
+
+	// SubscriptionID is the ID of the subscription of the hub virtual network.
+	// Defaults to the subscription of the cluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to an AzureClusterIdentity used to manage the peering from the hub virtual network
+	// to the virtual network of the cluster. It can only be set together with SubscriptionID.
+	// Defaults to the identity of the cluster.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+
+	// ForwardPeeringProperties specifies VnetPeeringProperties for peering from the cluster's virtual network to the
+	// hub virtual network.
+	// +optional
+	ForwardPeeringProperties VnetPeeringProperties `json:"forwardPeeringProperties,omitempty"`
+
+	// ReversePeeringProperties specifies VnetPeeringProperties for peering from the hub virtual network to the
+	// cluster's virtual network.
+	// +optional
+	ReversePeeringProperties VnetPeeringProperties `json:"reversePeeringProperties,omitempty"`
+}
+
 // VnetPeeringSpec specifies an existing remote virtual network to peer with the AzureCluster's virtual network.
 type VnetPeeringSpec struct {
 	VnetPeeringClassSpec `json:",inline"`
@@ -172,6 +353,17 @@ type VnetPeeringClassSpec struct {
 	// RemoteVnetName defines name of the remote virtual network.
 	RemoteVnetName string `json:"remoteVnetName"`
 
+	// SubscriptionID is the ID of the subscription of the remote virtual network.
+	// Defaults to the subscription of the cluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to an AzureClusterIdentity used to manage the peering from the remote virtual network
+	// to the virtual network of the cluster. It can only be set together with SubscriptionID.
+	// Defaults to the identity of the cluster.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+
 	// ForwardPeeringProperties specifies VnetPeeringProperties for peering from the cluster's virtual network to the
 	// remote virtual network.
 	// +optional
@@ -216,6 +408,11 @@ func (v *VnetSpec) IsManaged(clusterName string) bool {
 	return v.ID == "" || v.Tags.HasOwned(clusterName)
 }
 
+// IsIPAMAllocationPending returns true if the CIDR blocks of the vnet are still to be allocated from its IPAM pool.
+func (v *VnetSpec) IsIPAMAllocationPending() bool {
+	return v.IPAMPoolRef != nil && len(v.CIDRBlocks) == 0
+}
+
 // Subnets is a slice of Subnet.
 // +listType=map
 // +listMapKey=name
@@ -335,9 +532,15 @@ type SecurityRule struct {
 	Source *string `json:"source,omitempty"`
 	// Sources specifies The CIDR or source IP ranges.
 	Sources []*string `json:"sources,omitempty"`
+	// SourceApplicationSecurityGroups are the application security groups where network traffic originates from, specified by the name of an application security group in the resource group of the cluster or by its resource ID. It cannot be set together with source or sources.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// DestinationApplicationSecurityGroups are the application security groups network traffic is sent to, specified by the name of an application security group in the resource group of the cluster or by its resource ID. It cannot be set together with destination.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
 	// Action specifies whether network traffic is allowed or denied. Can either be "Allow" or "Deny". Defaults to "Allow".
 	// +kubebuilder:default=Allow
 	// +kubebuilder:validation:Enum=Allow;Deny
@@ -363,6 +566,11 @@ type LoadBalancerSpec struct {
 	// FrontendIPsCount specifies the number of frontend IP addresses for the load balancer.
 	// +optional
 	FrontendIPsCount *int32 `json:"frontendIPsCount,omitempty"`
+	// IPv6FrontendIPsCount specifies the number of IPv6 frontend IP addresses of the load balancer, in addition to the
+	// FrontendIPsCount IPv4 ones, for the outbound traffic of the IPv6 addresses of dual-stack nodes.
+	// Only supported on the node outbound load balancer, and requires a dual-stack virtual network and node subnet.
+	// +optional
+	IPv6FrontendIPsCount *int32 `json:"ipv6FrontendIPsCount,omitempty"`
 	// BackendPool describes the backend pool of the load balancer.
 	// +optional
 	BackendPool BackendPool `json:"backendPool,omitempty"`
@@ -378,6 +586,153 @@ type LoadBalancerSpec struct {
 	// +kubebuilder:validation:MaxItems=3
 	// +kubebuilder:validation:items:Pattern=`^[1-3]$`
 	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// AdditionalBackendPools are backend pools of the load balancer in addition to its default backend pool, which can
+	// be referenced by its load-balancing rules. Their members are not managed by CAPZ.
+	// Only supported on the API server and node outbound load balancers. Entries cannot be removed once added, as they
+	// are not removed from the existing load balancer.
+	// +optional
+	AdditionalBackendPools []BackendPool `json:"additionalBackendPools,omitempty"`
+
+	// Probes are health probes of the load balancer in addition to the ones built by CAPZ, which can be referenced by
+	// its load-balancing rules.
+	// Only supported on the API server and node outbound load balancers. Entries cannot be removed once added, as they
+	// are not removed from the existing load balancer.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Probes []LoadBalancerProbe `json:"probes,omitempty"`
+
+	// Rules are load-balancing rules of the load balancer in addition to the ones built by CAPZ.
+	// Only supported on the API server and node outbound load balancers. Entries cannot be removed once added, as they
+	// are not removed from the existing load balancer.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Rules []LoadBalancerRule `json:"rules,omitempty"`
+
+	// PrivateLinkService is an Azure Private Link Service in front of the load balancer, through which private
+	// endpoints in other virtual networks, subscriptions or tenants can reach the API server without virtual network
+	// peering.
+	// Only supported on the API server load balancer, when it is internal or the APIServerILB feature is enabled.
+	// +optional
+	PrivateLinkService *PrivateLinkServiceSpec `json:"privateLinkService,omitempty"`
+}
+
+// LoadBalancerProbeProtocol defines the protocol of a load balancer health probe.
+type LoadBalancerProbeProtocol string
+
+const (
+	// LoadBalancerProbeProtocolTCP probes an endpoint with a TCP connection.
+	LoadBalancerProbeProtocolTCP = LoadBalancerProbeProtocol("Tcp")
+	// LoadBalancerProbeProtocolHTTP probes an endpoint with an HTTP request.
+	LoadBalancerProbeProtocolHTTP = LoadBalancerProbeProtocol("Http")
+	// LoadBalancerProbeProtocolHTTPS probes an endpoint with an HTTPS request.
+	LoadBalancerProbeProtocolHTTPS = LoadBalancerProbeProtocol("Https")
+)
+
+// LoadBalancerProbe defines a health probe of a load balancer.
+type LoadBalancerProbe struct {
+	// Name is the name of the probe.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Protocol is the protocol of the probe.
+	// +kubebuilder:validation:Enum=Tcp;Http;Https
+	Protocol LoadBalancerProbeProtocol `json:"protocol"`
+
+	// Port is the port of the backend endpoints probed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// RequestPath is the path requested from the backend endpoints by HTTP and HTTPS probes.
+	// Required for HTTP and HTTPS probes, and not allowed for TCP probes.
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+
+	// IntervalInSeconds is the interval between two probes of a backend endpoint. Defaults to 15.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	IntervalInSeconds *int32 `json:"intervalInSeconds,omitempty"`
+
+	// Threshold is the number of consecutive successful or failed probes after which a backend endpoint is marked up
+	// or down. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Threshold *int32 `json:"threshold,omitempty"`
+}
+
+// LoadBalancerRuleProtocol defines the transport protocol of a load-balancing rule.
+type LoadBalancerRuleProtocol string
+
+const (
+	// LoadBalancerRuleProtocolTCP load balances TCP traffic.
+	LoadBalancerRuleProtocolTCP = LoadBalancerRuleProtocol("Tcp")
+	// LoadBalancerRuleProtocolUDP load balances UDP traffic.
+	LoadBalancerRuleProtocolUDP = LoadBalancerRuleProtocol("Udp")
+)
+
+// LoadBalancerRule defines a load-balancing rule of a load balancer.
+type LoadBalancerRule struct {
+	// Name is the name of the load-balancing rule.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Protocol is the transport protocol of the load-balancing rule. Defaults to Tcp.
+	// +kubebuilder:validation:Enum=Tcp;Udp
+	// +optional
+	Protocol LoadBalancerRuleProtocol `json:"protocol,omitempty"`
+
+	// FrontendPort is the port of the frontend IP of the load balancer.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	FrontendPort int32 `json:"frontendPort"`
+
+	// BackendPort is the port of the backend endpoints. Defaults to the frontend port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	BackendPort *int32 `json:"backendPort,omitempty"`
+
+	// FrontendIPName is the name of the frontend IP of the load balancer the rule applies to. Defaults to the first
+	// frontend IP of the load balancer.
+	// +optional
+	FrontendIPName string `json:"frontendIPName,omitempty"`
+
+	// BackendPoolName is the name of the backend pool of the load balancer traffic is sent to, either its default
+	// backend pool or one of its additional backend pools. Defaults to the default backend pool.
+	// +optional
+	BackendPoolName string `json:"backendPoolName,omitempty"`
+
+	// ProbeName is the name of the health probe of the backend endpoints, one of the probes of the load balancer.
+	// If omitted, no health probe is used.
+	// +optional
+	ProbeName string `json:"probeName,omitempty"`
+
+	// EnableFloatingIP enables floating IP, aka direct server return, on the load-balancing rule.
+	// +optional
+	EnableFloatingIP bool `json:"enableFloatingIP,omitempty"`
+}
+
+// PrivateLinkServiceSpec defines an Azure Private Link Service in front of an internal load balancer.
+type PrivateLinkServiceSpec struct {
+	// Name is the name of the private link service. Defaults to the name of the load balancer with a "-pls" suffix.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// AllowedSubscriptions are the IDs of the subscriptions which can see the private link service and request a
+	// private endpoint connection to it. Connections requested from other subscriptions are rejected.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	AllowedSubscriptions []string `json:"allowedSubscriptions"`
+
+	// AutoApprovedSubscriptions are the IDs of the subscriptions whose private endpoint connections are approved
+	// automatically. They must also be allowed. The connections of the other allowed subscriptions stay pending until
+	// they are approved on the private link service.
+	// +optional
+	// +listType=set
+	AutoApprovedSubscriptions []string `json:"autoApprovedSubscriptions,omitempty"`
 }
 
 // SKU defines an Azure load balancer SKU.
@@ -415,8 +770,28 @@ type PublicIPSpec struct {
 	DNSName string `json:"dnsName,omitempty"`
 	// +optional
 	IPTags []IPTag `json:"ipTags,omitempty"`
+	// IPVersion is the IP version of the public IP address. Defaults to IPv4.
+	// IPv6 is only supported on the frontend IPs of the node outbound load balancer.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	IPVersion IPVersion `json:"ipVersion,omitempty"`
+}
+
+// IsIPv6 returns whether the public IP address is an IPv6 address.
+func (p PublicIPSpec) IsIPv6() bool {
+	return p.IPVersion == IPv6
 }
 
+// IPVersion defines the version of an IP address.
+type IPVersion string
+
+const (
+	// IPv4 is the value for IPv4 addresses.
+	IPv4 = IPVersion("IPv4")
+	// IPv6 is the value for IPv6 addresses.
+	IPv6 = IPVersion("IPv6")
+)
+
 // IPTag contains the IpTag associated with the object.
 type IPTag struct {
 	// Type specifies the IP tag type. Example: FirstPartyUsage.
@@ -784,6 +1159,12 @@ const (
 	// SubnetBastion defines a Bastion subnet role.
 	SubnetBastion = SubnetRole(Bastion)
 
+	// SubnetIngress defines an ingress Application Gateway subnet role.
+	SubnetIngress = SubnetRole(Ingress)
+
+	// SubnetFirewall defines an Azure Firewall subnet role.
+	SubnetFirewall = SubnetRole(Firewall)
+
 	// SubnetCluster defines a role that can be used for both Kubernetes control plane node and Kubernetes workload node.
 	SubnetCluster = SubnetRole(Cluster)
 )
@@ -807,6 +1188,12 @@ type SubnetSpec struct {
 	// +optional
 	NatGateway NatGateway `json:"natGateway,omitempty"`
 
+	// IPAMPoolRef is a reference to a Cluster API IPAM pool from which the CIDR block of the subnet is allocated when
+	// CIDRBlocks is empty. The pool must allocate network prefixes: the address and prefix of the IPAddress allocated
+	// for the claim of the subnet are used as its CIDR block.
+	// +optional
+	IPAMPoolRef *corev1.TypedLocalObjectReference `json:"ipamPoolRef,omitempty"`
+
 	SubnetClassSpec `json:",inline"`
 }
 
@@ -875,6 +1262,23 @@ type NetworkInterface struct {
 	// +kubebuilder:validation:nullable
 	// +optional
 	AcceleratedNetworking *bool `json:"acceleratedNetworking,omitempty"`
+
+	// PrivateIPAddress is the static private IP address of the primary IP configuration of the network interface.
+	// It must be within the subnet of the network interface and is set from the allocated IP address when
+	// IPAMPoolRef is set. If omitted, the private IP address is allocated dynamically by Azure.
+	// +optional
+	PrivateIPAddress string `json:"privateIPAddress,omitempty"`
+
+	// IPAMPoolRef is a reference to a Cluster API IPAM pool, e.g. an InClusterIPPool, from which the static private IP
+	// address of the network interface is allocated. The IP address is claimed for the lifetime of the machine.
+	// +optional
+	IPAMPoolRef *corev1.TypedLocalObjectReference `json:"ipamPoolRef,omitempty"`
+}
+
+// IsIPAMAllocationPending returns true if the private IP address of the network interface is still to be allocated
+// from its IPAM pool.
+func (n NetworkInterface) IsIPAMAllocationPending() bool {
+	return n.IPAMPoolRef != nil && n.PrivateIPAddress == ""
 }
 
 // GetControlPlaneSubnet returns a subnet that has a role assigned to controlplane or all. Subnets with role controlplane are given higher priority.
@@ -926,6 +1330,11 @@ func (s SubnetSpec) IsIPv6Enabled() bool {
 	return slices.ContainsFunc(s.CIDRBlocks, net.IsIPv6CIDRString)
 }
 
+// IsIPAMAllocationPending returns true if the CIDR blocks of the subnet are still to be allocated from its IPAM pool.
+func (s SubnetSpec) IsIPAMAllocationPending() bool {
+	return s.IPAMPoolRef != nil && len(s.CIDRBlocks) == 0
+}
+
 // GetSecurityRuleByDestination returns security group rule, which matches provided destination ports.
 func (s SubnetSpec) GetSecurityRuleByDestination(port string) *SecurityRule {
 	for _, rule := range s.SecurityGroup.SecurityRules {
@@ -1095,6 +1504,116 @@ type AzureBastion struct {
 	// +kubebuilder:default=false
 	// +optional
 	EnableTunneling bool `json:"enableTunneling,omitempty"`
+	// ScaleUnits is the number of scale units of the Azure Bastion Host, which determines how many concurrent sessions
+	// it supports. Must be between 2 and 50 and requires the Standard SKU. Defaults to 2.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	ScaleUnits *int32 `json:"scaleUnits,omitempty"`
+	// EnableIPConnect enables connecting to VMs through their private IP address. Requires the Standard SKU.
+	// When unset, the setting of the Azure Bastion Host is left unchanged.
+	// +optional
+	EnableIPConnect *bool `json:"enableIPConnect,omitempty"`
+	// EnableShareableLink enables shareable links, which let users connect to VMs without access to the Azure portal.
+	// Requires the Standard SKU. When unset, the setting of the Azure Bastion Host is left unchanged.
+	// +optional
+	EnableShareableLink *bool `json:"enableShareableLink,omitempty"`
+	// DisableCopyPaste disables copy and paste in the sessions of the Azure Bastion Host.
+	// When unset, the setting of the Azure Bastion Host is left unchanged.
+	// +optional
+	DisableCopyPaste *bool `json:"disableCopyPaste,omitempty"`
+	// EnableFileCopy enables uploading and downloading files through the native client. Requires the Standard SKU.
+	// When unset, the setting of the Azure Bastion Host is left unchanged.
+	// +optional
+	EnableFileCopy *bool `json:"enableFileCopy,omitempty"`
+}
+
+// BastionConnection is the metadata needed to open an Azure Bastion session to a VM.
+type BastionConnection struct {
+	// BastionHostID is the resource ID of the Azure Bastion Host of the cluster.
+	BastionHostID string `json:"bastionHostID"`
+	// TargetResourceID is the resource ID of the VM.
+	TargetResourceID string `json:"targetResourceID"`
+	// Username is the name of the SSH user of the VM.
+	Username string `json:"username"`
+}
+
+// ApplicationGatewaySKU is the SKU of an Application Gateway.
+type ApplicationGatewaySKU string
+
+const (
+	// StandardV2ApplicationGatewaySKU is the Standard v2 Application Gateway SKU.
+	StandardV2ApplicationGatewaySKU ApplicationGatewaySKU = "Standard_v2"
+	// WAFV2ApplicationGatewaySKU is the WAF v2 Application Gateway SKU, which supports a web application firewall.
+	WAFV2ApplicationGatewaySKU ApplicationGatewaySKU = "WAF_v2"
+)
+
+// IngressSpec specifies how the Application Gateway used for ingress should be configured.
+// The ingress controller owns the listeners, backends and rules of the Application Gateway, CAPZ only creates it with
+// a placeholder configuration.
+type IngressSpec struct {
+	// Name is the name of the Application Gateway. Defaults to <cluster-name>-appgw.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// SKU is the SKU of the Application Gateway. Can be either Standard_v2 or WAF_v2. Defaults to Standard_v2.
+	// +kubebuilder:default=Standard_v2
+	// +kubebuilder:validation:Enum=Standard_v2;WAF_v2
+	// +optional
+	SKU ApplicationGatewaySKU `json:"sku,omitempty"`
+	// Capacity is the number of instances of the Application Gateway. Defaults to 2.
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=125
+	// +optional
+	Capacity int32 `json:"capacity,omitempty"`
+	// WAFPolicyID is the resource ID of the web application firewall policy to associate with the Application
+	// Gateway. It requires the WAF_v2 SKU.
+	// +optional
+	WAFPolicyID string `json:"wafPolicyID,omitempty"`
+	// Subnet is the dedicated subnet of the Application Gateway.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+	// PublicIP is the public IP of the frontend of the Application Gateway.
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
+}
+
+// AzureFirewallSKUTier is the SKU tier of an Azure Firewall and of its firewall policy.
+type AzureFirewallSKUTier string
+
+const (
+	// StandardAzureFirewallSKUTier is the Standard Azure Firewall SKU tier.
+	StandardAzureFirewallSKUTier AzureFirewallSKUTier = "Standard"
+	// PremiumAzureFirewallSKUTier is the Premium Azure Firewall SKU tier.
+	PremiumAzureFirewallSKUTier AzureFirewallSKUTier = "Premium"
+)
+
+// FirewallSpec specifies how the Azure Firewall used for egress should be configured.
+// CAPZ creates the Azure Firewall with a firewall policy that allows the egress traffic required by Kubernetes, image
+// registries and Azure endpoints from the node subnets.
+type FirewallSpec struct {
+	// Name is the name of the Azure Firewall. Defaults to <cluster-name>-azfw.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// SKUTier is the SKU tier of the Azure Firewall and of its firewall policy. Can be either Standard or Premium.
+	// Defaults to Standard.
+	// +kubebuilder:default=Standard
+	// +kubebuilder:validation:Enum=Standard;Premium
+	// +optional
+	SKUTier AzureFirewallSKUTier `json:"skuTier,omitempty"`
+	// PolicyName is the name of the firewall policy of the Azure Firewall. Defaults to <cluster-name>-azfw-policy.
+	// +optional
+	PolicyName string `json:"policyName,omitempty"`
+	// AdditionalFQDNs is a list of fully qualified domain names the node subnets are allowed to reach over HTTP and
+	// HTTPS, in addition to the default ones. Wildcards such as *.example.com are supported.
+	// +optional
+	AdditionalFQDNs []string `json:"additionalFQDNs,omitempty"`
+	// Subnet is the dedicated subnet of the Azure Firewall. Its name must be AzureFirewallSubnet and its size at least /26.
+	// +optional
+	Subnet SubnetSpec `json:"subnet,omitempty"`
+	// PublicIP is the public IP the egress traffic of the Azure Firewall is translated to.
+	// +optional
+	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
 }
 
 // FleetsMember defines the fleets member configuration.
@@ -463,6 +463,26 @@ type NetworkClassSpec struct {
 	// If not specified, the resource group of the cluster will be used to create the Azure Private DNS Zone.
 	// +optional
 	PrivateDNSZoneResourceGroup string `json:"privateDNSZoneResourceGroup,omitempty"`
+
+	// PrivateDNSZoneID is the resource ID of an existing Azure Private DNS Zone, which can be in another subscription,
+	// used for the API server of a private cluster instead of a zone created in the resource group of the cluster.
+	// Only the API server record and the virtual network links inside the zone are managed, the zone itself is never
+	// created nor deleted. It cannot be set together with PrivateDNSZoneName or PrivateDNSZoneResourceGroup.
+	// +optional
+	PrivateDNSZoneID string `json:"privateDNSZoneID,omitempty"`
+
+	// PrivateDNSZoneIdentityRef is a reference to an AzureClusterIdentity used to manage the records and virtual network
+	// links of the private DNS zone referenced by PrivateDNSZoneID, when the zone is in another subscription than the
+	// cluster. It can only be set together with PrivateDNSZoneID. Defaults to the identity of the cluster.
+	// +optional
+	PrivateDNSZoneIdentityRef *corev1.ObjectReference `json:"privateDNSZoneIdentityRef,omitempty"`
+
+	// ApplicationSecurityGroupsEnabled creates an application security group named "<cluster>-<role>-asg" in the
+	// resource group of the cluster for each machine role, control-plane and node, and adds the network interfaces of
+	// the AzureMachines to the application security group of their role. Security rules can then reference them in
+	// sourceApplicationSecurityGroups and destinationApplicationSecurityGroups. It cannot be disabled once enabled.
+	// +optional
+	ApplicationSecurityGroupsEnabled bool `json:"applicationSecurityGroupsEnabled,omitempty"`
 }
 
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
@@ -482,7 +502,7 @@ type SubnetClassSpec struct {
 	Name string `json:"name"`
 
 	// Role defines the subnet role (eg. Node, ControlPlane)
-	// +kubebuilder:validation:Enum=node;control-plane;bastion;cluster
+	// +kubebuilder:validation:Enum=node;control-plane;bastion;ingress;firewall;cluster
 	Role SubnetRole `json:"role"`
 
 	// CIDRBlocks defines the subnet's address space, specified as one or more address prefixes in CIDR notation.
@@ -527,6 +547,11 @@ type FleetsMemberClassSpec struct {
 type SecurityGroupClass struct {
 	// +optional
 	SecurityRules SecurityRules `json:"securityRules,omitempty"`
+	// SecurityRuleSets are the names of AzureSecurityRuleSets in the namespace of the cluster whose security rules
+	// are added to the security group.
+	// +optional
+	// +listType=set
+	SecurityRuleSets []string `json:"securityRuleSets,omitempty"`
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
 }
@@ -222,6 +222,22 @@ func (in *AddressRecord) DeepCopy() *AddressRecord {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationAttempt) DeepCopyInto(out *AllocationAttempt) {
+	*out = *in
+	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationAttempt.
+func (in *AllocationAttempt) DeepCopy() *AllocationAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
 	*out = *in
@@ -980,6 +996,31 @@ func (in *AzureBastion) DeepCopyInto(out *AzureBastion) {
 	*out = *in
 	in.Subnet.DeepCopyInto(&out.Subnet)
 	in.PublicIP.DeepCopyInto(&out.PublicIP)
+	if in.ScaleUnits != nil {
+		in, out := &in.ScaleUnits, &out.ScaleUnits
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnableIPConnect != nil {
+		in, out := &in.EnableIPConnect, &out.EnableIPConnect
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableShareableLink != nil {
+		in, out := &in.EnableShareableLink, &out.EnableShareableLink
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableCopyPaste != nil {
+		in, out := &in.DisableCopyPaste, &out.DisableCopyPaste
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableFileCopy != nil {
+		in, out := &in.EnableFileCopy, &out.EnableFileCopy
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBastion.
@@ -1220,6 +1261,11 @@ func (in *AzureClusterSpec) DeepCopyInto(out *AzureClusterSpec) {
 	in.NetworkSpec.DeepCopyInto(&out.NetworkSpec)
 	in.BastionSpec.DeepCopyInto(&out.BastionSpec)
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterSpec.
@@ -1254,6 +1300,21 @@ func (in *AzureClusterStatus) DeepCopyInto(out *AzureClusterStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]ResourceChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.EstimatedMonthlyCost != nil {
+		in, out := &in.EstimatedMonthlyCost, &out.EstimatedMonthlyCost
+		*out = new(CostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterStatus.
@@ -1551,6 +1612,16 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 		*out = new(SpotVMOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SpotEvictionRecovery != nil {
+		in, out := &in.SpotEvictionRecovery, &out.SpotEvictionRecovery
+		*out = new(SpotEvictionRecovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityFallback != nil {
+		in, out := &in.CapacityFallback, &out.CapacityFallback
+		*out = new(CapacityFallback)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecurityProfile != nil {
 		in, out := &in.SecurityProfile, &out.SecurityProfile
 		*out = new(SecurityProfile)
@@ -1642,6 +1713,31 @@ func (in *AzureMachineStatus) DeepCopyInto(out *AzureMachineStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.EstimatedMonthlyCost != nil {
+		in, out := &in.EstimatedMonthlyCost, &out.EstimatedMonthlyCost
+		*out = new(CostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpotEviction != nil {
+		in, out := &in.SpotEviction, &out.SpotEviction
+		*out = new(SpotEvictionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityFallback != nil {
+		in, out := &in.CapacityFallback, &out.CapacityFallback
+		*out = new(CapacityFallbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(BastionConnection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineStatus.
@@ -2737,6 +2833,86 @@ func (in *AzureMarketplaceImage) DeepCopy() *AzureMarketplaceImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureSecurityRuleSet) DeepCopyInto(out *AzureSecurityRuleSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSecurityRuleSet.
+func (in *AzureSecurityRuleSet) DeepCopy() *AzureSecurityRuleSet {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureSecurityRuleSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureSecurityRuleSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureSecurityRuleSetList) DeepCopyInto(out *AzureSecurityRuleSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureSecurityRuleSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSecurityRuleSetList.
+func (in *AzureSecurityRuleSetList) DeepCopy() *AzureSecurityRuleSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureSecurityRuleSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureSecurityRuleSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureSecurityRuleSetSpec) DeepCopyInto(out *AzureSecurityRuleSetSpec) {
+	*out = *in
+	if in.SecurityRules != nil {
+		in, out := &in.SecurityRules, &out.SecurityRules
+		*out = make(SecurityRules, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSecurityRuleSetSpec.
+func (in *AzureSecurityRuleSetSpec) DeepCopy() *AzureSecurityRuleSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureSecurityRuleSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureSharedGalleryImage) DeepCopyInto(out *AzureSharedGalleryImage) {
 	*out = *in
@@ -2807,6 +2983,21 @@ func (in *BackendPool) DeepCopy() *BackendPool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BastionConnection) DeepCopyInto(out *BastionConnection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionConnection.
+func (in *BastionConnection) DeepCopy() *BastionConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(BastionConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BastionSpec) DeepCopyInto(out *BastionSpec) {
 	*out = *in
@@ -2899,6 +3090,48 @@ func (in *BuildParams) DeepCopy() *BuildParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityFallback) DeepCopyInto(out *CapacityFallback) {
+	*out = *in
+	if in.VMSizes != nil {
+		in, out := &in.VMSizes, &out.VMSizes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityFallback.
+func (in *CapacityFallback) DeepCopy() *CapacityFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityFallbackStatus) DeepCopyInto(out *CapacityFallbackStatus) {
+	*out = *in
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]AllocationAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityFallbackStatus.
+func (in *CapacityFallbackStatus) DeepCopy() *CapacityFallbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityFallbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudProviderConfigOverrides) DeepCopyInto(out *CloudProviderConfigOverrides) {
 	*out = *in
@@ -2922,6 +3155,26 @@ func (in *CloudProviderConfigOverrides) DeepCopy() *CloudProviderConfigOverrides
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostEstimate) DeepCopyInto(out *CostEstimate) {
+	*out = *in
+	if in.UnpricedResources != nil {
+		in, out := &in.UnpricedResources, &out.UnpricedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostEstimate.
+func (in *CostEstimate) DeepCopy() *CostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(CostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataDisk) DeepCopyInto(out *DataDisk) {
 	*out = *in
@@ -3002,6 +3255,55 @@ func (in *DiskEncryptionSetParameters) DeepCopy() *DiskEncryptionSetParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetection) DeepCopyInto(out *DriftDetection) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetection.
+func (in *DriftDetection) DeepCopy() *DriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftStatus) DeepCopyInto(out *DriftStatus) {
+	*out = *in
+	if in.LastScanTime != nil {
+		in, out := &in.LastScanTime, &out.LastScanTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceDrift, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnscannedServices != nil {
+		in, out := &in.UnscannedServices, &out.UnscannedServices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftStatus.
+func (in *DriftStatus) DeepCopy() *DriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedLocationSpec) DeepCopyInto(out *ExtendedLocationSpec) {
 	*out = *in
@@ -3047,6 +3349,28 @@ func (in *ExtensionScope) DeepCopy() *ExtensionScope {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallSpec) DeepCopyInto(out *FirewallSpec) {
+	*out = *in
+	if in.AdditionalFQDNs != nil {
+		in, out := &in.AdditionalFQDNs, &out.AdditionalFQDNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallSpec.
+func (in *FirewallSpec) DeepCopy() *FirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FleetsMember) DeepCopyInto(out *FleetsMember) {
 	*out = *in
@@ -3183,6 +3507,28 @@ func (in *HTTPProxyConfig) DeepCopy() *HTTPProxyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HubVnetSpec) DeepCopyInto(out *HubVnetSpec) {
+	*out = *in
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	in.ForwardPeeringProperties.DeepCopyInto(&out.ForwardPeeringProperties)
+	in.ReversePeeringProperties.DeepCopyInto(&out.ReversePeeringProperties)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubVnetSpec.
+func (in *HubVnetSpec) DeepCopy() *HubVnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HubVnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPTag) DeepCopyInto(out *IPTag) {
 	*out = *in
@@ -3263,6 +3609,23 @@ func (in *ImagePlan) DeepCopy() *ImagePlan {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
 	*out = *in
@@ -3403,6 +3766,31 @@ func (in *LoadBalancerPort) DeepCopy() *LoadBalancerPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerProbe) DeepCopyInto(out *LoadBalancerProbe) {
+	*out = *in
+	if in.IntervalInSeconds != nil {
+		in, out := &in.IntervalInSeconds, &out.IntervalInSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerProbe.
+func (in *LoadBalancerProbe) DeepCopy() *LoadBalancerProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerProfile) DeepCopyInto(out *LoadBalancerProfile) {
 	*out = *in
@@ -3443,6 +3831,26 @@ func (in *LoadBalancerProfile) DeepCopy() *LoadBalancerProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerRule) DeepCopyInto(out *LoadBalancerRule) {
+	*out = *in
+	if in.BackendPort != nil {
+		in, out := &in.BackendPort, &out.BackendPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerRule.
+func (in *LoadBalancerRule) DeepCopy() *LoadBalancerRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 	*out = *in
@@ -3458,6 +3866,11 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.IPv6FrontendIPsCount != nil {
+		in, out := &in.IPv6FrontendIPsCount, &out.IPv6FrontendIPsCount
+		*out = new(int32)
+		**out = **in
+	}
 	out.BackendPool = in.BackendPool
 	in.LoadBalancerClassSpec.DeepCopyInto(&out.LoadBalancerClassSpec)
 	if in.AvailabilityZones != nil {
@@ -3465,6 +3878,30 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalBackendPools != nil {
+		in, out := &in.AdditionalBackendPools, &out.AdditionalBackendPools
+		*out = make([]BackendPool, len(*in))
+		copy(*out, *in)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]LoadBalancerProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]LoadBalancerRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrivateLinkService != nil {
+		in, out := &in.PrivateLinkService, &out.PrivateLinkService
+		*out = new(PrivateLinkServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
@@ -3749,6 +4186,11 @@ func (in *NatGatewayClassSpec) DeepCopy() *NatGatewayClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkClassSpec) DeepCopyInto(out *NetworkClassSpec) {
 	*out = *in
+	if in.PrivateDNSZoneIdentityRef != nil {
+		in, out := &in.PrivateDNSZoneIdentityRef, &out.PrivateDNSZoneIdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClassSpec.
@@ -3769,6 +4211,11 @@ func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IPAMPoolRef != nil {
+		in, out := &in.IPAMPoolRef, &out.IPAMPoolRef
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
@@ -3817,7 +4264,17 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(PrivateDNSZoneMode)
 		**out = **in
 	}
-	out.NetworkClassSpec = in.NetworkClassSpec
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(FirewallSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -3833,7 +4290,7 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkTemplateSpec) DeepCopyInto(out *NetworkTemplateSpec) {
 	*out = *in
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 	in.Vnet.DeepCopyInto(&out.Vnet)
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -4028,6 +4485,31 @@ func (in *PrivateLinkServiceConnection) DeepCopy() *PrivateLinkServiceConnection
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkServiceSpec) DeepCopyInto(out *PrivateLinkServiceSpec) {
+	*out = *in
+	if in.AllowedSubscriptions != nil {
+		in, out := &in.AllowedSubscriptions, &out.AllowedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApprovedSubscriptions != nil {
+		in, out := &in.AutoApprovedSubscriptions, &out.AutoApprovedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkServiceSpec.
+func (in *PrivateLinkServiceSpec) DeepCopy() *PrivateLinkServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 	*out = *in
@@ -4089,6 +4571,36 @@ func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceChange) DeepCopyInto(out *ResourceChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceChange.
+func (in *ResourceChange) DeepCopy() *ResourceChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDrift) DeepCopyInto(out *ResourceDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDrift.
+func (in *ResourceDrift) DeepCopy() *ResourceDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceStatus) DeepCopyInto(out *ResourceStatus) {
 	*out = *in
@@ -4146,6 +4658,11 @@ func (in *SecurityGroupClass) DeepCopyInto(out *SecurityGroupClass) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SecurityRuleSets != nil {
+		in, out := &in.SecurityRuleSets, &out.SecurityRuleSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(Tags, len(*in))
@@ -4219,11 +4736,21 @@ func (in *SecurityRule) DeepCopyInto(out *SecurityRule) {
 			}
 		}
 	}
+	if in.SourceApplicationSecurityGroups != nil {
+		in, out := &in.SourceApplicationSecurityGroups, &out.SourceApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Destination != nil {
 		in, out := &in.Destination, &out.Destination
 		*out = new(string)
 		**out = **in
 	}
+	if in.DestinationApplicationSecurityGroups != nil {
+		in, out := &in.DestinationApplicationSecurityGroups, &out.DestinationApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRule.
@@ -4298,6 +4825,50 @@ func (in ServiceEndpoints) DeepCopy() ServiceEndpoints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotEvictionRecovery) DeepCopyInto(out *SpotEvictionRecovery) {
+	*out = *in
+	if in.FallbackVMSizes != nil {
+		in, out := &in.FallbackVMSizes, &out.FallbackVMSizes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegularPriorityAfterEvictions != nil {
+		in, out := &in.RegularPriorityAfterEvictions, &out.RegularPriorityAfterEvictions
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotEvictionRecovery.
+func (in *SpotEvictionRecovery) DeepCopy() *SpotEvictionRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotEvictionRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotEvictionStatus) DeepCopyInto(out *SpotEvictionStatus) {
+	*out = *in
+	if in.LastEvictionTime != nil {
+		in, out := &in.LastEvictionTime, &out.LastEvictionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotEvictionStatus.
+func (in *SpotEvictionStatus) DeepCopy() *SpotEvictionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotEvictionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpotVMOptions) DeepCopyInto(out *SpotVMOptions) {
 	*out = *in
@@ -4378,6 +4949,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
 	out.RouteTable = in.RouteTable
 	in.NatGateway.DeepCopyInto(&out.NatGateway)
+	if in.IPAMPoolRef != nil {
+		in, out := &in.IPAMPoolRef, &out.IPAMPoolRef
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SubnetClassSpec.DeepCopyInto(&out.SubnetClassSpec)
 }
 
@@ -4810,6 +5386,11 @@ func (in *VnetClassSpec) DeepCopy() *VnetClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetPeeringClassSpec) DeepCopyInto(out *VnetPeeringClassSpec) {
 	*out = *in
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 	in.ForwardPeeringProperties.DeepCopyInto(&out.ForwardPeeringProperties)
 	in.ReversePeeringProperties.DeepCopyInto(&out.ReversePeeringProperties)
 }
@@ -4927,6 +5508,21 @@ func (in *VnetSpec) DeepCopyInto(out *VnetSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Hub != nil {
+		in, out := &in.Hub, &out.Hub
+		*out = new(HubVnetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.IPAMPoolRef != nil {
+		in, out := &in.IPAMPoolRef, &out.IPAMPoolRef
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
 	in.VnetClassSpec.DeepCopyInto(&out.VnetClassSpec)
 }
 
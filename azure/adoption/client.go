@@ -0,0 +1,226 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adoption
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client discovers the Azure resources of a resource group and tags them.
+type Client interface {
+	ListVirtualNetworks(ctx context.Context, resourceGroup string) ([]armnetwork.VirtualNetwork, error)
+	ListSecurityGroups(ctx context.Context, resourceGroup string) ([]armnetwork.SecurityGroup, error)
+	ListLoadBalancers(ctx context.Context, resourceGroup string) ([]armnetwork.LoadBalancer, error)
+	ListVirtualMachines(ctx context.Context, resourceGroup string) ([]armcompute.VirtualMachine, error)
+	GetNetworkInterface(ctx context.Context, id string) (armnetwork.Interface, error)
+	GetPublicIPAddress(ctx context.Context, id string) (armnetwork.PublicIPAddress, error)
+	GetNatGateway(ctx context.Context, id string) (armnetwork.NatGateway, error)
+	MergeTags(ctx context.Context, id string, tags infrav1.Tags) error
+}
+
+// AzureClient contains the Azure go-sdk clients.
+type AzureClient struct {
+	virtualNetworks *armnetwork.VirtualNetworksClient
+	securityGroups  *armnetwork.SecurityGroupsClient
+	loadBalancers   *armnetwork.LoadBalancersClient
+	interfaces      *armnetwork.InterfacesClient
+	publicIPs       *armnetwork.PublicIPAddressesClient
+	natGateways     *armnetwork.NatGatewaysClient
+	virtualMachines *armcompute.VirtualMachinesClient
+	tags            *armresources.TagsClient
+}
+
+var _ Client = (*AzureClient)(nil)
+
+// NewClient creates an adoption client from an authorizer.
+func NewClient(auth azure.Authorizer) (*AzureClient, error) {
+	opts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create adoption client options")
+	}
+	networkFactory, err := armnetwork.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armnetwork client factory")
+	}
+	computeFactory, err := armcompute.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armcompute client factory")
+	}
+	resourcesFactory, err := armresources.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armresources client factory")
+	}
+	return &AzureClient{
+		virtualNetworks: networkFactory.NewVirtualNetworksClient(),
+		securityGroups:  networkFactory.NewSecurityGroupsClient(),
+		loadBalancers:   networkFactory.NewLoadBalancersClient(),
+		interfaces:      networkFactory.NewInterfacesClient(),
+		publicIPs:       networkFactory.NewPublicIPAddressesClient(),
+		natGateways:     networkFactory.NewNatGatewaysClient(),
+		virtualMachines: computeFactory.NewVirtualMachinesClient(),
+		tags:            resourcesFactory.NewTagsClient(),
+	}, nil
+}
+
+// ListVirtualNetworks returns the virtual networks in a resource group.
+func (ac *AzureClient) ListVirtualNetworks(ctx context.Context, resourceGroup string) ([]armnetwork.VirtualNetwork, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.ListVirtualNetworks")
+	defer done()
+
+	var vnets []armnetwork.VirtualNetwork
+	pager := ac.virtualNetworks.NewListPager(resourceGroup, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not iterate virtual networks")
+		}
+		for _, vnet := range page.Value {
+			vnets = append(vnets, *vnet)
+		}
+	}
+	return vnets, nil
+}
+
+// ListSecurityGroups returns the network security groups in a resource group.
+func (ac *AzureClient) ListSecurityGroups(ctx context.Context, resourceGroup string) ([]armnetwork.SecurityGroup, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.ListSecurityGroups")
+	defer done()
+
+	var nsgs []armnetwork.SecurityGroup
+	pager := ac.securityGroups.NewListPager(resourceGroup, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not iterate network security groups")
+		}
+		for _, nsg := range page.Value {
+			nsgs = append(nsgs, *nsg)
+		}
+	}
+	return nsgs, nil
+}
+
+// ListLoadBalancers returns the load balancers in a resource group.
+func (ac *AzureClient) ListLoadBalancers(ctx context.Context, resourceGroup string) ([]armnetwork.LoadBalancer, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.ListLoadBalancers")
+	defer done()
+
+	var lbs []armnetwork.LoadBalancer
+	pager := ac.loadBalancers.NewListPager(resourceGroup, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not iterate load balancers")
+		}
+		for _, lb := range page.Value {
+			lbs = append(lbs, *lb)
+		}
+	}
+	return lbs, nil
+}
+
+// ListVirtualMachines returns the virtual machines in a resource group.
+func (ac *AzureClient) ListVirtualMachines(ctx context.Context, resourceGroup string) ([]armcompute.VirtualMachine, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.ListVirtualMachines")
+	defer done()
+
+	var vms []armcompute.VirtualMachine
+	pager := ac.virtualMachines.NewListPager(resourceGroup, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not iterate virtual machines")
+		}
+		for _, vm := range page.Value {
+			vms = append(vms, *vm)
+		}
+	}
+	return vms, nil
+}
+
+// GetNetworkInterface returns the network interface with the given resource ID.
+func (ac *AzureClient) GetNetworkInterface(ctx context.Context, id string) (armnetwork.Interface, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.GetNetworkInterface")
+	defer done()
+
+	resourceID, err := arm.ParseResourceID(id)
+	if err != nil {
+		return armnetwork.Interface{}, errors.Wrapf(err, "failed to parse network interface ID %s", id)
+	}
+	resp, err := ac.interfaces.Get(ctx, resourceID.ResourceGroupName, resourceID.Name, nil)
+	if err != nil {
+		return armnetwork.Interface{}, err
+	}
+	return resp.Interface, nil
+}
+
+// GetPublicIPAddress returns the public IP address with the given resource ID.
+func (ac *AzureClient) GetPublicIPAddress(ctx context.Context, id string) (armnetwork.PublicIPAddress, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.GetPublicIPAddress")
+	defer done()
+
+	resourceID, err := arm.ParseResourceID(id)
+	if err != nil {
+		return armnetwork.PublicIPAddress{}, errors.Wrapf(err, "failed to parse public IP address ID %s", id)
+	}
+	resp, err := ac.publicIPs.Get(ctx, resourceID.ResourceGroupName, resourceID.Name, nil)
+	if err != nil {
+		return armnetwork.PublicIPAddress{}, err
+	}
+	return resp.PublicIPAddress, nil
+}
+
+// GetNatGateway returns the NAT gateway with the given resource ID.
+func (ac *AzureClient) GetNatGateway(ctx context.Context, id string) (armnetwork.NatGateway, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.GetNatGateway")
+	defer done()
+
+	resourceID, err := arm.ParseResourceID(id)
+	if err != nil {
+		return armnetwork.NatGateway{}, errors.Wrapf(err, "failed to parse NAT gateway ID %s", id)
+	}
+	resp, err := ac.natGateways.Get(ctx, resourceID.ResourceGroupName, resourceID.Name, nil)
+	if err != nil {
+		return armnetwork.NatGateway{}, err
+	}
+	return resp.NatGateway, nil
+}
+
+// MergeTags adds tags to the resource with the given resource ID, keeping its other tags.
+func (ac *AzureClient) MergeTags(ctx context.Context, id string, tags infrav1.Tags) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "adoption.AzureClient.MergeTags")
+	defer done()
+
+	patch := armresources.TagsPatchResource{
+		Operation:  ptr.To(armresources.TagsPatchOperationMerge),
+		Properties: &armresources.Tags{Tags: converters.TagsToMap(tags)},
+	}
+	_, err := ac.tags.UpdateAtScope(ctx, id, patch, nil)
+	return err
+}
@@ -0,0 +1,253 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adoption
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"k8s.io/utils/ptr"
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// ApplyTo sets the virtual network, subnets, API server load balancer and control plane endpoint of spec
+// to describe the resources in the inventory. The other fields of spec are left unchanged.
+func (i *Inventory) ApplyTo(spec *infrav1.AzureClusterSpec) {
+	vnet := &spec.NetworkSpec.Vnet
+	vnet.Name = ptr.Deref(i.VirtualNetwork.Name, "")
+	vnet.ID = ptr.Deref(i.VirtualNetwork.ID, "")
+	vnet.ResourceGroup = i.ResourceGroup
+	if i.VirtualNetwork.Properties != nil && i.VirtualNetwork.Properties.AddressSpace != nil {
+		vnet.CIDRBlocks = derefStrings(i.VirtualNetwork.Properties.AddressSpace.AddressPrefixes)
+	}
+
+	spec.NetworkSpec.Subnets = i.subnets()
+	spec.NetworkSpec.APIServerLB = i.apiServerLB()
+	spec.ControlPlaneEndpoint = i.controlPlaneEndpoint()
+}
+
+// subnets returns the subnets of the virtual network. A subnet's role is control-plane when it hosts
+// control plane machines or the frontend of an internal API server load balancer, node when it hosts
+// other machines, cluster when it hosts both, and node when it hosts neither.
+func (i *Inventory) subnets() infrav1.Subnets {
+	controlPlane, node := map[string]bool{}, map[string]bool{}
+	for _, machine := range i.Machines {
+		for _, nic := range machine.Spec.NetworkInterfaces {
+			if machine.ControlPlane {
+				controlPlane[strings.ToLower(nic.SubnetName)] = true
+			} else {
+				node[strings.ToLower(nic.SubnetName)] = true
+			}
+		}
+	}
+	if frontend := apiServerFrontend(i.APIServerLB, i.APIServerLBRule); frontend != nil && frontend.Properties.Subnet != nil {
+		controlPlane[strings.ToLower(resourceName(ptr.Deref(frontend.Properties.Subnet.ID, "")))] = true
+	}
+
+	if i.VirtualNetwork.Properties == nil {
+		return nil
+	}
+	var subnets infrav1.Subnets
+	for _, subnet := range i.VirtualNetwork.Properties.Subnets {
+		name := ptr.Deref(subnet.Name, "")
+		role := infrav1.SubnetNode
+		switch key := strings.ToLower(name); {
+		case controlPlane[key] && node[key]:
+			role = infrav1.SubnetCluster
+		case controlPlane[key]:
+			role = infrav1.SubnetControlPlane
+		}
+		spec := infrav1.SubnetSpec{
+			ID: ptr.Deref(subnet.ID, ""),
+			SubnetClassSpec: infrav1.SubnetClassSpec{
+				Name: name,
+				Role: role,
+			},
+		}
+		if subnet.Properties != nil {
+			if subnet.Properties.AddressPrefix != nil {
+				spec.CIDRBlocks = []string{*subnet.Properties.AddressPrefix}
+			} else {
+				spec.CIDRBlocks = derefStrings(subnet.Properties.AddressPrefixes)
+			}
+			if subnet.Properties.NetworkSecurityGroup != nil {
+				spec.SecurityGroup = i.securityGroup(ptr.Deref(subnet.Properties.NetworkSecurityGroup.ID, ""))
+			}
+			if subnet.Properties.RouteTable != nil {
+				spec.RouteTable = infrav1.RouteTable{
+					ID:   ptr.Deref(subnet.Properties.RouteTable.ID, ""),
+					Name: resourceName(ptr.Deref(subnet.Properties.RouteTable.ID, "")),
+				}
+			}
+			if subnet.Properties.NatGateway != nil {
+				spec.NatGateway = i.natGateway(ptr.Deref(subnet.Properties.NatGateway.ID, ""))
+			}
+			for _, endpoint := range subnet.Properties.ServiceEndpoints {
+				spec.ServiceEndpoints = append(spec.ServiceEndpoints, infrav1.ServiceEndpointSpec{
+					Service:   ptr.Deref(endpoint.Service, ""),
+					Locations: derefStrings(endpoint.Locations),
+				})
+			}
+		}
+		subnets = append(subnets, spec)
+	}
+	return subnets
+}
+
+// natGateway returns the NAT gateway with the given resource ID and the name of its first public IP address.
+func (i *Inventory) natGateway(id string) infrav1.NatGateway {
+	natGateway := infrav1.NatGateway{
+		ID:                  id,
+		NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: resourceName(id)},
+	}
+	existing, ok := i.NatGateways[strings.ToLower(id)]
+	if !ok || existing.Properties == nil {
+		return natGateway
+	}
+	if len(existing.Properties.PublicIPAddresses) > 0 {
+		natGateway.NatGatewayIP.Name = resourceName(ptr.Deref(existing.Properties.PublicIPAddresses[0].ID, ""))
+	}
+	natGateway.Zones = derefStrings(existing.Zones)
+	return natGateway
+}
+
+// securityGroup returns the network security group with the given resource ID and the rules CAPZ can represent.
+// Rules which cannot be represented are left out and are kept as they are by CAPZ.
+func (i *Inventory) securityGroup(id string) infrav1.SecurityGroup {
+	group := infrav1.SecurityGroup{ID: id, Name: resourceName(id)}
+	nsg, ok := i.SecurityGroups[strings.ToLower(id)]
+	if !ok || nsg.Properties == nil {
+		return group
+	}
+	for _, rule := range nsg.Properties.SecurityRules {
+		if securityRule, ok := securityRuleFromSDK(rule); ok {
+			group.SecurityRules = append(group.SecurityRules, securityRule)
+		}
+	}
+	return group
+}
+
+// securityRuleFromSDK converts a security rule to its CAPZ representation. It returns false when the rule uses
+// a protocol or several port ranges, which CAPZ does not support.
+func securityRuleFromSDK(rule *armnetwork.SecurityRule) (infrav1.SecurityRule, bool) {
+	if rule == nil || rule.Properties == nil {
+		return infrav1.SecurityRule{}, false
+	}
+	props := rule.Properties
+	protocol := infrav1.SecurityGroupProtocol(ptr.Deref(props.Protocol, ""))
+	switch protocol {
+	case infrav1.SecurityGroupProtocolAll, infrav1.SecurityGroupProtocolTCP, infrav1.SecurityGroupProtocolUDP, infrav1.SecurityGroupProtocolICMP:
+	default:
+		return infrav1.SecurityRule{}, false
+	}
+	if len(props.SourcePortRanges) > 0 || len(props.DestinationPortRanges) > 0 || len(props.DestinationAddressPrefixes) > 0 {
+		return infrav1.SecurityRule{}, false
+	}
+
+	securityRule := infrav1.SecurityRule{
+		Name:             ptr.Deref(rule.Name, ""),
+		Description:      ptr.Deref(props.Description, ""),
+		Protocol:         protocol,
+		Direction:        infrav1.SecurityRuleDirection(ptr.Deref(props.Direction, "")),
+		Priority:         ptr.Deref(props.Priority, 0),
+		SourcePorts:      props.SourcePortRange,
+		DestinationPorts: props.DestinationPortRange,
+		Destination:      props.DestinationAddressPrefix,
+		Action:           infrav1.SecurityRuleAccess(ptr.Deref(props.Access, "")),
+	}
+	if len(props.SourceAddressPrefixes) > 0 {
+		securityRule.Sources = props.SourceAddressPrefixes
+	} else {
+		securityRule.Source = props.SourceAddressPrefix
+	}
+	return securityRule, true
+}
+
+// apiServerLB returns the spec of the API server load balancer.
+func (i *Inventory) apiServerLB() *infrav1.LoadBalancerSpec {
+	lb := &infrav1.LoadBalancerSpec{
+		ID:   ptr.Deref(i.APIServerLB.ID, ""),
+		Name: ptr.Deref(i.APIServerLB.Name, ""),
+		BackendPool: infrav1.BackendPool{
+			Name: resourceName(i.apiServerBackendPoolID()),
+		},
+		LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+			Type: infrav1.Internal,
+		},
+	}
+	if i.APIServerLB.SKU != nil {
+		lb.SKU = infrav1.SKU(ptr.Deref(i.APIServerLB.SKU.Name, ""))
+	}
+	if i.APIServerLBRule.Properties != nil {
+		lb.IdleTimeoutInMinutes = i.APIServerLBRule.Properties.IdleTimeoutInMinutes
+	}
+
+	frontend := apiServerFrontend(i.APIServerLB, i.APIServerLBRule)
+	if frontend == nil {
+		return lb
+	}
+	for _, zone := range frontend.Zones {
+		lb.AvailabilityZones = append(lb.AvailabilityZones, ptr.Deref(zone, ""))
+	}
+	frontendIP := infrav1.FrontendIP{Name: ptr.Deref(frontend.Name, "")}
+	if i.APIServerPublicIP != nil {
+		lb.Type = infrav1.Public
+		frontendIP.PublicIP = &infrav1.PublicIPSpec{Name: ptr.Deref(i.APIServerPublicIP.Name, "")}
+		if props := i.APIServerPublicIP.Properties; props != nil && props.DNSSettings != nil {
+			frontendIP.PublicIP.DNSName = ptr.Deref(props.DNSSettings.Fqdn, "")
+		}
+	} else {
+		frontendIP.PrivateIPAddress = ptr.Deref(frontend.Properties.PrivateIPAddress, "")
+	}
+	lb.FrontendIPs = []infrav1.FrontendIP{frontendIP}
+	return lb
+}
+
+// controlPlaneEndpoint returns the endpoint of the API server: the DNS name or address of the public IP of a public
+// load balancer, or the private IP address of an internal load balancer.
+func (i *Inventory) controlPlaneEndpoint() clusterv1beta1.APIEndpoint {
+	endpoint := clusterv1beta1.APIEndpoint{}
+	if i.APIServerLBRule.Properties != nil {
+		endpoint.Port = ptr.Deref(i.APIServerLBRule.Properties.FrontendPort, 0)
+	}
+	if i.APIServerPublicIP != nil {
+		if props := i.APIServerPublicIP.Properties; props != nil {
+			endpoint.Host = ptr.Deref(props.IPAddress, "")
+			if props.DNSSettings != nil && ptr.Deref(props.DNSSettings.Fqdn, "") != "" {
+				endpoint.Host = *props.DNSSettings.Fqdn
+			}
+		}
+		return endpoint
+	}
+	if frontend := apiServerFrontend(i.APIServerLB, i.APIServerLBRule); frontend != nil {
+		endpoint.Host = ptr.Deref(frontend.Properties.PrivateIPAddress, "")
+	}
+	return endpoint
+}
+
+// derefStrings returns the values of the non-nil pointers in s.
+func derefStrings(s []*string) []string {
+	var values []string
+	for _, v := range s {
+		if v != nil {
+			values = append(values, *v)
+		}
+	}
+	return values
+}
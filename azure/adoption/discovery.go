@@ -0,0 +1,264 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adoption discovers the Azure resources of a self-managed cluster which was not created by CAPZ,
+// so that they can be described by an AzureCluster and AzureMachines and managed by CAPZ from then on.
+package adoption
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Inventory is the set of Azure resources of a self-managed cluster found in a resource group.
+type Inventory struct {
+	// ResourceGroup is the resource group the resources were found in.
+	ResourceGroup string
+	// VirtualNetwork is the virtual network of the cluster.
+	VirtualNetwork armnetwork.VirtualNetwork
+	// SecurityGroups are the network security groups of the subnets, keyed by their lowercase resource ID.
+	SecurityGroups map[string]armnetwork.SecurityGroup
+	// NatGateways are the NAT gateways of the subnets, keyed by their lowercase resource ID.
+	NatGateways map[string]armnetwork.NatGateway
+	// APIServerLB is the load balancer with a rule for the API server port.
+	APIServerLB armnetwork.LoadBalancer
+	// APIServerLBRule is the load balancing rule for the API server port.
+	APIServerLBRule armnetwork.LoadBalancingRule
+	// APIServerPublicIP is the public IP address of the frontend of a public API server load balancer.
+	APIServerPublicIP *armnetwork.PublicIPAddress
+	// Machines are the virtual machines which can be adopted.
+	Machines []Machine
+	// Skipped maps the names of the virtual machines which cannot be adopted to the reason why.
+	Skipped map[string]string
+}
+
+// Discover finds the resources of a self-managed cluster in a resource group. The virtual network is the one
+// named vnetName or, when there is none, the only virtual network in the resource group. The API server load
+// balancer is the only load balancer with a rule for apiServerPort.
+func Discover(ctx context.Context, c Client, resourceGroup, vnetName string, apiServerPort int32) (*Inventory, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "adoption.Discover")
+	defer done()
+
+	inventory := &Inventory{
+		ResourceGroup:  resourceGroup,
+		SecurityGroups: map[string]armnetwork.SecurityGroup{},
+		NatGateways:    map[string]armnetwork.NatGateway{},
+		Skipped:        map[string]string{},
+	}
+
+	vnets, err := c.ListVirtualNetworks(ctx, resourceGroup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list virtual networks in resource group %s", resourceGroup)
+	}
+	vnet, err := selectVirtualNetwork(vnets, vnetName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select the virtual network in resource group %s", resourceGroup)
+	}
+	inventory.VirtualNetwork = vnet
+
+	if vnet.Properties != nil {
+		for _, subnet := range vnet.Properties.Subnets {
+			if subnet.Properties == nil || subnet.Properties.NatGateway == nil {
+				continue
+			}
+			id := ptr.Deref(subnet.Properties.NatGateway.ID, "")
+			natGateway, err := c.GetNatGateway(ctx, id)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get NAT gateway %s of subnet %s", id, ptr.Deref(subnet.Name, ""))
+			}
+			inventory.NatGateways[strings.ToLower(id)] = natGateway
+		}
+	}
+
+	nsgs, err := c.ListSecurityGroups(ctx, resourceGroup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list network security groups in resource group %s", resourceGroup)
+	}
+	for _, nsg := range nsgs {
+		inventory.SecurityGroups[strings.ToLower(ptr.Deref(nsg.ID, ""))] = nsg
+	}
+
+	lbs, err := c.ListLoadBalancers(ctx, resourceGroup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list load balancers in resource group %s", resourceGroup)
+	}
+	lb, rule, err := selectAPIServerLB(lbs, apiServerPort)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select the API server load balancer in resource group %s", resourceGroup)
+	}
+	inventory.APIServerLB, inventory.APIServerLBRule = lb, rule
+	if frontend := apiServerFrontend(lb, rule); frontend != nil && frontend.Properties.PublicIPAddress != nil {
+		pip, err := c.GetPublicIPAddress(ctx, ptr.Deref(frontend.Properties.PublicIPAddress.ID, ""))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the public IP address of load balancer %s", ptr.Deref(lb.Name, ""))
+		}
+		inventory.APIServerPublicIP = &pip
+	}
+
+	vms, err := c.ListVirtualMachines(ctx, resourceGroup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list virtual machines in resource group %s", resourceGroup)
+	}
+	for _, vm := range vms {
+		var nics []armnetwork.Interface
+		if vm.Properties != nil && vm.Properties.NetworkProfile != nil {
+			for _, ref := range vm.Properties.NetworkProfile.NetworkInterfaces {
+				nic, err := c.GetNetworkInterface(ctx, ptr.Deref(ref.ID, ""))
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to get network interface %s of virtual machine %s", ptr.Deref(ref.ID, ""), ptr.Deref(vm.Name, ""))
+				}
+				nics = append(nics, nic)
+			}
+		}
+		machine, reason := newMachine(vm, nics, inventory)
+		if reason != "" {
+			log.V(2).Info("skipping virtual machine", "name", ptr.Deref(vm.Name, ""), "reason", reason)
+			inventory.Skipped[ptr.Deref(vm.Name, "")] = reason
+			continue
+		}
+		inventory.Machines = append(inventory.Machines, machine)
+	}
+
+	return inventory, nil
+}
+
+// selectVirtualNetwork returns the virtual network named vnetName or, when there is none, the only virtual network.
+func selectVirtualNetwork(vnets []armnetwork.VirtualNetwork, vnetName string) (armnetwork.VirtualNetwork, error) {
+	for _, vnet := range vnets {
+		if strings.EqualFold(ptr.Deref(vnet.Name, ""), vnetName) {
+			return vnet, nil
+		}
+	}
+	if len(vnets) != 1 {
+		return armnetwork.VirtualNetwork{}, errors.Errorf("found no virtual network named %s and %d other virtual networks", vnetName, len(vnets))
+	}
+	return vnets[0], nil
+}
+
+// selectAPIServerLB returns the only load balancer with a load balancing rule for port, and that rule.
+func selectAPIServerLB(lbs []armnetwork.LoadBalancer, port int32) (armnetwork.LoadBalancer, armnetwork.LoadBalancingRule, error) {
+	var (
+		found []armnetwork.LoadBalancer
+		rule  armnetwork.LoadBalancingRule
+	)
+	for _, lb := range lbs {
+		if lb.Properties == nil {
+			continue
+		}
+		for _, r := range lb.Properties.LoadBalancingRules {
+			if r.Properties != nil && ptr.Deref(r.Properties.FrontendPort, 0) == port {
+				found = append(found, lb)
+				rule = *r
+				break
+			}
+		}
+	}
+	if len(found) != 1 {
+		return armnetwork.LoadBalancer{}, armnetwork.LoadBalancingRule{}, errors.Errorf("found %d load balancers with a rule for port %d", len(found), port)
+	}
+	return found[0], rule, nil
+}
+
+// apiServerFrontend returns the frontend IP configuration of lb used by rule.
+func apiServerFrontend(lb armnetwork.LoadBalancer, rule armnetwork.LoadBalancingRule) *armnetwork.FrontendIPConfiguration {
+	if rule.Properties == nil || rule.Properties.FrontendIPConfiguration == nil {
+		return nil
+	}
+	for _, frontend := range lb.Properties.FrontendIPConfigurations {
+		if frontend.Properties != nil && strings.EqualFold(ptr.Deref(frontend.ID, ""), ptr.Deref(rule.Properties.FrontendIPConfiguration.ID, "")) {
+			return frontend
+		}
+	}
+	return nil
+}
+
+// apiServerBackendPoolID returns the resource ID of the backend pool of the API server load balancing rule.
+func (i *Inventory) apiServerBackendPoolID() string {
+	if i.APIServerLBRule.Properties == nil || i.APIServerLBRule.Properties.BackendAddressPool == nil {
+		return ""
+	}
+	return ptr.Deref(i.APIServerLBRule.Properties.BackendAddressPool.ID, "")
+}
+
+// subnet returns the subnet of the virtual network with the given resource ID.
+func (i *Inventory) subnet(id string) *armnetwork.Subnet {
+	if i.VirtualNetwork.Properties == nil {
+		return nil
+	}
+	for _, subnet := range i.VirtualNetwork.Properties.Subnets {
+		if strings.EqualFold(ptr.Deref(subnet.ID, ""), id) {
+			return subnet
+		}
+	}
+	return nil
+}
+
+// ResourceIDs returns the IDs of the resources to tag as owned by the cluster.
+func (i *Inventory) ResourceIDs() []string {
+	ids := []string{ptr.Deref(i.VirtualNetwork.ID, "")}
+	if i.VirtualNetwork.Properties != nil {
+		for _, subnet := range i.VirtualNetwork.Properties.Subnets {
+			if subnet.Properties == nil {
+				continue
+			}
+			if subnet.Properties.NetworkSecurityGroup != nil {
+				ids = append(ids, ptr.Deref(subnet.Properties.NetworkSecurityGroup.ID, ""))
+			}
+			if subnet.Properties.RouteTable != nil {
+				ids = append(ids, ptr.Deref(subnet.Properties.RouteTable.ID, ""))
+			}
+			if subnet.Properties.NatGateway != nil {
+				ids = append(ids, ptr.Deref(subnet.Properties.NatGateway.ID, ""))
+				natGateway := i.NatGateways[strings.ToLower(ptr.Deref(subnet.Properties.NatGateway.ID, ""))]
+				if natGateway.Properties != nil {
+					for _, ip := range natGateway.Properties.PublicIPAddresses {
+						ids = append(ids, ptr.Deref(ip.ID, ""))
+					}
+				}
+			}
+		}
+	}
+	ids = append(ids, ptr.Deref(i.APIServerLB.ID, ""))
+	if i.APIServerPublicIP != nil {
+		ids = append(ids, ptr.Deref(i.APIServerPublicIP.ID, ""))
+	}
+	for _, machine := range i.Machines {
+		ids = append(ids, machine.ResourceIDs...)
+	}
+
+	result := make([]string, 0, len(ids))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if id == "" || seen[strings.ToLower(id)] {
+			continue
+		}
+		seen[strings.ToLower(id)] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+// resourceName returns the name of the resource with the given resource ID.
+func resourceName(id string) string {
+	return path.Base(id)
+}
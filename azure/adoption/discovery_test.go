@@ -0,0 +1,364 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adoption
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/adoption/mock_adoption"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const (
+	fakeProviders = "/subscriptions/123/resourceGroups/my-rg/providers"
+	fakeVnetID    = fakeProviders + "/Microsoft.Network/virtualNetworks/my-vnet"
+	fakeCPNet     = fakeVnetID + "/subnets/cp-subnet"
+	fakeNodeID    = fakeVnetID + "/subnets/node-subnet"
+	fakeNSGID     = fakeProviders + "/Microsoft.Network/networkSecurityGroups/cp-nsg"
+	fakeLBID      = fakeProviders + "/Microsoft.Network/loadBalancers/my-lb"
+	fakePIPID     = fakeProviders + "/Microsoft.Network/publicIPAddresses/my-lb-ip"
+	fakeFEID      = fakeLBID + "/frontendIPConfigurations/my-lb-frontend"
+	fakePoolID    = fakeLBID + "/backendAddressPools/my-lb-backendpool"
+)
+
+var (
+	fakeVnet = armnetwork.VirtualNetwork{
+		ID:   ptr.To(fakeVnetID),
+		Name: ptr.To("my-vnet"),
+		Properties: &armnetwork.VirtualNetworkPropertiesFormat{
+			AddressSpace: &armnetwork.AddressSpace{AddressPrefixes: []*string{ptr.To("10.0.0.0/16")}},
+			Subnets: []*armnetwork.Subnet{
+				{
+					ID:   ptr.To(fakeCPNet),
+					Name: ptr.To("cp-subnet"),
+					Properties: &armnetwork.SubnetPropertiesFormat{
+						AddressPrefix:        ptr.To("10.0.0.0/24"),
+						NetworkSecurityGroup: &armnetwork.SecurityGroup{ID: ptr.To(fakeNSGID)},
+					},
+				},
+				{
+					ID:   ptr.To(fakeNodeID),
+					Name: ptr.To("node-subnet"),
+					Properties: &armnetwork.SubnetPropertiesFormat{
+						AddressPrefix: ptr.To("10.0.1.0/24"),
+					},
+				},
+			},
+		},
+	}
+	fakeNSG = armnetwork.SecurityGroup{
+		ID:   ptr.To(fakeNSGID),
+		Name: ptr.To("cp-nsg"),
+		Properties: &armnetwork.SecurityGroupPropertiesFormat{
+			SecurityRules: []*armnetwork.SecurityRule{
+				{
+					Name: ptr.To("allow_apiserver"),
+					Properties: &armnetwork.SecurityRulePropertiesFormat{
+						Protocol:                 ptr.To(armnetwork.SecurityRuleProtocolTCP),
+						Direction:                ptr.To(armnetwork.SecurityRuleDirectionInbound),
+						Access:                   ptr.To(armnetwork.SecurityRuleAccessAllow),
+						Priority:                 ptr.To[int32](2201),
+						SourcePortRange:          ptr.To("*"),
+						DestinationPortRange:     ptr.To("6443"),
+						SourceAddressPrefix:      ptr.To("*"),
+						DestinationAddressPrefix: ptr.To("*"),
+					},
+				},
+				{
+					Name: ptr.To("allow_ranges"),
+					Properties: &armnetwork.SecurityRulePropertiesFormat{
+						Protocol:              ptr.To(armnetwork.SecurityRuleProtocolTCP),
+						DestinationPortRanges: []*string{ptr.To("80"), ptr.To("443")},
+					},
+				},
+			},
+		},
+	}
+	fakeLB = armnetwork.LoadBalancer{
+		ID:   ptr.To(fakeLBID),
+		Name: ptr.To("my-lb"),
+		SKU:  &armnetwork.LoadBalancerSKU{Name: ptr.To(armnetwork.LoadBalancerSKUNameStandard)},
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{
+				{
+					ID:   ptr.To(fakeFEID),
+					Name: ptr.To("my-lb-frontend"),
+					Properties: &armnetwork.FrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &armnetwork.PublicIPAddress{ID: ptr.To(fakePIPID)},
+					},
+				},
+			},
+			LoadBalancingRules: []*armnetwork.LoadBalancingRule{
+				{
+					Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+						FrontendPort:            ptr.To[int32](6443),
+						IdleTimeoutInMinutes:    ptr.To[int32](4),
+						FrontendIPConfiguration: &armnetwork.SubResource{ID: ptr.To(fakeFEID)},
+						BackendAddressPool:      &armnetwork.SubResource{ID: ptr.To(fakePoolID)},
+					},
+				},
+			},
+		},
+	}
+	fakeOtherLB = armnetwork.LoadBalancer{
+		ID:   ptr.To(fakeProviders + "/Microsoft.Network/loadBalancers/other-lb"),
+		Name: ptr.To("other-lb"),
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			LoadBalancingRules: []*armnetwork.LoadBalancingRule{
+				{Properties: &armnetwork.LoadBalancingRulePropertiesFormat{FrontendPort: ptr.To[int32](80)}},
+			},
+		},
+	}
+	fakePIP = armnetwork.PublicIPAddress{
+		ID:   ptr.To(fakePIPID),
+		Name: ptr.To("my-lb-ip"),
+		Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+			IPAddress:   ptr.To("20.1.2.3"),
+			DNSSettings: &armnetwork.PublicIPAddressDNSSettings{Fqdn: ptr.To("my-cluster.eastus.cloudapp.azure.com")},
+		},
+	}
+)
+
+// fakeVM returns a virtual machine with an OS disk and a network interface named the way CAPZ names them.
+func fakeVM(name string) armcompute.VirtualMachine {
+	return armcompute.VirtualMachine{
+		ID:    ptr.To(fakeProviders + "/Microsoft.Compute/virtualMachines/" + name),
+		Name:  ptr.To(name),
+		Zones: []*string{ptr.To("1")},
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{VMSize: ptr.To(armcompute.VirtualMachineSizeTypesStandardD2SV3)},
+			StorageProfile: &armcompute.StorageProfile{
+				ImageReference: &armcompute.ImageReference{
+					Publisher:    ptr.To("cncf-upstream"),
+					Offer:        ptr.To("capi"),
+					SKU:          ptr.To("ubuntu-2204-gen1"),
+					ExactVersion: ptr.To("130.3.20240717"),
+				},
+				OSDisk: &armcompute.OSDisk{
+					Name:        ptr.To(name + "_OSDisk"),
+					OSType:      ptr.To(armcompute.OperatingSystemTypesLinux),
+					DiskSizeGB:  ptr.To[int32](128),
+					Caching:     ptr.To(armcompute.CachingTypesReadWrite),
+					ManagedDisk: &armcompute.ManagedDiskParameters{ID: ptr.To(fakeProviders + "/Microsoft.Compute/disks/" + name + "_OSDisk"), StorageAccountType: ptr.To(armcompute.StorageAccountTypesPremiumLRS)},
+				},
+			},
+			NetworkProfile: &armcompute.NetworkProfile{
+				NetworkInterfaces: []*armcompute.NetworkInterfaceReference{
+					{ID: ptr.To(fakeNICID(name + "-nic"))},
+				},
+			},
+		},
+	}
+}
+
+func fakeNICID(name string) string {
+	return fakeProviders + "/Microsoft.Network/networkInterfaces/" + name
+}
+
+// fakeNIC returns a network interface in subnetID, in the API server backend pool when controlPlane is true.
+func fakeNIC(name, subnetID string, controlPlane bool) armnetwork.Interface {
+	ipConfig := &armnetwork.InterfaceIPConfiguration{
+		Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+			Subnet: &armnetwork.Subnet{ID: ptr.To(subnetID)},
+		},
+	}
+	if controlPlane {
+		ipConfig.Properties.LoadBalancerBackendAddressPools = []*armnetwork.BackendAddressPool{{ID: ptr.To(fakePoolID)}}
+	}
+	return armnetwork.Interface{
+		ID:   ptr.To(fakeNICID(name)),
+		Name: ptr.To(name),
+		Properties: &armnetwork.InterfacePropertiesFormat{
+			IPConfigurations: []*armnetwork.InterfaceIPConfiguration{ipConfig},
+		},
+	}
+}
+
+// expectDiscovery sets up the calls made to discover a cluster with a control plane machine, a node machine and
+// a machine whose network interface is not named the way CAPZ names them.
+func expectDiscovery(m *mock_adoption.MockClientMockRecorder) {
+	strayVM := fakeVM("stray")
+	strayVM.Properties.NetworkProfile.NetworkInterfaces[0].ID = ptr.To(fakeNICID("stray-nic-primary"))
+
+	m.ListVirtualNetworks(gomockinternal.AContext(), "my-rg").Return([]armnetwork.VirtualNetwork{fakeVnet}, nil)
+	m.ListSecurityGroups(gomockinternal.AContext(), "my-rg").Return([]armnetwork.SecurityGroup{fakeNSG}, nil)
+	m.ListLoadBalancers(gomockinternal.AContext(), "my-rg").Return([]armnetwork.LoadBalancer{fakeOtherLB, fakeLB}, nil)
+	m.GetPublicIPAddress(gomockinternal.AContext(), fakePIPID).Return(fakePIP, nil)
+	m.ListVirtualMachines(gomockinternal.AContext(), "my-rg").Return([]armcompute.VirtualMachine{fakeVM("cp-0"), fakeVM("node-0"), strayVM}, nil)
+	m.GetNetworkInterface(gomockinternal.AContext(), fakeNICID("cp-0-nic")).Return(fakeNIC("cp-0-nic", fakeCPNet, true), nil)
+	m.GetNetworkInterface(gomockinternal.AContext(), fakeNICID("node-0-nic")).Return(fakeNIC("node-0-nic", fakeNodeID, false), nil)
+	m.GetNetworkInterface(gomockinternal.AContext(), fakeNICID("stray-nic-primary")).Return(fakeNIC("stray-nic-primary", fakeNodeID, false), nil)
+}
+
+func TestDiscover(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	c := mock_adoption.NewMockClient(mockCtrl)
+	expectDiscovery(c.EXPECT())
+
+	inventory, err := Discover(t.Context(), c, "my-rg", "", 6443)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(inventory.VirtualNetwork).To(Equal(fakeVnet))
+	g.Expect(inventory.APIServerLB).To(Equal(fakeLB))
+	g.Expect(inventory.APIServerPublicIP).To(Equal(&fakePIP))
+	g.Expect(inventory.Skipped).To(HaveKeyWithValue("stray", "network interface stray-nic-primary is not named stray-nic"))
+	g.Expect(inventory.Machines).To(HaveLen(2))
+
+	cp := inventory.Machines[0]
+	g.Expect(cp.Name).To(Equal("cp-0"))
+	g.Expect(cp.ControlPlane).To(BeTrue())
+	g.Expect(cp.FailureDomain).To(Equal("1"))
+	g.Expect(cp.Spec.VMSize).To(Equal("Standard_D2s_v3"))
+	g.Expect(cp.Spec.Image.Marketplace.Version).To(Equal("130.3.20240717"))
+	g.Expect(cp.Spec.OSDisk.ManagedDisk.StorageAccountType).To(Equal("Premium_LRS"))
+	g.Expect(cp.Spec.NetworkInterfaces).To(Equal([]infrav1.NetworkInterface{{SubnetName: "cp-subnet", PrivateIPConfigs: 1}}))
+	g.Expect(*cp.Spec.DisableVMBootstrapExtension).To(BeTrue())
+
+	node := inventory.Machines[1]
+	g.Expect(node.Name).To(Equal("node-0"))
+	g.Expect(node.ControlPlane).To(BeFalse())
+
+	g.Expect(inventory.ResourceIDs()).To(ConsistOf(
+		fakeVnetID,
+		fakeNSGID,
+		fakeLBID,
+		fakePIPID,
+		fakeProviders+"/Microsoft.Compute/virtualMachines/cp-0",
+		fakeProviders+"/Microsoft.Compute/disks/cp-0_OSDisk",
+		fakeNICID("cp-0-nic"),
+		fakeProviders+"/Microsoft.Compute/virtualMachines/node-0",
+		fakeProviders+"/Microsoft.Compute/disks/node-0_OSDisk",
+		fakeNICID("node-0-nic"),
+	))
+}
+
+func TestDiscoverErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(m *mock_adoption.MockClientMockRecorder)
+		err    string
+	}{
+		{
+			name: "no virtual network",
+			expect: func(m *mock_adoption.MockClientMockRecorder) {
+				m.ListVirtualNetworks(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+			},
+			err: "failed to select the virtual network in resource group my-rg: found no virtual network named my-vnet and 0 other virtual networks",
+		},
+		{
+			name: "several API server load balancers",
+			expect: func(m *mock_adoption.MockClientMockRecorder) {
+				m.ListVirtualNetworks(gomockinternal.AContext(), "my-rg").Return([]armnetwork.VirtualNetwork{fakeVnet}, nil)
+				m.ListSecurityGroups(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+				m.ListLoadBalancers(gomockinternal.AContext(), "my-rg").Return([]armnetwork.LoadBalancer{fakeLB, fakeLB}, nil)
+			},
+			err: "failed to select the API server load balancer in resource group my-rg: found 2 load balancers with a rule for port 6443",
+		},
+		{
+			name: "listing virtual machines fails",
+			expect: func(m *mock_adoption.MockClientMockRecorder) {
+				m.ListVirtualNetworks(gomockinternal.AContext(), "my-rg").Return([]armnetwork.VirtualNetwork{fakeVnet}, nil)
+				m.ListSecurityGroups(gomockinternal.AContext(), "my-rg").Return(nil, nil)
+				m.ListLoadBalancers(gomockinternal.AContext(), "my-rg").Return([]armnetwork.LoadBalancer{fakeLB}, nil)
+				m.GetPublicIPAddress(gomockinternal.AContext(), fakePIPID).Return(fakePIP, nil)
+				m.ListVirtualMachines(gomockinternal.AContext(), "my-rg").Return(nil, errors.New("boom"))
+			},
+			err: "failed to list virtual machines in resource group my-rg: boom",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			c := mock_adoption.NewMockClient(mockCtrl)
+			tc.expect(c.EXPECT())
+
+			_, err := Discover(t.Context(), c, "my-rg", "my-vnet", 6443)
+			g.Expect(err).To(MatchError(tc.err))
+		})
+	}
+}
+
+func TestApplyTo(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	c := mock_adoption.NewMockClient(mockCtrl)
+	expectDiscovery(c.EXPECT())
+	inventory, err := Discover(t.Context(), c, "my-rg", "my-vnet", 6443)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	spec := infrav1.AzureClusterSpec{
+		AzureClusterClassSpec: infrav1.AzureClusterClassSpec{Location: "eastus"},
+		ResourceGroup:         "my-rg",
+	}
+	inventory.ApplyTo(&spec)
+
+	g.Expect(spec.Location).To(Equal("eastus"))
+	g.Expect(spec.NetworkSpec.Vnet.ID).To(Equal(fakeVnetID))
+	g.Expect(spec.NetworkSpec.Vnet.Name).To(Equal("my-vnet"))
+	g.Expect(spec.NetworkSpec.Vnet.ResourceGroup).To(Equal("my-rg"))
+	g.Expect(spec.NetworkSpec.Vnet.CIDRBlocks).To(Equal([]string{"10.0.0.0/16"}))
+
+	g.Expect(spec.NetworkSpec.Subnets).To(HaveLen(2))
+	cpSubnet := spec.NetworkSpec.Subnets[0]
+	g.Expect(cpSubnet.Role).To(Equal(infrav1.SubnetControlPlane))
+	g.Expect(cpSubnet.CIDRBlocks).To(Equal([]string{"10.0.0.0/24"}))
+	g.Expect(cpSubnet.SecurityGroup.Name).To(Equal("cp-nsg"))
+	g.Expect(cpSubnet.SecurityGroup.SecurityRules).To(Equal(infrav1.SecurityRules{
+		{
+			Name:             "allow_apiserver",
+			Protocol:         infrav1.SecurityGroupProtocolTCP,
+			Direction:        infrav1.SecurityRuleDirectionInbound,
+			Priority:         2201,
+			SourcePorts:      ptr.To("*"),
+			DestinationPorts: ptr.To("6443"),
+			Source:           ptr.To("*"),
+			Destination:      ptr.To("*"),
+			Action:           infrav1.SecurityRuleActionAllow,
+		},
+	}))
+	nodeSubnet := spec.NetworkSpec.Subnets[1]
+	g.Expect(nodeSubnet.Role).To(Equal(infrav1.SubnetNode))
+	g.Expect(nodeSubnet.SecurityGroup.Name).To(BeEmpty())
+
+	lb := spec.NetworkSpec.APIServerLB
+	g.Expect(lb.Name).To(Equal("my-lb"))
+	g.Expect(lb.Type).To(Equal(infrav1.Public))
+	g.Expect(lb.SKU).To(Equal(infrav1.SKUStandard))
+	g.Expect(lb.BackendPool.Name).To(Equal("my-lb-backendpool"))
+	g.Expect(lb.IdleTimeoutInMinutes).To(Equal(ptr.To[int32](4)))
+	g.Expect(lb.FrontendIPs).To(Equal([]infrav1.FrontendIP{
+		{
+			Name: "my-lb-frontend",
+			PublicIP: &infrav1.PublicIPSpec{
+				Name:    "my-lb-ip",
+				DNSName: "my-cluster.eastus.cloudapp.azure.com",
+			},
+		},
+	}))
+
+	g.Expect(spec.ControlPlaneEndpoint.Host).To(Equal("my-cluster.eastus.cloudapp.azure.com"))
+	g.Expect(spec.ControlPlaneEndpoint.Port).To(Equal(int32(6443)))
+}
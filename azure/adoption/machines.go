@@ -0,0 +1,181 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adoption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// Machine describes a virtual machine which can be adopted as an AzureMachine.
+type Machine struct {
+	// Name is the name of the virtual machine, which is also the name of the AzureMachine.
+	Name string
+	// ControlPlane is true when the virtual machine is in the backend pool of the API server load balancer.
+	ControlPlane bool
+	// FailureDomain is the availability zone of the virtual machine, if any.
+	FailureDomain string
+	// Spec is the AzureMachine spec matching the virtual machine.
+	Spec infrav1.AzureMachineSpec
+	// ResourceIDs are the IDs of the virtual machine, its network interfaces and its disks.
+	ResourceIDs []string
+}
+
+// newMachine returns the Machine describing vm with the network interfaces nics, or the reason why vm cannot
+// be adopted. Since CAPZ finds the resources of an AzureMachine by name, the network interfaces and disks of
+// vm must be named the way CAPZ names them.
+func newMachine(vm armcompute.VirtualMachine, nics []armnetwork.Interface, i *Inventory) (Machine, string) {
+	name := ptr.Deref(vm.Name, "")
+	props := vm.Properties
+	if props == nil || props.HardwareProfile == nil || props.StorageProfile == nil || props.StorageProfile.OSDisk == nil {
+		return Machine{}, "virtual machine has no hardware or storage profile"
+	}
+
+	machine := Machine{
+		Name:        name,
+		ResourceIDs: []string{ptr.Deref(vm.ID, "")},
+		Spec: infrav1.AzureMachineSpec{
+			VMSize:                      string(ptr.Deref(props.HardwareProfile.VMSize, "")),
+			DisableVMBootstrapExtension: ptr.To(true),
+		},
+	}
+	if len(vm.Zones) > 0 {
+		machine.FailureDomain = ptr.Deref(vm.Zones[0], "")
+	}
+	if ptr.Deref(props.Priority, "") == armcompute.VirtualMachinePriorityTypesSpot {
+		machine.Spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+	}
+
+	image, reason := imageFromReference(props.StorageProfile.ImageReference)
+	if reason != "" {
+		return Machine{}, reason
+	}
+	machine.Spec.Image = image
+
+	osDisk := props.StorageProfile.OSDisk
+	if osDisk.ManagedDisk == nil {
+		return Machine{}, "OS disk is not a managed disk"
+	}
+	if want := azure.GenerateOSDiskName(name); !strings.EqualFold(ptr.Deref(osDisk.Name, ""), want) {
+		return Machine{}, fmt.Sprintf("OS disk %s is not named %s", ptr.Deref(osDisk.Name, ""), want)
+	}
+	machine.Spec.OSDisk = infrav1.OSDisk{
+		OSType:      string(ptr.Deref(osDisk.OSType, "")),
+		DiskSizeGB:  osDisk.DiskSizeGB,
+		ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: string(ptr.Deref(osDisk.ManagedDisk.StorageAccountType, ""))},
+		CachingType: string(ptr.Deref(osDisk.Caching, "")),
+	}
+	machine.ResourceIDs = append(machine.ResourceIDs, ptr.Deref(osDisk.ManagedDisk.ID, ""))
+
+	for _, disk := range props.StorageProfile.DataDisks {
+		diskName := ptr.Deref(disk.Name, "")
+		if disk.ManagedDisk == nil {
+			return Machine{}, fmt.Sprintf("data disk %s is not a managed disk", diskName)
+		}
+		prefix := azure.GenerateDataDiskName(name, "")
+		if len(diskName) <= len(prefix) || !strings.EqualFold(diskName[:len(prefix)], prefix) {
+			return Machine{}, fmt.Sprintf("data disk %s is not named with the prefix %s", diskName, prefix)
+		}
+		machine.Spec.DataDisks = append(machine.Spec.DataDisks, infrav1.DataDisk{
+			NameSuffix:  diskName[len(prefix):],
+			DiskSizeGB:  ptr.Deref(disk.DiskSizeGB, 0),
+			ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: string(ptr.Deref(disk.ManagedDisk.StorageAccountType, ""))},
+			Lun:         disk.Lun,
+			CachingType: string(ptr.Deref(disk.Caching, "")),
+		})
+		machine.ResourceIDs = append(machine.ResourceIDs, ptr.Deref(disk.ManagedDisk.ID, ""))
+	}
+
+	if len(nics) == 0 {
+		return Machine{}, "virtual machine has no network interfaces"
+	}
+	backendPoolID := i.apiServerBackendPoolID()
+	for index, nic := range nics {
+		nicName := ptr.Deref(nic.Name, "")
+		if want := azure.GenerateNICName(name, len(nics) > 1, index); !strings.EqualFold(nicName, want) {
+			return Machine{}, fmt.Sprintf("network interface %s is not named %s", nicName, want)
+		}
+		if nic.Properties == nil || len(nic.Properties.IPConfigurations) == 0 {
+			return Machine{}, fmt.Sprintf("network interface %s has no IP configurations", nicName)
+		}
+		var subnetID string
+		for _, ipConfig := range nic.Properties.IPConfigurations {
+			if ipConfig.Properties == nil {
+				continue
+			}
+			if subnetID == "" && ipConfig.Properties.Subnet != nil {
+				subnetID = ptr.Deref(ipConfig.Properties.Subnet.ID, "")
+			}
+			for _, pool := range ipConfig.Properties.LoadBalancerBackendAddressPools {
+				if backendPoolID != "" && strings.EqualFold(ptr.Deref(pool.ID, ""), backendPoolID) {
+					machine.ControlPlane = true
+				}
+			}
+		}
+		if i.subnet(subnetID) == nil {
+			return Machine{}, fmt.Sprintf("network interface %s is not in virtual network %s", nicName, ptr.Deref(i.VirtualNetwork.Name, ""))
+		}
+		machine.Spec.NetworkInterfaces = append(machine.Spec.NetworkInterfaces, infrav1.NetworkInterface{
+			SubnetName:            resourceName(subnetID),
+			PrivateIPConfigs:      len(nic.Properties.IPConfigurations),
+			AcceleratedNetworking: nic.Properties.EnableAcceleratedNetworking,
+		})
+		machine.ResourceIDs = append(machine.ResourceIDs, ptr.Deref(nic.ID, ""))
+	}
+
+	if props.OSProfile != nil && props.OSProfile.LinuxConfiguration != nil && props.OSProfile.LinuxConfiguration.SSH != nil {
+		for _, key := range props.OSProfile.LinuxConfiguration.SSH.PublicKeys {
+			if data := ptr.Deref(key.KeyData, ""); data != "" {
+				machine.Spec.SSHPublicKey = base64.StdEncoding.EncodeToString([]byte(data))
+				break
+			}
+		}
+	}
+
+	return machine, ""
+}
+
+// imageFromReference returns the image of a virtual machine created from ref, or the reason why it is not supported.
+func imageFromReference(ref *armcompute.ImageReference) (*infrav1.Image, string) {
+	switch {
+	case ref == nil:
+		return nil, "virtual machine has no image reference"
+	case ptr.Deref(ref.ID, "") != "":
+		return &infrav1.Image{ID: ref.ID}, ""
+	case ptr.Deref(ref.Publisher, "") != "" && ptr.Deref(ref.Offer, "") != "" && ptr.Deref(ref.SKU, "") != "":
+		return &infrav1.Image{
+			Marketplace: &infrav1.AzureMarketplaceImage{
+				ImagePlan: infrav1.ImagePlan{
+					Publisher: *ref.Publisher,
+					Offer:     *ref.Offer,
+					SKU:       *ref.SKU,
+				},
+				Version: ptr.Deref(ref.ExactVersion, ptr.Deref(ref.Version, "latest")),
+			},
+		}, ""
+	default:
+		return nil, "virtual machine image is not a marketplace image or an image ID"
+	}
+}
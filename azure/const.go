@@ -46,4 +46,19 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	SecurityRuleLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-security-rules"
+
+	// PlanAnnotation is the key for the Azure Cluster object annotation which, when set to "true",
+	// makes the controller compute the changes it would make to Azure resources and record them
+	// in the status instead of applying them.
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	PlanAnnotation = "sigs.k8s.io/cluster-api-provider-azure-plan"
+
+	// AdoptAnnotation is the key for the object annotation which, when set to "true", asks CAPZ to adopt
+	// existing Azure resources. On an AzureCluster, the resources of a self-managed cluster in its resource
+	// group are discovered and described by the AzureCluster and new AzureMachines, after which the annotation
+	// is set to "adopted".
+	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
+	// for annotation formatting rules.
+	AdoptAnnotation = "sigs.k8s.io/cluster-api-provider-azure-adopt"
 )
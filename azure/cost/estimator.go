@@ -0,0 +1,210 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	sharedEstimatorMu sync.RWMutex
+	sharedEstimator   *Estimator
+)
+
+// Resources is an inventory of the billable Azure resources described by a spec.
+type Resources struct {
+	// Location is the Azure location of the resources.
+	Location string
+	// VMs are the virtual machines.
+	VMs []VM
+	// Disks are the managed disks.
+	Disks []Disk
+	// PublicIPs are the names of the public IP addresses.
+	PublicIPs []string
+	// NATGateways are the names of the NAT gateways.
+	NATGateways []string
+	// LoadBalancers are the load balancers.
+	LoadBalancers []LoadBalancer
+	// Bastions are the Azure Bastion hosts.
+	Bastions []Bastion
+}
+
+// VM describes a number of identical virtual machines.
+type VM struct {
+	// Size is the VM size, e.g. Standard_D2s_v3.
+	Size string
+	// VCPUs is the number of vCPUs of the size, or 0 if unknown.
+	VCPUs int
+	// Count is the number of VMs.
+	Count int
+}
+
+// Disk describes a number of identical managed disks.
+type Disk struct {
+	// StorageAccountType is the disk SKU, e.g. Premium_LRS.
+	StorageAccountType string
+	// SizeGiB is the size of a disk.
+	SizeGiB int32
+	// Count is the number of disks.
+	Count int
+}
+
+// LoadBalancer describes a load balancer.
+type LoadBalancer struct {
+	// Name is the name of the load balancer.
+	Name string
+	// SKU is the load balancer SKU, e.g. Standard.
+	SKU string
+}
+
+// Bastion describes an Azure Bastion host.
+type Bastion struct {
+	// Name is the name of the Bastion host.
+	Name string
+	// SKU is the Bastion SKU, e.g. Basic.
+	SKU string
+}
+
+// Estimate is the estimated monthly cost of Resources.
+type Estimate struct {
+	// MonthlyCost is the estimated cost per month.
+	MonthlyCost float64
+	// Currency is the currency of MonthlyCost.
+	Currency string
+	// Unpriced lists the resources missing from the price sheet, which are excluded from MonthlyCost.
+	Unpriced []string
+}
+
+// Status returns the estimate as published in the status of CAPZ resources.
+func (e Estimate) Status() *infrav1.CostEstimate {
+	return &infrav1.CostEstimate{
+		MonthlyCost:       strconv.FormatFloat(math.Round(e.MonthlyCost*100)/100, 'f', 2, 64),
+		Currency:          e.Currency,
+		UnpricedResources: e.Unpriced,
+	}
+}
+
+// Estimator estimates the monthly cost of Azure resources from a price sheet.
+type Estimator struct {
+	sheet *PriceSheet
+}
+
+// NewEstimator returns an Estimator using the prices of sheet.
+func NewEstimator(sheet *PriceSheet) *Estimator {
+	return &Estimator{sheet: sheet}
+}
+
+// SetDefaultEstimator sets the Estimator used by the controllers. A nil Estimator disables cost estimation.
+func SetDefaultEstimator(e *Estimator) {
+	sharedEstimatorMu.Lock()
+	defer sharedEstimatorMu.Unlock()
+	sharedEstimator = e
+}
+
+// DefaultEstimator returns the Estimator used by the controllers, or nil if cost estimation is disabled.
+func DefaultEstimator() *Estimator {
+	sharedEstimatorMu.RLock()
+	defer sharedEstimatorMu.RUnlock()
+	return sharedEstimator
+}
+
+// Estimate returns the estimated monthly cost of r.
+func (e *Estimator) Estimate(r Resources) Estimate {
+	var (
+		hours    = e.sheet.HoursPerMonth
+		monthly  float64
+		unpriced []string
+	)
+	if hours == 0 {
+		hours = DefaultHoursPerMonth
+	}
+	addUnpriced := func(resource string) {
+		if !slices.Contains(unpriced, resource) {
+			unpriced = append(unpriced, resource)
+		}
+	}
+
+	for _, vm := range r.VMs {
+		if vm.Count <= 0 {
+			continue
+		}
+		price, ok := e.sheet.vmHourly(r.Location, vm.Size, vm.VCPUs)
+		if !ok {
+			addUnpriced("virtualMachine/" + vm.Size)
+			continue
+		}
+		monthly += price * hours * float64(vm.Count)
+	}
+
+	for _, disk := range r.Disks {
+		if disk.Count <= 0 {
+			continue
+		}
+		price, ok := e.sheet.diskMonthlyPerGiB(r.Location, disk.StorageAccountType)
+		if !ok {
+			addUnpriced("disk/" + disk.StorageAccountType)
+			continue
+		}
+		monthly += price * float64(disk.SizeGiB) * float64(disk.Count)
+	}
+
+	if len(r.PublicIPs) > 0 {
+		if price, ok := e.sheet.publicIPHourly(r.Location); ok {
+			monthly += price * hours * float64(len(r.PublicIPs))
+		} else {
+			addUnpriced("publicIP")
+		}
+	}
+
+	if len(r.NATGateways) > 0 {
+		if price, ok := e.sheet.natGatewayHourly(r.Location); ok {
+			monthly += price * hours * float64(len(r.NATGateways))
+		} else {
+			addUnpriced("natGateway")
+		}
+	}
+
+	for _, lb := range r.LoadBalancers {
+		price, ok := e.sheet.loadBalancerHourly(r.Location, lb.SKU)
+		if !ok {
+			addUnpriced("loadBalancer/" + lb.SKU)
+			continue
+		}
+		monthly += price * hours
+	}
+
+	for _, bastion := range r.Bastions {
+		price, ok := e.sheet.bastionHourly(r.Location, bastion.SKU)
+		if !ok {
+			addUnpriced("bastion/" + bastion.SKU)
+			continue
+		}
+		monthly += price * hours
+	}
+
+	slices.Sort(unpriced)
+	return Estimate{
+		MonthlyCost: monthly,
+		Currency:    e.sheet.Currency,
+		Unpriced:    unpriced,
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func testPriceSheet() *PriceSheet {
+	return &PriceSheet{
+		Currency:      "USD",
+		HoursPerMonth: 100,
+		Default: Prices{
+			VMSizes:       map[string]float64{"Standard_D2s_v3": 0.1},
+			Disks:         map[string]float64{"Premium_LRS": 0.2},
+			PublicIP:      ptr.To(0.01),
+			NATGateway:    ptr.To(0.05),
+			LoadBalancers: map[string]float64{"Standard": 0.03},
+			Bastions:      map[string]float64{"Basic": 0.2},
+		},
+	}
+}
+
+func TestEstimatorEstimate(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources Resources
+		want      Estimate
+	}{
+		{
+			name: "empty",
+			want: Estimate{Currency: "USD"},
+		},
+		{
+			name: "priced resources",
+			resources: Resources{
+				Location:      "eastus",
+				VMs:           []VM{{Size: "Standard_D2s_v3", Count: 3}},
+				Disks:         []Disk{{StorageAccountType: "Premium_LRS", SizeGiB: 10, Count: 3}},
+				PublicIPs:     []string{"pip-1", "pip-2"},
+				NATGateways:   []string{"natgw"},
+				LoadBalancers: []LoadBalancer{{Name: "lb", SKU: "Standard"}},
+				Bastions:      []Bastion{{Name: "bastion", SKU: "Basic"}},
+			},
+			// VMs 3*0.1*100 + disks 3*10*0.2 + public IPs 2*0.01*100 + NAT gateway 0.05*100 + LB 0.03*100 + bastion 0.2*100.
+			want: Estimate{MonthlyCost: 30 + 6 + 2 + 5 + 3 + 20, Currency: "USD"},
+		},
+		{
+			name: "unpriced resources are reported and excluded",
+			resources: Resources{
+				VMs:           []VM{{Size: "Standard_D2s_v3", Count: 1}, {Size: "Standard_E8s_v5", Count: 2}, {Size: "Standard_E8s_v5", Count: 1}},
+				Disks:         []Disk{{StorageAccountType: "UltraSSD_LRS", SizeGiB: 10, Count: 1}},
+				LoadBalancers: []LoadBalancer{{Name: "lb", SKU: "Basic"}},
+				Bastions:      []Bastion{{Name: "bastion", SKU: "Standard"}},
+			},
+			want: Estimate{
+				MonthlyCost: 10,
+				Currency:    "USD",
+				Unpriced:    []string{"bastion/Standard", "disk/UltraSSD_LRS", "loadBalancer/Basic", "virtualMachine/Standard_E8s_v5"},
+			},
+		},
+		{
+			name: "zero count is free",
+			resources: Resources{
+				VMs:   []VM{{Size: "Standard_E8s_v5", Count: 0}},
+				Disks: []Disk{{StorageAccountType: "UltraSSD_LRS", SizeGiB: 10, Count: 0}},
+			},
+			want: Estimate{Currency: "USD"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := NewEstimator(testPriceSheet()).Estimate(tc.resources)
+			g.Expect(got.MonthlyCost).To(BeNumerically("~", tc.want.MonthlyCost, 1e-9))
+			g.Expect(got.Currency).To(Equal(tc.want.Currency))
+			g.Expect(got.Unpriced).To(Equal(tc.want.Unpriced))
+		})
+	}
+}
+
+func TestEstimateStatus(t *testing.T) {
+	g := NewWithT(t)
+	status := Estimate{MonthlyCost: 12.345, Currency: "EUR", Unpriced: []string{"publicIP"}}.Status()
+	g.Expect(status).To(Equal(&infrav1.CostEstimate{
+		MonthlyCost:       "12.35",
+		Currency:          "EUR",
+		UnpricedResources: []string{"publicIP"},
+	}))
+}
+
+func TestUpdateAndForget(t *testing.T) {
+	g := NewWithT(t)
+	t.Cleanup(func() { SetDefaultEstimator(nil) })
+
+	key := Key{Namespace: "default", Cluster: "my-cluster", Kind: infrav1.AzureMachineKind, Name: "machine-0"}
+	other := Key{Namespace: "default", Cluster: "my-cluster", Kind: infrav1.AzureMachineKind, Name: "machine-1"}
+	resources := Resources{VMs: []VM{{Size: "Standard_D2s_v3", Count: 1}}}
+
+	// Cost estimation is disabled without a default estimator.
+	SetDefaultEstimator(nil)
+	g.Expect(Update(key, resources)).To(BeNil())
+	g.Expect(testutil.CollectAndCount(clusterMonthlyCost)).To(BeZero())
+
+	SetDefaultEstimator(NewEstimator(testPriceSheet()))
+	g.Expect(Update(key, resources)).To(Equal(&infrav1.CostEstimate{MonthlyCost: "10.00", Currency: "USD"}))
+	g.Expect(Update(other, resources)).NotTo(BeNil())
+	clusterCost := func() float64 {
+		return testutil.ToFloat64(clusterMonthlyCost.WithLabelValues("default", "my-cluster", "USD"))
+	}
+	g.Expect(clusterCost()).To(BeNumerically("~", 20, 1e-9))
+
+	// Updating an object replaces its contribution.
+	Update(other, Resources{})
+	g.Expect(clusterCost()).To(BeNumerically("~", 10, 1e-9))
+
+	Forget(key)
+	Forget(other)
+	g.Expect(testutil.CollectAndCount(clusterMonthlyCost)).To(BeZero())
+}
@@ -0,0 +1,111 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	clusterMonthlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "capz",
+		Name:      "cluster_estimated_monthly_cost",
+		Help:      "Estimated monthly cost of the Azure resources of a cluster described by its AzureCluster, AzureMachines and AzureMachinePools.",
+	}, []string{"namespace", "cluster", "currency"})
+
+	recordedMu sync.Mutex
+	recorded   = map[Key]Estimate{}
+)
+
+// Key identifies an object whose cost contributes to the estimate of a cluster.
+type Key struct {
+	Namespace string
+	Cluster   string
+	Kind      string
+	Name      string
+}
+
+// NewKey returns the Key of obj, a kind object belonging to cluster.
+func NewKey(obj client.Object, kind, cluster string) Key {
+	return Key{
+		Namespace: obj.GetNamespace(),
+		Cluster:   cluster,
+		Kind:      kind,
+		Name:      obj.GetName(),
+	}
+}
+
+// Collectors returns the Prometheus collectors for the cost metrics.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{clusterMonthlyCost}
+}
+
+// Update estimates the monthly cost of resources with the default Estimator, records it as the contribution of key to
+// the cost of its cluster and returns it for publishing in the status of the object. It returns nil when cost
+// estimation is disabled.
+func Update(key Key, resources Resources) *infrav1.CostEstimate {
+	estimator := DefaultEstimator()
+	if estimator == nil {
+		return nil
+	}
+
+	estimate := estimator.Estimate(resources)
+	Record(key, estimate)
+	return estimate.Status()
+}
+
+// Record sets the contribution of key to the estimated monthly cost of its cluster.
+func Record(key Key, estimate Estimate) {
+	recordedMu.Lock()
+	defer recordedMu.Unlock()
+
+	recorded[key] = estimate
+	updateClusterMetric(key.Namespace, key.Cluster)
+}
+
+// Forget removes the contribution of key to the estimated monthly cost of its cluster, typically once the object is
+// deleted.
+func Forget(key Key) {
+	recordedMu.Lock()
+	defer recordedMu.Unlock()
+
+	if _, ok := recorded[key]; !ok {
+		return
+	}
+	delete(recorded, key)
+	updateClusterMetric(key.Namespace, key.Cluster)
+}
+
+// updateClusterMetric sums the recorded estimates of a cluster by currency. recordedMu must be held.
+func updateClusterMetric(namespace, cluster string) {
+	totals := map[string]float64{}
+	for key, estimate := range recorded {
+		if key.Namespace == namespace && key.Cluster == cluster {
+			totals[estimate.Currency] += estimate.MonthlyCost
+		}
+	}
+
+	clusterMonthlyCost.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "cluster": cluster})
+	for currency, total := range totals {
+		clusterMonthlyCost.WithLabelValues(namespace, cluster, currency).Set(total)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cost estimates the monthly cost of the Azure resources described by CAPZ specs from an offline price
+// sheet, so that estimates are available without network access and before the resources exist.
+package cost
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultHoursPerMonth is the number of hours Azure uses to convert hourly prices to monthly prices.
+const DefaultHoursPerMonth = 730
+
+// PriceSheet lists the prices used to estimate costs.
+type PriceSheet struct {
+	// Currency is the currency of every price in the sheet, e.g. USD.
+	Currency string `json:"currency"`
+	// HoursPerMonth converts hourly prices to monthly prices. Defaults to DefaultHoursPerMonth.
+	HoursPerMonth float64 `json:"hoursPerMonth,omitempty"`
+	// Default lists the prices used for every location.
+	Default Prices `json:"default,omitempty"`
+	// Regions lists prices by location. They take precedence over Default.
+	Regions map[string]Prices `json:"regions,omitempty"`
+}
+
+// Prices lists the prices of billable resources.
+type Prices struct {
+	// VMSizes is the hourly price of a VM by size, e.g. Standard_D2s_v3.
+	VMSizes map[string]float64 `json:"vmSizes,omitempty"`
+	// VCPU is the hourly price of a vCPU, used for VM sizes missing from VMSizes.
+	VCPU *float64 `json:"vcpu,omitempty"`
+	// Disks is the monthly price of a GiB of managed disk by storage account type, e.g. Premium_LRS.
+	Disks map[string]float64 `json:"disks,omitempty"`
+	// PublicIP is the hourly price of a public IP address.
+	PublicIP *float64 `json:"publicIP,omitempty"`
+	// NATGateway is the hourly price of a NAT gateway.
+	NATGateway *float64 `json:"natGateway,omitempty"`
+	// LoadBalancers is the hourly price of a load balancer by SKU, e.g. Standard.
+	LoadBalancers map[string]float64 `json:"loadBalancers,omitempty"`
+	// Bastions is the hourly price of an Azure Bastion host by SKU, e.g. Basic.
+	Bastions map[string]float64 `json:"bastions,omitempty"`
+}
+
+// LoadPriceSheet reads a YAML or JSON price sheet from path.
+func LoadPriceSheet(path string) (*PriceSheet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read price sheet %s", path)
+	}
+
+	sheet := &PriceSheet{}
+	if err := yaml.UnmarshalStrict(data, sheet); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse price sheet %s", path)
+	}
+	if sheet.Currency == "" {
+		return nil, errors.Errorf("price sheet %s must specify a currency", path)
+	}
+	if sheet.HoursPerMonth < 0 {
+		return nil, errors.Errorf("price sheet %s must not specify negative hoursPerMonth", path)
+	}
+	if sheet.HoursPerMonth == 0 {
+		sheet.HoursPerMonth = DefaultHoursPerMonth
+	}
+
+	return sheet, nil
+}
+
+// prices returns the regional prices for location followed by the default prices.
+func (s *PriceSheet) prices(location string) []Prices {
+	location = normalizeLocation(location)
+	for name, prices := range s.Regions {
+		if normalizeLocation(name) == location {
+			return []Prices{prices, s.Default}
+		}
+	}
+	return []Prices{s.Default}
+}
+
+// vmHourly returns the hourly price of a VM size, falling back to the vCPU price when vCPUs is known.
+func (s *PriceSheet) vmHourly(location, size string, vCPUs int) (float64, bool) {
+	prices := s.prices(location)
+	for _, p := range prices {
+		if price, ok := lookup(p.VMSizes, size); ok {
+			return price, true
+		}
+	}
+	if vCPUs <= 0 {
+		return 0, false
+	}
+	for _, p := range prices {
+		if p.VCPU != nil {
+			return *p.VCPU * float64(vCPUs), true
+		}
+	}
+	return 0, false
+}
+
+// diskMonthlyPerGiB returns the monthly price of a GiB of managed disk.
+func (s *PriceSheet) diskMonthlyPerGiB(location, storageAccountType string) (float64, bool) {
+	for _, p := range s.prices(location) {
+		if price, ok := lookup(p.Disks, storageAccountType); ok {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+// publicIPHourly returns the hourly price of a public IP address.
+func (s *PriceSheet) publicIPHourly(location string) (float64, bool) {
+	for _, p := range s.prices(location) {
+		if p.PublicIP != nil {
+			return *p.PublicIP, true
+		}
+	}
+	return 0, false
+}
+
+// natGatewayHourly returns the hourly price of a NAT gateway.
+func (s *PriceSheet) natGatewayHourly(location string) (float64, bool) {
+	for _, p := range s.prices(location) {
+		if p.NATGateway != nil {
+			return *p.NATGateway, true
+		}
+	}
+	return 0, false
+}
+
+// loadBalancerHourly returns the hourly price of a load balancer SKU.
+func (s *PriceSheet) loadBalancerHourly(location, sku string) (float64, bool) {
+	for _, p := range s.prices(location) {
+		if price, ok := lookup(p.LoadBalancers, sku); ok {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+// bastionHourly returns the hourly price of an Azure Bastion SKU.
+func (s *PriceSheet) bastionHourly(location, sku string) (float64, bool) {
+	for _, p := range s.prices(location) {
+		if price, ok := lookup(p.Bastions, sku); ok {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+// lookup finds key in m, ignoring case as Azure does for SKU names.
+func lookup(m map[string]float64, key string) (float64, bool) {
+	if price, ok := m[key]; ok {
+		return price, true
+	}
+	for k, price := range m {
+		if strings.EqualFold(k, key) {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeLocation turns a location display name such as "East US" into its name, "eastus".
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
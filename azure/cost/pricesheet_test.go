@@ -0,0 +1,130 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestLoadPriceSheet(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+		expect  func(g *WithT, sheet *PriceSheet)
+	}{
+		{
+			name: "valid sheet defaults hours per month",
+			data: `
+currency: USD
+default:
+  vmSizes:
+    Standard_D2s_v3: 0.096
+  publicIP: 0.005
+regions:
+  westeurope:
+    vmSizes:
+      Standard_D2s_v3: 0.11
+`,
+			expect: func(g *WithT, sheet *PriceSheet) {
+				g.Expect(sheet.Currency).To(Equal("USD"))
+				g.Expect(sheet.HoursPerMonth).To(Equal(float64(DefaultHoursPerMonth)))
+				g.Expect(sheet.Default.VMSizes).To(HaveKeyWithValue("Standard_D2s_v3", 0.096))
+				g.Expect(sheet.Regions).To(HaveKey("westeurope"))
+			},
+		},
+		{
+			name: "explicit hours per month",
+			data: "currency: EUR\nhoursPerMonth: 720\n",
+			expect: func(g *WithT, sheet *PriceSheet) {
+				g.Expect(sheet.HoursPerMonth).To(Equal(float64(720)))
+			},
+		},
+		{
+			name:    "missing currency",
+			data:    "default:\n  publicIP: 0.005\n",
+			wantErr: "must specify a currency",
+		},
+		{
+			name:    "negative hours per month",
+			data:    "currency: USD\nhoursPerMonth: -1\n",
+			wantErr: "negative hoursPerMonth",
+		},
+		{
+			name:    "unknown field",
+			data:    "currency: USD\nvmSize: {}\n",
+			wantErr: "failed to parse price sheet",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			path := filepath.Join(t.TempDir(), "prices.yaml")
+			g.Expect(os.WriteFile(path, []byte(tc.data), 0o600)).To(Succeed())
+
+			sheet, err := LoadPriceSheet(path)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			tc.expect(g, sheet)
+		})
+	}
+}
+
+func TestLoadPriceSheetMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	_, err := LoadPriceSheet(filepath.Join(t.TempDir(), "missing.yaml"))
+	g.Expect(err).To(MatchError(ContainSubstring("failed to read price sheet")))
+}
+
+func TestPriceSheetRegionalPrices(t *testing.T) {
+	g := NewWithT(t)
+	sheet := &PriceSheet{
+		Currency: "USD",
+		Default: Prices{
+			VMSizes: map[string]float64{"Standard_D2s_v3": 0.096},
+			VCPU:    ptr.To(0.05),
+		},
+		Regions: map[string]Prices{
+			"westeurope": {VMSizes: map[string]float64{"standard_d2s_v3": 0.11}},
+		},
+	}
+
+	price, ok := sheet.vmHourly("eastus", "Standard_D2s_v3", 2)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(price).To(Equal(0.096))
+
+	// Regional prices take precedence, and locations and sizes are matched regardless of case and spacing.
+	price, ok = sheet.vmHourly("West Europe", "Standard_D2s_v3", 2)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(price).To(Equal(0.11))
+
+	// Unknown sizes fall back to the vCPU price when the number of vCPUs is known.
+	price, ok = sheet.vmHourly("eastus", "Standard_D4s_v3", 4)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(price).To(Equal(0.2))
+
+	_, ok = sheet.vmHourly("eastus", "Standard_D4s_v3", 0)
+	g.Expect(ok).To(BeFalse())
+}
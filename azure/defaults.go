@@ -18,7 +18,10 @@ package azure
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
+	"os"
 	"regexp"
 	"strings"
 
@@ -27,8 +30,12 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/tracing/azotel"
+	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
@@ -48,6 +55,15 @@ const (
 	GermanCloudName = "AzureGermanCloud"
 )
 
+const (
+	// ResourceManagerEndpointEnvVar is the environment variable that overrides the Resource Manager endpoint of the
+	// cloud, as it does for ASO.
+	ResourceManagerEndpointEnvVar = "AZURE_RESOURCE_MANAGER_ENDPOINT"
+	// ResourceManagerAudienceEnvVar is the environment variable that overrides the Resource Manager token audience of
+	// the cloud, as it does for ASO.
+	ResourceManagerAudienceEnvVar = "AZURE_RESOURCE_MANAGER_AUDIENCE"
+)
+
 const (
 	// DefaultPublicGalleryName is the default Azure compute gallery.
 	DefaultPublicGalleryName = "ClusterAPI-f72ceb4f-5159-4c26-a0fe-2ea738f0d019"
@@ -175,6 +191,18 @@ func GenerateAvailabilitySetName(clusterName, nodeGroup string) string {
 	return fmt.Sprintf("%s_%s-as", clusterName, nodeGroup)
 }
 
+// GenerateApplicationSecurityGroupName generates the name of the application security group of the machines with the
+// given role, `control-plane` or `node`.
+func GenerateApplicationSecurityGroupName(clusterName, role string) string {
+	return fmt.Sprintf("%s-%s-asg", clusterName, role)
+}
+
+// GenerateIPv6BackendPoolName generates the name of the backend pool of the IPv6 IP configurations of the network
+// interfaces of a load balancer, based on the name of its backend pool of the IPv4 ones.
+func GenerateIPv6BackendPoolName(backendPoolName string) string {
+	return fmt.Sprintf("%s-ipv6", backendPoolName)
+}
+
 // VMID returns the azure resource ID for a given VM.
 func VMID(subscriptionID, resourceGroup, vmName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/virtualMachines/%s", subscriptionID, resourceGroup, vmName)
@@ -185,6 +213,16 @@ func VMSSID(subscriptionID, resourceGroup, vmssName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/virtualMachineScaleSets/%s", subscriptionID, resourceGroup, vmssName)
 }
 
+// DiskID returns the azure resource ID for a given managed disk.
+func DiskID(subscriptionID, resourceGroup, diskName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/disks/%s", subscriptionID, resourceGroup, diskName)
+}
+
+// ResourceGroupID returns the azure resource ID for a given resource group.
+func ResourceGroupID(subscriptionID, resourceGroup string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, resourceGroup)
+}
+
 // VNetID returns the azure resource ID for a given VNet.
 func VNetID(subscriptionID, resourceGroup, vnetName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s", subscriptionID, resourceGroup, vnetName)
@@ -215,6 +253,11 @@ func SecurityGroupID(subscriptionID, resourceGroup, nsgName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s", subscriptionID, resourceGroup, nsgName)
 }
 
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, asgName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, asgName)
+}
+
 // NatGatewayID returns the azure resource ID for a given NAT gateway.
 func NatGatewayID(subscriptionID, resourceGroup, natgatewayName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/natGateways/%s", subscriptionID, resourceGroup, natgatewayName)
@@ -245,6 +288,26 @@ func NATRuleID(subscriptionID, resourceGroup, loadBalancerName, natRuleName stri
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s/inboundNatRules/%s", subscriptionID, resourceGroup, loadBalancerName, natRuleName)
 }
 
+// PrivateLinkServiceID returns the azure resource ID for a given private link service.
+func PrivateLinkServiceID(subscriptionID, resourceGroup, privateLinkServiceName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateLinkServices/%s", subscriptionID, resourceGroup, privateLinkServiceName)
+}
+
+// ApplicationGatewayID returns the azure resource ID for a given application gateway.
+func ApplicationGatewayID(subscriptionID, resourceGroup, applicationGatewayName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationGateways/%s", subscriptionID, resourceGroup, applicationGatewayName)
+}
+
+// BastionHostID returns the azure resource ID for a given bastion host.
+func BastionHostID(subscriptionID, resourceGroup, bastionHostName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/bastionHosts/%s", subscriptionID, resourceGroup, bastionHostName)
+}
+
+// FirewallPolicyID returns the azure resource ID for a given firewall policy.
+func FirewallPolicyID(subscriptionID, resourceGroup, firewallPolicyName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/firewallPolicies/%s", subscriptionID, resourceGroup, firewallPolicyName)
+}
+
 // AvailabilitySetID returns the azure resource ID for a given availability set.
 func AvailabilitySetID(subscriptionID, resourceGroup, availabilitySetName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/availabilitySets/%s", subscriptionID, resourceGroup, availabilitySetName)
@@ -318,7 +381,9 @@ func UserAgent() string {
 	return fmt.Sprintf("cluster-api-provider-azure/%s", version.Get().String())
 }
 
-// ARMClientOptions returns default ARM client options for CAPZ SDK v2 requests.
+// ARMClientOptions returns default ARM client options for CAPZ SDK v2 requests. The Resource Manager endpoint and
+// audience of the cloud are overridden by the AZURE_RESOURCE_MANAGER_ENDPOINT and AZURE_RESOURCE_MANAGER_AUDIENCE
+// environment variables when set.
 func ARMClientOptions(azureEnvironment string, extraPolicies ...policy.Policy) (*arm.ClientOptions, error) {
 	opts := &arm.ClientOptions{}
 
@@ -334,9 +399,21 @@ func ARMClientOptions(azureEnvironment string, extraPolicies ...policy.Policy) (
 	default:
 		return nil, fmt.Errorf("invalid cloud name %q", azureEnvironment)
 	}
+	if endpoint := os.Getenv(ResourceManagerEndpointEnvVar); endpoint != "" {
+		if err := setResourceManagerEndpoint(opts, endpoint, os.Getenv(ResourceManagerAudienceEnvVar)); err != nil {
+			return nil, err
+		}
+	}
 	opts.PerCallPolicies = []policy.Policy{
 		correlationIDPolicy{},
 		userAgentPolicy{},
+		metrics.Policy{},
+	}
+	if budgeter := ratelimit.DefaultBudgeter(); budgeter != nil {
+		opts.PerCallPolicies = append(opts.PerCallPolicies, ratelimit.Policy{Budgeter: budgeter})
+	}
+	if audit.Enabled() {
+		opts.PerCallPolicies = append(opts.PerCallPolicies, audit.Policy{})
 	}
 	opts.PerCallPolicies = append(opts.PerCallPolicies, extraPolicies...)
 	opts.Retry.MaxRetries = -1 // Less than zero means one try and no retries.
@@ -346,6 +423,67 @@ func ARMClientOptions(azureEnvironment string, extraPolicies ...policy.Policy) (
 	return opts, nil
 }
 
+// ARMClientOptionsForAuthorizer returns default ARM client options for CAPZ SDK v2 requests made with auth.
+// Requests are sent to auth's BaseURI only if auth was created with WithBaseURI, other authorizers such as regional
+// ones keep the default Resource Manager endpoint of their cloud.
+func ARMClientOptionsForAuthorizer(auth Authorizer, extraPolicies ...policy.Policy) (*arm.ClientOptions, error) {
+	opts, err := ARMClientOptions(auth.CloudEnvironment(), extraPolicies...)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := auth.(CustomBaseURIAuthorizer); !ok {
+		return opts, nil
+	}
+	baseURI := auth.BaseURI()
+	if baseURI == "" {
+		return opts, nil
+	}
+	if err := setResourceManagerEndpoint(opts, baseURI, ""); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// setResourceManagerEndpoint points opts at endpoint for Resource Manager requests. The audience of the cloud is kept
+// when audience is empty.
+func setResourceManagerEndpoint(opts *arm.ClientOptions, endpoint, audience string) error {
+	parsedURI, err := url.Parse(endpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the base URI of client")
+	}
+
+	cloudConfig := opts.Cloud
+	if len(cloudConfig.Services) == 0 {
+		// The SDK defaults to the public cloud when none is configured.
+		cloudConfig = cloud.AzurePublic
+	}
+	resourceManager := cloudConfig.Services[cloud.ResourceManager]
+	if audience == "" {
+		audience = resourceManager.Audience
+	}
+	if strings.TrimSuffix(resourceManager.Endpoint, "/") == strings.TrimSuffix(endpoint, "/") && resourceManager.Audience == audience {
+		return nil
+	}
+
+	// Copy the services so the SDK's package-level cloud configurations are not modified.
+	services := make(map[cloud.ServiceName]cloud.ServiceConfiguration, len(cloudConfig.Services))
+	maps.Copy(services, cloudConfig.Services)
+	services[cloud.ResourceManager] = cloud.ServiceConfiguration{
+		Audience: audience,
+		Endpoint: endpoint,
+	}
+	opts.Cloud = cloud.Configuration{
+		ActiveDirectoryAuthorityHost: cloudConfig.ActiveDirectoryAuthorityHost,
+		Services:                     services,
+	}
+	// Allow endpoints without TLS such as a local fake Resource Manager.
+	opts.InsecureAllowCredentialWithHTTP = parsedURI.Scheme == "http"
+
+	return nil
+}
+
 // correlationIDPolicy adds the "x-ms-correlation-request-id" header to requests.
 // It implements the policy.Policy interface.
 type correlationIDPolicy struct{}
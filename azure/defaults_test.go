@@ -29,7 +29,10 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -80,11 +83,59 @@ func TestARMClientOptions(t *testing.T) {
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(opts.Cloud).To(Equal(tc.expectedCloud))
 			g.Expect(opts.Retry.MaxRetries).To(BeNumerically("==", -1))
-			g.Expect(opts.PerCallPolicies).To(HaveLen(2))
+			g.Expect(opts.PerCallPolicies).To(HaveLen(3))
 		})
 	}
 }
 
+func TestARMClientOptionsEndpointFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name             string
+		cloudName        string
+		endpoint         string
+		audience         string
+		expectedAudience string
+		expectedInsecure bool
+	}{
+		{
+			name:             "endpoint keeps the audience of the cloud",
+			cloudName:        ChinaCloudName,
+			endpoint:         "https://127.0.0.1:8443",
+			expectedAudience: cloud.AzureChina.Services[cloud.ResourceManager].Audience,
+		},
+		{
+			name:             "endpoint and audience",
+			cloudName:        PublicCloudName,
+			endpoint:         "https://management.local.azurestack.external/",
+			audience:         "https://management.azurestack.example/",
+			expectedAudience: "https://management.azurestack.example/",
+		},
+		{
+			name:             "HTTP endpoint without cloud name",
+			endpoint:         "http://127.0.0.1:8080",
+			expectedAudience: cloud.AzurePublic.Services[cloud.ResourceManager].Audience,
+			expectedInsecure: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Setenv(ResourceManagerEndpointEnvVar, tc.endpoint)
+			t.Setenv(ResourceManagerAudienceEnvVar, tc.audience)
+
+			opts, err := ARMClientOptions(tc.cloudName)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(opts.Cloud.Services[cloud.ResourceManager].Endpoint).To(Equal(tc.endpoint))
+			g.Expect(opts.Cloud.Services[cloud.ResourceManager].Audience).To(Equal(tc.expectedAudience))
+			g.Expect(opts.InsecureAllowCredentialWithHTTP).To(Equal(tc.expectedInsecure))
+		})
+	}
+
+	// The SDK's cloud configurations must not be modified.
+	g := NewWithT(t)
+	g.Expect(cloud.AzureChina.Services[cloud.ResourceManager].Endpoint).To(Equal("https://management.chinacloudapi.cn"))
+}
+
 // TestPerCallPolicies tests the per-call policies returned by `ARMClientOptions()`.
 func TestPerCallPolicies(t *testing.T) {
 	g := NewWithT(t)
@@ -98,12 +149,13 @@ func TestPerCallPolicies(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Call the factory function and ensure it has both PerCallPolicies.
+	// Call the factory function and ensure it has all the default PerCallPolicies.
 	opts, err := ARMClientOptions("")
 	g.Expect(err).NotTo(HaveOccurred())
-	g.Expect(opts.PerCallPolicies).To(HaveLen(2))
+	g.Expect(opts.PerCallPolicies).To(HaveLen(3))
 	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(correlationIDPolicy{})))
 	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(userAgentPolicy{})))
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(metrics.Policy{})))
 
 	// Create a request with a correlation ID.
 	ctx := context.WithValue(t.Context(), tele.CorrIDKeyVal, tele.CorrID(corrID))
@@ -352,3 +404,103 @@ func TestNormalizeAzureName(t *testing.T) {
 		})
 	}
 }
+
+func TestARMClientOptionsBudget(t *testing.T) {
+	g := NewWithT(t)
+
+	ratelimit.SetDefaultBudgeter(ratelimit.NewBudgeter(ratelimit.DefaultConfig()))
+	defer ratelimit.SetDefaultBudgeter(nil)
+
+	opts, err := ARMClientOptions("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(ratelimit.Policy{})))
+}
+
+func TestARMClientOptionsAudit(t *testing.T) {
+	g := NewWithT(t)
+
+	opts, err := ARMClientOptions("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(opts.PerCallPolicies).NotTo(ContainElement(BeAssignableToTypeOf(audit.Policy{})))
+
+	audit.SetDefaultSink(audit.MultiSink{})
+	defer audit.SetDefaultSink(nil)
+
+	opts, err = ARMClientOptions("")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(opts.PerCallPolicies).To(ContainElement(BeAssignableToTypeOf(audit.Policy{})))
+}
+
+func TestARMClientOptionsForAuthorizer(t *testing.T) {
+	tests := []struct {
+		name             string
+		cloudName        string
+		baseURI          string
+		customBaseURI    bool
+		expectedEndpoint string
+		expectedInsecure bool
+	}{
+		{
+			name:             "default endpoint of public cloud",
+			cloudName:        PublicCloudName,
+			baseURI:          "https://management.azure.com/",
+			expectedEndpoint: cloud.AzurePublic.Services[cloud.ResourceManager].Endpoint,
+		},
+		{
+			name:             "regional endpoint is not used as Resource Manager endpoint",
+			cloudName:        PublicCloudName,
+			baseURI:          "https://westus2.management.azure.com",
+			expectedEndpoint: cloud.AzurePublic.Services[cloud.ResourceManager].Endpoint,
+		},
+		{
+			name:             "empty custom base URI",
+			cloudName:        ChinaCloudName,
+			customBaseURI:    true,
+			expectedEndpoint: cloud.AzureChina.Services[cloud.ResourceManager].Endpoint,
+		},
+		{
+			name:             "custom base URI equal to the default endpoint",
+			cloudName:        PublicCloudName,
+			baseURI:          "https://management.azure.com/",
+			customBaseURI:    true,
+			expectedEndpoint: cloud.AzurePublic.Services[cloud.ResourceManager].Endpoint,
+		},
+		{
+			name:             "custom HTTPS endpoint",
+			cloudName:        PublicCloudName,
+			baseURI:          "https://127.0.0.1:8443",
+			customBaseURI:    true,
+			expectedEndpoint: "https://127.0.0.1:8443",
+		},
+		{
+			name:             "custom HTTP endpoint without cloud name",
+			baseURI:          "http://127.0.0.1:8080",
+			customBaseURI:    true,
+			expectedEndpoint: "http://127.0.0.1:8080",
+			expectedInsecure: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			authMock := mock_azure.NewMockAuthorizer(mockCtrl)
+			authMock.EXPECT().CloudEnvironment().Return(tc.cloudName).AnyTimes()
+			authMock.EXPECT().BaseURI().Return(tc.baseURI).AnyTimes()
+
+			var auth Authorizer = authMock
+			if tc.customBaseURI {
+				auth = WithBaseURI(authMock, tc.baseURI)
+			}
+			opts, err := ARMClientOptionsForAuthorizer(auth)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(opts.Cloud.Services[cloud.ResourceManager].Endpoint).To(Equal(tc.expectedEndpoint))
+			g.Expect(opts.InsecureAllowCredentialWithHTTP).To(Equal(tc.expectedInsecure))
+		})
+	}
+
+	// The SDK's cloud configurations must not be modified.
+	g := NewWithT(t)
+	g.Expect(cloud.AzurePublic.Services[cloud.ResourceManager].Endpoint).To(Equal("https://management.azure.com"))
+}
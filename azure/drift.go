@@ -0,0 +1,166 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// DriftDetector may be implemented for a ServiceReconciler that can detect changes made to its Azure resources
+// outside of CAPZ.
+type DriftDetector interface {
+	// DetectDrift returns the resources of the service which have drifted from their desired state. When correct
+	// is true, the drifted resources are also updated to their desired state.
+	DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error)
+}
+
+// ResourceDriftDetector detects and corrects the drift of an individual Azure resource.
+type ResourceDriftDetector interface {
+	DetectResourceDrift(ctx context.Context, spec ResourceSpecGetter, serviceName string, correct bool) (*infrav1.ResourceDrift, error)
+}
+
+// DetectDriftAll detects the drift of the resource of each spec, returning the first error encountered.
+func DetectDriftAll(ctx context.Context, detector ResourceDriftDetector, specs []ResourceSpecGetter, serviceName string, correct bool) ([]infrav1.ResourceDrift, error) {
+	var drifts []infrav1.ResourceDrift
+	for _, spec := range specs {
+		drift, err := detector.DetectResourceDrift(ctx, spec, serviceName, correct)
+		if err != nil {
+			return nil, err
+		}
+		if drift != nil {
+			drifts = append(drifts, *drift)
+		}
+	}
+	return drifts, nil
+}
+
+// DriftCorrection compares an existing resource to the desired state of the whole resource. Only the fields set in
+// desired are compared, and the elements of a list are matched by name or ID so that elements added outside of CAPZ,
+// like the load balancing rules of the cloud provider, are not drift. It returns a human-readable diff, empty when the
+// resource has not drifted, and a copy of existing with the drifted fields set to their desired values.
+func DriftCorrection(existing, desired any) (string, any, error) {
+	existingObj, err := toUnstructured(existing)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to convert existing resource")
+	}
+	desiredObj, err := toUnstructured(desired)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to convert desired resource")
+	}
+
+	corrected := mergeDesired(existingObj, desiredObj)
+	diff := cmp.Diff(existingObj, corrected)
+	if diff == "" {
+		return "", nil, nil
+	}
+
+	data, err := json.Marshal(corrected)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to marshal corrected resource")
+	}
+	correctedResource := reflect.New(reflect.TypeOf(existing))
+	if err := json.Unmarshal(data, correctedResource.Interface()); err != nil {
+		return "", nil, errors.Wrap(err, "failed to unmarshal corrected resource")
+	}
+	return diff, correctedResource.Elem().Interface(), nil
+}
+
+// mergeDesired returns existing with the fields set in desired set to their desired values, recursively.
+func mergeDesired(existing, desired any) any {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		existingMap, ok := existing.(map[string]any)
+		if !ok {
+			return desired
+		}
+		merged := make(map[string]any, len(existingMap))
+		for k, v := range existingMap {
+			merged[k] = v
+		}
+		for k, v := range desiredValue {
+			if k == "id" && equalIDs(existingMap[k], v) {
+				continue
+			}
+			merged[k] = mergeDesired(existingMap[k], v)
+		}
+		return merged
+	case []any:
+		existingList, ok := existing.([]any)
+		if !ok {
+			return desired
+		}
+		return mergeDesiredList(existingList, desiredValue)
+	default:
+		return desired
+	}
+}
+
+// mergeDesiredList merges the desired elements of a list into the existing ones with the same name or ID, appending
+// the desired elements which do not exist. Existing elements which are not desired are kept. A list whose desired
+// elements have no name or ID is replaced.
+func mergeDesiredList(existing, desired []any) []any {
+	existingIndex := make(map[string]int, len(existing))
+	for i, e := range existing {
+		if key := elementKey(e); key != "" {
+			existingIndex[key] = i
+		}
+	}
+
+	merged := append([]any{}, existing...)
+	for _, d := range desired {
+		key := elementKey(d)
+		if key == "" {
+			return desired
+		}
+		if i, ok := existingIndex[key]; ok {
+			merged[i] = mergeDesired(existing[i], d)
+		} else {
+			merged = append(merged, d)
+		}
+	}
+	return merged
+}
+
+// elementKey returns the name or, when it has none, the lowercase ID of a list element.
+func elementKey(element any) string {
+	m, ok := element.(map[string]any)
+	if !ok {
+		return ""
+	}
+	if name, ok := m["name"].(string); ok && name != "" {
+		return "name/" + strings.ToLower(name)
+	}
+	if id, ok := m["id"].(string); ok && id != "" {
+		return "id/" + strings.ToLower(id)
+	}
+	return ""
+}
+
+// equalIDs reports whether a and b are the same resource ID. Azure resource IDs are case-insensitive.
+func equalIDs(a, b any) bool {
+	aString, aOK := a.(string)
+	bString, bOK := b.(string)
+	return aOK && bOK && strings.EqualFold(aString, bString)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+type driftTestResource struct {
+	Name     *string            `json:"name,omitempty"`
+	Location *string            `json:"location,omitempty"`
+	Tags     map[string]*string `json:"tags,omitempty"`
+	Rules    []driftTestRule    `json:"rules,omitempty"`
+	Subnet   *driftTestSubnet   `json:"subnet,omitempty"`
+}
+
+type driftTestRule struct {
+	Name     *string `json:"name,omitempty"`
+	Priority *int    `json:"priority,omitempty"`
+}
+
+type driftTestSubnet struct {
+	ID *string `json:"id,omitempty"`
+}
+
+func TestDriftCorrection(t *testing.T) {
+	tests := []struct {
+		name              string
+		existing          driftTestResource
+		desired           driftTestResource
+		expectDrift       bool
+		expectedCorrected driftTestResource
+	}{
+		{
+			name:     "no drift",
+			existing: driftTestResource{Name: ptr.To("foo"), Location: ptr.To("westus"), Tags: map[string]*string{"foo": ptr.To("bar")}},
+			desired:  driftTestResource{Name: ptr.To("foo"), Location: ptr.To("westus")},
+		},
+		{
+			name: "rules added outside of CAPZ are not drift",
+			existing: driftTestResource{Rules: []driftTestRule{
+				{Name: ptr.To("capz"), Priority: ptr.To(100)},
+				{Name: ptr.To("cloud-provider"), Priority: ptr.To(500)},
+			}},
+			desired: driftTestResource{Rules: []driftTestRule{{Name: ptr.To("capz"), Priority: ptr.To(100)}}},
+		},
+		{
+			name:     "resource IDs differing in case are not drift",
+			existing: driftTestResource{Subnet: &driftTestSubnet{ID: ptr.To("/subscriptions/123/resourceGroups/MY-RG/subnets/node")}},
+			desired:  driftTestResource{Subnet: &driftTestSubnet{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/subnets/node")}},
+		},
+		{
+			name:        "changed field is drift",
+			existing:    driftTestResource{Location: ptr.To("westus"), Tags: map[string]*string{"foo": ptr.To("bar")}},
+			desired:     driftTestResource{Location: ptr.To("eastus")},
+			expectDrift: true,
+			expectedCorrected: driftTestResource{
+				Location: ptr.To("eastus"),
+				Tags:     map[string]*string{"foo": ptr.To("bar")},
+			},
+		},
+		{
+			name: "changed rule is drift and keeps rules added outside of CAPZ",
+			existing: driftTestResource{Rules: []driftTestRule{
+				{Name: ptr.To("capz"), Priority: ptr.To(200)},
+				{Name: ptr.To("cloud-provider"), Priority: ptr.To(500)},
+			}},
+			desired: driftTestResource{Rules: []driftTestRule{
+				{Name: ptr.To("capz"), Priority: ptr.To(100)},
+			}},
+			expectDrift: true,
+			expectedCorrected: driftTestResource{Rules: []driftTestRule{
+				{Name: ptr.To("capz"), Priority: ptr.To(100)},
+				{Name: ptr.To("cloud-provider"), Priority: ptr.To(500)},
+			}},
+		},
+		{
+			name:        "deleted rule is drift",
+			existing:    driftTestResource{Rules: []driftTestRule{{Name: ptr.To("cloud-provider"), Priority: ptr.To(500)}}},
+			desired:     driftTestResource{Rules: []driftTestRule{{Name: ptr.To("capz"), Priority: ptr.To(100)}}},
+			expectDrift: true,
+			expectedCorrected: driftTestResource{Rules: []driftTestRule{
+				{Name: ptr.To("cloud-provider"), Priority: ptr.To(500)},
+				{Name: ptr.To("capz"), Priority: ptr.To(100)},
+			}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			diff, corrected, err := DriftCorrection(tc.existing, tc.desired)
+			g.Expect(err).NotTo(HaveOccurred())
+			if !tc.expectDrift {
+				g.Expect(diff).To(BeEmpty())
+				g.Expect(corrected).To(BeNil())
+				return
+			}
+			g.Expect(diff).NotTo(BeEmpty())
+			g.Expect(corrected).To(Equal(tc.expectedCorrected))
+		})
+	}
+}
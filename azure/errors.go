@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -34,6 +35,26 @@ func ResourceNotFound(err error) bool {
 	return errors.As(err, &rerr) && rerr.StatusCode == http.StatusNotFound
 }
 
+// allocationFailureCodes are the error codes Azure returns when it fails to allocate a VM because of a lack of
+// capacity for its size in its zone or region.
+var allocationFailureCodes = []string{
+	"AllocationFailed",
+	"OverconstrainedAllocationRequest",
+	"OverconstrainedZonalAllocationRequest",
+	"SkuNotAvailable",
+	"ZonalAllocationFailed",
+}
+
+// AllocationFailureCode returns the error code of err if Azure failed to allocate a VM because of a lack of capacity,
+// or an empty string otherwise.
+func AllocationFailureCode(err error) string {
+	var rerr *azcore.ResponseError
+	if errors.As(err, &rerr) && slices.Contains(allocationFailureCodes, rerr.ErrorCode) {
+		return rerr.ErrorCode
+	}
+	return ""
+}
+
 // VMDeletedError is returned when a virtual machine is deleted outside of capz.
 type VMDeletedError struct {
 	ProviderID string
@@ -101,3 +101,40 @@ func TestResourceNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocationFailureCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "zonal allocation failure",
+			err:  &azcore.ResponseError{StatusCode: http.StatusOK, ErrorCode: "ZonalAllocationFailed"},
+			want: "ZonalAllocationFailed",
+		},
+		{
+			name: "wrapped SKU not available error",
+			err:  errors.Wrap(&azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "SkuNotAvailable"}, "failed to create resource"),
+			want: "SkuNotAvailable",
+		},
+		{
+			name: "other response error",
+			err:  &azcore.ResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "InvalidParameter"},
+			want: "",
+		},
+		{
+			name: "generic error",
+			err:  errors.New("ZonalAllocationFailed"),
+			want: "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := AllocationFailureCode(tc.err); got != tc.want {
+				t.Errorf("AllocationFailureCode() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -42,6 +42,12 @@ type Pauser interface {
 	Pause(context.Context) error
 }
 
+// Planner may be implemented for a ServiceReconciler that can report the changes it would make to Azure
+// resources without applying them.
+type Planner interface {
+	Plan(context.Context) ([]infrav1.ResourceChange, error)
+}
+
 // ServiceReconciler is an Azure service reconciler which can reconcile an Azure service.
 type ServiceReconciler interface {
 	Name() string
@@ -61,6 +67,14 @@ type Authorizer interface {
 	Token() azcore.TokenCredential
 }
 
+// SubscriptionAuthorizer is an interface which can get the Authorizer of the resources of a given subscription, for
+// clusters whose resources are spread across several subscriptions.
+type SubscriptionAuthorizer interface {
+	// AuthorizerForSubscription returns the Authorizer used to manage the resources in the subscription with the given
+	// ID. It defaults to the Authorizer of the cluster.
+	AuthorizerForSubscription(subscriptionID string) Authorizer
+}
+
 // NetworkDescriber is an interface which can get common Azure Cluster Networking information.
 type NetworkDescriber interface {
 	Vnet() *infrav1.VnetSpec
@@ -76,9 +90,13 @@ type NetworkDescriber interface {
 	APIServerLBName() string
 	APIServerLBPoolName() string
 	IsAPIServerPrivate() bool
+	ApplicationSecurityGroupsEnabled() bool
 	GetPrivateDNSZoneName() string
 	OutboundLBName(string) string
 	OutboundPoolName(string) string
+	OutboundIPv6PoolName(string) string
+	VnetSubscriptionID() string
+	ApplicationSecurityGroupResourceGroup() string
 }
 
 // ClusterDescriber is an interface which can get common Azure Cluster information.
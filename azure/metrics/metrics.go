@@ -0,0 +1,192 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides Prometheus metrics for the Azure service reconcilers and the Azure API calls they make.
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "capz"
+
+// Operations of a service recorded in the service metrics.
+const (
+	// OperationReconcile is the Reconcile operation of an azure.ServiceReconciler.
+	OperationReconcile = "reconcile"
+	// OperationDelete is the Delete operation of an azure.ServiceReconciler.
+	OperationDelete = "delete"
+	// OperationPause is the Pause operation of an azure.Pauser.
+	OperationPause = "pause"
+	// OperationCreateOrUpdateResource is the CreateOrUpdateResource operation of an async.Service.
+	OperationCreateOrUpdateResource = "create_or_update_resource"
+	// OperationDeleteResource is the DeleteResource operation of an async.Service.
+	OperationDeleteResource = "delete_resource"
+)
+
+// Types of errors recorded in the service error counter.
+const (
+	// ErrorTypeTransient is a ReconcileError which will be retried after a delay.
+	ErrorTypeTransient = "transient"
+	// ErrorTypeTerminal is a ReconcileError which will not be retried.
+	ErrorTypeTerminal = "terminal"
+	// ErrorTypeOther is any other error.
+	ErrorTypeOther = "other"
+)
+
+var (
+	serviceDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "service",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of the operations of the Azure service reconcilers.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"service", "operation"})
+
+	serviceErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "service",
+		Name:      "errors_total",
+		Help:      "Total number of errors returned by the operations of the Azure service reconcilers.",
+	}, []string{"service", "operation", "type"})
+
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "azure_api",
+		Name:      "requests_total",
+		Help:      "Total number of Azure API requests by HTTP method, resource type and response status code.",
+	}, []string{"method", "resource_type", "code"})
+
+	longRunningOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "long_running_operations",
+		Help:      "Number of Azure long-running operations in flight, tracked in LongRunningOperationStates.",
+	}, []string{"service", "type"})
+
+	driftedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "drift",
+		Name:      "resources_total",
+		Help:      "Total number of drifted Azure resources found by the drift scans, by service and whether they were corrected.",
+	}, []string{"service", "corrected"})
+)
+
+// Collectors returns the Prometheus collectors for the service metrics.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		serviceDuration,
+		serviceErrors,
+		apiRequests,
+		longRunningOperations,
+		driftedResources,
+	}
+}
+
+// ObserveService records the duration of an operation of service which started at start and the error it returned, if any.
+func ObserveService(service, operation string, start time.Time, err error) {
+	serviceDuration.WithLabelValues(service, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		serviceErrors.WithLabelValues(service, operation, ErrorType(err)).Inc()
+	}
+}
+
+// ObserveDrift records a drifted resource of service found by a drift scan.
+func ObserveDrift(service string, corrected bool) {
+	driftedResources.WithLabelValues(service, strconv.FormatBool(corrected)).Inc()
+}
+
+// reconcileError matches azure.ReconcileError without importing the azure package.
+type reconcileError interface {
+	IsTransient() bool
+	IsTerminal() bool
+}
+
+// ErrorType classifies err as a transient or terminal ReconcileError, or any other error.
+func ErrorType(err error) string {
+	var reconcileErr reconcileError
+	if !errors.As(err, &reconcileErr) {
+		return ErrorTypeOther
+	}
+	switch {
+	case reconcileErr.IsTerminal():
+		return ErrorTypeTerminal
+	case reconcileErr.IsTransient():
+		return ErrorTypeTransient
+	default:
+		return ErrorTypeOther
+	}
+}
+
+// LongRunningOperation identifies a long-running operation stored in a LongRunningOperationStates.
+type LongRunningOperation struct {
+	// Owner is the UID of the object storing the state of the operation. Resources with the same name in the same
+	// resource group, e.g. in different subscriptions, are operated on by different owners.
+	Owner string
+	// SubscriptionID is the subscription of the cluster of the owner.
+	SubscriptionID string
+	ResourceGroup  string
+	Name           string
+	Service        string
+	Type           string
+}
+
+var (
+	inFlightMu sync.Mutex
+	inFlight   = map[LongRunningOperation]struct{}{}
+)
+
+// TrackLongRunningOperation counts op as in flight until UntrackLongRunningOperation is called for it.
+// Tracking an operation again, e.g. after resuming it from its stored state, does not count it twice.
+func TrackLongRunningOperation(op LongRunningOperation) {
+	inFlightMu.Lock()
+	defer inFlightMu.Unlock()
+
+	if _, ok := inFlight[op]; ok {
+		return
+	}
+	inFlight[op] = struct{}{}
+	longRunningOperations.WithLabelValues(op.Service, op.Type).Inc()
+}
+
+// UntrackLongRunningOperation stops counting op as in flight. It does nothing if op is not tracked.
+func UntrackLongRunningOperation(op LongRunningOperation) {
+	inFlightMu.Lock()
+	defer inFlightMu.Unlock()
+
+	if _, ok := inFlight[op]; !ok {
+		return
+	}
+	delete(inFlight, op)
+	longRunningOperations.WithLabelValues(op.Service, op.Type).Dec()
+}
+
+// UntrackLongRunningOperations stops counting the operations of owner as in flight, e.g. once owner is deleted.
+func UntrackLongRunningOperations(owner string) {
+	inFlightMu.Lock()
+	defer inFlightMu.Unlock()
+
+	for op := range inFlight {
+		if op.Owner == owner {
+			delete(inFlight, op)
+			longRunningOperations.WithLabelValues(op.Service, op.Type).Dec()
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeReconcileError behaves like azure.ReconcileError.
+type fakeReconcileError struct {
+	transient, terminal bool
+}
+
+func (e fakeReconcileError) Error() string     { return "reconcile error" }
+func (e fakeReconcileError) IsTransient() bool { return e.transient }
+func (e fakeReconcileError) IsTerminal() bool  { return e.terminal }
+
+func TestErrorType(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "transient error",
+			err:  fakeReconcileError{transient: true},
+			want: ErrorTypeTransient,
+		},
+		{
+			name: "wrapped terminal error",
+			err:  fmt.Errorf("failed to reconcile: %w", fakeReconcileError{terminal: true}),
+			want: ErrorTypeTerminal,
+		},
+		{
+			name: "other error",
+			err:  errors.New("boom"),
+			want: ErrorTypeOther,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ErrorType(tc.err)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestObserveService(t *testing.T) {
+	g := NewWithT(t)
+
+	ObserveService("test-observe", OperationReconcile, time.Now(), nil)
+	ObserveService("test-observe", OperationReconcile, time.Now(), fakeReconcileError{transient: true})
+	ObserveService("test-observe", OperationReconcile, time.Now(), errors.New("boom"))
+
+	g.Expect(testutil.CollectAndCount(serviceDuration, "capz_service_operation_duration_seconds")).To(BeNumerically(">=", 1))
+	g.Expect(testutil.ToFloat64(serviceErrors.WithLabelValues("test-observe", OperationReconcile, ErrorTypeTransient))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(serviceErrors.WithLabelValues("test-observe", OperationReconcile, ErrorTypeOther))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(serviceErrors.WithLabelValues("test-observe", OperationReconcile, ErrorTypeTerminal))).To(BeZero())
+}
+
+func TestTrackLongRunningOperation(t *testing.T) {
+	g := NewWithT(t)
+
+	op := LongRunningOperation{Owner: "owner", SubscriptionID: "sub", ResourceGroup: "rg", Name: "vm", Service: "test-track", Type: "PUT"}
+	other := LongRunningOperation{Owner: "owner", SubscriptionID: "sub", ResourceGroup: "rg", Name: "vm-2", Service: "test-track", Type: "PUT"}
+	inFlight := func() float64 {
+		return testutil.ToFloat64(longRunningOperations.WithLabelValues("test-track", "PUT"))
+	}
+
+	TrackLongRunningOperation(op)
+	TrackLongRunningOperation(other)
+	// Resuming an operation does not count it twice.
+	TrackLongRunningOperation(op)
+	g.Expect(inFlight()).To(Equal(float64(2)))
+
+	UntrackLongRunningOperation(op)
+	// Untracking an operation which is not in flight does nothing.
+	UntrackLongRunningOperation(op)
+	g.Expect(inFlight()).To(Equal(float64(1)))
+
+	UntrackLongRunningOperation(other)
+	g.Expect(inFlight()).To(BeZero())
+}
+
+func TestTrackLongRunningOperationOfOwners(t *testing.T) {
+	g := NewWithT(t)
+
+	// Two clusters with a VM of the same name in a resource group of the same name, in different subscriptions.
+	op := LongRunningOperation{Owner: "owner-1", SubscriptionID: "sub-1", ResourceGroup: "rg", Name: "vm", Service: "test-track-owners", Type: "PUT"}
+	other := LongRunningOperation{Owner: "owner-2", SubscriptionID: "sub-2", ResourceGroup: "rg", Name: "vm", Service: "test-track-owners", Type: "PUT"}
+	inFlight := func() float64 {
+		return testutil.ToFloat64(longRunningOperations.WithLabelValues("test-track-owners", "PUT"))
+	}
+
+	TrackLongRunningOperation(op)
+	TrackLongRunningOperation(other)
+	g.Expect(inFlight()).To(Equal(float64(2)))
+
+	UntrackLongRunningOperation(op)
+	g.Expect(inFlight()).To(Equal(float64(1)))
+
+	UntrackLongRunningOperations("owner-1")
+	g.Expect(inFlight()).To(Equal(float64(1)))
+
+	UntrackLongRunningOperations("owner-2")
+	g.Expect(inFlight()).To(BeZero())
+}
+
+func TestObserveDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	ObserveDrift("test-drift", false)
+	ObserveDrift("test-drift", true)
+	ObserveDrift("test-drift", true)
+
+	g.Expect(testutil.ToFloat64(driftedResources.WithLabelValues("test-drift", "false"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(driftedResources.WithLabelValues("test-drift", "true"))).To(Equal(float64(2)))
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const (
+	// unknownResourceType is the resource type of requests which do not target a resource provider.
+	unknownResourceType = "unknown"
+	// noResponseCode is the code of requests which did not get a response.
+	noResponseCode = "none"
+)
+
+// Policy counts Azure API requests by HTTP method, resource type and response status code.
+// It implements the policy.Policy interface.
+type Policy struct{}
+
+// Do sends the request and records its outcome.
+func (Policy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	code := noResponseCode
+	if resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	apiRequests.WithLabelValues(req.Raw().Method, ResourceTypeForRequest(req.Raw()), code).Inc()
+	return resp, err
+}
+
+// ResourceTypeForRequest returns the fully qualified type of the resource targeted by req, e.g.
+// "microsoft.network/virtualnetworks/subnets", from the path of its URL.
+func ResourceTypeForRequest(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i := len(segments) - 2; i >= 0; i-- {
+		if !strings.EqualFold(segments[i], "providers") {
+			continue
+		}
+		// The segments following the provider namespace alternate between resource types and names.
+		types := []string{strings.ToLower(segments[i+1])}
+		for j := i + 2; j < len(segments); j += 2 {
+			types = append(types, strings.ToLower(segments[j]))
+		}
+		if len(types) == 1 {
+			break
+		}
+		return strings.Join(types, "/")
+	}
+	if len(segments) >= 3 && strings.EqualFold(segments[0], "subscriptions") {
+		return "microsoft.resources/" + strings.ToLower(segments[2])
+	}
+	return unknownResourceType
+}
@@ -0,0 +1,108 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestResourceTypeForRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "top-level resource",
+			path: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm",
+			want: "microsoft.compute/virtualmachines",
+		},
+		{
+			name: "child resource",
+			path: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet",
+			want: "microsoft.network/virtualnetworks/subnets",
+		},
+		{
+			name: "list of resources",
+			path: "/subscriptions/sub/providers/Microsoft.Compute/skus",
+			want: "microsoft.compute/skus",
+		},
+		{
+			name: "extension resource uses the last provider",
+			path: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm/providers/Microsoft.Authorization/roleAssignments/ra",
+			want: "microsoft.authorization/roleassignments",
+		},
+		{
+			name: "resource group",
+			path: "/subscriptions/sub/resourcegroups/rg",
+			want: "microsoft.resources/resourcegroups",
+		},
+		{
+			name: "subscription",
+			path: "/subscriptions/sub",
+			want: unknownResourceType,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			req := &http.Request{Method: http.MethodGet, URL: &url.URL{Path: tc.path}}
+			g.Expect(ResourceTypeForRequest(req)).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pipeline := runtime.NewPipeline("testmodule", "v0.1.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+		PerCallPolicies: []policy.Policy{Policy{}},
+		Retry:           policy.RetryOptions{MaxRetries: -1},
+	})
+	path := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/pip"
+	resourceType := "microsoft.network/publicipaddresses"
+	gets := testutil.ToFloat64(apiRequests.WithLabelValues(http.MethodGet, resourceType, "200"))
+	deletes := testutil.ToFloat64(apiRequests.WithLabelValues(http.MethodDelete, resourceType, "404"))
+
+	for _, method := range []string{http.MethodGet, http.MethodGet, http.MethodDelete} {
+		req, err := runtime.NewRequest(t.Context(), method, server.URL+path)
+		g.Expect(err).NotTo(HaveOccurred())
+		resp, err := pipeline.Do(req)
+		g.Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+	}
+
+	g.Expect(testutil.ToFloat64(apiRequests.WithLabelValues(http.MethodGet, resourceType, "200"))).To(Equal(gets + 2))
+	g.Expect(testutil.ToFloat64(apiRequests.WithLabelValues(http.MethodDelete, resourceType, "404"))).To(Equal(deletes + 1))
+}
@@ -19,7 +19,7 @@ limitations under the License.
 //
 // Generated by this command:
 //
-//	mockgen -destination azure_mock.go -package mock_azure -source ../interfaces.go
+//	mockgen -destination azure_mock.go -package mock_azure -source ../interfaces.go -exclude_interfaces SubscriptionAuthorizer
 //
 
 // Package mock_azure is a generated GoMock package.
@@ -131,6 +131,45 @@ func (mr *MockPauserMockRecorder) Pause(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockPauser)(nil).Pause), arg0)
 }
 
+// MockPlanner is a mock of Planner interface.
+type MockPlanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlannerMockRecorder
+	isgomock struct{}
+}
+
+// MockPlannerMockRecorder is the mock recorder for MockPlanner.
+type MockPlannerMockRecorder struct {
+	mock *MockPlanner
+}
+
+// NewMockPlanner creates a new mock instance.
+func NewMockPlanner(ctrl *gomock.Controller) *MockPlanner {
+	mock := &MockPlanner{ctrl: ctrl}
+	mock.recorder = &MockPlannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlanner) EXPECT() *MockPlannerMockRecorder {
+	return m.recorder
+}
+
+// Plan mocks base method.
+func (m *MockPlanner) Plan(arg0 context.Context) ([]v1beta1.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", arg0)
+	ret0, _ := ret[0].([]v1beta1.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockPlannerMockRecorder) Plan(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockPlanner)(nil).Plan), arg0)
+}
+
 // MockServiceReconciler is a mock of ServiceReconciler interface.
 type MockServiceReconciler struct {
 	ctrl     *gomock.Controller
@@ -399,6 +438,34 @@ func (mr *MockNetworkDescriberMockRecorder) APIServerLBPoolName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIServerLBPoolName", reflect.TypeOf((*MockNetworkDescriber)(nil).APIServerLBPoolName))
 }
 
+// ApplicationSecurityGroupResourceGroup mocks base method.
+func (m *MockNetworkDescriber) ApplicationSecurityGroupResourceGroup() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroupResourceGroup")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ApplicationSecurityGroupResourceGroup indicates an expected call of ApplicationSecurityGroupResourceGroup.
+func (mr *MockNetworkDescriberMockRecorder) ApplicationSecurityGroupResourceGroup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroupResourceGroup", reflect.TypeOf((*MockNetworkDescriber)(nil).ApplicationSecurityGroupResourceGroup))
+}
+
+// ApplicationSecurityGroupsEnabled mocks base method.
+func (m *MockNetworkDescriber) ApplicationSecurityGroupsEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroupsEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ApplicationSecurityGroupsEnabled indicates an expected call of ApplicationSecurityGroupsEnabled.
+func (mr *MockNetworkDescriberMockRecorder) ApplicationSecurityGroupsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroupsEnabled", reflect.TypeOf((*MockNetworkDescriber)(nil).ApplicationSecurityGroupsEnabled))
+}
+
 // ControlPlaneRouteTable mocks base method.
 func (m *MockNetworkDescriber) ControlPlaneRouteTable() v1beta1.RouteTable {
 	m.ctrl.T.Helper()
@@ -497,6 +564,20 @@ func (mr *MockNetworkDescriberMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockNetworkDescriber)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockNetworkDescriber) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockNetworkDescriberMockRecorder) OutboundIPv6PoolName(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockNetworkDescriber)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockNetworkDescriber) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -579,6 +660,20 @@ func (mr *MockNetworkDescriberMockRecorder) Vnet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockNetworkDescriber)(nil).Vnet))
 }
 
+// VnetSubscriptionID mocks base method.
+func (m *MockNetworkDescriber) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockNetworkDescriberMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockNetworkDescriber)(nil).VnetSubscriptionID))
+}
+
 // MockClusterDescriber is a mock of ClusterDescriber interface.
 type MockClusterDescriber struct {
 	ctrl     *gomock.Controller
@@ -1155,6 +1250,34 @@ func (mr *MockClusterScoperMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockClusterScoper)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroupResourceGroup mocks base method.
+func (m *MockClusterScoper) ApplicationSecurityGroupResourceGroup() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroupResourceGroup")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ApplicationSecurityGroupResourceGroup indicates an expected call of ApplicationSecurityGroupResourceGroup.
+func (mr *MockClusterScoperMockRecorder) ApplicationSecurityGroupResourceGroup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroupResourceGroup", reflect.TypeOf((*MockClusterScoper)(nil).ApplicationSecurityGroupResourceGroup))
+}
+
+// ApplicationSecurityGroupsEnabled mocks base method.
+func (m *MockClusterScoper) ApplicationSecurityGroupsEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroupsEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ApplicationSecurityGroupsEnabled indicates an expected call of ApplicationSecurityGroupsEnabled.
+func (mr *MockClusterScoperMockRecorder) ApplicationSecurityGroupsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroupsEnabled", reflect.TypeOf((*MockClusterScoper)(nil).ApplicationSecurityGroupsEnabled))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockClusterScoper) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -1545,6 +1668,20 @@ func (mr *MockClusterScoperMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockClusterScoper)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockClusterScoper) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockClusterScoperMockRecorder) OutboundIPv6PoolName(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockClusterScoper)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockClusterScoper) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -1731,6 +1868,20 @@ func (mr *MockClusterScoperMockRecorder) Vnet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockClusterScoper)(nil).Vnet))
 }
 
+// VnetSubscriptionID mocks base method.
+func (m *MockClusterScoper) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockClusterScoperMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockClusterScoper)(nil).VnetSubscriptionID))
+}
+
 // MockManagedClusterScoper is a mock of ManagedClusterScoper interface.
 type MockManagedClusterScoper struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,145 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// ResourcePlanner computes the change that reconciling an individual Azure resource would make.
+type ResourcePlanner interface {
+	PlanResource(ctx context.Context, spec ResourceSpecGetter, serviceName string) (infrav1.ResourceChange, error)
+}
+
+// PlanAll computes the change for each resource spec, returning the first error encountered.
+func PlanAll(ctx context.Context, planner ResourcePlanner, specs []ResourceSpecGetter, serviceName string) ([]infrav1.ResourceChange, error) {
+	changes := make([]infrav1.ResourceChange, 0, len(specs))
+	for _, spec := range specs {
+		change, err := planner.PlanResource(ctx, spec, serviceName)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// PlanDiff returns a human-readable diff between an existing resource and the desired parameters for it.
+// Only the fields set in desired are compared, since fields left unset are not changed by a PUT or PATCH
+// built from the desired parameters. An empty string is returned when no compared field differs.
+func PlanDiff(existing, desired any) (string, error) {
+	existingMap, err := toUnstructured(existing)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to convert existing resource")
+	}
+	desiredMap, err := toUnstructured(desired)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to convert desired parameters")
+	}
+	return cmp.Diff(pruneToKeys(existingMap, desiredMap), desiredMap), nil
+}
+
+// toUnstructured round-trips obj through JSON so that resources of different Go types can be compared.
+func toUnstructured(obj any) (any, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// pruneToKeys drops the map keys of existing which are not present in desired, recursively. List elements are
+// matched by name or ID like mergeDesiredList does, or by position when desired has elements without either, so
+// fields Azure populates inside list elements are pruned too.
+func pruneToKeys(existing, desired any) any {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		existingMap, ok := existing.(map[string]any)
+		if !ok {
+			return existing
+		}
+		pruned := make(map[string]any, len(desiredValue))
+		for k, v := range desiredValue {
+			if ev, ok := existingMap[k]; ok {
+				pruned[k] = pruneToKeys(ev, v)
+			}
+		}
+		return pruned
+	case []any:
+		existingList, ok := existing.([]any)
+		if !ok {
+			return existing
+		}
+		return pruneListToKeys(existingList, desiredValue)
+	default:
+		return existing
+	}
+}
+
+// pruneListToKeys prunes the elements of existing matching an element of desired. Matched elements are ordered as in
+// desired and the elements of existing without a match are kept at the end, since a PUT of desired removes them.
+func pruneListToKeys(existing, desired []any) []any {
+	matched := make([]bool, len(existing))
+	pruned := make([]any, 0, len(existing))
+
+	// Existing elements are indexed by both name and ID, since desired elements may only have one of them.
+	existingIndex := make(map[string]int, 2*len(existing))
+	for i, e := range existing {
+		if key := elementKey(e); key != "" {
+			existingIndex[key] = i
+		}
+		if m, ok := e.(map[string]any); ok {
+			if key := elementKey(map[string]any{"id": m["id"]}); key != "" {
+				existingIndex[key] = i
+			}
+		}
+	}
+	for i, d := range desired {
+		key := elementKey(d)
+		if key == "" {
+			// Elements without a name or ID can only be matched by position.
+			if i < len(existing) && !matched[i] {
+				matched[i] = true
+				pruned = append(pruned, pruneToKeys(existing[i], d))
+			}
+			continue
+		}
+		if j, ok := existingIndex[key]; ok && !matched[j] {
+			matched[j] = true
+			pruned = append(pruned, pruneToKeys(existing[j], d))
+		}
+	}
+	for i, e := range existing {
+		if !matched[i] {
+			pruned = append(pruned, e)
+		}
+	}
+	return pruned
+}
@@ -0,0 +1,133 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+type planTestResource struct {
+	Name       *string            `json:"name,omitempty"`
+	Location   *string            `json:"location,omitempty"`
+	Tags       map[string]*string `json:"tags,omitempty"`
+	Properties *planTestProps     `json:"properties,omitempty"`
+}
+
+type planTestProps struct {
+	State *string        `json:"state,omitempty"`
+	Size  *int           `json:"size,omitempty"`
+	Rules []planTestRule `json:"rules,omitempty"`
+}
+
+type planTestRule struct {
+	ID       *string `json:"id,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	Priority *int    `json:"priority,omitempty"`
+	Etag     *string `json:"etag,omitempty"`
+}
+
+func TestPlanDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing any
+		desired  any
+		wantDiff bool
+	}{
+		{
+			name:     "identical resources",
+			existing: planTestResource{Name: ptr.To("a"), Location: ptr.To("westus")},
+			desired:  planTestResource{Name: ptr.To("a"), Location: ptr.To("westus")},
+			wantDiff: false,
+		},
+		{
+			name:     "fields not set in desired are ignored",
+			existing: planTestResource{Name: ptr.To("a"), Location: ptr.To("westus"), Properties: &planTestProps{State: ptr.To("Succeeded"), Size: ptr.To(1)}},
+			desired:  planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Size: ptr.To(1)}},
+			wantDiff: false,
+		},
+		{
+			name:     "changed nested field",
+			existing: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{State: ptr.To("Succeeded"), Size: ptr.To(1)}},
+			desired:  planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Size: ptr.To(2)}},
+			wantDiff: true,
+		},
+		{
+			name:     "added tag",
+			existing: planTestResource{Name: ptr.To("a"), Tags: map[string]*string{"foo": ptr.To("bar")}},
+			desired:  planTestResource{Name: ptr.To("a"), Tags: map[string]*string{"foo": ptr.To("bar"), "baz": ptr.To("qux")}},
+			wantDiff: true,
+		},
+		{
+			name: "fields not set in desired list elements are ignored",
+			existing: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{ID: ptr.To("/rules/b"), Name: ptr.To("b"), Priority: ptr.To(200), Etag: ptr.To("2")},
+				{ID: ptr.To("/rules/a"), Name: ptr.To("a"), Priority: ptr.To(100), Etag: ptr.To("1")},
+			}}},
+			desired: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{Name: ptr.To("a"), Priority: ptr.To(100)},
+				{Name: ptr.To("b"), Priority: ptr.To(200)},
+			}}},
+			wantDiff: false,
+		},
+		{
+			name: "list elements without a name are matched by ID",
+			existing: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{ID: ptr.To("/rules/a"), Name: ptr.To("a"), Etag: ptr.To("1")},
+			}}},
+			desired: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{ID: ptr.To("/rules/a")},
+			}}},
+			wantDiff: false,
+		},
+		{
+			name: "changed field of a list element",
+			existing: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{Name: ptr.To("a"), Priority: ptr.To(100), Etag: ptr.To("1")},
+			}}},
+			desired: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{Name: ptr.To("a"), Priority: ptr.To(150)},
+			}}},
+			wantDiff: true,
+		},
+		{
+			name: "removed list element",
+			existing: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{Name: ptr.To("a"), Priority: ptr.To(100), Etag: ptr.To("1")},
+				{Name: ptr.To("b"), Priority: ptr.To(200), Etag: ptr.To("2")},
+			}}},
+			desired: planTestResource{Name: ptr.To("a"), Properties: &planTestProps{Rules: []planTestRule{
+				{Name: ptr.To("a"), Priority: ptr.To(100)},
+			}}},
+			wantDiff: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			diff, err := PlanDiff(tc.existing, tc.desired)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.wantDiff {
+				g.Expect(diff).NotTo(BeEmpty())
+			} else {
+				g.Expect(diff).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit implements a client-side budget for Azure Resource Manager requests so that CAPZ delays
+// calls before ARM starts throttling them.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Operation is the ARM throttling category of a request.
+type Operation string
+
+const (
+	// Read is the category of GET and HEAD requests.
+	Read Operation = "read"
+	// Write is the category of PUT, PATCH and POST requests.
+	Write Operation = "write"
+	// Delete is the category of DELETE requests.
+	Delete Operation = "delete"
+)
+
+// BucketConfig configures a token bucket.
+type BucketConfig struct {
+	// Capacity is the maximum number of tokens in the bucket.
+	Capacity float64
+	// RefillPerSecond is the number of tokens added to the bucket every second.
+	RefillPerSecond float64
+}
+
+// Config configures a Budgeter.
+type Config struct {
+	// Buckets configures the token bucket of each operation.
+	Buckets map[Operation]BucketConfig
+}
+
+// DefaultConfig returns a Config matching the ARM token bucket limits for a subscription.
+// See https://learn.microsoft.com/azure/azure-resource-manager/management/request-limits-and-throttling.
+func DefaultConfig() Config {
+	return Config{
+		Buckets: map[Operation]BucketConfig{
+			Read:   {Capacity: 250, RefillPerSecond: 25},
+			Write:  {Capacity: 200, RefillPerSecond: 10},
+			Delete: {Capacity: 200, RefillPerSecond: 10},
+		},
+	}
+}
+
+// Key identifies a budget.
+type Key struct {
+	SubscriptionID string
+	Provider       string
+	Operation      Operation
+}
+
+// Budgeter tracks a token bucket per subscription, resource provider and operation. It is safe for concurrent use.
+type Budgeter struct {
+	config Config
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets map[Key]*bucket
+}
+
+// NewBudgeter creates a new Budgeter.
+func NewBudgeter(config Config) *Budgeter {
+	return &Budgeter{
+		config:  config,
+		now:     time.Now,
+		buckets: make(map[Key]*bucket),
+	}
+}
+
+var (
+	sharedBudgeterMu sync.RWMutex
+	sharedBudgeter   *Budgeter
+)
+
+// SetDefaultBudgeter sets the Budgeter shared by all Azure SDK clients. A nil Budgeter disables client-side budgeting.
+func SetDefaultBudgeter(b *Budgeter) {
+	sharedBudgeterMu.Lock()
+	defer sharedBudgeterMu.Unlock()
+	sharedBudgeter = b
+}
+
+// DefaultBudgeter returns the Budgeter shared by all Azure SDK clients, or nil if budgeting is disabled.
+func DefaultBudgeter() *Budgeter {
+	sharedBudgeterMu.RLock()
+	defer sharedBudgeterMu.RUnlock()
+	return sharedBudgeter
+}
+
+// Wait blocks until a token is available for key and takes it, or until ctx is done.
+func (b *Budgeter) Wait(ctx context.Context, key Key) error {
+	var waited time.Duration
+	defer func() {
+		if waited > 0 {
+			waitSeconds.WithLabelValues(key.labels()...).Add(waited.Seconds())
+		}
+	}()
+
+	for {
+		delay := b.take(key)
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+			waited += delay
+		}
+	}
+}
+
+// Observe lowers the budget for key to the number of requests ARM reports as remaining.
+func (b *Budgeter) Observe(key Key, remaining int) {
+	armRemaining.WithLabelValues(key.labels()...).Set(float64(remaining))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bk := b.bucket(key)
+	bk.refill(b.now())
+	bk.tokens = math.Min(bk.tokens, float64(remaining))
+	remainingTokens.WithLabelValues(key.labels()...).Set(bk.tokens)
+}
+
+// Throttle empties the budget for key and blocks it for retryAfter after ARM throttled a request.
+func (b *Budgeter) Throttle(key Key, retryAfter time.Duration) {
+	throttledRequests.WithLabelValues(key.labels()...).Inc()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	bk := b.bucket(key)
+	bk.refill(now)
+	bk.tokens = 0
+	if until := now.Add(retryAfter); until.After(bk.blockedUntil) {
+		bk.blockedUntil = until
+	}
+	remainingTokens.WithLabelValues(key.labels()...).Set(bk.tokens)
+}
+
+// take takes a token for key if one is available, otherwise it returns how long to wait before trying again.
+func (b *Budgeter) take(key Key) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bk := b.bucket(key)
+	delay := bk.take(b.now())
+	remainingTokens.WithLabelValues(key.labels()...).Set(bk.tokens)
+	return delay
+}
+
+// bucket returns the bucket for key, creating a full one if needed. b.mu must be held.
+func (b *Budgeter) bucket(key Key) *bucket {
+	if bk, ok := b.buckets[key]; ok {
+		return bk
+	}
+	cfg, ok := b.config.Buckets[key.Operation]
+	if !ok {
+		cfg = DefaultConfig().Buckets[key.Operation]
+	}
+	bk := &bucket{
+		capacity:        cfg.Capacity,
+		refillPerSecond: cfg.RefillPerSecond,
+		tokens:          cfg.Capacity,
+		last:            b.now(),
+	}
+	b.buckets[key] = bk
+	return bk
+}
+
+func (k Key) labels() []string {
+	return []string{k.SubscriptionID, k.Provider, string(k.Operation)}
+}
+
+// bucket is a token bucket. It is not safe for concurrent use.
+type bucket struct {
+	capacity        float64
+	refillPerSecond float64
+	tokens          float64
+	last            time.Time
+	blockedUntil    time.Time
+}
+
+func (bk *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(bk.last).Seconds(); elapsed > 0 {
+		bk.tokens = math.Min(bk.capacity, bk.tokens+elapsed*bk.refillPerSecond)
+		bk.last = now
+	}
+}
+
+func (bk *bucket) take(now time.Time) time.Duration {
+	if now.Before(bk.blockedUntil) {
+		return bk.blockedUntil.Sub(now)
+	}
+	bk.refill(now)
+	if bk.tokens >= 1 {
+		bk.tokens--
+		return 0
+	}
+	if bk.refillPerSecond <= 0 {
+		// A bucket which never refills would block forever, so let the request through and let ARM decide.
+		return 0
+	}
+	return time.Duration((1 - bk.tokens) / bk.refillPerSecond * float64(time.Second))
+}
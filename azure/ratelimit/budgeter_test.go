@@ -0,0 +1,101 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func newTestBudgeter(capacity, refill float64) (*Budgeter, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBudgeter(Config{Buckets: map[Operation]BucketConfig{
+		Read:   {Capacity: capacity, RefillPerSecond: refill},
+		Write:  {Capacity: capacity, RefillPerSecond: refill},
+		Delete: {Capacity: capacity, RefillPerSecond: refill},
+	}})
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBudgeterTake(t *testing.T) {
+	g := NewWithT(t)
+	b, now := newTestBudgeter(2, 1)
+	key := Key{SubscriptionID: "sub", Provider: "microsoft.compute", Operation: Write}
+
+	g.Expect(b.take(key)).To(BeZero())
+	g.Expect(b.take(key)).To(BeZero())
+	g.Expect(b.take(key)).To(Equal(time.Second))
+
+	// Half a token refilled.
+	*now = now.Add(500 * time.Millisecond)
+	g.Expect(b.take(key)).To(Equal(500 * time.Millisecond))
+
+	*now = now.Add(500 * time.Millisecond)
+	g.Expect(b.take(key)).To(BeZero())
+
+	// Buckets are independent per key.
+	other := Key{SubscriptionID: "sub", Provider: "microsoft.network", Operation: Write}
+	g.Expect(b.take(other)).To(BeZero())
+
+	// Buckets never exceed their capacity.
+	*now = now.Add(time.Hour)
+	g.Expect(b.take(key)).To(BeZero())
+	g.Expect(b.take(key)).To(BeZero())
+	g.Expect(b.take(key)).To(Equal(time.Second))
+}
+
+func TestBudgeterObserve(t *testing.T) {
+	g := NewWithT(t)
+	b, _ := newTestBudgeter(10, 1)
+	key := Key{SubscriptionID: "sub", Provider: "microsoft.compute", Operation: Read}
+
+	b.Observe(key, 1)
+	g.Expect(b.take(key)).To(BeZero())
+	g.Expect(b.take(key)).To(Equal(time.Second))
+
+	// Reporting more remaining requests than the budget does not raise it.
+	b.Observe(key, 100)
+	g.Expect(b.take(key)).To(Equal(time.Second))
+}
+
+func TestBudgeterThrottle(t *testing.T) {
+	g := NewWithT(t)
+	b, now := newTestBudgeter(10, 1)
+	key := Key{SubscriptionID: "sub", Provider: "microsoft.compute", Operation: Delete}
+
+	b.Throttle(key, 30*time.Second)
+	g.Expect(b.take(key)).To(Equal(30 * time.Second))
+
+	*now = now.Add(30 * time.Second)
+	g.Expect(b.take(key)).To(BeZero())
+}
+
+func TestBudgeterWaitContextCanceled(t *testing.T) {
+	g := NewWithT(t)
+	b, _ := newTestBudgeter(1, 0.001)
+	key := Key{SubscriptionID: "sub", Provider: "microsoft.compute", Operation: Write}
+
+	g.Expect(b.Wait(t.Context(), key)).To(Succeed())
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	g.Expect(b.Wait(ctx, key)).To(MatchError(context.DeadlineExceeded))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "capz_azure_api_budget"
+
+var (
+	labels = []string{"subscription_id", "provider", "operation"}
+
+	remainingTokens = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "remaining_tokens",
+		Help:      "Number of Azure API requests the client-side budget currently allows without waiting.",
+	}, labels)
+
+	armRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "arm_remaining_requests",
+		Help:      "Number of Azure API requests remaining as last reported by the x-ms-ratelimit-remaining-* response headers.",
+	}, labels)
+
+	waitSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "wait_seconds_total",
+		Help:      "Total time Azure API requests were delayed by the client-side budget.",
+	}, labels)
+
+	throttledRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "throttled_requests_total",
+		Help:      "Total number of Azure API requests throttled by ARM with a 429 response.",
+	}, labels)
+)
+
+// Collectors returns the Prometheus collectors for the budget metrics.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		remainingTokens,
+		armRemaining,
+		waitSeconds,
+		throttledRequests,
+	}
+}
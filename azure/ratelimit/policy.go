@@ -0,0 +1,137 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+// defaultProvider is the resource provider of requests which do not target a provider, e.g. resource groups.
+const defaultProvider = "microsoft.resources"
+
+// remainingHeaders lists the response headers ARM uses to report the requests remaining for each operation.
+var remainingHeaders = map[Operation][]string{
+	Read:   {"x-ms-ratelimit-remaining-subscription-reads", "x-ms-ratelimit-remaining-subscription-global-reads"},
+	Write:  {"x-ms-ratelimit-remaining-subscription-writes", "x-ms-ratelimit-remaining-subscription-global-writes"},
+	Delete: {"x-ms-ratelimit-remaining-subscription-deletes", "x-ms-ratelimit-remaining-subscription-global-deletes"},
+}
+
+// Policy delays ARM requests according to a Budgeter and feeds ARM's rate limit response headers back into it.
+// It implements the policy.Policy interface.
+type Policy struct {
+	Budgeter *Budgeter
+}
+
+// Do waits for the budget of the request's subscription, provider and operation before sending it.
+func (p Policy) Do(req *policy.Request) (*http.Response, error) {
+	key, ok := KeyForRequest(req.Raw())
+	if !ok {
+		return req.Next()
+	}
+
+	if err := p.Budgeter.Wait(req.Raw().Context(), key); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Next()
+	if resp == nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		p.Budgeter.Throttle(key, retryAfter(resp.Header))
+	}
+	if remaining, ok := remainingFromHeaders(resp.Header, key.Operation); ok {
+		p.Budgeter.Observe(key, remaining)
+	}
+
+	return resp, err
+}
+
+// KeyForRequest returns the budget key of an ARM request. It returns false for requests which are not scoped to
+// a subscription.
+func KeyForRequest(req *http.Request) (Key, bool) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	key := Key{Provider: defaultProvider, Operation: operationForMethod(req.Method)}
+	for i := 0; i < len(segments)-1; i++ {
+		switch strings.ToLower(segments[i]) {
+		case "subscriptions":
+			if key.SubscriptionID == "" {
+				key.SubscriptionID = strings.ToLower(segments[i+1])
+			}
+		case "providers":
+			if key.SubscriptionID != "" && key.Provider == defaultProvider {
+				key.Provider = strings.ToLower(segments[i+1])
+			}
+		}
+	}
+	return key, key.SubscriptionID != ""
+}
+
+func operationForMethod(method string) Operation {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return Read
+	case http.MethodDelete:
+		return Delete
+	default:
+		return Write
+	}
+}
+
+// remainingFromHeaders returns the lowest number of remaining requests reported for op.
+func remainingFromHeaders(header http.Header, op Operation) (int, bool) {
+	remaining, found := 0, false
+	for _, name := range remainingHeaders[op] {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		if !found || n < remaining {
+			remaining, found = n, true
+		}
+	}
+	return remaining, found
+}
+
+// retryAfter returns the duration of a Retry-After header, or a default if there is no meaningful value. A
+// Retry-After in the past, e.g. because of clock skew, still throttles for the default duration.
+func retryAfter(header http.Header) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		var d time.Duration
+		if seconds, err := strconv.Atoi(value); err == nil {
+			d = time.Duration(seconds) * time.Second
+		} else if t, err := http.ParseTime(value); err == nil {
+			d = time.Until(t)
+		}
+		if d > 0 {
+			return d
+		}
+	}
+	return reconciler.DefaultReconcilerRequeue
+}
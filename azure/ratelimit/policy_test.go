@@ -0,0 +1,178 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+func TestKeyForRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   Key
+		wantOK bool
+	}{
+		{
+			name:   "virtual machine get",
+			method: http.MethodGet,
+			path:   "/subscriptions/SUB/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm",
+			want:   Key{SubscriptionID: "sub", Provider: "microsoft.compute", Operation: Read},
+			wantOK: true,
+		},
+		{
+			name:   "nested provider uses the first provider",
+			method: http.MethodPut,
+			path:   "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateDnsZones/zone/providers/Microsoft.Resources/tags/default",
+			want:   Key{SubscriptionID: "sub", Provider: "microsoft.network", Operation: Write},
+			wantOK: true,
+		},
+		{
+			name:   "resource group delete",
+			method: http.MethodDelete,
+			path:   "/subscriptions/sub/resourcegroups/rg",
+			want:   Key{SubscriptionID: "sub", Provider: defaultProvider, Operation: Delete},
+			wantOK: true,
+		},
+		{
+			name:   "tenant-level request",
+			method: http.MethodGet,
+			path:   "/providers/Microsoft.Compute/operations",
+			wantOK: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			req := &http.Request{Method: tc.method, URL: &url.URL{Path: tc.path}}
+			got, ok := KeyForRequest(req)
+			g.Expect(ok).To(Equal(tc.wantOK))
+			if tc.wantOK {
+				g.Expect(got).To(Equal(tc.want))
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{
+			name:  "seconds",
+			value: "20",
+			want:  20 * time.Second,
+		},
+		{
+			name:  "no header",
+			value: "",
+			want:  reconciler.DefaultReconcilerRequeue,
+		},
+		{
+			name:  "invalid value",
+			value: "soon",
+			want:  reconciler.DefaultReconcilerRequeue,
+		},
+		{
+			name:  "zero seconds",
+			value: "0",
+			want:  reconciler.DefaultReconcilerRequeue,
+		},
+		{
+			name:  "HTTP date in the past",
+			value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat),
+			want:  reconciler.DefaultReconcilerRequeue,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			header := http.Header{}
+			if tc.value != "" {
+				header.Set("Retry-After", tc.value)
+			}
+			g.Expect(retryAfter(header)).To(Equal(tc.want))
+		})
+	}
+
+	t.Run("HTTP date in the future", func(t *testing.T) {
+		g := NewWithT(t)
+		header := http.Header{}
+		header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		g.Expect(retryAfter(header)).To(BeNumerically("~", time.Hour, time.Minute))
+	})
+}
+
+func TestPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("x-ms-ratelimit-remaining-subscription-reads", "5")
+			w.Header().Set("x-ms-ratelimit-remaining-subscription-global-reads", "3")
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.Header().Set("Retry-After", "20")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	b, _ := newTestBudgeter(100, 1)
+	pipeline := runtime.NewPipeline("testmodule", "v0.1.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+		PerCallPolicies: []policy.Policy{Policy{Budgeter: b}},
+		Retry:           policy.RetryOptions{MaxRetries: -1},
+	})
+	path := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm"
+
+	req, err := runtime.NewRequest(t.Context(), http.MethodGet, server.URL+path)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp, err := pipeline.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+
+	// The lowest remaining count reported by ARM limits the budget.
+	readKey := Key{SubscriptionID: "sub", Provider: "microsoft.compute", Operation: Read}
+	g.Expect(b.buckets[readKey].tokens).To(Equal(float64(3)))
+
+	req, err = runtime.NewRequest(t.Context(), http.MethodPut, server.URL+path)
+	g.Expect(err).NotTo(HaveOccurred())
+	resp, err = pipeline.Do(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+
+	// A throttled request blocks the budget for the Retry-After duration.
+	writeKey := Key{SubscriptionID: "sub", Provider: "microsoft.compute", Operation: Write}
+	g.Expect(b.take(writeKey)).To(Equal(20 * time.Second))
+	// Other operations are not affected.
+	g.Expect(b.take(readKey)).To(BeZero())
+}
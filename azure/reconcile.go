@@ -18,8 +18,11 @@ package azure
 
 import (
 	"context"
+	"time"
 
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
 )
 
 // ResourceReconciler creates, updates, and deletes individual Azure resources.
@@ -83,3 +86,37 @@ func DeleteAll(
 	updater.UpdateDeleteStatus(conditionType, serviceName, result)
 	return result
 }
+
+// ReconcileService calls Reconcile on service and records its duration and error in the service metrics.
+func ReconcileService(ctx context.Context, service ServiceReconciler) error {
+	start := time.Now()
+	err := service.Reconcile(ctx)
+	ObserveService(service.Name(), metrics.OperationReconcile, start, err)
+	return err
+}
+
+// DeleteService calls Delete on service and records its duration and error in the service metrics.
+func DeleteService(ctx context.Context, service ServiceReconciler) error {
+	start := time.Now()
+	err := service.Delete(ctx)
+	ObserveService(service.Name(), metrics.OperationDelete, start, err)
+	return err
+}
+
+// PauseService calls Pause on pauser, the service with the given name, and records its duration and error
+// in the service metrics.
+func PauseService(ctx context.Context, name string, pauser Pauser) error {
+	start := time.Now()
+	err := pauser.Pause(ctx)
+	ObserveService(name, metrics.OperationPause, start, err)
+	return err
+}
+
+// ObserveService records the duration of an operation of service which started at start in the service metrics, and
+// the error it returned unless it only reports a long-running operation which is not done yet.
+func ObserveService(service, operation string, start time.Time, err error) {
+	if IsOperationNotDoneError(err) {
+		err = nil
+	}
+	metrics.ObserveService(service, operation, start, err)
+}
@@ -19,13 +19,16 @@ package azure_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/mock/gomock"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_reconciler"
 )
@@ -194,3 +197,32 @@ func TestDeleteAll(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveService(t *testing.T) {
+	g := NewWithT(t)
+
+	azure.ObserveService("test-observe-not-done", metrics.OperationReconcile, time.Now(), azure.WithTransientError(opNotDoneErr, time.Second))
+	azure.ObserveService("test-observe-not-done", metrics.OperationReconcile, time.Now(), errFoo)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.Collectors()...)
+	families, err := registry.Gather()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var errorTypes []string
+	for _, family := range families {
+		if family.GetName() != "capz_service_errors_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["service"] == "test-observe-not-done" {
+				errorTypes = append(errorTypes, labels["type"])
+			}
+		}
+	}
+	g.Expect(errorTypes).To(ConsistOf(metrics.ErrorTypeOther))
+}
@@ -60,3 +60,32 @@ func (a *baseURIAdapter) BaseURI() string {
 	sansScheme := path.Join(fmt.Sprintf("%s.%s", a.Region, a.parsedURL.Host), a.parsedURL.Path)
 	return fmt.Sprintf("%s://%s", a.parsedURL.Scheme, sansScheme)
 }
+
+// CustomBaseURIAuthorizer is an Authorizer whose requests are sent to its BaseURI instead of the Resource Manager
+// endpoint of its cloud. It can only be created with WithBaseURI.
+type CustomBaseURIAuthorizer interface {
+	Authorizer
+	customBaseURI()
+}
+
+// customBaseURIAdapter wraps an azure.Authorizer and replaces its BaseURI.
+type customBaseURIAdapter struct {
+	aliasAuth
+	baseURI string
+}
+
+// WithBaseURI returns an authorizer whose requests are sent to baseURI instead of the Resource Manager endpoint of
+// its cloud, e.g. to direct them to a local fake Resource Manager in tests.
+func WithBaseURI(authorizer Authorizer, baseURI string) CustomBaseURIAuthorizer {
+	return &customBaseURIAdapter{
+		aliasAuth: authorizer,
+		baseURI:   baseURI,
+	}
+}
+
+// BaseURI returns the custom base URI.
+func (a *customBaseURIAdapter) BaseURI() string {
+	return a.baseURI
+}
+
+func (a *customBaseURIAdapter) customBaseURI() {}
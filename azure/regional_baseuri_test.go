@@ -72,3 +72,15 @@ func TestWithRegionalBaseURI(t *testing.T) {
 		})
 	}
 }
+
+func TestWithBaseURI(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	authMock := mock_azure.NewMockAuthorizer(mockCtrl)
+	authMock.EXPECT().SubscriptionID().Return("123")
+
+	auth := WithBaseURI(authMock, "http://127.0.0.1:8080")
+	g.Expect(auth.BaseURI()).To(Equal("http://127.0.0.1:8080"))
+	g.Expect(auth.SubscriptionID()).To(Equal("123"))
+}
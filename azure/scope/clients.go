@@ -79,6 +79,11 @@ func (c *AzureClients) Token() azcore.TokenCredential {
 	return c.TokenCredential
 }
 
+// BaseURI returns the Azure Resource Manager endpoint.
+func (c *AzureClients) BaseURI() string {
+	return c.ResourceManagerEndpoint
+}
+
 // HashKey returns a base64 url encoded sha256 hash for the Auth scope (Azure TenantID + CloudEnv + SubscriptionID +
 // ClientID).
 func (c *AzureClients) HashKey() string {
@@ -164,6 +169,8 @@ func (c *AzureClients) getSettingsFromEnvironment(environmentName string) error
 	c.activeDirectoryEndpoint = cloudConfig.ActiveDirectoryAuthorityHost
 	c.ResourceManagerEndpoint = cloudConfig.Services[cloud.ResourceManager].Endpoint
 	c.tokenAudience = cloudConfig.Services[cloud.ResourceManager].Audience
+	setValue(&c.ResourceManagerEndpoint, azure.ResourceManagerEndpointEnvVar)
+	setValue(&c.tokenAudience, azure.ResourceManagerAudienceEnvVar)
 
 	return nil
 }
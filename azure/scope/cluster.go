@@ -21,15 +21,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"net/url"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	asonetworkv1api20201101 "github.com/Azure/azure-service-operator/v2/api/network/v1api20201101"
 	asonetworkv1api20220701 "github.com/Azure/azure-service-operator/v2/api/network/v1api20220701"
 	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/net"
 	"k8s.io/utils/ptr"
@@ -41,6 +44,10 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
@@ -55,6 +62,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	apiinternal "sigs.k8s.io/cluster-api-provider-azure/internal/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/aso"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -92,6 +100,11 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		return nil, errors.Wrap(err, "failed to configure azure settings and credentials for Identity")
 	}
 
+	subscriptionClients, err := newSubscriptionClients(ctx, params, credentialsProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	if params.Cache == nil {
 		params.Cache = &ClusterCache{}
 	}
@@ -102,13 +115,14 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 	}
 
 	return &ClusterScope{
-		Client:          params.Client,
-		AzureClients:    params.AzureClients,
-		Cluster:         params.Cluster,
-		AzureCluster:    params.AzureCluster,
-		patchHelper:     helper,
-		cache:           params.Cache,
-		AsyncReconciler: params.Timeouts,
+		Client:              params.Client,
+		AzureClients:        params.AzureClients,
+		subscriptionClients: subscriptionClients,
+		Cluster:             params.Cluster,
+		AzureCluster:        params.AzureCluster,
+		patchHelper:         helper,
+		cache:               params.Cache,
+		AsyncReconciler:     params.Timeouts,
 	}, nil
 }
 
@@ -117,6 +131,9 @@ type ClusterScope struct {
 	Client      client.Client
 	patchHelper *v1beta1patch.Helper
 	cache       *ClusterCache
+	// subscriptionClients are the Azure clients used to manage the network resources in other subscriptions than the
+	// subscription of the cluster, indexed by subscription ID.
+	subscriptionClients map[string]*AzureClients
 
 	AzureClients
 	Cluster      *clusterv1.Cluster
@@ -124,6 +141,58 @@ type ClusterScope struct {
 	azure.AsyncReconciler
 }
 
+// newSubscriptionClients returns the Azure clients used to manage the network resources of the cluster which are in
+// another subscription than the cluster: the virtual network, the remote virtual networks of its peerings, the hub
+// virtual network and the existing private DNS zone. Each subscription is managed with the identity referenced
+// alongside it, or with the identity of the cluster.
+func newSubscriptionClients(ctx context.Context, params ClusterScopeParams, credentialsProvider CredentialsProvider) (map[string]*AzureClients, error) {
+	networkSpec := params.AzureCluster.Spec.NetworkSpec
+	type placement struct {
+		subscriptionID string
+		identityRef    *corev1.ObjectReference
+	}
+	placements := []placement{{networkSpec.Vnet.SubscriptionID, networkSpec.Vnet.IdentityRef}}
+	for _, peering := range networkSpec.Vnet.Peerings {
+		placements = append(placements, placement{peering.SubscriptionID, peering.IdentityRef})
+	}
+	if hub := networkSpec.Vnet.Hub; hub != nil {
+		placements = append(placements, placement{hub.SubscriptionID, hub.IdentityRef})
+	}
+	if zoneID, err := arm.ParseResourceID(networkSpec.PrivateDNSZoneID); err == nil {
+		placements = append(placements, placement{zoneID.SubscriptionID, networkSpec.PrivateDNSZoneIdentityRef})
+	}
+
+	// The resources in a subscription are managed with the identity referenced by any of them, or with the identity of
+	// the cluster if none of them references one.
+	identityRefs := make(map[string]*corev1.ObjectReference)
+	for _, p := range placements {
+		if p.subscriptionID == "" || p.subscriptionID == params.AzureClients.SubscriptionID() {
+			continue
+		}
+		if identityRef, ok := identityRefs[p.subscriptionID]; !ok || identityRef == nil {
+			identityRefs[p.subscriptionID] = p.identityRef
+		}
+	}
+
+	subscriptionClients := make(map[string]*AzureClients, len(identityRefs))
+	for subscriptionID, identityRef := range identityRefs {
+		provider := credentialsProvider
+		if identityRef != nil {
+			var err error
+			provider, err = NewAzureCredentialsProvider(ctx, params.CredentialCache, params.Client, identityRef, params.AzureCluster.Namespace)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to init credentials provider for subscription %s", subscriptionID)
+			}
+		}
+		clients := &AzureClients{}
+		if err := clients.setCredentialsWithProvider(ctx, subscriptionID, params.AzureCluster.Spec.AzureEnvironment, provider); err != nil {
+			return nil, errors.Wrapf(err, "failed to configure azure settings and credentials for subscription %s", subscriptionID)
+		}
+		subscriptionClients[subscriptionID] = clients
+	}
+	return subscriptionClients, nil
+}
+
 // ClusterCache stores ClusterCache data locally so we don't have to hit the API multiple times within the same reconcile loop.
 type ClusterCache struct {
 	isVnetManaged *bool
@@ -202,8 +271,8 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 				Name:             ip.PublicIP.Name,
 				ResourceGroup:    s.ResourceGroup(),
 				ClusterName:      s.ClusterName(),
-				DNSName:          "",    // Set to default value
-				IsIPv6:           false, // Set to default value
+				DNSName:          "", // Set to default value
+				IsIPv6:           ip.PublicIP.IsIPv6(),
 				Location:         s.Location(),
 				ExtendedLocation: s.ExtendedLocation(),
 				FailureDomains:   failureDomains,
@@ -247,6 +316,36 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 		publicIPSpecs = append(publicIPSpecs, azureBastionPublicIP)
 	}
 
+	if ingress := s.Ingress(); ingress != nil {
+		// public IP for the ingress application gateway.
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:           ingress.PublicIP.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			DNSName:        ingress.PublicIP.DNSName,
+			IsIPv6:         false, // Public IP is IPv4 by default
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			FailureDomains: s.FailureDomains(),
+			AdditionalTags: s.AdditionalTags(),
+			IPTags:         ingress.PublicIP.IPTags,
+		})
+	}
+
+	if firewall := s.Firewall(); firewall != nil {
+		// public IP for the egress Azure Firewall.
+		publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
+			Name:           firewall.PublicIP.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			DNSName:        firewall.PublicIP.DNSName,
+			IsIPv6:         false, // Public IP is IPv4 by default
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			FailureDomains: s.FailureDomains(),
+			AdditionalTags: s.AdditionalTags(),
+			IPTags:         firewall.PublicIP.IPTags,
+		})
+	}
+
 	return publicIPSpecs
 }
 
@@ -275,6 +374,10 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			AdditionalTags:        s.AdditionalTags(),
 			AdditionalPorts:       s.AdditionalAPIServerLBPorts(),
 			AvailabilityZones:     s.APIServerLB().AvailabilityZones,
+			VNetSubscriptionID:    s.Vnet().SubscriptionID,
+			AdditionalPools:       s.APIServerLB().AdditionalBackendPools,
+			Probes:                s.APIServerLB().Probes,
+			Rules:                 s.APIServerLB().Rules,
 		}
 
 		if s.APIServerLB().FrontendIPs != nil {
@@ -311,6 +414,7 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			AdditionalTags:        s.AdditionalTags(),
 			AdditionalPorts:       s.AdditionalAPIServerLBPorts(),
 			AvailabilityZones:     s.APIServerLB().AvailabilityZones,
+			VNetSubscriptionID:    s.Vnet().SubscriptionID,
 		}
 
 		privateIPFound := false
@@ -359,6 +463,10 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			Role:                 infrav1.NodeOutboundRole,
 			AdditionalTags:       s.AdditionalTags(),
 			AvailabilityZones:    s.NodeOutboundLB().AvailabilityZones,
+			AdditionalPools:      s.NodeOutboundLB().AdditionalBackendPools,
+			Probes:               s.NodeOutboundLB().Probes,
+			Rules:                s.NodeOutboundLB().Rules,
+			IPv6BackendPoolName:  s.OutboundIPv6PoolName(infrav1.Node),
 		})
 	}
 
@@ -387,32 +495,96 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 	return specs
 }
 
+// PrivateLinkServiceSpec returns the spec of the private link service in front of the internal API server load
+// balancer, or nil if the API server load balancer has no private link service.
+func (s *ClusterScope) PrivateLinkServiceSpec() azure.ResourceSpecGetter {
+	if s.APIServerLB() == nil || s.APIServerLB().PrivateLinkService == nil {
+		return nil
+	}
+	privateLinkService := s.APIServerLB().PrivateLinkService
+	for _, spec := range s.LBSpecs() {
+		lbSpec, ok := spec.(*loadbalancers.LBSpec)
+		if !ok || lbSpec.Type != infrav1.Internal || len(lbSpec.FrontendIPConfigs) == 0 ||
+			(lbSpec.Role != infrav1.APIServerRole && lbSpec.Role != infrav1.APIServerRoleInternal) {
+			continue
+		}
+		return &loadbalancers.PrivateLinkServiceSpec{
+			Name:                      privateLinkService.Name,
+			ResourceGroup:             s.ResourceGroup(),
+			SubscriptionID:            s.SubscriptionID(),
+			Location:                  s.Location(),
+			ExtendedLocation:          s.ExtendedLocation(),
+			ClusterName:               s.ClusterName(),
+			LoadBalancerName:          lbSpec.Name,
+			FrontendIPConfigName:      lbSpec.FrontendIPConfigs[0].Name,
+			SubnetID:                  azure.SubnetID(s.VnetSubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, lbSpec.SubnetName),
+			AllowedSubscriptions:      privateLinkService.AllowedSubscriptions,
+			AutoApprovedSubscriptions: privateLinkService.AutoApprovedSubscriptions,
+			AdditionalTags:            s.AdditionalTags(),
+		}
+	}
+	return nil
+}
+
+// SetPrivateLinkServiceID sets the ID of the private link service of the API server load balancer in the AzureCluster
+// status.
+func (s *ClusterScope) SetPrivateLinkServiceID(id string) {
+	s.AzureCluster.Status.PrivateLinkServiceID = id
+}
+
 // RouteTableSpecs returns the subnet route tables.
 func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
 	// Multiple subnets may reference the same route table (e.g. the control
 	// plane and node subnets both share the node route table), so de-duplicate
 	// by name to avoid reconciling the same route table more than once.
-	seen := make(map[string]struct{})
+	seen := make(map[string]*routetables.RouteTableSpec)
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		if subnet.RouteTable.Name != "" {
-			if _, ok := seen[subnet.RouteTable.Name]; ok {
-				continue
+			spec, ok := seen[subnet.RouteTable.Name]
+			if !ok {
+				spec = &routetables.RouteTableSpec{
+					Name:           subnet.RouteTable.Name,
+					Location:       s.Location(),
+					ResourceGroup:  s.Vnet().ResourceGroup,
+					ClusterName:    s.ClusterName(),
+					AdditionalTags: s.AdditionalTags(),
+				}
+				seen[subnet.RouteTable.Name] = spec
+				specs = append(specs, spec)
+			}
+			// Node egress goes through the Azure Firewall once its private IP is known. The webhook only allows a
+			// cluster subnet with a firewall when the API server load balancer is internal, so that the replies of
+			// the control plane nodes sharing it do not go through the firewall.
+			if s.Firewall() != nil && (subnet.Role == infrav1.SubnetNode || subnet.Role == infrav1.SubnetCluster) {
+				spec.DefaultRouteNextHopIP = s.AzureCluster.Status.FirewallPrivateIP
 			}
-			seen[subnet.RouteTable.Name] = struct{}{}
-			specs = append(specs, &routetables.RouteTableSpec{
-				Name:           subnet.RouteTable.Name,
-				Location:       s.Location(),
-				ResourceGroup:  s.Vnet().ResourceGroup,
-				ClusterName:    s.ClusterName(),
-				AdditionalTags: s.AdditionalTags(),
-			})
 		}
 	}
 
 	return specs
 }
 
+// ApplicationSecurityGroupSpecs returns the application security groups of the control plane and node roles when
+// application security groups are enabled.
+func (s *ClusterScope) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter {
+	if !s.ApplicationSecurityGroupsEnabled() {
+		return []azure.ResourceSpecGetter{}
+	}
+	specs := make([]azure.ResourceSpecGetter, 0, 2)
+	for _, role := range []string{infrav1.ControlPlane, infrav1.Node} {
+		specs = append(specs, &applicationsecuritygroups.ASGSpec{
+			Name:           azure.GenerateApplicationSecurityGroupName(s.ClusterName(), role),
+			ResourceGroup:  s.ApplicationSecurityGroupResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			Role:           role,
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
 // NatGatewaySpecs returns the node NAT gateway.
 func (s *ClusterScope) NatGatewaySpecs() []azure.ASOResourceSpecGetter[*asonetworkv1api20220701.NatGateway] {
 	natGatewaySet := make(map[string]struct{})
@@ -451,17 +623,29 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 		nsgspecs[i] = &securitygroups.NSGSpec{
 			Name:                     subnet.SecurityGroup.Name,
 			SecurityRules:            subnet.SecurityGroup.SecurityRules,
+			SecurityRuleSets:         subnet.SecurityGroup.SecurityRuleSets,
 			ResourceGroup:            s.Vnet().ResourceGroup,
 			Location:                 s.Location(),
 			ClusterName:              s.ClusterName(),
 			AdditionalTags:           s.AdditionalTags(),
 			LastAppliedSecurityRules: s.getLastAppliedSecurityRules(subnet.SecurityGroup.Name),
+			SubscriptionID:           s.VnetSubscriptionID(),
+			ASGResourceGroup:         s.ApplicationSecurityGroupResourceGroup(),
 		}
 	}
 
 	return nsgspecs
 }
 
+// SecurityRuleSet returns the AzureSecurityRuleSet with the given name in the namespace of the AzureCluster.
+func (s *ClusterScope) SecurityRuleSet(ctx context.Context, name string) (*infrav1.AzureSecurityRuleSet, error) {
+	ruleSet := &infrav1.AzureSecurityRuleSet{}
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: s.Namespace(), Name: name}, ruleSet); err != nil {
+		return nil, err
+	}
+	return ruleSet, nil
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1api20201101.VirtualNetworksSubnet] {
 	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
@@ -473,17 +657,24 @@ func (s *ClusterScope) SubnetSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1
 
 	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
 		subnetSpec := &subnets.SubnetSpec{
-			Name:              subnet.Name,
-			ResourceGroup:     s.ResourceGroup(),
-			SubscriptionID:    s.SubscriptionID(),
-			CIDRs:             subnet.CIDRBlocks,
-			VNetName:          s.Vnet().Name,
-			VNetResourceGroup: s.Vnet().ResourceGroup,
-			IsVNetManaged:     s.IsVnetManaged(),
-			RouteTableName:    subnet.RouteTable.Name,
-			SecurityGroupName: subnet.SecurityGroup.Name,
-			NatGatewayName:    subnet.NatGateway.Name,
-			ServiceEndpoints:  subnet.ServiceEndpoints,
+			Name:               subnet.Name,
+			ResourceGroup:      s.ResourceGroup(),
+			SubscriptionID:     s.SubscriptionID(),
+			CIDRs:              subnet.CIDRBlocks,
+			VNetName:           s.Vnet().Name,
+			VNetResourceGroup:  s.Vnet().ResourceGroup,
+			IsVNetManaged:      s.IsVnetManaged(),
+			RouteTableName:     subnet.RouteTable.Name,
+			SecurityGroupName:  subnet.SecurityGroup.Name,
+			NatGatewayName:     subnet.NatGateway.Name,
+			ServiceEndpoints:   subnet.ServiceEndpoints,
+			VNetSubscriptionID: s.Vnet().SubscriptionID,
+			ASOSecretName:      s.vnetASOSecretName(),
+		}
+		// The private link service of the API server load balancer translates the source IP of its connections to
+		// private IPs of the control plane subnet.
+		if subnet.Name == s.ControlPlaneSubnet().Name && s.PrivateLinkServiceSpec() != nil {
+			subnetSpec.DisablePrivateLinkServiceNetworkPolicies = true
 		}
 		subnetSpecs = append(subnetSpecs, subnetSpec)
 	}
@@ -491,16 +682,51 @@ func (s *ClusterScope) SubnetSpecs() []azure.ASOResourceSpecGetter[*asonetworkv1
 	if s.IsAzureBastionEnabled() {
 		azureBastionSubnet := s.AzureCluster.Spec.BastionSpec.AzureBastion.Subnet
 		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
-			Name:              azureBastionSubnet.Name,
-			ResourceGroup:     s.ResourceGroup(),
-			SubscriptionID:    s.SubscriptionID(),
-			CIDRs:             azureBastionSubnet.CIDRBlocks,
-			VNetName:          s.Vnet().Name,
-			VNetResourceGroup: s.Vnet().ResourceGroup,
-			IsVNetManaged:     s.IsVnetManaged(),
-			SecurityGroupName: azureBastionSubnet.SecurityGroup.Name,
-			RouteTableName:    azureBastionSubnet.RouteTable.Name,
-			ServiceEndpoints:  azureBastionSubnet.ServiceEndpoints,
+			Name:               azureBastionSubnet.Name,
+			ResourceGroup:      s.ResourceGroup(),
+			SubscriptionID:     s.SubscriptionID(),
+			CIDRs:              azureBastionSubnet.CIDRBlocks,
+			VNetName:           s.Vnet().Name,
+			VNetResourceGroup:  s.Vnet().ResourceGroup,
+			IsVNetManaged:      s.IsVnetManaged(),
+			SecurityGroupName:  azureBastionSubnet.SecurityGroup.Name,
+			RouteTableName:     azureBastionSubnet.RouteTable.Name,
+			ServiceEndpoints:   azureBastionSubnet.ServiceEndpoints,
+			VNetSubscriptionID: s.Vnet().SubscriptionID,
+			ASOSecretName:      s.vnetASOSecretName(),
+		})
+	}
+
+	if ingress := s.Ingress(); ingress != nil {
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:               ingress.Subnet.Name,
+			ResourceGroup:      s.ResourceGroup(),
+			SubscriptionID:     s.SubscriptionID(),
+			CIDRs:              ingress.Subnet.CIDRBlocks,
+			VNetName:           s.Vnet().Name,
+			VNetResourceGroup:  s.Vnet().ResourceGroup,
+			IsVNetManaged:      s.IsVnetManaged(),
+			SecurityGroupName:  ingress.Subnet.SecurityGroup.Name,
+			RouteTableName:     ingress.Subnet.RouteTable.Name,
+			ServiceEndpoints:   ingress.Subnet.ServiceEndpoints,
+			VNetSubscriptionID: s.Vnet().SubscriptionID,
+			ASOSecretName:      s.vnetASOSecretName(),
+		})
+	}
+
+	if firewall := s.Firewall(); firewall != nil {
+		// Azure Firewall does not support a network security group or a route table on its subnet.
+		subnetSpecs = append(subnetSpecs, &subnets.SubnetSpec{
+			Name:               firewall.Subnet.Name,
+			ResourceGroup:      s.ResourceGroup(),
+			SubscriptionID:     s.SubscriptionID(),
+			CIDRs:              firewall.Subnet.CIDRBlocks,
+			VNetName:           s.Vnet().Name,
+			VNetResourceGroup:  s.Vnet().ResourceGroup,
+			IsVNetManaged:      s.IsVnetManaged(),
+			ServiceEndpoints:   firewall.Subnet.ServiceEndpoints,
+			VNetSubscriptionID: s.Vnet().SubscriptionID,
+			ASOSecretName:      s.vnetASOSecretName(),
 		})
 	}
 
@@ -518,7 +744,8 @@ func (s *ClusterScope) GroupSpecs() []azure.ASOResourceSpecGetter[*asoresourcesv
 			AdditionalTags: s.AdditionalTags(),
 		},
 	}
-	if s.Vnet().ResourceGroup != "" && s.Vnet().ResourceGroup != s.ResourceGroup() {
+	// The resource group of a virtual network in another subscription must already exist.
+	if s.Vnet().ResourceGroup != "" && s.Vnet().ResourceGroup != s.ResourceGroup() && s.VnetSubscriptionID() == s.SubscriptionID() {
 		specs = append(specs, &groups.GroupSpec{
 			Name:           azure.GetNormalizedKubernetesName(s.Vnet().ResourceGroup),
 			AzureName:      s.Vnet().ResourceGroup,
@@ -534,17 +761,22 @@ func (s *ClusterScope) GroupSpecs() []azure.ASOResourceSpecGetter[*asoresourcesv
 func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	peeringSpecs := make([]azure.ResourceSpecGetter, 2*len(s.Vnet().Peerings))
 	for i, peering := range s.Vnet().Peerings {
+		remoteSubscriptionID := peering.SubscriptionID
+		if remoteSubscriptionID == "" {
+			remoteSubscriptionID = s.SubscriptionID()
+		}
 		forwardPeering := &vnetpeerings.VnetPeeringSpec{
 			PeeringName:               azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
 			SourceVnetName:            s.Vnet().Name,
 			SourceResourceGroup:       s.Vnet().ResourceGroup,
 			RemoteVnetName:            peering.RemoteVnetName,
 			RemoteResourceGroup:       peering.ResourceGroup,
-			SubscriptionID:            s.SubscriptionID(),
+			SubscriptionID:            remoteSubscriptionID,
 			AllowForwardedTraffic:     peering.ForwardPeeringProperties.AllowForwardedTraffic,
 			AllowGatewayTransit:       peering.ForwardPeeringProperties.AllowGatewayTransit,
 			AllowVirtualNetworkAccess: peering.ForwardPeeringProperties.AllowVirtualNetworkAccess,
 			UseRemoteGateways:         peering.ForwardPeeringProperties.UseRemoteGateways,
+			SourceSubscriptionID:      s.Vnet().SubscriptionID,
 		}
 		reversePeering := &vnetpeerings.VnetPeeringSpec{
 			PeeringName:               azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
@@ -552,19 +784,96 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 			SourceResourceGroup:       peering.ResourceGroup,
 			RemoteVnetName:            s.Vnet().Name,
 			RemoteResourceGroup:       s.Vnet().ResourceGroup,
-			SubscriptionID:            s.SubscriptionID(),
+			SubscriptionID:            s.VnetSubscriptionID(),
 			AllowForwardedTraffic:     peering.ReversePeeringProperties.AllowForwardedTraffic,
 			AllowGatewayTransit:       peering.ReversePeeringProperties.AllowGatewayTransit,
 			AllowVirtualNetworkAccess: peering.ReversePeeringProperties.AllowVirtualNetworkAccess,
 			UseRemoteGateways:         peering.ReversePeeringProperties.UseRemoteGateways,
+			SourceSubscriptionID:      peering.SubscriptionID,
 		}
 		peeringSpecs[i*2] = forwardPeering
 		peeringSpecs[i*2+1] = reversePeering
 	}
 
+	if hub := s.Hub(); hub != nil {
+		peeringSpecs = append(peeringSpecs,
+			&vnetpeerings.VnetPeeringSpec{
+				PeeringName:               azure.GenerateVnetPeeringName(s.Vnet().Name, hub.Name),
+				SourceVnetName:            s.Vnet().Name,
+				SourceResourceGroup:       s.Vnet().ResourceGroup,
+				RemoteVnetName:            hub.Name,
+				RemoteResourceGroup:       hub.ResourceGroup,
+				SubscriptionID:            s.HubSubscriptionID(),
+				AllowForwardedTraffic:     hub.ForwardPeeringProperties.AllowForwardedTraffic,
+				AllowGatewayTransit:       hub.ForwardPeeringProperties.AllowGatewayTransit,
+				AllowVirtualNetworkAccess: hub.ForwardPeeringProperties.AllowVirtualNetworkAccess,
+				UseRemoteGateways:         hub.ForwardPeeringProperties.UseRemoteGateways,
+				SourceSubscriptionID:      s.Vnet().SubscriptionID,
+			},
+			&vnetpeerings.VnetPeeringSpec{
+				PeeringName:               azure.GenerateVnetPeeringName(hub.Name, s.Vnet().Name),
+				SourceVnetName:            hub.Name,
+				SourceResourceGroup:       hub.ResourceGroup,
+				RemoteVnetName:            s.Vnet().Name,
+				RemoteResourceGroup:       s.Vnet().ResourceGroup,
+				SubscriptionID:            s.VnetSubscriptionID(),
+				AllowForwardedTraffic:     hub.ReversePeeringProperties.AllowForwardedTraffic,
+				AllowGatewayTransit:       hub.ReversePeeringProperties.AllowGatewayTransit,
+				AllowVirtualNetworkAccess: hub.ReversePeeringProperties.AllowVirtualNetworkAccess,
+				UseRemoteGateways:         hub.ReversePeeringProperties.UseRemoteGateways,
+				SourceSubscriptionID:      hub.SubscriptionID,
+			},
+		)
+	}
+
 	return peeringSpecs
 }
 
+// Hub returns the hub virtual network of the cluster, or nil if the cluster is not part of a hub-and-spoke topology.
+func (s *ClusterScope) Hub() *infrav1.HubVnetSpec {
+	return s.Vnet().Hub
+}
+
+// HubSubscriptionID returns the ID of the subscription of the hub virtual network.
+func (s *ClusterScope) HubSubscriptionID() string {
+	if hub := s.Hub(); hub != nil && hub.SubscriptionID != "" {
+		return hub.SubscriptionID
+	}
+	return s.SubscriptionID()
+}
+
+// AuthorizerForSubscription returns the authorizer used to manage the resources in the given subscription.
+func (s *ClusterScope) AuthorizerForSubscription(subscriptionID string) azure.Authorizer {
+	if clients, ok := s.subscriptionClients[subscriptionID]; ok {
+		return clients
+	}
+	return s
+}
+
+// NetworkAuthorizer returns the authorizer used to manage the network security groups and route tables of the
+// virtual network.
+func (s *ClusterScope) NetworkAuthorizer() azure.Authorizer {
+	return s.AuthorizerForSubscription(s.VnetSubscriptionID())
+}
+
+// VnetSubscriptionID returns the ID of the subscription of the virtual network.
+func (s *ClusterScope) VnetSubscriptionID() string {
+	if s.Vnet().SubscriptionID != "" {
+		return s.Vnet().SubscriptionID
+	}
+	return s.SubscriptionID()
+}
+
+// ApplicationSecurityGroupResourceGroup returns the resource group of the application security groups of the cluster.
+// They are in the resource group of the virtual network when it is in another subscription, so that its network
+// security groups can reference them.
+func (s *ClusterScope) ApplicationSecurityGroupResourceGroup() string {
+	if s.VnetSubscriptionID() != s.SubscriptionID() {
+		return s.Vnet().ResourceGroup
+	}
+	return s.ResourceGroup()
+}
+
 // VNetSpec returns the virtual network spec.
 func (s *ClusterScope) VNetSpec() azure.ASOResourceSpecGetter[*asonetworkv1api20201101.VirtualNetwork] {
 	return &virtualnetworks.VNetSpec{
@@ -575,7 +884,18 @@ func (s *ClusterScope) VNetSpec() azure.ASOResourceSpecGetter[*asonetworkv1api20
 		Location:         s.Location(),
 		ClusterName:      s.ClusterName(),
 		AdditionalTags:   s.AdditionalTags(),
+		SubscriptionID:   s.Vnet().SubscriptionID,
+		ASOSecretName:    s.vnetASOSecretName(),
+	}
+}
+
+// vnetASOSecretName returns the name of the secret with the credentials ASO uses to manage the virtual network and its
+// subnets, or an empty string if they are managed with the credentials of the cluster.
+func (s *ClusterScope) vnetASOSecretName() string {
+	if s.VnetSubscriptionID() != s.SubscriptionID() {
+		return aso.GetASOVnetSecretName(s.ClusterName())
 	}
+	return ""
 }
 
 // PrivateDNSSpec returns the private dns zone spec.
@@ -591,12 +911,18 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
 		}
+		if existingZone := s.existingPrivateDNSZone(); existingZone != nil {
+			resourceGroup = existingZone.ResourceGroupName
+			zone.ResourceGroup = resourceGroup
+			zone.SubscriptionID = existingZone.SubscriptionID
+			zone.Existing = true
+		}
 
 		links := make([]azure.ResourceSpecGetter, 1+len(s.Vnet().Peerings))
 		links[0] = privatedns.LinkSpec{
 			Name:              azure.GenerateVNetLinkName(s.Vnet().Name),
 			ZoneName:          s.GetPrivateDNSZoneName(),
-			SubscriptionID:    s.SubscriptionID(),
+			SubscriptionID:    s.VnetSubscriptionID(),
 			VNetResourceGroup: s.Vnet().ResourceGroup,
 			VNetName:          s.Vnet().Name,
 			ResourceGroup:     resourceGroup,
@@ -604,10 +930,14 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 			AdditionalTags:    s.AdditionalTags(),
 		}
 		for i, peering := range s.Vnet().Peerings {
+			peeringSubscriptionID := peering.SubscriptionID
+			if peeringSubscriptionID == "" {
+				peeringSubscriptionID = s.SubscriptionID()
+			}
 			links[i+1] = privatedns.LinkSpec{
 				Name:              azure.GenerateVNetLinkName(peering.RemoteVnetName),
 				ZoneName:          s.GetPrivateDNSZoneName(),
-				SubscriptionID:    s.SubscriptionID(),
+				SubscriptionID:    peeringSubscriptionID,
 				VNetResourceGroup: peering.ResourceGroup,
 				VNetName:          peering.RemoteVnetName,
 				ResourceGroup:     resourceGroup,
@@ -615,11 +945,23 @@ func (s *ClusterScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, link
 				AdditionalTags:    s.AdditionalTags(),
 			}
 		}
+		if hub := s.Hub(); hub != nil {
+			links = append(links, privatedns.LinkSpec{
+				Name:              azure.GenerateVNetLinkName(hub.Name),
+				ZoneName:          s.GetPrivateDNSZoneName(),
+				SubscriptionID:    s.HubSubscriptionID(),
+				VNetResourceGroup: hub.ResourceGroup,
+				VNetName:          hub.Name,
+				ResourceGroup:     resourceGroup,
+				ClusterName:       s.ClusterName(),
+				AdditionalTags:    s.AdditionalTags(),
+			})
+		}
 
 		records := make([]azure.ResourceSpecGetter, 1)
 		records[0] = privatedns.RecordSpec{
 			Record: infrav1.AddressRecord{
-				Hostname: azure.PrivateAPIServerHostname,
+				Hostname: s.privateAPIServerHostname(),
 				IP:       s.APIServerPrivateIP(),
 			},
 			ZoneName:      s.GetPrivateDNSZoneName(),
@@ -642,27 +984,186 @@ func (s *ClusterScope) AzureBastion() *infrav1.AzureBastion {
 	return s.AzureCluster.Spec.BastionSpec.AzureBastion
 }
 
+// Ingress returns the ingress application gateway configuration of the cluster, or nil if ingress is not configured.
+func (s *ClusterScope) Ingress() *infrav1.IngressSpec {
+	return s.AzureCluster.Spec.NetworkSpec.Ingress
+}
+
+// ApplicationGatewaySpec returns the ingress application gateway spec, or nil if ingress is not configured.
+func (s *ClusterScope) ApplicationGatewaySpec() azure.ResourceSpecGetter {
+	ingress := s.Ingress()
+	if ingress == nil {
+		return nil
+	}
+	return &applicationgateways.ApplicationGatewaySpec{
+		Name:           ingress.Name,
+		ResourceGroup:  s.ResourceGroup(),
+		SubscriptionID: s.SubscriptionID(),
+		Location:       s.Location(),
+		ClusterName:    s.ClusterName(),
+		SKU:            ingress.SKU,
+		Capacity:       ingress.Capacity,
+		WAFPolicyID:    ingress.WAFPolicyID,
+		SubnetID:       azure.SubnetID(s.VnetSubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, ingress.Subnet.Name),
+		PublicIPID:     azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), ingress.PublicIP.Name),
+		AdditionalTags: s.AdditionalTags(),
+	}
+}
+
+// SetApplicationGatewayID sets the ID of the ingress application gateway in the AzureCluster status.
+func (s *ClusterScope) SetApplicationGatewayID(id string) {
+	s.AzureCluster.Status.ApplicationGatewayID = id
+}
+
+// Firewall returns the egress Azure Firewall configuration of the cluster, or nil if no firewall is configured.
+func (s *ClusterScope) Firewall() *infrav1.FirewallSpec {
+	return s.AzureCluster.Spec.NetworkSpec.Firewall
+}
+
+// AzureFirewallSpecs returns the specs of the firewall policy, its rule collection group and the Azure Firewall,
+// or nil if no firewall is configured.
+func (s *ClusterScope) AzureFirewallSpecs() (policySpec, ruleCollectionGroupSpec, firewallSpec azure.ResourceSpecGetter) {
+	firewall := s.Firewall()
+	if firewall == nil {
+		return nil, nil, nil
+	}
+
+	policySpec = &azurefirewalls.FirewallPolicySpec{
+		Name:           firewall.PolicyName,
+		ResourceGroup:  s.ResourceGroup(),
+		Location:       s.Location(),
+		ClusterName:    s.ClusterName(),
+		SKUTier:        firewall.SKUTier,
+		AdditionalTags: s.AdditionalTags(),
+	}
+
+	var sourceAddresses []string
+	for _, subnet := range s.NodeSubnets() {
+		sourceAddresses = append(sourceAddresses, subnet.CIDRBlocks...)
+	}
+	ruleCollectionGroup := &azurefirewalls.RuleCollectionGroupSpec{
+		Name:               azurefirewalls.DefaultRuleCollectionGroupName,
+		ResourceGroup:      s.ResourceGroup(),
+		FirewallPolicyName: firewall.PolicyName,
+		SourceAddresses:    sourceAddresses,
+		AzureEndpointFQDNs: s.azureEndpointFQDNs(),
+		AdditionalFQDNs:    firewall.AdditionalFQDNs,
+	}
+	if lb := s.APIServerLB(); lb != nil && lb.Type == infrav1.Public && len(lb.FrontendIPs) > 0 && lb.FrontendIPs[0].PublicIP != nil {
+		ruleCollectionGroup.APIServerFQDN = s.APIServerHost()
+		ruleCollectionGroup.APIServerPort = s.APIServerFrontendPort()
+	}
+
+	firewallSpec = &azurefirewalls.AzureFirewallSpec{
+		Name:             firewall.Name,
+		ResourceGroup:    s.ResourceGroup(),
+		Location:         s.Location(),
+		ClusterName:      s.ClusterName(),
+		SKUTier:          firewall.SKUTier,
+		FirewallPolicyID: azure.FirewallPolicyID(s.SubscriptionID(), s.ResourceGroup(), firewall.PolicyName),
+		SubnetID:         azure.SubnetID(s.VnetSubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, firewall.Subnet.Name),
+		PublicIPID:       azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), firewall.PublicIP.Name),
+		AdditionalTags:   s.AdditionalTags(),
+	}
+
+	return policySpec, ruleCollectionGroup, firewallSpec
+}
+
+// SetFirewallPrivateIP sets the private IP of the egress Azure Firewall in the AzureCluster status.
+func (s *ClusterScope) SetFirewallPrivateIP(ip string) {
+	s.AzureCluster.Status.FirewallPrivateIP = ip
+}
+
+// azureEndpointFQDNs returns the hosts of the Azure Resource Manager and Microsoft Entra ID endpoints the nodes
+// need to reach.
+func (s *ClusterScope) azureEndpointFQDNs() []string {
+	var fqdns []string
+	for _, endpoint := range []string{s.ResourceManagerEndpoint, s.activeDirectoryEndpoint} {
+		if u, err := url.Parse(endpoint); err == nil && u.Hostname() != "" {
+			fqdns = append(fqdns, u.Hostname())
+		}
+	}
+	return fqdns
+}
+
 // AzureBastionSpec returns the bastion spec.
 func (s *ClusterScope) AzureBastionSpec() azure.ASOResourceSpecGetter[*asonetworkv1api20220701.BastionHost] {
 	if s.IsAzureBastionEnabled() {
-		subnetID := azure.SubnetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, s.AzureBastion().Subnet.Name)
+		subnetID := azure.SubnetID(s.VnetSubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name, s.AzureBastion().Subnet.Name)
 		publicIPID := azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), s.AzureBastion().PublicIP.Name)
 
 		return &bastionhosts.AzureBastionSpec{
-			Name:            s.AzureBastion().Name,
-			ResourceGroup:   s.ResourceGroup(),
-			Location:        s.Location(),
-			ClusterName:     s.ClusterName(),
-			SubnetID:        subnetID,
-			PublicIPID:      publicIPID,
-			Sku:             s.AzureBastion().Sku,
-			EnableTunneling: s.AzureBastion().EnableTunneling,
+			Name:                s.AzureBastion().Name,
+			ResourceGroup:       s.ResourceGroup(),
+			Location:            s.Location(),
+			ClusterName:         s.ClusterName(),
+			SubnetID:            subnetID,
+			PublicIPID:          publicIPID,
+			Sku:                 s.AzureBastion().Sku,
+			EnableTunneling:     s.AzureBastion().EnableTunneling,
+			ScaleUnits:          s.AzureBastion().ScaleUnits,
+			EnableIPConnect:     s.AzureBastion().EnableIPConnect,
+			EnableShareableLink: s.AzureBastion().EnableShareableLink,
+			DisableCopyPaste:    s.AzureBastion().DisableCopyPaste,
+			EnableFileCopy:      s.AzureBastion().EnableFileCopy,
 		}
 	}
 
 	return nil
 }
 
+// AzureBastionID returns the resource ID of the Azure Bastion Host, or an empty string if the Azure Bastion Host is
+// not enabled.
+func (s *ClusterScope) AzureBastionID() string {
+	if !s.IsAzureBastionEnabled() {
+		return ""
+	}
+	return azure.BastionHostID(s.SubscriptionID(), s.ResourceGroup(), s.AzureBastion().Name)
+}
+
+// CostResources returns the inventory of billable resources owned by the AzureCluster.
+func (s *ClusterScope) CostResources() cost.Resources {
+	resources := cost.Resources{
+		Location: s.Location(),
+	}
+
+	seen := make(map[string]struct{})
+	for _, spec := range s.PublicIPSpecs() {
+		name := spec.ResourceName()
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		resources.PublicIPs = append(resources.PublicIPs, name)
+	}
+
+	for _, subnet := range s.NodeSubnets() {
+		if subnet.IsNatGatewayEnabled() && !slices.Contains(resources.NATGateways, subnet.NatGateway.Name) {
+			resources.NATGateways = append(resources.NATGateways, subnet.NatGateway.Name)
+		}
+	}
+
+	for _, spec := range s.LBSpecs() {
+		lbSpec, ok := spec.(*loadbalancers.LBSpec)
+		if !ok {
+			continue
+		}
+		resources.LoadBalancers = append(resources.LoadBalancers, cost.LoadBalancer{
+			Name: lbSpec.Name,
+			SKU:  string(lbSpec.SKU),
+		})
+	}
+
+	if s.IsAzureBastionEnabled() {
+		resources.Bastions = append(resources.Bastions, cost.Bastion{
+			Name: s.AzureBastion().Name,
+			SKU:  string(s.AzureBastion().Sku),
+		})
+	}
+
+	return resources
+}
+
 // Vnet returns the cluster Vnet.
 func (s *ClusterScope) Vnet() *infrav1.VnetSpec {
 	return &s.AzureCluster.Spec.NetworkSpec.Vnet
@@ -808,6 +1309,11 @@ func (s *ClusterScope) IsAPIServerPrivate() bool {
 	return s.APIServerLB() != nil && s.APIServerLB().Type == infrav1.Internal
 }
 
+// ApplicationSecurityGroupsEnabled returns true if application security groups are enabled for the cluster.
+func (s *ClusterScope) ApplicationSecurityGroupsEnabled() bool {
+	return s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroupsEnabled
+}
+
 // APIServerPublicIP returns the API Server public IP.
 func (s *ClusterScope) APIServerPublicIP() *infrav1.PublicIPSpec {
 	return s.APIServerLB().FrontendIPs[0].PublicIP
@@ -820,12 +1326,41 @@ func (s *ClusterScope) APIServerPrivateIP() string {
 
 // GetPrivateDNSZoneName returns the Private DNS Zone from the spec or generate it from cluster name.
 func (s *ClusterScope) GetPrivateDNSZoneName() string {
+	if existingZone := s.existingPrivateDNSZone(); existingZone != nil {
+		return existingZone.Name
+	}
 	if s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneName != "" {
 		return s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneName
 	}
 	return azure.GeneratePrivateDNSZoneName(s.ClusterName())
 }
 
+// ExistingPrivateDNSZoneID returns the resource ID of the existing private DNS zone used for the API server, if any.
+func (s *ClusterScope) ExistingPrivateDNSZoneID() string {
+	return s.AzureCluster.Spec.NetworkSpec.PrivateDNSZoneID
+}
+
+// existingPrivateDNSZone returns the parsed resource ID of the existing private DNS zone, or nil if none is set.
+func (s *ClusterScope) existingPrivateDNSZone() *arm.ResourceID {
+	if s.ExistingPrivateDNSZoneID() == "" {
+		return nil
+	}
+	zoneID, err := arm.ParseResourceID(s.ExistingPrivateDNSZoneID())
+	if err != nil {
+		return nil
+	}
+	return zoneID
+}
+
+// privateAPIServerHostname returns the hostname of the API server record in the private DNS zone.
+// An existing private DNS zone can be shared by several clusters, so the hostname includes the cluster name.
+func (s *ClusterScope) privateAPIServerHostname() string {
+	if s.existingPrivateDNSZone() != nil {
+		return fmt.Sprintf("%s-%s", s.ClusterName(), azure.PrivateAPIServerHostname)
+	}
+	return azure.PrivateAPIServerHostname
+}
+
 // APIServerLBPoolName returns the API Server LB backend pool name.
 func (s *ClusterScope) APIServerLBPoolName() string {
 	return s.APIServerLB().BackendPool.Name
@@ -860,6 +1395,21 @@ func (s *ClusterScope) OutboundPoolName(role string) string {
 	return lb.BackendPool.Name
 }
 
+// OutboundIPv6PoolName returns the outbound LB backend pool name of the IPv6 IP configurations of the network
+// interfaces, or an empty string when the outbound LB has no IPv6 frontend IPs.
+func (s *ClusterScope) OutboundIPv6PoolName(role string) string {
+	lb := s.outboundLB(role)
+	if lb == nil || !slices.ContainsFunc(lb.FrontendIPs, isIPv6FrontendIP) {
+		return ""
+	}
+	return azure.GenerateIPv6BackendPoolName(lb.BackendPool.Name)
+}
+
+// isIPv6FrontendIP returns true if the frontend IP of a load balancer is an IPv6 public IP.
+func isIPv6FrontendIP(ip infrav1.FrontendIP) bool {
+	return ip.PublicIP != nil && ip.PublicIP.IsIPv6()
+}
+
 // ResourceGroup returns the cluster resource group.
 func (s *ClusterScope) ResourceGroup() string {
 	return s.AzureCluster.Spec.ResourceGroup
@@ -961,12 +1511,16 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			clusterv1beta1.ReadyCondition,
 			infrav1.ResourceGroupReadyCondition,
 			infrav1.RouteTablesReadyCondition,
+			infrav1.ApplicationSecurityGroupsReadyCondition,
 			infrav1.NetworkInfrastructureReadyCondition,
 			infrav1.VnetPeeringReadyCondition,
 			infrav1.DisksReadyCondition,
 			infrav1.NATGatewaysReadyCondition,
 			infrav1.LoadBalancersReadyCondition,
+			infrav1.PrivateLinkServiceReadyCondition,
 			infrav1.BastionHostReadyCondition,
+			infrav1.ApplicationGatewayReadyCondition,
+			infrav1.AzureFirewallReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
@@ -1010,6 +1564,9 @@ func (s *ClusterScope) APIServerFrontendPort() int32 {
 // APIServerHost returns the hostname used to reach the API server.
 func (s *ClusterScope) APIServerHost() string {
 	if s.IsAPIServerPrivate() {
+		if s.existingPrivateDNSZone() != nil {
+			return fmt.Sprintf("%s.%s", s.privateAPIServerHostname(), s.GetPrivateDNSZoneName())
+		}
 		return azure.GeneratePrivateFQDN(s.GetPrivateDNSZoneName())
 	}
 	return s.APIServerPublicIP().DNSName
@@ -1152,16 +1709,20 @@ func (s *ClusterScope) SetDNSName() {
 // in the next reconciliation.
 func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
 	futures.Set(s.AzureCluster, future)
+	futures.Track(s.AzureCluster, s.SubscriptionID(), future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureCluster status.
 func (s *ClusterScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
-	return futures.Get(s.AzureCluster, name, service, futureType)
+	// A stored future is in flight, e.g. when it is resumed after a restart.
+	future := futures.Get(s.AzureCluster, name, service, futureType)
+	futures.Track(s.AzureCluster, s.SubscriptionID(), future)
+	return future
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureCluster status.
 func (s *ClusterScope) DeleteLongRunningOperationState(name, service, futureType string) {
-	futures.Delete(s.AzureCluster, name, service, futureType)
+	futures.Delete(s.AzureCluster, s.SubscriptionID(), name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureCluster status after a DELETE operation.
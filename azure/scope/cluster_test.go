@@ -39,15 +39,21 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	apiinternal "sigs.k8s.io/cluster-api-provider-azure/internal/api/v1beta1"
@@ -1188,6 +1194,113 @@ func TestRouteTableSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "points the node route tables at the firewall if a firewall is specified",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "my-rg",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane},
+									RouteTable: infrav1.RouteTable{
+										Name: "control-plane-route-table",
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode},
+									RouteTable: infrav1.RouteTable{
+										Name: "node-route-table",
+									},
+								},
+							},
+							Firewall: &infrav1.FirewallSpec{
+								Name: "my-cluster-azfw",
+							},
+						},
+					},
+					Status: infrav1.AzureClusterStatus{
+						FirewallPrivateIP: "10.255.253.4",
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:           "control-plane-route-table",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&routetables.RouteTableSpec{
+					Name:                  "node-route-table",
+					ResourceGroup:         "my-rg",
+					Location:              "centralIndia",
+					ClusterName:           "my-cluster",
+					AdditionalTags:        make(infrav1.Tags),
+					DefaultRouteNextHopIP: "10.255.253.4",
+				},
+			},
+		},
+		{
+			name: "points the cluster route table at the firewall if a firewall is specified",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "my-rg",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetCluster},
+									RouteTable: infrav1.RouteTable{
+										Name: "cluster-route-table",
+									},
+								},
+							},
+							Firewall: &infrav1.FirewallSpec{
+								Name: "my-cluster-azfw",
+							},
+						},
+					},
+					Status: infrav1.AzureClusterStatus{
+						FirewallPrivateIP: "10.255.253.4",
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:                  "cluster-route-table",
+					ResourceGroup:         "my-rg",
+					Location:              "centralIndia",
+					ClusterName:           "my-cluster",
+					AdditionalTags:        make(infrav1.Tags),
+					DefaultRouteNextHopIP: "10.255.253.4",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1200,6 +1313,81 @@ func TestRouteTableSpecs(t *testing.T) {
 	}
 }
 
+func TestApplicationSecurityGroupSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns empty if application security groups are not enabled",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
+		{
+			name: "returns the control plane and node application security groups if enabled",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup: "vnet-rg",
+							},
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								ApplicationSecurityGroupsEnabled: true,
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "my-cluster-control-plane-asg",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					Role:           infrav1.ControlPlane,
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "my-cluster-node-asg",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					Role:           infrav1.Node,
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.ApplicationSecurityGroupSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplicationSecurityGroupSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
 func TestNatGatewaySpecs(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = asonetworkv1api20201101.AddToScheme(scheme)
@@ -1598,8 +1786,12 @@ func TestNSGSpecs(t *testing.T) {
 						Name: "my-cluster",
 					},
 				},
+				AzureClients: AzureClients{
+					subscriptionID: "123",
+				},
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "cluster-rg",
 						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
 							Location: "centralIndia",
 						},
@@ -1639,6 +1831,8 @@ func TestNSGSpecs(t *testing.T) {
 					ClusterName:              "my-cluster",
 					AdditionalTags:           make(infrav1.Tags),
 					LastAppliedSecurityRules: map[string]any{},
+					SubscriptionID:           "123",
+					ASGResourceGroup:         "cluster-rg",
 				},
 			},
 		},
@@ -2030,6 +2224,243 @@ func TestIsVnetManaged(t *testing.T) {
 	}
 }
 
+func TestApplicationGatewaySpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope ClusterScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no ingress is specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "returns application gateway spec if ingress is specified",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					subscriptionID: "123",
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location:       "westus2",
+							AdditionalTags: infrav1.Tags{"foo": "bar"},
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "my-vnet",
+								ResourceGroup: "my-rg-vnet",
+							},
+							Ingress: &infrav1.IngressSpec{
+								Name:        "my-cluster-appgw",
+								SKU:         infrav1.WAFV2ApplicationGatewaySKU,
+								Capacity:    3,
+								WAFPolicyID: "my-waf-policy-id",
+								Subnet: infrav1.SubnetSpec{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetIngress,
+										CIDRBlocks: []string{"10.255.254.0/24"},
+										Name:       "my-cluster-ingress-subnet",
+									},
+								},
+								PublicIP: infrav1.PublicIPSpec{
+									Name: "my-cluster-appgw-pip",
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: &applicationgateways.ApplicationGatewaySpec{
+				Name:           "my-cluster-appgw",
+				ResourceGroup:  "my-rg",
+				SubscriptionID: "123",
+				Location:       "westus2",
+				ClusterName:    "my-cluster",
+				SKU:            infrav1.WAFV2ApplicationGatewaySKU,
+				Capacity:       3,
+				WAFPolicyID:    "my-waf-policy-id",
+				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "my-vnet", "my-cluster-ingress-subnet"),
+				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"publicIPAddresses/%s", "123", "my-rg", "my-cluster-appgw-pip"),
+				AdditionalTags: infrav1.Tags{"foo": "bar"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.ApplicationGatewaySpec(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplicationGatewaySpec() = \n%s, want \n%s", specToString(got), specToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestAzureFirewallSpecs(t *testing.T) {
+	tests := []struct {
+		name                        string
+		clusterScope                ClusterScope
+		wantPolicySpec              azure.ResourceSpecGetter
+		wantRuleCollectionGroupSpec azure.ResourceSpecGetter
+		wantFirewallSpec            azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no firewall is specified",
+			clusterScope: ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{},
+					},
+				},
+			},
+		},
+		{
+			name: "returns firewall specs if a firewall is specified",
+			clusterScope: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					subscriptionID:          "123",
+					ResourceManagerEndpoint: "https://management.azure.com/",
+					activeDirectoryEndpoint: "https://login.microsoftonline.com/",
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location:       "westus2",
+							AdditionalTags: infrav1.Tags{"foo": "bar"},
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "my-vnet",
+								ResourceGroup: "my-rg-vnet",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetControlPlane,
+										CIDRBlocks: []string{"10.0.0.0/16"},
+										Name:       "my-cluster-controlplane-subnet",
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetNode,
+										CIDRBlocks: []string{"10.1.0.0/16"},
+										Name:       "my-cluster-node-subnet",
+									},
+								},
+							},
+							APIServerLB: &infrav1.LoadBalancerSpec{
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Public,
+								},
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										PublicIP: &infrav1.PublicIPSpec{
+											Name:    "my-cluster-api-pip",
+											DNSName: "my-cluster.westus2.cloudapp.azure.com",
+										},
+									},
+								},
+							},
+							Firewall: &infrav1.FirewallSpec{
+								Name:            "my-cluster-azfw",
+								SKUTier:         infrav1.PremiumAzureFirewallSKUTier,
+								PolicyName:      "my-cluster-azfw-policy",
+								AdditionalFQDNs: []string{"example.com"},
+								Subnet: infrav1.SubnetSpec{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role:       infrav1.SubnetFirewall,
+										CIDRBlocks: []string{"10.255.253.0/26"},
+										Name:       "AzureFirewallSubnet",
+									},
+								},
+								PublicIP: infrav1.PublicIPSpec{
+									Name: "my-cluster-azfw-pip",
+								},
+							},
+						},
+						ControlPlaneEndpoint: clusterv1beta1.APIEndpoint{
+							Port: 443,
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			wantPolicySpec: &azurefirewalls.FirewallPolicySpec{
+				Name:           "my-cluster-azfw-policy",
+				ResourceGroup:  "my-rg",
+				Location:       "westus2",
+				ClusterName:    "my-cluster",
+				SKUTier:        infrav1.PremiumAzureFirewallSKUTier,
+				AdditionalTags: infrav1.Tags{"foo": "bar"},
+			},
+			wantRuleCollectionGroupSpec: &azurefirewalls.RuleCollectionGroupSpec{
+				Name:               "kubernetes-egress",
+				ResourceGroup:      "my-rg",
+				FirewallPolicyName: "my-cluster-azfw-policy",
+				SourceAddresses:    []string{"10.1.0.0/16"},
+				AzureEndpointFQDNs: []string{"management.azure.com", "login.microsoftonline.com"},
+				APIServerFQDN:      "my-cluster.westus2.cloudapp.azure.com",
+				APIServerPort:      443,
+				AdditionalFQDNs:    []string{"example.com"},
+			},
+			wantFirewallSpec: &azurefirewalls.AzureFirewallSpec{
+				Name:          "my-cluster-azfw",
+				ResourceGroup: "my-rg",
+				Location:      "westus2",
+				ClusterName:   "my-cluster",
+				SKUTier:       infrav1.PremiumAzureFirewallSKUTier,
+				FirewallPolicyID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"firewallPolicies/%s", "123", "my-rg", "my-cluster-azfw-policy"),
+				SubnetID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"virtualNetworks/%s/subnets/%s", "123", "my-rg-vnet", "my-vnet", "AzureFirewallSubnet"),
+				PublicIPID: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/"+
+					"publicIPAddresses/%s", "123", "my-rg", "my-cluster-azfw-pip"),
+				AdditionalTags: infrav1.Tags{"foo": "bar"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			policySpec, ruleCollectionGroupSpec, firewallSpec := tt.clusterScope.AzureFirewallSpecs()
+			if !reflect.DeepEqual(policySpec, tt.wantPolicySpec) {
+				t.Errorf("AzureFirewallSpecs() policySpec = \n%s, want \n%s", specToString(policySpec), specToString(tt.wantPolicySpec))
+			}
+			if !reflect.DeepEqual(ruleCollectionGroupSpec, tt.wantRuleCollectionGroupSpec) {
+				t.Errorf("AzureFirewallSpecs() ruleCollectionGroupSpec = \n%s, want \n%s", specToString(ruleCollectionGroupSpec), specToString(tt.wantRuleCollectionGroupSpec))
+			}
+			if !reflect.DeepEqual(firewallSpec, tt.wantFirewallSpec) {
+				t.Errorf("AzureFirewallSpecs() firewallSpec = \n%s, want \n%s", specToString(firewallSpec), specToString(tt.wantFirewallSpec))
+			}
+		})
+	}
+}
+
 func TestAzureBastionSpec(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2157,6 +2588,45 @@ func TestAzureBastionSpec(t *testing.T) {
 	}
 }
 
+func TestAzureBastionID(t *testing.T) {
+	tests := []struct {
+		name    string
+		bastion *infrav1.AzureBastion
+		want    string
+	}{
+		{
+			name:    "returns empty if azure bastion is not enabled",
+			bastion: nil,
+			want:    "",
+		},
+		{
+			name:    "returns the resource ID of the azure bastion",
+			bastion: &infrav1.AzureBastion{Name: "my-bastion"},
+			want:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/bastionHosts/my-bastion",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterScope := ClusterScope{
+				AzureClients: AzureClients{
+					subscriptionID: "123",
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						BastionSpec: infrav1.BastionSpec{
+							AzureBastion: tt.bastion,
+						},
+					},
+				},
+			}
+			g.Expect(clusterScope.AzureBastionID()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestSubnet(t *testing.T) {
 	tests := []struct {
 		clusterName             string
@@ -2739,6 +3209,58 @@ func TestOutboundPoolName(t *testing.T) {
 	}
 }
 
+func TestOutboundIPv6PoolName(t *testing.T) {
+	tests := []struct {
+		name           string
+		nodeOutboundLB *infrav1.LoadBalancerSpec
+		expected       string
+	}{
+		{
+			name:     "no node outbound lb",
+			expected: "",
+		},
+		{
+			name: "node outbound lb without IPv6 frontend IPs",
+			nodeOutboundLB: &infrav1.LoadBalancerSpec{
+				Name:        "my-cluster",
+				BackendPool: infrav1.BackendPool{Name: "my-cluster-outboundBackendPool"},
+				FrontendIPs: []infrav1.FrontendIP{
+					{Name: "my-cluster-frontEnd", PublicIP: &infrav1.PublicIPSpec{Name: "pip-my-cluster-node-outbound"}},
+				},
+			},
+			expected: "",
+		},
+		{
+			name: "node outbound lb with IPv6 frontend IPs",
+			nodeOutboundLB: &infrav1.LoadBalancerSpec{
+				Name:        "my-cluster",
+				BackendPool: infrav1.BackendPool{Name: "my-cluster-outboundBackendPool"},
+				FrontendIPs: []infrav1.FrontendIP{
+					{Name: "my-cluster-frontEnd", PublicIP: &infrav1.PublicIPSpec{Name: "pip-my-cluster-node-outbound"}},
+					{Name: "my-cluster-frontEnd-ipv6", PublicIP: &infrav1.PublicIPSpec{Name: "pip-my-cluster-node-outbound-ipv6", IPVersion: infrav1.IPv6}},
+				},
+			},
+			expected: "my-cluster-outboundBackendPool-ipv6",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterScope := &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							NodeOutboundLB: tc.nodeOutboundLB,
+						},
+					},
+				},
+			}
+			g.Expect(clusterScope.OutboundIPv6PoolName(infrav1.Node)).To(Equal(tc.expected))
+		})
+	}
+}
+
 func TestGenerateFQDN(t *testing.T) {
 	tests := []struct {
 		clusterName    string
@@ -3529,25 +4051,162 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			if tc.featureGate == feature.APIServerILB {
+			if tc.featureGate == feature.APIServerILB {
+				featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, tc.featureGate, true)
+			}
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.azureCluster.Name,
+					Namespace: "default",
+				},
+			}
+
+			clusterScope := &ClusterScope{
+				Cluster:      cluster,
+				AzureCluster: tc.azureCluster,
+				AzureClients: AzureClients{
+					subscriptionID: tc.azureCluster.Spec.SubscriptionID,
+				},
+			}
+			if got := clusterScope.LBSpecs(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("LBSpecs() diff between expected result and actual result (%v): %s", got, cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestPrivateLinkServiceSpec(t *testing.T) {
+	privateLinkService := &infrav1.PrivateLinkServiceSpec{
+		Name:                      "my-pls",
+		AllowedSubscriptions:      []string{"456", "789"},
+		AutoApprovedSubscriptions: []string{"789"},
+	}
+	tests := []struct {
+		name        string
+		featureGate featuregate.Feature
+		apiServerLB *infrav1.LoadBalancerSpec
+		want        azure.ResourceSpecGetter
+	}{
+		{
+			name: "no private link service",
+			apiServerLB: &infrav1.LoadBalancerSpec{
+				Name: "api-server-lb",
+				FrontendIPs: []infrav1.FrontendIP{
+					{Name: "api-server-lb-frontend-ip", FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"}},
+				},
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Internal},
+			},
+			want: nil,
+		},
+		{
+			name: "private link service in front of the internal API server LB",
+			apiServerLB: &infrav1.LoadBalancerSpec{
+				Name: "api-server-lb",
+				FrontendIPs: []infrav1.FrontendIP{
+					{Name: "api-server-lb-frontend-ip", FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"}},
+				},
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Internal},
+				PrivateLinkService:    privateLinkService,
+			},
+			want: &loadbalancers.PrivateLinkServiceSpec{
+				Name:                      "my-pls",
+				ResourceGroup:             "my-rg",
+				SubscriptionID:            "123",
+				Location:                  "westus2",
+				ClusterName:               "my-cluster",
+				LoadBalancerName:          "api-server-lb",
+				FrontendIPConfigName:      "api-server-lb-frontend-ip",
+				SubnetID:                  "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/cp-subnet",
+				AllowedSubscriptions:      []string{"456", "789"},
+				AutoApprovedSubscriptions: []string{"789"},
+				AdditionalTags:            infrav1.Tags{},
+			},
+		},
+		{
+			name:        "private link service in front of the internal LB of a public API server LB",
+			featureGate: feature.APIServerILB,
+			apiServerLB: &infrav1.LoadBalancerSpec{
+				Name: "api-server-lb",
+				FrontendIPs: []infrav1.FrontendIP{
+					{Name: "api-server-lb-frontend-ip", PublicIP: &infrav1.PublicIPSpec{Name: "api-server-lb-pip"}},
+					{Name: "api-server-lb-internal-ip", FrontendIPClass: infrav1.FrontendIPClass{PrivateIPAddress: "10.0.0.100"}},
+				},
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Public},
+				PrivateLinkService:    privateLinkService,
+			},
+			want: &loadbalancers.PrivateLinkServiceSpec{
+				Name:                      "my-pls",
+				ResourceGroup:             "my-rg",
+				SubscriptionID:            "123",
+				Location:                  "westus2",
+				ClusterName:               "my-cluster",
+				LoadBalancerName:          "api-server-lb-internal",
+				FrontendIPConfigName:      "api-server-lb-internal-ip",
+				SubnetID:                  "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/cp-subnet",
+				AllowedSubscriptions:      []string{"456", "789"},
+				AutoApprovedSubscriptions: []string{"789"},
+				AdditionalTags:            infrav1.Tags{},
+			},
+		},
+		{
+			name: "no internal LB for the private link service of a public API server LB",
+			apiServerLB: &infrav1.LoadBalancerSpec{
+				Name: "api-server-lb",
+				FrontendIPs: []infrav1.FrontendIP{
+					{Name: "api-server-lb-frontend-ip", PublicIP: &infrav1.PublicIPSpec{Name: "api-server-lb-pip"}},
+				},
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Public},
+				PrivateLinkService:    privateLinkService,
+			},
+			want: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.featureGate != "" {
 				featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, tc.featureGate, true)
 			}
-			cluster := &clusterv1.Cluster{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      tc.azureCluster.Name,
-					Namespace: "default",
-				},
-			}
-
 			clusterScope := &ClusterScope{
-				Cluster:      cluster,
-				AzureCluster: tc.azureCluster,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-cluster",
+						Namespace: "default",
+					},
+				},
 				AzureClients: AzureClients{
-					subscriptionID: tc.azureCluster.Spec.SubscriptionID,
+					subscriptionID: "123",
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+					Spec: infrav1.AzureClusterSpec{
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							SubscriptionID: "123",
+							Location:       "westus2",
+						},
+						ControlPlaneEnabled: true,
+						ResourceGroup:       "my-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "my-vnet",
+								ResourceGroup: "my-rg",
+							},
+							Subnets: []infrav1.SubnetSpec{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Name: "cp-subnet",
+										Role: infrav1.SubnetControlPlane,
+									},
+								},
+							},
+							APIServerLB: tc.apiServerLB,
+						},
+					},
 				},
 			}
-			if got := clusterScope.LBSpecs(); !reflect.DeepEqual(got, tc.want) {
-				t.Errorf("LBSpecs() diff between expected result and actual result (%v): %s", got, cmp.Diff(tc.want, got))
+			if got := clusterScope.PrivateLinkServiceSpec(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("PrivateLinkServiceSpec() diff between expected result and actual result: %s", cmp.Diff(tc.want, got))
 			}
 		})
 	}
@@ -3829,6 +4488,88 @@ func TestVNetPeerings(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "Hub VNet in another subscription is specified",
+			subscriptionID: fakeSubscriptionID,
+			azureClusterVNetSpec: infrav1.VnetSpec{
+				ResourceGroup: "rg1",
+				Name:          "vnet1",
+				Hub: &infrav1.HubVnetSpec{
+					Name:           "hub-vnet",
+					ResourceGroup:  "hub-rg",
+					SubscriptionID: "456",
+					ForwardPeeringProperties: infrav1.VnetPeeringProperties{
+						AllowForwardedTraffic: ptr.To(true),
+						UseRemoteGateways:     ptr.To(true),
+					},
+					ReversePeeringProperties: infrav1.VnetPeeringProperties{
+						AllowForwardedTraffic: ptr.To(true),
+						AllowGatewayTransit:   ptr.To(true),
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:           "vnet1-To-hub-vnet",
+					SourceResourceGroup:   "rg1",
+					SourceVnetName:        "vnet1",
+					RemoteResourceGroup:   "hub-rg",
+					RemoteVnetName:        "hub-vnet",
+					SubscriptionID:        "456",
+					AllowForwardedTraffic: ptr.To(true),
+					UseRemoteGateways:     ptr.To(true),
+				},
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:           "hub-vnet-To-vnet1",
+					SourceResourceGroup:   "hub-rg",
+					SourceVnetName:        "hub-vnet",
+					RemoteResourceGroup:   "rg1",
+					RemoteVnetName:        "vnet1",
+					SubscriptionID:        fakeSubscriptionID,
+					AllowForwardedTraffic: ptr.To(true),
+					AllowGatewayTransit:   ptr.To(true),
+					SourceSubscriptionID:  "456",
+				},
+			},
+		},
+		{
+			name:           "VNet and peered VNet in other subscriptions are specified",
+			subscriptionID: fakeSubscriptionID,
+			azureClusterVNetSpec: infrav1.VnetSpec{
+				ResourceGroup:  "rg1",
+				Name:           "vnet1",
+				SubscriptionID: "456",
+				Peerings: infrav1.VnetPeerings{
+					{
+						VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+							ResourceGroup:  "rg2",
+							RemoteVnetName: "vnet2",
+							SubscriptionID: "789",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:          "vnet1-To-vnet2",
+					SourceResourceGroup:  "rg1",
+					SourceVnetName:       "vnet1",
+					RemoteResourceGroup:  "rg2",
+					RemoteVnetName:       "vnet2",
+					SubscriptionID:       "789",
+					SourceSubscriptionID: "456",
+				},
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:          "vnet2-To-vnet1",
+					SourceResourceGroup:  "rg2",
+					SourceVnetName:       "vnet2",
+					RemoteResourceGroup:  "rg1",
+					RemoteVnetName:       "vnet1",
+					SubscriptionID:       "456",
+					SourceSubscriptionID: "789",
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -4320,6 +5061,36 @@ func TestGroupSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtualNetwork belongs to a resource group in another subscription",
+			input: ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "cluster1",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "dummy-rg",
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ResourceGroup:  "network-rg",
+								SubscriptionID: "network-sub",
+							},
+						},
+					},
+				},
+			},
+			expected: []azure.ASOResourceSpecGetter[*asoresourcesv1.ResourceGroup]{
+				&groups.GroupSpec{
+					Name:           "dummy-rg",
+					AzureName:      "dummy-rg",
+					ClusterName:    "cluster1",
+					Location:       "",
+					AdditionalTags: make(infrav1.Tags, 0),
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -4335,6 +5106,80 @@ func TestGroupSpecs(t *testing.T) {
 	}
 }
 
+func TestAuthorizerForSubscription(t *testing.T) {
+	g := NewWithT(t)
+
+	networkClients := &AzureClients{}
+	s := &ClusterScope{
+		AzureClients: AzureClients{subscriptionID: "cluster-sub"},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:           "vnet1",
+						SubscriptionID: "network-sub",
+					},
+				},
+			},
+		},
+		subscriptionClients: map[string]*AzureClients{"network-sub": networkClients},
+	}
+
+	g.Expect(s.AuthorizerForSubscription("network-sub")).To(BeIdenticalTo(networkClients))
+	g.Expect(s.AuthorizerForSubscription("cluster-sub")).To(BeIdenticalTo(s))
+	g.Expect(s.NetworkAuthorizer()).To(BeIdenticalTo(networkClients))
+
+	s.AzureCluster.Spec.NetworkSpec.Vnet.SubscriptionID = ""
+	g.Expect(s.NetworkAuthorizer()).To(BeIdenticalTo(s))
+}
+
+func TestVnetInAnotherSubscription(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &ClusterScope{
+		AzureClients: AzureClients{subscriptionID: "cluster-sub"},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "cluster-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						Name:           "vnet1",
+						ResourceGroup:  "network-rg",
+						SubscriptionID: "network-sub",
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet", Role: infrav1.SubnetNode},
+							SecurityGroup:   infrav1.SecurityGroup{Name: "node-nsg"},
+						},
+					},
+					Ingress: &infrav1.IngressSpec{
+						Subnet: infrav1.SubnetSpec{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "ingress-subnet"}},
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(s.VNetSpec().(*virtualnetworks.VNetSpec).ASOSecretName).To(Equal("cluster1-aso-vnet-secret"))
+	for _, spec := range s.SubnetSpecs() {
+		g.Expect(spec.(*subnets.SubnetSpec).ASOSecretName).To(Equal("cluster1-aso-vnet-secret"))
+	}
+	g.Expect(s.ApplicationSecurityGroupResourceGroup()).To(Equal("network-rg"))
+	nsgSpec := s.NSGSpecs()[0].(*securitygroups.NSGSpec)
+	g.Expect(nsgSpec.SubscriptionID).To(Equal("network-sub"))
+	g.Expect(nsgSpec.ASGResourceGroup).To(Equal("network-rg"))
+	g.Expect(s.ApplicationGatewaySpec().(*applicationgateways.ApplicationGatewaySpec).SubnetID).To(Equal(
+		"/subscriptions/network-sub/resourceGroups/network-rg/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/ingress-subnet"))
+
+	s.AzureCluster.Spec.NetworkSpec.Vnet.SubscriptionID = ""
+	g.Expect(s.VNetSpec().(*virtualnetworks.VNetSpec).ASOSecretName).To(BeEmpty())
+	g.Expect(s.ApplicationSecurityGroupResourceGroup()).To(Equal("cluster-rg"))
+}
+
 func TestAPIServerLBName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -4469,3 +5314,221 @@ func TestPrivateDNSSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestPrivateDNSSpecHubLink(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+		},
+		AzureClients: AzureClients{
+			subscriptionID: "123",
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					NetworkClassSpec: infrav1.NetworkClassSpec{
+						PrivateDNSZoneName: "my-cluster.capz.io",
+					},
+					Vnet: infrav1.VnetSpec{
+						Name:          "my-vnet",
+						ResourceGroup: "my-rg",
+						Hub: &infrav1.HubVnetSpec{
+							Name:           "hub-vnet",
+							ResourceGroup:  "hub-rg",
+							SubscriptionID: "456",
+						},
+					},
+					APIServerLB: &infrav1.LoadBalancerSpec{
+						FrontendIPs: []infrav1.FrontendIP{
+							{
+								Name: "api-server-lb-internal-ip",
+								FrontendIPClass: infrav1.FrontendIPClass{
+									PrivateIPAddress: apiinternal.DefaultInternalLBIPAddress,
+								},
+							},
+						},
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Internal,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, links, _ := clusterScope.PrivateDNSSpec()
+	g.Expect(links).To(HaveLen(2))
+	g.Expect(links[1]).To(Equal(privatedns.LinkSpec{
+		Name:              "hub-vnet-link",
+		ZoneName:          "my-cluster.capz.io",
+		SubscriptionID:    "456",
+		VNetResourceGroup: "hub-rg",
+		VNetName:          "hub-vnet",
+		ResourceGroup:     "my-rg",
+		ClusterName:       "my-cluster",
+		AdditionalTags:    infrav1.Tags{},
+	}))
+}
+
+func TestPrivateDNSSpecExistingZone(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+		},
+		AzureClients: AzureClients{
+			subscriptionID: "123",
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cluster",
+			},
+			Spec: infrav1.AzureClusterSpec{
+				ResourceGroup: "my-rg",
+				NetworkSpec: infrav1.NetworkSpec{
+					NetworkClassSpec: infrav1.NetworkClassSpec{
+						PrivateDNSZoneID: "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.example.com",
+					},
+					Vnet: infrav1.VnetSpec{
+						Name:          "my-vnet",
+						ResourceGroup: "my-rg",
+					},
+					APIServerLB: &infrav1.LoadBalancerSpec{
+						FrontendIPs: []infrav1.FrontendIP{
+							{
+								Name: "api-server-lb-internal-ip",
+								FrontendIPClass: infrav1.FrontendIPClass{
+									PrivateIPAddress: apiinternal.DefaultInternalLBIPAddress,
+								},
+							},
+						},
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Internal,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	zone, links, records := clusterScope.PrivateDNSSpec()
+	g.Expect(zone).To(Equal(privatedns.ZoneSpec{
+		Name:           "privatelink.example.com",
+		ResourceGroup:  "dns-rg",
+		ClusterName:    "my-cluster",
+		AdditionalTags: infrav1.Tags{},
+		SubscriptionID: "456",
+		Existing:       true,
+	}))
+	g.Expect(links).To(Equal([]azure.ResourceSpecGetter{privatedns.LinkSpec{
+		Name:              "my-vnet-link",
+		ZoneName:          "privatelink.example.com",
+		SubscriptionID:    "123",
+		VNetResourceGroup: "my-rg",
+		VNetName:          "my-vnet",
+		ResourceGroup:     "dns-rg",
+		ClusterName:       "my-cluster",
+		AdditionalTags:    infrav1.Tags{},
+	}}))
+	g.Expect(records).To(Equal([]azure.ResourceSpecGetter{privatedns.RecordSpec{
+		Record: infrav1.AddressRecord{
+			Hostname: "my-cluster-apiserver",
+			IP:       apiinternal.DefaultInternalLBIPAddress,
+		},
+		ZoneName:      "privatelink.example.com",
+		ResourceGroup: "dns-rg",
+	}}))
+	g.Expect(clusterScope.APIServerHost()).To(Equal("my-cluster-apiserver.privatelink.example.com"))
+}
+
+func TestClusterScope_CostResources(t *testing.T) {
+	g := NewWithT(t)
+
+	natGateway := infrav1.NatGateway{
+		NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: "node-natgw"},
+		NatGatewayIP:        infrav1.PublicIPSpec{Name: "node-natgw-ip"},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-cluster",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "123",
+				Location:       "westus2",
+			},
+			ControlPlaneEnabled: true,
+			ResourceGroup:       "my-rg",
+			BastionSpec: infrav1.BastionSpec{
+				AzureBastion: &infrav1.AzureBastion{
+					Name:     "my-bastion",
+					Sku:      infrav1.StandardBastionHostSku,
+					PublicIP: infrav1.PublicIPSpec{Name: "bastion-ip"},
+				},
+			},
+			NetworkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:          "my-vnet",
+					ResourceGroup: "my-rg",
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp-subnet", Role: infrav1.SubnetControlPlane},
+					},
+					// Both node subnets share a NAT gateway, which must only be counted once.
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet-1", Role: infrav1.SubnetNode},
+						NatGateway:      natGateway,
+					},
+					{
+						SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet-2", Role: infrav1.SubnetNode},
+						NatGateway:      natGateway,
+					},
+				},
+				APIServerLB: &infrav1.LoadBalancerSpec{
+					Name: "api-server-lb",
+					LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+						Type: infrav1.Public,
+						SKU:  infrav1.SKUStandard,
+					},
+					FrontendIPs: []infrav1.FrontendIP{
+						{
+							Name:     "api-server-lb-frontend-ip",
+							PublicIP: &infrav1.PublicIPSpec{Name: "api-server-ip"},
+						},
+					},
+				},
+			},
+		},
+	}
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		},
+		AzureCluster: azureCluster,
+		AzureClients: AzureClients{
+			subscriptionID: "123",
+		},
+	}
+
+	g.Expect(clusterScope.CostResources()).To(Equal(cost.Resources{
+		Location:      "westus2",
+		PublicIPs:     []string{"api-server-ip", "node-natgw-ip", "bastion-ip"},
+		NATGateways:   []string{"node-natgw"},
+		LoadBalancers: []cost.LoadBalancer{{Name: "api-server-lb", SKU: "Standard"}},
+		Bastions:      []cost.Bastion{{Name: "my-bastion", SKU: "Standard"}},
+	}))
+}
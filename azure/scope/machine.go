@@ -20,12 +20,15 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
@@ -37,6 +40,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
@@ -53,6 +57,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// defaultOSDiskSizeGB is the size Azure assigns to an OS disk when none is specified.
+const defaultOSDiskSizeGB int32 = 30
+
 // MachineScopeParams defines the input parameters used to create a new MachineScope.
 type MachineScopeParams struct {
 	Client       client.Client
@@ -145,9 +152,9 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 			skuCache = cache
 		}
 
-		m.cache.VMSKU, err = skuCache.Get(ctx, m.AzureMachine.Spec.VMSize, resourceskus.VirtualMachines)
+		m.cache.VMSKU, err = skuCache.Get(ctx, m.VMSize(), resourceskus.VirtualMachines)
 		if err != nil {
-			return errors.Wrapf(err, "failed to get VM SKU %s in compute api", m.AzureMachine.Spec.VMSize)
+			return errors.Wrapf(err, "failed to get VM SKU %s in compute api", m.VMSize())
 		}
 
 		m.cache.availabilitySetSKU, err = skuCache.Get(ctx, string(armcompute.AvailabilitySetSKUTypesAligned), resourceskus.AvailabilitySets)
@@ -170,7 +177,7 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 		Role:                        m.Role(),
 		NICIDs:                      m.NICIDs(),
 		SSHKeyData:                  m.AzureMachine.Spec.SSHPublicKey,
-		Size:                        m.AzureMachine.Spec.VMSize,
+		Size:                        m.VMSize(),
 		OSDisk:                      m.AzureMachine.Spec.OSDisk,
 		DataDisks:                   m.AzureMachine.Spec.DataDisks,
 		AvailabilitySetID:           m.AvailabilitySetID(),
@@ -178,7 +185,7 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 		Zone:                        m.AvailabilityZone(),
 		Identity:                    m.AzureMachine.Spec.Identity,
 		UserAssignedIdentities:      m.AzureMachine.Spec.UserAssignedIdentities,
-		SpotVMOptions:               m.AzureMachine.Spec.SpotVMOptions,
+		SpotVMOptions:               m.SpotVMOptions(),
 		SecurityProfile:             m.AzureMachine.Spec.SecurityProfile,
 		DiagnosticsProfile:          m.AzureMachine.Spec.Diagnostics,
 		DisableExtensionOperations:  ptr.Deref(m.AzureMachine.Spec.DisableExtensionOperations, false),
@@ -187,6 +194,12 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 		AdditionalCapabilities:      m.AzureMachine.Spec.AdditionalCapabilities,
 		CapacityReservationGroupID:  m.GetCapacityReservationGroupID(),
 		ProviderID:                  m.ProviderID(),
+		SubscriptionID:              m.SubscriptionID(),
+		SpotEvictionRecovery:        m.AzureMachine.Spec.SpotEvictionRecovery != nil,
+		CapacityFallback:            m.AzureMachine.Spec.CapacityFallback != nil,
+		// A VM recovered with regular priority is only recreated while its eviction is being recovered, to keep
+		// treating VMs deleted outside of CAPZ as failed machines.
+		RecreateFromDisks: m.spotEvictionStatus().RegularPriority && v1beta1conditions.IsFalse(m.AzureMachine, infrav1.SpotVMRunningCondition),
 	}
 	if m.cache != nil {
 		spec.SKU = m.cache.VMSKU
@@ -196,6 +209,150 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 	return spec
 }
 
+// VMSize returns the size of the VM, which is the fallback size chosen after a Spot VM eviction or an allocation
+// failure if any.
+func (m *MachineScope) VMSize() string {
+	if size := m.spotEvictionStatus().VMSize; size != "" {
+		return size
+	}
+	if fallback := m.AzureMachine.Status.CapacityFallback; fallback != nil && fallback.VMSize != "" {
+		return fallback.VMSize
+	}
+	return m.AzureMachine.Spec.VMSize
+}
+
+// SpotVMOptions returns the Spot VM options of the VM, or nil once an evicted Spot VM is recovered with regular priority.
+func (m *MachineScope) SpotVMOptions() *infrav1.SpotVMOptions {
+	if m.spotEvictionStatus().RegularPriority {
+		return nil
+	}
+	return m.AzureMachine.Spec.SpotVMOptions
+}
+
+// SetSpotVMEvicted reports whether the Spot VM is evicted. The first time an eviction is reported it is counted and
+// the size and priority to recover the VM with are chosen according to the SpotEvictionRecovery of the AzureMachine.
+func (m *MachineScope) SetSpotVMEvicted(evicted bool) {
+	if !evicted {
+		v1beta1conditions.MarkTrue(m.AzureMachine, infrav1.SpotVMRunningCondition)
+		return
+	}
+	if v1beta1conditions.GetReason(m.AzureMachine, infrav1.SpotVMRunningCondition) == infrav1.SpotVMEvictedReason {
+		return
+	}
+
+	status := m.spotEvictionStatus()
+	status.Evictions++
+	status.LastEvictionTime = ptr.To(metav1.Now())
+	if recovery := m.AzureMachine.Spec.SpotEvictionRecovery; recovery != nil {
+		if n := len(recovery.FallbackVMSizes); n > 0 {
+			status.VMSize = recovery.FallbackVMSizes[min(int(status.Evictions), n)-1]
+		}
+		if recovery.RegularPriorityAfterEvictions != nil && status.Evictions >= *recovery.RegularPriorityAfterEvictions {
+			status.RegularPriority = true
+		}
+	}
+	m.AzureMachine.Status.SpotEviction = &status
+
+	v1beta1conditions.MarkFalse(m.AzureMachine, infrav1.SpotVMRunningCondition, infrav1.SpotVMEvictedReason, clusterv1beta1.ConditionSeverityWarning, "Spot VM was evicted %d time(s)", status.Evictions)
+}
+
+// CapacityFallbackTargets returns the failure domains and VM sizes the VM can fall back to when Azure fails to
+// allocate it, in order of preference, skipping the current ones and those which already failed. It returns nil
+// when the AzureMachine has no capacity fallback.
+func (m *MachineScope) CapacityFallbackTargets() []azure.AllocationTarget {
+	fallback := m.AzureMachine.Spec.CapacityFallback
+	if fallback == nil {
+		return nil
+	}
+
+	failureDomains := []string{m.machineFailureDomain()}
+	if fallback.AcrossFailureDomains && failureDomains[0] != "" {
+		for _, fd := range m.FailureDomains() {
+			if *fd != failureDomains[0] {
+				failureDomains = append(failureDomains, *fd)
+			}
+		}
+	}
+
+	current := azure.AllocationTarget{FailureDomain: m.AvailabilityZone(), VMSize: m.VMSize()}
+	targets := []azure.AllocationTarget{}
+	for _, size := range append([]string{m.AzureMachine.Spec.VMSize}, fallback.VMSizes...) {
+		for _, fd := range failureDomains {
+			target := azure.AllocationTarget{FailureDomain: fd, VMSize: size}
+			if target == current || m.allocationAttemptIndex(target) >= 0 {
+				continue
+			}
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// SetAllocationFailed records that Azure failed to allocate the VM in its current failure domain and with its
+// current size with the given reason, and falls back to next unless it is nil.
+func (m *MachineScope) SetAllocationFailed(reason string, next *azure.AllocationTarget) {
+	current := azure.AllocationTarget{FailureDomain: m.AvailabilityZone(), VMSize: m.VMSize()}
+	if m.AzureMachine.Status.CapacityFallback == nil {
+		m.AzureMachine.Status.CapacityFallback = &infrav1.CapacityFallbackStatus{}
+	}
+	status := m.AzureMachine.Status.CapacityFallback
+
+	attempt := infrav1.AllocationAttempt{
+		FailureDomain:   current.FailureDomain,
+		VMSize:          current.VMSize,
+		Reason:          reason,
+		LastAttemptTime: metav1.Now(),
+	}
+	if i := m.allocationAttemptIndex(current); i >= 0 {
+		status.Attempts[i] = attempt
+	} else {
+		status.Attempts = append(status.Attempts, attempt)
+	}
+
+	if next == nil {
+		return
+	}
+	status.FailureDomain = next.FailureDomain
+	status.VMSize = next.VMSize
+	status.RetryPending = true
+	m.AzureMachine.Status.FailureDomain = ""
+	if next.FailureDomain != m.machineFailureDomain() {
+		m.AzureMachine.Status.FailureDomain = next.FailureDomain
+	}
+}
+
+// AllocationRetryPending returns true while the VM which failed to be allocated and its disks must be deleted before
+// the allocation is retried.
+func (m *MachineScope) AllocationRetryPending() bool {
+	return m.AzureMachine.Status.CapacityFallback != nil && m.AzureMachine.Status.CapacityFallback.RetryPending
+}
+
+// SetAllocationRetryPending sets whether the VM which failed to be allocated and its disks must be deleted before the
+// allocation is retried.
+func (m *MachineScope) SetAllocationRetryPending(pending bool) {
+	if m.AzureMachine.Status.CapacityFallback != nil {
+		m.AzureMachine.Status.CapacityFallback.RetryPending = pending
+	}
+}
+
+// allocationAttemptIndex returns the index of the failed allocation attempt for target, or -1 if there is none.
+func (m *MachineScope) allocationAttemptIndex(target azure.AllocationTarget) int {
+	if m.AzureMachine.Status.CapacityFallback == nil {
+		return -1
+	}
+	return slices.IndexFunc(m.AzureMachine.Status.CapacityFallback.Attempts, func(attempt infrav1.AllocationAttempt) bool {
+		return attempt.FailureDomain == target.FailureDomain && attempt.VMSize == target.VMSize
+	})
+}
+
+// spotEvictionStatus returns a copy of the Spot eviction status of the AzureMachine.
+func (m *MachineScope) spotEvictionStatus() infrav1.SpotEvictionStatus {
+	if m.AzureMachine.Status.SpotEviction == nil {
+		return infrav1.SpotEvictionStatus{}
+	}
+	return *m.AzureMachine.Status.SpotEviction
+}
+
 // TagsSpecs returns the tags for the AzureMachine.
 func (m *MachineScope) TagsSpecs() []azure.TagsSpec {
 	return []azure.TagsSpec{
@@ -278,9 +435,11 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 		IPv6Enabled:           m.IsIPv6Enabled(),
 		EnableIPForwarding:    m.AzureMachine.Spec.EnableIPForwarding,
 		SubnetName:            infrav1NetworkInterface.SubnetName,
+		StaticIPAddress:       infrav1NetworkInterface.PrivateIPAddress,
 		AdditionalTags:        m.AdditionalTags(),
 		ClusterName:           m.ClusterName(),
 		IPConfigs:             []networkinterfaces.IPConfig{},
+		VNetSubscriptionID:    m.VnetSubscriptionID(),
 	}
 
 	if m.cache != nil {
@@ -291,6 +450,12 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 		spec.IPConfigs = append(spec.IPConfigs, networkinterfaces.IPConfig{})
 	}
 
+	if m.ApplicationSecurityGroupsEnabled() {
+		spec.ApplicationSecurityGroupIDs = []string{
+			azure.ApplicationSecurityGroupID(m.VnetSubscriptionID(), m.ApplicationSecurityGroupResourceGroup(), azure.GenerateApplicationSecurityGroupName(m.ClusterName(), m.Role())),
+		}
+	}
+
 	if primaryNetworkInterface {
 		spec.DNSServers = m.AzureMachine.Spec.DNSServers
 
@@ -318,6 +483,14 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 			spec.PublicLBName = m.OutboundLBName(m.Role())
 			spec.PublicLBAddressPoolName = m.OutboundPoolName(m.Role())
 		}
+		// NAT gateways and node public IPs only handle IPv4 outbound traffic, so the IPv6 IP configuration of the NIC
+		// always references the outbound LB when it has IPv6 frontend IPs.
+		if m.Role() == infrav1.Node && m.IsIPv6Enabled() {
+			if poolName := m.OutboundIPv6PoolName(m.Role()); poolName != "" {
+				spec.PublicLBName = m.OutboundLBName(m.Role())
+				spec.PublicLBIPv6AddressPoolName = poolName
+			}
+		}
 	}
 
 	return spec
@@ -351,6 +524,64 @@ func (m *MachineScope) DiskSpecs() []azure.ResourceSpecGetter {
 	return diskSpecs
 }
 
+// CostResources returns the inventory of billable resources owned by the AzureMachine.
+func (m *MachineScope) CostResources() cost.Resources {
+	var sku resourceskus.SKU
+	if m.cache != nil {
+		sku = m.cache.VMSKU
+	}
+	resources := vmCostResources(m.Location(), m.VMSize(), sku, m.AzureMachine.Spec.OSDisk, m.AzureMachine.Spec.DataDisks, 1)
+	for _, spec := range m.PublicIPSpecs() {
+		resources.PublicIPs = append(resources.PublicIPs, spec.ResourceName())
+	}
+	return resources
+}
+
+// vmCostResources returns the inventory of count identical VMs and their managed disks.
+func vmCostResources(location, vmSize string, sku resourceskus.SKU, osDisk infrav1.OSDisk, dataDisks []infrav1.DataDisk, count int) cost.Resources {
+	resources := cost.Resources{
+		Location: location,
+	}
+	if count <= 0 {
+		return resources
+	}
+
+	vm := cost.VM{Size: vmSize, Count: count}
+	if vCPUs, ok := sku.GetCapability(resourceskus.VCPUs); ok {
+		vm.VCPUs, _ = strconv.Atoi(vCPUs)
+	}
+	resources.VMs = append(resources.VMs, vm)
+
+	// Azure bills managed disks at the SKU's default tier when none is specified.
+	defaultStorageAccountType := string(armcompute.StorageAccountTypesStandardLRS)
+	if sku.HasCapability(resourceskus.PremiumIO) {
+		defaultStorageAccountType = string(armcompute.StorageAccountTypesPremiumLRS)
+	}
+	storageAccountType := func(params *infrav1.ManagedDiskParameters) string {
+		if params != nil && params.StorageAccountType != "" {
+			return params.StorageAccountType
+		}
+		return defaultStorageAccountType
+	}
+
+	// Ephemeral OS disks live on the VM's local storage and are not billed separately.
+	if osDisk.DiffDiskSettings == nil {
+		resources.Disks = append(resources.Disks, cost.Disk{
+			StorageAccountType: storageAccountType(osDisk.ManagedDisk),
+			SizeGiB:            ptr.Deref(osDisk.DiskSizeGB, defaultOSDiskSizeGB),
+			Count:              count,
+		})
+	}
+	for _, dd := range dataDisks {
+		resources.Disks = append(resources.Disks, cost.Disk{
+			StorageAccountType: storageAccountType(dd.ManagedDisk),
+			SizeGiB:            dd.DiskSizeGB,
+			Count:              count,
+		})
+	}
+	return resources
+}
+
 // RoleAssignmentSpecs returns the role assignment specs.
 func (m *MachineScope) RoleAssignmentSpecs(principalID *string) []azure.ResourceSpecGetter {
 	roles := make([]azure.ResourceSpecGetter, 1)
@@ -432,10 +663,19 @@ func (m *MachineScope) Subnet() infrav1.SubnetSpec {
 
 // AvailabilityZone returns the AzureMachine Availability Zone.
 // Priority for selecting the AZ is
-//  1. Machine.Spec.FailureDomain
-//  2. AzureMachine.Spec.FailureDomain (This is to support deprecated AZ)
-//  3. No AZ
+//  1. AzureMachine.Status.CapacityFallback.FailureDomain (after an allocation failure)
+//  2. Machine.Spec.FailureDomain
+//  3. AzureMachine.Spec.FailureDomain (This is to support deprecated AZ)
+//  4. No AZ
 func (m *MachineScope) AvailabilityZone() string {
+	if fallback := m.AzureMachine.Status.CapacityFallback; fallback != nil && fallback.FailureDomain != "" {
+		return fallback.FailureDomain
+	}
+	return m.machineFailureDomain()
+}
+
+// machineFailureDomain returns the failure domain the Machine is placed in.
+func (m *MachineScope) machineFailureDomain() string {
 	if m.Machine.Spec.FailureDomain != "" {
 		return m.Machine.Spec.FailureDomain
 	}
@@ -656,6 +896,21 @@ func (m *MachineScope) SetAddresses(addrs []corev1.NodeAddress) {
 	m.AzureMachine.Status.Addresses = addrs
 }
 
+// SetBastionConnection sets the metadata needed to open a session to the VM through the Azure Bastion Host with the
+// given resource ID. It is cleared when the cluster has no Azure Bastion Host or the VM does not exist yet.
+func (m *MachineScope) SetBastionConnection(bastionHostID string) {
+	resourceID, err := azureutil.ParseResourceID(m.ProviderID())
+	if bastionHostID == "" || err != nil {
+		m.AzureMachine.Status.Bastion = nil
+		return
+	}
+	m.AzureMachine.Status.Bastion = &infrav1.BastionConnection{
+		BastionHostID:    bastionHostID,
+		TargetResourceID: resourceID.String(),
+		Username:         azure.DefaultUserName,
+	}
+}
+
 // PatchObject persists the machine spec and status.
 func (m *MachineScope) PatchObject(ctx context.Context) error {
 	v1beta1conditions.SetSummary(m.AzureMachine)
@@ -775,16 +1030,20 @@ func (m *MachineScope) SetSubnetName() error {
 // in the next reconciliation.
 func (m *MachineScope) SetLongRunningOperationState(future *infrav1.Future) {
 	futures.Set(m.AzureMachine, future)
+	futures.Track(m.AzureMachine, m.SubscriptionID(), future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureMachine status.
 func (m *MachineScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
-	return futures.Get(m.AzureMachine, name, service, futureType)
+	// A stored future is in flight, e.g. when it is resumed after a restart.
+	future := futures.Get(m.AzureMachine, name, service, futureType)
+	futures.Track(m.AzureMachine, m.SubscriptionID(), future)
+	return future
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureMachine status.
 func (m *MachineScope) DeleteLongRunningOperationState(name, service, futureType string) {
-	futures.Delete(m.AzureMachine, name, service, futureType)
+	futures.Delete(m.AzureMachine, m.SubscriptionID(), name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureMachine status after a DELETE operation.
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
@@ -32,9 +33,11 @@ import (
 	"k8s.io/utils/ptr"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/inboundnatrules"
@@ -244,6 +247,56 @@ func TestMachineScope_ProviderID(t *testing.T) {
 	}
 }
 
+func TestMachineScope_SetBastionConnection(t *testing.T) {
+	bastionHostID := "/subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Network/bastionHosts/my-bastion"
+	tests := []struct {
+		name          string
+		providerID    *string
+		bastionHostID string
+		want          *infrav1.BastionConnection
+	}{
+		{
+			name:          "sets the connection metadata of the VM",
+			providerID:    ptr.To("azure:///subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Compute/virtualMachines/machine-name"),
+			bastionHostID: bastionHostID,
+			want: &infrav1.BastionConnection{
+				BastionHostID:    bastionHostID,
+				TargetResourceID: "/subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Compute/virtualMachines/machine-name",
+				Username:         "capi",
+			},
+		},
+		{
+			name:          "clears the connection metadata without an azure bastion",
+			providerID:    ptr.To("azure:///subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Compute/virtualMachines/machine-name"),
+			bastionHostID: "",
+			want:          nil,
+		},
+		{
+			name:          "clears the connection metadata before the VM is created",
+			providerID:    nil,
+			bastionHostID: bastionHostID,
+			want:          nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: tt.providerID,
+					},
+					Status: infrav1.AzureMachineStatus{
+						Bastion: &infrav1.BastionConnection{BastionHostID: "stale"},
+					},
+				},
+			}
+			machineScope.SetBastionConnection(tt.bastionHostID)
+			g.Expect(machineScope.AzureMachine.Status.Bastion).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestMachineScope_PublicIPSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1168,6 +1221,27 @@ func TestMachineScope_AvailabilityZone(t *testing.T) {
 			},
 			want: "dummy-failure-domain-from-azuremachine-spec",
 		},
+		{
+			name: "returns failure domain from the capacity fallback",
+			machineScope: MachineScope{
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: "dummy-failure-domain-from-machine-spec",
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine-name",
+					},
+					Status: infrav1.AzureMachineStatus{
+						CapacityFallback: &infrav1.CapacityFallbackStatus{
+							FailureDomain: "dummy-failure-domain-from-capacity-fallback",
+						},
+					},
+				},
+			},
+			want: "dummy-failure-domain-from-capacity-fallback",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1953,6 +2027,218 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Node Machine with a static private IP address",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						subscriptionID: "123",
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+									BackendPool: infrav1.BackendPool{
+										Name: "outbound-lb-outboundBackendPool",
+									},
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: ptr.To("azure:///subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Compute/virtualMachines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+							PrivateIPAddress: "10.0.0.10",
+						}},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					StaticIPAddress:           "10.0.0.10",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+				},
+			},
+		},
+		{
+			name: "Node Machine with application security groups enabled",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						subscriptionID: "123",
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								NetworkClassSpec: infrav1.NetworkClassSpec{
+									ApplicationSecurityGroupsEnabled: true,
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+									BackendPool: infrav1.BackendPool{
+										Name: "outbound-lb-outboundBackendPool",
+									},
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: ptr.To("azure:///subscriptions/1234-5678/resourceGroups/my-cluster/providers/Microsoft.Compute/virtualMachines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+						}},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabel: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+					ApplicationSecurityGroupIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/cluster-node-asg"},
+				},
+			},
+		},
 		{
 			name: "Node Machine with no NAT gateway and no public IP address and SKU is in machine cache",
 			machineScope: MachineScope{
@@ -3408,6 +3694,214 @@ func TestMachineScope_GetCapacityReservationGroupID(t *testing.T) {
 	}
 }
 
+func TestMachineScope_SetSpotVMEvicted(t *testing.T) {
+	type step struct {
+		evicted             bool
+		wantEvictions       int32
+		wantVMSize          string
+		wantSpotVMOptions   bool
+		wantConditionStatus bool
+	}
+	tests := []struct {
+		name     string
+		recovery *infrav1.SpotEvictionRecovery
+		steps    []step
+	}{
+		{
+			name: "evictions are only counted without recovery",
+			steps: []step{
+				{evicted: false, wantEvictions: 0, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: true, wantConditionStatus: true},
+				{evicted: true, wantEvictions: 1, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: true, wantConditionStatus: false},
+				{evicted: true, wantEvictions: 1, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: true, wantConditionStatus: false},
+				{evicted: false, wantEvictions: 1, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: true, wantConditionStatus: true},
+			},
+		},
+		{
+			name: "fallback VM sizes are used in order and the last one is kept",
+			recovery: &infrav1.SpotEvictionRecovery{
+				FallbackVMSizes: []string{"Standard_D4s_v3", "Standard_E4s_v3"},
+			},
+			steps: []step{
+				{evicted: true, wantEvictions: 1, wantVMSize: "Standard_D4s_v3", wantSpotVMOptions: true, wantConditionStatus: false},
+				{evicted: false, wantEvictions: 1, wantVMSize: "Standard_D4s_v3", wantSpotVMOptions: true, wantConditionStatus: true},
+				{evicted: true, wantEvictions: 2, wantVMSize: "Standard_E4s_v3", wantSpotVMOptions: true, wantConditionStatus: false},
+				{evicted: false, wantEvictions: 2, wantVMSize: "Standard_E4s_v3", wantSpotVMOptions: true, wantConditionStatus: true},
+				{evicted: true, wantEvictions: 3, wantVMSize: "Standard_E4s_v3", wantSpotVMOptions: true, wantConditionStatus: false},
+			},
+		},
+		{
+			name: "regular priority is used after the configured number of evictions",
+			recovery: &infrav1.SpotEvictionRecovery{
+				RegularPriorityAfterEvictions: ptr.To[int32](2),
+			},
+			steps: []step{
+				{evicted: true, wantEvictions: 1, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: true, wantConditionStatus: false},
+				{evicted: false, wantEvictions: 1, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: true, wantConditionStatus: true},
+				{evicted: true, wantEvictions: 2, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: false, wantConditionStatus: false},
+				{evicted: false, wantEvictions: 2, wantVMSize: "Standard_D2s_v3", wantSpotVMOptions: false, wantConditionStatus: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						VMSize:               "Standard_D2s_v3",
+						SpotVMOptions:        &infrav1.SpotVMOptions{},
+						SpotEvictionRecovery: tt.recovery,
+					},
+				},
+			}
+			for _, step := range tt.steps {
+				machineScope.SetSpotVMEvicted(step.evicted)
+				g.Expect(machineScope.spotEvictionStatus().Evictions).To(Equal(step.wantEvictions))
+				g.Expect(machineScope.VMSize()).To(Equal(step.wantVMSize))
+				g.Expect(machineScope.SpotVMOptions() != nil).To(Equal(step.wantSpotVMOptions))
+				g.Expect(v1beta1conditions.IsTrue(machineScope.AzureMachine, infrav1.SpotVMRunningCondition)).To(Equal(step.wantConditionStatus))
+			}
+		})
+	}
+}
+
+func TestMachineScope_CapacityFallback(t *testing.T) {
+	g := NewWithT(t)
+	machineScope := MachineScope{
+		ClusterScoper: &ClusterScope{
+			AzureCluster: &infrav1.AzureCluster{
+				Status: infrav1.AzureClusterStatus{
+					FailureDomains: clusterv1beta1.FailureDomains{
+						"1": {ControlPlane: true},
+						"2": {ControlPlane: true},
+						"3": {ControlPlane: true},
+					},
+				},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				FailureDomain: "2",
+			},
+		},
+		AzureMachine: &infrav1.AzureMachine{
+			Spec: infrav1.AzureMachineSpec{
+				VMSize: "Standard_D2s_v3",
+				CapacityFallback: &infrav1.CapacityFallback{
+					AcrossFailureDomains: true,
+					VMSizes:              []string{"Standard_D4s_v3"},
+				},
+			},
+		},
+	}
+
+	g.Expect(machineScope.CapacityFallbackTargets()).To(Equal([]azure.AllocationTarget{
+		{FailureDomain: "1", VMSize: "Standard_D2s_v3"},
+		{FailureDomain: "3", VMSize: "Standard_D2s_v3"},
+		{FailureDomain: "2", VMSize: "Standard_D4s_v3"},
+		{FailureDomain: "1", VMSize: "Standard_D4s_v3"},
+		{FailureDomain: "3", VMSize: "Standard_D4s_v3"},
+	}))
+
+	machineScope.SetAllocationFailed("ZonalAllocationFailed", &azure.AllocationTarget{FailureDomain: "3", VMSize: "Standard_D2s_v3"})
+	g.Expect(machineScope.AvailabilityZone()).To(Equal("3"))
+	g.Expect(machineScope.VMSize()).To(Equal("Standard_D2s_v3"))
+	g.Expect(machineScope.AzureMachine.Status.FailureDomain).To(Equal("3"))
+	g.Expect(machineScope.AllocationRetryPending()).To(BeTrue())
+	machineScope.SetAllocationRetryPending(false)
+	g.Expect(machineScope.AllocationRetryPending()).To(BeFalse())
+
+	g.Expect(machineScope.CapacityFallbackTargets()).To(Equal([]azure.AllocationTarget{
+		{FailureDomain: "1", VMSize: "Standard_D2s_v3"},
+		{FailureDomain: "2", VMSize: "Standard_D4s_v3"},
+		{FailureDomain: "1", VMSize: "Standard_D4s_v3"},
+		{FailureDomain: "3", VMSize: "Standard_D4s_v3"},
+	}))
+
+	machineScope.SetAllocationFailed("ZonalAllocationFailed", &azure.AllocationTarget{FailureDomain: "2", VMSize: "Standard_D4s_v3"})
+	g.Expect(machineScope.AvailabilityZone()).To(Equal("2"))
+	g.Expect(machineScope.VMSize()).To(Equal("Standard_D4s_v3"))
+	g.Expect(machineScope.AzureMachine.Status.FailureDomain).To(BeEmpty())
+
+	machineScope.SetAllocationFailed("SkuNotAvailable", nil)
+	machineScope.SetAllocationFailed("ZonalAllocationFailed", nil)
+	attempts := machineScope.AzureMachine.Status.CapacityFallback.Attempts
+	g.Expect(attempts).To(HaveLen(3))
+	g.Expect(attempts[0].FailureDomain).To(Equal("2"))
+	g.Expect(attempts[0].VMSize).To(Equal("Standard_D2s_v3"))
+	g.Expect(attempts[1].FailureDomain).To(Equal("3"))
+	g.Expect(attempts[1].VMSize).To(Equal("Standard_D2s_v3"))
+	g.Expect(attempts[2].FailureDomain).To(Equal("2"))
+	g.Expect(attempts[2].VMSize).To(Equal("Standard_D4s_v3"))
+	g.Expect(attempts[2].Reason).To(Equal("ZonalAllocationFailed"))
+}
+
+func TestMachineScope_CapacityFallbackTargets(t *testing.T) {
+	tests := []struct {
+		name          string
+		failureDomain string
+		fallback      *infrav1.CapacityFallback
+		want          []azure.AllocationTarget
+	}{
+		{
+			name:          "no targets without capacity fallback",
+			failureDomain: "1",
+			want:          nil,
+		},
+		{
+			name:          "only VM sizes in the failure domain of the machine",
+			failureDomain: "1",
+			fallback: &infrav1.CapacityFallback{
+				VMSizes: []string{"Standard_D4s_v3"},
+			},
+			want: []azure.AllocationTarget{
+				{FailureDomain: "1", VMSize: "Standard_D4s_v3"},
+			},
+		},
+		{
+			name: "only VM sizes without failure domain",
+			fallback: &infrav1.CapacityFallback{
+				AcrossFailureDomains: true,
+				VMSizes:              []string{"Standard_D4s_v3"},
+			},
+			want: []azure.AllocationTarget{
+				{VMSize: "Standard_D4s_v3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureCluster: &infrav1.AzureCluster{
+						Status: infrav1.AzureClusterStatus{
+							FailureDomains: clusterv1beta1.FailureDomains{"1": {}, "2": {}},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: tt.failureDomain,
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						VMSize:           "Standard_D2s_v3",
+						CapacityFallback: tt.fallback,
+					},
+				},
+			}
+			got := machineScope.CapacityFallbackTargets()
+			if tt.want == nil {
+				g.Expect(got).To(BeNil())
+			} else {
+				g.Expect(got).To(Equal(tt.want))
+			}
+		})
+	}
+}
+
 func TestMachineScope_GetVirtualMachineScaleSetID(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -3466,3 +3960,93 @@ func TestMachineScope_GetVirtualMachineScaleSetID(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineScope_CostResources(t *testing.T) {
+	premiumSKU := resourceskus.SKU{
+		Capabilities: []*armcompute.ResourceSKUCapabilities{
+			{Name: ptr.To(resourceskus.VCPUs), Value: ptr.To("2")},
+			{Name: ptr.To(resourceskus.PremiumIO), Value: ptr.To(string(resourceskus.CapabilitySupported))},
+		},
+	}
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					Location: "westus2",
+				},
+			},
+		},
+	}
+	testcases := []struct {
+		name string
+		spec infrav1.AzureMachineSpec
+		sku  *resourceskus.SKU
+		want cost.Resources
+	}{
+		{
+			name: "managed disks default to premium storage when the size supports it",
+			spec: infrav1.AzureMachineSpec{
+				VMSize: "Standard_D2s_v3",
+				OSDisk: infrav1.OSDisk{DiskSizeGB: ptr.To[int32](128)},
+				DataDisks: []infrav1.DataDisk{
+					{NameSuffix: "etcd", DiskSizeGB: 256, ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: "UltraSSD_LRS"}},
+					{NameSuffix: "data", DiskSizeGB: 64},
+				},
+				AllocatePublicIP: true,
+			},
+			sku: &premiumSKU,
+			want: cost.Resources{
+				Location: "westus2",
+				VMs:      []cost.VM{{Size: "Standard_D2s_v3", VCPUs: 2, Count: 1}},
+				Disks: []cost.Disk{
+					{StorageAccountType: "Premium_LRS", SizeGiB: 128, Count: 1},
+					{StorageAccountType: "UltraSSD_LRS", SizeGiB: 256, Count: 1},
+					{StorageAccountType: "Premium_LRS", SizeGiB: 64, Count: 1},
+				},
+				PublicIPs: []string{azure.GenerateNodePublicIPName("my-machine")},
+			},
+		},
+		{
+			name: "managed disks default to standard storage without a known size",
+			spec: infrav1.AzureMachineSpec{
+				VMSize: "Standard_A2_v2",
+			},
+			want: cost.Resources{
+				Location: "westus2",
+				VMs:      []cost.VM{{Size: "Standard_A2_v2", Count: 1}},
+				Disks:    []cost.Disk{{StorageAccountType: "Standard_LRS", SizeGiB: defaultOSDiskSizeGB, Count: 1}},
+			},
+		},
+		{
+			name: "ephemeral OS disks are not billed",
+			spec: infrav1.AzureMachineSpec{
+				VMSize: "Standard_D2s_v3",
+				OSDisk: infrav1.OSDisk{DiffDiskSettings: &infrav1.DiffDiskSettings{Option: "Local"}},
+			},
+			sku: &premiumSKU,
+			want: cost.Resources{
+				Location: "westus2",
+				VMs:      []cost.VM{{Size: "Standard_D2s_v3", VCPUs: 2, Count: 1}},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machineScope := MachineScope{
+				ClusterScoper: clusterScope,
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-machine"},
+					Spec:       tc.spec,
+				},
+			}
+			if tc.sku != nil {
+				machineScope.cache = &MachineCache{VMSKU: *tc.sku}
+			}
+			g.Expect(machineScope.CostResources()).To(Equal(tc.want))
+		})
+	}
+}
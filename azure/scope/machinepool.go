@@ -18,16 +18,21 @@ package scope
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -43,6 +48,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
 	machinepool "sigs.k8s.io/cluster-api-provider-azure/azure/scope/strategies/machinepool_deployments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
@@ -60,6 +66,20 @@ import (
 // added here to avoid a circular dependency.
 const ScalesetsServiceName = "scalesets"
 
+const (
+	// DefaultBlueGreenReadyTimeout is how long a BlueGreen rollout waits for the new VMSS to become ready when
+	// MachineBlueGreenDeployment.ReadyTimeout is not set.
+	DefaultBlueGreenReadyTimeout = 20 * time.Minute
+
+	// blueGreenScaleSetSuffix is appended to the AzureMachinePool name to name the alternate VMSS of a BlueGreen
+	// rollout.
+	blueGreenScaleSetSuffix = "-g"
+
+	// DefaultRolloutProgressDeadline is how long the health gate of a rolling update may fail before the rollout is
+	// paused when MachinePoolRolloutHealthGate.ProgressDeadline is not set.
+	DefaultRolloutProgressDeadline = 10 * time.Minute
+)
+
 type (
 	// MachinePoolScopeParams defines the input parameters used to create a new MachinePoolScope.
 	MachinePoolScopeParams struct {
@@ -79,6 +99,7 @@ type (
 		patchHelper                *v1beta1patch.Helper
 		capiMachinePoolPatchHelper *v1beta1patch.Helper
 		vmssState                  *azure.VMSS
+		pendingVMSSState           *azure.VMSS
 		cache                      *MachinePoolCache
 		skuCache                   *resourceskus.Cache
 	}
@@ -175,13 +196,23 @@ func (m *MachinePoolScope) InitMachinePoolCache(ctx context.Context) error {
 	return nil
 }
 
+// CostResources returns the inventory of billable resources owned by the AzureMachinePool at its desired replica count.
+func (m *MachinePoolScope) CostResources() cost.Resources {
+	var sku resourceskus.SKU
+	if m.cache != nil {
+		sku = m.cache.VMSKU
+	}
+	template := m.AzureMachinePool.Spec.Template
+	return vmCostResources(m.Location(), template.VMSize, sku, template.OSDisk, template.DataDisks, int(m.DesiredReplicas()))
+}
+
 // ScaleSetSpec returns the scale set spec.
 func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecGetter {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.ScaleSetSpec")
 	defer done()
 
 	spec := &scalesets.ScaleSetSpec{
-		Name:                         m.Name(),
+		Name:                         m.ActiveScaleSetName(),
 		ResourceGroup:                m.NodeResourceGroup(),
 		Size:                         m.AzureMachinePool.Spec.Template.VMSize,
 		Capacity:                     int64(ptr.Deref[int32](m.MachinePool.Spec.Replicas, 0)),
@@ -193,6 +224,7 @@ func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecG
 		VNetResourceGroup:            m.Vnet().ResourceGroup,
 		PublicLBName:                 m.OutboundLBName(infrav1.Node),
 		PublicLBAddressPoolName:      m.OutboundPoolName(infrav1.Node),
+		PublicLBIPv6AddressPoolName:  m.OutboundIPv6PoolName(infrav1.Node),
 		AcceleratedNetworking:        m.AzureMachinePool.Spec.Template.NetworkInterfaces[0].AcceleratedNetworking,
 		AdditionalCapabilities:       m.AzureMachinePool.Spec.Template.AdditionalCapabilities,
 		Identity:                     m.AzureMachinePool.Spec.Identity,
@@ -212,6 +244,7 @@ func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecG
 		AdditionalTags:               m.AdditionalTags(),
 		PlatformFaultDomainCount:     m.AzureMachinePool.Spec.PlatformFaultDomainCount,
 		ZoneBalance:                  m.AzureMachinePool.Spec.ZoneBalance,
+		VNetSubscriptionID:           m.VnetSubscriptionID(),
 	}
 
 	if m.AzureMachinePool.Spec.ZoneBalance != nil && len(m.MachinePool.Spec.FailureDomains) <= 1 {
@@ -223,6 +256,9 @@ func (m *MachinePoolScope) ScaleSetSpec(ctx context.Context) azure.ResourceSpecG
 		spec.VMSSExtensionSpecs = m.VMSSExtensionSpecs()
 		spec.SKU = m.cache.VMSKU
 		spec.VMImage = m.cache.VMImage
+		if image := m.rolledBackImage(); image != nil {
+			spec.VMImage = image
+		}
 		spec.BootstrapData = m.cache.BootstrapData
 		spec.MaxSurge = m.cache.MaxSurge
 	} else {
@@ -241,6 +277,34 @@ func (m *MachinePoolScope) Name() string {
 	return m.AzureMachinePool.Name
 }
 
+// ActiveScaleSetName returns the name of the VMSS serving the machine pool. This is the Azure Machine Pool Name
+// unless a BlueGreen rollout moved the pool to its alternate VMSS.
+func (m *MachinePoolScope) ActiveScaleSetName() string {
+	if status := m.AzureMachinePool.Status.BlueGreen; status != nil && status.ActiveScaleSetName != "" {
+		return status.ActiveScaleSetName
+	}
+	return m.Name()
+}
+
+// alternateScaleSetName returns the name of the VMSS a BlueGreen rollout brings up next to the active one.
+func (m *MachinePoolScope) alternateScaleSetName() string {
+	if m.ActiveScaleSetName() != m.Name() {
+		return m.Name()
+	}
+	return m.blueGreenScaleSetName()
+}
+
+// blueGreenScaleSetName returns the name of the VMSS which alternates with the Azure Machine Pool Name across
+// BlueGreen rollouts.
+func (m *MachinePoolScope) blueGreenScaleSetName() string {
+	name := m.Name() + blueGreenScaleSetSuffix
+	// Windows Machine pools names cannot be longer than 9 chars
+	if m.AzureMachinePool.Spec.Template.OSDisk.OSType == azure.WindowsOS && len(name) > 9 {
+		name = m.Name()[:9-len(blueGreenScaleSetSuffix)] + blueGreenScaleSetSuffix
+	}
+	return name
+}
+
 // SetInfrastructureMachineKind sets the infrastructure machine kind in the status if it is not set already, returning
 // `true` if the status was updated. This supports MachinePool Machines.
 func (m *MachinePoolScope) SetInfrastructureMachineKind() bool {
@@ -304,6 +368,384 @@ func (m *MachinePoolScope) SetVMSSState(vmssState *azure.VMSS) {
 	m.vmssState = vmssState
 }
 
+// SetPendingVMSSState updates the machine pool scope with the current state of the VMSS being brought up by a BlueGreen
+// rollout.
+func (m *MachinePoolScope) SetPendingVMSSState(vmssState *azure.VMSS) {
+	m.pendingVMSSState = vmssState
+}
+
+// BlueGreenEnabled reports whether model changes are rolled out by replacing the VMSS with the BlueGreen deployment
+// strategy. Replicas managed by an external autoscaler are updated in place, as with the RollingUpdate strategy.
+func (m *MachinePoolScope) BlueGreenEnabled(ctx context.Context) bool {
+	return m.AzureMachinePool.Spec.Strategy.Type == infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType &&
+		!m.HasReplicasExternallyManaged(ctx)
+}
+
+// PendingScaleSetName returns the name of the VMSS being brought up by a BlueGreen rollout, or an empty string.
+func (m *MachinePoolScope) PendingScaleSetName() string {
+	if status := m.AzureMachinePool.Status.BlueGreen; status != nil {
+		return status.PendingScaleSetName
+	}
+	return ""
+}
+
+// UpdateBlueGreenRollout returns the name of the VMSS being brought up by a BlueGreen rollout, starting a rollout if
+// modelHash identifies a model which differs from the active VMSS. An empty modelHash means the active VMSS is already
+// on the latest model, which cancels any rollout in progress. An empty name is returned when no rollout is in progress,
+// including when the model was already rolled back or the VMSS of a previous rollout is still being retired. A pool
+// without replicas switches to the alternate VMSS directly, which is created with the latest model on the next
+// reconcile, instead of rolling it out.
+func (m *MachinePoolScope) UpdateBlueGreenRollout(modelHash string) string {
+	if m.AzureMachinePool.Status.BlueGreen == nil {
+		m.AzureMachinePool.Status.BlueGreen = &infrav1exp.AzureMachinePoolBlueGreenStatus{}
+	}
+	status := m.AzureMachinePool.Status.BlueGreen
+
+	if status.PendingScaleSetName != "" {
+		if modelHash == "" {
+			// The spec was reverted to the model of the active VMSS, so the pending VMSS is no longer needed.
+			m.retirePendingScaleSet()
+			return ""
+		}
+		status.ModelHash = modelHash
+		return status.PendingScaleSetName
+	}
+
+	if modelHash == "" {
+		status.RolledBackModelHash = ""
+		return ""
+	}
+
+	if modelHash == status.RolledBackModelHash || status.RetiringScaleSetName != "" {
+		return ""
+	}
+
+	if m.DesiredReplicas() == 0 {
+		// Without replicas there is nothing to bring up, so the alternate VMSS becomes active at once and the
+		// previously active VMSS is retired.
+		alternate := m.alternateScaleSetName()
+		status.RetiringScaleSetName = m.ActiveScaleSetName()
+		status.ActiveScaleSetName = alternate
+		return ""
+	}
+
+	status.PendingScaleSetName = m.alternateScaleSetName()
+	status.ModelHash = modelHash
+	status.RolloutStartTime = ptr.To(metav1.Now())
+	return status.PendingScaleSetName
+}
+
+// RetiringScaleSetName returns the name of the VMSS being drained by a BlueGreen rollout, or an empty string.
+func (m *MachinePoolScope) RetiringScaleSetName() string {
+	if status := m.AzureMachinePool.Status.BlueGreen; status != nil {
+		return status.RetiringScaleSetName
+	}
+	return ""
+}
+
+// SetScaleSetRetired records that the VMSS being drained by a BlueGreen rollout has been deleted.
+func (m *MachinePoolScope) SetScaleSetRetired() {
+	if status := m.AzureMachinePool.Status.BlueGreen; status != nil {
+		status.RetiringScaleSetName = ""
+	}
+}
+
+// retirePendingScaleSet abandons the VMSS being brought up by a BlueGreen rollout.
+func (m *MachinePoolScope) retirePendingScaleSet() {
+	status := m.AzureMachinePool.Status.BlueGreen
+	status.RetiringScaleSetName = status.PendingScaleSetName
+	status.PendingScaleSetName = ""
+	status.ModelHash = ""
+	status.RolloutStartTime = nil
+	m.pendingVMSSState = nil
+}
+
+// blueGreenReadyTimeout returns how long a BlueGreen rollout waits for the new VMSS to become ready.
+func (m *MachinePoolScope) blueGreenReadyTimeout() time.Duration {
+	if blueGreen := m.AzureMachinePool.Spec.Strategy.BlueGreen; blueGreen != nil && blueGreen.ReadyTimeout != nil {
+		return blueGreen.ReadyTimeout.Duration
+	}
+	return DefaultBlueGreenReadyTimeout
+}
+
+// reconcileBlueGreenRollout completes an in-progress BlueGreen rollout once every desired replica in the pending VMSS
+// is a Ready node on the latest model, retiring the previously active VMSS. If that does not happen within the ready
+// timeout, the rollout is rolled back by retiring the pending VMSS instead.
+func (m *MachinePoolScope) reconcileBlueGreenRollout(ctx context.Context, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) {
+	_, log, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.reconcileBlueGreenRollout")
+	defer done()
+
+	status := m.AzureMachinePool.Status.BlueGreen
+	if status == nil || status.PendingScaleSetName == "" {
+		return
+	}
+
+	var ready int32
+	for providerID, ampm := range machinesByProviderID {
+		if !strings.EqualFold(azure.ScaleSetNameFromProviderID(providerID), status.PendingScaleSetName) {
+			continue
+		}
+		if ampm.Status.Ready && ampm.Status.LatestModelApplied && ampm.DeletionTimestamp.IsZero() {
+			ready++
+		}
+	}
+
+	// A pool scaled to zero during the rollout has nothing to wait for, so it switches to the pending VMSS directly.
+	if ready >= m.DesiredReplicas() {
+		log.Info("blue/green rollout complete, retiring the previously active scale set", "active", status.PendingScaleSetName, "retiring", m.ActiveScaleSetName())
+		status.RetiringScaleSetName = m.ActiveScaleSetName()
+		status.ActiveScaleSetName = status.PendingScaleSetName
+		status.PendingScaleSetName = ""
+		status.ModelHash = ""
+		status.RolloutStartTime = nil
+		status.RolledBackModelHash = ""
+		return
+	}
+
+	if status.RolloutStartTime != nil && time.Since(status.RolloutStartTime.Time) > m.blueGreenReadyTimeout() {
+		log.Info("blue/green rollout timed out, rolling back", "pending", status.PendingScaleSetName, "readyReplicas", ready, "timeout", m.blueGreenReadyTimeout())
+		status.RolledBackModelHash = status.ModelHash
+		m.retirePendingScaleSet()
+	}
+}
+
+// rolloutHealthGate returns the health gate of the rolling update, or nil if the pool is not gated.
+func (m *MachinePoolScope) rolloutHealthGate() *infrav1exp.MachinePoolRolloutHealthGate {
+	return rolloutHealthGate(m.AzureMachinePool)
+}
+
+func rolloutHealthGate(amp *infrav1exp.AzureMachinePool) *infrav1exp.MachinePoolRolloutHealthGate {
+	strategy := amp.Spec.Strategy
+	if strategy.Type == infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType || strategy.RollingUpdate == nil {
+		return nil
+	}
+	return strategy.RollingUpdate.HealthGate
+}
+
+// rolloutProgressDeadline returns how long the health gate may fail before the rollout is paused.
+func (m *MachinePoolScope) rolloutProgressDeadline() time.Duration {
+	if gate := m.rolloutHealthGate(); gate != nil && gate.ProgressDeadline != nil {
+		return gate.ProgressDeadline.Duration
+	}
+	return DefaultRolloutProgressDeadline
+}
+
+// rolloutModelHash identifies the model of the pool by its image, which is what a rollback restores.
+func rolloutModelHash(image *infrav1.Image) string {
+	if image == nil {
+		return ""
+	}
+	data, err := json.Marshal(image)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// rolledBackImage returns the image restored by a rollback of the current model, or nil.
+func (m *MachinePoolScope) rolledBackImage() *infrav1.Image {
+	status := m.AzureMachinePool.Status.Rollout
+	if status == nil || status.RolledBackModelHash == "" || m.cache == nil {
+		return nil
+	}
+	if status.RolledBackModelHash != rolloutModelHash(m.cache.VMImage) {
+		return nil
+	}
+	return status.LastHealthyImage
+}
+
+// rolloutHeld reports whether the rolling update must stop replacing machines because the machines running the
+// latest model fail the health gate.
+func (m *MachinePoolScope) rolloutHeld() bool {
+	status := m.AzureMachinePool.Status.Rollout
+	return status != nil && status.ModelHash != "" && (status.Paused || status.GateFailingSince != nil)
+}
+
+// reconcileRolloutHealthGate evaluates the health gate of a rolling update. While the gate fails the rollout is held,
+// once it has failed for longer than the progress deadline the rollout is paused, and, if enabled, the image is rolled
+// back to the one of the last model that passed the gate.
+func (m *MachinePoolScope) reconcileRolloutHealthGate(ctx context.Context, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.reconcileRolloutHealthGate")
+	defer done()
+
+	gate := m.rolloutHealthGate()
+	if gate == nil {
+		m.AzureMachinePool.Status.Rollout = nil
+		return nil
+	}
+	if m.cache == nil {
+		return nil
+	}
+
+	if m.AzureMachinePool.Status.Rollout == nil {
+		m.AzureMachinePool.Status.Rollout = &infrav1exp.AzureMachinePoolRolloutStatus{}
+	}
+	status := m.AzureMachinePool.Status.Rollout
+	modelHash := rolloutModelHash(m.cache.VMImage)
+
+	if status.RolledBackModelHash != "" {
+		if status.RolledBackModelHash == modelHash {
+			// The rollback itself restores a model known to be healthy, so it is not gated.
+			return nil
+		}
+		// The image changed since the rollback, so roll out the new one.
+		status.RolledBackModelHash = ""
+	}
+
+	rolloutInProgress := false
+	for _, ampm := range machinesByProviderID {
+		if !ampm.Status.LatestModelApplied && ampm.DeletionTimestamp.IsZero() {
+			rolloutInProgress = true
+			break
+		}
+	}
+
+	if status.ModelHash != modelHash {
+		status.ModelHash = modelHash
+		status.GateFailingSince = nil
+		status.Paused = false
+		status.Message = ""
+	}
+
+	passed, message, err := m.evaluateRolloutHealthGate(ctx, gate, machinesByProviderID)
+	if err != nil {
+		return errors.Wrap(err, "failed to evaluate the rollout health gate")
+	}
+
+	if passed {
+		status.GateFailingSince = nil
+		status.Paused = false
+		status.Message = ""
+		if !rolloutInProgress && len(machinesByProviderID) >= int(m.DesiredReplicas()) {
+			status.ModelHash = ""
+			status.LastHealthyModelHash = modelHash
+			status.LastHealthyImage = m.cache.VMImage.DeepCopy()
+		}
+		return nil
+	}
+
+	status.Message = message
+	if !rolloutInProgress {
+		// Nothing is left to replace, so there is no rollout to hold.
+		status.GateFailingSince = nil
+		status.Paused = false
+		return nil
+	}
+
+	if status.GateFailingSince == nil {
+		log.Info("holding the rollout until the latest model passes the health gate", "reason", message)
+		status.GateFailingSince = ptr.To(metav1.Now())
+		return nil
+	}
+
+	if time.Since(status.GateFailingSince.Time) <= m.rolloutProgressDeadline() {
+		return nil
+	}
+
+	if !status.Paused {
+		log.Info("pausing the rollout because the latest model failed the health gate", "reason", message, "progressDeadline", m.rolloutProgressDeadline())
+		status.Paused = true
+	}
+
+	if gate.AutoRollback && status.LastHealthyImage != nil && status.LastHealthyModelHash != modelHash {
+		log.Info("rolling back to the image of the last healthy model", "image", status.LastHealthyImage)
+		status.RolledBackModelHash = modelHash
+		status.GateFailingSince = nil
+		status.Paused = false
+	}
+
+	return nil
+}
+
+// evaluateRolloutHealthGate reports whether the machines running the latest model pass the health gate, and why not
+// otherwise.
+func (m *MachinePoolScope) evaluateRolloutHealthGate(ctx context.Context, gate *infrav1exp.MachinePoolRolloutHealthGate, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (bool, string, error) {
+	checksNodes := gate.ReadinessLabel != "" || len(gate.UnhealthyNodeConditions) > 0
+
+	var unhealthy []string
+	for _, ampm := range machinesByProviderID {
+		if !ampm.Status.LatestModelApplied || !ampm.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !ampm.Status.Ready || (checksNodes && !v1beta1conditions.IsTrue(&ampm, infrav1.NodeHealthGatePassedCondition)) {
+			unhealthy = append(unhealthy, ampm.Name)
+		}
+	}
+	if len(unhealthy) > 0 {
+		slices.Sort(unhealthy)
+		return false, fmt.Sprintf("machines running the latest model are not healthy: %s", strings.Join(unhealthy, ", ")), nil
+	}
+
+	if ref := gate.ExternalGateRef; ref != nil {
+		return m.evaluateExternalGate(ctx, ref)
+	}
+
+	return true, "", nil
+}
+
+// capzManagerAggregationLabel is the label of the ClusterRoles aggregated into the CAPZ manager role, which grant it
+// access to the kinds referenced by external gates.
+const capzManagerAggregationLabel = "cluster.x-k8s.io/aggregate-to-capz-manager"
+
+// evaluateExternalGate reports whether the status condition of the object referenced by an external gate is True. The
+// CAPZ manager is only granted access to the kind of the object by a ClusterRole aggregated into its role.
+func (m *MachinePoolScope) evaluateExternalGate(ctx context.Context, ref *infrav1exp.RolloutGateReference) (bool, string, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	key := client.ObjectKey{Namespace: m.AzureMachinePool.Namespace, Name: ref.Name}
+	if err := m.client.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Sprintf("external gate %s %s not found", ref.Kind, key), nil
+		}
+		if apierrors.IsForbidden(err) {
+			return false, fmt.Sprintf("not allowed to get external gate %s %s, grant access with a ClusterRole labeled %s: \"true\"", ref.Kind, key, capzManagerAggregationLabel), nil
+		}
+		return false, "", errors.Wrapf(err, "failed to get external gate %s %s", ref.Kind, key)
+	}
+
+	conditionType := ref.ConditionType
+	if conditionType == "" {
+		conditionType = string(clusterv1beta1.ReadyCondition)
+	}
+
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, "", errors.Wrapf(err, "failed to read the conditions of external gate %s %s", ref.Kind, key)
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		if condition["status"] == string(metav1.ConditionTrue) {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("external gate %s %s condition %s is not True: %v", ref.Kind, key, conditionType, condition["message"]), nil
+	}
+
+	return false, fmt.Sprintf("external gate %s %s has no %s condition", ref.Kind, key, conditionType), nil
+}
+
+// nodeHealthGateFailure returns why a node fails the node checks of a health gate, or an empty string if it passes.
+func nodeHealthGateFailure(gate *infrav1exp.MachinePoolRolloutHealthGate, node *corev1.Node) string {
+	for _, condition := range node.Status.Conditions {
+		if slices.Contains(gate.UnhealthyNodeConditions, condition.Type) && condition.Status == corev1.ConditionTrue {
+			return fmt.Sprintf("node condition %s is True", condition.Type)
+		}
+	}
+
+	if gate.ReadinessLabel != "" {
+		key, value, hasValue := strings.Cut(gate.ReadinessLabel, "=")
+		actual, ok := node.Labels[key]
+		if !ok || (hasValue && actual != value) {
+			return fmt.Sprintf("node is missing label %s", gate.ReadinessLabel)
+		}
+	}
+
+	return ""
+}
+
 // NeedsRequeue returns true if the VMSS is not in a terminal provisioning state, desired replicas do not match actual,
 // or (when SkipMachinePoolModelReconciliation is disabled) any machines are not on the latest model.
 func (m *MachinePoolScope) NeedsRequeue() bool {
@@ -312,6 +754,11 @@ func (m *MachinePoolScope) NeedsRequeue() bool {
 		return state != nil && infrav1.IsTerminalProvisioningState(*state)
 	}
 
+	// A BlueGreen rollout progresses as the pending VMSS becomes ready or the old VMSS drains.
+	if status := m.AzureMachinePool.Status.BlueGreen; status != nil && (status.PendingScaleSetName != "" || status.RetiringScaleSetName != "") {
+		return true
+	}
+
 	// Skip requeue for model state when SkipMachinePoolModelReconciliation is enabled.
 	// This allows instances with stale models to persist until explicitly scaled.
 	if !feature.Gates.Enabled(feature.SkipMachinePoolModelReconciliation) {
@@ -459,6 +906,9 @@ func (m *MachinePoolScope) applyAzureMachinePoolMachines(ctx context.Context) er
 
 	// determine which machines need to be created to reflect the current state in Azure
 	azureMachinesByProviderID := m.vmssState.InstancesByProviderID(m.AzureMachinePool.Spec.OrchestrationMode)
+	if m.pendingVMSSState != nil {
+		maps.Copy(azureMachinesByProviderID, m.pendingVMSSState.InstancesByProviderID(m.AzureMachinePool.Spec.OrchestrationMode))
+	}
 	for key, val := range azureMachinesByProviderID {
 		if val.State == infrav1.Deleting || val.State == infrav1.Deleted {
 			log.V(4).Info("not recreating AzureMachinePoolMachine because VMSS VM is deleting", "providerID", key)
@@ -477,6 +927,10 @@ func (m *MachinePoolScope) applyAzureMachinePoolMachines(ctx context.Context) er
 	// Delete MachinePool Machines for instances that no longer exist in Azure, i.e. deleted out-of-band
 	for key, ampm := range existingMachinesByProviderID {
 		if _, ok := azureMachinesByProviderID[key]; !ok {
+			if !m.isScaleSetStateKnown(key) {
+				// The instances of the pending or retiring VMSS of a BlueGreen rollout are not listed on every reconcile.
+				continue
+			}
 			deleted = true
 			log.V(4).Info("deleting AzureMachinePoolMachine because it no longer exists in the VMSS", "providerID", key)
 			delete(existingMachinesByProviderID, key)
@@ -492,9 +946,9 @@ func (m *MachinePoolScope) applyAzureMachinePoolMachines(ctx context.Context) er
 		return nil
 	}
 
-	if futures.Has(m.AzureMachinePool, m.Name(), ScalesetsServiceName, infrav1.PatchFuture) ||
-		futures.Has(m.AzureMachinePool, m.Name(), ScalesetsServiceName, infrav1.PutFuture) ||
-		futures.Has(m.AzureMachinePool, m.Name(), ScalesetsServiceName, infrav1.DeleteFuture) {
+	if futures.Has(m.AzureMachinePool, m.ActiveScaleSetName(), ScalesetsServiceName, infrav1.PatchFuture) ||
+		futures.Has(m.AzureMachinePool, m.ActiveScaleSetName(), ScalesetsServiceName, infrav1.PutFuture) ||
+		futures.Has(m.AzureMachinePool, m.ActiveScaleSetName(), ScalesetsServiceName, infrav1.DeleteFuture) {
 		log.V(4).Info("exiting early due an in-progress long running operation on the ScaleSet")
 		// exit early to be less greedy about delete
 		return nil
@@ -517,11 +971,26 @@ func (m *MachinePoolScope) applyAzureMachinePoolMachines(ctx context.Context) er
 	// LatestModelApplied status stale (e.g. true for old-model instances that have not yet been re-reconciled).
 	for key, ampm := range existingMachinesByProviderID {
 		if vm, ok := azureMachinesByProviderID[key]; ok {
-			ampm.Status.LatestModelApplied = m.vmssState.HasLatestModelApplied(vm)
+			ampm.Status.LatestModelApplied = m.scaleSetStateFor(key).HasLatestModelApplied(vm)
 			existingMachinesByProviderID[key] = ampm
 		}
 	}
 
+	// Complete or roll back a BlueGreen rollout before selecting machines, so that the retired VMSS starts draining
+	// in this reconcile.
+	if m.AzureMachinePool.Status.BlueGreen != nil {
+		m.reconcileBlueGreenRollout(ctx, existingMachinesByProviderID)
+		deleteSelector = m.getDeploymentStrategy()
+	}
+
+	// Hold the rolling update while the machines running the latest model fail the health gate.
+	if m.rolloutHealthGate() != nil || m.AzureMachinePool.Status.Rollout != nil {
+		if err := m.reconcileRolloutHealthGate(ctx, existingMachinesByProviderID); err != nil {
+			return err
+		}
+		deleteSelector = m.getDeploymentStrategy()
+	}
+
 	// Select Machines to delete to lower the replica count
 	toDelete, err := deleteSelector.SelectMachinesToDelete(ctx, m.DesiredReplicas(), existingMachinesByProviderID, m.RolloutInProgress())
 	if err != nil {
@@ -547,6 +1016,33 @@ func (m *MachinePoolScope) applyAzureMachinePoolMachines(ctx context.Context) er
 	return nil
 }
 
+// scaleSetStateFor returns the state of the VMSS owning the instance with the given provider ID.
+func (m *MachinePoolScope) scaleSetStateFor(providerID string) *azure.VMSS {
+	if m.pendingVMSSState != nil && strings.EqualFold(azure.ScaleSetNameFromProviderID(providerID), m.pendingVMSSState.Name) {
+		return m.pendingVMSSState
+	}
+	return m.vmssState
+}
+
+// isScaleSetStateKnown reports whether the instances of the VMSS owning the instance with the given provider ID were
+// listed during this reconcile. Only the active VMSS and, during a BlueGreen rollout, the pending VMSS are listed.
+func (m *MachinePoolScope) isScaleSetStateKnown(providerID string) bool {
+	status := m.AzureMachinePool.Status.BlueGreen
+	if status == nil {
+		return true
+	}
+
+	scaleSetName := azure.ScaleSetNameFromProviderID(providerID)
+	switch {
+	case status.RetiringScaleSetName != "" && strings.EqualFold(scaleSetName, status.RetiringScaleSetName):
+		return false
+	case status.PendingScaleSetName != "" && strings.EqualFold(scaleSetName, status.PendingScaleSetName):
+		return m.pendingVMSSState != nil
+	default:
+		return true
+	}
+}
+
 func (m *MachinePoolScope) createMachine(ctx context.Context, machine azure.VMSSVM) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.createMachine")
 	defer done()
@@ -557,9 +1053,18 @@ func (m *MachinePoolScope) createMachine(ctx context.Context, machine azure.VMSS
 	}
 	instanceID := strings.ReplaceAll(parsed.Name, "_", "-")
 
+	name := m.AzureMachinePool.Name + "-" + instanceID
+	// Uniform instance IDs are only unique within a VMSS, so instances of the alternate VMSS of a BlueGreen rollout
+	// are named after it.
+	if machine.OrchestrationMode != infrav1.FlexibleOrchestrationMode {
+		if scaleSetName := azure.ScaleSetNameFromProviderID(machine.ProviderID()); strings.EqualFold(scaleSetName, m.blueGreenScaleSetName()) {
+			name = m.AzureMachinePool.Name + blueGreenScaleSetSuffix + "-" + instanceID
+		}
+	}
+
 	ampm := infrav1exp.AzureMachinePoolMachine{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      m.AzureMachinePool.Name + "-" + instanceID,
+			Name:      name,
 			Namespace: m.AzureMachinePool.Namespace,
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -622,16 +1127,20 @@ func (m *MachinePoolScope) DeleteMachine(ctx context.Context, ampm infrav1exp.Az
 // in the next reconciliation.
 func (m *MachinePoolScope) SetLongRunningOperationState(future *infrav1.Future) {
 	futures.Set(m.AzureMachinePool, future)
+	futures.Track(m.AzureMachinePool, m.SubscriptionID(), future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureMachinePool status.
 func (m *MachinePoolScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
-	return futures.Get(m.AzureMachinePool, name, service, futureType)
+	// A stored future is in flight, e.g. when it is resumed after a restart.
+	future := futures.Get(m.AzureMachinePool, name, service, futureType)
+	futures.Track(m.AzureMachinePool, m.SubscriptionID(), future)
+	return future
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureMachinePool status.
 func (m *MachinePoolScope) DeleteLongRunningOperationState(name, service, futureType string) {
-	futures.Delete(m.AzureMachinePool, name, service, futureType)
+	futures.Delete(m.AzureMachinePool, m.SubscriptionID(), name, service, futureType)
 }
 
 // setProvisioningStateAndConditions sets the AzureMachinePool provisioning state and conditions.
@@ -673,6 +1182,26 @@ func (m *MachinePoolScope) setProvisioningStateAndConditions(v infrav1.Provision
 		// Preserve readiness for unhandled provisioning states.
 		v1beta1conditions.MarkFalse(m.AzureMachinePool, infrav1.ScaleSetRunningCondition, string(v), clusterv1beta1.ConditionSeverityInfo, "")
 	}
+
+	if status := m.AzureMachinePool.Status.BlueGreen; status != nil {
+		switch {
+		case status.PendingScaleSetName != "":
+			v1beta1conditions.MarkFalse(m.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition, infrav1.ScaleSetBlueGreenRolloutReason, clusterv1beta1.ConditionSeverityInfo, "rolling out scale set %s", status.PendingScaleSetName)
+		case status.RolledBackModelHash != "":
+			v1beta1conditions.MarkFalse(m.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition, infrav1.ScaleSetBlueGreenRolledBackReason, clusterv1beta1.ConditionSeverityWarning, "the latest model did not become ready within %s and was rolled back", m.blueGreenReadyTimeout())
+		}
+	}
+
+	if status := m.AzureMachinePool.Status.Rollout; status != nil {
+		switch {
+		case status.RolledBackModelHash != "":
+			v1beta1conditions.MarkFalse(m.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition, infrav1.ScaleSetRolloutRolledBackReason, clusterv1beta1.ConditionSeverityWarning, "the latest image failed the health gate and was rolled back: %s", status.Message)
+		case status.Paused:
+			v1beta1conditions.MarkFalse(m.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition, infrav1.ScaleSetRolloutPausedReason, clusterv1beta1.ConditionSeverityWarning, "rollout paused: %s", status.Message)
+		case status.ModelHash != "" && status.GateFailingSince != nil:
+			v1beta1conditions.MarkFalse(m.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition, infrav1.ScaleSetRolloutWaitingForHealthGateReason, clusterv1beta1.ConditionSeverityInfo, "waiting for the health gate: %s", status.Message)
+		}
+	}
 }
 
 // SetReady sets the AzureMachinePool Ready Status to true.
@@ -835,7 +1364,7 @@ func (m *MachinePoolScope) RoleAssignmentSpecs(principalID *string) []azure.Reso
 	if m.HasSystemAssignedIdentity() {
 		roles[0] = &roleassignments.RoleAssignmentSpec{
 			Name:             m.SystemAssignedIdentityName(),
-			MachineName:      m.Name(),
+			MachineName:      m.ActiveScaleSetName(),
 			ResourceGroup:    m.NodeResourceGroup(),
 			ResourceType:     azure.VirtualMachineScaleSet,
 			Scope:            m.SystemAssignedIdentityScope(),
@@ -867,7 +1396,7 @@ func (m *MachinePoolScope) VMSSExtensionSpecs() []azure.ResourceSpecGetter {
 		extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
 			ExtensionSpec: azure.ExtensionSpec{
 				Name:              extension.Name,
-				VMName:            m.Name(),
+				VMName:            m.ActiveScaleSetName(),
 				Publisher:         extension.Publisher,
 				Version:           extension.Version,
 				Settings:          extension.Settings,
@@ -879,7 +1408,7 @@ func (m *MachinePoolScope) VMSSExtensionSpecs() []azure.ResourceSpecGetter {
 
 	if !ptr.Deref(m.AzureMachinePool.Spec.Template.DisableVMBootstrapExtension, true) {
 		cpuArchitectureType, _ := m.cache.VMSKU.GetCapability(resourceskus.CPUArchitectureType)
-		bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.CloudEnvironment(), m.Name(), cpuArchitectureType)
+		bootstrapExtensionSpec := azure.GetBootstrappingVMExtension(m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.CloudEnvironment(), m.ActiveScaleSetName(), cpuArchitectureType)
 
 		if bootstrapExtensionSpec != nil {
 			extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
@@ -897,7 +1426,21 @@ func (m *MachinePoolScope) getDeploymentStrategy() machinepool.TypedDeleteSelect
 		return nil
 	}
 
-	return machinepool.NewMachinePoolDeploymentStrategy(m.AzureMachinePool.Spec.Strategy)
+	strategy := m.AzureMachinePool.Spec.Strategy
+	if strategy.Type == infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType {
+		scaleSets := machinepool.BlueGreenScaleSets{Active: m.ActiveScaleSetName()}
+		if status := m.AzureMachinePool.Status.BlueGreen; status != nil {
+			scaleSets.Pending = status.PendingScaleSetName
+			scaleSets.Retiring = status.RetiringScaleSetName
+		}
+		return machinepool.NewBlueGreenStrategy(strategy.BlueGreen, scaleSets)
+	}
+
+	if m.rolloutHealthGate() != nil {
+		return machinepool.NewRollingUpdateStrategy(strategy.RollingUpdate, m.rolloutHeld())
+	}
+
+	return machinepool.NewMachinePoolDeploymentStrategy(strategy)
 }
 
 // SetSubnetName defaults the AzureMachinePool subnet name to the name of the subnet with role 'node' when there is only one of them.
@@ -1022,7 +1565,7 @@ func (m *MachinePoolScope) UpdateAnnotationJSON(annotation string, content map[s
 func (m *MachinePoolScope) TagsSpecs() []azure.TagsSpec {
 	return []azure.TagsSpec{
 		{
-			Scope:      azure.VMSSID(m.SubscriptionID(), m.NodeResourceGroup(), m.Name()),
+			Scope:      azure.VMSSID(m.SubscriptionID(), m.NodeResourceGroup(), m.ActiveScaleSetName()),
 			Tags:       m.AdditionalTags(),
 			Annotation: azure.VMSSTagsLastAppliedAnnotation,
 		},
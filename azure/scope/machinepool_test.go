@@ -18,6 +18,7 @@ package scope
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -28,18 +29,24 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
@@ -741,6 +748,532 @@ func TestMachinePoolScope_RolloutInProgress(t *testing.T) {
 	}
 }
 
+func TestMachinePoolScope_UpdateBlueGreenRollout(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Replicas       int32
+		Status         *infrav1exp.AzureMachinePoolBlueGreenStatus
+		ModelHash      string
+		ExpectedName   string
+		ExpectedStatus infrav1exp.AzureMachinePoolBlueGreenStatus
+	}{
+		{
+			Name:           "no rollout when the active VMSS is on the latest model",
+			Replicas:       2,
+			ModelHash:      "",
+			ExpectedName:   "",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{},
+		},
+		{
+			Name:           "starts a rollout to the alternate VMSS",
+			Replicas:       2,
+			ModelHash:      "new",
+			ExpectedName:   "mp-g",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{PendingScaleSetName: "mp-g", ModelHash: "new"},
+		},
+		{
+			Name:           "rolls back to the AzureMachinePool name from the alternate VMSS",
+			Replicas:       2,
+			Status:         &infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g"},
+			ModelHash:      "new",
+			ExpectedName:   "mp",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g", PendingScaleSetName: "mp", ModelHash: "new"},
+		},
+		{
+			Name:           "continues a rollout in progress",
+			Replicas:       2,
+			Status:         &infrav1exp.AzureMachinePoolBlueGreenStatus{PendingScaleSetName: "mp-g", ModelHash: "new"},
+			ModelHash:      "newer",
+			ExpectedName:   "mp-g",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{PendingScaleSetName: "mp-g", ModelHash: "newer"},
+		},
+		{
+			Name:           "cancels a rollout in progress when the model is reverted",
+			Replicas:       2,
+			Status:         &infrav1exp.AzureMachinePoolBlueGreenStatus{PendingScaleSetName: "mp-g", ModelHash: "new"},
+			ModelHash:      "",
+			ExpectedName:   "",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{RetiringScaleSetName: "mp-g"},
+		},
+		{
+			Name:           "does not roll out a model which was rolled back",
+			Replicas:       2,
+			Status:         &infrav1exp.AzureMachinePoolBlueGreenStatus{RolledBackModelHash: "new"},
+			ModelHash:      "new",
+			ExpectedName:   "",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{RolledBackModelHash: "new"},
+		},
+		{
+			Name:           "does not start a rollout while the previous VMSS is retiring",
+			Replicas:       2,
+			Status:         &infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g", RetiringScaleSetName: "mp"},
+			ModelHash:      "new",
+			ExpectedName:   "",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g", RetiringScaleSetName: "mp"},
+		},
+		{
+			Name:           "forgets a rolled back model once the active VMSS is on the latest model",
+			Replicas:       2,
+			Status:         &infrav1exp.AzureMachinePoolBlueGreenStatus{RolledBackModelHash: "new"},
+			ModelHash:      "",
+			ExpectedName:   "",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{},
+		},
+		{
+			Name:           "switches to the alternate VMSS directly without replicas",
+			Replicas:       0,
+			ModelHash:      "new",
+			ExpectedName:   "",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g", RetiringScaleSetName: "mp"},
+		},
+		{
+			Name:           "switches back to the AzureMachinePool name directly without replicas",
+			Replicas:       0,
+			Status:         &infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g", RolledBackModelHash: "old"},
+			ModelHash:      "new",
+			ExpectedName:   "",
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp", RetiringScaleSetName: "mp-g", RolledBackModelHash: "old"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &MachinePoolScope{
+				MachinePool: &clusterv1.MachinePool{
+					Spec: clusterv1.MachinePoolSpec{Replicas: ptr.To(c.Replicas)},
+				},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "mp"},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Strategy: infrav1exp.AzureMachinePoolDeploymentStrategy{
+							Type: infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+						},
+					},
+					Status: infrav1exp.AzureMachinePoolStatus{BlueGreen: c.Status},
+				},
+			}
+			g.Expect(s.UpdateBlueGreenRollout(c.ModelHash)).To(Equal(c.ExpectedName))
+
+			status := *s.AzureMachinePool.Status.BlueGreen
+			if c.ExpectedStatus.PendingScaleSetName != "" && (c.Status == nil || c.Status.PendingScaleSetName == "") {
+				g.Expect(status.RolloutStartTime).NotTo(BeNil())
+			}
+			status.RolloutStartTime = nil
+			g.Expect(status).To(Equal(c.ExpectedStatus))
+		})
+	}
+}
+
+func TestMachinePoolScope_reconcileBlueGreenRollout(t *testing.T) {
+	var (
+		blue  = "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/mp/virtualMachines/%d"
+		green = "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/mp-g/virtualMachines/%d"
+		ampm  = func(format string, i int, ready bool) infrav1exp.AzureMachinePoolMachine {
+			return infrav1exp.AzureMachinePoolMachine{
+				Spec:   infrav1exp.AzureMachinePoolMachineSpec{ProviderID: fmt.Sprintf(format, i)},
+				Status: infrav1exp.AzureMachinePoolMachineStatus{Ready: ready, LatestModelApplied: true},
+			}
+		}
+		machines = func(ampms ...infrav1exp.AzureMachinePoolMachine) map[string]infrav1exp.AzureMachinePoolMachine {
+			m := map[string]infrav1exp.AzureMachinePoolMachine{}
+			for _, ampm := range ampms {
+				m[ampm.Spec.ProviderID] = ampm
+			}
+			return m
+		}
+	)
+
+	cases := []struct {
+		Name           string
+		Replicas       int32
+		RolloutStarted time.Time
+		Machines       map[string]infrav1exp.AzureMachinePoolMachine
+		ExpectedStatus infrav1exp.AzureMachinePoolBlueGreenStatus
+		ExpectedReason string
+	}{
+		{
+			Name:           "waits for the pending VMSS to become ready",
+			Replicas:       2,
+			RolloutStarted: time.Now(),
+			Machines:       machines(ampm(blue, 0, true), ampm(blue, 1, true), ampm(green, 0, true), ampm(green, 1, false)),
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{PendingScaleSetName: "mp-g", ModelHash: "new"},
+			ExpectedReason: infrav1.ScaleSetBlueGreenRolloutReason,
+		},
+		{
+			Name:           "retires the active VMSS once every pending instance is ready",
+			Replicas:       2,
+			RolloutStarted: time.Now(),
+			Machines:       machines(ampm(blue, 0, true), ampm(blue, 1, true), ampm(green, 0, true), ampm(green, 1, true)),
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g", RetiringScaleSetName: "mp"},
+		},
+		{
+			Name:           "rolls back when the pending VMSS is not ready in time",
+			Replicas:       2,
+			RolloutStarted: time.Now().Add(-time.Hour),
+			Machines:       machines(ampm(blue, 0, true), ampm(blue, 1, true), ampm(green, 0, true), ampm(green, 1, false)),
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{RetiringScaleSetName: "mp-g", RolledBackModelHash: "new"},
+			ExpectedReason: infrav1.ScaleSetBlueGreenRolledBackReason,
+		},
+		{
+			Name:           "switches to the pending VMSS directly when the pool was scaled to zero",
+			Replicas:       0,
+			RolloutStarted: time.Now(),
+			Machines:       machines(ampm(blue, 0, true), ampm(blue, 1, true)),
+			ExpectedStatus: infrav1exp.AzureMachinePoolBlueGreenStatus{ActiveScaleSetName: "mp-g", RetiringScaleSetName: "mp"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &MachinePoolScope{
+				MachinePool: &clusterv1.MachinePool{
+					Spec: clusterv1.MachinePoolSpec{Replicas: ptr.To(c.Replicas)},
+				},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "mp"},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Strategy: infrav1exp.AzureMachinePoolDeploymentStrategy{
+							Type: infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+							BlueGreen: &infrav1exp.MachineBlueGreenDeployment{
+								ReadyTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+							},
+						},
+					},
+					Status: infrav1exp.AzureMachinePoolStatus{
+						BlueGreen: &infrav1exp.AzureMachinePoolBlueGreenStatus{
+							PendingScaleSetName: "mp-g",
+							ModelHash:           "new",
+							RolloutStartTime:    ptr.To(metav1.NewTime(c.RolloutStarted)),
+						},
+					},
+				},
+			}
+			s.reconcileBlueGreenRollout(t.Context(), c.Machines)
+
+			status := *s.AzureMachinePool.Status.BlueGreen
+			status.RolloutStartTime = nil
+			g.Expect(status).To(Equal(c.ExpectedStatus))
+
+			s.setProvisioningStateAndConditions(infrav1.Succeeded)
+			if c.ExpectedReason != "" {
+				g.Expect(v1beta1conditions.GetReason(s.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition)).To(Equal(c.ExpectedReason))
+			}
+		})
+	}
+}
+
+func TestMachinePoolScope_reconcileRolloutHealthGate(t *testing.T) {
+	var (
+		oldImage = &infrav1.Image{Marketplace: &infrav1.AzureMarketplaceImage{ImagePlan: infrav1.ImagePlan{Publisher: "p", Offer: "o", SKU: "s"}, Version: "1.0.0"}}
+		newImage = &infrav1.Image{Marketplace: &infrav1.AzureMarketplaceImage{ImagePlan: infrav1.ImagePlan{Publisher: "p", Offer: "o", SKU: "s"}, Version: "2.0.0"}}
+		ampm     = func(name string, ready, latest bool) infrav1exp.AzureMachinePoolMachine {
+			return infrav1exp.AzureMachinePoolMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       infrav1exp.AzureMachinePoolMachineSpec{ProviderID: "azure://" + name},
+				Status:     infrav1exp.AzureMachinePoolMachineStatus{Ready: ready, LatestModelApplied: latest},
+			}
+		}
+		machines = func(ampms ...infrav1exp.AzureMachinePoolMachine) map[string]infrav1exp.AzureMachinePoolMachine {
+			m := map[string]infrav1exp.AzureMachinePoolMachine{}
+			for _, ampm := range ampms {
+				m[ampm.Spec.ProviderID] = ampm
+			}
+			return m
+		}
+		healthyStatus = func() *infrav1exp.AzureMachinePoolRolloutStatus {
+			return &infrav1exp.AzureMachinePoolRolloutStatus{
+				LastHealthyModelHash: rolloutModelHash(oldImage),
+				LastHealthyImage:     oldImage,
+			}
+		}
+		failingStatus = func(since time.Time) *infrav1exp.AzureMachinePoolRolloutStatus {
+			status := healthyStatus()
+			status.ModelHash = rolloutModelHash(newImage)
+			status.GateFailingSince = ptr.To(metav1.NewTime(since))
+			return status
+		}
+		externalGate = func(status string) *unstructured.Unstructured {
+			gate := &unstructured.Unstructured{}
+			gate.SetAPIVersion("example.com/v1")
+			gate.SetKind("RolloutCheck")
+			gate.SetNamespace("default")
+			gate.SetName("check")
+			_ = unstructured.SetNestedSlice(gate.Object, []any{
+				map[string]any{"type": "Ready", "status": status},
+			}, "status", "conditions")
+			return gate
+		}
+	)
+
+	cases := []struct {
+		Name            string
+		Image           *infrav1.Image
+		Gate            infrav1exp.MachinePoolRolloutHealthGate
+		Status          *infrav1exp.AzureMachinePoolRolloutStatus
+		Machines        map[string]infrav1exp.AzureMachinePoolMachine
+		Objects         []client.Object
+		ClientFuncs     interceptor.Funcs
+		ExpectHeld      bool
+		ExpectPaused    bool
+		ExpectRollback  bool
+		ExpectHealthy   string
+		ExpectedReason  string
+		ExpectedMessage string
+	}{
+		{
+			Name:          "records the model of a converged pool passing the gate as healthy",
+			Image:         newImage,
+			Machines:      machines(ampm("a", true, true), ampm("b", true, true)),
+			ExpectHealthy: rolloutModelHash(newImage),
+		},
+		{
+			Name:            "holds the rollout when a machine running the latest model is not ready",
+			Image:           newImage,
+			Status:          healthyStatus(),
+			Machines:        machines(ampm("a", true, false), ampm("b", true, false), ampm("c", false, true)),
+			ExpectHeld:      true,
+			ExpectHealthy:   rolloutModelHash(oldImage),
+			ExpectedReason:  infrav1.ScaleSetRolloutWaitingForHealthGateReason,
+			ExpectedMessage: "waiting for the health gate: machines running the latest model are not healthy: c",
+		},
+		{
+			Name:          "proceeds once the machines running the latest model are healthy",
+			Image:         newImage,
+			Status:        failingStatus(time.Now()),
+			Machines:      machines(ampm("a", true, false), ampm("b", true, true), ampm("c", true, true)),
+			ExpectHealthy: rolloutModelHash(oldImage),
+		},
+		{
+			Name:   "holds the rollout when a node fails the node checks",
+			Image:  newImage,
+			Gate:   infrav1exp.MachinePoolRolloutHealthGate{ReadinessLabel: "example.com/ready"},
+			Status: healthyStatus(),
+			Machines: machines(ampm("a", true, false), func() infrav1exp.AzureMachinePoolMachine {
+				m := ampm("b", true, true)
+				v1beta1conditions.MarkFalse(&m, infrav1.NodeHealthGatePassedCondition, infrav1.NodeHealthGateFailedReason, clusterv1beta1.ConditionSeverityWarning, "")
+				return m
+			}()),
+			ExpectHeld:    true,
+			ExpectHealthy: rolloutModelHash(oldImage),
+		},
+		{
+			Name:            "pauses the rollout once the gate fails for longer than the progress deadline",
+			Image:           newImage,
+			Status:          failingStatus(time.Now().Add(-time.Hour)),
+			Machines:        machines(ampm("a", true, false), ampm("b", true, false), ampm("c", false, true)),
+			ExpectHeld:      true,
+			ExpectPaused:    true,
+			ExpectHealthy:   rolloutModelHash(oldImage),
+			ExpectedReason:  infrav1.ScaleSetRolloutPausedReason,
+			ExpectedMessage: "rollout paused: machines running the latest model are not healthy: c",
+		},
+		{
+			Name:           "rolls back to the last healthy image once the gate fails for longer than the progress deadline",
+			Image:          newImage,
+			Gate:           infrav1exp.MachinePoolRolloutHealthGate{AutoRollback: true},
+			Status:         failingStatus(time.Now().Add(-time.Hour)),
+			Machines:       machines(ampm("a", true, false), ampm("b", true, false), ampm("c", false, true)),
+			ExpectRollback: true,
+			ExpectHealthy:  rolloutModelHash(oldImage),
+			ExpectedReason: infrav1.ScaleSetRolloutRolledBackReason,
+		},
+		{
+			Name:  "holds the rollout while the external gate is not True",
+			Image: newImage,
+			Gate: infrav1exp.MachinePoolRolloutHealthGate{
+				ExternalGateRef: &infrav1exp.RolloutGateReference{APIVersion: "example.com/v1", Kind: "RolloutCheck", Name: "check", ConditionType: "Ready"},
+			},
+			Status:          healthyStatus(),
+			Machines:        machines(ampm("a", true, false), ampm("b", true, true)),
+			Objects:         []client.Object{externalGate("False")},
+			ExpectHeld:      true,
+			ExpectHealthy:   rolloutModelHash(oldImage),
+			ExpectedReason:  infrav1.ScaleSetRolloutWaitingForHealthGateReason,
+			ExpectedMessage: "waiting for the health gate: external gate RolloutCheck default/check condition Ready is not True: <nil>",
+		},
+		{
+			Name:  "proceeds once the external gate is True",
+			Image: newImage,
+			Gate: infrav1exp.MachinePoolRolloutHealthGate{
+				ExternalGateRef: &infrav1exp.RolloutGateReference{APIVersion: "example.com/v1", Kind: "RolloutCheck", Name: "check", ConditionType: "Ready"},
+			},
+			Status:        failingStatus(time.Now()),
+			Machines:      machines(ampm("a", true, false), ampm("b", true, true)),
+			Objects:       []client.Object{externalGate("True")},
+			ExpectHealthy: rolloutModelHash(oldImage),
+		},
+		{
+			Name:  "holds the rollout while the external gate cannot be read",
+			Image: newImage,
+			Gate: infrav1exp.MachinePoolRolloutHealthGate{
+				ExternalGateRef: &infrav1exp.RolloutGateReference{APIVersion: "example.com/v1", Kind: "RolloutCheck", Name: "check", ConditionType: "Ready"},
+			},
+			Status:   healthyStatus(),
+			Machines: machines(ampm("a", true, false), ampm("b", true, true)),
+			ClientFuncs: interceptor.Funcs{
+				Get: func(_ context.Context, _ client.WithWatch, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+					return apierrors.NewForbidden(schema.GroupResource{Group: "example.com", Resource: "rolloutchecks"}, key.Name, errors.New("forbidden"))
+				},
+			},
+			ExpectHeld:      true,
+			ExpectHealthy:   rolloutModelHash(oldImage),
+			ExpectedReason:  infrav1.ScaleSetRolloutWaitingForHealthGateReason,
+			ExpectedMessage: `waiting for the health gate: not allowed to get external gate RolloutCheck default/check, grant access with a ClusterRole labeled cluster.x-k8s.io/aggregate-to-capz-manager: "true"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			gate := c.Gate
+			s := &MachinePoolScope{
+				client: fake.NewClientBuilder().WithObjects(c.Objects...).WithInterceptorFuncs(c.ClientFuncs).Build(),
+				MachinePool: &clusterv1.MachinePool{
+					Spec: clusterv1.MachinePoolSpec{Replicas: ptr.To[int32](2)},
+				},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "mp", Namespace: "default"},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Strategy: infrav1exp.AzureMachinePoolDeploymentStrategy{
+							Type: infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType,
+							RollingUpdate: &infrav1exp.MachineRollingUpdateDeployment{
+								HealthGate: &gate,
+							},
+						},
+					},
+					Status: infrav1exp.AzureMachinePoolStatus{Rollout: c.Status},
+				},
+				cache: &MachinePoolCache{VMImage: c.Image},
+			}
+
+			g.Expect(s.reconcileRolloutHealthGate(t.Context(), c.Machines)).To(Succeed())
+
+			status := s.AzureMachinePool.Status.Rollout
+			g.Expect(s.rolloutHeld()).To(Equal(c.ExpectHeld))
+			g.Expect(status.Paused).To(Equal(c.ExpectPaused))
+			g.Expect(status.LastHealthyModelHash).To(Equal(c.ExpectHealthy))
+			if c.ExpectRollback {
+				g.Expect(status.RolledBackModelHash).To(Equal(rolloutModelHash(newImage)))
+				g.Expect(s.rolledBackImage()).To(Equal(oldImage))
+			} else {
+				g.Expect(status.RolledBackModelHash).To(BeEmpty())
+				g.Expect(s.rolledBackImage()).To(BeNil())
+			}
+
+			s.setProvisioningStateAndConditions(infrav1.Succeeded)
+			if c.ExpectedReason != "" {
+				g.Expect(v1beta1conditions.GetReason(s.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition)).To(Equal(c.ExpectedReason))
+			}
+			if c.ExpectedMessage != "" {
+				g.Expect(v1beta1conditions.GetMessage(s.AzureMachinePool, infrav1.ScaleSetModelUpdatedCondition)).To(Equal(c.ExpectedMessage))
+			}
+		})
+	}
+}
+
+func TestMachinePoolScope_reconcileRolloutHealthGate_afterRollback(t *testing.T) {
+	g := NewWithT(t)
+	oldImage := &infrav1.Image{ID: ptr.To("old")}
+	newImage := &infrav1.Image{ID: ptr.To("new")}
+	newerImage := &infrav1.Image{ID: ptr.To("newer")}
+
+	s := &MachinePoolScope{
+		MachinePool: &clusterv1.MachinePool{Spec: clusterv1.MachinePoolSpec{Replicas: ptr.To[int32](1)}},
+		AzureMachinePool: &infrav1exp.AzureMachinePool{
+			Spec: infrav1exp.AzureMachinePoolSpec{
+				Strategy: infrav1exp.AzureMachinePoolDeploymentStrategy{
+					Type: infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType,
+					RollingUpdate: &infrav1exp.MachineRollingUpdateDeployment{
+						HealthGate: &infrav1exp.MachinePoolRolloutHealthGate{AutoRollback: true},
+					},
+				},
+			},
+			Status: infrav1exp.AzureMachinePoolStatus{
+				Rollout: &infrav1exp.AzureMachinePoolRolloutStatus{
+					ModelHash:            rolloutModelHash(newImage),
+					LastHealthyModelHash: rolloutModelHash(oldImage),
+					LastHealthyImage:     oldImage,
+					RolledBackModelHash:  rolloutModelHash(newImage),
+				},
+			},
+		},
+		cache: &MachinePoolCache{VMImage: newImage},
+	}
+	unhealthy := map[string]infrav1exp.AzureMachinePoolMachine{
+		"a": {ObjectMeta: metav1.ObjectMeta{Name: "a"}, Status: infrav1exp.AzureMachinePoolMachineStatus{LatestModelApplied: false, Ready: true}},
+		"b": {ObjectMeta: metav1.ObjectMeta{Name: "b"}, Status: infrav1exp.AzureMachinePoolMachineStatus{LatestModelApplied: true}},
+	}
+
+	// The rollback is not gated while the rolled back image is still specified.
+	g.Expect(s.reconcileRolloutHealthGate(t.Context(), unhealthy)).To(Succeed())
+	g.Expect(s.rolloutHeld()).To(BeFalse())
+	g.Expect(s.rolledBackImage()).To(Equal(oldImage))
+
+	// A new image is rolled out, and gated, again.
+	s.cache.VMImage = newerImage
+	g.Expect(s.reconcileRolloutHealthGate(t.Context(), unhealthy)).To(Succeed())
+	g.Expect(s.AzureMachinePool.Status.Rollout.RolledBackModelHash).To(BeEmpty())
+	g.Expect(s.AzureMachinePool.Status.Rollout.ModelHash).To(Equal(rolloutModelHash(newerImage)))
+	g.Expect(s.rolloutHeld()).To(BeTrue())
+	g.Expect(s.rolledBackImage()).To(BeNil())
+}
+
+func TestNodeHealthGateFailure(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Gate     infrav1exp.MachinePoolRolloutHealthGate
+		Node     corev1.Node
+		Expected string
+	}{
+		{
+			Name: "passes without node checks",
+			Node: corev1.Node{},
+		},
+		{
+			Name: "fails when an unhealthy node condition is True",
+			Gate: infrav1exp.MachinePoolRolloutHealthGate{UnhealthyNodeConditions: []corev1.NodeConditionType{corev1.NodeMemoryPressure}},
+			Node: corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+			}}},
+			Expected: "node condition MemoryPressure is True",
+		},
+		{
+			Name: "passes when the unhealthy node conditions are False",
+			Gate: infrav1exp.MachinePoolRolloutHealthGate{UnhealthyNodeConditions: []corev1.NodeConditionType{corev1.NodeMemoryPressure}},
+			Node: corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			}}},
+		},
+		{
+			Name:     "fails when the readiness label is missing",
+			Gate:     infrav1exp.MachinePoolRolloutHealthGate{ReadinessLabel: "example.com/ready"},
+			Node:     corev1.Node{},
+			Expected: "node is missing label example.com/ready",
+		},
+		{
+			Name:     "fails when the readiness label has a different value",
+			Gate:     infrav1exp.MachinePoolRolloutHealthGate{ReadinessLabel: "example.com/ready=true"},
+			Node:     corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"example.com/ready": "false"}}},
+			Expected: "node is missing label example.com/ready=true",
+		},
+		{
+			Name: "passes when the readiness label matches",
+			Gate: infrav1exp.MachinePoolRolloutHealthGate{ReadinessLabel: "example.com/ready=true"},
+			Node: corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"example.com/ready": "true"}}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(nodeHealthGateFailure(&c.Gate, &c.Node)).To(Equal(c.Expected))
+		})
+	}
+}
+
 func TestMachinePoolScope_updateReplicasAndProviderIDs(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = clusterv1.AddToScheme(scheme)
@@ -2162,3 +2695,43 @@ func TestMachinePoolScope_setProvisioningStateAndConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestMachinePoolScope_CostResources(t *testing.T) {
+	g := NewWithT(t)
+	s := &MachinePoolScope{
+		ClusterScoper: &ClusterScope{
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						Location: "westus2",
+					},
+				},
+			},
+		},
+		MachinePool: &clusterv1.MachinePool{
+			Spec: clusterv1.MachinePoolSpec{Replicas: ptr.To[int32](3)},
+		},
+		AzureMachinePool: &infrav1exp.AzureMachinePool{
+			Spec: infrav1exp.AzureMachinePoolSpec{
+				Template: infrav1exp.AzureMachinePoolMachineTemplate{
+					VMSize: "Standard_D2s_v3",
+					OSDisk: infrav1.OSDisk{
+						DiskSizeGB:  ptr.To[int32](64),
+						ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: "Premium_LRS"},
+					},
+				},
+			},
+		},
+	}
+
+	// Every replica is billed.
+	g.Expect(s.CostResources()).To(Equal(cost.Resources{
+		Location: "westus2",
+		VMs:      []cost.VM{{Size: "Standard_D2s_v3", Count: 3}},
+		Disks:    []cost.Disk{{StorageAccountType: "Premium_LRS", SizeGiB: 64, Count: 3}},
+	}))
+
+	// A pool scaled to zero costs nothing.
+	s.MachinePool.Spec.Replicas = ptr.To[int32](0)
+	g.Expect(s.CostResources()).To(Equal(cost.Resources{Location: "westus2"}))
+}
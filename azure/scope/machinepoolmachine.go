@@ -182,9 +182,13 @@ func (s *MachinePoolMachineScope) InstanceID() string {
 	return s.AzureMachinePoolMachine.Spec.InstanceID
 }
 
-// ScaleSetName is the name of the VMSS.
+// ScaleSetName is the name of the VMSS. During a BlueGreen rollout the instances of a machine pool belong to more
+// than one VMSS, so the name is taken from the provider ID when possible.
 func (s *MachinePoolMachineScope) ScaleSetName() string {
-	return s.MachinePoolScope.Name()
+	if name := azure.ScaleSetNameFromProviderID(s.ProviderID()); name != "" {
+		return name
+	}
+	return s.MachinePoolScope.ActiveScaleSetName()
 }
 
 // OrchestrationMode is the VMSS orchestration mode, either Uniform or Flexible.
@@ -196,16 +200,20 @@ func (s *MachinePoolMachineScope) OrchestrationMode() infrav1.OrchestrationModeT
 // in the next reconciliation.
 func (s *MachinePoolMachineScope) SetLongRunningOperationState(future *infrav1.Future) {
 	futures.Set(s.AzureMachinePoolMachine, future)
+	futures.Track(s.AzureMachinePoolMachine, s.SubscriptionID(), future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureMachinePoolMachine status.
 func (s *MachinePoolMachineScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
-	return futures.Get(s.AzureMachinePoolMachine, name, service, futureType)
+	// A stored future is in flight, e.g. when it is resumed after a restart.
+	future := futures.Get(s.AzureMachinePoolMachine, name, service, futureType)
+	futures.Track(s.AzureMachinePoolMachine, s.SubscriptionID(), future)
+	return future
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureMachinePoolMachine status.
 func (s *MachinePoolMachineScope) DeleteLongRunningOperationState(name, service, futureType string) {
-	futures.Delete(s.AzureMachinePoolMachine, name, service, futureType)
+	futures.Delete(s.AzureMachinePoolMachine, s.SubscriptionID(), name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureMachinePoolMachine status after a DELETE operation.
@@ -324,6 +332,21 @@ func (s *MachinePoolMachineScope) Close(ctx context.Context) error {
 	return s.PatchObject(ctx)
 }
 
+// updateNodeHealthGateCondition records whether the node passes the node checks of the rollout health gate.
+func (s *MachinePoolMachineScope) updateNodeHealthGateCondition(node *corev1.Node) {
+	gate := rolloutHealthGate(s.AzureMachinePool)
+	if gate == nil || (gate.ReadinessLabel == "" && len(gate.UnhealthyNodeConditions) == 0) {
+		v1beta1conditions.Delete(s.AzureMachinePoolMachine, infrav1.NodeHealthGatePassedCondition)
+		return
+	}
+
+	if failure := nodeHealthGateFailure(gate, node); failure != "" {
+		v1beta1conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.NodeHealthGatePassedCondition, infrav1.NodeHealthGateFailedReason, clusterv1beta1.ConditionSeverityWarning, "%s", failure)
+		return
+	}
+	v1beta1conditions.MarkTrue(s.AzureMachinePoolMachine, infrav1.NodeHealthGatePassedCondition)
+}
+
 // UpdateNodeStatus updates AzureMachinePoolMachine conditions and ready status. It will also update the node ref and the Kubernetes
 // version of the VM instance if the node is found.
 // Note: This func should be called at the end of a reconcile request and after updating the scope with the most recent Azure data.
@@ -374,6 +397,7 @@ func (s *MachinePoolMachineScope) UpdateNodeStatus(ctx context.Context) error {
 		} else {
 			v1beta1conditions.MarkFalse(s.AzureMachinePoolMachine, clusterv1.MachineNodeHealthyCondition, clusterv1beta1.NodeConditionsFailedReason, clusterv1beta1.ConditionSeverityWarning, "")
 		}
+		s.updateNodeHealthGateCondition(node)
 
 		s.AzureMachinePoolMachine.Status.NodeRef = &corev1.ObjectReference{
 			Kind:       node.Kind,
@@ -461,6 +461,35 @@ func TestMachineScope_UpdateNodeStatus(t *testing.T) {
 	}
 }
 
+func TestMachinePoolMachineScope_updateNodeHealthGateCondition(t *testing.T) {
+	g := NewWithT(t)
+	s := &MachinePoolMachineScope{
+		AzureMachinePoolMachine: &infrav1exp.AzureMachinePoolMachine{},
+		AzureMachinePool: &infrav1exp.AzureMachinePool{
+			Spec: infrav1exp.AzureMachinePoolSpec{
+				Strategy: infrav1exp.AzureMachinePoolDeploymentStrategy{
+					Type: infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType,
+					RollingUpdate: &infrav1exp.MachineRollingUpdateDeployment{
+						HealthGate: &infrav1exp.MachinePoolRolloutHealthGate{ReadinessLabel: "example.com/ready"},
+					},
+				},
+			},
+		},
+	}
+
+	node := getReadyNode()
+	s.updateNodeHealthGateCondition(node)
+	assertCondition(t, s.AzureMachinePoolMachine, v1beta1conditions.FalseCondition(infrav1.NodeHealthGatePassedCondition, infrav1.NodeHealthGateFailedReason, clusterv1beta1.ConditionSeverityWarning, "node is missing label example.com/ready"))
+
+	node.Labels = map[string]string{"example.com/ready": ""}
+	s.updateNodeHealthGateCondition(node)
+	assertCondition(t, s.AzureMachinePoolMachine, v1beta1conditions.TrueCondition(infrav1.NodeHealthGatePassedCondition))
+
+	s.AzureMachinePool.Spec.Strategy.RollingUpdate.HealthGate = nil
+	s.updateNodeHealthGateCondition(node)
+	g.Expect(v1beta1conditions.Has(s.AzureMachinePoolMachine, infrav1.NodeHealthGatePassedCondition)).To(BeFalse())
+}
+
 func getReadyNode() *corev1.Node {
 	return &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
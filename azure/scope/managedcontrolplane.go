@@ -471,6 +471,11 @@ func (s *ManagedControlPlaneScope) IsAPIServerPrivate() bool {
 	return false
 }
 
+// ApplicationSecurityGroupsEnabled returns false as application security groups are not supported for managed clusters.
+func (s *ManagedControlPlaneScope) ApplicationSecurityGroupsEnabled() bool {
+	return false
+}
+
 // OutboundLBName returns the name of the outbound LB.
 // Note: for managed clusters, the outbound LB lifecycle is not managed.
 func (s *ManagedControlPlaneScope) OutboundLBName(_ string) string {
@@ -482,6 +487,23 @@ func (s *ManagedControlPlaneScope) OutboundPoolName(_ string) string {
 	return "aksOutboundBackendPool" // hard-coded in aks
 }
 
+// OutboundIPv6PoolName returns an empty string as the outbound LB of managed clusters is not managed.
+func (s *ManagedControlPlaneScope) OutboundIPv6PoolName(_ string) string {
+	return ""
+}
+
+// VnetSubscriptionID returns the ID of the subscription of the virtual network, which is always the subscription of
+// the cluster for AKS.
+func (s *ManagedControlPlaneScope) VnetSubscriptionID() string {
+	return s.SubscriptionID()
+}
+
+// ApplicationSecurityGroupResourceGroup returns the resource group of the cluster, as application security groups
+// are not used by AKS.
+func (s *ManagedControlPlaneScope) ApplicationSecurityGroupResourceGroup() string {
+	return s.ResourceGroup()
+}
+
 // GetPrivateDNSZoneName returns the Private DNS Zone from the spec or generate it from cluster name.
 // Currently always empty as managed control planes do not currently implement private clusters.
 func (s *ManagedControlPlaneScope) GetPrivateDNSZoneName() string {
@@ -859,16 +881,20 @@ func (s *ManagedControlPlaneScope) StoreClusterInfo(ctx context.Context, caData
 // in the next reconciliation.
 func (s *ManagedControlPlaneScope) SetLongRunningOperationState(future *infrav1.Future) {
 	futures.Set(s.ControlPlane, future)
+	futures.Track(s.ControlPlane, s.SubscriptionID(), future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureManagedControlPlane status.
 func (s *ManagedControlPlaneScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
-	return futures.Get(s.ControlPlane, name, service, futureType)
+	// A stored future is in flight, e.g. when it is resumed after a restart.
+	future := futures.Get(s.ControlPlane, name, service, futureType)
+	futures.Track(s.ControlPlane, s.SubscriptionID(), future)
+	return future
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureManagedControlPlane status.
 func (s *ManagedControlPlaneScope) DeleteLongRunningOperationState(name, service, futureType string) {
-	futures.Delete(s.ControlPlane, name, service, futureType)
+	futures.Delete(s.ControlPlane, s.SubscriptionID(), name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureManagedControlPlane status after a DELETE operation.
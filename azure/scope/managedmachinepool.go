@@ -269,16 +269,20 @@ func (s *ManagedMachinePoolScope) SetAgentPoolReady(ready bool) {
 // in the next reconciliation.
 func (s *ManagedMachinePoolScope) SetLongRunningOperationState(future *infrav1.Future) {
 	futures.Set(s.InfraMachinePool, future)
+	futures.Track(s.InfraMachinePool, s.SubscriptionID(), future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureManagedMachinePool status.
 func (s *ManagedMachinePoolScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
-	return futures.Get(s.InfraMachinePool, name, service, futureType)
+	// A stored future is in flight, e.g. when it is resumed after a restart.
+	future := futures.Get(s.InfraMachinePool, name, service, futureType)
+	futures.Track(s.InfraMachinePool, s.SubscriptionID(), future)
+	return future
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureManagedMachinePool status.
 func (s *ManagedMachinePoolScope) DeleteLongRunningOperationState(name, service, futureType string) {
-	futures.Delete(s.InfraMachinePool, name, service, futureType)
+	futures.Delete(s.InfraMachinePool, s.SubscriptionID(), name, service, futureType)
 }
 
 // UpdateDeleteStatus updates a condition on the AzureManagedControlPlane status after a DELETE operation.
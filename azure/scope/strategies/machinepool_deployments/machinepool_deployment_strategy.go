@@ -20,6 +20,7 @@ import (
 	"context"
 	"math/rand"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -28,6 +29,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -59,6 +61,24 @@ type (
 
 	rollingUpdateStrategy struct {
 		infrav1exp.MachineRollingUpdateDeployment
+		// rolloutHeld stops the replacement of ready machines running an older model while the machines running
+		// the latest model fail the health gate.
+		rolloutHeld bool
+	}
+
+	// BlueGreenScaleSets names the scale sets of an AzureMachinePool using the BlueGreen deployment strategy.
+	BlueGreenScaleSets struct {
+		// Active is the scale set serving the pool.
+		Active string
+		// Pending is the scale set being brought up with the latest model, if any.
+		Pending string
+		// Retiring is the scale set being drained, if any.
+		Retiring string
+	}
+
+	blueGreenStrategy struct {
+		infrav1exp.MachineBlueGreenDeployment
+		scaleSets BlueGreenScaleSets
 	}
 )
 
@@ -67,14 +87,9 @@ type (
 func NewMachinePoolDeploymentStrategy(strategy infrav1exp.AzureMachinePoolDeploymentStrategy) TypedDeleteSelector {
 	switch strategy.Type {
 	case infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType:
-		rollingUpdate := strategy.RollingUpdate
-		if rollingUpdate == nil {
-			rollingUpdate = &infrav1exp.MachineRollingUpdateDeployment{}
-		}
-
-		return &rollingUpdateStrategy{
-			MachineRollingUpdateDeployment: *rollingUpdate,
-		}
+		return NewRollingUpdateStrategy(strategy.RollingUpdate, false)
+	case infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType:
+		return NewBlueGreenStrategy(strategy.BlueGreen, BlueGreenScaleSets{})
 	default:
 		// default to a rolling update strategy if unknown type
 		return &rollingUpdateStrategy{
@@ -83,6 +98,19 @@ func NewMachinePoolDeploymentStrategy(strategy infrav1exp.AzureMachinePoolDeploy
 	}
 }
 
+// NewRollingUpdateStrategy constructs a rolling update strategy. When rolloutHeld is true, ready machines running an
+// older model are not replaced, which holds the rollout while the latest model fails its health gate.
+func NewRollingUpdateStrategy(rollingUpdate *infrav1exp.MachineRollingUpdateDeployment, rolloutHeld bool) TypedDeleteSelector {
+	if rollingUpdate == nil {
+		rollingUpdate = &infrav1exp.MachineRollingUpdateDeployment{}
+	}
+
+	return &rollingUpdateStrategy{
+		MachineRollingUpdateDeployment: *rollingUpdate,
+		rolloutHeld:                    rolloutHeld,
+	}
+}
+
 // Type is the AzureMachinePoolDeploymentStrategyType for the strategy.
 func (rollingUpdateStrategy *rollingUpdateStrategy) Type() infrav1exp.AzureMachinePoolDeploymentStrategyType {
 	return infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType
@@ -203,6 +231,7 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 		"deleteAnnotatedMachines", len(deleteAnnotatedMachines),
 		"failedMachines", len(failedMachines),
 		"deletingMachines", len(deletingMachines),
+		"rolloutHeld", rollingUpdateStrategy.rolloutHeld,
 	)
 
 	// if we have failed or deleting machines, remove them
@@ -286,6 +315,12 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 
 		// When SkipMachinePoolModelReconciliation is enabled, skip prioritizing machines without latest model.
 		// Just delete the oldest ready machines to meet the desired replica count.
+		// The surged machines are waiting for the health gate, so keep the ready machines they replace.
+		if rollingUpdateStrategy.rolloutHeld && rolloutInProgress {
+			log.Info("rollout held until the AzureMachinePoolMachine(s) running the latest model pass the health gate", "overProvisionCount", overProvisionCount)
+			return toDelete, nil
+		}
+
 		if !skipModelReconciliation {
 			// we are over-provisioned try to remove old models
 			for _, v := range machinesWithoutLatestModel {
@@ -332,6 +367,11 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 		return []infrav1exp.AzureMachinePoolMachine{}, nil
 	}
 
+	if rollingUpdateStrategy.rolloutHeld {
+		log.Info("rollout held until the AzureMachinePoolMachine(s) running the latest model pass the health gate", "machinesWithoutTheLatestModel", getProviderIDs(machinesWithoutLatestModel))
+		return []infrav1exp.AzureMachinePoolMachine{}, nil
+	}
+
 	if disruptionBudget <= 0 {
 		log.Info("exit early since disruption budget is less than or equal to zero", "disruptionBudget", disruptionBudget, "desiredReplicaCount", desiredReplicaCount, "maxUnavailable", maxUnavailable, "readyMachines", getProviderIDs(readyMachines), "readyMachinesCount", len(readyMachines))
 		return []infrav1exp.AzureMachinePoolMachine{}, nil
@@ -353,6 +393,112 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 	return toDelete, nil
 }
 
+// NewBlueGreenStrategy constructs a BlueGreen strategy for a pool whose machines are spread across the given scale sets.
+func NewBlueGreenStrategy(blueGreen *infrav1exp.MachineBlueGreenDeployment, scaleSets BlueGreenScaleSets) TypedDeleteSelector {
+	if blueGreen == nil {
+		blueGreen = &infrav1exp.MachineBlueGreenDeployment{}
+	}
+
+	return &blueGreenStrategy{
+		MachineBlueGreenDeployment: *blueGreen,
+		scaleSets:                  scaleSets,
+	}
+}
+
+// Type is the AzureMachinePoolDeploymentStrategyType for the strategy.
+func (blueGreenStrategy *blueGreenStrategy) Type() infrav1exp.AzureMachinePoolDeploymentStrategyType {
+	return infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType
+}
+
+// SelectMachinesToDelete selects every machine of the retiring scale set, so that the old scale set is drained in a
+// single step. Within the active and pending scale sets, failed, deleting and delete annotated machines are selected,
+// along with any machines in excess of the desired replica count. Machines in the pending scale set which are not on
+// the latest model are replaced as well, since the pending scale set must be entirely on the latest model before the
+// rollout can complete.
+//
+// The BlueGreen strategy never surges, and rolloutInProgress is ignored: a rollout is tracked by the scale sets
+// themselves rather than by over-provisioning.
+func (blueGreenStrategy blueGreenStrategy) SelectMachinesToDelete(ctx context.Context, desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine, _ bool) ([]infrav1exp.AzureMachinePoolMachine, error) {
+	ctx, _, done := tele.StartSpanWithLogger(
+		ctx,
+		"strategies.blueGreenStrategy.SelectMachinesToDelete",
+	)
+	defer done()
+
+	var (
+		log              = ctrl.LoggerFrom(ctx).V(4)
+		activeMachines   = map[string]infrav1exp.AzureMachinePoolMachine{}
+		pendingMachines  = map[string]infrav1exp.AzureMachinePoolMachine{}
+		retiringMachines []infrav1exp.AzureMachinePoolMachine
+	)
+
+	for providerID, machine := range machinesByProviderID {
+		switch scaleSetName := azure.ScaleSetNameFromProviderID(providerID); {
+		case blueGreenStrategy.scaleSets.Retiring != "" && strings.EqualFold(scaleSetName, blueGreenStrategy.scaleSets.Retiring):
+			if machine.DeletionTimestamp.IsZero() {
+				retiringMachines = append(retiringMachines, machine)
+			}
+		case blueGreenStrategy.scaleSets.Pending != "" && strings.EqualFold(scaleSetName, blueGreenStrategy.scaleSets.Pending):
+			pendingMachines[providerID] = machine
+		default:
+			activeMachines[providerID] = machine
+		}
+	}
+
+	toDelete := orderByOldest(retiringMachines)
+	toDelete = append(toDelete, selectScaleSetMachinesToDelete(desiredReplicaCount, activeMachines, false)...)
+	toDelete = append(toDelete, selectScaleSetMachinesToDelete(desiredReplicaCount, pendingMachines, true)...)
+
+	log.Info("selected machines to delete",
+		"desiredReplicaCount", desiredReplicaCount,
+		"activeScaleSet", blueGreenStrategy.scaleSets.Active,
+		"pendingScaleSet", blueGreenStrategy.scaleSets.Pending,
+		"retiringScaleSet", blueGreenStrategy.scaleSets.Retiring,
+		"retiringMachines", getProviderIDs(retiringMachines),
+		"toDelete", getProviderIDs(toDelete),
+	)
+
+	return toDelete, nil
+}
+
+// selectScaleSetMachinesToDelete selects the machines of a single scale set that are failed, deleting, annotated for
+// delete or, when replaceOldModels is set, not on the latest model, followed by the oldest machines in excess of the
+// desired replica count, preferring unready machines.
+func selectScaleSetMachinesToDelete(desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine, replaceOldModels bool) []infrav1exp.AzureMachinePoolMachine {
+	var (
+		toDelete []infrav1exp.AzureMachinePoolMachine
+		selected = map[string]bool{}
+		selectFn = func(machines []infrav1exp.AzureMachinePoolMachine) {
+			for _, machine := range machines {
+				if !selected[machine.Spec.ProviderID] && machine.DeletionTimestamp.IsZero() {
+					selected[machine.Spec.ProviderID] = true
+					toDelete = append(toDelete, machine)
+				}
+			}
+		}
+	)
+
+	selectFn(orderByOldest(getFailedMachines(machinesByProviderID)))
+	selectFn(orderByOldest(getDeletingMachines(machinesByProviderID)))
+	selectFn(orderByOldest(getDeleteAnnotatedMachines(machinesByProviderID)))
+	if replaceOldModels {
+		selectFn(orderByOldest(getMachinesWithoutLatestModel(machinesByProviderID)))
+	}
+
+	var remaining []infrav1exp.AzureMachinePoolMachine
+	for _, machine := range append(orderByOldest(getUnreadyMachines(machinesByProviderID)), orderByOldest(getReadyMachines(machinesByProviderID))...) {
+		if !selected[machine.Spec.ProviderID] {
+			remaining = append(remaining, machine)
+		}
+	}
+
+	if overProvisionCount := len(remaining) - int(desiredReplicaCount); overProvisionCount > 0 {
+		selectFn(remaining[:overProvisionCount])
+	}
+
+	return toDelete
+}
+
 func getDeleteAnnotatedMachines(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
 	var machines []infrav1exp.AzureMachinePoolMachine
 	for _, v := range machinesByProviderID {
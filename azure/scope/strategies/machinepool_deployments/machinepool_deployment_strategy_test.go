@@ -17,6 +17,7 @@ limitations under the License.
 package machinepool
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -665,6 +666,55 @@ func TestMachinePoolRollingUpdateStrategy_SelectMachinesToDelete(t *testing.T) {
 			},
 			want: BeEmpty(),
 		},
+		{
+			name:            "if the rollout is held, do not replace ready machines with an out-of-date model within the disruption budget",
+			strategy:        NewRollingUpdateStrategy(&infrav1exp.MachineRollingUpdateDeployment{MaxUnavailable: &one}, true),
+			desiredReplicas: 3,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+			},
+			want: BeEmpty(),
+		},
+		{
+			name:              "if the rollout is held, keep the ready machines replaced by surged machines",
+			strategy:          NewRollingUpdateStrategy(&infrav1exp.MachineRollingUpdateDeployment{MaxSurge: &one}, true),
+			desiredReplicas:   2,
+			rolloutInProgress: true,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+			},
+			want: BeEmpty(),
+		},
+		{
+			name:            "if the rollout is held, still scale down by removing machines with an out-of-date model",
+			strategy:        NewRollingUpdateStrategy(&infrav1exp.MachineRollingUpdateDeployment{DeletePolicy: infrav1exp.OldestDeletePolicyType}, true),
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+			},
+			want: Equal([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+			}),
+		},
+		{
+			name:            "if the rollout is held, still remove failed machines",
+			strategy:        NewRollingUpdateStrategy(&infrav1exp.MachineRollingUpdateDeployment{}, true),
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+				"baz": makeAMPM(ampmOptions{Ready: false, LatestModel: true, ProvisioningState: infrav1.Failed}),
+			},
+			want: Equal([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{Ready: false, LatestModel: true, ProvisioningState: infrav1.Failed}),
+			}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -682,6 +732,127 @@ func TestMachinePoolRollingUpdateStrategy_SelectMachinesToDelete(t *testing.T) {
 	}
 }
 
+func TestMachinePoolBlueGreenStrategy_Type(t *testing.T) {
+	g := NewWithT(t)
+	strategy := NewMachinePoolDeploymentStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+		Type: infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+	})
+	g.Expect(strategy.Type()).To(Equal(infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType))
+	_, isSurger := strategy.(Surger)
+	g.Expect(isSurger).To(BeFalse())
+}
+
+func TestMachinePoolBlueGreenStrategy_SelectMachinesToDelete(t *testing.T) {
+	var (
+		succeeded  = infrav1.Succeeded
+		failed     = infrav1.Failed
+		baseTime   = time.Now().Add(-24 * time.Hour).Truncate(time.Microsecond)
+		deleteTime = metav1.NewTime(time.Now())
+		blue       = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/mp/virtualMachines/%d"
+		green      = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/mp-g/virtualMachines/%d"
+		machines   = func(opts ...ampmOptions) map[string]infrav1exp.AzureMachinePoolMachine {
+			m := map[string]infrav1exp.AzureMachinePoolMachine{}
+			for _, o := range opts {
+				m[o.ProviderID] = makeAMPM(o)
+			}
+			return m
+		}
+		ampm = func(format string, i int, ready, latest bool) ampmOptions {
+			return ampmOptions{
+				ProviderID:        "azure://" + fmt.Sprintf(format, i),
+				Ready:             ready,
+				LatestModel:       latest,
+				ProvisioningState: succeeded,
+				CreationTime:      metav1.NewTime(baseTime.Add(time.Duration(i) * time.Hour)),
+			}
+		}
+		providerIDs = func(ampms []infrav1exp.AzureMachinePoolMachine) []string {
+			ids := make([]string, len(ampms))
+			for i, m := range ampms {
+				ids[i] = m.Spec.ProviderID
+			}
+			return ids
+		}
+	)
+
+	tests := []struct {
+		name            string
+		scaleSets       BlueGreenScaleSets
+		input           map[string]infrav1exp.AzureMachinePoolMachine
+		desiredReplicas int32
+		want            []string
+	}{
+		{
+			name:            "should not select machines when the active scale set is at the desired replica count",
+			scaleSets:       BlueGreenScaleSets{Active: "mp"},
+			desiredReplicas: 2,
+			input:           machines(ampm(blue, 0, true, true), ampm(blue, 1, true, false)),
+			want:            []string{},
+		},
+		{
+			name:            "should select the oldest machines over the desired replica count, preferring unready machines",
+			scaleSets:       BlueGreenScaleSets{Active: "mp"},
+			desiredReplicas: 1,
+			input:           machines(ampm(blue, 0, true, true), ampm(blue, 1, true, true), ampm(blue, 2, false, true)),
+			want:            []string{"azure://" + fmt.Sprintf(blue, 2), "azure://" + fmt.Sprintf(blue, 0)},
+		},
+		{
+			name:            "should select failed and delete annotated machines",
+			scaleSets:       BlueGreenScaleSets{Active: "mp"},
+			desiredReplicas: 3,
+			input: func() map[string]infrav1exp.AzureMachinePoolMachine {
+				failedMachine := ampm(blue, 0, false, true)
+				failedMachine.ProvisioningState = failed
+				annotated := ampm(blue, 1, true, true)
+				annotated.HasDeleteMachineAnnotation = true
+				return machines(failedMachine, annotated, ampm(blue, 2, true, true))
+			}(),
+			want: []string{"azure://" + fmt.Sprintf(blue, 0), "azure://" + fmt.Sprintf(blue, 1)},
+		},
+		{
+			name:            "should not replace active machines or surge while the pending scale set comes up",
+			scaleSets:       BlueGreenScaleSets{Active: "mp", Pending: "mp-g"},
+			desiredReplicas: 2,
+			input:           machines(ampm(blue, 0, true, true), ampm(blue, 1, true, true), ampm(green, 0, false, true), ampm(green, 1, true, true)),
+			want:            []string{},
+		},
+		{
+			name:            "should replace pending machines which are not on the latest model",
+			scaleSets:       BlueGreenScaleSets{Active: "mp", Pending: "mp-g"},
+			desiredReplicas: 2,
+			input:           machines(ampm(blue, 0, true, true), ampm(blue, 1, true, true), ampm(green, 0, true, false), ampm(green, 1, true, true)),
+			want:            []string{"azure://" + fmt.Sprintf(green, 0)},
+		},
+		{
+			name:            "should select every machine of the retiring scale set at once",
+			scaleSets:       BlueGreenScaleSets{Active: "mp-g", Retiring: "mp"},
+			desiredReplicas: 2,
+			input: func() map[string]infrav1exp.AzureMachinePoolMachine {
+				deleting := ampm(blue, 2, true, true)
+				deleting.DeletionTime = &deleteTime
+				return machines(ampm(blue, 1, true, true), ampm(blue, 0, true, true), deleting, ampm(green, 0, true, true), ampm(green, 1, true, true))
+			}(),
+			want: []string{"azure://" + fmt.Sprintf(blue, 0), "azure://" + fmt.Sprintf(blue, 1)},
+		},
+		{
+			name:            "should treat every machine as active when the scale sets are unknown",
+			desiredReplicas: 2,
+			input:           machines(ampm(blue, 0, true, true), ampm(green, 1, true, true), ampm(green, 2, true, true)),
+			want:            []string{"azure://" + fmt.Sprintf(blue, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			strategy := NewBlueGreenStrategy(nil, tt.scaleSets)
+			got, err := strategy.SelectMachinesToDelete(t.Context(), tt.desiredReplicas, tt.input, false)
+			g.Expect(err).To(Succeed())
+			g.Expect(providerIDs(got)).To(Equal(tt.want))
+		})
+	}
+}
+
 func makeRollingUpdateStrategy(rolling infrav1exp.MachineRollingUpdateDeployment) *rollingUpdateStrategy {
 	return &rollingUpdateStrategy{
 		MachineRollingUpdateDeployment: rolling,
@@ -695,6 +866,7 @@ type ampmOptions struct {
 	CreationTime               metav1.Time
 	DeletionTime               *metav1.Time
 	HasDeleteMachineAnnotation bool
+	ProviderID                 string
 }
 
 func makeAMPM(opts ampmOptions) infrav1exp.AzureMachinePoolMachine {
@@ -704,6 +876,9 @@ func makeAMPM(opts ampmOptions) infrav1exp.AzureMachinePoolMachine {
 			DeletionTimestamp: opts.DeletionTime,
 			Annotations:       map[string]string{},
 		},
+		Spec: infrav1exp.AzureMachinePoolMachineSpec{
+			ProviderID: opts.ProviderID,
+		},
 		Status: infrav1exp.AzureMachinePoolMachineStatus{
 			Ready:              opts.Ready,
 			LatestModelApplied: opts.LatestModel,
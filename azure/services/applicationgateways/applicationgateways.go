@@ -0,0 +1,145 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationgateways
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "applicationgateways"
+
+// ApplicationGatewayScope defines the scope interface for an application gateway service.
+type ApplicationGatewayScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ApplicationGatewaySpec() azure.ResourceSpecGetter
+	SetApplicationGatewayID(string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ApplicationGatewayScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope ApplicationGatewayScope) (*Service, error) {
+	client, err := newClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		Reconciler: async.New[armnetwork.ApplicationGatewaysClientCreateOrUpdateResponse,
+			armnetwork.ApplicationGatewaysClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates the ingress application gateway and records its ID.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationgateways.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	spec := s.Scope.ApplicationGatewaySpec()
+	if spec == nil {
+		return nil
+	}
+
+	result, err := s.CreateOrUpdateResource(ctx, spec, serviceName)
+	s.Scope.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, err)
+	if err == nil && result != nil {
+		applicationGateway, ok := result.(armnetwork.ApplicationGateway)
+		if !ok {
+			return errors.Errorf("%T is not an armnetwork.ApplicationGateway", result)
+		}
+		s.Scope.SetApplicationGatewayID(ptr.Deref(applicationGateway.ID, ""))
+	}
+	return err
+}
+
+// Plan implements azure.Planner. Only the SKU, capacity and web application firewall policy are planned, as the rest
+// of the configuration is owned by the ingress controller.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationgateways.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.PlanAll(ctx, s.Reconciler, s.specs(), serviceName)
+}
+
+// DetectDrift implements azure.DriftDetector. Only the SKU, capacity and web application firewall policy are compared,
+// as the rest of the configuration is owned by the ingress controller.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationgateways.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.DetectDriftAll(ctx, s.Reconciler, s.specs(), serviceName, correct)
+}
+
+// Delete deletes the ingress application gateway.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationgateways.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	spec := s.Scope.ApplicationGatewaySpec()
+	if spec == nil {
+		return nil
+	}
+
+	err := s.DeleteResource(ctx, spec, serviceName)
+	s.Scope.UpdateDeleteStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, err)
+	return err
+}
+
+// IsManaged always returns true as CAPZ does not support BYO application gateways.
+func (s *Service) IsManaged(_ context.Context) (bool, error) {
+	return true, nil
+}
+
+// specs returns the spec of the application gateway limited to the fields CAPZ manages as a list, which is empty if
+// ingress is not configured.
+func (s *Service) specs() []azure.ResourceSpecGetter {
+	if spec, ok := s.Scope.ApplicationGatewaySpec().(*ApplicationGatewaySpec); ok && spec != nil {
+		return []azure.ResourceSpecGetter{managedFieldsSpec{spec}}
+	}
+	return nil
+}
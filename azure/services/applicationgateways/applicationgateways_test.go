@@ -0,0 +1,186 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationgateways
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways/mock_applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+var (
+	fakeAppGwSpec = ApplicationGatewaySpec{
+		Name:           "test-cluster-appgw",
+		ResourceGroup:  "test-rg",
+		SubscriptionID: "123",
+		Location:       "fake-location",
+		ClusterName:    "test-cluster",
+		SKU:            infrav1.StandardV2ApplicationGatewaySKU,
+		Capacity:       2,
+		SubnetID:       "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/test-cluster-ingress-subnet",
+		PublicIPID:     "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/publicIPAddresses/test-cluster-appgw-pip",
+	}
+	fakeAppGwID = "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/applicationGateways/test-cluster-appgw"
+	errFake     = errors.New("this is an error")
+)
+
+func TestReconcileApplicationGateway(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if ingress is not configured",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationGatewaySpec().Return(nil)
+			},
+		},
+		{
+			name:          "create application gateway succeeds and sets its ID",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationGatewaySpec().Return(&fakeAppGwSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAppGwSpec, serviceName).Return(armnetwork.ApplicationGateway{ID: ptr.To(fakeAppGwID)}, nil)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, nil)
+				s.SetApplicationGatewayID(fakeAppGwID)
+			},
+		},
+		{
+			name:          "create application gateway fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationGatewaySpec().Return(&fakeAppGwSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAppGwSpec, serviceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "create application gateway returns an unexpected result",
+			expectedError: "string is not an armnetwork.ApplicationGateway",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationGatewaySpec().Return(&fakeAppGwSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeAppGwSpec, serviceName).Return("not an application gateway", nil)
+				s.UpdatePutStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationgateways.NewMockApplicationGatewayScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(t.Context())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteApplicationGateway(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if ingress is not configured",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationGatewaySpec().Return(nil)
+			},
+		},
+		{
+			name:          "delete application gateway succeeds",
+			expectedError: "",
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationGatewaySpec().Return(&fakeAppGwSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeAppGwSpec, serviceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete application gateway fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_applicationgateways.MockApplicationGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.ApplicationGatewaySpec().Return(&fakeAppGwSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakeAppGwSpec, serviceName).Return(errFake)
+				s.UpdateDeleteStatus(infrav1.ApplicationGatewayReadyCondition, serviceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_applicationgateways.NewMockApplicationGatewayScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(t.Context())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationgateways
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// Names of the placeholder configuration an application gateway is created with. An application gateway cannot be
+// created without a listener, backend and rule, which the ingress controller replaces once it takes over.
+const (
+	gatewayIPConfigName     = "appGatewayIpConfig"
+	frontendIPConfigName    = "appGatewayFrontendIP"
+	frontendPortName        = "appGatewayFrontendPort"
+	backendAddressPoolName  = "appGatewayBackendPool"
+	backendHTTPSettingsName = "appGatewayBackendHttpSettings"
+	httpListenerName        = "appGatewayHttpListener"
+	requestRoutingRuleName  = "appGatewayRoutingRule"
+	requestRoutingPriority  = 19500
+)
+
+// ApplicationGatewaySpec defines the specification for an application gateway.
+type ApplicationGatewaySpec struct {
+	Name           string
+	ResourceGroup  string
+	SubscriptionID string
+	Location       string
+	ClusterName    string
+	SKU            infrav1.ApplicationGatewaySKU
+	Capacity       int32
+	WAFPolicyID    string
+	SubnetID       string
+	PublicIPID     string
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the application gateway.
+func (s *ApplicationGatewaySpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *ApplicationGatewaySpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for application gateways.
+func (s *ApplicationGatewaySpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the application gateway.
+func (s *ApplicationGatewaySpec) Parameters(_ context.Context, existing any) (params any, err error) {
+	if existing != nil {
+		existingGateway, ok := existing.(armnetwork.ApplicationGateway)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.ApplicationGateway", existing)
+		}
+		return s.update(existingGateway), nil
+	}
+
+	return armnetwork.ApplicationGateway{
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+			SKU:            s.sku(),
+			FirewallPolicy: s.firewallPolicy(),
+			GatewayIPConfigurations: []*armnetwork.ApplicationGatewayIPConfiguration{
+				{
+					Name: ptr.To(gatewayIPConfigName),
+					Properties: &armnetwork.ApplicationGatewayIPConfigurationPropertiesFormat{
+						Subnet: &armnetwork.SubResource{ID: ptr.To(s.SubnetID)},
+					},
+				},
+			},
+			FrontendIPConfigurations: []*armnetwork.ApplicationGatewayFrontendIPConfiguration{
+				{
+					Name: ptr.To(frontendIPConfigName),
+					Properties: &armnetwork.ApplicationGatewayFrontendIPConfigurationPropertiesFormat{
+						PublicIPAddress: &armnetwork.SubResource{ID: ptr.To(s.PublicIPID)},
+					},
+				},
+			},
+			FrontendPorts: []*armnetwork.ApplicationGatewayFrontendPort{
+				{
+					Name: ptr.To(frontendPortName),
+					Properties: &armnetwork.ApplicationGatewayFrontendPortPropertiesFormat{
+						Port: ptr.To[int32](80),
+					},
+				},
+			},
+			BackendAddressPools: []*armnetwork.ApplicationGatewayBackendAddressPool{
+				{
+					Name: ptr.To(backendAddressPoolName),
+				},
+			},
+			BackendHTTPSettingsCollection: []*armnetwork.ApplicationGatewayBackendHTTPSettings{
+				{
+					Name: ptr.To(backendHTTPSettingsName),
+					Properties: &armnetwork.ApplicationGatewayBackendHTTPSettingsPropertiesFormat{
+						Port:                ptr.To[int32](80),
+						Protocol:            ptr.To(armnetwork.ApplicationGatewayProtocolHTTP),
+						CookieBasedAffinity: ptr.To(armnetwork.ApplicationGatewayCookieBasedAffinityDisabled),
+						RequestTimeout:      ptr.To[int32](30),
+					},
+				},
+			},
+			HTTPListeners: []*armnetwork.ApplicationGatewayHTTPListener{
+				{
+					Name: ptr.To(httpListenerName),
+					Properties: &armnetwork.ApplicationGatewayHTTPListenerPropertiesFormat{
+						FrontendIPConfiguration: &armnetwork.SubResource{ID: ptr.To(s.subResourceID("frontendIPConfigurations", frontendIPConfigName))},
+						FrontendPort:            &armnetwork.SubResource{ID: ptr.To(s.subResourceID("frontendPorts", frontendPortName))},
+						Protocol:                ptr.To(armnetwork.ApplicationGatewayProtocolHTTP),
+					},
+				},
+			},
+			RequestRoutingRules: []*armnetwork.ApplicationGatewayRequestRoutingRule{
+				{
+					Name: ptr.To(requestRoutingRuleName),
+					Properties: &armnetwork.ApplicationGatewayRequestRoutingRulePropertiesFormat{
+						RuleType:            ptr.To(armnetwork.ApplicationGatewayRequestRoutingRuleTypeBasic),
+						Priority:            ptr.To[int32](requestRoutingPriority),
+						HTTPListener:        &armnetwork.SubResource{ID: ptr.To(s.subResourceID("httpListeners", httpListenerName))},
+						BackendAddressPool:  &armnetwork.SubResource{ID: ptr.To(s.subResourceID("backendAddressPools", backendAddressPoolName))},
+						BackendHTTPSettings: &armnetwork.SubResource{ID: ptr.To(s.subResourceID("backendHttpSettingsCollection", backendHTTPSettingsName))},
+					},
+				},
+			},
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Role:        ptr.To(infrav1.Ingress),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// update returns the existing application gateway with the SKU and web application firewall policy of the spec, or
+// nil if they are already up to date. The rest of the configuration is owned by the ingress controller and kept as is.
+func (s *ApplicationGatewaySpec) update(existing armnetwork.ApplicationGateway) any {
+	if existing.Properties == nil {
+		return nil
+	}
+	var wafPolicyID string
+	if existing.Properties.FirewallPolicy != nil {
+		wafPolicyID = ptr.Deref(existing.Properties.FirewallPolicy.ID, "")
+	}
+	sku := existing.Properties.SKU
+	if sku != nil &&
+		ptr.Deref(sku.Name, "") == armnetwork.ApplicationGatewaySKUName(s.SKU) &&
+		ptr.Deref(sku.Tier, "") == armnetwork.ApplicationGatewayTier(s.SKU) &&
+		ptr.Deref(sku.Capacity, 0) == s.Capacity &&
+		strings.EqualFold(wafPolicyID, s.WAFPolicyID) {
+		return nil
+	}
+
+	properties := *existing.Properties
+	properties.SKU = s.sku()
+	properties.FirewallPolicy = s.firewallPolicy()
+	existing.Properties = &properties
+	return existing
+}
+
+// managedFieldsSpec is an application gateway spec whose desired state is limited to the SKU, capacity and web
+// application firewall policy, the only fields CAPZ keeps managing once the ingress controller owns the rest of the
+// configuration. The listener, pool and routing rule created with the application gateway are only placeholders which
+// the ingress controller replaces, so they are never compared to the existing application gateway.
+type managedFieldsSpec struct {
+	*ApplicationGatewaySpec
+}
+
+// Parameters returns the SKU and web application firewall policy of the application gateway when there is no
+// existing one to compare, and the parameters of the application gateway spec otherwise.
+func (s managedFieldsSpec) Parameters(ctx context.Context, existing any) (any, error) {
+	if existing != nil {
+		return s.ApplicationGatewaySpec.Parameters(ctx, existing)
+	}
+	return armnetwork.ApplicationGateway{
+		Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+			SKU:            s.sku(),
+			FirewallPolicy: s.firewallPolicy(),
+		},
+	}, nil
+}
+
+// sku returns the SKU of the application gateway, which has the same name as its tier for v2 application gateways.
+func (s *ApplicationGatewaySpec) sku() *armnetwork.ApplicationGatewaySKU {
+	return &armnetwork.ApplicationGatewaySKU{
+		Name:     ptr.To(armnetwork.ApplicationGatewaySKUName(s.SKU)),
+		Tier:     ptr.To(armnetwork.ApplicationGatewayTier(s.SKU)),
+		Capacity: ptr.To(s.Capacity),
+	}
+}
+
+// firewallPolicy returns a reference to the web application firewall policy of the application gateway, if any.
+func (s *ApplicationGatewaySpec) firewallPolicy() *armnetwork.SubResource {
+	if s.WAFPolicyID == "" {
+		return nil
+	}
+	return &armnetwork.SubResource{ID: ptr.To(s.WAFPolicyID)}
+}
+
+// subResourceID returns the ID of a child resource of the application gateway.
+func (s *ApplicationGatewaySpec) subResourceID(resourceType, name string) string {
+	return fmt.Sprintf("%s/%s/%s", azure.ApplicationGatewayID(s.SubscriptionID, s.ResourceGroup, s.Name), resourceType, name)
+}
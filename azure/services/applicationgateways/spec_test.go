@@ -0,0 +1,165 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationgateways
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	fakeWAFPolicyID = "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/ApplicationGatewayWebApplicationFirewallPolicies/test-waf-policy"
+	fakeWAFSpec     = ApplicationGatewaySpec{
+		Name:           "test-cluster-appgw",
+		ResourceGroup:  "test-rg",
+		SubscriptionID: "123",
+		Location:       "fake-location",
+		ClusterName:    "test-cluster",
+		SKU:            infrav1.WAFV2ApplicationGatewaySKU,
+		Capacity:       3,
+		WAFPolicyID:    fakeWAFPolicyID,
+	}
+	fakeExistingAppGw = armnetwork.ApplicationGateway{
+		ID:       ptr.To(fakeAppGwID),
+		Location: ptr.To("fake-location"),
+		Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+			SKU: &armnetwork.ApplicationGatewaySKU{
+				Name:     ptr.To(armnetwork.ApplicationGatewaySKUNameStandardV2),
+				Tier:     ptr.To(armnetwork.ApplicationGatewayTierStandardV2),
+				Capacity: ptr.To[int32](2),
+			},
+			BackendAddressPools: []*armnetwork.ApplicationGatewayBackendAddressPool{
+				{Name: ptr.To("pool-default-my-service-80-bp-80")},
+			},
+		},
+	}
+)
+
+func TestApplicationGatewaySpec_Parameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          *ApplicationGatewaySpec
+		existing      any
+		expect        func(g *WithT, result any)
+		expectedError string
+	}{
+		{
+			name:     "error when existing is not of ApplicationGateway type",
+			spec:     &fakeAppGwSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not an armnetwork.ApplicationGateway",
+		},
+		{
+			name:     "get result as nil when existing ApplicationGateway is up to date",
+			spec:     &fakeAppGwSpec,
+			existing: fakeExistingAppGw,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "update the SKU and WAF policy of an existing ApplicationGateway and keep the rest of its configuration",
+			spec:     &fakeWAFSpec,
+			existing: fakeExistingAppGw,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.ApplicationGateway{}))
+				appGw := result.(armnetwork.ApplicationGateway)
+				g.Expect(appGw.Properties.SKU).To(Equal(&armnetwork.ApplicationGatewaySKU{
+					Name:     ptr.To(armnetwork.ApplicationGatewaySKUNameWAFV2),
+					Tier:     ptr.To(armnetwork.ApplicationGatewayTierWAFV2),
+					Capacity: ptr.To[int32](3),
+				}))
+				g.Expect(appGw.Properties.FirewallPolicy).To(Equal(&armnetwork.SubResource{ID: ptr.To(fakeWAFPolicyID)}))
+				g.Expect(appGw.Properties.BackendAddressPools).To(Equal(fakeExistingAppGw.Properties.BackendAddressPools))
+				// The existing application gateway is not modified.
+				g.Expect(fakeExistingAppGw.Properties.FirewallPolicy).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get ApplicationGateway with a placeholder configuration when it does not exist",
+			spec:     &fakeAppGwSpec,
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.ApplicationGateway{}))
+				appGw := result.(armnetwork.ApplicationGateway)
+				g.Expect(appGw.Location).To(Equal(ptr.To("fake-location")))
+				g.Expect(appGw.Tags).To(Equal(map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": ptr.To("owned"),
+					"sigs.k8s.io_cluster-api-provider-azure_role":                 ptr.To("ingress"),
+					"Name": ptr.To("test-cluster-appgw"),
+				}))
+				g.Expect(appGw.Properties.SKU).To(Equal(&armnetwork.ApplicationGatewaySKU{
+					Name:     ptr.To(armnetwork.ApplicationGatewaySKUNameStandardV2),
+					Tier:     ptr.To(armnetwork.ApplicationGatewayTierStandardV2),
+					Capacity: ptr.To[int32](2),
+				}))
+				g.Expect(appGw.Properties.FirewallPolicy).To(BeNil())
+				g.Expect(appGw.Properties.GatewayIPConfigurations[0].Properties.Subnet.ID).To(Equal(ptr.To(fakeAppGwSpec.SubnetID)))
+				g.Expect(appGw.Properties.FrontendIPConfigurations[0].Properties.PublicIPAddress.ID).To(Equal(ptr.To(fakeAppGwSpec.PublicIPID)))
+				g.Expect(appGw.Properties.HTTPListeners[0].Properties.FrontendPort.ID).To(Equal(ptr.To(fakeAppGwID + "/frontendPorts/appGatewayFrontendPort")))
+				g.Expect(appGw.Properties.RequestRoutingRules[0].Properties.BackendAddressPool.ID).To(Equal(ptr.To(fakeAppGwID + "/backendAddressPools/appGatewayBackendPool")))
+			},
+			expectedError: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(t.Context(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func TestManagedFieldsSpec_Parameters(t *testing.T) {
+	g := NewWithT(t)
+
+	result, err := managedFieldsSpec{&fakeWAFSpec}.Parameters(t.Context(), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(armnetwork.ApplicationGateway{
+		Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+			SKU: &armnetwork.ApplicationGatewaySKU{
+				Name:     ptr.To(armnetwork.ApplicationGatewaySKUNameWAFV2),
+				Tier:     ptr.To(armnetwork.ApplicationGatewayTierWAFV2),
+				Capacity: ptr.To[int32](3),
+			},
+			FirewallPolicy: &armnetwork.SubResource{ID: ptr.To(fakeWAFPolicyID)},
+		},
+	}))
+
+	result, err = managedFieldsSpec{&fakeWAFSpec}.Parameters(t.Context(), fakeExistingAppGw)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.(armnetwork.ApplicationGateway).Properties.BackendAddressPools).To(Equal(fakeExistingAppGw.Properties.BackendAddressPools))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "applicationsecuritygroups"
+
+// ASGScope defines the scope interface for an application security groups service.
+type ASGScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter
+	NetworkAuthorizer() azure.Authorizer
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope ASGScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope ASGScope) (*Service, error) {
+	client, err := newClient(scope.NetworkAuthorizer(), scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		Reconciler: async.New[armnetwork.ApplicationSecurityGroupsClientCreateOrUpdateResponse,
+			armnetwork.ApplicationSecurityGroupsClientDeleteResponse](scope, client, client),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates the application security groups of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.ReconcileAll(ctx, s.Reconciler, s.Scope, s.Scope.ApplicationSecurityGroupSpecs(), serviceName, infrav1.ApplicationSecurityGroupsReadyCondition)
+}
+
+// Plan implements azure.Planner.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.PlanAll(ctx, s.Reconciler, s.Scope.ApplicationSecurityGroupSpecs(), serviceName)
+}
+
+// Delete deletes the application security groups of the cluster.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "applicationsecuritygroups.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.DeleteAll(ctx, s.Reconciler, s.Scope, s.Scope.ApplicationSecurityGroupSpecs(), serviceName, infrav1.ApplicationSecurityGroupsReadyCondition)
+}
+
+// IsManaged always returns true as CAPZ does not support BYO application security groups.
+func (s *Service) IsManaged(_ context.Context) (bool, error) {
+	return true, nil
+}
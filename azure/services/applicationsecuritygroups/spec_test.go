@@ -0,0 +1,103 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationsecuritygroups
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	fakeApplicationSecurityGroup = armnetwork.ApplicationSecurityGroup{
+		ID:       ptr.To("fake-id"),
+		Location: ptr.To("fake-location"),
+		Name:     ptr.To("fake-name"),
+	}
+	fakeASGSpec = ASGSpec{
+		Name:        "cluster-node-asg",
+		Location:    "fake-location",
+		ClusterName: "cluster",
+		Role:        "node",
+		AdditionalTags: map[string]string{
+			"foo": "bar",
+		},
+	}
+	fakeASGTags = map[string]*string{
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster": ptr.To("owned"),
+		"sigs.k8s.io_cluster-api-provider-azure_role":            ptr.To("node"),
+		"foo":  ptr.To("bar"),
+		"Name": ptr.To("cluster-node-asg"),
+	}
+)
+
+func TestASGSpec_Parameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          *ASGSpec
+		existing      any
+		expect        func(g *WithT, result any)
+		expectedError string
+	}{
+		{
+			name:     "error when existing is not of ApplicationSecurityGroup type",
+			spec:     &ASGSpec{},
+			existing: struct{}{},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not an armnetwork.ApplicationSecurityGroup",
+		},
+		{
+			name:     "get result as nil when existing ApplicationSecurityGroup is present",
+			spec:     &fakeASGSpec,
+			existing: fakeApplicationSecurityGroup,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get ApplicationSecurityGroup when all values are present",
+			spec:     &fakeASGSpec,
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.ApplicationSecurityGroup{}))
+				g.Expect(result.(armnetwork.ApplicationSecurityGroup).Location).To(Equal(ptr.To(fakeASGSpec.Location)))
+				g.Expect(result.(armnetwork.ApplicationSecurityGroup).Tags).To(Equal(fakeASGTags))
+			},
+			expectedError: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(t.Context(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -40,6 +40,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/aso"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -206,6 +207,9 @@ func (r *reconciler[T]) CreateOrUpdateResource(ctx context.Context, spec azure.A
 	// Set the secret name annotation in order to leverage the ASO resource credential scope as defined in
 	// https://azure.github.io/azure-service-operator/guide/authentication/credential-scope/#resource-scope.
 	annotations[asoannotations.PerResourceSecret] = aso.GetASOSecretName(r.clusterName)
+	if namer, ok := spec.(PerResourceSecretNamer); ok && namer.PerResourceSecretName() != "" {
+		annotations[asoannotations.PerResourceSecret] = namer.PerResourceSecretName()
+	}
 
 	if len(labels) == 0 {
 		labels = nil
@@ -295,6 +299,7 @@ func (r *reconciler[T]) createOrUpdateResource(ctx context.Context, existing T,
 		logMessageVerbPrefix = "creat"
 		err = r.Client.Create(ctx, parameters)
 	}
+	r.auditMutation(ctx, audit.OperationPut, parameters, serviceName, err, err == nil)
 	if err == nil {
 		// Resources need to be requeued to wait for the create or update to finish.
 		return zero, azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{
@@ -306,6 +311,57 @@ func (r *reconciler[T]) createOrUpdateResource(ctx context.Context, existing T,
 	return zero, errors.Wrapf(err, "failed to %se resource %s/%s (service: %s)", logMessageVerbPrefix, parameters.GetNamespace(), parameters.GetName(), serviceName)
 }
 
+// PlanResource computes the change CreateOrUpdateResource would make to the ASO resource without making it.
+func (r *reconciler[T]) PlanResource(ctx context.Context, spec azure.ASOResourceSpecGetter[T], serviceName string) (infrav1.ResourceChange, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "services.aso.PlanResource")
+	defer done()
+
+	resource := spec.ResourceRef()
+	resource.SetNamespace(r.owner.GetNamespace())
+	resourceName := resource.GetName()
+	resourceNamespace := resource.GetNamespace()
+
+	change := infrav1.ResourceChange{
+		ServiceName:  serviceName,
+		ResourceName: resourceName,
+		Action:       infrav1.ResourceChangeActionNone,
+	}
+
+	var existing T
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(resource), resource); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return change, errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+		}
+		change.Action = infrav1.ResourceChangeActionCreate
+		return change, nil
+	}
+	existing = resource
+
+	if isOwned, err := isOwnedBy(existing, r.owner, r.Scheme()); err != nil {
+		return change, err
+	} else if !isOwned && !hasLegacyOwnedByLabel(existing.GetLabels(), r.clusterName) {
+		log.V(4).Info("skipping plan for unmanaged resource", "service", serviceName, "resource", resourceName)
+		return change, nil
+	}
+
+	parameters, err := PatchedParameters(ctx, r.Scheme(), spec, existing.DeepCopyObject().(T))
+	if err != nil {
+		return change, errors.Wrapf(err, "failed to get desired parameters for resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+	}
+	parameters.SetName(resourceName)
+	parameters.SetNamespace(resourceNamespace)
+
+	diff, err := azure.PlanDiff(existing, parameters)
+	if err != nil {
+		return change, errors.Wrapf(err, "failed to compute diff for resource %s/%s (service: %s)", resourceNamespace, resourceName, serviceName)
+	}
+	if diff != "" {
+		change.Action = infrav1.ResourceChangeActionUpdate
+		change.Diff = diff
+	}
+	return change, nil
+}
+
 // DeleteResource implements the logic for deleting a resource Asynchronously.
 func (r *reconciler[T]) DeleteResource(ctx context.Context, resource T, serviceName string) (err error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "services.aso.DeleteResource")
@@ -333,6 +389,7 @@ func (r *reconciler[T]) DeleteResource(ctx context.Context, resource T, serviceN
 
 	log.V(2).Info("deleting resource")
 	err = r.Client.Delete(ctx, resource)
+	r.auditMutation(ctx, audit.OperationDelete, resource, serviceName, err, err == nil)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// already deleted
@@ -349,6 +406,33 @@ func (r *reconciler[T]) DeleteResource(ctx context.Context, resource T, serviceN
 	}), requeueInterval)
 }
 
+// auditMutation records a change to an ASO resource in the audit log. ASO applies the change to Azure
+// asynchronously, so requests accepted by the API server are recorded as in progress. Deleting a resource which does
+// not exist is recorded as not found.
+func (r *reconciler[T]) auditMutation(ctx context.Context, op audit.Operation, obj client.Object, serviceName string, err error, inProgress bool) {
+	if !audit.Enabled() {
+		return
+	}
+	record := audit.Record{
+		Operation:    op,
+		ServiceName:  serviceName,
+		ResourceID:   obj.GetAnnotations()[genruntime.ResourceIDAnnotation],
+		ResourceName: obj.GetName(),
+		Requester:    audit.RequesterFromContext(ctx),
+	}
+	if record.Requester == nil {
+		if gvk, err := apiutil.GVKForObject(r.owner, r.Scheme()); err == nil {
+			record.Requester = audit.ObjectReference(gvk, r.owner)
+		}
+	}
+	if op == audit.OperationDelete && apierrors.IsNotFound(err) {
+		record.Result = audit.ResultNotFound
+	} else {
+		record.Result, record.Error = audit.ResultOf(err, inProgress)
+	}
+	audit.Log(ctx, record)
+}
+
 // IsManaged returns whether the ASO resource referred to by spec was created by
 // CAPZ and therefore whether CAPZ should manage its lifecycle.
 func IsManaged[T genruntime.MetaObject](ctx context.Context, ctrlClient client.Client, resource T, owner client.Object) (bool, error) {
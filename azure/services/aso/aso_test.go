@@ -26,6 +26,7 @@ import (
 	"github.com/Azure/azure-service-operator/v2/pkg/genruntime/conditions"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/aso/mock_aso"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 )
 
 const clusterName = "cluster"
@@ -183,6 +185,45 @@ func TestCreateOrUpdateResource(t *testing.T) {
 		}))
 	})
 
+	t.Run("create resource with the credentials of its spec", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			Build()
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, newOwner())
+
+		mockCtrl := gomock.NewController(t)
+		specMock := struct {
+			*mock_azure.MockASOResourceSpecGetter[*asoresourcesv1.ResourceGroup]
+			*mock_aso.MockPerResourceSecretNamer
+		}{
+			mock_azure.NewMockASOResourceSpecGetter[*asoresourcesv1.ResourceGroup](mockCtrl),
+			mock_aso.NewMockPerResourceSecretNamer(mockCtrl),
+		}
+		specMock.MockASOResourceSpecGetter.EXPECT().ResourceRef().Return(&asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "name",
+			},
+		})
+		specMock.MockASOResourceSpecGetter.EXPECT().Parameters(gomockinternal.AContext(), gomock.Nil()).Return(&asoresourcesv1.ResourceGroup{
+			Spec: asoresourcesv1.ResourceGroup_Spec{
+				Location: ptr.To("location"),
+			},
+		}, nil)
+		specMock.MockPerResourceSecretNamer.EXPECT().PerResourceSecretName().Return("cluster-aso-vnet-secret").AnyTimes()
+
+		ctx := t.Context()
+		_, err := s.CreateOrUpdateResource(ctx, specMock, "service")
+		g.Expect(azure.IsOperationNotDoneError(err)).To(BeTrue())
+
+		created := &asoresourcesv1.ResourceGroup{}
+		g.Expect(c.Get(ctx, types.NamespacedName{Name: "name", Namespace: "namespace"}, created)).To(Succeed())
+		g.Expect(created.Annotations).To(HaveKeyWithValue(asoannotations.PerResourceSecret, "cluster-aso-vnet-secret"))
+	})
+
 	t.Run("resource is not ready in non-terminal state", func(t *testing.T) {
 		g := NewGomegaWithT(t)
 
@@ -1091,6 +1132,182 @@ func TestCreateOrUpdateResource(t *testing.T) {
 	})
 }
 
+// TestPlanResource tests the PlanResource function.
+func TestPlanResource(t *testing.T) {
+	t.Run("resource doesn't already exist", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			Build()
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, newOwner())
+
+		mockCtrl := gomock.NewController(t)
+		specMock := mock_azure.NewMockASOResourceSpecGetter[*asoresourcesv1.ResourceGroup](mockCtrl)
+		specMock.EXPECT().ResourceRef().Return(&asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "name",
+			},
+		})
+
+		ctx := t.Context()
+		change, err := s.PlanResource(ctx, specMock, "service")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(change).To(Equal(infrav1.ResourceChange{
+			ServiceName:  "service",
+			ResourceName: "name",
+			Action:       infrav1.ResourceChangeActionCreate,
+		}))
+
+		// Planning must not create the resource.
+		g.Expect(apierrors.IsNotFound(c.Get(ctx, types.NamespacedName{Name: "name", Namespace: "namespace"}, &asoresourcesv1.ResourceGroup{}))).To(BeTrue())
+	})
+
+	t.Run("resource would be updated", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			Build()
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, newOwner())
+
+		mockCtrl := gomock.NewController(t)
+		specMock := mock_azure.NewMockASOResourceSpecGetter[*asoresourcesv1.ResourceGroup](mockCtrl)
+		specMock.EXPECT().ResourceRef().Return(&asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "name",
+			},
+		})
+		specMock.EXPECT().Parameters(gomockinternal.AContext(), gomock.Not(gomock.Nil())).DoAndReturn(func(_ context.Context, group *asoresourcesv1.ResourceGroup) (*asoresourcesv1.ResourceGroup, error) {
+			group.Spec.Location = ptr.To("new-location")
+			return group, nil
+		})
+
+		ctx := t.Context()
+		g.Expect(c.Create(ctx, &asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "name",
+				Namespace:       "namespace",
+				OwnerReferences: ownerRefs(),
+			},
+			Spec: asoresourcesv1.ResourceGroup_Spec{
+				Location: ptr.To("location"),
+			},
+		})).To(Succeed())
+
+		change, err := s.PlanResource(ctx, specMock, "service")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(change.Action).To(Equal(infrav1.ResourceChangeActionUpdate))
+		g.Expect(change.Diff).To(ContainSubstring("new-location"))
+
+		// Planning must not update the resource.
+		existing := &asoresourcesv1.ResourceGroup{}
+		g.Expect(c.Get(ctx, types.NamespacedName{Name: "name", Namespace: "namespace"}, existing)).To(Succeed())
+		g.Expect(existing.Spec.Location).To(Equal(ptr.To("location")))
+	})
+
+	t.Run("resource is up to date", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			Build()
+		s := New[*asoresourcesv1.ResourceGroup](c, clusterName, newOwner())
+
+		mockCtrl := gomock.NewController(t)
+		specMock := mock_azure.NewMockASOResourceSpecGetter[*asoresourcesv1.ResourceGroup](mockCtrl)
+		specMock.EXPECT().ResourceRef().Return(&asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "name",
+			},
+		})
+		specMock.EXPECT().Parameters(gomockinternal.AContext(), gomock.Not(gomock.Nil())).DoAndReturn(func(_ context.Context, group *asoresourcesv1.ResourceGroup) (*asoresourcesv1.ResourceGroup, error) {
+			return group, nil
+		})
+
+		ctx := t.Context()
+		g.Expect(c.Create(ctx, &asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "name",
+				Namespace:       "namespace",
+				OwnerReferences: ownerRefs(),
+			},
+			Spec: asoresourcesv1.ResourceGroup_Spec{
+				Location: ptr.To("location"),
+			},
+		})).To(Succeed())
+
+		change, err := s.PlanResource(ctx, specMock, "service")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(change.Action).To(Equal(infrav1.ResourceChangeActionNone))
+		g.Expect(change.Diff).To(BeEmpty())
+	})
+}
+
+// auditSink stores audit records in memory.
+type auditSink struct {
+	records []audit.Record
+}
+
+func (s *auditSink) Write(_ context.Context, r audit.Record) error {
+	s.records = append(s.records, r)
+	return nil
+}
+
+func TestAuditMutations(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sink := &auditSink{}
+	audit.SetDefaultSink(sink)
+	defer audit.SetDefaultSink(nil)
+
+	sch := runtime.NewScheme()
+	g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+	c := fakeclient.NewClientBuilder().
+		WithScheme(sch).
+		Build()
+	owner := newOwner()
+	owner.Name = "owner"
+	s := New[*asoresourcesv1.ResourceGroup](c, clusterName, owner)
+
+	mockCtrl := gomock.NewController(t)
+	specMock := mock_azure.NewMockASOResourceSpecGetter[*asoresourcesv1.ResourceGroup](mockCtrl)
+	specMock.EXPECT().ResourceRef().Return(&asoresourcesv1.ResourceGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "name",
+		},
+	})
+	specMock.EXPECT().Parameters(gomockinternal.AContext(), gomock.Nil()).Return(&asoresourcesv1.ResourceGroup{
+		Spec: asoresourcesv1.ResourceGroup_Spec{
+			Location: ptr.To("location"),
+		},
+	}, nil)
+
+	ctx := t.Context()
+	_, err := s.CreateOrUpdateResource(ctx, specMock, "service")
+	g.Expect(azure.IsOperationNotDoneError(err)).To(BeTrue())
+	g.Expect(sink.records).To(HaveLen(1))
+	g.Expect(sink.records[0].Operation).To(Equal(audit.OperationPut))
+	g.Expect(sink.records[0].ServiceName).To(Equal("service"))
+	g.Expect(sink.records[0].ResourceName).To(Equal("name"))
+	g.Expect(sink.records[0].Result).To(Equal(audit.ResultInProgress))
+	g.Expect(sink.records[0].Requester).NotTo(BeNil())
+	g.Expect(sink.records[0].Requester.Kind).To(Equal("ResourceGroup"))
+	g.Expect(sink.records[0].Requester.Name).To(Equal("owner"))
+
+	created := &asoresourcesv1.ResourceGroup{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: "name", Namespace: "namespace"}, created)).To(Succeed())
+	g.Expect(s.DeleteResource(ctx, created, "service")).NotTo(Succeed())
+	g.Expect(sink.records).To(HaveLen(2))
+	g.Expect(sink.records[1].Operation).To(Equal(audit.OperationDelete))
+	g.Expect(sink.records[1].Result).To(Equal(audit.ResultInProgress))
+}
+
 // TestDeleteResource tests the DeleteResource function.
 func TestDeleteResource(t *testing.T) {
 	t.Run("successful delete", func(t *testing.T) {
@@ -1213,6 +1430,37 @@ func TestDeleteResource(t *testing.T) {
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(err.Error()).To(ContainSubstring("failed to delete resource"))
 	})
+
+	t.Run("resource deleted concurrently", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+		sink := &auditSink{}
+		audit.SetDefaultSink(sink)
+		defer audit.SetDefaultSink(nil)
+
+		sch := runtime.NewScheme()
+		g.Expect(asoresourcesv1.AddToScheme(sch)).To(Succeed())
+		c := fakeclient.NewClientBuilder().
+			WithScheme(sch).
+			Build()
+		notFound := apierrors.NewNotFound(asoresourcesv1.GroupVersion.WithResource("resourcegroups").GroupResource(), "name")
+		s := New[*asoresourcesv1.ResourceGroup](ErroringDeleteClient{Client: c, err: notFound}, clusterName, newOwner())
+
+		resource := &asoresourcesv1.ResourceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "name",
+				Namespace:       "namespace",
+				OwnerReferences: ownerRefs(),
+			},
+		}
+
+		ctx := t.Context()
+		g.Expect(c.Create(ctx, resource)).To(Succeed())
+
+		g.Expect(s.DeleteResource(ctx, resource, "service")).To(Succeed())
+		g.Expect(sink.records).To(HaveLen(1))
+		g.Expect(sink.records[0].Operation).To(Equal(audit.OperationDelete))
+		g.Expect(sink.records[0].Result).To(Equal(audit.ResultNotFound))
+	})
 }
 
 func TestPauseResource(t *testing.T) {
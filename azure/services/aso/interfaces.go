@@ -31,6 +31,7 @@ type Reconciler[T genruntime.MetaObject] interface {
 	CreateOrUpdateResource(ctx context.Context, spec azure.ASOResourceSpecGetter[T], serviceName string) (result T, err error)
 	DeleteResource(ctx context.Context, resource T, serviceName string) (err error)
 	PauseResource(ctx context.Context, resource T, serviceName string) (err error)
+	PlanResource(ctx context.Context, spec azure.ASOResourceSpecGetter[T], serviceName string) (change infrav1.ResourceChange, err error)
 }
 
 // TagsGetterSetter represents an object that supports tags.
@@ -45,6 +46,12 @@ type Patcher interface {
 	ExtraPatches() []string
 }
 
+// PerResourceSecretNamer supplies the name of the secret with the credentials used to manage an ASO resource, when
+// they are not the credentials of the cluster.
+type PerResourceSecretNamer interface {
+	PerResourceSecretName() string
+}
+
 // Scope represents the common functionality related to all scopes needed for ASO services.
 type Scope interface {
 	azure.AsyncStatusUpdater
@@ -105,6 +105,21 @@ func (mr *MockReconcilerMockRecorder[T]) PauseResource(ctx, resource, serviceNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseResource", reflect.TypeOf((*MockReconciler[T])(nil).PauseResource), ctx, resource, serviceName)
 }
 
+// PlanResource mocks base method.
+func (m *MockReconciler[T]) PlanResource(ctx context.Context, spec azure.ASOResourceSpecGetter[T], serviceName string) (v1beta1.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanResource", ctx, spec, serviceName)
+	ret0, _ := ret[0].(v1beta1.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanResource indicates an expected call of PlanResource.
+func (mr *MockReconcilerMockRecorder[T]) PlanResource(ctx, spec, serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanResource", reflect.TypeOf((*MockReconciler[T])(nil).PlanResource), ctx, spec, serviceName)
+}
+
 // MockTagsGetterSetter is a mock of TagsGetterSetter interface.
 type MockTagsGetterSetter[T genruntime.MetaObject] struct {
 	ctrl     *gomock.Controller
@@ -207,6 +222,44 @@ func (mr *MockPatcherMockRecorder) ExtraPatches() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtraPatches", reflect.TypeOf((*MockPatcher)(nil).ExtraPatches))
 }
 
+// MockPerResourceSecretNamer is a mock of PerResourceSecretNamer interface.
+type MockPerResourceSecretNamer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPerResourceSecretNamerMockRecorder
+	isgomock struct{}
+}
+
+// MockPerResourceSecretNamerMockRecorder is the mock recorder for MockPerResourceSecretNamer.
+type MockPerResourceSecretNamerMockRecorder struct {
+	mock *MockPerResourceSecretNamer
+}
+
+// NewMockPerResourceSecretNamer creates a new mock instance.
+func NewMockPerResourceSecretNamer(ctrl *gomock.Controller) *MockPerResourceSecretNamer {
+	mock := &MockPerResourceSecretNamer{ctrl: ctrl}
+	mock.recorder = &MockPerResourceSecretNamerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPerResourceSecretNamer) EXPECT() *MockPerResourceSecretNamerMockRecorder {
+	return m.recorder
+}
+
+// PerResourceSecretName mocks base method.
+func (m *MockPerResourceSecretNamer) PerResourceSecretName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PerResourceSecretName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PerResourceSecretName indicates an expected call of PerResourceSecretName.
+func (mr *MockPerResourceSecretNamerMockRecorder) PerResourceSecretName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PerResourceSecretName", reflect.TypeOf((*MockPerResourceSecretNamer)(nil).PerResourceSecretName))
+}
+
 // MockScope is a mock of Scope interface.
 type MockScope struct {
 	ctrl     *gomock.Controller
@@ -24,6 +24,7 @@ import (
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -164,3 +165,21 @@ func (s *Service[T, S]) Pause(ctx context.Context) error {
 
 	return nil
 }
+
+// Plan implements azure.Planner.
+func (s *Service[T, S]) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	var _ azure.Planner = (*Service[T, S])(nil)
+
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "aso.Service.Plan")
+	defer done()
+
+	changes := make([]infrav1.ResourceChange, 0, len(s.Specs))
+	for _, spec := range s.Specs {
+		change, err := s.PlanResource(ctx, spec, s.Name())
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
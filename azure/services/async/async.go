@@ -28,6 +28,8 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -58,6 +60,9 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.CreateOrUpdateResource")
 	defer done()
 
+	start := time.Now()
+	defer func() { azure.ObserveService(serviceName, metrics.OperationCreateOrUpdateResource, start, err) }()
+
 	resourceName := spec.ResourceName()
 	rgName := spec.ResourceGroupName()
 	futureType := infrav1.PutFuture
@@ -107,7 +112,15 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 		}
 	}
 
-	result, poller, err := s.Creator.CreateOrUpdateAsync(ctx, spec, resumeToken, parameters)
+	auditCtx, capturedID := audit.WithResourceIDCapture(ctx)
+	result, poller, err := s.Creator.CreateOrUpdateAsync(auditCtx, spec, resumeToken, parameters)
+	auditMutation(ctx, audit.Record{
+		Operation:     audit.OperationPut,
+		ServiceName:   serviceName,
+		ResourceID:    firstNonEmpty(capturedID(), audit.ResourceID(result)),
+		ResourceGroup: rgName,
+		ResourceName:  resourceName,
+	}, resumeToken != "", poller != nil, err)
 	errWrapped := errors.Wrapf(err, "failed to create or update resource %s/%s (service: %s)", rgName, resourceName, serviceName)
 	if poller != nil && azure.IsContextDeadlineExceededOrCanceledError(err) {
 		future, err := converters.PollerToFuture(poller, infrav1.PutFuture, serviceName, resourceName, rgName)
@@ -130,11 +143,133 @@ func (s *Service[C, D]) CreateOrUpdateResource(ctx context.Context, spec azure.R
 	return result, nil
 }
 
+// PlanResource computes the change that CreateOrUpdateResource would make to a resource without making it.
+func (s *Service[C, D]) PlanResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (infrav1.ResourceChange, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.PlanResource")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+	change := infrav1.ResourceChange{
+		ServiceName:   serviceName,
+		ResourceName:  resourceName,
+		ResourceGroup: rgName,
+		Action:        infrav1.ResourceChangeActionNone,
+	}
+
+	var existingResource any
+	if existing, err := s.Creator.Get(ctx, spec); err != nil && !azure.ResourceNotFound(err) {
+		errWrapped := errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		return change, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+	} else if err == nil {
+		existingResource = existing
+	}
+
+	parameters, err := spec.Parameters(ctx, existingResource)
+	if err != nil {
+		return change, errors.Wrapf(err, "failed to get desired parameters for resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
+
+	switch {
+	case parameters == nil:
+		change.Action = infrav1.ResourceChangeActionNone
+	case existingResource == nil:
+		change.Action = infrav1.ResourceChangeActionCreate
+	default:
+		diff, err := azure.PlanDiff(existingResource, parameters)
+		if err != nil {
+			return change, errors.Wrapf(err, "failed to compute diff for resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		}
+		// Parameters may return non-nil parameters without any effective change, e.g. when it
+		// rebuilds the whole resource rather than comparing it against the existing one.
+		if diff != "" {
+			change.Action = infrav1.ResourceChangeActionUpdate
+			change.Diff = diff
+		}
+	}
+
+	log.V(2).Info("planned resource change", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "action", change.Action)
+	return change, nil
+}
+
+// DetectResourceDrift compares a resource to the desired state of the whole resource described by spec, and updates
+// it to its desired state when it has drifted and correct is true. It returns nil when the resource does not exist or
+// has not drifted.
+func (s *Service[C, D]) DetectResourceDrift(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, correct bool) (*infrav1.ResourceDrift, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.DetectResourceDrift")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+
+	existing, err := s.Creator.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		// A missing resource is created by the next reconciliation rather than reported as drift.
+		return nil, nil
+	} else if err != nil {
+		errWrapped := errors.Wrapf(err, "failed to get existing resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+		return nil, azure.WithTransientError(errWrapped, getRetryAfterFromError(err))
+	}
+
+	// Parameters returns the desired state of the whole resource when there is no existing resource, while it may
+	// ignore changes made to an existing resource.
+	desired, err := spec.Parameters(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get desired parameters for resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	} else if desired == nil {
+		return nil, nil
+	}
+
+	diff, corrected, err := azure.DriftCorrection(existing, desired)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute drift for resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	} else if diff == "" {
+		return nil, nil
+	}
+
+	drift := &infrav1.ResourceDrift{
+		ServiceName:   serviceName,
+		ResourceName:  resourceName,
+		ResourceGroup: rgName,
+		Diff:          diff,
+	}
+	log.V(2).Info("detected resource drift", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	if !correct {
+		return drift, nil
+	}
+
+	// A correction which does not complete in time is resumed by the next reconciliation from its long-running
+	// operation state.
+	if _, err := s.CreateOrUpdateResource(ctx, correctedSpec{ResourceSpecGetter: spec, parameters: corrected}, serviceName); err != nil {
+		if azure.IsOperationNotDoneError(err) {
+			return drift, nil
+		}
+		return drift, errors.Wrapf(err, "failed to correct drift of resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
+	drift.Corrected = true
+	log.V(2).Info("corrected resource drift", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	return drift, nil
+}
+
+// correctedSpec is a resource spec whose parameters are the existing resource with its drifted fields corrected.
+type correctedSpec struct {
+	azure.ResourceSpecGetter
+	parameters any
+}
+
+// Parameters returns the corrected resource.
+func (s correctedSpec) Parameters(_ context.Context, _ any) (any, error) {
+	return s.parameters, nil
+}
+
 // DeleteResource deletes a resource asynchronously.
 func (s *Service[C, D]) DeleteResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (err error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.DeleteResource")
 	defer done()
 
+	start := time.Now()
+	defer func() { azure.ObserveService(serviceName, metrics.OperationDeleteResource, start, err) }()
+
 	resourceName := spec.ResourceName()
 	rgName := spec.ResourceGroupName()
 	futureType := infrav1.DeleteFuture
@@ -152,7 +287,15 @@ func (s *Service[C, D]) DeleteResource(ctx context.Context, spec azure.ResourceS
 
 	// Delete the resource.
 	log.V(2).Info("deleting resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
-	poller, err := s.Deleter.DeleteAsync(ctx, spec, resumeToken)
+	auditCtx, capturedID := audit.WithResourceIDCapture(ctx)
+	poller, err := s.Deleter.DeleteAsync(auditCtx, spec, resumeToken)
+	auditMutation(ctx, audit.Record{
+		Operation:     audit.OperationDelete,
+		ServiceName:   serviceName,
+		ResourceID:    capturedID(),
+		ResourceGroup: rgName,
+		ResourceName:  resourceName,
+	}, resumeToken != "", poller != nil, err)
 	if poller != nil && azure.IsContextDeadlineExceededOrCanceledError(err) {
 		future, err := converters.PollerToFuture(poller, infrav1.DeleteFuture, serviceName, resourceName, rgName)
 		if err != nil {
@@ -174,6 +317,34 @@ func (s *Service[C, D]) DeleteResource(ctx context.Context, spec azure.ResourceS
 	return nil
 }
 
+// auditMutation completes record with the result of a PUT or DELETE request and records it in the audit log.
+// Requests which resume an operation that is still in progress are not recorded again, and deleting a resource which
+// does not exist is recorded as not found.
+func auditMutation(ctx context.Context, record audit.Record, resumed, hasPoller bool, err error) {
+	if !audit.Enabled() {
+		return
+	}
+	inProgress := hasPoller && azure.IsContextDeadlineExceededOrCanceledError(err)
+	if resumed && inProgress {
+		return
+	}
+	if record.Operation == audit.OperationDelete && azure.ResourceNotFound(err) {
+		record.Result = audit.ResultNotFound
+	} else {
+		record.Result, record.Error = audit.ResultOf(err, inProgress)
+	}
+	audit.Log(ctx, record)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 // requeueTime returns the time to wait before requeuing a reconciliation.
 // It would be ideal to use the "retry-after" header from the API response, but
 // that is not readily accessible in the SDK v2 Poller framework.
@@ -32,12 +32,14 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 )
 
@@ -289,6 +291,242 @@ func TestServiceDeleteResource(t *testing.T) {
 	}
 }
 
+func TestServicePlanResource(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedError  string
+		expectedChange infrav1.ResourceChange
+		expect         func(c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name: "resource does not exist",
+			expectedChange: infrav1.ResourceChange{
+				ServiceName:   serviceName,
+				ResourceName:  resourceName,
+				ResourceGroup: resourceGroupName,
+				Action:        infrav1.ResourceChangeActionCreate,
+			},
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound}),
+					r.Parameters(gomockinternal.AContext(), nil).Return(fakeParameters, nil),
+				)
+			},
+		},
+		{
+			name: "resource is up to date",
+			expectedChange: infrav1.ResourceChange{
+				ServiceName:   serviceName,
+				ResourceName:  resourceName,
+				ResourceGroup: resourceGroupName,
+				Action:        infrav1.ResourceChangeActionNone,
+			},
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(fakeResource, nil),
+					r.Parameters(gomockinternal.AContext(), fakeResource).Return(nil, nil),
+				)
+			},
+		},
+		{
+			name: "resource would be updated",
+			expectedChange: infrav1.ResourceChange{
+				ServiceName:   serviceName,
+				ResourceName:  resourceName,
+				ResourceGroup: resourceGroupName,
+				Action:        infrav1.ResourceChangeActionUpdate,
+			},
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(armresources.GenericResource{Location: ptr.To("westus")}, nil),
+					r.Parameters(gomockinternal.AContext(), gomock.Any()).Return(armresources.GenericResource{Location: ptr.To("eastus")}, nil),
+				)
+			},
+		},
+		{
+			name:          "get fails",
+			expectedError: "failed to get existing resource mock-resourcegroup/mock-resource (service: mock-service)",
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, errors.New("foo")),
+				)
+			},
+		},
+		{
+			name:          "parameters fails",
+			expectedError: "failed to get desired parameters for resource mock-resourcegroup/mock-resource (service: mock-service): foo",
+			expect: func(c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(fakeResource, nil),
+					r.Parameters(gomockinternal.AContext(), fakeResource).Return(nil, errors.New("foo")),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+			svc := New[MockCreator, MockDeleter](scopeMock, creatorMock, nil)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(creatorMock.EXPECT(), specMock.EXPECT())
+
+			change, err := svc.PlanResource(t.Context(), specMock, serviceName)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(change.Action).To(Equal(tc.expectedChange.Action))
+			g.Expect(change.ServiceName).To(Equal(tc.expectedChange.ServiceName))
+			g.Expect(change.ResourceName).To(Equal(tc.expectedChange.ResourceName))
+			g.Expect(change.ResourceGroup).To(Equal(tc.expectedChange.ResourceGroup))
+			if change.Action == infrav1.ResourceChangeActionUpdate {
+				g.Expect(change.Diff).To(ContainSubstring("eastus"))
+			} else {
+				g.Expect(change.Diff).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestServiceDetectResourceDrift(t *testing.T) {
+	existing := armresources.GenericResource{Location: ptr.To("westus"), Tags: map[string]*string{"foo": ptr.To("bar")}}
+	testcases := []struct {
+		name          string
+		correct       bool
+		expectedError string
+		expectedDrift *infrav1.ResourceDrift
+		expect        func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name: "resource does not exist",
+			expect: func(_ *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound}),
+				)
+			},
+		},
+		{
+			name: "resource has not drifted",
+			expect: func(_ *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(existing, nil),
+					r.Parameters(gomockinternal.AContext(), nil).Return(armresources.GenericResource{Location: ptr.To("westus")}, nil),
+				)
+			},
+		},
+		{
+			name: "drifted resource is reported",
+			expectedDrift: &infrav1.ResourceDrift{
+				ServiceName:   serviceName,
+				ResourceName:  resourceName,
+				ResourceGroup: resourceGroupName,
+			},
+			expect: func(_ *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(existing, nil),
+					r.Parameters(gomockinternal.AContext(), nil).Return(armresources.GenericResource{Location: ptr.To("eastus")}, nil),
+				)
+			},
+		},
+		{
+			name:    "drifted resource is corrected without losing fields set outside of CAPZ",
+			correct: true,
+			expectedDrift: &infrav1.ResourceDrift{
+				ServiceName:   serviceName,
+				ResourceName:  resourceName,
+				ResourceGroup: resourceGroupName,
+				Corrected:     true,
+			},
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				corrected := armresources.GenericResource{Location: ptr.To("eastus"), Tags: map[string]*string{"foo": ptr.To("bar")}}
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(existing, nil),
+					r.Parameters(gomockinternal.AContext(), nil).Return(armresources.GenericResource{Location: ptr.To("eastus")}, nil),
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					s.GetLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture).Return(nil),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(existing, nil),
+					c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "", corrected).Return(corrected, nil, nil),
+					s.DeleteLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture),
+				)
+			},
+		},
+		{
+			name:          "get fails",
+			expectedError: "failed to get existing resource mock-resourcegroup/mock-resource (service: mock-service)",
+			expect: func(_ *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder[MockCreator], r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				gomock.InOrder(
+					r.ResourceName().Return(resourceName),
+					r.ResourceGroupName().Return(resourceGroupName),
+					c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, errors.New("foo")),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+			svc := New[MockCreator, MockDeleter](scopeMock, creatorMock, nil)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), creatorMock.EXPECT(), specMock.EXPECT())
+
+			drift, err := svc.DetectResourceDrift(t.Context(), specMock, serviceName, tc.correct)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expectedDrift == nil {
+				g.Expect(drift).To(BeNil())
+				return
+			}
+			g.Expect(drift).NotTo(BeNil())
+			g.Expect(drift.ServiceName).To(Equal(tc.expectedDrift.ServiceName))
+			g.Expect(drift.ResourceName).To(Equal(tc.expectedDrift.ResourceName))
+			g.Expect(drift.ResourceGroup).To(Equal(tc.expectedDrift.ResourceGroup))
+			g.Expect(drift.Corrected).To(Equal(tc.expectedDrift.Corrected))
+			g.Expect(drift.Diff).To(ContainSubstring("eastus"))
+		})
+	}
+}
+
 const (
 	resourceGroupName  = "mock-resourcegroup"
 	resourceName       = "mock-resource"
@@ -297,6 +535,150 @@ const (
 	invalidResumeToken = "!invalid-resume-token"
 )
 
+type auditSink struct {
+	records []audit.Record
+}
+
+func (s *auditSink) Write(_ context.Context, r audit.Record) error {
+	s.records = append(s.records, r)
+	return nil
+}
+
+func TestServiceAudit(t *testing.T) {
+	resourceID := "/subscriptions/123/resourceGroups/mock-resourcegroup/providers/Microsoft.Resources/mock/mock-resource"
+
+	t.Run("new request in progress", func(t *testing.T) {
+		g := NewWithT(t)
+		sink := &auditSink{}
+		audit.SetDefaultSink(sink)
+		defer audit.SetDefaultSink(nil)
+
+		mockCtrl := gomock.NewController(t)
+		scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+		creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+		specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+		svc := New[MockCreator, MockDeleter](scopeMock, creatorMock, nil)
+
+		specMock.EXPECT().ResourceName().Return(resourceName)
+		specMock.EXPECT().ResourceGroupName().Return(resourceGroupName)
+		scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture).Return(nil)
+		creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType)).Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+		specMock.EXPECT().Parameters(gomockinternal.AContext(), nil).Return(fakeParameters, nil)
+		creatorMock.EXPECT().CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "", gomock.Any()).Return(nil, fakePoller[MockCreator](g, http.StatusAccepted), context.DeadlineExceeded)
+		scopeMock.EXPECT().SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+		scopeMock.EXPECT().DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue)
+
+		_, err := svc.CreateOrUpdateResource(t.Context(), specMock, serviceName)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(sink.records).To(HaveLen(1))
+		g.Expect(sink.records[0].Operation).To(Equal(audit.OperationPut))
+		g.Expect(sink.records[0].ResourceGroup).To(Equal(resourceGroupName))
+		g.Expect(sink.records[0].ResourceName).To(Equal(resourceName))
+		g.Expect(sink.records[0].Result).To(Equal(audit.ResultInProgress))
+	})
+
+	t.Run("resumed request still in progress", func(t *testing.T) {
+		g := NewWithT(t)
+		sink := &auditSink{}
+		audit.SetDefaultSink(sink)
+		defer audit.SetDefaultSink(nil)
+
+		mockCtrl := gomock.NewController(t)
+		scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+		creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+		specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+		svc := New[MockCreator, MockDeleter](scopeMock, creatorMock, nil)
+
+		specMock.EXPECT().ResourceName().Return(resourceName)
+		specMock.EXPECT().ResourceGroupName().Return(resourceGroupName)
+		scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture).Return(validPutFuture)
+		creatorMock.EXPECT().CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), resumeToken, gomock.Any()).Return(nil, fakePoller[MockCreator](g, http.StatusAccepted), context.DeadlineExceeded)
+		scopeMock.EXPECT().SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+		scopeMock.EXPECT().DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue)
+
+		_, err := svc.CreateOrUpdateResource(t.Context(), specMock, serviceName)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(sink.records).To(BeEmpty())
+	})
+
+	t.Run("resumed request completed", func(t *testing.T) {
+		g := NewWithT(t)
+		sink := &auditSink{}
+		audit.SetDefaultSink(sink)
+		defer audit.SetDefaultSink(nil)
+
+		mockCtrl := gomock.NewController(t)
+		scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+		creatorMock := mock_async.NewMockCreator[MockCreator](mockCtrl)
+		specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+		svc := New[MockCreator, MockDeleter](scopeMock, creatorMock, nil)
+
+		specMock.EXPECT().ResourceName().Return(resourceName)
+		specMock.EXPECT().ResourceGroupName().Return(resourceGroupName)
+		scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture).Return(validPutFuture)
+		creatorMock.EXPECT().CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), resumeToken, gomock.Any()).Return(armresources.GenericResource{ID: ptr.To(resourceID)}, nil, nil)
+		scopeMock.EXPECT().DeleteLongRunningOperationState(resourceName, serviceName, infrav1.PutFuture)
+
+		_, err := svc.CreateOrUpdateResource(t.Context(), specMock, serviceName)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(sink.records).To(HaveLen(1))
+		g.Expect(sink.records[0].ResourceID).To(Equal(resourceID))
+		g.Expect(sink.records[0].Result).To(Equal(audit.ResultSucceeded))
+	})
+
+	t.Run("delete failed", func(t *testing.T) {
+		g := NewWithT(t)
+		sink := &auditSink{}
+		audit.SetDefaultSink(sink)
+		defer audit.SetDefaultSink(nil)
+
+		mockCtrl := gomock.NewController(t)
+		scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+		deleterMock := mock_async.NewMockDeleter[MockDeleter](mockCtrl)
+		specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+		svc := New[MockCreator, MockDeleter](scopeMock, nil, deleterMock)
+
+		specMock.EXPECT().ResourceName().Return(resourceName)
+		specMock.EXPECT().ResourceGroupName().Return(resourceGroupName)
+		scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, infrav1.DeleteFuture).Return(nil)
+		deleterMock.EXPECT().DeleteAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "").Return(nil, errors.New("foo"))
+		scopeMock.EXPECT().DeleteLongRunningOperationState(resourceName, serviceName, infrav1.DeleteFuture)
+
+		err := svc.DeleteResource(t.Context(), specMock, serviceName)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(sink.records).To(HaveLen(1))
+		g.Expect(sink.records[0].Operation).To(Equal(audit.OperationDelete))
+		g.Expect(sink.records[0].Result).To(Equal(audit.ResultFailed))
+		g.Expect(sink.records[0].Error).To(Equal("foo"))
+	})
+
+	t.Run("delete of a resource which does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+		sink := &auditSink{}
+		audit.SetDefaultSink(sink)
+		defer audit.SetDefaultSink(nil)
+
+		mockCtrl := gomock.NewController(t)
+		scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+		deleterMock := mock_async.NewMockDeleter[MockDeleter](mockCtrl)
+		specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+		svc := New[MockCreator, MockDeleter](scopeMock, nil, deleterMock)
+
+		specMock.EXPECT().ResourceName().Return(resourceName)
+		specMock.EXPECT().ResourceGroupName().Return(resourceGroupName)
+		scopeMock.EXPECT().GetLongRunningOperationState(resourceName, serviceName, infrav1.DeleteFuture).Return(nil)
+		deleterMock.EXPECT().DeleteAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(azureResourceGetterType), "").Return(nil, &azcore.ResponseError{StatusCode: http.StatusNotFound})
+		scopeMock.EXPECT().DeleteLongRunningOperationState(resourceName, serviceName, infrav1.DeleteFuture)
+
+		err := svc.DeleteResource(t.Context(), specMock, serviceName)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(sink.records).To(HaveLen(1))
+		g.Expect(sink.records[0].Operation).To(Equal(audit.OperationDelete))
+		g.Expect(sink.records[0].Result).To(Equal(audit.ResultNotFound))
+		g.Expect(sink.records[0].Error).To(BeEmpty())
+	})
+}
+
 var (
 	validPutFuture = &infrav1.Future{
 		Type:          infrav1.PutFuture,
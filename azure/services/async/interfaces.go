@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
@@ -55,4 +56,6 @@ type Deleter[T any] interface {
 type Reconciler interface {
 	CreateOrUpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (result any, err error)
 	DeleteResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (err error)
+	PlanResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (change infrav1.ResourceChange, err error)
+	DetectResourceDrift(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, correct bool) (drift *infrav1.ResourceDrift, err error)
 }
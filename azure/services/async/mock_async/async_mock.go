@@ -402,3 +402,33 @@ func (mr *MockReconcilerMockRecorder) DeleteResource(ctx, spec, serviceName any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockReconciler)(nil).DeleteResource), ctx, spec, serviceName)
 }
+
+// DetectResourceDrift mocks base method.
+func (m *MockReconciler) DetectResourceDrift(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, correct bool) (*v1beta1.ResourceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectResourceDrift", ctx, spec, serviceName, correct)
+	ret0, _ := ret[0].(*v1beta1.ResourceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectResourceDrift indicates an expected call of DetectResourceDrift.
+func (mr *MockReconcilerMockRecorder) DetectResourceDrift(ctx, spec, serviceName, correct any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectResourceDrift", reflect.TypeOf((*MockReconciler)(nil).DetectResourceDrift), ctx, spec, serviceName, correct)
+}
+
+// PlanResource mocks base method.
+func (m *MockReconciler) PlanResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (v1beta1.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanResource", ctx, spec, serviceName)
+	ret0, _ := ret[0].(v1beta1.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanResource indicates an expected call of PlanResource.
+func (mr *MockReconcilerMockRecorder) PlanResource(ctx, spec, serviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanResource", reflect.TypeOf((*MockReconciler)(nil).PlanResource), ctx, spec, serviceName)
+}
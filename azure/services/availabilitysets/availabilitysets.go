@@ -86,6 +86,21 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return err
 }
 
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "availabilitysets.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	setSpec := s.Scope.AvailabilitySetSpec()
+	if setSpec == nil {
+		return nil, nil
+	}
+	return azure.DetectDriftAll(ctx, s.Reconciler, []azure.ResourceSpecGetter{setSpec}, serviceName, correct)
+}
+
 // Delete deletes availability sets.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "availabilitysets.Service.Delete")
@@ -0,0 +1,194 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "azurefirewalls"
+
+// FirewallScope defines the scope interface for an Azure Firewall service.
+type FirewallScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	AzureFirewallSpecs() (policySpec, ruleCollectionGroupSpec, firewallSpec azure.ResourceSpecGetter)
+	SetFirewallPrivateIP(string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope                         FirewallScope
+	policyReconciler              async.Reconciler
+	ruleCollectionGroupReconciler async.Reconciler
+	firewallReconciler            async.Reconciler
+}
+
+// New creates a new Azure Firewall service.
+func New(scope FirewallScope) (*Service, error) {
+	policiesClient, err := newFirewallPoliciesClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	ruleCollectionGroupsClient, err := newRuleCollectionGroupsClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	firewallsClient, err := newAzureFirewallsClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		Scope: scope,
+		policyReconciler: async.New[armnetwork.FirewallPoliciesClientCreateOrUpdateResponse,
+			armnetwork.FirewallPoliciesClientDeleteResponse](scope, policiesClient, policiesClient),
+		ruleCollectionGroupReconciler: async.New[armnetwork.FirewallPolicyRuleCollectionGroupsClientCreateOrUpdateResponse,
+			armnetwork.FirewallPolicyRuleCollectionGroupsClientDeleteResponse](scope, ruleCollectionGroupsClient, ruleCollectionGroupsClient),
+		firewallReconciler: async.New[armnetwork.AzureFirewallsClientCreateOrUpdateResponse,
+			armnetwork.AzureFirewallsClientDeleteResponse](scope, firewallsClient, firewallsClient),
+	}, nil
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile idempotently creates or updates the firewall policy, its default rule collection group and the Azure
+// Firewall, and records the private IP of the Azure Firewall.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	policySpec, ruleCollectionGroupSpec, firewallSpec := s.Scope.AzureFirewallSpecs()
+	if policySpec == nil {
+		return nil
+	}
+
+	var result any
+	_, err := s.policyReconciler.CreateOrUpdateResource(ctx, policySpec, serviceName)
+	if err == nil {
+		_, err = s.ruleCollectionGroupReconciler.CreateOrUpdateResource(ctx, ruleCollectionGroupSpec, serviceName)
+	}
+	if err == nil {
+		result, err = s.firewallReconciler.CreateOrUpdateResource(ctx, firewallSpec, serviceName)
+	}
+	s.Scope.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, err)
+	if err == nil && result != nil {
+		firewall, ok := result.(armnetwork.AzureFirewall)
+		if !ok {
+			return errors.Errorf("%T is not an armnetwork.AzureFirewall", result)
+		}
+		s.Scope.SetFirewallPrivateIP(privateIPAddress(firewall))
+	}
+	return err
+}
+
+// Plan implements azure.Planner.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	policySpec, ruleCollectionGroupSpec, firewallSpec := s.Scope.AzureFirewallSpecs()
+	if policySpec == nil {
+		return nil, nil
+	}
+
+	policyChanges, err := azure.PlanAll(ctx, s.policyReconciler, []azure.ResourceSpecGetter{policySpec}, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	ruleCollectionGroupChanges, err := azure.PlanAll(ctx, s.ruleCollectionGroupReconciler, []azure.ResourceSpecGetter{ruleCollectionGroupSpec}, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	firewallChanges, err := azure.PlanAll(ctx, s.firewallReconciler, []azure.ResourceSpecGetter{firewallSpec}, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(policyChanges, ruleCollectionGroupChanges...), firewallChanges...), nil
+}
+
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	policySpec, ruleCollectionGroupSpec, firewallSpec := s.Scope.AzureFirewallSpecs()
+	if policySpec == nil {
+		return nil, nil
+	}
+
+	policyDrift, err := azure.DetectDriftAll(ctx, s.policyReconciler, []azure.ResourceSpecGetter{policySpec}, serviceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	ruleCollectionGroupDrift, err := azure.DetectDriftAll(ctx, s.ruleCollectionGroupReconciler, []azure.ResourceSpecGetter{ruleCollectionGroupSpec}, serviceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	firewallDrift, err := azure.DetectDriftAll(ctx, s.firewallReconciler, []azure.ResourceSpecGetter{firewallSpec}, serviceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(policyDrift, ruleCollectionGroupDrift...), firewallDrift...), nil
+}
+
+// Delete deletes the Azure Firewall and its firewall policy, which also deletes the rule collection groups of the
+// firewall policy.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	policySpec, _, firewallSpec := s.Scope.AzureFirewallSpecs()
+	if policySpec == nil {
+		return nil
+	}
+
+	// The firewall policy cannot be deleted while the Azure Firewall uses it.
+	err := s.firewallReconciler.DeleteResource(ctx, firewallSpec, serviceName)
+	if err == nil {
+		err = s.policyReconciler.DeleteResource(ctx, policySpec, serviceName)
+	}
+	s.Scope.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, err)
+	return err
+}
+
+// IsManaged always returns true as CAPZ does not support BYO Azure Firewalls.
+func (s *Service) IsManaged(_ context.Context) (bool, error) {
+	return true, nil
+}
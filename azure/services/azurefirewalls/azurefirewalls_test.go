@@ -0,0 +1,258 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls/mock_azurefirewalls"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+var (
+	fakePolicySpec = FirewallPolicySpec{
+		Name:          "test-cluster-azfw-policy",
+		ResourceGroup: "test-rg",
+		Location:      "fake-location",
+		ClusterName:   "test-cluster",
+		SKUTier:       infrav1.StandardAzureFirewallSKUTier,
+	}
+	fakeRuleCollectionGroupSpec = RuleCollectionGroupSpec{
+		Name:               "kubernetes-egress",
+		ResourceGroup:      "test-rg",
+		FirewallPolicyName: "test-cluster-azfw-policy",
+		SourceAddresses:    []string{"10.1.0.0/16"},
+		AzureEndpointFQDNs: []string{"management.azure.com", "login.microsoftonline.com"},
+	}
+	fakeFirewallSpec = AzureFirewallSpec{
+		Name:             "test-cluster-azfw",
+		ResourceGroup:    "test-rg",
+		Location:         "fake-location",
+		ClusterName:      "test-cluster",
+		SKUTier:          infrav1.StandardAzureFirewallSKUTier,
+		FirewallPolicyID: "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/firewallPolicies/test-cluster-azfw-policy",
+		SubnetID:         "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/AzureFirewallSubnet",
+		PublicIPID:       "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Network/publicIPAddresses/test-cluster-azfw-pip",
+	}
+	fakeFirewall = armnetwork.AzureFirewall{
+		Properties: &armnetwork.AzureFirewallPropertiesFormat{
+			IPConfigurations: []*armnetwork.AzureFirewallIPConfiguration{
+				{
+					Name: ptr.To(ipConfigName),
+					Properties: &armnetwork.AzureFirewallIPConfigurationPropertiesFormat{
+						PrivateIPAddress: ptr.To("10.255.253.4"),
+					},
+				},
+			},
+		},
+	}
+	errFake = errors.New("this is an error")
+)
+
+func TestReconcileAzureFirewall(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, ruleCollectionGroup, firewall *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if firewall is not configured",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, ruleCollectionGroup, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(nil, nil, nil)
+			},
+		},
+		{
+			name:          "create firewall succeeds and sets its private IP",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, ruleCollectionGroup, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				policy.CreateOrUpdateResource(gomockinternal.AContext(), &fakePolicySpec, serviceName).Return(armnetwork.FirewallPolicy{}, nil)
+				ruleCollectionGroup.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRuleCollectionGroupSpec, serviceName).Return(armnetwork.FirewallPolicyRuleCollectionGroup{}, nil)
+				firewall.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(fakeFirewall, nil)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, nil)
+				s.SetFirewallPrivateIP("10.255.253.4")
+			},
+		},
+		{
+			name:          "create firewall policy fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, ruleCollectionGroup, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				policy.CreateOrUpdateResource(gomockinternal.AContext(), &fakePolicySpec, serviceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "create rule collection group fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, ruleCollectionGroup, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				policy.CreateOrUpdateResource(gomockinternal.AContext(), &fakePolicySpec, serviceName).Return(armnetwork.FirewallPolicy{}, nil)
+				ruleCollectionGroup.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRuleCollectionGroupSpec, serviceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "create firewall fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, ruleCollectionGroup, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				policy.CreateOrUpdateResource(gomockinternal.AContext(), &fakePolicySpec, serviceName).Return(armnetwork.FirewallPolicy{}, nil)
+				ruleCollectionGroup.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRuleCollectionGroupSpec, serviceName).Return(armnetwork.FirewallPolicyRuleCollectionGroup{}, nil)
+				firewall.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "create firewall returns an unexpected result",
+			expectedError: "string is not an armnetwork.AzureFirewall",
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, ruleCollectionGroup, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				policy.CreateOrUpdateResource(gomockinternal.AContext(), &fakePolicySpec, serviceName).Return(armnetwork.FirewallPolicy{}, nil)
+				ruleCollectionGroup.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRuleCollectionGroupSpec, serviceName).Return(armnetwork.FirewallPolicyRuleCollectionGroup{}, nil)
+				firewall.CreateOrUpdateResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return("not a firewall", nil)
+				s.UpdatePutStatus(infrav1.AzureFirewallReadyCondition, serviceName, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_azurefirewalls.NewMockFirewallScope(mockCtrl)
+			policyMock := mock_async.NewMockReconciler(mockCtrl)
+			ruleCollectionGroupMock := mock_async.NewMockReconciler(mockCtrl)
+			firewallMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), policyMock.EXPECT(), ruleCollectionGroupMock.EXPECT(), firewallMock.EXPECT())
+
+			s := &Service{
+				Scope:                         scopeMock,
+				policyReconciler:              policyMock,
+				ruleCollectionGroupReconciler: ruleCollectionGroupMock,
+				firewallReconciler:            firewallMock,
+			}
+
+			err := s.Reconcile(t.Context())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteAzureFirewall(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, firewall *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if firewall is not configured",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(nil, nil, nil)
+			},
+		},
+		{
+			name:          "delete firewall and firewall policy succeeds",
+			expectedError: "",
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				gomock.InOrder(
+					firewall.DeleteResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(nil),
+					policy.DeleteResource(gomockinternal.AContext(), &fakePolicySpec, serviceName).Return(nil),
+				)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "delete firewall fails and keeps the firewall policy",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				firewall.DeleteResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(errFake)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, errFake)
+			},
+		},
+		{
+			name:          "delete firewall policy fails",
+			expectedError: errFake.Error(),
+			expect: func(s *mock_azurefirewalls.MockFirewallScopeMockRecorder, policy, firewall *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.AzureFirewallSpecs().Return(&fakePolicySpec, &fakeRuleCollectionGroupSpec, &fakeFirewallSpec)
+				firewall.DeleteResource(gomockinternal.AContext(), &fakeFirewallSpec, serviceName).Return(nil)
+				policy.DeleteResource(gomockinternal.AContext(), &fakePolicySpec, serviceName).Return(errFake)
+				s.UpdateDeleteStatus(infrav1.AzureFirewallReadyCondition, serviceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_azurefirewalls.NewMockFirewallScope(mockCtrl)
+			policyMock := mock_async.NewMockReconciler(mockCtrl)
+			firewallMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), policyMock.EXPECT(), firewallMock.EXPECT())
+
+			s := &Service{
+				Scope:                         scopeMock,
+				policyReconciler:              policyMock,
+				ruleCollectionGroupReconciler: mock_async.NewMockReconciler(mockCtrl),
+				firewallReconciler:            firewallMock,
+			}
+
+			err := s.Delete(t.Context())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
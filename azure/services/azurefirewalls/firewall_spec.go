@@ -0,0 +1,140 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// ipConfigName is the name of the IP configuration of the Azure Firewall.
+const ipConfigName = "ipconfig"
+
+// AzureFirewallSpec defines the specification for an Azure Firewall.
+type AzureFirewallSpec struct {
+	Name             string
+	ResourceGroup    string
+	Location         string
+	ClusterName      string
+	SKUTier          infrav1.AzureFirewallSKUTier
+	FirewallPolicyID string
+	SubnetID         string
+	PublicIPID       string
+	AdditionalTags   infrav1.Tags
+}
+
+// ResourceName returns the name of the Azure Firewall.
+func (s *AzureFirewallSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *AzureFirewallSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for Azure Firewalls.
+func (s *AzureFirewallSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the Azure Firewall.
+func (s *AzureFirewallSpec) Parameters(_ context.Context, existing any) (params any, err error) {
+	if existing != nil {
+		existingFirewall, ok := existing.(armnetwork.AzureFirewall)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.AzureFirewall", existing)
+		}
+		return s.update(existingFirewall), nil
+	}
+
+	return armnetwork.AzureFirewall{
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.AzureFirewallPropertiesFormat{
+			SKU:            s.sku(),
+			FirewallPolicy: &armnetwork.SubResource{ID: ptr.To(s.FirewallPolicyID)},
+			IPConfigurations: []*armnetwork.AzureFirewallIPConfiguration{
+				{
+					Name: ptr.To(ipConfigName),
+					Properties: &armnetwork.AzureFirewallIPConfigurationPropertiesFormat{
+						Subnet:          &armnetwork.SubResource{ID: ptr.To(s.SubnetID)},
+						PublicIPAddress: &armnetwork.SubResource{ID: ptr.To(s.PublicIPID)},
+					},
+				},
+			},
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Role:        ptr.To(infrav1.Firewall),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// update returns the existing Azure Firewall with the SKU tier and firewall policy of the spec, or nil if the existing
+// Azure Firewall already has them.
+func (s *AzureFirewallSpec) update(existing armnetwork.AzureFirewall) any {
+	if existing.Properties == nil {
+		return nil
+	}
+	var policyID string
+	if existing.Properties.FirewallPolicy != nil {
+		policyID = ptr.Deref(existing.Properties.FirewallPolicy.ID, "")
+	}
+	sku := existing.Properties.SKU
+	if sku != nil &&
+		ptr.Deref(sku.Tier, "") == armnetwork.AzureFirewallSKUTier(s.SKUTier) &&
+		strings.EqualFold(policyID, s.FirewallPolicyID) {
+		return nil
+	}
+
+	properties := *existing.Properties
+	properties.SKU = s.sku()
+	properties.FirewallPolicy = &armnetwork.SubResource{ID: ptr.To(s.FirewallPolicyID)}
+	existing.Properties = &properties
+	return existing
+}
+
+// sku returns the SKU of the Azure Firewall, which is deployed in a virtual network.
+func (s *AzureFirewallSpec) sku() *armnetwork.AzureFirewallSKU {
+	return &armnetwork.AzureFirewallSKU{
+		Name: ptr.To(armnetwork.AzureFirewallSKUNameAZFWVnet),
+		Tier: ptr.To(armnetwork.AzureFirewallSKUTier(s.SKUTier)),
+	}
+}
+
+// privateIPAddress returns the private IP of the Azure Firewall, or an empty string if it has not been allocated yet.
+func privateIPAddress(firewall armnetwork.AzureFirewall) string {
+	if firewall.Properties == nil {
+		return ""
+	}
+	for _, ipConfig := range firewall.Properties.IPConfigurations {
+		if ipConfig != nil && ipConfig.Properties != nil && ipConfig.Properties.PrivateIPAddress != nil {
+			return *ipConfig.Properties.PrivateIPAddress
+		}
+	}
+	return ""
+}
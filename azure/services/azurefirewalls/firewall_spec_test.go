@@ -0,0 +1,155 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var fakeExistingFirewall = armnetwork.AzureFirewall{
+	Location: ptr.To("fake-location"),
+	Properties: &armnetwork.AzureFirewallPropertiesFormat{
+		SKU: &armnetwork.AzureFirewallSKU{
+			Name: ptr.To(armnetwork.AzureFirewallSKUNameAZFWVnet),
+			Tier: ptr.To(armnetwork.AzureFirewallSKUTierStandard),
+		},
+		FirewallPolicy: &armnetwork.SubResource{ID: ptr.To(fakeFirewallSpec.FirewallPolicyID)},
+		IPConfigurations: []*armnetwork.AzureFirewallIPConfiguration{
+			{
+				Name: ptr.To(ipConfigName),
+				Properties: &armnetwork.AzureFirewallIPConfigurationPropertiesFormat{
+					PrivateIPAddress: ptr.To("10.255.253.4"),
+				},
+			},
+		},
+	},
+}
+
+func TestAzureFirewallSpec_Parameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          *AzureFirewallSpec
+		existing      any
+		expect        func(g *WithT, result any)
+		expectedError string
+	}{
+		{
+			name:     "error when existing is not of AzureFirewall type",
+			spec:     &fakeFirewallSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not an armnetwork.AzureFirewall",
+		},
+		{
+			name:     "get result as nil when existing AzureFirewall is up to date",
+			spec:     &fakeFirewallSpec,
+			existing: fakeExistingFirewall,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "update SKU tier of existing AzureFirewall",
+			spec: func() *AzureFirewallSpec {
+				spec := fakeFirewallSpec
+				spec.SKUTier = infrav1.PremiumAzureFirewallSKUTier
+				return &spec
+			}(),
+			existing: fakeExistingFirewall,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.AzureFirewall{}))
+				firewall := result.(armnetwork.AzureFirewall)
+				g.Expect(firewall.Properties.SKU.Tier).To(Equal(ptr.To(armnetwork.AzureFirewallSKUTierPremium)))
+				g.Expect(firewall.Properties.IPConfigurations).To(Equal(fakeExistingFirewall.Properties.IPConfigurations))
+				// the existing AzureFirewall is not modified.
+				g.Expect(fakeExistingFirewall.Properties.SKU.Tier).To(Equal(ptr.To(armnetwork.AzureFirewallSKUTierStandard)))
+			},
+			expectedError: "",
+		},
+		{
+			name: "reassociate firewall policy of existing AzureFirewall",
+			spec: &fakeFirewallSpec,
+			existing: armnetwork.AzureFirewall{
+				Properties: &armnetwork.AzureFirewallPropertiesFormat{
+					SKU: fakeExistingFirewall.Properties.SKU,
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.AzureFirewall{}))
+				firewall := result.(armnetwork.AzureFirewall)
+				g.Expect(firewall.Properties.FirewallPolicy).To(Equal(&armnetwork.SubResource{ID: ptr.To(fakeFirewallSpec.FirewallPolicyID)}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "AzureFirewall does not exist",
+			spec:     &fakeFirewallSpec,
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(Equal(armnetwork.AzureFirewall{
+					Location: ptr.To("fake-location"),
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						SKU: &armnetwork.AzureFirewallSKU{
+							Name: ptr.To(armnetwork.AzureFirewallSKUNameAZFWVnet),
+							Tier: ptr.To(armnetwork.AzureFirewallSKUTierStandard),
+						},
+						FirewallPolicy: &armnetwork.SubResource{ID: ptr.To(fakeFirewallSpec.FirewallPolicyID)},
+						IPConfigurations: []*armnetwork.AzureFirewallIPConfiguration{
+							{
+								Name: ptr.To(ipConfigName),
+								Properties: &armnetwork.AzureFirewallIPConfigurationPropertiesFormat{
+									Subnet:          &armnetwork.SubResource{ID: ptr.To(fakeFirewallSpec.SubnetID)},
+									PublicIPAddress: &armnetwork.SubResource{ID: ptr.To(fakeFirewallSpec.PublicIPID)},
+								},
+							},
+						},
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": ptr.To("owned"),
+						"sigs.k8s.io_cluster-api-provider-azure_role":                 ptr.To("firewall"),
+						"Name": ptr.To("test-cluster-azfw"),
+					},
+				}))
+			},
+			expectedError: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(t.Context(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
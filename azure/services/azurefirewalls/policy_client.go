@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// firewallPoliciesClient contains the Azure go-sdk Client for firewall policies.
+type firewallPoliciesClient struct {
+	policies       *armnetwork.FirewallPoliciesClient
+	apiCallTimeout time.Duration
+}
+
+// newFirewallPoliciesClient creates a firewall policies client from an authorizer.
+func newFirewallPoliciesClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*firewallPoliciesClient, error) {
+	opts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create firewall policies client options")
+	}
+	factory, err := armnetwork.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armnetwork client factory")
+	}
+	return &firewallPoliciesClient{factory.NewFirewallPoliciesClient(), apiCallTimeout}, nil
+}
+
+// Get gets the specified firewall policy.
+func (ac *firewallPoliciesClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result any, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.firewallPoliciesClient.Get")
+	defer done()
+
+	resp, err := ac.policies.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.FirewallPolicy, nil
+}
+
+// CreateOrUpdateAsync creates or updates a firewall policy asynchronously.
+// It sends a PUT request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *firewallPoliciesClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, parameters any) (result any, poller *runtime.Poller[armnetwork.FirewallPoliciesClientCreateOrUpdateResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.firewallPoliciesClient.CreateOrUpdateAsync")
+	defer done()
+
+	params, ok := parameters.(armnetwork.FirewallPolicy)
+	if !ok && parameters != nil {
+		return nil, nil, errors.Errorf("%T is not an armnetwork.FirewallPolicy", parameters)
+	}
+
+	opts := &armnetwork.FirewallPoliciesClientBeginCreateOrUpdateOptions{ResumeToken: resumeToken}
+	poller, err = ac.policies.BeginCreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), params, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ac.apiCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	resp, err := poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// If an error occurs, return the poller.
+		// This means the long-running operation didn't finish in the specified timeout.
+		return nil, poller, err
+	}
+
+	// if the operation completed, return a nil poller
+	return resp.FirewallPolicy, nil, err
+}
+
+// DeleteAsync deletes a firewall policy asynchronously. DeleteAsync sends a DELETE
+// request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
+// progress of the operation.
+func (ac *firewallPoliciesClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armnetwork.FirewallPoliciesClientDeleteResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "azurefirewalls.firewallPoliciesClient.DeleteAsync")
+	defer done()
+
+	opts := &armnetwork.FirewallPoliciesClientBeginDeleteOptions{ResumeToken: resumeToken}
+	poller, err = ac.policies.BeginDelete(ctx, spec.ResourceGroupName(), spec.ResourceName(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ac.apiCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// if an error occurs, return the poller.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
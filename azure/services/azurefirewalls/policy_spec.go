@@ -0,0 +1,96 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// FirewallPolicySpec defines the specification for a firewall policy.
+type FirewallPolicySpec struct {
+	Name           string
+	ResourceGroup  string
+	Location       string
+	ClusterName    string
+	SKUTier        infrav1.AzureFirewallSKUTier
+	AdditionalTags infrav1.Tags
+}
+
+// ResourceName returns the name of the firewall policy.
+func (s *FirewallPolicySpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *FirewallPolicySpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for firewall policies.
+func (s *FirewallPolicySpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the firewall policy.
+func (s *FirewallPolicySpec) Parameters(_ context.Context, existing any) (params any, err error) {
+	if existing != nil {
+		existingPolicy, ok := existing.(armnetwork.FirewallPolicy)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.FirewallPolicy", existing)
+		}
+		if existingPolicy.Properties != nil && existingPolicy.Properties.SKU != nil &&
+			ptr.Deref(existingPolicy.Properties.SKU.Tier, "") == armnetwork.FirewallPolicySKUTier(s.SKUTier) {
+			// firewall policy already exists with the expected SKU tier, its rules are managed by its rule collection groups.
+			return nil, nil
+		}
+		properties := armnetwork.FirewallPolicyPropertiesFormat{}
+		if existingPolicy.Properties != nil {
+			properties = *existingPolicy.Properties
+		}
+		properties.SKU = s.sku()
+		existingPolicy.Properties = &properties
+		return existingPolicy, nil
+	}
+
+	return armnetwork.FirewallPolicy{
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.FirewallPolicyPropertiesFormat{
+			SKU:             s.sku(),
+			ThreatIntelMode: ptr.To(armnetwork.AzureFirewallThreatIntelModeAlert),
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// sku returns the SKU of the firewall policy, which must have the same tier as the Azure Firewall using it.
+func (s *FirewallPolicySpec) sku() *armnetwork.FirewallPolicySKU {
+	return &armnetwork.FirewallPolicySKU{
+		Tier: ptr.To(armnetwork.FirewallPolicySKUTier(s.SKUTier)),
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestFirewallPolicySpec_Parameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          *FirewallPolicySpec
+		existing      any
+		expect        func(g *WithT, result any)
+		expectedError string
+	}{
+		{
+			name:     "error when existing is not of FirewallPolicy type",
+			spec:     &fakePolicySpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not an armnetwork.FirewallPolicy",
+		},
+		{
+			name: "get result as nil when existing FirewallPolicy has the expected SKU tier",
+			spec: &fakePolicySpec,
+			existing: armnetwork.FirewallPolicy{
+				Properties: &armnetwork.FirewallPolicyPropertiesFormat{
+					SKU: &armnetwork.FirewallPolicySKU{Tier: ptr.To(armnetwork.FirewallPolicySKUTierStandard)},
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "update SKU tier of existing FirewallPolicy",
+			spec: &fakePolicySpec,
+			existing: armnetwork.FirewallPolicy{
+				Properties: &armnetwork.FirewallPolicyPropertiesFormat{
+					SKU:             &armnetwork.FirewallPolicySKU{Tier: ptr.To(armnetwork.FirewallPolicySKUTierPremium)},
+					ThreatIntelMode: ptr.To(armnetwork.AzureFirewallThreatIntelModeDeny),
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.FirewallPolicy{}))
+				policy := result.(armnetwork.FirewallPolicy)
+				g.Expect(policy.Properties.SKU.Tier).To(Equal(ptr.To(armnetwork.FirewallPolicySKUTierStandard)))
+				g.Expect(policy.Properties.ThreatIntelMode).To(Equal(ptr.To(armnetwork.AzureFirewallThreatIntelModeDeny)))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "FirewallPolicy does not exist",
+			spec:     &fakePolicySpec,
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(Equal(armnetwork.FirewallPolicy{
+					Location: ptr.To("fake-location"),
+					Properties: &armnetwork.FirewallPolicyPropertiesFormat{
+						SKU:             &armnetwork.FirewallPolicySKU{Tier: ptr.To(armnetwork.FirewallPolicySKUTierStandard)},
+						ThreatIntelMode: ptr.To(armnetwork.AzureFirewallThreatIntelModeAlert),
+					},
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_test-cluster": ptr.To("owned"),
+						"Name": ptr.To("test-cluster-azfw-policy"),
+					},
+				}))
+			},
+			expectedError: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(t.Context(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
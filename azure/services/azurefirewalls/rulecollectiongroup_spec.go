@@ -0,0 +1,237 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+// Names and priorities of the rule collection group holding the default egress rules of the node subnets and of its
+// rule collections. The rule collection group has a high priority value so that rule collection groups added to the
+// firewall policy by users are processed first.
+const (
+	// DefaultRuleCollectionGroupName is the name of the rule collection group holding the default egress rules.
+	DefaultRuleCollectionGroupName = "kubernetes-egress"
+	networkRuleCollectionName      = "kubernetes-network"
+	networkRuleCollectionPriority  = 100
+	appRuleCollectionName          = "kubernetes-application"
+	appRuleCollectionPriority      = 200
+	ruleCollectionGroupPriority    = 1000
+)
+
+var (
+	// kubernetesFQDNs are the FQDNs serving Kubernetes binaries, packages and images.
+	kubernetesFQDNs = []string{
+		"dl.k8s.io",
+		"cdn.dl.k8s.io",
+		"pkgs.k8s.io",
+		"prod-cdn.packages.k8s.io",
+		"registry.k8s.io",
+		"*.pkg.dev",
+		"storage.googleapis.com",
+	}
+	// imageRegistryFQDNs are the FQDNs of the common container image registries.
+	imageRegistryFQDNs = []string{
+		"mcr.microsoft.com",
+		"*.data.mcr.microsoft.com",
+		"*.azurecr.io",
+		"docker.io",
+		"registry-1.docker.io",
+		"auth.docker.io",
+		"production.cloudflare.docker.com",
+		"ghcr.io",
+		"pkg-containers.githubusercontent.com",
+		"quay.io",
+		"*.quay.io",
+	}
+	// azureFQDNs are the FQDNs of the Azure endpoints used by the nodes which do not depend on the Azure cloud.
+	azureFQDNs = []string{
+		"packages.microsoft.com",
+		"acs-mirror.azureedge.net",
+	}
+)
+
+// RuleCollectionGroupSpec defines the specification for the firewall policy rule collection group holding the default
+// egress rules of the node subnets.
+type RuleCollectionGroupSpec struct {
+	Name               string
+	ResourceGroup      string
+	FirewallPolicyName string
+	// SourceAddresses are the CIDRs of the node subnets.
+	SourceAddresses []string
+	// AzureEndpointFQDNs are the FQDNs of the Azure Resource Manager and Microsoft Entra ID endpoints of the Azure cloud.
+	AzureEndpointFQDNs []string
+	// APIServerFQDN and APIServerPort are the endpoint of a public API server, which the nodes reach through the
+	// Azure Firewall.
+	APIServerFQDN   string
+	APIServerPort   int32
+	AdditionalFQDNs []string
+}
+
+// ResourceName returns the name of the rule collection group.
+func (s *RuleCollectionGroupSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *RuleCollectionGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the firewall policy of the rule collection group.
+func (s *RuleCollectionGroupSpec) OwnerResourceName() string {
+	return s.FirewallPolicyName
+}
+
+// Parameters returns the parameters for the rule collection group.
+func (s *RuleCollectionGroupSpec) Parameters(_ context.Context, existing any) (params any, err error) {
+	appRules := s.applicationRules()
+	if existing != nil {
+		existingGroup, ok := existing.(armnetwork.FirewallPolicyRuleCollectionGroup)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.FirewallPolicyRuleCollectionGroup", existing)
+		}
+		if applicationRulesEqual(existingGroup, appRules) {
+			// rule collection group already exists with the expected FQDNs.
+			return nil, nil
+		}
+	}
+
+	return armnetwork.FirewallPolicyRuleCollectionGroup{
+		Properties: &armnetwork.FirewallPolicyRuleCollectionGroupProperties{
+			Priority: ptr.To[int32](ruleCollectionGroupPriority),
+			RuleCollections: []armnetwork.FirewallPolicyRuleCollectionClassification{
+				allowRuleCollection(networkRuleCollectionName, networkRuleCollectionPriority, s.networkRules()),
+				allowRuleCollection(appRuleCollectionName, appRuleCollectionPriority, appRules),
+			},
+		},
+	}, nil
+}
+
+// networkRules returns the network rules allowing the node subnets to synchronize their clocks.
+func (s *RuleCollectionGroupSpec) networkRules() []armnetwork.FirewallPolicyRuleClassification {
+	return []armnetwork.FirewallPolicyRuleClassification{
+		&armnetwork.Rule{
+			Name:                 ptr.To("ntp"),
+			RuleType:             ptr.To(armnetwork.FirewallPolicyRuleTypeNetworkRule),
+			IPProtocols:          []*armnetwork.FirewallPolicyRuleNetworkProtocol{ptr.To(armnetwork.FirewallPolicyRuleNetworkProtocolUDP)},
+			SourceAddresses:      azure.PtrSlice(&s.SourceAddresses),
+			DestinationAddresses: []*string{ptr.To("*")},
+			DestinationPorts:     []*string{ptr.To("123")},
+		},
+	}
+}
+
+// applicationRules returns the application rules allowing the node subnets to reach the FQDNs required by
+// Kubernetes, the container image registries, Azure and the additional FQDNs of the spec over HTTP and HTTPS.
+func (s *RuleCollectionGroupSpec) applicationRules() []armnetwork.FirewallPolicyRuleClassification {
+	rules := []armnetwork.FirewallPolicyRuleClassification{
+		s.applicationRule("kubernetes", kubernetesFQDNs, webProtocols()),
+		s.applicationRule("image-registries", imageRegistryFQDNs, webProtocols()),
+		s.applicationRule("azure", append(slices.Clone(azureFQDNs), s.AzureEndpointFQDNs...), webProtocols()),
+	}
+	if s.APIServerFQDN != "" {
+		rules = append(rules, s.applicationRule("api-server", []string{s.APIServerFQDN}, []*armnetwork.FirewallPolicyRuleApplicationProtocol{
+			{ProtocolType: ptr.To(armnetwork.FirewallPolicyRuleApplicationProtocolTypeHTTPS), Port: ptr.To(s.APIServerPort)},
+		}))
+	}
+	if len(s.AdditionalFQDNs) > 0 {
+		rules = append(rules, s.applicationRule("additional", s.AdditionalFQDNs, webProtocols()))
+	}
+	return rules
+}
+
+// applicationRule returns an application rule allowing the node subnets to reach the given FQDNs.
+func (s *RuleCollectionGroupSpec) applicationRule(name string, fqdns []string, protocols []*armnetwork.FirewallPolicyRuleApplicationProtocol) *armnetwork.ApplicationRule {
+	return &armnetwork.ApplicationRule{
+		Name:            ptr.To(name),
+		RuleType:        ptr.To(armnetwork.FirewallPolicyRuleTypeApplicationRule),
+		Protocols:       protocols,
+		SourceAddresses: azure.PtrSlice(&s.SourceAddresses),
+		TargetFqdns:     azure.PtrSlice(&fqdns),
+	}
+}
+
+// webProtocols returns the HTTP and HTTPS protocols on their default ports.
+func webProtocols() []*armnetwork.FirewallPolicyRuleApplicationProtocol {
+	return []*armnetwork.FirewallPolicyRuleApplicationProtocol{
+		{ProtocolType: ptr.To(armnetwork.FirewallPolicyRuleApplicationProtocolTypeHTTP), Port: ptr.To[int32](80)},
+		{ProtocolType: ptr.To(armnetwork.FirewallPolicyRuleApplicationProtocolTypeHTTPS), Port: ptr.To[int32](443)},
+	}
+}
+
+// allowRuleCollection returns a rule collection allowing the traffic matched by the given rules.
+func allowRuleCollection(name string, priority int32, rules []armnetwork.FirewallPolicyRuleClassification) *armnetwork.FirewallPolicyFilterRuleCollection {
+	return &armnetwork.FirewallPolicyFilterRuleCollection{
+		Name:               ptr.To(name),
+		Priority:           ptr.To(priority),
+		RuleCollectionType: ptr.To(armnetwork.FirewallPolicyRuleCollectionTypeFirewallPolicyFilterRuleCollection),
+		Action: &armnetwork.FirewallPolicyFilterRuleCollectionAction{
+			Type: ptr.To(armnetwork.FirewallPolicyFilterRuleCollectionActionTypeAllow),
+		},
+		Rules: rules,
+	}
+}
+
+// applicationRulesEqual returns true if the application rule collection of the existing rule collection group allows
+// the same source addresses to reach the same FQDNs as the given application rules.
+func applicationRulesEqual(existing armnetwork.FirewallPolicyRuleCollectionGroup, rules []armnetwork.FirewallPolicyRuleClassification) bool {
+	if existing.Properties == nil {
+		return false
+	}
+	for _, collection := range existing.Properties.RuleCollections {
+		filter, ok := collection.(*armnetwork.FirewallPolicyFilterRuleCollection)
+		if !ok || ptr.Deref(filter.Name, "") != appRuleCollectionName {
+			continue
+		}
+		if len(filter.Rules) != len(rules) {
+			return false
+		}
+		for i, rule := range filter.Rules {
+			existingRule, ok := rule.(*armnetwork.ApplicationRule)
+			if !ok {
+				return false
+			}
+			desiredRule := rules[i].(*armnetwork.ApplicationRule)
+			if ptr.Deref(existingRule.Name, "") != ptr.Deref(desiredRule.Name, "") ||
+				!slices.Equal(derefAll(existingRule.TargetFqdns), derefAll(desiredRule.TargetFqdns)) ||
+				!slices.Equal(derefAll(existingRule.SourceAddresses), derefAll(desiredRule.SourceAddresses)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// derefAll returns the values of a slice of pointers, skipping nil pointers.
+func derefAll(ptrs []*string) []string {
+	values := make([]string, 0, len(ptrs))
+	for _, p := range ptrs {
+		if p != nil {
+			values = append(values, *p)
+		}
+	}
+	return values
+}
@@ -0,0 +1,144 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azurefirewalls
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestRuleCollectionGroupSpec_Parameters(t *testing.T) {
+	publicClusterSpec := fakeRuleCollectionGroupSpec
+	publicClusterSpec.APIServerFQDN = "test-cluster.fake-location.cloudapp.azure.com"
+	publicClusterSpec.APIServerPort = 6443
+	publicClusterSpec.AdditionalFQDNs = []string{"*.example.com"}
+
+	existing, err := fakeRuleCollectionGroupSpec.Parameters(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		spec          *RuleCollectionGroupSpec
+		existing      any
+		expect        func(g *WithT, result any)
+		expectedError string
+	}{
+		{
+			name:     "error when existing is not of FirewallPolicyRuleCollectionGroup type",
+			spec:     &fakeRuleCollectionGroupSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not an armnetwork.FirewallPolicyRuleCollectionGroup",
+		},
+		{
+			name:     "get result as nil when existing FirewallPolicyRuleCollectionGroup is up to date",
+			spec:     &fakeRuleCollectionGroupSpec,
+			existing: existing,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "update existing FirewallPolicyRuleCollectionGroup when FQDNs are added",
+			spec:     &publicClusterSpec,
+			existing: existing,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.FirewallPolicyRuleCollectionGroup{}))
+				rules := applicationRuleCollection(g, result).Rules
+				g.Expect(rules).To(HaveLen(5))
+				apiServerRule := rules[3].(*armnetwork.ApplicationRule)
+				g.Expect(apiServerRule.TargetFqdns).To(Equal([]*string{ptr.To("test-cluster.fake-location.cloudapp.azure.com")}))
+				g.Expect(apiServerRule.Protocols).To(Equal([]*armnetwork.FirewallPolicyRuleApplicationProtocol{
+					{ProtocolType: ptr.To(armnetwork.FirewallPolicyRuleApplicationProtocolTypeHTTPS), Port: ptr.To[int32](6443)},
+				}))
+				additionalRule := rules[4].(*armnetwork.ApplicationRule)
+				g.Expect(additionalRule.TargetFqdns).To(Equal([]*string{ptr.To("*.example.com")}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "FirewallPolicyRuleCollectionGroup does not exist",
+			spec:     &fakeRuleCollectionGroupSpec,
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.FirewallPolicyRuleCollectionGroup{}))
+				group := result.(armnetwork.FirewallPolicyRuleCollectionGroup)
+				g.Expect(group.Properties.Priority).To(Equal(ptr.To[int32](ruleCollectionGroupPriority)))
+				g.Expect(group.Properties.RuleCollections).To(HaveLen(2))
+
+				networkRules := group.Properties.RuleCollections[0].(*armnetwork.FirewallPolicyFilterRuleCollection)
+				g.Expect(networkRules.Name).To(Equal(ptr.To(networkRuleCollectionName)))
+				g.Expect(networkRules.Action.Type).To(Equal(ptr.To(armnetwork.FirewallPolicyFilterRuleCollectionActionTypeAllow)))
+				g.Expect(networkRules.Rules).To(Equal([]armnetwork.FirewallPolicyRuleClassification{
+					&armnetwork.Rule{
+						Name:                 ptr.To("ntp"),
+						RuleType:             ptr.To(armnetwork.FirewallPolicyRuleTypeNetworkRule),
+						IPProtocols:          []*armnetwork.FirewallPolicyRuleNetworkProtocol{ptr.To(armnetwork.FirewallPolicyRuleNetworkProtocolUDP)},
+						SourceAddresses:      []*string{ptr.To("10.1.0.0/16")},
+						DestinationAddresses: []*string{ptr.To("*")},
+						DestinationPorts:     []*string{ptr.To("123")},
+					},
+				}))
+
+				rules := applicationRuleCollection(g, result).Rules
+				g.Expect(rules).To(HaveLen(3))
+				for _, rule := range rules {
+					g.Expect(rule.(*armnetwork.ApplicationRule).SourceAddresses).To(Equal([]*string{ptr.To("10.1.0.0/16")}))
+				}
+				g.Expect(rules[0].(*armnetwork.ApplicationRule).TargetFqdns).To(ContainElement(ptr.To("registry.k8s.io")))
+				g.Expect(rules[1].(*armnetwork.ApplicationRule).TargetFqdns).To(ContainElement(ptr.To("mcr.microsoft.com")))
+				g.Expect(rules[2].(*armnetwork.ApplicationRule).TargetFqdns).To(ContainElements(ptr.To("management.azure.com"), ptr.To("login.microsoftonline.com")))
+			},
+			expectedError: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(t.Context(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+// applicationRuleCollection returns the application rule collection of a rule collection group.
+func applicationRuleCollection(g *WithT, result any) *armnetwork.FirewallPolicyFilterRuleCollection {
+	group := result.(armnetwork.FirewallPolicyRuleCollectionGroup)
+	for _, collection := range group.Properties.RuleCollections {
+		if filter := collection.(*armnetwork.FirewallPolicyFilterRuleCollection); ptr.Deref(filter.Name, "") == appRuleCollectionName {
+			return filter
+		}
+	}
+	g.Expect(false).To(BeTrue(), "no application rule collection")
+	return nil
+}
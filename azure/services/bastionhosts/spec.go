@@ -40,6 +40,13 @@ type AzureBastionSpec struct {
 	PublicIPID      string
 	Sku             infrav1.BastionHostSkuName
 	EnableTunneling bool
+	// ScaleUnits, EnableIPConnect, EnableShareableLink, DisableCopyPaste and EnableFileCopy are left as is on the
+	// bastion host when nil.
+	ScaleUnits          *int32
+	EnableIPConnect     *bool
+	EnableShareableLink *bool
+	DisableCopyPaste    *bool
+	EnableFileCopy      *bool
 }
 
 // ResourceRef implements azure.ASOResourceSpecGetter.
@@ -74,6 +81,21 @@ func (s *AzureBastionSpec) Parameters(_ context.Context, existingBastionHost *as
 		Name: ptr.To(asonetworkv1.Sku_Name(s.Sku)),
 	}
 	bastionHost.Spec.EnableTunneling = ptr.To(s.EnableTunneling)
+	if s.ScaleUnits != nil {
+		bastionHost.Spec.ScaleUnits = ptr.To(int(*s.ScaleUnits))
+	}
+	if s.EnableIPConnect != nil {
+		bastionHost.Spec.EnableIpConnect = s.EnableIPConnect
+	}
+	if s.EnableShareableLink != nil {
+		bastionHost.Spec.EnableShareableLink = s.EnableShareableLink
+	}
+	if s.DisableCopyPaste != nil {
+		bastionHost.Spec.DisableCopyPaste = s.DisableCopyPaste
+	}
+	if s.EnableFileCopy != nil {
+		bastionHost.Spec.EnableFileCopy = s.EnableFileCopy
+	}
 	bastionHost.Spec.DnsName = ptr.To(fmt.Sprintf("%s-bastion", strings.ToLower(s.Name)))
 	bastionHost.Spec.IpConfigurations = []asonetworkv1.BastionHostIPConfiguration{
 		{
@@ -179,6 +179,35 @@ func TestAzureBastionSpec_Parameters(t *testing.T) {
 				g.Expect(result.Status).To(Equal(fakeBastionHostStatus))
 			},
 		},
+		{
+			name: "session settings of the spec overwrite the ones of the existing bastion host",
+			spec: func() *AzureBastionSpec {
+				spec := fakeAzureBastionSpec1
+				spec.ScaleUnits = ptr.To[int32](4)
+				spec.EnableIPConnect = ptr.To(true)
+				spec.EnableShareableLink = ptr.To(false)
+				spec.DisableCopyPaste = ptr.To(false)
+				spec.EnableFileCopy = ptr.To(true)
+				return &spec
+			}(),
+			existing: getASOBastionHost(
+				func(bastion *asonetworkv1.BastionHost) {
+					bastion.Spec.DisableCopyPaste = ptr.To(true)
+					bastion.Spec.EnableShareableLink = ptr.To(true)
+				},
+			),
+			expect: func(g *WithT, result asonetworkv1.BastionHost) {
+				g.Expect(result.Spec).To(Equal(getASOBastionHost(
+					func(bastion *asonetworkv1.BastionHost) {
+						bastion.Spec.ScaleUnits = ptr.To(4)
+						bastion.Spec.EnableIpConnect = ptr.To(true)
+						bastion.Spec.EnableShareableLink = ptr.To(false)
+						bastion.Spec.DisableCopyPaste = ptr.To(false)
+						bastion.Spec.EnableFileCopy = ptr.To(true)
+					},
+				).Spec))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
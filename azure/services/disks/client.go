@@ -29,15 +29,15 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-// azureClient contains the Azure go-sdk Client.
-type azureClient struct {
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
 	disks          *armcompute.DisksClient
 	apiCallTimeout time.Duration
 }
 
-// newClient creates a new disks client from an authorizer.
-func newClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*azureClient, error) {
-	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+// NewClient creates a new disks client from an authorizer.
+func NewClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*AzureClient, error) {
+	opts, err := azure.ARMClientOptionsForAuthorizer(auth)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create disks client options")
 	}
@@ -45,14 +45,14 @@ func newClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*azureClien
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create armcompute client factory")
 	}
-	return &azureClient{factory.NewDisksClient(), apiCallTimeout}, nil
+	return &AzureClient{factory.NewDisksClient(), apiCallTimeout}, nil
 }
 
 // DeleteAsync deletes a disk asynchronously. DeleteAsync sends a DELETE
 // request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
 // progress of the operation.
-func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armcompute.DisksClientDeleteResponse], err error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.azureClient.DeleteAsync")
+func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armcompute.DisksClientDeleteResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.AzureClient.DeleteAsync")
 	defer done()
 
 	opts := &armcompute.DisksClientBeginDeleteOptions{ResumeToken: resumeToken}
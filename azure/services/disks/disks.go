@@ -44,7 +44,7 @@ type Service struct {
 
 // New creates a disks service.
 func New(scope DiskScope) (*Service, error) {
-	client, err := newClient(scope, scope.DefaultedAzureCallTimeout())
+	client, err := NewClient(scope, scope.DefaultedAzureCallTimeout())
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,8 @@ import (
 	"context"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -33,6 +35,7 @@ const (
 	httpsProbeRequestPath = "/readyz"
 	lbRuleHTTPS           = "LBRuleHTTPS"
 	outboundNAT           = "OutboundNATAllProtocols"
+	outboundNATIPv6       = "OutboundNATAllProtocols-ipv6"
 )
 
 // LBScope defines the scope interface for a load balancer service.
@@ -40,12 +43,15 @@ type LBScope interface {
 	azure.ClusterScoper
 	azure.AsyncStatusUpdater
 	LBSpecs() []azure.ResourceSpecGetter
+	PrivateLinkServiceSpec() azure.ResourceSpecGetter
+	SetPrivateLinkServiceID(string)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope LBScope
 	async.Reconciler
+	privateLinkServiceReconciler async.Reconciler
 }
 
 // New creates a new service.
@@ -54,10 +60,16 @@ func New(scope LBScope) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	privateLinkServicesClient, err := newPrivateLinkServicesClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
 	return &Service{
 		Scope: scope,
 		Reconciler: async.New[armnetwork.LoadBalancersClientCreateOrUpdateResponse,
 			armnetwork.LoadBalancersClientDeleteResponse](scope, client, client),
+		privateLinkServiceReconciler: async.New[armnetwork.PrivateLinkServicesClientCreateOrUpdateResponse,
+			armnetwork.PrivateLinkServicesClientDeleteResponse](scope, privateLinkServicesClient, privateLinkServicesClient),
 	}, nil
 }
 
@@ -66,7 +78,8 @@ func (s *Service) Name() string {
 	return serviceName
 }
 
-// Reconcile idempotently creates or updates a load balancer.
+// Reconcile idempotently creates or updates the load balancers, then the private link service of the API server load
+// balancer and records its ID.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.Reconcile")
 	defer done()
@@ -74,10 +87,66 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
 	defer cancel()
 
-	return azure.ReconcileAll(ctx, s.Reconciler, s.Scope, s.Scope.LBSpecs(), serviceName, infrav1.LoadBalancersReadyCondition)
+	if err := azure.ReconcileAll(ctx, s.Reconciler, s.Scope, s.Scope.LBSpecs(), serviceName, infrav1.LoadBalancersReadyCondition); err != nil {
+		return err
+	}
+
+	spec := s.Scope.PrivateLinkServiceSpec()
+	if spec == nil {
+		return nil
+	}
+	result, err := s.privateLinkServiceReconciler.CreateOrUpdateResource(ctx, spec, serviceName)
+	s.Scope.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, serviceName, err)
+	if err == nil && result != nil {
+		privateLinkService, ok := result.(armnetwork.PrivateLinkService)
+		if !ok {
+			return errors.Errorf("%T is not an armnetwork.PrivateLinkService", result)
+		}
+		s.Scope.SetPrivateLinkServiceID(ptr.Deref(privateLinkService.ID, ""))
+	}
+	return err
+}
+
+// Plan implements azure.Planner.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	changes, err := azure.PlanAll(ctx, s.Reconciler, s.Scope.LBSpecs(), serviceName)
+	if err != nil {
+		return nil, err
+	}
+	privateLinkServiceChanges, err := azure.PlanAll(ctx, s.privateLinkServiceReconciler, s.privateLinkServiceSpecs(), serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, privateLinkServiceChanges...), nil
+}
+
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	drifts, err := azure.DetectDriftAll(ctx, s.Reconciler, s.Scope.LBSpecs(), serviceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	privateLinkServiceDrifts, err := azure.DetectDriftAll(ctx, s.privateLinkServiceReconciler, s.privateLinkServiceSpecs(), serviceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	return append(drifts, privateLinkServiceDrifts...), nil
 }
 
-// Delete deletes the public load balancer with the provided name.
+// Delete deletes the private link service of the API server load balancer, which references the frontend of the load
+// balancer, then the load balancers.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "loadbalancers.Service.Delete")
 	defer done()
@@ -85,6 +154,14 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
 	defer cancel()
 
+	if spec := s.Scope.PrivateLinkServiceSpec(); spec != nil {
+		err := s.privateLinkServiceReconciler.DeleteResource(ctx, spec, serviceName)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, serviceName, err)
+		if err != nil {
+			return err
+		}
+	}
+
 	return azure.DeleteAll(ctx, s.Reconciler, s.Scope, s.Scope.LBSpecs(), serviceName, infrav1.LoadBalancersReadyCondition)
 }
 
@@ -92,3 +169,12 @@ func (s *Service) Delete(ctx context.Context) error {
 func (s *Service) IsManaged(_ context.Context) (bool, error) {
 	return true, nil
 }
+
+// privateLinkServiceSpecs returns the spec of the private link service as a list, which is empty if the API server
+// load balancer has no private link service.
+func (s *Service) privateLinkServiceSpecs() []azure.ResourceSpecGetter {
+	if spec := s.Scope.PrivateLinkServiceSpec(); spec != nil {
+		return []azure.ResourceSpecGetter{spec}
+	}
+	return nil
+}
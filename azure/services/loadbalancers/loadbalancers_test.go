@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	"k8s.io/utils/ptr"
@@ -188,6 +189,18 @@ var (
 		},
 	}
 
+	fakePrivateLinkServiceSpec = PrivateLinkServiceSpec{
+		Name:                 "my-private-lb-pls",
+		ResourceGroup:        "my-rg",
+		SubscriptionID:       "123",
+		ClusterName:          "my-cluster",
+		Location:             "my-location",
+		LoadBalancerName:     "my-private-lb",
+		FrontendIPConfigName: "my-private-lb-frontEnd",
+		SubnetID:             "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-cp-subnet",
+		AllowedSubscriptions: []string{"456"},
+	}
+
 	internalError = &azcore.ResponseError{
 		RawResponse: &http.Response{
 			Body:       io.NopCloser(strings.NewReader("#: Internal Server Error: StatusCode=500")),
@@ -208,6 +221,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{})
+				s.PrivateLinkServiceSpec().Return(nil)
 			},
 		},
 		{
@@ -228,6 +242,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.PrivateLinkServiceSpec().Return(nil)
 			},
 		},
 		{
@@ -238,6 +253,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.PrivateLinkServiceSpec().Return(nil)
 			},
 		},
 		{
@@ -248,6 +264,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeNodeOutboundLBSpec})
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.PrivateLinkServiceSpec().Return(nil)
 			},
 		},
 		{
@@ -260,6 +277,34 @@ func TestReconcileLoadBalancer(t *testing.T) {
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
 				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
 				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.PrivateLinkServiceSpec().Return(nil)
+			},
+		},
+		{
+			name:          "create internal apiserver LB with a private link service",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, serviceName).Return(armnetwork.PrivateLinkService{ID: ptr.To("my-pls-id")}, nil)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, serviceName, nil)
+				s.SetPrivateLinkServiceID("my-pls-id")
+			},
+		},
+		{
+			name:          "fail to create a private link service",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.PrivateLinkServiceReadyCondition, serviceName, internalError)
 			},
 		},
 	}
@@ -278,8 +323,9 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
 
 			s := &Service{
-				Scope:      scopeMock,
-				Reconciler: asyncMock,
+				Scope:                        scopeMock,
+				Reconciler:                   asyncMock,
+				privateLinkServiceReconciler: asyncMock,
 			}
 			err := s.Reconcile(t.Context())
 			if tc.expectedError != "" {
@@ -303,6 +349,7 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(nil)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{})
 			},
 		},
@@ -311,6 +358,7 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(nil)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
 				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
 				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
@@ -321,6 +369,7 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(nil)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec})
 				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
 				r.DeleteResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil)
@@ -333,11 +382,37 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(nil)
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
 				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(internalError)
 				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
 			},
 		},
+		{
+			name:          "delete the private link service before the load balancers",
+			expectedError: "",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				gomock.InOrder(
+					r.DeleteResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, serviceName).Return(nil),
+					r.DeleteResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil),
+				)
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, serviceName, nil)
+				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
+				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "private link service deletion fails",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateLinkServiceSpec().Return(&fakePrivateLinkServiceSpec)
+				r.DeleteResource(gomockinternal.AContext(), &fakePrivateLinkServiceSpec, serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.PrivateLinkServiceReadyCondition, serviceName, internalError)
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -354,8 +429,9 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
 
 			s := &Service{
-				Scope:      scopeMock,
-				Reconciler: asyncMock,
+				Scope:                        scopeMock,
+				Reconciler:                   asyncMock,
+				privateLinkServiceReconciler: asyncMock,
 			}
 
 			err := s.Delete(t.Context())
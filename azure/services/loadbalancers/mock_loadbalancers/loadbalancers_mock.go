@@ -118,6 +118,34 @@ func (mr *MockLBScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockLBScope)(nil).AdditionalTags))
 }
 
+// ApplicationSecurityGroupResourceGroup mocks base method.
+func (m *MockLBScope) ApplicationSecurityGroupResourceGroup() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroupResourceGroup")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ApplicationSecurityGroupResourceGroup indicates an expected call of ApplicationSecurityGroupResourceGroup.
+func (mr *MockLBScopeMockRecorder) ApplicationSecurityGroupResourceGroup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroupResourceGroup", reflect.TypeOf((*MockLBScope)(nil).ApplicationSecurityGroupResourceGroup))
+}
+
+// ApplicationSecurityGroupsEnabled mocks base method.
+func (m *MockLBScope) ApplicationSecurityGroupsEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationSecurityGroupsEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ApplicationSecurityGroupsEnabled indicates an expected call of ApplicationSecurityGroupsEnabled.
+func (mr *MockLBScopeMockRecorder) ApplicationSecurityGroupsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationSecurityGroupsEnabled", reflect.TypeOf((*MockLBScope)(nil).ApplicationSecurityGroupsEnabled))
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockLBScope) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
@@ -522,6 +550,20 @@ func (mr *MockLBScopeMockRecorder) NodeSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSubnets", reflect.TypeOf((*MockLBScope)(nil).NodeSubnets))
 }
 
+// OutboundIPv6PoolName mocks base method.
+func (m *MockLBScope) OutboundIPv6PoolName(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboundIPv6PoolName", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OutboundIPv6PoolName indicates an expected call of OutboundIPv6PoolName.
+func (mr *MockLBScopeMockRecorder) OutboundIPv6PoolName(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundIPv6PoolName", reflect.TypeOf((*MockLBScope)(nil).OutboundIPv6PoolName), arg0)
+}
+
 // OutboundLBName mocks base method.
 func (m *MockLBScope) OutboundLBName(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -550,6 +592,20 @@ func (mr *MockLBScopeMockRecorder) OutboundPoolName(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboundPoolName", reflect.TypeOf((*MockLBScope)(nil).OutboundPoolName), arg0)
 }
 
+// PrivateLinkServiceSpec mocks base method.
+func (m *MockLBScope) PrivateLinkServiceSpec() azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrivateLinkServiceSpec")
+	ret0, _ := ret[0].(azure.ResourceSpecGetter)
+	return ret0
+}
+
+// PrivateLinkServiceSpec indicates an expected call of PrivateLinkServiceSpec.
+func (mr *MockLBScopeMockRecorder) PrivateLinkServiceSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrivateLinkServiceSpec", reflect.TypeOf((*MockLBScope)(nil).PrivateLinkServiceSpec))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockLBScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -576,6 +632,18 @@ func (mr *MockLBScopeMockRecorder) SetLongRunningOperationState(arg0 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockLBScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetPrivateLinkServiceID mocks base method.
+func (m *MockLBScope) SetPrivateLinkServiceID(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPrivateLinkServiceID", arg0)
+}
+
+// SetPrivateLinkServiceID indicates an expected call of SetPrivateLinkServiceID.
+func (mr *MockLBScopeMockRecorder) SetPrivateLinkServiceID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrivateLinkServiceID", reflect.TypeOf((*MockLBScope)(nil).SetPrivateLinkServiceID), arg0)
+}
+
 // SetSubnet mocks base method.
 func (m *MockLBScope) SetSubnet(arg0 v1beta1.SubnetSpec) {
 	m.ctrl.T.Helper()
@@ -707,3 +775,17 @@ func (mr *MockLBScopeMockRecorder) Vnet() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockLBScope)(nil).Vnet))
 }
+
+// VnetSubscriptionID mocks base method.
+func (m *MockLBScope) VnetSubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VnetSubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// VnetSubscriptionID indicates an expected call of VnetSubscriptionID.
+func (mr *MockLBScopeMockRecorder) VnetSubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VnetSubscriptionID", reflect.TypeOf((*MockLBScope)(nil).VnetSubscriptionID))
+}
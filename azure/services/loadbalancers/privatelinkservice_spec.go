@@ -0,0 +1,145 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PrivateLinkServiceSpec defines the specification for a private link service in front of an internal load balancer.
+type PrivateLinkServiceSpec struct {
+	Name                 string
+	ResourceGroup        string
+	SubscriptionID       string
+	Location             string
+	ExtendedLocation     *infrav1.ExtendedLocationSpec
+	ClusterName          string
+	LoadBalancerName     string
+	FrontendIPConfigName string
+	// SubnetID is the ID of the subnet of the private IPs which the private link service translates the source IP of
+	// the connections from private endpoints to.
+	SubnetID                  string
+	AllowedSubscriptions      []string
+	AutoApprovedSubscriptions []string
+	AdditionalTags            infrav1.Tags
+}
+
+// ResourceName returns the name of the private link service.
+func (s *PrivateLinkServiceSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateLinkServiceSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for private link services.
+func (s *PrivateLinkServiceSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the private link service.
+func (s *PrivateLinkServiceSpec) Parameters(_ context.Context, existing any) (params any, err error) {
+	if existing != nil {
+		existingPLS, ok := existing.(armnetwork.PrivateLinkService)
+		if !ok {
+			return nil, errors.Errorf("%T is not an armnetwork.PrivateLinkService", existing)
+		}
+		return s.update(existingPLS), nil
+	}
+
+	return armnetwork.PrivateLinkService{
+		Location:         ptr.To(s.Location),
+		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
+		Properties: &armnetwork.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{
+				{
+					ID: ptr.To(azure.FrontendIPConfigID(s.SubscriptionID, s.ResourceGroup, s.LoadBalancerName, s.FrontendIPConfigName)),
+				},
+			},
+			IPConfigurations: []*armnetwork.PrivateLinkServiceIPConfiguration{
+				{
+					Name: ptr.To(s.Name + "-nat-ipconfig"),
+					Properties: &armnetwork.PrivateLinkServiceIPConfigurationProperties{
+						Primary:                   ptr.To(true),
+						PrivateIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodDynamic),
+						PrivateIPAddressVersion:   ptr.To(armnetwork.IPVersionIPv4),
+						Subnet:                    &armnetwork.Subnet{ID: ptr.To(s.SubnetID)},
+					},
+				},
+			},
+			Visibility:   &armnetwork.PrivateLinkServicePropertiesVisibility{Subscriptions: azure.PtrSlice(&s.AllowedSubscriptions)},
+			AutoApproval: &armnetwork.PrivateLinkServicePropertiesAutoApproval{Subscriptions: azure.PtrSlice(&s.AutoApprovedSubscriptions)},
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        ptr.To(s.Name),
+			Role:        ptr.To(infrav1.APIServerRole),
+			Additional:  s.AdditionalTags,
+		})),
+	}, nil
+}
+
+// update returns the existing private link service with the allowed and auto-approved subscriptions of the spec, or
+// nil if they are already up to date. The load balancer frontend and the NAT IP configurations of a private link
+// service are kept as is.
+func (s *PrivateLinkServiceSpec) update(existing armnetwork.PrivateLinkService) any {
+	if existing.Properties == nil {
+		return nil
+	}
+	var allowed, autoApproved []*string
+	if existing.Properties.Visibility != nil {
+		allowed = existing.Properties.Visibility.Subscriptions
+	}
+	if existing.Properties.AutoApproval != nil {
+		autoApproved = existing.Properties.AutoApproval.Subscriptions
+	}
+	if subscriptionsMatch(allowed, s.AllowedSubscriptions) && subscriptionsMatch(autoApproved, s.AutoApprovedSubscriptions) {
+		return nil
+	}
+
+	properties := *existing.Properties
+	properties.Visibility = &armnetwork.PrivateLinkServicePropertiesVisibility{Subscriptions: azure.PtrSlice(&s.AllowedSubscriptions)}
+	properties.AutoApproval = &armnetwork.PrivateLinkServicePropertiesAutoApproval{Subscriptions: azure.PtrSlice(&s.AutoApprovedSubscriptions)}
+	existing.Properties = &properties
+	return existing
+}
+
+// subscriptionsMatch returns true if existing and desired hold the same subscription IDs, in any order.
+func subscriptionsMatch(existing []*string, desired []string) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	for _, subscription := range existing {
+		if !slices.ContainsFunc(desired, func(d string) bool { return strings.EqualFold(d, ptr.Deref(subscription, "")) }) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+var fakeExistingPrivateLinkService = armnetwork.PrivateLinkService{
+	ID:       ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/privateLinkServices/my-private-lb-pls"),
+	Location: ptr.To("my-location"),
+	Properties: &armnetwork.PrivateLinkServiceProperties{
+		LoadBalancerFrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{
+			{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-private-lb/frontendIPConfigurations/my-private-lb-frontEnd")},
+		},
+		Visibility:   &armnetwork.PrivateLinkServicePropertiesVisibility{Subscriptions: []*string{ptr.To("456")}},
+		AutoApproval: &armnetwork.PrivateLinkServicePropertiesAutoApproval{},
+		PrivateEndpointConnections: []*armnetwork.PrivateEndpointConnection{
+			{Name: ptr.To("my-connection")},
+		},
+	},
+}
+
+func TestPrivateLinkServiceSpec_Parameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          *PrivateLinkServiceSpec
+		existing      any
+		expect        func(g *WithT, result any)
+		expectedError string
+	}{
+		{
+			name:     "error when existing is not of PrivateLinkService type",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not an armnetwork.PrivateLinkService",
+		},
+		{
+			name:     "get result as nil when existing PrivateLinkService is up to date",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: fakeExistingPrivateLinkService,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "update the subscriptions of an existing PrivateLinkService and keep the rest of its configuration",
+			spec: func() *PrivateLinkServiceSpec {
+				spec := fakePrivateLinkServiceSpec
+				spec.AllowedSubscriptions = []string{"456", "789"}
+				spec.AutoApprovedSubscriptions = []string{"789"}
+				return &spec
+			}(),
+			existing: fakeExistingPrivateLinkService,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.PrivateLinkService{}))
+				pls := result.(armnetwork.PrivateLinkService)
+				g.Expect(pls.Properties.Visibility.Subscriptions).To(Equal([]*string{ptr.To("456"), ptr.To("789")}))
+				g.Expect(pls.Properties.AutoApproval.Subscriptions).To(Equal([]*string{ptr.To("789")}))
+				g.Expect(pls.Properties.PrivateEndpointConnections).To(Equal(fakeExistingPrivateLinkService.Properties.PrivateEndpointConnections))
+				// The existing private link service is not modified.
+				g.Expect(fakeExistingPrivateLinkService.Properties.Visibility.Subscriptions).To(HaveLen(1))
+			},
+		},
+		{
+			name:     "get PrivateLinkService in front of the load balancer frontend when it does not exist",
+			spec:     &fakePrivateLinkServiceSpec,
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.PrivateLinkService{}))
+				pls := result.(armnetwork.PrivateLinkService)
+				g.Expect(pls.Location).To(Equal(ptr.To("my-location")))
+				g.Expect(pls.Tags).To(Equal(map[string]*string{
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": ptr.To("owned"),
+					"sigs.k8s.io_cluster-api-provider-azure_role":               ptr.To("apiserver"),
+					"Name": ptr.To("my-private-lb-pls"),
+				}))
+				g.Expect(pls.Properties.LoadBalancerFrontendIPConfigurations).To(Equal(fakeExistingPrivateLinkService.Properties.LoadBalancerFrontendIPConfigurations))
+				g.Expect(pls.Properties.IPConfigurations).To(HaveLen(1))
+				g.Expect(pls.Properties.IPConfigurations[0].Name).To(Equal(ptr.To("my-private-lb-pls-nat-ipconfig")))
+				g.Expect(pls.Properties.IPConfigurations[0].Properties.Subnet.ID).To(Equal(ptr.To(fakePrivateLinkServiceSpec.SubnetID)))
+				g.Expect(pls.Properties.Visibility.Subscriptions).To(Equal([]*string{ptr.To("456")}))
+				g.Expect(pls.Properties.AutoApproval.Subscriptions).To(BeEmpty())
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(t.Context(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -51,8 +51,27 @@ type LBSpec struct {
 	AdditionalTags        map[string]string
 	AdditionalPorts       []infrav1.LoadBalancerPort
 	AvailabilityZones     []string
+	// AdditionalPools are the backend pools of the load balancer in addition to its default backend pool.
+	AdditionalPools []infrav1.BackendPool
+	// Probes are the health probes of the load balancer in addition to the ones built for its role.
+	Probes []infrav1.LoadBalancerProbe
+	// Rules are the load-balancing rules of the load balancer in addition to the ones built for its role.
+	Rules []infrav1.LoadBalancerRule
+	// IPv6BackendPoolName is the name of the backend pool of the IPv6 IP configurations of the network interfaces,
+	// whose outbound traffic goes through the IPv6 frontend IPs. Empty when the load balancer has no IPv6 frontend IPs.
+	IPv6BackendPoolName string
+	// VNetSubscriptionID is the ID of the subscription of the virtual network of the subnet of an internal load
+	// balancer. Defaults to SubscriptionID.
+	VNetSubscriptionID string
 }
 
+const (
+	// defaultProbeIntervalInSeconds is the interval between two probes of a health probe which does not set one.
+	defaultProbeIntervalInSeconds = 15
+	// defaultProbeThreshold is the threshold of a health probe which does not set one.
+	defaultProbeThreshold = 1
+)
+
 // ResourceName returns the name of the load balancer.
 func (s *LBSpec) ResourceName() string {
 	return s.Name
@@ -193,7 +212,7 @@ func getFrontendIPConfigs(lbSpec LBSpec) ([]*armnetwork.FrontendIPConfiguration,
 			properties = armnetwork.FrontendIPConfigurationPropertiesFormat{
 				PrivateIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodStatic),
 				Subnet: &armnetwork.Subnet{
-					ID: ptr.To(azure.SubnetID(lbSpec.SubscriptionID, lbSpec.VNetResourceGroup, lbSpec.VNetName, lbSpec.SubnetName)),
+					ID: ptr.To(azure.SubnetID(lbSpec.vnetSubscriptionID(), lbSpec.VNetResourceGroup, lbSpec.VNetName, lbSpec.SubnetName)),
 				},
 				PrivateIPAddress: ptr.To(ipConfig.PrivateIPAddress),
 			}
@@ -220,16 +239,38 @@ func getOutboundRules(lbSpec LBSpec, frontendIDs []*armnetwork.SubResource) []*a
 	if lbSpec.Type == infrav1.Internal {
 		return []*armnetwork.OutboundRule{}
 	}
-	return []*armnetwork.OutboundRule{
-		{
-			Name: ptr.To(outboundNAT),
-			Properties: &armnetwork.OutboundRulePropertiesFormat{
-				Protocol:                 ptr.To(armnetwork.LoadBalancerOutboundRuleProtocolAll),
-				IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
-				FrontendIPConfigurations: frontendIDs,
-				BackendAddressPool: &armnetwork.SubResource{
-					ID: ptr.To(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
-				},
+	if lbSpec.IPv6BackendPoolName == "" {
+		return []*armnetwork.OutboundRule{
+			newOutboundRule(lbSpec, outboundNAT, frontendIDs, lbSpec.BackendPoolName),
+		}
+	}
+
+	// The outbound traffic of the IPv4 and IPv6 IP configurations of the network interfaces goes through separate
+	// rules, each with the frontend IPs and the backend pool of its IP version.
+	var ipv4FrontendIDs, ipv6FrontendIDs []*armnetwork.SubResource
+	for i, ipConfig := range lbSpec.FrontendIPConfigs {
+		if ipConfig.PublicIP != nil && ipConfig.PublicIP.IsIPv6() {
+			ipv6FrontendIDs = append(ipv6FrontendIDs, frontendIDs[i])
+		} else {
+			ipv4FrontendIDs = append(ipv4FrontendIDs, frontendIDs[i])
+		}
+	}
+	rules := []*armnetwork.OutboundRule{}
+	if len(ipv4FrontendIDs) > 0 {
+		rules = append(rules, newOutboundRule(lbSpec, outboundNAT, ipv4FrontendIDs, lbSpec.BackendPoolName))
+	}
+	return append(rules, newOutboundRule(lbSpec, outboundNATIPv6, ipv6FrontendIDs, lbSpec.IPv6BackendPoolName))
+}
+
+func newOutboundRule(lbSpec LBSpec, name string, frontendIDs []*armnetwork.SubResource, backendPoolName string) *armnetwork.OutboundRule {
+	return &armnetwork.OutboundRule{
+		Name: ptr.To(name),
+		Properties: &armnetwork.OutboundRulePropertiesFormat{
+			Protocol:                 ptr.To(armnetwork.LoadBalancerOutboundRuleProtocolAll),
+			IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
+			FrontendIPConfigurations: frontendIDs,
+			BackendAddressPool: &armnetwork.SubResource{
+				ID: ptr.To(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, backendPoolName)),
 			},
 		},
 	}
@@ -287,22 +328,84 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []*armnetwork.SubResource)
 			})
 		}
 
-		return rules
+		return append(rules, getCustomLoadBalancingRules(lbSpec, frontendIDs)...)
 	}
-	return []*armnetwork.LoadBalancingRule{}
+	return getCustomLoadBalancingRules(lbSpec, frontendIDs)
+}
+
+// getCustomLoadBalancingRules returns the load-balancing rules declared on the load balancer.
+func getCustomLoadBalancingRules(lbSpec LBSpec, frontendIDs []*armnetwork.SubResource) []*armnetwork.LoadBalancingRule {
+	rules := make([]*armnetwork.LoadBalancingRule, 0, len(lbSpec.Rules))
+	for _, rule := range lbSpec.Rules {
+		var frontendIPConfig *armnetwork.SubResource
+		if rule.FrontendIPName != "" {
+			frontendIPConfig = &armnetwork.SubResource{
+				ID: ptr.To(azure.FrontendIPConfigID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, rule.FrontendIPName)),
+			}
+		} else if len(frontendIDs) != 0 {
+			frontendIPConfig = frontendIDs[0]
+		}
+		backendPoolName := lbSpec.BackendPoolName
+		if rule.BackendPoolName != "" {
+			backendPoolName = rule.BackendPoolName
+		}
+		protocol := infrav1.LoadBalancerRuleProtocolTCP
+		if rule.Protocol != "" {
+			protocol = rule.Protocol
+		}
+
+		properties := &armnetwork.LoadBalancingRulePropertiesFormat{
+			DisableOutboundSnat:     ptr.To(true),
+			Protocol:                ptr.To(armnetwork.TransportProtocol(protocol)),
+			FrontendPort:            ptr.To(rule.FrontendPort),
+			BackendPort:             ptr.To(ptr.Deref(rule.BackendPort, rule.FrontendPort)),
+			EnableFloatingIP:        ptr.To(rule.EnableFloatingIP),
+			LoadDistribution:        ptr.To(armnetwork.LoadDistributionDefault),
+			FrontendIPConfiguration: frontendIPConfig,
+			BackendAddressPool: &armnetwork.SubResource{
+				ID: ptr.To(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, backendPoolName)),
+			},
+		}
+		// The idle timeout only applies to TCP connections.
+		if protocol != infrav1.LoadBalancerRuleProtocolUDP {
+			properties.IdleTimeoutInMinutes = lbSpec.IdleTimeoutInMinutes
+		}
+		if rule.ProbeName != "" {
+			properties.Probe = &armnetwork.SubResource{
+				ID: ptr.To(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, rule.ProbeName)),
+			}
+		}
+		rules = append(rules, &armnetwork.LoadBalancingRule{
+			Name:       ptr.To(rule.Name),
+			Properties: properties,
+		})
+	}
+	return rules
 }
 
 func getBackendAddressPools(lbSpec LBSpec) []*armnetwork.BackendAddressPool {
-	return []*armnetwork.BackendAddressPool{
+	pools := []*armnetwork.BackendAddressPool{
 		{
 			Name: ptr.To(lbSpec.BackendPoolName),
 		},
 	}
+	if lbSpec.IPv6BackendPoolName != "" {
+		pools = append(pools, &armnetwork.BackendAddressPool{
+			Name: ptr.To(lbSpec.IPv6BackendPoolName),
+		})
+	}
+	for _, pool := range lbSpec.AdditionalPools {
+		pools = append(pools, &armnetwork.BackendAddressPool{
+			Name: ptr.To(pool.Name),
+		})
+	}
+	return pools
 }
 
 func getProbes(lbSpec LBSpec) []*armnetwork.Probe {
+	probes := getCustomProbes(lbSpec)
 	if lbSpec.Role == infrav1.APIServerRole || lbSpec.Role == infrav1.APIServerRoleInternal {
-		return []*armnetwork.Probe{
+		return append([]*armnetwork.Probe{
 			{
 				Name: ptr.To(httpsProbe),
 				Properties: &armnetwork.ProbePropertiesFormat{
@@ -313,9 +416,30 @@ func getProbes(lbSpec LBSpec) []*armnetwork.Probe {
 					ProbeThreshold:    ptr.To[int32](1),
 				},
 			},
+		}, probes...)
+	}
+	return probes
+}
+
+// getCustomProbes returns the health probes declared on the load balancer.
+func getCustomProbes(lbSpec LBSpec) []*armnetwork.Probe {
+	probes := make([]*armnetwork.Probe, 0, len(lbSpec.Probes))
+	for _, probe := range lbSpec.Probes {
+		properties := &armnetwork.ProbePropertiesFormat{
+			Protocol:          ptr.To(armnetwork.ProbeProtocol(probe.Protocol)),
+			Port:              ptr.To(probe.Port),
+			IntervalInSeconds: ptr.To(ptr.Deref(probe.IntervalInSeconds, defaultProbeIntervalInSeconds)),
+			ProbeThreshold:    ptr.To(ptr.Deref(probe.Threshold, defaultProbeThreshold)),
 		}
+		if probe.RequestPath != "" {
+			properties.RequestPath = ptr.To(probe.RequestPath)
+		}
+		probes = append(probes, &armnetwork.Probe{
+			Name:       ptr.To(probe.Name),
+			Properties: properties,
+		})
 	}
-	return []*armnetwork.Probe{}
+	return probes
 }
 
 func (s LBSpec) apiServerFrontendPort() int32 {
@@ -436,3 +560,11 @@ func ipExists(configs []*armnetwork.FrontendIPConfiguration, config armnetwork.F
 	}
 	return false
 }
+
+// vnetSubscriptionID returns the ID of the subscription of the virtual network.
+func (s *LBSpec) vnetSubscriptionID() string {
+	if s.VNetSubscriptionID != "" {
+		return s.VNetSubscriptionID
+	}
+	return s.SubscriptionID
+}
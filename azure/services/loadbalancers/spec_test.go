@@ -24,6 +24,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
 func getExistingLBWithMissingFrontendIPConfigs() armnetwork.LoadBalancer {
@@ -290,6 +291,69 @@ func TestAPIServerLBPortPropagation(t *testing.T) {
 	g.Expect(probes[0].Properties.Port).To(Equal(ptr.To(backendPort)))
 }
 
+// TestCustomProbesRulesAndPools verifies that the declared health probes, load-balancing rules and backend pools are
+// added to the ones built for the role of the load balancer.
+func TestCustomProbesRulesAndPools(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := fakePublicAPILBSpec
+	spec.AdditionalPools = []infrav1.BackendPool{{Name: "my-extra-pool"}}
+	spec.Probes = []infrav1.LoadBalancerProbe{
+		{Name: "http-probe", Protocol: infrav1.LoadBalancerProbeProtocolHTTP, Port: 8080, RequestPath: "/healthz"},
+		{Name: "tcp-probe", Protocol: infrav1.LoadBalancerProbeProtocolTCP, Port: 9000, IntervalInSeconds: ptr.To[int32](30), Threshold: ptr.To[int32](3)},
+	}
+	spec.Rules = []infrav1.LoadBalancerRule{
+		{Name: "http-rule", FrontendPort: 80, BackendPort: ptr.To[int32](8080), BackendPoolName: "my-extra-pool", ProbeName: "http-probe"},
+		{Name: "udp-rule", Protocol: infrav1.LoadBalancerRuleProtocolUDP, FrontendPort: 9000},
+	}
+	frontendIDs := []*armnetwork.SubResource{{ID: ptr.To("/some/frontend/id")}}
+
+	pools := getBackendAddressPools(spec)
+	g.Expect(pools).To(HaveLen(2))
+	g.Expect(pools[1].Name).To(Equal(ptr.To("my-extra-pool")))
+
+	probes := getProbes(spec)
+	g.Expect(probes).To(HaveLen(3))
+	g.Expect(probes[0].Name).To(Equal(ptr.To(httpsProbe)))
+	g.Expect(probes[1].Properties).To(Equal(&armnetwork.ProbePropertiesFormat{
+		Protocol:          ptr.To(armnetwork.ProbeProtocolHTTP),
+		Port:              ptr.To[int32](8080),
+		RequestPath:       ptr.To("/healthz"),
+		IntervalInSeconds: ptr.To[int32](15),
+		ProbeThreshold:    ptr.To[int32](1),
+	}))
+	g.Expect(probes[2].Properties).To(Equal(&armnetwork.ProbePropertiesFormat{
+		Protocol:          ptr.To(armnetwork.ProbeProtocolTCP),
+		Port:              ptr.To[int32](9000),
+		IntervalInSeconds: ptr.To[int32](30),
+		ProbeThreshold:    ptr.To[int32](3),
+	}))
+
+	rules := getLoadBalancingRules(spec, frontendIDs)
+	g.Expect(rules).To(HaveLen(3))
+	g.Expect(rules[1].Name).To(Equal(ptr.To("http-rule")))
+	g.Expect(rules[1].Properties.Protocol).To(Equal(ptr.To(armnetwork.TransportProtocolTCP)))
+	g.Expect(rules[1].Properties.FrontendPort).To(Equal(ptr.To[int32](80)))
+	g.Expect(rules[1].Properties.BackendPort).To(Equal(ptr.To[int32](8080)))
+	g.Expect(rules[1].Properties.FrontendIPConfiguration).To(Equal(frontendIDs[0]))
+	g.Expect(rules[1].Properties.BackendAddressPool.ID).To(Equal(ptr.To(azure.AddressPoolID("123", "my-rg", "my-publiclb", "my-extra-pool"))))
+	g.Expect(rules[1].Properties.Probe.ID).To(Equal(ptr.To(azure.ProbeID("123", "my-rg", "my-publiclb", "http-probe"))))
+	g.Expect(rules[1].Properties.IdleTimeoutInMinutes).To(Equal(spec.IdleTimeoutInMinutes))
+	g.Expect(rules[2].Name).To(Equal(ptr.To("udp-rule")))
+	g.Expect(rules[2].Properties.Protocol).To(Equal(ptr.To(armnetwork.TransportProtocolUDP)))
+	g.Expect(rules[2].Properties.BackendPort).To(Equal(ptr.To[int32](9000)))
+	g.Expect(rules[2].Properties.BackendAddressPool.ID).To(Equal(ptr.To(azure.AddressPoolID("123", "my-rg", "my-publiclb", spec.BackendPoolName))))
+	g.Expect(rules[2].Properties.Probe).To(BeNil())
+	g.Expect(rules[2].Properties.IdleTimeoutInMinutes).To(BeNil())
+
+	nodeSpec := fakeNodeOutboundLBSpec
+	nodeSpec.Rules = []infrav1.LoadBalancerRule{{Name: "node-rule", FrontendPort: 443, FrontendIPName: "my-cluster-frontEnd"}}
+	rules = getLoadBalancingRules(nodeSpec, nil)
+	g.Expect(rules).To(HaveLen(1))
+	g.Expect(rules[0].Properties.FrontendIPConfiguration.ID).To(Equal(ptr.To(azure.FrontendIPConfigID("123", "my-rg", "my-cluster", "my-cluster-frontEnd"))))
+	g.Expect(getProbes(nodeSpec)).To(BeEmpty())
+}
+
 func newDefaultNodeOutboundLB() armnetwork.LoadBalancer {
 	return armnetwork.LoadBalancer{
 		Tags: map[string]*string{
@@ -508,3 +572,43 @@ func newDefaultInternalAPIServerLB() armnetwork.LoadBalancer {
 		},
 	}
 }
+
+// TestDualStackOutboundRules verifies that the IPv4 and IPv6 frontend IPs of an outbound LB get separate outbound rules
+// and backend pools.
+func TestDualStackOutboundRules(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := fakeNodeOutboundLBSpec
+	spec.FrontendIPConfigs = []infrav1.FrontendIP{
+		{
+			Name:     "my-cluster-frontEnd",
+			PublicIP: &infrav1.PublicIPSpec{Name: "outbound-publicip"},
+		},
+		{
+			Name:     "my-cluster-frontEnd-ipv6",
+			PublicIP: &infrav1.PublicIPSpec{Name: "outbound-publicip-ipv6", IPVersion: infrav1.IPv6},
+		},
+	}
+	spec.IPv6BackendPoolName = "my-cluster-outboundBackendPool-ipv6"
+
+	_, frontendIDs := getFrontendIPConfigs(spec)
+	rules := getOutboundRules(spec, frontendIDs)
+	g.Expect(rules).To(HaveLen(2))
+	g.Expect(rules[0].Name).To(Equal(ptr.To(outboundNAT)))
+	g.Expect(rules[0].Properties.FrontendIPConfigurations).To(Equal([]*armnetwork.SubResource{frontendIDs[0]}))
+	g.Expect(rules[0].Properties.BackendAddressPool.ID).To(Equal(ptr.To(azure.AddressPoolID("123", "my-rg", "my-cluster", "my-cluster-outboundBackendPool"))))
+	g.Expect(rules[1].Name).To(Equal(ptr.To(outboundNATIPv6)))
+	g.Expect(rules[1].Properties.FrontendIPConfigurations).To(Equal([]*armnetwork.SubResource{frontendIDs[1]}))
+	g.Expect(rules[1].Properties.BackendAddressPool.ID).To(Equal(ptr.To(azure.AddressPoolID("123", "my-rg", "my-cluster", "my-cluster-outboundBackendPool-ipv6"))))
+
+	pools := getBackendAddressPools(spec)
+	g.Expect(pools).To(HaveLen(2))
+	g.Expect(pools[1].Name).To(Equal(ptr.To("my-cluster-outboundBackendPool-ipv6")))
+
+	// Without IPv4 frontend IPs only the IPv6 outbound rule is created.
+	spec.FrontendIPConfigs = spec.FrontendIPConfigs[1:]
+	_, frontendIDs = getFrontendIPConfigs(spec)
+	rules = getOutboundRules(spec, frontendIDs)
+	g.Expect(rules).To(HaveLen(1))
+	g.Expect(rules[0].Name).To(Equal(ptr.To(outboundNATIPv6)))
+}
@@ -74,6 +74,17 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return azure.ReconcileAll(ctx, s.Reconciler, s.Scope, s.Scope.NICSpecs(), serviceName, infrav1.NetworkInterfaceReadyCondition)
 }
 
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkinterfaces.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.DetectDriftAll(ctx, s.Reconciler, s.Scope.NICSpecs(), serviceName, correct)
+}
+
 // Delete deletes the network interface with the provided name.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "networkinterfaces.Service.Delete")
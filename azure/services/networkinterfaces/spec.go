@@ -18,7 +18,9 @@ package networkinterfaces
 
 import (
 	"context"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
@@ -57,6 +59,14 @@ type NICSpec struct {
 	AdditionalTags            infrav1.Tags
 	ClusterName               string
 	IPConfigs                 []IPConfig
+	// ApplicationSecurityGroupIDs are the IDs of the application security groups all the IP configurations of the
+	// network interface are added to.
+	ApplicationSecurityGroupIDs []string
+	// PublicLBIPv6AddressPoolName is the name of the backend pool of the public LB the IPv6 IP configuration of the
+	// network interface is added to, for its IPv6 outbound traffic.
+	PublicLBIPv6AddressPoolName string
+	// VNetSubscriptionID is the ID of the subscription of the virtual network of the subnet. Defaults to SubscriptionID.
+	VNetSubscriptionID string
 }
 
 // IPConfig defines the specification for an IP address configuration.
@@ -98,6 +108,11 @@ func (s *NICSpec) Parameters(ctx context.Context, existing any) (parameters any,
 		if existingNIC.Properties != nil && existingNIC.Properties.ProvisioningState != nil && *existingNIC.Properties.ProvisioningState != armnetwork.ProvisioningStateFailed {
 			// Return nil for both parameters and error as no changes are needed for the existing resource
 			// otherwise rebuild the parameters of the existing NIC so that we can patch the ProvisioningState
+			// The application security groups of a NIC can be updated in place, so add the NIC to the ones it is missing from.
+			if nic, updated := s.withApplicationSecurityGroups(existingNIC); updated {
+				log.V(4).Info("existing NIC is missing application security groups, updating it", "applicationSecurityGroups", s.ApplicationSecurityGroupIDs)
+				return nic, nil
+			}
 			log.V(4).Info("existing NIC is not in ProvisioningFailed state, returning nil parameters and nil error", "ProvisioningState", *existingNIC.Properties.ProvisioningState)
 			return nil, nil
 		}
@@ -108,7 +123,7 @@ func (s *NICSpec) Parameters(ctx context.Context, existing any) (parameters any,
 	}
 
 	subnet := &armnetwork.Subnet{
-		ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, s.SubnetName)),
+		ID: ptr.To(azure.SubnetID(s.vnetSubscriptionID(), s.VNetResourceGroup, s.VNetName, s.SubnetName)),
 	}
 	primaryIPConfig.Subnet = subnet
 
@@ -212,10 +227,23 @@ func (s *NICSpec) Parameters(ctx context.Context, existing any) (parameters any,
 				Subnet:                  &armnetwork.Subnet{ID: subnet.ID},
 			},
 		}
+		if s.PublicLBName != "" && s.PublicLBIPv6AddressPoolName != "" {
+			ipv6Config.Properties.LoadBalancerBackendAddressPools = []*armnetwork.BackendAddressPool{
+				{
+					ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBIPv6AddressPoolName)),
+				},
+			}
+		}
 
 		ipConfigurations = append(ipConfigurations, ipv6Config)
 	}
 
+	if asgs := s.applicationSecurityGroups(); len(asgs) > 0 {
+		for _, ipConfig := range ipConfigurations {
+			ipConfig.Properties.ApplicationSecurityGroups = asgs
+		}
+	}
+
 	return armnetwork.Interface{
 		Location:         ptr.To(s.Location),
 		ExtendedLocation: converters.ExtendedLocationToNetworkSDK(s.ExtendedLocation),
@@ -233,3 +261,72 @@ func (s *NICSpec) Parameters(ctx context.Context, existing any) (parameters any,
 		})),
 	}, nil
 }
+
+// applicationSecurityGroups returns references to the application security groups of the network interface.
+func (s *NICSpec) applicationSecurityGroups() []*armnetwork.ApplicationSecurityGroup {
+	asgs := make([]*armnetwork.ApplicationSecurityGroup, 0, len(s.ApplicationSecurityGroupIDs))
+	for _, id := range s.ApplicationSecurityGroupIDs {
+		asgs = append(asgs, &armnetwork.ApplicationSecurityGroup{ID: ptr.To(id)})
+	}
+	return asgs
+}
+
+// withApplicationSecurityGroups returns a copy of the existing network interface with the IP configurations that are
+// missing from any of the application security groups of the spec added to them, and whether any IP configuration
+// was updated. Application security groups the IP configurations are already in are kept.
+func (s *NICSpec) withApplicationSecurityGroups(existing armnetwork.Interface) (armnetwork.Interface, bool) {
+	if len(s.ApplicationSecurityGroupIDs) == 0 || existing.Properties == nil {
+		return existing, false
+	}
+
+	updated := false
+	ipConfigurations := make([]*armnetwork.InterfaceIPConfiguration, 0, len(existing.Properties.IPConfigurations))
+	for _, ipConfig := range existing.Properties.IPConfigurations {
+		if ipConfig == nil || ipConfig.Properties == nil {
+			ipConfigurations = append(ipConfigurations, ipConfig)
+			continue
+		}
+		asgs := slices.Clone(ipConfig.Properties.ApplicationSecurityGroups)
+		for _, id := range s.ApplicationSecurityGroupIDs {
+			if !hasApplicationSecurityGroup(asgs, id) {
+				asgs = append(asgs, &armnetwork.ApplicationSecurityGroup{ID: ptr.To(id)})
+			}
+		}
+		if len(asgs) == len(ipConfig.Properties.ApplicationSecurityGroups) {
+			ipConfigurations = append(ipConfigurations, ipConfig)
+			continue
+		}
+		updated = true
+		properties := *ipConfig.Properties
+		properties.ApplicationSecurityGroups = asgs
+		newIPConfig := *ipConfig
+		newIPConfig.Properties = &properties
+		ipConfigurations = append(ipConfigurations, &newIPConfig)
+	}
+	if !updated {
+		return existing, false
+	}
+
+	properties := *existing.Properties
+	properties.IPConfigurations = ipConfigurations
+	existing.Properties = &properties
+	return existing, true
+}
+
+// hasApplicationSecurityGroup returns true if the application security groups contain the one with the given ID.
+func hasApplicationSecurityGroup(asgs []*armnetwork.ApplicationSecurityGroup, id string) bool {
+	for _, asg := range asgs {
+		if asg != nil && strings.EqualFold(ptr.Deref(asg.ID, ""), id) {
+			return true
+		}
+	}
+	return false
+}
+
+// vnetSubscriptionID returns the ID of the subscription of the virtual network.
+func (s *NICSpec) vnetSubscriptionID() string {
+	if s.VNetSubscriptionID != "" {
+		return s.VNetSubscriptionID
+	}
+	return s.SubscriptionID
+}
@@ -64,6 +64,8 @@ var (
 
 	fakeCustomDNSServers = []string{"123.123.123.123", "124.124.124.124"}
 
+	fakeASGID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg"
+
 	fakeStaticPrivateIPNICSpec = NICSpec{
 		Name:                    "my-net-interface",
 		ResourceGroup:           "my-rg",
@@ -478,6 +480,25 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "get parameters for network interface ipv6 in the IPv6 backend pool of the outbound LB",
+			spec: func() *NICSpec {
+				s := fakeIpv6NICSpec
+				s.PublicLBIPv6AddressPoolName = "cluster-name-outboundBackendPool-ipv6"
+				return &s
+			}(),
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Interface{}))
+				ipConfigs := result.(armnetwork.Interface).Properties.IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				g.Expect(ipConfigs[0].Properties.LoadBalancerBackendAddressPools).To(BeEmpty())
+				g.Expect(ipConfigs[1].Properties.LoadBalancerBackendAddressPools).To(Equal([]*armnetwork.BackendAddressPool{
+					{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/cluster-name-outboundBackendPool-ipv6")},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for network interface default ipconfig",
 			spec:     &fakeDefaultIPconfigNICSpec,
@@ -815,6 +836,107 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "get parameters for network interface with application security groups",
+			spec: func() *NICSpec {
+				s := fakeDynamicPrivateIPNICSpec
+				s.ApplicationSecurityGroupIDs = []string{fakeASGID}
+				return &s
+			}(),
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.Interface{}))
+				g.Expect(result.(armnetwork.Interface).Properties.IPConfigurations).To(Equal([]*armnetwork.InterfaceIPConfiguration{
+					{
+						Name: ptr.To("pipConfig"),
+						Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+							Primary:                         ptr.To(true),
+							LoadBalancerBackendAddressPools: []*armnetwork.BackendAddressPool{{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/cluster-name-outboundBackendPool")}},
+							PrivateIPAllocationMethod:       ptr.To(armnetwork.IPAllocationMethodDynamic),
+							Subnet:                          &armnetwork.Subnet{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+							ApplicationSecurityGroups:       []*armnetwork.ApplicationSecurityGroup{{ID: ptr.To(fakeASGID)}},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "update existing network interface missing from its application security groups",
+			spec: func() *NICSpec {
+				s := fakeDynamicPrivateIPNICSpec
+				s.ApplicationSecurityGroupIDs = []string{fakeASGID}
+				return &s
+			}(),
+			existing: armnetwork.Interface{
+				Name:     ptr.To("my-net-interface"),
+				Location: ptr.To("fake-location"),
+				Properties: &armnetwork.InterfacePropertiesFormat{
+					ProvisioningState: ptr.To(armnetwork.ProvisioningStateSucceeded),
+					IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+						{
+							Name: ptr.To("pipConfig"),
+							Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+								Primary: ptr.To(true),
+								ApplicationSecurityGroups: []*armnetwork.ApplicationSecurityGroup{
+									{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/other-asg")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(Equal(armnetwork.Interface{
+					Name:     ptr.To("my-net-interface"),
+					Location: ptr.To("fake-location"),
+					Properties: &armnetwork.InterfacePropertiesFormat{
+						ProvisioningState: ptr.To(armnetwork.ProvisioningStateSucceeded),
+						IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+							{
+								Name: ptr.To("pipConfig"),
+								Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+									Primary: ptr.To(true),
+									ApplicationSecurityGroups: []*armnetwork.ApplicationSecurityGroup{
+										{ID: ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/other-asg")},
+										{ID: ptr.To(fakeASGID)},
+									},
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "do not update existing network interface already in its application security groups",
+			spec: func() *NICSpec {
+				s := fakeDynamicPrivateIPNICSpec
+				s.ApplicationSecurityGroupIDs = []string{fakeASGID}
+				return &s
+			}(),
+			existing: armnetwork.Interface{
+				Name:     ptr.To("my-net-interface"),
+				Location: ptr.To("fake-location"),
+				Properties: &armnetwork.InterfacePropertiesFormat{
+					ProvisioningState: ptr.To(armnetwork.ProvisioningStateSucceeded),
+					IPConfigurations: []*armnetwork.InterfaceIPConfiguration{
+						{
+							Name: ptr.To("pipConfig"),
+							Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+								Primary:                   ptr.To(true),
+								ApplicationSecurityGroups: []*armnetwork.ApplicationSecurityGroup{{ID: ptr.To(fakeASGID)}},
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 	}
 	format.MaxLength = 10000
 	for _, tc := range testcases {
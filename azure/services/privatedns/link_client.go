@@ -37,7 +37,7 @@ type azureVirtualNetworkLinksClient struct {
 
 // newVirtualNetworkLinksClient creates a virtual network links client from an authorizer.
 func newVirtualNetworkLinksClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*azureVirtualNetworkLinksClient, error) {
-	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	opts, err := azure.ARMClientOptionsForAuthorizer(auth)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create virtualnetworkslink client options")
 	}
@@ -26,7 +26,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-func (s *Service) reconcileLinks(ctx context.Context, links []azure.ResourceSpecGetter) (managed bool, err error) {
+func (s *Service) reconcileLinks(ctx context.Context, zoneSpec azure.ResourceSpecGetter, links []azure.ResourceSpecGetter) (managed bool, err error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.reconcileLinks")
 	defer done()
 
@@ -36,7 +36,7 @@ func (s *Service) reconcileLinks(ctx context.Context, links []azure.ResourceSpec
 	// If multiple errors occur, we return the most pressing one.
 	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	for _, linkSpec := range links {
-		isLinkManaged, err := s.isVnetLinkManaged(ctx, linkSpec)
+		isLinkManaged, err := s.isVnetLinkManaged(ctx, zoneSpec, linkSpec)
 		if err != nil {
 			if azure.ResourceNotFound(err) {
 				isLinkManaged = true
@@ -53,7 +53,7 @@ func (s *Service) reconcileLinks(ctx context.Context, links []azure.ResourceSpec
 
 		// we consider VnetLinks as managed if at least of the links is managed.
 		managed = true
-		if _, err := s.vnetLinkReconciler.CreateOrUpdateResource(ctx, linkSpec, serviceName); err != nil {
+		if _, err := s.vnetLinkReconciler.CreateOrUpdateResource(ctx, linkSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || resErr == nil {
 				resErr = err
 			}
@@ -63,7 +63,7 @@ func (s *Service) reconcileLinks(ctx context.Context, links []azure.ResourceSpec
 	return managed, resErr
 }
 
-func (s *Service) deleteLinks(ctx context.Context, links []azure.ResourceSpecGetter) (managed bool, err error) {
+func (s *Service) deleteLinks(ctx context.Context, zoneSpec azure.ResourceSpecGetter, links []azure.ResourceSpecGetter) (managed bool, err error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.deleteLinks")
 	defer done()
 
@@ -74,11 +74,11 @@ func (s *Service) deleteLinks(ctx context.Context, links []azure.ResourceSpecGet
 	// Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
 	for _, linkSpec := range links {
 		// If the virtual network link is not managed by capz, skip its reconciliation
-		isVnetLinkManaged, err := s.isVnetLinkManaged(ctx, linkSpec)
+		isVnetLinkManaged, err := s.isVnetLinkManaged(ctx, zoneSpec, linkSpec)
 		if err != nil {
 			if azure.ResourceNotFound(err) {
 				// already deleted or doesn't exist, cleanup status and return.
-				s.Scope.DeleteLongRunningOperationState(linkSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
+				s.Scope.DeleteLongRunningOperationState(linkSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
 				continue
 			}
 			return managed, errors.Wrapf(err, "could not get vnet link state of %s in resource group %s",
@@ -94,7 +94,7 @@ func (s *Service) deleteLinks(ctx context.Context, links []azure.ResourceSpecGet
 		// if we reach here, it means that this vnet link is managed by capz.
 		managed = true
 
-		if err := s.vnetLinkReconciler.DeleteResource(ctx, linkSpec, serviceName); err != nil {
+		if err := s.vnetLinkReconciler.DeleteResource(ctx, linkSpec, ServiceName); err != nil {
 			if !azure.IsOperationNotDoneError(err) || resErr == nil {
 				resErr = err
 			}
@@ -74,6 +74,20 @@ func (mr *MockScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockScope)(nil).AdditionalTags))
 }
 
+// AuthorizerForSubscription mocks base method.
+func (m *MockScope) AuthorizerForSubscription(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizerForSubscription", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// AuthorizerForSubscription indicates an expected call of AuthorizerForSubscription.
+func (mr *MockScopeMockRecorder) AuthorizerForSubscription(subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizerForSubscription", reflect.TypeOf((*MockScope)(nil).AuthorizerForSubscription), subscriptionID)
+}
+
 // AvailabilitySetEnabled mocks base method.
 func (m *MockScope) AvailabilitySetEnabled() bool {
 	m.ctrl.T.Helper()
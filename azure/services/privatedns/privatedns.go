@@ -30,13 +30,15 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "privatedns"
+// ServiceName is the name of this service.
+const ServiceName = "privatedns"
 
 // Scope defines the scope interface for a private dns service.
 type Scope interface {
 	azure.ClusterDescriber
 	azure.Authorizer
 	azure.AsyncStatusUpdater
+	azure.SubscriptionAuthorizer
 	PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linksSpec, recordsSpec []azure.ResourceSpecGetter)
 }
 
@@ -51,15 +53,20 @@ type Service struct {
 
 // New creates a new private dns service.
 func New(scope Scope) (*Service, error) {
-	zoneClient, err := newPrivateZonesClient(scope, scope.DefaultedAzureCallTimeout())
+	// An existing private DNS zone can be in another subscription than the cluster.
+	auth := azure.Authorizer(scope)
+	if zoneSpec, _, _ := scope.PrivateDNSSpec(); zoneSpec != nil {
+		auth = scope.AuthorizerForSubscription(zoneSubscriptionID(scope, zoneSpec))
+	}
+	zoneClient, err := newPrivateZonesClient(auth, scope.DefaultedAzureCallTimeout())
 	if err != nil {
 		return nil, err
 	}
-	vnetLinkClient, err := newVirtualNetworkLinksClient(scope, scope.DefaultedAzureCallTimeout())
+	vnetLinkClient, err := newVirtualNetworkLinksClient(auth, scope.DefaultedAzureCallTimeout())
 	if err != nil {
 		return nil, err
 	}
-	recordSetsClient, err := newRecordSetsClient(scope)
+	recordSetsClient, err := newRecordSetsClient(auth)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +88,7 @@ func New(scope Scope) (*Service, error) {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
 }
 
 // Reconcile creates or updates the private zone, links it to the vnet, and creates DNS records.
@@ -99,26 +106,120 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	managed, err := s.reconcileZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
 	}
 
-	managed, err = s.reconcileLinks(ctx, links)
+	managed, err = s.reconcileLinks(ctx, zoneSpec, links)
 	if managed {
-		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
 	}
 
 	err = s.reconcileRecords(ctx, records)
-	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+	s.Scope.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	return err
 }
 
-// Delete deletes the private zone and vnet links.
+// Plan implements azure.Planner. Changes are only computed for the private zone's links and records when
+// the zone itself is managed or is an existing zone referenced by its resource ID.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	zoneSpec, links, records := s.Scope.PrivateDNSSpec()
+	if zoneSpec == nil {
+		return nil, nil
+	}
+
+	zoneSpecs := []azure.ResourceSpecGetter{zoneSpec}
+	if isExistingZone(zoneSpec) {
+		// An existing private DNS zone is never updated, only its links and records are.
+		zoneSpecs = nil
+	} else {
+		managed, err := s.IsManaged(ctx)
+		if err != nil {
+			if !azure.ResourceNotFound(err) {
+				return nil, err
+			}
+			managed = true
+		}
+		if !managed {
+			log.V(1).Info("Skipping plan of unmanaged private DNS zone", "private DNS", zoneSpec.ResourceName())
+			return nil, nil
+		}
+	}
+
+	zoneChanges, err := azure.PlanAll(ctx, s.zoneReconciler, zoneSpecs, ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	linkChanges, err := azure.PlanAll(ctx, s.vnetLinkReconciler, links, ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	recordChanges, err := azure.PlanAll(ctx, s.recordReconciler, records, ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(zoneChanges, linkChanges...), recordChanges...), nil
+}
+
+// DetectDrift implements azure.DriftDetector. Drift is only detected for the private zone's links and records when
+// the zone itself is managed or is an existing zone referenced by its resource ID.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	zoneSpec, links, records := s.Scope.PrivateDNSSpec()
+	if zoneSpec == nil {
+		return nil, nil
+	}
+
+	zoneSpecs := []azure.ResourceSpecGetter{zoneSpec}
+	if isExistingZone(zoneSpec) {
+		// An existing private DNS zone is never updated, only its links and records are.
+		zoneSpecs = nil
+	} else {
+		managed, err := s.IsManaged(ctx)
+		if azure.ResourceNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if !managed {
+			log.V(1).Info("Skipping drift detection of unmanaged private DNS zone", "private DNS", zoneSpec.ResourceName())
+			return nil, nil
+		}
+	}
+
+	zoneDrift, err := azure.DetectDriftAll(ctx, s.zoneReconciler, zoneSpecs, ServiceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	linkDrift, err := azure.DetectDriftAll(ctx, s.vnetLinkReconciler, links, ServiceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	recordDrift, err := azure.DetectDriftAll(ctx, s.recordReconciler, records, ServiceName, correct)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(zoneDrift, linkDrift...), recordDrift...), nil
+}
+
+// Delete deletes the private zone and vnet links. When the zone is an existing zone referenced by its resource ID,
+// only the records and vnet links are deleted.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.Delete")
 	defer done()
@@ -126,14 +227,21 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
 	defer cancel()
 
-	zoneSpec, links, _ := s.Scope.PrivateDNSSpec()
+	zoneSpec, links, records := s.Scope.PrivateDNSSpec()
 	if zoneSpec == nil {
 		return nil
 	}
 
-	managed, err := s.deleteLinks(ctx, links)
+	if isExistingZone(zoneSpec) {
+		// The records are deleted along with a managed zone, but an existing zone is never deleted.
+		if err := azure.DeleteAll(ctx, s.recordReconciler, s.Scope, records, ServiceName, infrav1.PrivateDNSRecordReadyCondition); err != nil {
+			return err
+		}
+	}
+
+	managed, err := s.deleteLinks(ctx, zoneSpec, links)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, err)
 	}
 	if err != nil {
 		return err
@@ -141,8 +249,8 @@ func (s *Service) Delete(ctx context.Context) error {
 
 	managed, err = s.deleteZone(ctx, zoneSpec)
 	if managed {
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, err)
-		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, err)
+		s.Scope.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, err)
 	}
 
 	return err
@@ -150,8 +258,8 @@ func (s *Service) Delete(ctx context.Context) error {
 
 // isVnetLinkManaged returns true if the vnet link has an owned tag with the cluster name as value,
 // meaning that the vnet link lifecycle is managed.
-func (s *Service) isVnetLinkManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
-	scope := azure.VirtualNetworkLinkID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+func (s *Service) isVnetLinkManaged(ctx context.Context, zoneSpec, spec azure.ResourceSpecGetter) (bool, error) {
+	scope := azure.VirtualNetworkLinkID(zoneSubscriptionID(s.Scope, zoneSpec), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
 	result, err := s.TagsGetter.GetAtScope(ctx, scope)
 	if err != nil {
 		return false, err
@@ -174,7 +282,7 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 		return false, errors.Errorf("no private dns zone spec available")
 	}
 
-	scope := azure.PrivateDNSZoneID(s.Scope.SubscriptionID(), zoneSpec.ResourceGroupName(), zoneSpec.ResourceName())
+	scope := azure.PrivateDNSZoneID(zoneSubscriptionID(s.Scope, zoneSpec), zoneSpec.ResourceGroupName(), zoneSpec.ResourceName())
 	result, err := s.TagsGetter.GetAtScope(ctx, scope)
 	if err != nil {
 		return false, err
@@ -188,3 +296,17 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	tags := converters.MapToTags(tagsMap)
 	return tags.HasOwned(s.Scope.ClusterName()), nil
 }
+
+// isExistingZone returns true if the private DNS zone is an existing zone referenced by its resource ID.
+func isExistingZone(zoneSpec azure.ResourceSpecGetter) bool {
+	zone, ok := zoneSpec.(ZoneSpec)
+	return ok && zone.Existing
+}
+
+// zoneSubscriptionID returns the subscription of the private DNS zone, which defaults to the subscription of the cluster.
+func zoneSubscriptionID(auth azure.Authorizer, zoneSpec azure.ResourceSpecGetter) string {
+	if zone, ok := zoneSpec.(ZoneSpec); ok && zone.SubscriptionID != "" {
+		return zone.SubscriptionID
+	}
+	return auth.SubscriptionID()
+}
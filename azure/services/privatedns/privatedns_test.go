@@ -76,6 +76,15 @@ var (
 		AdditionalTags:    nil,
 	}
 
+	fakeExistingZone = ZoneSpec{
+		Name:           zoneName,
+		ResourceGroup:  resourceGroup,
+		ClusterName:    clusterName,
+		AdditionalTags: nil,
+		SubscriptionID: "zone-subscription-id",
+		Existing:       true,
+	}
+
 	fakeRecord1 = RecordSpec{
 		Record:        infrav1.AddressRecord{Hostname: "my-host", IP: "10.0.0.8"},
 		ZoneName:      zoneName,
@@ -127,13 +136,13 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -146,8 +155,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -160,8 +169,8 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -180,11 +189,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -203,11 +212,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, errFake)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, errFake)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -226,11 +235,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -249,11 +258,11 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, notDoneError)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, notDoneError)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -273,10 +282,10 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, nil)
 				s.ClusterName().Return(clusterName)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -295,12 +304,12 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -319,13 +328,13 @@ func TestReconcilePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
 
-				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil, nil)
-				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, serviceName).Return(nil, errFake)
-				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				z.CreateOrUpdateResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
 			},
 		},
 	}
@@ -389,22 +398,22 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -441,16 +450,16 @@ func TestDeletePrivateDNS(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, nil)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -464,14 +473,14 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(notDoneError)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, notDoneError)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -485,14 +494,14 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(errFake)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, errFake)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, errFake)
 			},
 		},
 		{
@@ -506,23 +515,23 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(notDoneError)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(notDoneError)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, notDoneError)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, notDoneError)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, notDoneError)
 			},
 		},
 		{
@@ -536,23 +545,23 @@ func TestDeletePrivateDNS(t *testing.T) {
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("123", fakeLink2.ResourceGroupName(), fakeLink2.OwnerResourceName(), fakeLink2.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, serviceName).Return(nil)
+				lr.DeleteResource(gomockinternal.AContext(), fakeLink2, ServiceName).Return(nil)
 
 				s.SubscriptionID().Return("123")
 				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("123", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(managedTags, nil)
 				s.ClusterName().Return(clusterName)
 
-				zr.DeleteResource(gomockinternal.AContext(), fakeZone, serviceName).Return(errFake)
+				zr.DeleteResource(gomockinternal.AContext(), fakeZone, ServiceName).Return(errFake)
 
-				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, serviceName, nil)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, serviceName, errFake)
-				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, serviceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSZoneReadyCondition, ServiceName, errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
 			},
 		},
 	}
@@ -588,3 +597,107 @@ func TestDeletePrivateDNS(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileAndDeleteExistingPrivateDNSZone(t *testing.T) {
+	testcases := []struct {
+		name          string
+		delete        bool
+		expectedError string
+		expect        func(s *mock_privatedns.MockScopeMockRecorder, zoneReconiler, linksReconciler, recordsReconciler *mock_async.MockReconcilerMockRecorder,
+			tagsGetter *mock_async.MockTagsGetterMockRecorder)
+	}{
+		{
+			name:          "links and records are created in existing zone of another subscription",
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeLink1}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("zone-subscription-id", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(armresources.TagsResource{}, nil)
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("zone-subscription-id", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(armresources.TagsResource{}, notFoundError)
+
+				l.CreateOrUpdateResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+				s.UpdatePutStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "existing zone cannot be found",
+			expectedError: "failed to get existing private DNS zone my-zone in resource group my-rg: this is an error",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeLink1}, []azure.ResourceSpecGetter{fakeRecord1}).Times(2)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.PrivateDNSZoneID("zone-subscription-id", fakeZone.ResourceGroupName(), fakeZone.ResourceName())).Return(armresources.TagsResource{}, errFake)
+			},
+		},
+		{
+			name:          "records and links are deleted but existing zone is not",
+			delete:        true,
+			expectedError: "",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeLink1}, []azure.ResourceSpecGetter{fakeRecord1})
+
+				r.DeleteResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, nil)
+
+				tg.GetAtScope(gomockinternal.AContext(), azure.VirtualNetworkLinkID("zone-subscription-id", fakeLink1.ResourceGroupName(), fakeLink1.OwnerResourceName(), fakeLink1.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return(clusterName)
+				l.DeleteResource(gomockinternal.AContext(), fakeLink1, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSLinkReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "record deletion fails",
+			delete:        true,
+			expectedError: "this is an error",
+			expect: func(s *mock_privatedns.MockScopeMockRecorder, z, l, r *mock_async.MockReconcilerMockRecorder, tg *mock_async.MockTagsGetterMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.PrivateDNSSpec().Return(fakeExistingZone, []azure.ResourceSpecGetter{fakeLink1}, []azure.ResourceSpecGetter{fakeRecord1})
+
+				r.DeleteResource(gomockinternal.AContext(), fakeRecord1, ServiceName).Return(errFake)
+				s.UpdateDeleteStatus(infrav1.PrivateDNSRecordReadyCondition, ServiceName, errFake)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_privatedns.NewMockScope(mockCtrl)
+			zoneReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			vnetLinkReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			recordReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), zoneReconcilerMock.EXPECT(), vnetLinkReconcilerMock.EXPECT(), recordReconcilerMock.EXPECT(), tagsGetterMock.EXPECT())
+
+			s := &Service{
+				Scope:              scopeMock,
+				zoneReconciler:     zoneReconcilerMock,
+				vnetLinkReconciler: vnetLinkReconcilerMock,
+				recordReconciler:   recordReconcilerMock,
+				TagsGetter:         tagsGetterMock,
+			}
+
+			var err error
+			if tc.delete {
+				err = s.Delete(t.Context())
+			} else {
+				err = s.Reconcile(t.Context())
+			}
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -34,7 +35,7 @@ type azureRecordsClient struct {
 
 // newRecordSetsClient creates a record sets client from an authorizer.
 func newRecordSetsClient(auth azure.Authorizer) (*azureRecordsClient, error) {
-	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	opts, err := azure.ARMClientOptionsForAuthorizer(auth)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create recordsets client options")
 	}
@@ -80,7 +81,17 @@ func (arc *azureRecordsClient) CreateOrUpdateAsync(ctx context.Context, spec azu
 	return recordSet, nil, err
 }
 
-// DeleteAsync deletes a record asynchronously. Noop for records.
-func (arc *azureRecordsClient) DeleteAsync(_ context.Context, _ azure.ResourceSpecGetter, _ string) (poller *runtime.Poller[armprivatedns.RecordSetsClientDeleteResponse], err error) {
-	return nil, nil
+// DeleteAsync deletes a record asynchronously.
+// Deleting a record set is not a long-running operation, so we don't ever return a future.
+func (arc *azureRecordsClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, _ string) (poller *runtime.Poller[armprivatedns.RecordSetsClientDeleteResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatedns.azureRecordsClient.DeleteAsync")
+	defer done()
+
+	recordSpec, ok := spec.(RecordSpec)
+	if !ok {
+		return nil, errors.Errorf("%T is not a privatedns.RecordSpec", spec)
+	}
+
+	_, err = arc.recordsets.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), converters.GetRecordType(recordSpec.Record.IP), spec.ResourceName(), nil)
+	return nil, err
 }
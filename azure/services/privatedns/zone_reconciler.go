@@ -31,6 +31,14 @@ func (s *Service) reconcileZone(ctx context.Context, zoneSpec azure.ResourceSpec
 	defer done()
 
 	managed, err = s.IsManaged(ctx)
+	if isExistingZone(zoneSpec) {
+		// An existing private DNS zone is never created nor updated, it only has to exist.
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get existing private DNS zone %s in resource group %s", zoneSpec.ResourceName(), zoneSpec.ResourceGroupName())
+		}
+		log.V(1).Info("Skipping reconciliation of existing private DNS zone", "private DNS", zoneSpec.ResourceName())
+		return false, nil
+	}
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			managed = true
@@ -44,7 +52,7 @@ func (s *Service) reconcileZone(ctx context.Context, zoneSpec azure.ResourceSpec
 		return managed, nil
 	}
 
-	_, err = s.zoneReconciler.CreateOrUpdateResource(ctx, zoneSpec, serviceName)
+	_, err = s.zoneReconciler.CreateOrUpdateResource(ctx, zoneSpec, ServiceName)
 	return managed, err
 }
 
@@ -52,12 +60,18 @@ func (s *Service) deleteZone(ctx context.Context, zoneSpec azure.ResourceSpecGet
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "privatedns.Service.deleteZone")
 	defer done()
 
+	// Never delete an existing private DNS zone referenced by its resource ID.
+	if isExistingZone(zoneSpec) {
+		log.V(1).Info("Skipping deletion of existing private DNS zone", "private DNS", zoneSpec.ResourceName())
+		return managed, nil
+	}
+
 	// Skip deleting the private DNS zone when it's not managed by capz.
 	isManaged, err := s.IsManaged(ctx)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted or doesn't exist, cleanup status and return.
-			s.Scope.DeleteLongRunningOperationState(zoneSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
+			s.Scope.DeleteLongRunningOperationState(zoneSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
 			return managed, nil
 		}
 		return managed, errors.Wrapf(err, "could not get private DNS zone state of %s in resource group %s", zoneSpec.ResourceName(), zoneSpec.ResourceGroupName())
@@ -72,6 +86,6 @@ func (s *Service) deleteZone(ctx context.Context, zoneSpec azure.ResourceSpecGet
 	managed = true
 
 	// Delete the private DNS zone, which also deletes all records
-	err = s.zoneReconciler.DeleteResource(ctx, zoneSpec, serviceName)
+	err = s.zoneReconciler.DeleteResource(ctx, zoneSpec, ServiceName)
 	return managed, err
 }
@@ -34,6 +34,10 @@ type ZoneSpec struct {
 	ResourceGroup  string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+	// SubscriptionID is the subscription of the private dns zone. Defaults to the subscription of the cluster when empty.
+	SubscriptionID string
+	// Existing is true when the private dns zone is referenced by its resource ID and is never created nor deleted.
+	Existing bool
 }
 
 // ResourceName returns the name of the private dns zone.
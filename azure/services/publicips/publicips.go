@@ -82,6 +82,28 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return azure.ReconcileAll(ctx, s.Reconciler, s.Scope, s.Scope.PublicIPSpecs(), serviceName, infrav1.PublicIPsReadyCondition)
 }
 
+// Plan implements azure.Planner.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.PlanAll(ctx, s.Reconciler, s.Scope.PublicIPSpecs(), serviceName)
+}
+
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicips.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.DetectDriftAll(ctx, s.Reconciler, s.Scope.PublicIPSpecs(), serviceName, correct)
+}
+
 // Delete deletes the public IP with the provided scope.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicips.Service.Delete")
@@ -38,7 +38,7 @@ type azureClient struct {
 
 // newClient creates a new resource health client from an authorizer.
 func newClient(auth azure.Authorizer) (*azureClient, error) {
-	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	opts, err := azure.ARMClientOptionsForAuthorizer(auth)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create resourcehealth client options")
 	}
@@ -76,6 +76,8 @@ const (
 	ConfidentialComputingType = "ConfidentialComputingType"
 	// CPUArchitectureType identifies the capability for cpu architecture.
 	CPUArchitectureType = "CpuArchitectureType"
+	// PremiumIO identifies the capability for premium storage support.
+	PremiumIO = "PremiumIO"
 )
 
 // HasCapability return true for a capability which can be either
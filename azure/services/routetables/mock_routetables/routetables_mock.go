@@ -212,6 +212,20 @@ func (mr *MockRouteTableScopeMockRecorder) IsVnetManaged() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVnetManaged", reflect.TypeOf((*MockRouteTableScope)(nil).IsVnetManaged))
 }
 
+// NetworkAuthorizer mocks base method.
+func (m *MockRouteTableScope) NetworkAuthorizer() azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkAuthorizer")
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// NetworkAuthorizer indicates an expected call of NetworkAuthorizer.
+func (mr *MockRouteTableScopeMockRecorder) NetworkAuthorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkAuthorizer", reflect.TypeOf((*MockRouteTableScope)(nil).NetworkAuthorizer))
+}
+
 // RouteTableSpecs mocks base method.
 func (m *MockRouteTableScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
@@ -36,6 +36,7 @@ type RouteTableScope interface {
 	azure.AsyncStatusUpdater
 	RouteTableSpecs() []azure.ResourceSpecGetter
 	IsVnetManaged() bool
+	NetworkAuthorizer() azure.Authorizer
 }
 
 // Service provides operations on azure resources.
@@ -46,7 +47,7 @@ type Service struct {
 
 // New creates a new service.
 func New(scope RouteTableScope) (*Service, error) {
-	client, err := newClient(scope, scope.DefaultedAzureCallTimeout())
+	client, err := newClient(scope.NetworkAuthorizer(), scope.DefaultedAzureCallTimeout())
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +81,42 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return azure.ReconcileAll(ctx, s.Reconciler, s.Scope, s.Scope.RouteTableSpecs(), serviceName, infrav1.RouteTablesReadyCondition)
 }
 
+// Plan implements azure.Planner.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "routetables.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping route tables plan in custom vnet mode")
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to check if route tables are managed")
+	}
+
+	return azure.PlanAll(ctx, s.Reconciler, s.Scope.RouteTableSpecs(), serviceName)
+}
+
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "routetables.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping route tables drift detection in custom vnet mode")
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to check if route tables are managed")
+	}
+
+	return azure.DetectDriftAll(ctx, s.Reconciler, s.Scope.RouteTableSpecs(), serviceName, correct)
+}
+
 // Delete deletes route tables.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "routetables.Service.Delete")
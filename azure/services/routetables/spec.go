@@ -27,6 +27,12 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
+// Name and address prefix of the default route sending the egress traffic of a subnet to a virtual appliance.
+const (
+	defaultRouteName          = "default-egress"
+	defaultRouteAddressPrefix = "0.0.0.0/0"
+)
+
 // RouteTableSpec defines the specification for a route table.
 type RouteTableSpec struct {
 	Name           string
@@ -34,6 +40,9 @@ type RouteTableSpec struct {
 	Location       string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+	// DefaultRouteNextHopIP is the IP of the virtual appliance, such as an Azure Firewall, the default route of the
+	// route table points at. No default route is managed when it is empty.
+	DefaultRouteNextHopIP string
 }
 
 // ResourceName returns the name of the route table.
@@ -54,16 +63,23 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the route table.
 func (s *RouteTableSpec) Parameters(_ context.Context, existing any) (params any, err error) {
 	if existing != nil {
-		if _, ok := existing.(armnetwork.RouteTable); !ok {
+		existingRouteTable, ok := existing.(armnetwork.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not an armnetwork.RouteTable", existing)
 		}
 		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// currently don't support specifying your own routes via spec, only the default egress route is managed.
+		return s.withDefaultRoute(existingRouteTable), nil
+	}
+	var routes []*armnetwork.Route
+	if s.DefaultRouteNextHopIP != "" {
+		routes = append(routes, s.defaultRoute())
 	}
 	return armnetwork.RouteTable{
-		Location:   ptr.To(s.Location),
-		Properties: &armnetwork.RouteTablePropertiesFormat{},
+		Location: ptr.To(s.Location),
+		Properties: &armnetwork.RouteTablePropertiesFormat{
+			Routes: routes,
+		},
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -72,3 +88,44 @@ func (s *RouteTableSpec) Parameters(_ context.Context, existing any) (params any
 		})),
 	}, nil
 }
+
+// withDefaultRoute returns a copy of the existing route table with the default route of the spec, or nil if the
+// spec has no default route or the existing route table already has it. The other routes of the existing route
+// table, such as the pod routes programmed by the cloud provider, are kept.
+func (s *RouteTableSpec) withDefaultRoute(existing armnetwork.RouteTable) any {
+	if s.DefaultRouteNextHopIP == "" {
+		return nil
+	}
+	properties := armnetwork.RouteTablePropertiesFormat{}
+	if existing.Properties != nil {
+		properties = *existing.Properties
+	}
+	routes := make([]*armnetwork.Route, 0, len(properties.Routes)+1)
+	for _, route := range properties.Routes {
+		if route == nil || ptr.Deref(route.Name, "") != defaultRouteName {
+			routes = append(routes, route)
+			continue
+		}
+		if route.Properties != nil &&
+			ptr.Deref(route.Properties.AddressPrefix, "") == defaultRouteAddressPrefix &&
+			ptr.Deref(route.Properties.NextHopType, "") == armnetwork.RouteNextHopTypeVirtualAppliance &&
+			ptr.Deref(route.Properties.NextHopIPAddress, "") == s.DefaultRouteNextHopIP {
+			return nil
+		}
+	}
+	properties.Routes = append(routes, s.defaultRoute())
+	existing.Properties = &properties
+	return existing
+}
+
+// defaultRoute returns the route sending all egress traffic to the virtual appliance of the spec.
+func (s *RouteTableSpec) defaultRoute() *armnetwork.Route {
+	return &armnetwork.Route{
+		Name: ptr.To(defaultRouteName),
+		Properties: &armnetwork.RoutePropertiesFormat{
+			AddressPrefix:    ptr.To(defaultRouteAddressPrefix),
+			NextHopType:      ptr.To(armnetwork.RouteNextHopTypeVirtualAppliance),
+			NextHopIPAddress: ptr.To(s.DefaultRouteNextHopIP),
+		},
+	}
+}
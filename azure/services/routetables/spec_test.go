@@ -38,6 +38,28 @@ var (
 			"foo": "bar",
 		},
 	}
+	fakeFirewallRouteTableSpec = RouteTableSpec{
+		Name:                  "test-rt-1",
+		Location:              "fake-location",
+		ClusterName:           "cluster",
+		DefaultRouteNextHopIP: "10.255.253.4",
+	}
+	fakePodRoute = &armnetwork.Route{
+		Name: ptr.To("pod-route"),
+		Properties: &armnetwork.RoutePropertiesFormat{
+			AddressPrefix:    ptr.To("192.168.0.0/24"),
+			NextHopType:      ptr.To(armnetwork.RouteNextHopTypeVirtualAppliance),
+			NextHopIPAddress: ptr.To("10.1.0.4"),
+		},
+	}
+	fakeDefaultRoute = &armnetwork.Route{
+		Name: ptr.To("default-egress"),
+		Properties: &armnetwork.RoutePropertiesFormat{
+			AddressPrefix:    ptr.To("0.0.0.0/0"),
+			NextHopType:      ptr.To(armnetwork.RouteNextHopTypeVirtualAppliance),
+			NextHopIPAddress: ptr.To("10.255.253.4"),
+		},
+	}
 	fakeRouteTableTags = map[string]*string{
 		"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster": ptr.To("owned"),
 		"foo":  ptr.To("bar"),
@@ -91,6 +113,69 @@ func TestRouteTableSpec_Parameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "get result as nil when existing RouteTable already has the default route",
+			spec: &fakeFirewallRouteTableSpec,
+			existing: armnetwork.RouteTable{
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{fakePodRoute, fakeDefaultRoute},
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "add the default route to an existing RouteTable and keep its other routes",
+			spec: &fakeFirewallRouteTableSpec,
+			existing: armnetwork.RouteTable{
+				Location: ptr.To("fake-location"),
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{fakePodRoute},
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.RouteTable{}))
+				g.Expect(result.(armnetwork.RouteTable).Location).To(Equal(ptr.To("fake-location")))
+				g.Expect(result.(armnetwork.RouteTable).Properties.Routes).To(Equal([]*armnetwork.Route{fakePodRoute, fakeDefaultRoute}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "replace an outdated default route of an existing RouteTable",
+			spec: &fakeFirewallRouteTableSpec,
+			existing: armnetwork.RouteTable{
+				Properties: &armnetwork.RouteTablePropertiesFormat{
+					Routes: []*armnetwork.Route{
+						{
+							Name: ptr.To("default-egress"),
+							Properties: &armnetwork.RoutePropertiesFormat{
+								AddressPrefix:    ptr.To("0.0.0.0/0"),
+								NextHopType:      ptr.To(armnetwork.RouteNextHopTypeVirtualAppliance),
+								NextHopIPAddress: ptr.To("10.255.253.5"),
+							},
+						},
+						fakePodRoute,
+					},
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.RouteTable{}))
+				g.Expect(result.(armnetwork.RouteTable).Properties.Routes).To(Equal([]*armnetwork.Route{fakePodRoute, fakeDefaultRoute}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "get RouteTable with the default route when a next hop IP is set",
+			spec:     &fakeFirewallRouteTableSpec,
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.RouteTable{}))
+				g.Expect(result.(armnetwork.RouteTable).Properties.Routes).To(Equal([]*armnetwork.Route{fakeDefaultRoute}))
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -49,6 +49,26 @@ type (
 		ReconcileReplicas(context.Context, *azure.VMSS) error
 	}
 
+	// BlueGreenScope is implemented by a ScaleSetScope which can roll model changes out by replacing the scale set
+	// with the BlueGreen deployment strategy, rather than updating it in place.
+	BlueGreenScope interface {
+		// BlueGreenEnabled reports whether model changes are rolled out by replacing the scale set.
+		BlueGreenEnabled(context.Context) bool
+		// UpdateBlueGreenRollout returns the name of the scale set the model with the given hash is being rolled out
+		// to, starting a rollout if needed. An empty hash means the active scale set is already on the latest model.
+		// An empty name is returned when the model must not be rolled out to a new scale set, including when the
+		// scale set has no replicas and the alternate scale set became active directly.
+		UpdateBlueGreenRollout(modelHash string) string
+		// PendingScaleSetName returns the name of the scale set being rolled out, or an empty string.
+		PendingScaleSetName() string
+		// RetiringScaleSetName returns the name of the scale set being drained, or an empty string.
+		RetiringScaleSetName() string
+		// SetScaleSetRetired records that the scale set being drained has been deleted.
+		SetScaleSetRetired()
+		// SetPendingVMSSState updates the scope with the current state of the scale set being rolled out.
+		SetPendingVMSSState(*azure.VMSS)
+	}
+
 	// Service provides operations on Azure resources.
 	Service struct {
 		Scope ScaleSetScope
@@ -110,6 +130,9 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 			if err := s.updateScopeState(ctx, result, scaleSetSpec); err != nil {
 				return err
 			}
+			if blueGreen, ok := s.Scope.(BlueGreenScope); ok && blueGreen.BlueGreenEnabled(ctx) {
+				return s.reconcileBlueGreen(ctx, blueGreen, scaleSetSpec, result)
+			}
 		}
 	} else if !azure.ResourceNotFound(err) {
 		return errors.Wrapf(err, "failed to get existing VMSS")
@@ -127,6 +150,102 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 	return err
 }
 
+// reconcileBlueGreen rolls model changes out to a second scale set rather than updating the active scale set in place.
+// Once the scope has retired one of the two scale sets, it is deleted as soon as all of its instances have been drained.
+func (s *Service) reconcileBlueGreen(ctx context.Context, blueGreen BlueGreenScope, scaleSetSpec *ScaleSetSpec, existing any) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.reconcileBlueGreen")
+	defer done()
+
+	if err := s.deleteRetiringScaleSet(ctx, blueGreen, scaleSetSpec.ResourceGroupName()); err != nil {
+		return err
+	}
+
+	modelHash, err := scaleSetSpec.modelHash(ctx, existing)
+	if err != nil {
+		return err
+	}
+
+	pending := blueGreen.UpdateBlueGreenRollout(modelHash)
+	if pending == "" {
+		if modelHash != "" {
+			log.V(2).Info("not rolling out the latest model to a new scale set", "scaleSet", scaleSetSpec.Name, "retiringScaleSet", blueGreen.RetiringScaleSetName())
+			return nil
+		}
+
+		// The active scale set is on the latest model, so only its capacity or custom data can change.
+		result, err := s.CreateOrUpdateResource(ctx, scaleSetSpec, serviceName)
+		s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, err)
+		if err == nil && result != nil {
+			return s.updateScopeState(ctx, result, scaleSetSpec)
+		}
+		return err
+	}
+
+	pendingSpec := *scaleSetSpec
+	pendingSpec.Name = pending
+	pendingSpec.VMSSInstances = nil
+	if _, err := s.Client.Get(ctx, &pendingSpec); err == nil {
+		pendingSpec.VMSSInstances, err = s.Client.ListInstances(ctx, pendingSpec.ResourceGroupName(), pendingSpec.ResourceName())
+		if err != nil {
+			return errors.Wrapf(err, "failed to get existing instances of VMSS %s", pending)
+		}
+	} else if !azure.ResourceNotFound(err) {
+		return errors.Wrapf(err, "failed to get existing VMSS %s", pending)
+	}
+
+	log.V(2).Info("rolling out the latest model to a new scale set", "scaleSet", pending, "activeScaleSet", scaleSetSpec.Name)
+	result, err := s.CreateOrUpdateResource(ctx, &pendingSpec, serviceName)
+	s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, err)
+	if err != nil || result == nil {
+		return err
+	}
+
+	vmss, ok := result.(armcompute.VirtualMachineScaleSet)
+	if !ok {
+		return errors.Errorf("%T is not an armcompute.VirtualMachineScaleSet", result)
+	}
+	pendingVMSS := converters.SDKToVMSS(vmss, pendingSpec.VMSSInstances)
+	blueGreen.SetPendingVMSSState(&pendingVMSS)
+	return nil
+}
+
+// deleteRetiringScaleSet deletes the scale set retired by a BlueGreen rollout once all of its instances have been
+// drained and deleted.
+func (s *Service) deleteRetiringScaleSet(ctx context.Context, blueGreen BlueGreenScope, resourceGroup string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.deleteRetiringScaleSet")
+	defer done()
+
+	name := blueGreen.RetiringScaleSetName()
+	if name == "" {
+		return nil
+	}
+
+	instances, err := s.Client.ListInstances(ctx, resourceGroup, name)
+	if azure.ResourceNotFound(err) {
+		blueGreen.SetScaleSetRetired()
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get existing instances of VMSS %s", name)
+	}
+	if len(instances) > 0 {
+		log.V(4).Info("waiting for the instances of the retiring scale set to be deleted", "scaleSet", name, "instances", len(instances))
+		return nil
+	}
+
+	log.V(2).Info("deleting retired scale set", "scaleSet", name)
+	if err := s.DeleteResource(ctx, &ScaleSetSpec{Name: name, ResourceGroup: resourceGroup}, serviceName); err != nil {
+		if azure.IsOperationNotDoneError(err) {
+			// the delete is resumed on the next reconcile
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete retired VMSS %s", name)
+	}
+
+	blueGreen.SetScaleSetRetired()
+	return nil
+}
+
 // updateScopeState updates the scope's VMSS state and provider ID
 //
 // Code later in the reconciler uses scope's VMSS state for determining scale status and whether to create/delete
@@ -164,11 +283,30 @@ func (s *Service) Delete(ctx context.Context) error {
 
 	scaleSetSpec := s.Scope.ScaleSetSpec(ctx)
 
-	err := s.DeleteResource(ctx, scaleSetSpec, serviceName)
+	specs := []azure.ResourceSpecGetter{scaleSetSpec}
+	if blueGreen, ok := s.Scope.(BlueGreenScope); ok {
+		// The pending and retiring scale sets of a BlueGreen rollout are deleted along with the active one.
+		for _, name := range []string{blueGreen.PendingScaleSetName(), blueGreen.RetiringScaleSetName()} {
+			if name != "" {
+				specs = append(specs, &ScaleSetSpec{Name: name, ResourceGroup: scaleSetSpec.ResourceGroupName()})
+			}
+		}
+	}
 
-	s.Scope.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, err)
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
+	var result error
+	for _, spec := range specs {
+		if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
 
-	return err
+	s.Scope.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, result)
+
+	return result
 }
 
 func (s *Service) validateSpec(ctx context.Context) error {
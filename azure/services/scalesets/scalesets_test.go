@@ -17,6 +17,7 @@ limitations under the License.
 package scalesets
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
@@ -358,6 +360,155 @@ func TestReconcileVMSS(t *testing.T) {
 	}
 }
 
+// blueGreenScope is a ScaleSetScope using the BlueGreen deployment strategy.
+type blueGreenScope struct {
+	*mock_scalesets.MockScaleSetScope
+	modelHash    string
+	pending      string
+	retiring     string
+	pendingState *azure.VMSS
+}
+
+func (s *blueGreenScope) BlueGreenEnabled(context.Context) bool { return true }
+
+func (s *blueGreenScope) UpdateBlueGreenRollout(modelHash string) string {
+	s.modelHash = modelHash
+	if modelHash != "" && s.retiring == "" {
+		s.pending = defaultVMSSName + "-g"
+	}
+	return s.pending
+}
+
+func (s *blueGreenScope) PendingScaleSetName() string { return s.pending }
+
+func (s *blueGreenScope) RetiringScaleSetName() string { return s.retiring }
+
+func (s *blueGreenScope) SetScaleSetRetired() { s.retiring = "" }
+
+func (s *blueGreenScope) SetPendingVMSSState(vmss *azure.VMSS) { s.pendingState = vmss }
+
+func TestReconcileVMSSBlueGreen(t *testing.T) {
+	defaultInstances := newDefaultInstances()
+	fetchedVMSS := converters.SDKToVMSS(getResultVMSS(), defaultInstances)
+
+	newModelVMSS := getResultVMSS()
+	newModelVMSS.Properties.VirtualMachineProfile.StorageProfile.ImageReference.Version = ptr.To("0.9")
+	fetchedNewModelVMSS := converters.SDKToVMSS(newModelVMSS, defaultInstances)
+
+	pendingVMSS := getResultVMSS()
+	pendingVMSS.Name = ptr.To(defaultVMSSName + "-g")
+
+	testcases := []struct {
+		name              string
+		retiring          string
+		expect            func(s *mock_scalesets.MockScaleSetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		expectedPending   string
+		expectedRetiring  string
+		expectPendingVMSS bool
+	}{
+		{
+			name: "update the active vmss in place when the model is unchanged",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := getDefaultVMSSSpec()
+				s.ScaleSetSpec(gomockinternal.AContext()).Return(spec).AnyTimes()
+				m.Get(gomockinternal.AContext(), spec).Return(getResultVMSS(), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(defaultInstances, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), spec, serviceName).Return(getResultVMSS(), nil)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				s.ReconcileReplicas(gomockinternal.AContext(), &fetchedVMSS).Return(nil).Times(2)
+				s.SetProviderID(azureutil.ProviderIDPrefix + defaultVMSSID).Times(2)
+				s.SetVMSSState(&fetchedVMSS).Times(2)
+			},
+		},
+		{
+			name: "create a second vmss when the model changes",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := getDefaultVMSSSpec()
+				s.ScaleSetSpec(gomockinternal.AContext()).Return(spec).AnyTimes()
+				m.Get(gomockinternal.AContext(), spec).Return(newModelVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(defaultInstances, nil)
+				s.ReconcileReplicas(gomockinternal.AContext(), &fetchedNewModelVMSS).Return(nil)
+				s.SetProviderID(azureutil.ProviderIDPrefix + defaultVMSSID)
+				s.SetVMSSState(&fetchedNewModelVMSS)
+
+				m.Get(gomockinternal.AContext(), gomock.Any()).Return(nil, notFoundError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), gomock.Any(), serviceName).DoAndReturn(
+					func(_ context.Context, spec azure.ResourceSpecGetter, _ string) (any, error) {
+						if spec.ResourceName() != defaultVMSSName+"-g" {
+							return nil, errors.Errorf("unexpected scale set %s", spec.ResourceName())
+						}
+						return pendingVMSS, nil
+					})
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+			},
+			expectedPending:   defaultVMSSName + "-g",
+			expectPendingVMSS: true,
+		},
+		{
+			name:     "wait for the instances of the retiring vmss to be deleted",
+			retiring: defaultVMSSName + "-g",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := getDefaultVMSSSpec()
+				s.ScaleSetSpec(gomockinternal.AContext()).Return(spec).AnyTimes()
+				m.Get(gomockinternal.AContext(), spec).Return(newModelVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(defaultInstances, nil)
+				s.ReconcileReplicas(gomockinternal.AContext(), &fetchedNewModelVMSS).Return(nil)
+				s.SetProviderID(azureutil.ProviderIDPrefix + defaultVMSSID)
+				s.SetVMSSState(&fetchedNewModelVMSS)
+
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName+"-g").Return(defaultInstances, nil)
+			},
+			expectedRetiring: defaultVMSSName + "-g",
+		},
+		{
+			name:     "delete the retiring vmss once it has no instances",
+			retiring: defaultVMSSName + "-g",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				spec := getDefaultVMSSSpec()
+				s.ScaleSetSpec(gomockinternal.AContext()).Return(spec).AnyTimes()
+				m.Get(gomockinternal.AContext(), spec).Return(getResultVMSS(), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(defaultInstances, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), spec, serviceName).Return(getResultVMSS(), nil)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				s.ReconcileReplicas(gomockinternal.AContext(), &fetchedVMSS).Return(nil).Times(2)
+				s.SetProviderID(azureutil.ProviderIDPrefix + defaultVMSSID).Times(2)
+				s.SetVMSSState(&fetchedVMSS).Times(2)
+
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName+"-g").Return(nil, nil)
+				r.DeleteResource(gomockinternal.AContext(), &ScaleSetSpec{Name: defaultVMSSName + "-g", ResourceGroup: defaultResourceGroup}, serviceName).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := &blueGreenScope{MockScaleSetScope: mock_scalesets.NewMockScaleSetScope(mockCtrl), retiring: tc.retiring}
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+
+			scopeMock.EXPECT().DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				Reconciler:       asyncMock,
+				Client:           clientMock,
+				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
+			}
+
+			g.Expect(s.Reconcile(t.Context())).To(Succeed())
+			g.Expect(scopeMock.pending).To(Equal(tc.expectedPending))
+			g.Expect(scopeMock.retiring).To(Equal(tc.expectedRetiring))
+			g.Expect(scopeMock.pendingState != nil).To(Equal(tc.expectPendingVMSS))
+		})
+	}
+}
+
 func TestDeleteVMSS(t *testing.T) {
 	defaultSpec := newDefaultVMSSSpec()
 
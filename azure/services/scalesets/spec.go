@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -52,6 +53,7 @@ type ScaleSetSpec struct {
 	VNetResourceGroup            string
 	PublicLBName                 string
 	PublicLBAddressPoolName      string
+	PublicLBIPv6AddressPoolName  string
 	AcceleratedNetworking        *bool
 	TerminateNotificationTimeout *int
 	Identity                     infrav1.VMIdentity
@@ -78,6 +80,8 @@ type ScaleSetSpec struct {
 	AdditionalTags               infrav1.Tags
 	PlatformFaultDomainCount     *int32
 	ZoneBalance                  *bool
+	// VNetSubscriptionID is the ID of the subscription of the virtual network of the subnets. Defaults to SubscriptionID.
+	VNetSubscriptionID string
 }
 
 // ResourceName returns the name of the Scale Set.
@@ -311,6 +315,38 @@ func (s *ScaleSetSpec) Parameters(ctx context.Context, existing any) (parameters
 	return vmss, nil
 }
 
+// modelHash returns a hash identifying the VMSS model described by the spec if it differs from the model of the existing
+// scale set, or an empty string if the existing scale set is already on that model.
+func (s *ScaleSetSpec) modelHash(ctx context.Context, existing any) (string, error) {
+	existingVMSS, ok := existing.(armcompute.VirtualMachineScaleSet)
+	if !ok {
+		return "", errors.Errorf("%T is not an armcompute.VirtualMachineScaleSet", existing)
+	}
+
+	params, err := s.Parameters(ctx, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to generate scale set parameters for %s", s.Name)
+	}
+
+	vmss, ok := params.(armcompute.VirtualMachineScaleSet)
+	if !ok {
+		return "", errors.Errorf("%T is not an armcompute.VirtualMachineScaleSet", params)
+	}
+
+	existingInfraVMSS := converters.SDKToVMSS(existingVMSS, nil)
+	if !hasModelModifyingDifferences(&existingInfraVMSS, vmss) {
+		return "", nil
+	}
+
+	// Hash the same fields HasModelChanges compares.
+	model := converters.SDKToVMSS(vmss, nil)
+	data, err := json.Marshal(azure.VMSS{Image: model.Image, Identity: model.Identity, Zones: model.Zones, Sku: model.Sku})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal scale set model")
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
 func hasModelModifyingDifferences(infraVMSS *azure.VMSS, vmss armcompute.VirtualMachineScaleSet) bool {
 	other := converters.SDKToVMSS(vmss, []armcompute.VirtualMachineScaleSetVM{})
 	return infraVMSS.HasModelChanges(other)
@@ -343,6 +379,13 @@ func (s *ScaleSetSpec) getVirtualMachineScaleSetNetworkConfiguration() *[]armcom
 				})
 		}
 	}
+	var ipv6BackendAddressPools []*armcompute.SubResource
+	if s.PublicLBName != "" && s.PublicLBIPv6AddressPoolName != "" {
+		ipv6BackendAddressPools = append(ipv6BackendAddressPools,
+			&armcompute.SubResource{
+				ID: ptr.To(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBIPv6AddressPoolName)),
+			})
+	}
 	nicConfigs := []armcompute.VirtualMachineScaleSetNetworkConfiguration{}
 	for i, n := range s.NetworkInterfaces {
 		nicConfig := armcompute.VirtualMachineScaleSetNetworkConfiguration{}
@@ -365,7 +408,7 @@ func (s *ScaleSetSpec) getVirtualMachineScaleSetNetworkConfiguration() *[]armcom
 				Properties: &armcompute.VirtualMachineScaleSetIPConfigurationProperties{
 					PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv4),
 					Subnet: &armcompute.APIEntityReference{
-						ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, n.SubnetName)),
+						ID: ptr.To(azure.SubnetID(s.vnetSubscriptionID(), s.VNetResourceGroup, s.VNetName, n.SubnetName)),
 					},
 				},
 			}
@@ -383,10 +426,13 @@ func (s *ScaleSetSpec) getVirtualMachineScaleSetNetworkConfiguration() *[]armcom
 					PrivateIPAddressVersion: ptr.To(armcompute.IPVersionIPv6),
 					Primary:                 ptr.To(false),
 					Subnet: &armcompute.APIEntityReference{
-						ID: ptr.To(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, n.SubnetName)),
+						ID: ptr.To(azure.SubnetID(s.vnetSubscriptionID(), s.VNetResourceGroup, s.VNetName, n.SubnetName)),
 					},
 				},
 			}
+			if i == 0 {
+				ipv6Config.Properties.LoadBalancerBackendAddressPools = ipv6BackendAddressPools
+			}
 			ipconfigs = append(ipconfigs, ipv6Config)
 		}
 		if i == 0 {
@@ -551,3 +597,11 @@ func calculateBootstrapDataHash(bootstrapData string) (string, error) {
 	}
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
+
+// vnetSubscriptionID returns the ID of the subscription of the virtual network.
+func (s *ScaleSetSpec) vnetSubscriptionID() string {
+	if s.VNetSubscriptionID != "" {
+		return s.VNetSubscriptionID
+	}
+	return s.SubscriptionID
+}
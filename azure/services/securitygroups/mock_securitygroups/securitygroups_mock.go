@@ -26,6 +26,7 @@ limitations under the License.
 package mock_securitygroups
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -226,6 +227,35 @@ func (mr *MockNSGScopeMockRecorder) NSGSpecs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NSGSpecs", reflect.TypeOf((*MockNSGScope)(nil).NSGSpecs))
 }
 
+// NetworkAuthorizer mocks base method.
+func (m *MockNSGScope) NetworkAuthorizer() azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkAuthorizer")
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// NetworkAuthorizer indicates an expected call of NetworkAuthorizer.
+func (mr *MockNSGScopeMockRecorder) NetworkAuthorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkAuthorizer", reflect.TypeOf((*MockNSGScope)(nil).NetworkAuthorizer))
+}
+
+// SecurityRuleSet mocks base method.
+func (m *MockNSGScope) SecurityRuleSet(ctx context.Context, name string) (*v1beta1.AzureSecurityRuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityRuleSet", ctx, name)
+	ret0, _ := ret[0].(*v1beta1.AzureSecurityRuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecurityRuleSet indicates an expected call of SecurityRuleSet.
+func (mr *MockNSGScopeMockRecorder) SecurityRuleSet(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityRuleSet", reflect.TypeOf((*MockNSGScope)(nil).SecurityRuleSet), ctx, name)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockNSGScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
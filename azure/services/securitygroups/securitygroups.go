@@ -18,9 +18,12 @@ package securitygroups
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -35,8 +38,10 @@ type NSGScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	NSGSpecs() []azure.ResourceSpecGetter
+	SecurityRuleSet(ctx context.Context, name string) (*infrav1.AzureSecurityRuleSet, error)
 	IsVnetManaged() bool
 	UpdateAnnotationJSON(string, map[string]any) error
+	NetworkAuthorizer() azure.Authorizer
 }
 
 // Service provides operations on Azure resources.
@@ -47,7 +52,7 @@ type Service struct {
 
 // New creates a new service.
 func New(scope NSGScope) (*Service, error) {
-	client, err := newClient(scope, scope.DefaultedAzureCallTimeout())
+	client, err := newClient(scope.NetworkAuthorizer(), scope.DefaultedAzureCallTimeout())
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +84,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed to check if security groups are managed")
 	}
 
-	specs := s.Scope.NSGSpecs()
+	specs, err := s.nsgSpecs(ctx)
+	if err != nil {
+		s.Scope.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, err)
+		return err
+	}
 	if len(specs) == 0 {
 		return nil
 	}
@@ -118,6 +127,52 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return resErr
 }
 
+// Plan implements azure.Planner.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping security groups plan in custom vnet mode")
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to check if security groups are managed")
+	}
+
+	specs, err := s.nsgSpecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return azure.PlanAll(ctx, s.Reconciler, specs, serviceName)
+}
+
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	if managed, err := s.IsManaged(ctx); err == nil && !managed {
+		log.V(4).Info("Skipping security groups drift detection in custom vnet mode")
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to check if security groups are managed")
+	}
+
+	specs, err := s.nsgSpecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return azure.DetectDriftAll(ctx, s.Reconciler, specs, serviceName, correct)
+}
+
 // Delete deletes network security groups.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "securitygroups.Service.Delete")
@@ -144,3 +199,50 @@ func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 
 	return s.Scope.IsVnetManaged(), nil
 }
+
+// nsgSpecs returns the security group specs with the rules of the AzureSecurityRuleSets they reference added to their
+// own rules.
+func (s *Service) nsgSpecs(ctx context.Context) ([]azure.ResourceSpecGetter, error) {
+	specs := s.Scope.NSGSpecs()
+	for i, spec := range specs {
+		nsgSpec, ok := spec.(*NSGSpec)
+		if !ok || len(nsgSpec.SecurityRuleSets) == 0 {
+			continue
+		}
+		merged := *nsgSpec
+		merged.SecurityRules = slices.Clone(nsgSpec.SecurityRules)
+		for _, name := range nsgSpec.SecurityRuleSets {
+			ruleSet, err := s.Scope.SecurityRuleSet(ctx, name)
+			if apierrors.IsNotFound(err) {
+				// The AzureCluster is reconciled again once the AzureSecurityRuleSet is created.
+				return nil, azure.WithTerminalError(errors.Errorf("AzureSecurityRuleSet %s of security group %s does not exist", name, nsgSpec.Name))
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get AzureSecurityRuleSet %s of security group %s", name, nsgSpec.Name)
+			}
+			merged.SecurityRules, err = mergeSecurityRules(merged.SecurityRules, ruleSet)
+			if err != nil {
+				return nil, azure.WithTerminalError(errors.Wrapf(err, "failed to add the rules of AzureSecurityRuleSet %s to security group %s", name, nsgSpec.Name))
+			}
+		}
+		specs[i] = &merged
+	}
+	return specs, nil
+}
+
+// mergeSecurityRules returns rules with the rules of ruleSet appended. Azure rejects security groups with two rules
+// with the same name, or with the same priority and direction, so such conflicts are returned as an error.
+func mergeSecurityRules(rules infrav1.SecurityRules, ruleSet *infrav1.AzureSecurityRuleSet) (infrav1.SecurityRules, error) {
+	for _, rule := range ruleSet.Spec.SecurityRules {
+		for _, existing := range rules {
+			if strings.EqualFold(rule.Name, existing.Name) {
+				return nil, errors.Errorf("security rule %s is already defined", rule.Name)
+			}
+			if rule.Priority == existing.Priority && rule.Direction == existing.Direction {
+				return nil, errors.Errorf("security rule %s has the same %s priority %d as security rule %s", rule.Name, rule.Direction, rule.Priority, existing.Name)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
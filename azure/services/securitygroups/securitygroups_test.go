@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"go.uber.org/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -85,6 +86,32 @@ var (
 		DestinationPorts: ptr.To("80"),
 		Action:           infrav1.SecurityRuleActionAllow,
 	}
+	ruleSetNSG = NSGSpec{
+		Name:        "rule-set-nsg",
+		Location:    "test-location",
+		ClusterName: "my-cluster",
+		SecurityRules: infrav1.SecurityRules{
+			securityRule1,
+		},
+		SecurityRuleSets: []string{"shared-rules"},
+		ResourceGroup:    "test-group",
+	}
+	sharedRuleSet = &infrav1.AzureSecurityRuleSet{
+		Spec: infrav1.AzureSecurityRuleSetSpec{
+			SecurityRules: infrav1.SecurityRules{securityRule2},
+		},
+	}
+	conflictingRuleSet = &infrav1.AzureSecurityRuleSet{
+		Spec: infrav1.AzureSecurityRuleSetSpec{
+			SecurityRules: infrav1.SecurityRules{{
+				Name:      "deny_all",
+				Priority:  securityRule1.Priority,
+				Protocol:  infrav1.SecurityGroupProtocolAll,
+				Direction: infrav1.SecurityRuleDirectionInbound,
+				Action:    infrav1.SecurityRuleActionDeny,
+			}},
+		},
+	}
 	errFake      = errors.New("this is an error")
 	notDoneError = azure.NewOperationNotDoneError(&infrav1.Future{})
 )
@@ -170,6 +197,54 @@ func TestReconcileSecurityGroups(t *testing.T) {
 				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, notDoneError)
 			},
 		},
+		{
+			name:          "security group referencing a rule set, should create the security group with the rules of the rule set",
+			expectedError: "",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				mergedNSG := ruleSetNSG
+				mergedNSG.SecurityRules = infrav1.SecurityRules{securityRule1, securityRule2}
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&ruleSetNSG})
+				s.SecurityRuleSet(gomockinternal.AContext(), "shared-rules").Return(sharedRuleSet, nil)
+				s.UpdateAnnotationJSON(annotation, map[string]any{ruleSetNSG.Name: map[string]string{securityRule1.Name: securityRule1.Description, securityRule2.Name: securityRule2.Description}}).Times(1)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &mergedNSG, serviceName).Return(nil, nil)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "security group referencing a missing rule set, should return terminal error",
+			expectedError: "reconcile error that cannot be recovered occurred: AzureSecurityRuleSet shared-rules of security group rule-set-nsg does not exist. Object will not be requeued",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&ruleSetNSG})
+				s.SecurityRuleSet(gomockinternal.AContext(), "shared-rules").Return(nil, apierrors.NewNotFound(infrav1.GroupVersion.WithResource("azuresecurityrulesets").GroupResource(), "shared-rules"))
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "security group failing to get a rule set, should return error",
+			expectedError: "failed to get AzureSecurityRuleSet shared-rules of security group rule-set-nsg: this is an error",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&ruleSetNSG})
+				s.SecurityRuleSet(gomockinternal.AContext(), "shared-rules").Return(nil, errFake)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, gomock.Any())
+			},
+		},
+		{
+			name:          "security group referencing a conflicting rule set, should return terminal error",
+			expectedError: "reconcile error that cannot be recovered occurred: failed to add the rules of AzureSecurityRuleSet shared-rules to security group rule-set-nsg: security rule deny_all has the same Inbound priority 2200 as security rule allow_ssh. Object will not be requeued",
+			expect: func(s *mock_securitygroups.MockNSGScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.IsVnetManaged().Return(true)
+				s.NSGSpecs().Return([]azure.ResourceSpecGetter{&ruleSetNSG})
+				s.SecurityRuleSet(gomockinternal.AContext(), "shared-rules").Return(conflictingRuleSet, nil)
+				s.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, gomock.Any())
+			},
+		},
 		{
 			name:          "vnet is not managed, should skip reconcile",
 			expectedError: "",
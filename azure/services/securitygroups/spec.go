@@ -18,6 +18,7 @@ package securitygroups
 
 import (
 	"context"
+	"maps"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
@@ -32,11 +34,16 @@ import (
 type NSGSpec struct {
 	Name                     string
 	SecurityRules            infrav1.SecurityRules
+	SecurityRuleSets         []string
 	Location                 string
 	ClusterName              string
 	ResourceGroup            string
 	AdditionalTags           infrav1.Tags
 	LastAppliedSecurityRules map[string]any
+	// SubscriptionID and ASGResourceGroup are used to build the IDs of the application security groups that
+	// security rules reference by name.
+	SubscriptionID   string
+	ASGResourceGroup string
 }
 
 // ResourceName returns the name of the security group.
@@ -71,16 +78,22 @@ func (s *NSGSpec) Parameters(_ context.Context, existing any) (any, error) {
 		// Check if the expected rules are present
 		update := false
 
+		changed := map[string]struct{}{}
 		for _, rule := range s.SecurityRules {
-			sdkRule := converters.SecurityRuleToSDK(rule)
+			sdkRule := s.securityRuleToSDK(rule)
 			if !ruleExists(existingNSG.Properties.SecurityRules, sdkRule) {
 				update = true
 				securityRules = append(securityRules, sdkRule)
+				changed[strings.ToLower(rule.Name)] = struct{}{}
 			}
 			newAnnotation[rule.Name] = rule.Description
 		}
 
 		for _, oldRule := range existingNSG.Properties.SecurityRules {
+			// A rule which changed replaces the existing rule of the same name.
+			if _, ok := changed[strings.ToLower(ptr.Deref(oldRule.Name, ""))]; ok {
+				continue
+			}
 			_, tracked := s.LastAppliedSecurityRules[*oldRule.Name]
 			// If rule is owned by CAPZ and applied last, and not found in the new rules, then it has been deleted
 			if _, ok := newAnnotation[*oldRule.Name]; !ok && tracked {
@@ -100,7 +113,7 @@ func (s *NSGSpec) Parameters(_ context.Context, existing any) (any, error) {
 	} else {
 		// new security group
 		for _, rule := range s.SecurityRules {
-			securityRules = append(securityRules, converters.SecurityRuleToSDK(rule))
+			securityRules = append(securityRules, s.securityRuleToSDK(rule))
 		}
 	}
 
@@ -119,6 +132,32 @@ func (s *NSGSpec) Parameters(_ context.Context, existing any) (any, error) {
 	}, nil
 }
 
+// securityRuleToSDK converts a CAPZ security rule to an Azure network security rule, resolving the application security
+// groups it references.
+func (s *NSGSpec) securityRuleToSDK(rule infrav1.SecurityRule) *armnetwork.SecurityRule {
+	sdkRule := converters.SecurityRuleToSDK(rule)
+	sdkRule.Properties.SourceApplicationSecurityGroups = s.applicationSecurityGroups(rule.SourceApplicationSecurityGroups)
+	sdkRule.Properties.DestinationApplicationSecurityGroups = s.applicationSecurityGroups(rule.DestinationApplicationSecurityGroups)
+	return sdkRule
+}
+
+// applicationSecurityGroups returns references to the given application security groups. Names are resolved to
+// the application security groups of the same name in the resource group of the cluster, resource IDs are used as is.
+func (s *NSGSpec) applicationSecurityGroups(asgs []string) []*armnetwork.ApplicationSecurityGroup {
+	if len(asgs) == 0 {
+		return nil
+	}
+	refs := make([]*armnetwork.ApplicationSecurityGroup, 0, len(asgs))
+	for _, asg := range asgs {
+		id := asg
+		if !strings.Contains(asg, "/") {
+			id = azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ASGResourceGroup, asg)
+		}
+		refs = append(refs, &armnetwork.ApplicationSecurityGroup{ID: ptr.To(id)})
+	}
+	return refs
+}
+
 // TODO: review this logic and make sure it is what we want. It seems incorrect to skip rules that don't have a certain protocol, etc.
 func ruleExists(rules []*armnetwork.SecurityRule, rule *armnetwork.SecurityRule) bool {
 	for _, existingRule := range rules {
@@ -138,7 +177,54 @@ func ruleExists(rules []*armnetwork.SecurityRule, rule *armnetwork.SecurityRule)
 			!strings.EqualFold(ptr.Deref(existingRule.Properties.DestinationAddressPrefix, ""), "*") {
 			continue
 		}
+		if ptr.Deref(existingRule.Properties.Priority, 0) != ptr.Deref(rule.Properties.Priority, 0) {
+			continue
+		}
+		if !strings.EqualFold(ptr.Deref(existingRule.Properties.SourceAddressPrefix, ""), ptr.Deref(rule.Properties.SourceAddressPrefix, "")) ||
+			!strings.EqualFold(ptr.Deref(existingRule.Properties.DestinationAddressPrefix, ""), ptr.Deref(rule.Properties.DestinationAddressPrefix, "")) ||
+			!sameSet(derefAll(existingRule.Properties.SourceAddressPrefixes), derefAll(rule.Properties.SourceAddressPrefixes)) {
+			continue
+		}
+		if !sameSet(applicationSecurityGroupIDs(existingRule.Properties.SourceApplicationSecurityGroups), applicationSecurityGroupIDs(rule.Properties.SourceApplicationSecurityGroups)) ||
+			!sameSet(applicationSecurityGroupIDs(existingRule.Properties.DestinationApplicationSecurityGroups), applicationSecurityGroupIDs(rule.Properties.DestinationApplicationSecurityGroups)) {
+			continue
+		}
 		return true
 	}
 	return false
 }
+
+// applicationSecurityGroupIDs returns the IDs of the referenced application security groups.
+func applicationSecurityGroupIDs(asgs []*armnetwork.ApplicationSecurityGroup) []string {
+	ids := make([]string, 0, len(asgs))
+	for _, asg := range asgs {
+		if asg != nil {
+			ids = append(ids, ptr.Deref(asg.ID, ""))
+		}
+	}
+	return ids
+}
+
+// derefAll dereferences a slice of string pointers, skipping nil entries.
+func derefAll(ptrs []*string) []string {
+	values := make([]string, 0, len(ptrs))
+	for _, p := range ptrs {
+		if p != nil {
+			values = append(values, *p)
+		}
+	}
+	return values
+}
+
+// sameSet returns true if a and b contain the same strings, ignoring order, duplicates and case, as Azure may return
+// prefixes and resource IDs in another order and case than they were sent with.
+func sameSet(a, b []string) bool {
+	set := func(values []string) map[string]struct{} {
+		m := make(map[string]struct{}, len(values))
+		for _, v := range values {
+			m[strings.ToLower(v)] = struct{}{}
+		}
+		return m
+	}
+	return maps.Equal(set(a), set(b))
+}
@@ -76,6 +76,18 @@ var (
 		DestinationPorts: ptr.To("80"),
 		Action:           infrav1.SecurityRuleActionDeny,
 	}
+	asgRule = infrav1.SecurityRule{
+		Name:                                 "asg_rule",
+		Description:                          "ASG Rule",
+		Priority:                             520,
+		Protocol:                             infrav1.SecurityGroupProtocolTCP,
+		Direction:                            infrav1.SecurityRuleDirectionInbound,
+		SourceApplicationSecurityGroups:      []string{"my-cluster-node-asg"},
+		SourcePorts:                          ptr.To("*"),
+		DestinationApplicationSecurityGroups: []string{"/subscriptions/456/resourceGroups/other-group/providers/Microsoft.Network/applicationSecurityGroups/other-asg"},
+		DestinationPorts:                     ptr.To("443"),
+		Action:                               infrav1.SecurityRuleActionAllow,
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -351,6 +363,71 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG does not exist and a rule references application security groups",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					asgRule,
+				},
+				ResourceGroup:    "test-group",
+				ClusterName:      "my-cluster",
+				SubscriptionID:   "123",
+				ASGResourceGroup: "cluster-group",
+			},
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				sdkRule := converters.SecurityRuleToSDK(asgRule)
+				sdkRule.Properties.SourceApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{
+					{ID: ptr.To("/subscriptions/123/resourceGroups/cluster-group/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg")},
+				}
+				sdkRule.Properties.DestinationApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{
+					{ID: ptr.To("/subscriptions/456/resourceGroups/other-group/providers/Microsoft.Network/applicationSecurityGroups/other-asg")},
+				}
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.SecurityGroup{}))
+				g.Expect(result.(armnetwork.SecurityGroup).Properties.SecurityRules).To(Equal([]*armnetwork.SecurityRule{sdkRule}))
+			},
+		},
+		{
+			name: "NSG already exists and the application security groups of a rule changed",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					asgRule,
+				},
+				ResourceGroup:    "test-group",
+				ClusterName:      "my-cluster",
+				SubscriptionID:   "123",
+				ASGResourceGroup: "cluster-group",
+			},
+			existing: armnetwork.SecurityGroup{
+				Name: ptr.To("test-nsg"),
+				Properties: &armnetwork.SecurityGroupPropertiesFormat{
+					SecurityRules: []*armnetwork.SecurityRule{
+						func() *armnetwork.SecurityRule {
+							sdkRule := converters.SecurityRuleToSDK(asgRule)
+							sdkRule.Properties.SourceApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{
+								{ID: ptr.To("/subscriptions/123/resourceGroups/cluster-group/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-control-plane-asg")},
+							}
+							sdkRule.Properties.DestinationApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{
+								{ID: ptr.To("/subscriptions/456/resourceGroups/other-group/providers/Microsoft.Network/applicationSecurityGroups/other-asg")},
+							}
+							return sdkRule
+						}(),
+					},
+				},
+			},
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armnetwork.SecurityGroup{}))
+				rules := result.(armnetwork.SecurityGroup).Properties.SecurityRules
+				g.Expect(rules).To(HaveLen(1))
+				g.Expect(rules[0].Properties.SourceApplicationSecurityGroups).To(Equal([]*armnetwork.ApplicationSecurityGroup{
+					{ID: ptr.To("/subscriptions/123/resourceGroups/cluster-group/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg")},
+				}))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -395,6 +472,41 @@ func TestRuleExists(t *testing.T) {
 			rule:     ruleBModified,
 			expected: false,
 		},
+		{
+			name:  "rule exists but its priority has been modified",
+			rules: []*armnetwork.SecurityRule{ruleA, ruleB},
+			rule: withRuleProperties(ruleB, func(p *armnetwork.SecurityRulePropertiesFormat) {
+				p.Priority = ptr.To[int32](200)
+			}),
+			expected: false,
+		},
+		{
+			name:  "rule exists but its source prefixes have been modified",
+			rules: []*armnetwork.SecurityRule{ruleA, ruleB},
+			rule: withRuleProperties(ruleB, func(p *armnetwork.SecurityRulePropertiesFormat) {
+				p.SourceAddressPrefix = nil
+				p.SourceAddressPrefixes = []*string{ptr.To("10.0.0.0/16")}
+			}),
+			expected: false,
+		},
+		{
+			name: "rule exists with the same application security groups in another order and case",
+			rules: []*armnetwork.SecurityRule{ruleA, withRuleProperties(ruleB, func(p *armnetwork.SecurityRulePropertiesFormat) {
+				p.SourceApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{{ID: ptr.To("/SUBSCRIPTIONS/123/asg-2")}, {ID: ptr.To("/subscriptions/123/asg-1")}}
+			})},
+			rule: withRuleProperties(ruleB, func(p *armnetwork.SecurityRulePropertiesFormat) {
+				p.SourceApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{{ID: ptr.To("/subscriptions/123/asg-1")}, {ID: ptr.To("/subscriptions/123/asg-2")}}
+			}),
+			expected: true,
+		},
+		{
+			name:  "rule exists but its destination application security groups have been modified",
+			rules: []*armnetwork.SecurityRule{ruleA, ruleB},
+			rule: withRuleProperties(ruleB, func(p *armnetwork.SecurityRulePropertiesFormat) {
+				p.DestinationApplicationSecurityGroups = []*armnetwork.ApplicationSecurityGroup{{ID: ptr.To("/subscriptions/123/asg-1")}}
+			}),
+			expected: false,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -405,3 +517,10 @@ func TestRuleExists(t *testing.T) {
 		})
 	}
 }
+
+// withRuleProperties returns a copy of rule with its properties modified by modify.
+func withRuleProperties(rule *armnetwork.SecurityRule, modify func(*armnetwork.SecurityRulePropertiesFormat)) *armnetwork.SecurityRule {
+	properties := *rule.Properties
+	modify(&properties)
+	return &armnetwork.SecurityRule{Name: rule.Name, Properties: &properties}
+}
@@ -42,6 +42,15 @@ type SubnetSpec struct {
 	SecurityGroupName string
 	NatGatewayName    string
 	ServiceEndpoints  infrav1.ServiceEndpoints
+	// VNetSubscriptionID is the ID of the subscription of the virtual network, its route tables and its network
+	// security groups. Defaults to SubscriptionID.
+	VNetSubscriptionID string
+	// DisablePrivateLinkServiceNetworkPolicies disables the network policies of private link services in the subnet,
+	// which Azure requires for a private link service to use private IPs of the subnet.
+	DisablePrivateLinkServiceNetworkPolicies bool
+	// ASOSecretName is the name of the secret with the credentials ASO uses to manage the subnet when they are not the
+	// credentials of the cluster.
+	ASOSecretName string
 }
 
 // ResourceRef implements azure.ASOResourceSpecGetter.
@@ -77,7 +86,7 @@ func (s *SubnetSpec) Parameters(_ context.Context, existing *asonetworkv1.Virtua
 	if s.RouteTableName != "" {
 		subnet.Spec.RouteTable = &asonetworkv1.RouteTableSpec_VirtualNetworks_Subnet_SubResourceEmbedded{
 			Reference: &genruntime.ResourceReference{
-				ARMID: azure.RouteTableID(s.SubscriptionID, s.VNetResourceGroup, s.RouteTableName),
+				ARMID: azure.RouteTableID(s.vnetSubscriptionID(), s.VNetResourceGroup, s.RouteTableName),
 			},
 		}
 	}
@@ -93,7 +102,7 @@ func (s *SubnetSpec) Parameters(_ context.Context, existing *asonetworkv1.Virtua
 	if s.SecurityGroupName != "" {
 		subnet.Spec.NetworkSecurityGroup = &asonetworkv1.NetworkSecurityGroupSpec_VirtualNetworks_Subnet_SubResourceEmbedded{
 			Reference: &genruntime.ResourceReference{
-				ARMID: azure.SecurityGroupID(s.SubscriptionID, s.VNetResourceGroup, s.SecurityGroupName),
+				ARMID: azure.SecurityGroupID(s.vnetSubscriptionID(), s.VNetResourceGroup, s.SecurityGroupName),
 			},
 		}
 	}
@@ -104,10 +113,27 @@ func (s *SubnetSpec) Parameters(_ context.Context, existing *asonetworkv1.Virtua
 	}
 	subnet.Spec.ServiceEndpoints = serviceEndpoints
 
+	if s.DisablePrivateLinkServiceNetworkPolicies {
+		subnet.Spec.PrivateLinkServiceNetworkPolicies = ptr.To("Disabled")
+	}
+
 	return subnet, nil
 }
 
+// PerResourceSecretName implements aso.PerResourceSecretNamer.
+func (s *SubnetSpec) PerResourceSecretName() string {
+	return s.ASOSecretName
+}
+
 // WasManaged implements azure.ASOResourceSpecGetter.
 func (s *SubnetSpec) WasManaged(_ *asonetworkv1.VirtualNetworksSubnet) bool {
 	return s.IsVNetManaged
 }
+
+// vnetSubscriptionID returns the ID of the subscription of the virtual network.
+func (s *SubnetSpec) vnetSubscriptionID() string {
+	if s.VNetSubscriptionID != "" {
+		return s.VNetSubscriptionID
+	}
+	return s.SubscriptionID
+}
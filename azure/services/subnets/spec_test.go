@@ -148,6 +148,67 @@ func TestParameters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "virtual network in another subscription",
+			spec: &SubnetSpec{
+				IsVNetManaged:      true,
+				Name:               "subnet",
+				SubscriptionID:     "sub",
+				VNetSubscriptionID: "network-sub",
+				ResourceGroup:      "rg",
+				VNetName:           "vnet",
+				VNetResourceGroup:  "vnet-rg",
+				CIDRs:              []string{"cidr"},
+				RouteTableName:     "routetable",
+				SecurityGroupName:  "securitygroup",
+			},
+			existing: nil,
+			expected: &asonetworkv1.VirtualNetworksSubnet{
+				Spec: asonetworkv1.VirtualNetworksSubnet_Spec{
+					AzureName: "subnet",
+					Owner: &genruntime.KnownResourceReference{
+						Name: "vnet",
+					},
+					AddressPrefixes: []string{"cidr"},
+					AddressPrefix:   ptr.To("cidr"),
+					RouteTable: &asonetworkv1.RouteTableSpec_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Reference: &genruntime.ResourceReference{
+							ARMID: "/subscriptions/network-sub/resourceGroups/vnet-rg/providers/Microsoft.Network/routeTables/routetable",
+						},
+					},
+					NetworkSecurityGroup: &asonetworkv1.NetworkSecurityGroupSpec_VirtualNetworks_Subnet_SubResourceEmbedded{
+						Reference: &genruntime.ResourceReference{
+							ARMID: "/subscriptions/network-sub/resourceGroups/vnet-rg/providers/Microsoft.Network/networkSecurityGroups/securitygroup",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "subnet of a private link service",
+			spec: &SubnetSpec{
+				IsVNetManaged:                            true,
+				Name:                                     "subnet",
+				SubscriptionID:                           "sub",
+				ResourceGroup:                            "rg",
+				VNetName:                                 "vnet",
+				VNetResourceGroup:                        "vnet-rg",
+				CIDRs:                                    []string{"cidr"},
+				DisablePrivateLinkServiceNetworkPolicies: true,
+			},
+			existing: nil,
+			expected: &asonetworkv1.VirtualNetworksSubnet{
+				Spec: asonetworkv1.VirtualNetworksSubnet_Spec{
+					AzureName: "subnet",
+					Owner: &genruntime.KnownResourceReference{
+						Name: "vnet",
+					},
+					AddressPrefixes:                   []string{"cidr"},
+					AddressPrefix:                     ptr.To("cidr"),
+					PrivateLinkServiceNetworkPolicies: ptr.To("Disabled"),
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
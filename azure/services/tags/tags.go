@@ -18,6 +18,7 @@ package tags
 
 import (
 	"context"
+	"path"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/pkg/errors"
@@ -25,6 +26,7 @@ import (
 
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -103,7 +105,9 @@ func (s *Service) Reconcile(ctx context.Context) error {
 					createdOrUpdatedTags[k] = ptr.To(v)
 				}
 
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, armresources.TagsPatchResource{Operation: ptr.To(armresources.TagsPatchOperationMerge), Properties: &armresources.Tags{Tags: createdOrUpdatedTags}}); err != nil {
+				_, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, armresources.TagsPatchResource{Operation: ptr.To(armresources.TagsPatchOperationMerge), Properties: &armresources.Tags{Tags: createdOrUpdatedTags}})
+				s.auditPatch(ctx, tagsSpec.Scope, err)
+				if err != nil {
 					return errors.Wrap(err, "cannot update tags")
 				}
 			}
@@ -114,7 +118,9 @@ func (s *Service) Reconcile(ctx context.Context) error {
 					deletedTags[k] = ptr.To(v)
 				}
 
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, armresources.TagsPatchResource{Operation: ptr.To(armresources.TagsPatchOperationDelete), Properties: &armresources.Tags{Tags: deletedTags}}); err != nil {
+				_, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, armresources.TagsPatchResource{Operation: ptr.To(armresources.TagsPatchOperationDelete), Properties: &armresources.Tags{Tags: deletedTags}})
+				s.auditPatch(ctx, tagsSpec.Scope, err)
+				if err != nil {
 					return errors.Wrap(err, "cannot update tags")
 				}
 			}
@@ -130,6 +136,22 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return nil
 }
 
+// auditPatch records a PATCH of the tags of the resource at scope in the audit log.
+func (s *Service) auditPatch(ctx context.Context, scope string, err error) {
+	if !audit.Enabled() {
+		return
+	}
+	result, message := audit.ResultOf(err, false)
+	audit.Log(ctx, audit.Record{
+		Operation:    audit.OperationPatch,
+		ServiceName:  s.Name(),
+		ResourceID:   scope,
+		ResourceName: path.Base(scope),
+		Result:       result,
+		Error:        message,
+	})
+}
+
 func (s *Service) isResourceManaged(tags map[string]*string) bool {
 	return converters.MapToTags(tags).HasOwned(s.Scope.ClusterName())
 }
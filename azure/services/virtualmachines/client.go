@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcehealth/armresourcehealth"
 	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
 
@@ -33,8 +34,9 @@ import (
 type (
 	// AzureClient contains the Azure go-sdk Client.
 	AzureClient struct {
-		virtualmachines *armcompute.VirtualMachinesClient
-		apiCallTimeout  time.Duration
+		virtualmachines      *armcompute.VirtualMachinesClient
+		availabilityStatuses *armresourcehealth.AvailabilityStatusesClient
+		apiCallTimeout       time.Duration
 	}
 
 	// Client provides operations on Azure virtual machine resources.
@@ -42,6 +44,10 @@ type (
 		Get(context.Context, azure.ResourceSpecGetter) (any, error)
 		CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, parameters any) (result any, poller *runtime.Poller[armcompute.VirtualMachinesClientCreateOrUpdateResponse], err error)
 		DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armcompute.VirtualMachinesClientDeleteResponse], err error)
+		InstanceView(context.Context, azure.ResourceSpecGetter) (armcompute.VirtualMachineInstanceView, error)
+		ResizeAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, size string) (poller *runtime.Poller[armcompute.VirtualMachinesClientUpdateResponse], err error)
+		StartAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armcompute.VirtualMachinesClientStartResponse], err error)
+		AvailabilityStatus(ctx context.Context, resourceID string) (armresourcehealth.AvailabilityStatus, error)
 	}
 )
 
@@ -49,7 +55,7 @@ var _ Client = &AzureClient{}
 
 // NewClient creates a VMs client from an authorizer.
 func NewClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*AzureClient, error) {
-	opts, err := azure.ARMClientOptions(auth.CloudEnvironment())
+	opts, err := azure.ARMClientOptionsForAuthorizer(auth)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create virtualmachines client options")
 	}
@@ -57,10 +63,14 @@ func NewClient(auth azure.Authorizer, apiCallTimeout time.Duration) (*AzureClien
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create armcompute client factory")
 	}
-	return &AzureClient{factory.NewVirtualMachinesClient(), apiCallTimeout}, nil
+	healthFactory, err := armresourcehealth.NewClientFactory(auth.SubscriptionID(), auth.Token(), opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create armresourcehealth client factory")
+	}
+	return &AzureClient{factory.NewVirtualMachinesClient(), healthFactory.NewAvailabilityStatusesClient(), apiCallTimeout}, nil
 }
 
-// Get retrieves information about the model view or the instance view of a virtual machine.
+// Get retrieves information about the model view of a virtual machine.
 func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result any, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Get")
 	defer done()
@@ -72,6 +82,18 @@ func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	return resp.VirtualMachine, nil
 }
 
+// InstanceView retrieves the run-time state of a virtual machine.
+func (ac *AzureClient) InstanceView(ctx context.Context, spec azure.ResourceSpecGetter) (armcompute.VirtualMachineInstanceView, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.InstanceView")
+	defer done()
+
+	resp, err := ac.virtualmachines.InstanceView(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	if err != nil {
+		return armcompute.VirtualMachineInstanceView{}, err
+	}
+	return resp.VirtualMachineInstanceView, nil
+}
+
 // CreateOrUpdateAsync creates or updates a virtual machine asynchronously.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Poller which can be used to track the ongoing
 // progress of the operation.
@@ -133,3 +155,76 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	// if the operation completed, return a nil poller.
 	return nil, err
 }
+
+// ResizeAsync changes the size of a virtual machine asynchronously. It sends a PATCH request to Azure and if accepted
+// without error, the func will return a Poller which can be used to track the ongoing progress of the operation.
+func (ac *AzureClient) ResizeAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, size string) (poller *runtime.Poller[armcompute.VirtualMachinesClientUpdateResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Resize")
+	defer done()
+
+	update := armcompute.VirtualMachineUpdate{
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{
+				VMSize: ptr.To(armcompute.VirtualMachineSizeTypes(size)),
+			},
+		},
+	}
+	opts := &armcompute.VirtualMachinesClientBeginUpdateOptions{ResumeToken: resumeToken}
+	poller, err = ac.virtualmachines.BeginUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), update, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ac.apiCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// if an error occurs, return the Poller.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
+
+// StartAsync starts a deallocated virtual machine asynchronously. It sends a POST request to Azure and if accepted
+// without error, the func will return a Poller which can be used to track the ongoing progress of the operation.
+func (ac *AzureClient) StartAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (poller *runtime.Poller[armcompute.VirtualMachinesClientStartResponse], err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Start")
+	defer done()
+
+	opts := &armcompute.VirtualMachinesClientBeginStartOptions{ResumeToken: resumeToken}
+	poller, err = ac.virtualmachines.BeginStart(ctx, spec.ResourceGroupName(), spec.ResourceName(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ac.apiCallTimeout)
+	defer cancel()
+
+	pollOpts := &runtime.PollUntilDoneOptions{Frequency: async.DefaultPollerFrequency}
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	if err != nil {
+		// if an error occurs, return the Poller.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return poller, err
+	}
+
+	// if the operation completed, return a nil poller.
+	return nil, err
+}
+
+// AvailabilityStatus gets the Resource Health availability status of a virtual machine.
+func (ac *AzureClient) AvailabilityStatus(ctx context.Context, resourceID string) (armresourcehealth.AvailabilityStatus, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.AvailabilityStatus")
+	defer done()
+
+	resp, err := ac.availabilityStatuses.GetByResource(ctx, resourceID, nil)
+	if err != nil {
+		return armresourcehealth.AvailabilityStatus{}, err
+	}
+	return resp.AvailabilityStatus, nil
+}
@@ -31,6 +31,7 @@ import (
 
 	runtime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	armcompute "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	armresourcehealth "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcehealth/armresourcehealth"
 	gomock "go.uber.org/mock/gomock"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 )
@@ -59,6 +60,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// AvailabilityStatus mocks base method.
+func (m *MockClient) AvailabilityStatus(ctx context.Context, resourceID string) (armresourcehealth.AvailabilityStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilityStatus", ctx, resourceID)
+	ret0, _ := ret[0].(armresourcehealth.AvailabilityStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AvailabilityStatus indicates an expected call of AvailabilityStatus.
+func (mr *MockClientMockRecorder) AvailabilityStatus(ctx, resourceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilityStatus", reflect.TypeOf((*MockClient)(nil).AvailabilityStatus), ctx, resourceID)
+}
+
 // CreateOrUpdateAsync mocks base method.
 func (m *MockClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string, parameters any) (any, *runtime.Poller[armcompute.VirtualMachinesClientCreateOrUpdateResponse], error) {
 	m.ctrl.T.Helper()
@@ -104,3 +120,48 @@ func (mr *MockClientMockRecorder) Get(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), arg0, arg1)
 }
+
+// InstanceView mocks base method.
+func (m *MockClient) InstanceView(arg0 context.Context, arg1 azure.ResourceSpecGetter) (armcompute.VirtualMachineInstanceView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceView", arg0, arg1)
+	ret0, _ := ret[0].(armcompute.VirtualMachineInstanceView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstanceView indicates an expected call of InstanceView.
+func (mr *MockClientMockRecorder) InstanceView(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceView", reflect.TypeOf((*MockClient)(nil).InstanceView), arg0, arg1)
+}
+
+// ResizeAsync mocks base method.
+func (m *MockClient) ResizeAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken, size string) (*runtime.Poller[armcompute.VirtualMachinesClientUpdateResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeAsync", ctx, spec, resumeToken, size)
+	ret0, _ := ret[0].(*runtime.Poller[armcompute.VirtualMachinesClientUpdateResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResizeAsync indicates an expected call of ResizeAsync.
+func (mr *MockClientMockRecorder) ResizeAsync(ctx, spec, resumeToken, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeAsync", reflect.TypeOf((*MockClient)(nil).ResizeAsync), ctx, spec, resumeToken, size)
+}
+
+// StartAsync mocks base method.
+func (m *MockClient) StartAsync(ctx context.Context, spec azure.ResourceSpecGetter, resumeToken string) (*runtime.Poller[armcompute.VirtualMachinesClientStartResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAsync", ctx, spec, resumeToken)
+	ret0, _ := ret[0].(*runtime.Poller[armcompute.VirtualMachinesClientStartResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAsync indicates an expected call of StartAsync.
+func (mr *MockClientMockRecorder) StartAsync(ctx, spec, resumeToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAsync", reflect.TypeOf((*MockClient)(nil).StartAsync), ctx, spec, resumeToken)
+}
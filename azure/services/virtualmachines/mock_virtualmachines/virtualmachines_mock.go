@@ -61,6 +61,20 @@ func (m *MockVMScope) EXPECT() *MockVMScopeMockRecorder {
 	return m.recorder
 }
 
+// AllocationRetryPending mocks base method.
+func (m *MockVMScope) AllocationRetryPending() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocationRetryPending")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AllocationRetryPending indicates an expected call of AllocationRetryPending.
+func (mr *MockVMScopeMockRecorder) AllocationRetryPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocationRetryPending", reflect.TypeOf((*MockVMScope)(nil).AllocationRetryPending))
+}
+
 // BaseURI mocks base method.
 func (m *MockVMScope) BaseURI() string {
 	m.ctrl.T.Helper()
@@ -75,6 +89,20 @@ func (mr *MockVMScopeMockRecorder) BaseURI() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockVMScope)(nil).BaseURI))
 }
 
+// CapacityFallbackTargets mocks base method.
+func (m *MockVMScope) CapacityFallbackTargets() []azure.AllocationTarget {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CapacityFallbackTargets")
+	ret0, _ := ret[0].([]azure.AllocationTarget)
+	return ret0
+}
+
+// CapacityFallbackTargets indicates an expected call of CapacityFallbackTargets.
+func (mr *MockVMScopeMockRecorder) CapacityFallbackTargets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CapacityFallbackTargets", reflect.TypeOf((*MockVMScope)(nil).CapacityFallbackTargets))
+}
+
 // ClientID mocks base method.
 func (m *MockVMScope) ClientID() string {
 	m.ctrl.T.Helper()
@@ -211,6 +239,30 @@ func (mr *MockVMScopeMockRecorder) SetAddresses(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAddresses", reflect.TypeOf((*MockVMScope)(nil).SetAddresses), arg0)
 }
 
+// SetAllocationFailed mocks base method.
+func (m *MockVMScope) SetAllocationFailed(arg0 string, arg1 *azure.AllocationTarget) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAllocationFailed", arg0, arg1)
+}
+
+// SetAllocationFailed indicates an expected call of SetAllocationFailed.
+func (mr *MockVMScopeMockRecorder) SetAllocationFailed(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllocationFailed", reflect.TypeOf((*MockVMScope)(nil).SetAllocationFailed), arg0, arg1)
+}
+
+// SetAllocationRetryPending mocks base method.
+func (m *MockVMScope) SetAllocationRetryPending(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAllocationRetryPending", arg0)
+}
+
+// SetAllocationRetryPending indicates an expected call of SetAllocationRetryPending.
+func (mr *MockVMScopeMockRecorder) SetAllocationRetryPending(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllocationRetryPending", reflect.TypeOf((*MockVMScope)(nil).SetAllocationRetryPending), arg0)
+}
+
 // SetAnnotation mocks base method.
 func (m *MockVMScope) SetAnnotation(arg0, arg1 string) {
 	m.ctrl.T.Helper()
@@ -259,6 +311,18 @@ func (mr *MockVMScopeMockRecorder) SetProviderID(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderID", reflect.TypeOf((*MockVMScope)(nil).SetProviderID), arg0)
 }
 
+// SetSpotVMEvicted mocks base method.
+func (m *MockVMScope) SetSpotVMEvicted(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSpotVMEvicted", arg0)
+}
+
+// SetSpotVMEvicted indicates an expected call of SetSpotVMEvicted.
+func (mr *MockVMScopeMockRecorder) SetSpotVMEvicted(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSpotVMEvicted", reflect.TypeOf((*MockVMScope)(nil).SetSpotVMEvicted), arg0)
+}
+
 // SetVMState mocks base method.
 func (m *MockVMScope) SetVMState(arg0 v1beta1.ProvisioningState) {
 	m.ctrl.T.Helper()
@@ -62,6 +62,15 @@ type VMSpec struct {
 	Image                       *infrav1.Image
 	BootstrapData               string
 	ProviderID                  string
+	SubscriptionID              string
+	// SpotEvictionRecovery is true when an evicted Spot VM is recovered rather than left deallocated.
+	SpotEvictionRecovery bool
+	// RecreateFromDisks is true when a VM deleted to recover it from an eviction is recreated by attaching its
+	// existing OS and data disks.
+	RecreateFromDisks bool
+	// CapacityFallback is true when the VM falls back to another failure domain or size when Azure fails to
+	// allocate it.
+	CapacityFallback bool
 }
 
 // ResourceName returns the name of the virtual machine.
@@ -90,7 +99,7 @@ func (s *VMSpec) Parameters(_ context.Context, existing any) (params any, err er
 	}
 
 	// VM got deleted outside of capz, do not recreate it as Machines are immutable.
-	if s.ProviderID != "" {
+	if s.ProviderID != "" && !s.RecreateFromDisks {
 		return nil, azure.VMDeletedError{ProviderID: s.ProviderID}
 	}
 
@@ -108,9 +117,13 @@ func (s *VMSpec) Parameters(_ context.Context, existing any) (params any, err er
 		return nil, err
 	}
 
-	osProfile, err := s.generateOSProfile()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate OS Profile")
+	var osProfile *armcompute.OSProfile
+	// The OS profile is part of the existing OS disk and cannot be set when attaching it.
+	if !s.RecreateFromDisks {
+		osProfile, err = s.generateOSProfile()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate OS Profile")
+		}
 	}
 
 	priority, evictionPolicy, billingProfile, err := converters.GetSpotVMOptions(s.SpotVMOptions, s.OSDisk.DiffDiskSettings)
@@ -260,6 +273,11 @@ func (s *VMSpec) generateStorageProfile() (*armcompute.StorageProfile, error) {
 	}
 	storageProfile.DataDisks = dataDisks
 
+	if s.RecreateFromDisks {
+		s.attachExistingDisks(storageProfile)
+		return storageProfile, nil
+	}
+
 	imageRef, err := converters.ImageToSDK(s.Image)
 	if err != nil {
 		return nil, err
@@ -270,6 +288,31 @@ func (s *VMSpec) generateStorageProfile() (*armcompute.StorageProfile, error) {
 	return storageProfile, nil
 }
 
+// attachExistingDisks changes storageProfile to attach the OS and data disks left behind by a previous VM with the
+// same name instead of creating new ones.
+func (s *VMSpec) attachExistingDisks(storageProfile *armcompute.StorageProfile) {
+	// The SKU and encryption of an attached disk are those of the existing disk, only its security profile is needed
+	// to attach the OS disk of a confidential VM.
+	attach := func(name *string, managedDisk *armcompute.ManagedDiskParameters) *armcompute.ManagedDiskParameters {
+		params := &armcompute.ManagedDiskParameters{
+			ID: ptr.To(azure.DiskID(s.SubscriptionID, s.ResourceGroup, ptr.Deref(name, ""))),
+		}
+		if managedDisk != nil {
+			params.SecurityProfile = managedDisk.SecurityProfile
+		}
+		return params
+	}
+
+	storageProfile.OSDisk.CreateOption = ptr.To(armcompute.DiskCreateOptionTypesAttach)
+	storageProfile.OSDisk.DiskSizeGB = nil
+	storageProfile.OSDisk.ManagedDisk = attach(storageProfile.OSDisk.Name, storageProfile.OSDisk.ManagedDisk)
+	for _, disk := range storageProfile.DataDisks {
+		disk.CreateOption = ptr.To(armcompute.DiskCreateOptionTypesAttach)
+		disk.DiskSizeGB = nil
+		disk.ManagedDisk = attach(disk.Name, disk.ManagedDisk)
+	}
+}
+
 func (s *VMSpec) generateOSProfile() (*armcompute.OSProfile, error) {
 	sshKey, err := base64.StdEncoding.DecodeString(s.SSHKeyData)
 	if err != nil {
@@ -275,6 +275,51 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: azure.VMDeletedError{ProviderID: "fake/vm/id"}.Error(),
 		},
+		{
+			name: "recreates a vm deleted to recover it from a spot eviction from its existing disks",
+			spec: &VMSpec{
+				Name:              "my-vm",
+				ResourceGroup:     "my-rg",
+				SubscriptionID:    "123",
+				Role:              infrav1.Node,
+				NICIDs:            []string{"my-nic"},
+				SSHKeyData:        "fakesshpublickey",
+				Size:              "Standard_D2v3",
+				Image:             &infrav1.Image{ID: ptr.To("fake-image-id")},
+				ProviderID:        "fake/vm/id",
+				RecreateFromDisks: true,
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: ptr.To[int32](128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+					},
+				},
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix: "etcddisk",
+						DiskSizeGB: 64,
+						Lun:        ptr.To[int32](0),
+					},
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result any) {
+				g.Expect(result).To(BeAssignableToTypeOf(armcompute.VirtualMachine{}))
+				vm := result.(armcompute.VirtualMachine)
+				g.Expect(vm.Properties.OSProfile).To(BeNil())
+				g.Expect(vm.Properties.StorageProfile.ImageReference).To(BeNil())
+				g.Expect(vm.Properties.StorageProfile.OSDisk.CreateOption).To(Equal(ptr.To(armcompute.DiskCreateOptionTypesAttach)))
+				g.Expect(vm.Properties.StorageProfile.OSDisk.DiskSizeGB).To(BeNil())
+				g.Expect(vm.Properties.StorageProfile.OSDisk.ManagedDisk.ID).To(Equal(ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/disks/my-vm_OSDisk")))
+				g.Expect(vm.Properties.StorageProfile.OSDisk.ManagedDisk.StorageAccountType).To(BeNil())
+				g.Expect(vm.Properties.StorageProfile.DataDisks).To(HaveLen(1))
+				g.Expect(vm.Properties.StorageProfile.DataDisks[0].CreateOption).To(Equal(ptr.To(armcompute.DiskCreateOptionTypesAttach)))
+				g.Expect(vm.Properties.StorageProfile.DataDisks[0].ManagedDisk.ID).To(Equal(ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/disks/my-vm_etcddisk")))
+			},
+			expectedError: "",
+		},
 		{
 			name: "can create a vm with system assigned identity ",
 			spec: &VMSpec{
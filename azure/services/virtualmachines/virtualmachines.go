@@ -18,10 +18,14 @@ package virtualmachines
 
 import (
 	"context"
+	"path"
+	"slices"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcehealth/armresourcehealth"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
@@ -32,8 +36,11 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -51,18 +58,27 @@ type VMScope interface {
 	SetAddresses([]corev1.NodeAddress)
 	SetVMState(infrav1.ProvisioningState)
 	SetConditionFalse(clusterv1beta1.ConditionType, string, clusterv1beta1.ConditionSeverity, string)
+	SetSpotVMEvicted(bool)
+	CapacityFallbackTargets() []azure.AllocationTarget
+	SetAllocationFailed(string, *azure.AllocationTarget)
+	AllocationRetryPending() bool
+	SetAllocationRetryPending(bool)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VMScope
 	async.Reconciler
+	// diskReconciler deletes the disks of a VM which failed to be allocated.
+	diskReconciler   async.Reconciler
 	interfacesGetter async.Getter
 	publicIPsGetter  async.Getter
+	client           Client
+	resourceSKUCache *resourceskus.Cache
 }
 
 // New creates a new service.
-func New(scope VMScope) (*Service, error) {
+func New(scope VMScope, skuCache *resourceskus.Cache) (*Service, error) {
 	Client, err := NewClient(scope, scope.DefaultedAzureCallTimeout())
 	if err != nil {
 		return nil, err
@@ -75,12 +91,20 @@ func New(scope VMScope) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	disksClient, err := disks.NewClient(scope, scope.DefaultedAzureCallTimeout())
+	if err != nil {
+		return nil, err
+	}
 	return &Service{
 		Scope:            scope,
 		interfacesGetter: interfacesSvc,
 		publicIPsGetter:  publicIPsSvc,
+		client:           Client,
+		resourceSKUCache: skuCache,
 		Reconciler: async.New[armcompute.VirtualMachinesClientCreateOrUpdateResponse,
 			armcompute.VirtualMachinesClientDeleteResponse](scope, Client, Client),
+		diskReconciler: async.New[armcompute.DisksClientCreateOrUpdateResponse,
+			armcompute.DisksClientDeleteResponse](scope, nil, disksClient),
 	}, nil
 }
 
@@ -102,10 +126,24 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
+	fallbackSpec, ok := vmSpec.(*VMSpec)
+	capacityFallback := ok && fallbackSpec.CapacityFallback
+	if capacityFallback && s.Scope.AllocationRetryPending() {
+		if err := s.deleteFailedAllocation(ctx, fallbackSpec); err != nil {
+			return err
+		}
+		s.Scope.SetAllocationRetryPending(false)
+	}
+
 	result, err := s.CreateOrUpdateResource(ctx, vmSpec, serviceName)
 	s.Scope.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, err)
 	// Set the DiskReady condition here since the disk gets created with the VM.
 	s.Scope.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, err)
+	if capacityFallback {
+		if reason := azure.AllocationFailureCode(err); reason != "" {
+			return s.fallBackFromAllocationFailure(ctx, fallbackSpec, reason, err)
+		}
+	}
 	if err == nil && result != nil {
 		vm, ok := result.(armcompute.VirtualMachine)
 		if !ok {
@@ -134,6 +172,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 
 		s.checkUserAssignedIdentities(spec.UserAssignedIdentities, infraVM.UserAssignedIdentities)
+
+		if spec.SpotVMOptions != nil || spec.SpotEvictionRecovery {
+			return s.reconcileSpotEviction(ctx, vm, spec)
+		}
 	}
 	return err
 }
@@ -179,6 +221,271 @@ func (s *Service) checkUserAssignedIdentities(specIdentities []infrav1.UserAssig
 	}
 }
 
+// reconcileSpotEviction reports whether a Spot VM has been evicted. When spot eviction recovery is enabled, an evicted
+// VM is started again, after being resized to a fallback VM size if needed, or is deleted to be recreated from its
+// disks with regular priority.
+func (s *Service) reconcileSpotEviction(ctx context.Context, vm armcompute.VirtualMachine, spec *VMSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.reconcileSpotEviction")
+	defer done()
+
+	// The priority of a VM can't be changed, so a VM recreated with regular priority is never evicted.
+	if ptr.Deref(vm.Properties.Priority, "") != armcompute.VirtualMachinePriorityTypesSpot {
+		s.Scope.SetSpotVMEvicted(false)
+		return nil
+	}
+
+	// The power state of a VM being recovered changes while it starts, so a recovery in progress is resumed first.
+	if spec.SpotEvictionRecovery && s.spotRecoveryInProgress(spec) {
+		return s.recoverEvictedVM(ctx, vm)
+	}
+
+	// The power state of the VM is only in its instance view, which is only fetched for Spot VMs.
+	instanceView, err := s.client.InstanceView(ctx, spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get VM instance view")
+	}
+	if !isDeallocated(instanceView) {
+		s.Scope.SetSpotVMEvicted(false)
+		return nil
+	}
+	evicted, err := s.isEvicted(ctx, vm)
+	if err != nil {
+		return err
+	}
+	s.Scope.SetSpotVMEvicted(evicted)
+	if !evicted || !spec.SpotEvictionRecovery {
+		return nil
+	}
+	return s.recoverEvictedVM(ctx, vm)
+}
+
+// isEvicted returns true if Resource Health reports that the platform made the deallocated VM unavailable, which tells
+// a Spot eviction apart from a deallocation requested by a user.
+func (s *Service) isEvicted(ctx context.Context, vm armcompute.VirtualMachine) (bool, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.isEvicted")
+	defer done()
+
+	status, err := s.client.AvailabilityStatus(ctx, ptr.Deref(vm.ID, ""))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get VM availability status")
+	}
+	properties := status.Properties
+	if properties == nil || ptr.Deref(properties.AvailabilityState, "") != armresourcehealth.AvailabilityStateValuesUnavailable {
+		return false, nil
+	}
+	return strings.EqualFold(ptr.Deref(properties.Context, ""), "Platform") ||
+		strings.EqualFold(ptr.Deref(properties.HealthEventCause, ""), "PlatformInitiated"), nil
+}
+
+// spotRecoveryInProgress returns true if a resize or a start of an evicted VM has not completed yet.
+func (s *Service) spotRecoveryInProgress(spec *VMSpec) bool {
+	return s.Scope.GetLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture) != nil ||
+		s.Scope.GetLongRunningOperationState(spec.Name, serviceName, infrav1.PostFuture) != nil
+}
+
+// recoverEvictedVM starts an evicted Spot VM again, after resizing it to its fallback VM size if needed, or deletes
+// it to be recreated with regular priority.
+func (s *Service) recoverEvictedVM(ctx context.Context, vm armcompute.VirtualMachine) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.recoverEvictedVM")
+	defer done()
+
+	// Recording the eviction may have changed the size and priority the VM should be recovered with.
+	vmSpec, ok := s.Scope.VMSpec().(*VMSpec)
+	if !ok {
+		return errors.Errorf("%T is not a valid VM spec", s.Scope.VMSpec())
+	}
+	if vmSpec.SpotVMOptions == nil {
+		return s.deleteForRegularPriority(ctx, vm, vmSpec)
+	}
+
+	size := ptr.Deref(vm.Properties.HardwareProfile.VMSize, "")
+	resizing := s.Scope.GetLongRunningOperationState(vmSpec.Name, serviceName, infrav1.PatchFuture) != nil
+	if resizing || !strings.EqualFold(string(size), vmSpec.Size) {
+		log.Info("resizing evicted Spot VM", "from", size, "to", vmSpec.Size)
+		err := pollSpotRecovery(ctx, s, vmSpec, ptr.Deref(vm.ID, ""), infrav1.PatchFuture, audit.OperationPatch,
+			func(resumeToken string) (*runtime.Poller[armcompute.VirtualMachinesClientUpdateResponse], error) {
+				return s.client.ResizeAsync(ctx, vmSpec, resumeToken, vmSpec.Size)
+			})
+		if azure.IsOperationNotDoneError(err) {
+			return err
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to resize evicted Spot VM to %s", vmSpec.Size)
+		}
+	}
+
+	log.Info("starting evicted Spot VM")
+	err := pollSpotRecovery(ctx, s, vmSpec, ptr.Deref(vm.ID, ""), infrav1.PostFuture, audit.OperationPost,
+		func(resumeToken string) (*runtime.Poller[armcompute.VirtualMachinesClientStartResponse], error) {
+			return s.client.StartAsync(ctx, vmSpec, resumeToken)
+		})
+	if azure.IsOperationNotDoneError(err) {
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to start evicted Spot VM")
+	}
+	s.Scope.SetSpotVMEvicted(false)
+	return nil
+}
+
+// pollSpotRecovery sends or resumes a long-running operation recovering an evicted VM. An operation which does not
+// complete in time is saved as a future of the given type and reported with an OperationNotDone error, so that the
+// next reconciliation resumes it.
+func pollSpotRecovery[T any](ctx context.Context, s *Service, spec *VMSpec, resourceID, futureType string, operation audit.Operation, send func(resumeToken string) (*runtime.Poller[T], error)) error {
+	resumeToken := ""
+	if future := s.Scope.GetLongRunningOperationState(spec.Name, serviceName, futureType); future != nil {
+		t, err := converters.FutureToResumeToken(*future)
+		if err != nil {
+			s.Scope.DeleteLongRunningOperationState(spec.Name, serviceName, futureType)
+			return errors.Wrap(err, "could not decode future data, resetting long-running operation state")
+		}
+		resumeToken = t
+	}
+
+	poller, err := send(resumeToken)
+	inProgress := poller != nil && azure.IsContextDeadlineExceededOrCanceledError(err)
+	if resumeToken == "" || !inProgress {
+		s.auditAction(ctx, operation, spec.ResourceGroup, resourceID, err, inProgress)
+	}
+	if inProgress {
+		future, err := converters.PollerToFuture(poller, futureType, serviceName, spec.Name, spec.ResourceGroup)
+		if err != nil {
+			return err
+		}
+		s.Scope.SetLongRunningOperationState(future)
+		return azure.WithTransientError(azure.NewOperationNotDoneError(future), s.Scope.DefaultedReconcilerRequeue())
+	}
+
+	// Even if the operation ended with an error, clear out any lingering state to try the operation again.
+	s.Scope.DeleteLongRunningOperationState(spec.Name, serviceName, futureType)
+	return err
+}
+
+// deleteForRegularPriority deletes an evicted Spot VM, keeping its disks, so that the next reconciliation recreates it
+// with regular priority.
+func (s *Service) deleteForRegularPriority(ctx context.Context, vm armcompute.VirtualMachine, spec *VMSpec) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.deleteForRegularPriority")
+	defer done()
+
+	// Disks deleted along with the VM can't be attached to the new one.
+	if storageProfile := vm.Properties.StorageProfile; storageProfile != nil {
+		if osDisk := storageProfile.OSDisk; osDisk != nil && ptr.Deref(osDisk.DeleteOption, "") == armcompute.DiskDeleteOptionTypesDelete {
+			return errors.Errorf("cannot recreate VM %s with regular priority: OS disk is deleted with the VM", spec.Name)
+		}
+		for _, dataDisk := range storageProfile.DataDisks {
+			if dataDisk != nil && ptr.Deref(dataDisk.DeleteOption, "") == armcompute.DiskDeleteOptionTypesDelete {
+				return errors.Errorf("cannot recreate VM %s with regular priority: data disk %s is deleted with the VM", spec.Name, ptr.Deref(dataDisk.Name, ""))
+			}
+		}
+	}
+
+	log.Info("deleting evicted Spot VM to recreate it with regular priority")
+	if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+		return err
+	}
+	return azure.WithTransientError(errors.Errorf("deleted evicted Spot VM %s to recreate it with regular priority", spec.Name), s.Scope.DefaultedReconcilerRequeue())
+}
+
+// fallBackFromAllocationFailure records that Azure failed to allocate the VM for the given reason and, if the
+// capacity fallback of the VM allows another failure domain or size which can host it, deletes what was created of
+// the VM to retry the allocation with them.
+func (s *Service) fallBackFromAllocationFailure(ctx context.Context, spec *VMSpec, reason string, allocationErr error) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.fallBackFromAllocationFailure")
+	defer done()
+
+	var next *azure.AllocationTarget
+	for _, target := range s.Scope.CapacityFallbackTargets() {
+		// Zonal disks of a VM recreated from its disks can't be attached in another zone.
+		if spec.RecreateFromDisks && target.FailureDomain != spec.Zone {
+			continue
+		}
+		if target.FailureDomain != "" {
+			zones, err := s.resourceSKUCache.GetZonesWithVMSize(ctx, target.VMSize, spec.Location)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get zones with VM size %s", target.VMSize)
+			}
+			if !slices.Contains(zones, target.FailureDomain) {
+				continue
+			}
+		}
+		next = &target
+		break
+	}
+
+	s.Scope.SetAllocationFailed(reason, next)
+	if next == nil {
+		return errors.Wrap(allocationErr, "no capacity fallback left to retry the VM allocation with")
+	}
+
+	log.Info("retrying VM allocation", "reason", reason, "failureDomain", next.FailureDomain, "size", next.VMSize)
+	if err := s.deleteFailedAllocation(ctx, spec); err != nil {
+		return err
+	}
+	s.Scope.SetAllocationRetryPending(false)
+	return azure.WithTransientError(errors.Errorf("failed to allocate VM with reason %s, retrying in failure domain %q with VM size %s", reason, next.FailureDomain, next.VMSize), s.Scope.DefaultedReconcilerRequeue())
+}
+
+// deleteFailedAllocation deletes a VM which Azure failed to allocate, if it was created in a failed state, along with
+// any disk created for it, since neither can be moved to another failure domain. The disks of a VM recreated from its
+// disks are kept, since they hold the only copy of its state.
+func (s *Service) deleteFailedAllocation(ctx context.Context, spec *VMSpec) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.deleteFailedAllocation")
+	defer done()
+
+	if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+		return err
+	}
+	if spec.RecreateFromDisks {
+		return nil
+	}
+
+	diskNames := []string{azure.GenerateOSDiskName(spec.Name)}
+	for _, disk := range spec.DataDisks {
+		diskNames = append(diskNames, azure.GenerateDataDiskName(spec.Name, disk.NameSuffix))
+	}
+	// The disks are deleted asynchronously, so a deletion which does not complete in time is resumed by the next
+	// reconciliation while the allocation retry is pending.
+	var result error
+	for _, name := range diskNames {
+		diskSpec := &disks.DiskSpec{Name: name, ResourceGroup: spec.ResourceGroup}
+		if err := s.diskReconciler.DeleteResource(ctx, diskSpec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+	return result
+}
+
+// auditAction records an operation made outside of the async reconciler in the audit log.
+func (s *Service) auditAction(ctx context.Context, operation audit.Operation, resourceGroup, resourceID string, err error, inProgress bool) {
+	if !audit.Enabled() {
+		return
+	}
+	result, message := audit.ResultOf(err, inProgress)
+	audit.Log(ctx, audit.Record{
+		Operation:     operation,
+		ServiceName:   serviceName,
+		ResourceID:    resourceID,
+		ResourceGroup: resourceGroup,
+		ResourceName:  path.Base(resourceID),
+		Result:        result,
+		Error:         message,
+	})
+}
+
+// isDeallocated returns true if the instance view of the VM reports that it is deallocated, which is how Azure leaves a
+// Spot VM evicted with the Deallocate eviction policy.
+func isDeallocated(instanceView armcompute.VirtualMachineInstanceView) bool {
+	for _, status := range instanceView.Statuses {
+		if status != nil && ptr.Deref(status.Code, "") == "PowerState/deallocated" {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) getAddresses(ctx context.Context, vm armcompute.VirtualMachine, rgName string) ([]corev1.NodeAddress, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.getAddresses")
 	defer done()
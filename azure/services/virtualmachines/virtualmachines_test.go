@@ -17,14 +17,19 @@ limitations under the License.
 package virtualmachines
 
 import (
+	"context"
+	"encoding/base64"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcehealth/armresourcehealth"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
@@ -32,9 +37,12 @@ import (
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines/mock_virtualmachines"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
@@ -240,6 +248,468 @@ func TestReconcileVM(t *testing.T) {
 	}
 }
 
+func allocationError(code string) *azcore.ResponseError {
+	return &azcore.ResponseError{
+		ErrorCode: code,
+		RawResponse: &http.Response{
+			Body:       io.NopCloser(strings.NewReader("#: " + code)),
+			StatusCode: http.StatusConflict,
+		},
+	}
+}
+
+func TestReconcileVMCapacityFallback(t *testing.T) {
+	fallbackSpec := func() *VMSpec {
+		spec := fakeVMSpec
+		spec.Zone = "1"
+		spec.CapacityFallback = true
+		spec.DataDisks = []infrav1.DataDisk{{NameSuffix: "etcddisk"}}
+		return &spec
+	}
+	recreateSpec := func() *VMSpec {
+		spec := fallbackSpec()
+		spec.RecreateFromDisks = true
+		return spec
+	}
+	zonesOf := func(zones ...string) []*armcompute.ResourceSKULocationInfo {
+		return []*armcompute.ResourceSKULocationInfo{
+			{Location: ptr.To("test-location"), Zones: azure.PtrSlice(&zones)},
+		}
+	}
+	skuCache := resourceskus.NewStaticCache([]armcompute.ResourceSKU{
+		{
+			Name:         ptr.To("Standard_D2s_v3"),
+			ResourceType: ptr.To(string(resourceskus.VirtualMachines)),
+			Locations:    []*string{ptr.To("test-location")},
+			LocationInfo: zonesOf("1", "2"),
+		},
+		{
+			Name:         ptr.To("Standard_D4s_v3"),
+			ResourceType: ptr.To(string(resourceskus.VirtualMachines)),
+			Locations:    []*string{ptr.To("test-location")},
+			LocationInfo: zonesOf("1", "2", "3"),
+		},
+	}, "test-location")
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, d *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "allocation failure is returned without capacity fallback",
+			expectedError: "#: ZonalAllocationFailed",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(&fakeVMSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil, allocationError("ZonalAllocationFailed"))
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, allocationError("ZonalAllocationFailed"))
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, allocationError("ZonalAllocationFailed"))
+			},
+		},
+		{
+			name:          "allocation failure falls back to the first failure domain which can host the VM size",
+			expectedError: `failed to allocate VM with reason ZonalAllocationFailed, retrying in failure domain "2" with VM size Standard_D2s_v3`,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, d *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(fallbackSpec())
+				s.AllocationRetryPending().Return(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fallbackSpec(), serviceName).Return(nil, allocationError("ZonalAllocationFailed"))
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, allocationError("ZonalAllocationFailed"))
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, allocationError("ZonalAllocationFailed"))
+				s.CapacityFallbackTargets().Return([]azure.AllocationTarget{
+					{FailureDomain: "3", VMSize: "Standard_D2s_v3"},
+					{FailureDomain: "2", VMSize: "Standard_D2s_v3"},
+					{FailureDomain: "3", VMSize: "Standard_D4s_v3"},
+				})
+				s.SetAllocationFailed("ZonalAllocationFailed", &azure.AllocationTarget{FailureDomain: "2", VMSize: "Standard_D2s_v3"})
+				r.DeleteResource(gomockinternal.AContext(), fallbackSpec(), serviceName).Return(nil)
+				d.DeleteResource(gomockinternal.AContext(), &disks.DiskSpec{Name: "test-vm_OSDisk", ResourceGroup: "test-group"}, serviceName).Return(nil)
+				d.DeleteResource(gomockinternal.AContext(), &disks.DiskSpec{Name: "test-vm_etcddisk", ResourceGroup: "test-group"}, serviceName).Return(nil)
+				s.SetAllocationRetryPending(false)
+				s.DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue)
+			},
+		},
+		{
+			name:          "allocation failure is returned once no capacity fallback is left",
+			expectedError: "no capacity fallback left to retry the VM allocation with:.*#: SkuNotAvailable",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(fallbackSpec())
+				s.AllocationRetryPending().Return(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fallbackSpec(), serviceName).Return(nil, allocationError("SkuNotAvailable"))
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, allocationError("SkuNotAvailable"))
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, allocationError("SkuNotAvailable"))
+				s.CapacityFallbackTargets().Return([]azure.AllocationTarget{
+					{FailureDomain: "3", VMSize: "Standard_D2s_v3"},
+				})
+				s.SetAllocationFailed("SkuNotAvailable", nil)
+			},
+		},
+		{
+			name:          "pending retry deletes the failed VM and its disks before creating it again",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, d *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(fallbackSpec())
+				s.AllocationRetryPending().Return(true)
+				r.DeleteResource(gomockinternal.AContext(), fallbackSpec(), serviceName).Return(nil)
+				d.DeleteResource(gomockinternal.AContext(), &disks.DiskSpec{Name: "test-vm_OSDisk", ResourceGroup: "test-group"}, serviceName).Return(nil)
+				d.DeleteResource(gomockinternal.AContext(), &disks.DiskSpec{Name: "test-vm_etcddisk", ResourceGroup: "test-group"}, serviceName).Return(nil)
+				s.SetAllocationRetryPending(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), fallbackSpec(), serviceName).Return(nil, internalError())
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, internalError())
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, internalError())
+			},
+		},
+		{
+			name:          "allocation failure of a VM recreated from its disks keeps the disks and its failure domain",
+			expectedError: `failed to allocate VM with reason ZonalAllocationFailed, retrying in failure domain "1" with VM size Standard_D4s_v3`,
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(recreateSpec())
+				s.AllocationRetryPending().Return(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), recreateSpec(), serviceName).Return(nil, allocationError("ZonalAllocationFailed"))
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, allocationError("ZonalAllocationFailed"))
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, allocationError("ZonalAllocationFailed"))
+				s.CapacityFallbackTargets().Return([]azure.AllocationTarget{
+					{FailureDomain: "2", VMSize: "Standard_D2s_v3"},
+					{FailureDomain: "1", VMSize: "Standard_D4s_v3"},
+				})
+				s.SetAllocationFailed("ZonalAllocationFailed", &azure.AllocationTarget{FailureDomain: "1", VMSize: "Standard_D4s_v3"})
+				r.DeleteResource(gomockinternal.AContext(), recreateSpec(), serviceName).Return(nil)
+				s.SetAllocationRetryPending(false)
+				s.DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue)
+			},
+		},
+		{
+			name:          "pending retry of a VM recreated from its disks keeps the disks",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(recreateSpec())
+				s.AllocationRetryPending().Return(true)
+				r.DeleteResource(gomockinternal.AContext(), recreateSpec(), serviceName).Return(nil)
+				s.SetAllocationRetryPending(false)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), recreateSpec(), serviceName).Return(nil, internalError())
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, internalError())
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, internalError())
+			},
+		},
+		{
+			name:          "pending retry waits for the disks of the failed VM to be deleted",
+			expectedError: "operation type DELETE on Azure resource test-group/test-vm_OSDisk is not done",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, d *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(fallbackSpec())
+				s.AllocationRetryPending().Return(true)
+				r.DeleteResource(gomockinternal.AContext(), fallbackSpec(), serviceName).Return(nil)
+				d.DeleteResource(gomockinternal.AContext(), &disks.DiskSpec{Name: "test-vm_OSDisk", ResourceGroup: "test-group"}, serviceName).Return(azure.NewOperationNotDoneError(&infrav1.Future{
+					Type:          infrav1.DeleteFuture,
+					ResourceGroup: "test-group",
+					Name:          "test-vm_OSDisk",
+				}))
+				d.DeleteResource(gomockinternal.AContext(), &disks.DiskSpec{Name: "test-vm_etcddisk", ResourceGroup: "test-group"}, serviceName).Return(nil)
+			},
+		},
+		{
+			name:          "pending retry waits for the failed VM to be deleted",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, _ *mock_async.MockReconcilerMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+				s.VMSpec().Return(fallbackSpec())
+				s.AllocationRetryPending().Return(true)
+				r.DeleteResource(gomockinternal.AContext(), fallbackSpec(), serviceName).Return(internalError())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			diskMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), diskMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:            scopeMock,
+				client:           clientMock,
+				resourceSKUCache: skuCache,
+				Reconciler:       asyncMock,
+				diskReconciler:   diskMock,
+			}
+
+			err := s.Reconcile(t.Context())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(strings.ReplaceAll(err.Error(), "\n", "")).To(MatchRegexp(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcileSpotEviction(t *testing.T) {
+	spotVM := func(size armcompute.VirtualMachineSizeTypes, osDiskDeleteOption armcompute.DiskDeleteOptionTypes) armcompute.VirtualMachine {
+		vm := fakeExistingVM
+		vm.Properties = &armcompute.VirtualMachineProperties{
+			Priority:        ptr.To(armcompute.VirtualMachinePriorityTypesSpot),
+			HardwareProfile: &armcompute.HardwareProfile{VMSize: ptr.To(size)},
+			StorageProfile: &armcompute.StorageProfile{
+				OSDisk: &armcompute.OSDisk{DeleteOption: ptr.To(osDiskDeleteOption)},
+			},
+		}
+		return vm
+	}
+	instanceView := func(powerState string) armcompute.VirtualMachineInstanceView {
+		return armcompute.VirtualMachineInstanceView{
+			Statuses: []*armcompute.InstanceViewStatus{
+				{Code: ptr.To("ProvisioningState/succeeded")},
+				{Code: ptr.To(powerState)},
+			},
+		}
+	}
+	unavailable := func(context string) armresourcehealth.AvailabilityStatus {
+		return armresourcehealth.AvailabilityStatus{
+			Properties: &armresourcehealth.AvailabilityStatusProperties{
+				AvailabilityState: ptr.To(armresourcehealth.AvailabilityStateValuesUnavailable),
+				Context:           ptr.To(context),
+			},
+		}
+	}
+	spotSpec := func(size string, recovery bool) *VMSpec {
+		spec := fakeVMSpec
+		spec.Size = size
+		spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+		spec.SpotEvictionRecovery = recovery
+		return &spec
+	}
+	regularSpec := func() *VMSpec {
+		spec := fakeVMSpec
+		spec.SpotEvictionRecovery = true
+		spec.RecreateFromDisks = true
+		return &spec
+	}
+	noFutures := func(s *mock_virtualmachines.MockVMScopeMockRecorder) {
+		s.GetLongRunningOperationState("test-vm", serviceName, gomock.Any()).Return(nil).AnyTimes()
+	}
+	vmID := *fakeExistingVM.ID
+
+	testcases := []struct {
+		name          string
+		vm            armcompute.VirtualMachine
+		spec          *VMSpec
+		powerState    string
+		expectedError string
+		expect        func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name: "VM with regular priority is not evicted",
+			vm:   fakeExistingVM,
+			spec: regularSpec(),
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, _ *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				s.SetSpotVMEvicted(false)
+			},
+		},
+		{
+			name:       "running Spot VM is not evicted",
+			vm:         spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState: "PowerState/running",
+			spec:       spotSpec("Standard_D2s_v3", true),
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, _ *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				s.SetSpotVMEvicted(false)
+			},
+		},
+		{
+			name:          "getting the instance view fails",
+			vm:            spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			spec:          spotSpec("Standard_D2s_v3", false),
+			expectedError: "failed to get VM instance view:.*#: Internal Server Error: StatusCode=500",
+			expect: func(_ *WithT, _ *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				c.InstanceView(gomockinternal.AContext(), spotSpec("Standard_D2s_v3", false)).Return(armcompute.VirtualMachineInstanceView{}, internalError())
+			},
+		},
+		{
+			name:       "Spot VM deallocated by a user is not evicted",
+			vm:         spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState: "PowerState/deallocated",
+			spec:       spotSpec("Standard_D2s_v3", true),
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Customer"), nil)
+				s.SetSpotVMEvicted(false)
+			},
+		},
+		{
+			name:       "evicted Spot VM is only reported without recovery",
+			vm:         spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState: "PowerState/deallocated",
+			spec:       spotSpec("Standard_D2s_v3", false),
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Platform"), nil)
+				s.SetSpotVMEvicted(true)
+			},
+		},
+		{
+			name:          "getting the availability status fails",
+			vm:            spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState:    "PowerState/deallocated",
+			spec:          spotSpec("Standard_D2s_v3", true),
+			expectedError: "failed to get VM availability status:.*#: Internal Server Error: StatusCode=500",
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(armresourcehealth.AvailabilityStatus{}, internalError())
+			},
+		},
+		{
+			name:       "evicted Spot VM is started",
+			vm:         spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState: "PowerState/deallocated",
+			spec:       spotSpec("Standard_D2s_v3", true),
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Platform"), nil)
+				s.SetSpotVMEvicted(true)
+				s.VMSpec().Return(spotSpec("Standard_D2s_v3", true))
+				c.StartAsync(gomockinternal.AContext(), spotSpec("Standard_D2s_v3", true), "").Return(nil, nil)
+				s.DeleteLongRunningOperationState("test-vm", serviceName, infrav1.PostFuture)
+				s.SetSpotVMEvicted(false)
+			},
+		},
+		{
+			name:       "evicted Spot VM is resized to a fallback VM size and started",
+			vm:         spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState: "PowerState/deallocated",
+			spec:       spotSpec("Standard_D2s_v3", true),
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Platform"), nil)
+				s.SetSpotVMEvicted(true)
+				s.VMSpec().Return(spotSpec("Standard_D4s_v3", true))
+				c.ResizeAsync(gomockinternal.AContext(), spotSpec("Standard_D4s_v3", true), "", "Standard_D4s_v3").Return(nil, nil)
+				s.DeleteLongRunningOperationState("test-vm", serviceName, infrav1.PatchFuture)
+				c.StartAsync(gomockinternal.AContext(), spotSpec("Standard_D4s_v3", true), "").Return(nil, nil)
+				s.DeleteLongRunningOperationState("test-vm", serviceName, infrav1.PostFuture)
+				s.SetSpotVMEvicted(false)
+			},
+		},
+		{
+			name:          "starting evicted Spot VM is saved as a future when it does not complete in time",
+			vm:            spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState:    "PowerState/deallocated",
+			spec:          spotSpec("Standard_D2s_v3", true),
+			expectedError: "operation type POST on Azure resource test-group/test-vm is not done",
+			expect: func(g *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Platform"), nil)
+				s.SetSpotVMEvicted(true)
+				s.VMSpec().Return(spotSpec("Standard_D2s_v3", true))
+				c.StartAsync(gomockinternal.AContext(), spotSpec("Standard_D2s_v3", true), "").Return(fakePoller[armcompute.VirtualMachinesClientStartResponse](g, http.StatusAccepted), context.DeadlineExceeded)
+				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+				s.DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue)
+			},
+		},
+		{
+			name: "start of an evicted Spot VM in progress is resumed whatever the power state",
+			vm:   spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			spec: spotSpec("Standard_D2s_v3", true),
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				future := &infrav1.Future{Type: infrav1.PostFuture, Name: "test-vm", ServiceName: serviceName, Data: base64.URLEncoding.EncodeToString([]byte("resume-token"))}
+				s.GetLongRunningOperationState("test-vm", serviceName, infrav1.PostFuture).Return(future).AnyTimes()
+				noFutures(s)
+				s.VMSpec().Return(spotSpec("Standard_D2s_v3", true))
+				c.StartAsync(gomockinternal.AContext(), spotSpec("Standard_D2s_v3", true), "resume-token").Return(nil, nil)
+				s.DeleteLongRunningOperationState("test-vm", serviceName, infrav1.PostFuture)
+				s.SetSpotVMEvicted(false)
+			},
+		},
+		{
+			name:          "starting evicted Spot VM fails",
+			vm:            spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState:    "PowerState/deallocated",
+			spec:          spotSpec("Standard_D2s_v3", true),
+			expectedError: "failed to start evicted Spot VM:.*#: Internal Server Error: StatusCode=500",
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Platform"), nil)
+				s.SetSpotVMEvicted(true)
+				s.VMSpec().Return(spotSpec("Standard_D2s_v3", true))
+				c.StartAsync(gomockinternal.AContext(), spotSpec("Standard_D2s_v3", true), "").Return(nil, internalError())
+				s.DeleteLongRunningOperationState("test-vm", serviceName, infrav1.PostFuture)
+			},
+		},
+		{
+			name:          "evicted Spot VM is deleted to be recreated with regular priority",
+			vm:            spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDetach),
+			powerState:    "PowerState/deallocated",
+			spec:          spotSpec("Standard_D2s_v3", true),
+			expectedError: "deleted evicted Spot VM test-vm to recreate it with regular priority",
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Platform"), nil)
+				s.SetSpotVMEvicted(true)
+				s.VMSpec().Return(regularSpec())
+				r.DeleteResource(gomockinternal.AContext(), regularSpec(), serviceName).Return(nil)
+				s.DefaultedReconcilerRequeue().Return(reconciler.DefaultReconcilerRequeue)
+			},
+		},
+		{
+			name:          "evicted Spot VM whose disks are deleted with it is not recreated",
+			vm:            spotVM("Standard_D2s_v3", armcompute.DiskDeleteOptionTypesDelete),
+			powerState:    "PowerState/deallocated",
+			spec:          spotSpec("Standard_D2s_v3", true),
+			expectedError: "cannot recreate VM test-vm with regular priority: OS disk is deleted with the VM",
+			expect: func(_ *WithT, s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder, _ *mock_async.MockReconcilerMockRecorder) {
+				noFutures(s)
+				c.AvailabilityStatus(gomockinternal.AContext(), vmID).Return(unavailable("Platform"), nil)
+				s.SetSpotVMEvicted(true)
+				s.VMSpec().Return(regularSpec())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			if tc.powerState != "" {
+				clientMock.EXPECT().InstanceView(gomockinternal.AContext(), tc.spec).Return(instanceView(tc.powerState), nil)
+			}
+			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				client:     clientMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.reconcileSpotEviction(t.Context(), tc.vm, tc.spec)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(strings.ReplaceAll(err.Error(), "\n", "")).To(MatchRegexp(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDeleteVM(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -374,3 +844,18 @@ func TestCheckUserAssignedIdentities(t *testing.T) {
 		})
 	}
 }
+
+func fakePoller[T any](g *WithT, statusCode int) *runtime.Poller[T] {
+	response := &http.Response{
+		Body: io.NopCloser(strings.NewReader("")),
+		Request: &http.Request{
+			Method: http.MethodPost,
+			URL:    &url.URL{Path: "/"},
+		},
+		StatusCode: statusCode,
+	}
+	pipeline := runtime.NewPipeline("testmodule", "v0.1.0", runtime.PipelineOptions{}, nil)
+	poller, err := runtime.NewPoller[T](response, pipeline, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	return poller
+}
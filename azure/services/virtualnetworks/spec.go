@@ -38,6 +38,12 @@ type VNetSpec struct {
 	ExtendedLocation *infrav1.ExtendedLocationSpec
 	ClusterName      string
 	AdditionalTags   infrav1.Tags
+	// SubscriptionID is the ID of the subscription of the virtual network when it is not in the subscription of the
+	// cluster, in which case its resource group is referenced by ID instead of by its ASO resource.
+	SubscriptionID string
+	// ASOSecretName is the name of the secret with the credentials ASO uses to manage the virtual network when they are
+	// not the credentials of the cluster.
+	ASOSecretName string
 }
 
 // ResourceRef implements azure.ASOResourceSpecGetter.
@@ -70,6 +76,11 @@ func (s *VNetSpec) Parameters(_ context.Context, existing *asonetworkv1.VirtualN
 	vnet.Spec.Owner = &genruntime.KnownResourceReference{
 		Name: azure.GetNormalizedKubernetesName(s.ResourceGroup),
 	}
+	if s.SubscriptionID != "" {
+		vnet.Spec.Owner = &genruntime.KnownResourceReference{
+			ARMID: azure.ResourceGroupID(s.SubscriptionID, s.ResourceGroup),
+		}
+	}
 	vnet.Spec.Location = ptr.To(s.Location)
 	vnet.Spec.ExtendedLocation = converters.ExtendedLocationToNetworkASO(s.ExtendedLocation)
 	vnet.Spec.AddressSpace = &asonetworkv1.AddressSpace{
@@ -79,6 +90,11 @@ func (s *VNetSpec) Parameters(_ context.Context, existing *asonetworkv1.VirtualN
 	return vnet, nil
 }
 
+// PerResourceSecretName implements aso.PerResourceSecretNamer.
+func (s *VNetSpec) PerResourceSecretName() string {
+	return s.ASOSecretName
+}
+
 // WasManaged implements azure.ASOResourceSpecGetter.
 func (s *VNetSpec) WasManaged(resource *asonetworkv1.VirtualNetwork) bool {
 	return infrav1.Tags(resource.Status.Tags).HasOwned(s.ClusterName)
@@ -72,6 +72,34 @@ func TestParameters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "new vnet in another subscription",
+			spec: VNetSpec{
+				ResourceGroup:  "rg",
+				Name:           "name",
+				CIDRs:          []string{"cidr"},
+				Location:       "location",
+				ClusterName:    "cluster",
+				SubscriptionID: "network-subscription",
+			},
+			expected: &asonetworkv1.VirtualNetwork{
+				Spec: asonetworkv1.VirtualNetwork_Spec{
+					Tags: map[string]string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_cluster": "owned",
+						"sigs.k8s.io_cluster-api-provider-azure_role":            "common",
+						"Name": "name",
+					},
+					AzureName: "name",
+					Owner: &genruntime.KnownResourceReference{
+						ARMID: "/subscriptions/network-subscription/resourceGroups/rg",
+					},
+					Location: ptr.To("location"),
+					AddressSpace: &asonetworkv1.AddressSpace{
+						AddressPrefixes: []string{"cidr"},
+					},
+				},
+			},
+		},
 		{
 			name: "from existing vnet",
 			spec: VNetSpec{
@@ -60,6 +60,20 @@ func (m *MockVnetPeeringScope) EXPECT() *MockVnetPeeringScopeMockRecorder {
 	return m.recorder
 }
 
+// AuthorizerForSubscription mocks base method.
+func (m *MockVnetPeeringScope) AuthorizerForSubscription(subscriptionID string) azure.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizerForSubscription", subscriptionID)
+	ret0, _ := ret[0].(azure.Authorizer)
+	return ret0
+}
+
+// AuthorizerForSubscription indicates an expected call of AuthorizerForSubscription.
+func (mr *MockVnetPeeringScopeMockRecorder) AuthorizerForSubscription(subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizerForSubscription", reflect.TypeOf((*MockVnetPeeringScope)(nil).AuthorizerForSubscription), subscriptionID)
+}
+
 // BaseURI mocks base method.
 func (m *MockVnetPeeringScope) BaseURI() string {
 	m.ctrl.T.Helper()
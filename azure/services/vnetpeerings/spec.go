@@ -28,16 +28,20 @@ import (
 
 // VnetPeeringSpec defines the specification for a virtual network peering.
 type VnetPeeringSpec struct {
-	SourceResourceGroup       string
-	SourceVnetName            string
-	RemoteResourceGroup       string
-	RemoteVnetName            string
-	PeeringName               string
+	SourceResourceGroup string
+	SourceVnetName      string
+	RemoteResourceGroup string
+	RemoteVnetName      string
+	PeeringName         string
+	// SubscriptionID is the ID of the subscription of the remote virtual network.
 	SubscriptionID            string
 	AllowForwardedTraffic     *bool
 	AllowGatewayTransit       *bool
 	AllowVirtualNetworkAccess *bool
 	UseRemoteGateways         *bool
+	// SourceSubscriptionID is the ID of the subscription of the source virtual network when it differs from the
+	// subscription of the cluster. The peering is then managed with the credentials of that subscription.
+	SourceSubscriptionID string
 }
 
 // ResourceName returns the name of the virtual network peering.
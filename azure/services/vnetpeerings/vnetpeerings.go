@@ -34,6 +34,7 @@ const ServiceName = "vnetpeerings"
 type VnetPeeringScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
+	azure.SubscriptionAuthorizer
 	VnetPeeringSpecs() []azure.ResourceSpecGetter
 }
 
@@ -41,6 +42,9 @@ type VnetPeeringScope interface {
 type Service struct {
 	Scope VnetPeeringScope
 	async.Reconciler
+	// SubscriptionReconcilers manage the peerings of the virtual networks in other subscriptions than the cluster,
+	// indexed by the subscription ID of the source virtual network.
+	SubscriptionReconcilers map[string]async.Reconciler
 }
 
 // New creates a new service.
@@ -49,10 +53,27 @@ func New(scope VnetPeeringScope) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	subscriptionReconcilers := make(map[string]async.Reconciler)
+	for _, spec := range scope.VnetPeeringSpecs() {
+		peering, ok := spec.(*VnetPeeringSpec)
+		if !ok || peering.SourceSubscriptionID == "" || peering.SourceSubscriptionID == scope.SubscriptionID() {
+			continue
+		}
+		if _, ok := subscriptionReconcilers[peering.SourceSubscriptionID]; ok {
+			continue
+		}
+		subscriptionClient, err := NewClient(scope.AuthorizerForSubscription(peering.SourceSubscriptionID), scope.DefaultedAzureCallTimeout())
+		if err != nil {
+			return nil, err
+		}
+		subscriptionReconcilers[peering.SourceSubscriptionID] = async.New[armnetwork.VirtualNetworkPeeringsClientCreateOrUpdateResponse,
+			armnetwork.VirtualNetworkPeeringsClientDeleteResponse](scope, subscriptionClient, subscriptionClient)
+	}
 	return &Service{
 		Scope: scope,
 		Reconciler: async.New[armnetwork.VirtualNetworkPeeringsClientCreateOrUpdateResponse,
 			armnetwork.VirtualNetworkPeeringsClientDeleteResponse](scope, Client, Client),
+		SubscriptionReconcilers: subscriptionReconcilers,
 	}, nil
 }
 
@@ -69,7 +90,29 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
 	defer cancel()
 
-	return azure.ReconcileAll(ctx, s.Reconciler, s.Scope, s.Scope.VnetPeeringSpecs(), ServiceName, infrav1.VnetPeeringReadyCondition)
+	return azure.ReconcileAll(ctx, s.peeringReconciler(), s.Scope, s.Scope.VnetPeeringSpecs(), ServiceName, infrav1.VnetPeeringReadyCondition)
+}
+
+// Plan implements azure.Planner.
+func (s *Service) Plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Plan")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.PlanAll(ctx, s.peeringReconciler(), s.Scope.VnetPeeringSpecs(), ServiceName)
+}
+
+// DetectDrift implements azure.DriftDetector.
+func (s *Service) DetectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.DetectDrift")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
+	defer cancel()
+
+	return azure.DetectDriftAll(ctx, s.peeringReconciler(), s.Scope.VnetPeeringSpecs(), ServiceName, correct)
 }
 
 // Delete deletes the peering with the provided name.
@@ -80,10 +123,55 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, s.Scope.DefaultedAzureServiceReconcileTimeout())
 	defer cancel()
 
-	return azure.DeleteAll(ctx, s.Reconciler, s.Scope, s.Scope.VnetPeeringSpecs(), ServiceName, infrav1.VnetPeeringReadyCondition)
+	return azure.DeleteAll(ctx, s.peeringReconciler(), s.Scope, s.Scope.VnetPeeringSpecs(), ServiceName, infrav1.VnetPeeringReadyCondition)
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO VNet peering.
 func (s *Service) IsManaged(_ context.Context) (bool, error) {
 	return true, nil
 }
+
+// peeringReconciler returns a reconciler managing the peerings of the virtual networks in other subscriptions with
+// the reconciler of their subscription and all other peerings with the reconciler of the cluster.
+func (s *Service) peeringReconciler() async.Reconciler {
+	if len(s.SubscriptionReconcilers) == 0 {
+		return s.Reconciler
+	}
+	return &peeringReconciler{cluster: s.Reconciler, subscriptions: s.SubscriptionReconcilers}
+}
+
+// peeringReconciler dispatches each virtual network peering to the reconciler holding the credentials of the
+// subscription of its source virtual network.
+type peeringReconciler struct {
+	cluster       async.Reconciler
+	subscriptions map[string]async.Reconciler
+}
+
+func (r *peeringReconciler) reconcilerFor(spec azure.ResourceSpecGetter) async.Reconciler {
+	if peering, ok := spec.(*VnetPeeringSpec); ok {
+		if reconciler, ok := r.subscriptions[peering.SourceSubscriptionID]; ok {
+			return reconciler
+		}
+	}
+	return r.cluster
+}
+
+// CreateOrUpdateResource implements async.Reconciler.
+func (r *peeringReconciler) CreateOrUpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (any, error) {
+	return r.reconcilerFor(spec).CreateOrUpdateResource(ctx, spec, serviceName)
+}
+
+// DeleteResource implements async.Reconciler.
+func (r *peeringReconciler) DeleteResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) error {
+	return r.reconcilerFor(spec).DeleteResource(ctx, spec, serviceName)
+}
+
+// PlanResource implements async.Reconciler.
+func (r *peeringReconciler) PlanResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (infrav1.ResourceChange, error) {
+	return r.reconcilerFor(spec).PlanResource(ctx, spec, serviceName)
+}
+
+// DetectResourceDrift implements async.Reconciler.
+func (r *peeringReconciler) DetectResourceDrift(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, correct bool) (*infrav1.ResourceDrift, error) {
+	return r.reconcilerFor(spec).DetectResourceDrift(ctx, spec, serviceName, correct)
+}
@@ -30,6 +30,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings/mock_vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -420,3 +421,59 @@ func TestDeleteVnetPeerings(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileAndDeleteVnetPeeringsInOtherSubscriptions(t *testing.T) {
+	hubToSpoke := fakePeeringHubToSpoke
+	hubToSpoke.SourceSubscriptionID = "hub-sub"
+	specs := []azure.ResourceSpecGetter{&fakePeeringSpokeToHub, &hubToSpoke}
+
+	t.Run("reconcile the peering of another subscription with the reconciler of that subscription", func(t *testing.T) {
+		g := NewWithT(t)
+
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+		asyncMock := mock_async.NewMockReconciler(mockCtrl)
+		hubAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+		scopeMock.EXPECT().DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+		scopeMock.EXPECT().VnetPeeringSpecs().Return(specs)
+		asyncMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), &fakePeeringSpokeToHub, ServiceName).Return(&fakePeeringSpokeToHub, nil)
+		hubAsyncMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), &hubToSpoke, ServiceName).Return(&hubToSpoke, nil)
+		scopeMock.EXPECT().UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
+
+		s := &Service{
+			Scope:                   scopeMock,
+			Reconciler:              asyncMock,
+			SubscriptionReconcilers: map[string]async.Reconciler{"hub-sub": hubAsyncMock},
+		}
+
+		g.Expect(s.Reconcile(t.Context())).To(Succeed())
+	})
+
+	t.Run("delete the peering of another subscription with the reconciler of that subscription", func(t *testing.T) {
+		g := NewWithT(t)
+
+		t.Parallel()
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+		asyncMock := mock_async.NewMockReconciler(mockCtrl)
+		hubAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+		scopeMock.EXPECT().DefaultedAzureServiceReconcileTimeout().Return(reconciler.DefaultAzureServiceReconcileTimeout)
+		scopeMock.EXPECT().VnetPeeringSpecs().Return(specs)
+		asyncMock.EXPECT().DeleteResource(gomockinternal.AContext(), &fakePeeringSpokeToHub, ServiceName).Return(nil)
+		hubAsyncMock.EXPECT().DeleteResource(gomockinternal.AContext(), &hubToSpoke, ServiceName).Return(internalError())
+		scopeMock.EXPECT().UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError())
+
+		s := &Service{
+			Scope:                   scopeMock,
+			Reconciler:              asyncMock,
+			SubscriptionReconcilers: map[string]async.Reconciler{"hub-sub": hubAsyncMock},
+		}
+
+		g.Expect(s.Delete(t.Context())).NotTo(Succeed())
+	})
+}
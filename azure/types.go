@@ -84,6 +84,12 @@ type TagsSpec struct {
 	Annotation string
 }
 
+// AllocationTarget is a failure domain and VM size to allocate a VM with.
+type AllocationTarget struct {
+	FailureDomain string
+	VMSize        string
+}
+
 // ExtensionSpec defines the specification for a VM or VMSS extension.
 type ExtensionSpec struct {
 	Name              string
@@ -157,6 +163,26 @@ func (vm VMSSVM) ProviderID() string {
 	return azureutil.ProviderIDPrefix + vm.ID
 }
 
+// ScaleSetNameFromProviderID returns the name of the scale set that owns the VM with the given K8s provider ID, or an
+// empty string if the provider ID does not identify a scale set VM.
+func ScaleSetNameFromProviderID(providerID string) string {
+	parts := strings.Split(strings.TrimPrefix(providerID, azureutil.ProviderIDPrefix), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], "virtualMachineScaleSets") {
+			return parts[i+1]
+		}
+	}
+
+	// Flex scaleset VMs are named after their scale set, e.g. my-cluster-mp-0_1234abcd.
+	if n := len(parts); n >= 2 && strings.EqualFold(parts[n-2], "virtualMachines") {
+		if i := strings.LastIndex(parts[n-1], "_"); i > 0 {
+			return parts[n-1][:i]
+		}
+	}
+
+	return ""
+}
+
 // HasLatestModelAppliedToAll returns true if all VMSS instance have the latest model applied.
 func (vmss VMSS) HasLatestModelAppliedToAll() bool {
 	for _, instance := range vmss.Instances {
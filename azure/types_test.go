@@ -136,6 +136,42 @@ func TestVMSS_HasModelChanges(t *testing.T) {
 	}
 }
 
+func TestScaleSetNameFromProviderID(t *testing.T) {
+	cases := []struct {
+		Name       string
+		ProviderID string
+		Expected   string
+	}{
+		{
+			Name:       "uniform scale set VM",
+			ProviderID: "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/my-cluster-mp-0/virtualMachines/0",
+			Expected:   "my-cluster-mp-0",
+		},
+		{
+			Name:       "flex scale set VM",
+			ProviderID: "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-cluster-mp-0-g_1234abcd",
+			Expected:   "my-cluster-mp-0-g",
+		},
+		{
+			Name:       "standalone VM",
+			ProviderID: "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+			Expected:   "",
+		},
+		{
+			Name:       "empty",
+			ProviderID: "",
+			Expected:   "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ScaleSetNameFromProviderID(c.ProviderID)).To(Equal(c.Expected))
+		})
+	}
+}
+
 func getDefaultVMSSForModelTesting() VMSS {
 	return VMSS{
 		Zones: []string{"0", "1"},
@@ -227,7 +227,7 @@ func (asos *ASOSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	// Construct the ASO secret for this Cluster
-	newASOSecret, err := asos.createSecretFromClusterIdentity(ctx, clusterIdentity, cluster, azureClient)
+	newASOSecret, err := asos.createSecretFromClusterIdentity(ctx, aso.GetASOSecretName(cluster.GetName()), clusterIdentity, cluster, azureClient.SubscriptionID())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -248,20 +248,39 @@ func (asos *ASOSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile ASO secret")
 	}
 
+	// A virtual network in another subscription than the cluster is managed by ASO with its own credentials, from the
+	// identity referenced by the virtual network or from the identity of the cluster.
+	if vnet := azureCluster.Spec.NetworkSpec.Vnet; !checkForManagedControlPlane && vnet.SubscriptionID != "" && vnet.SubscriptionID != azureClient.SubscriptionID() {
+		vnetIdentity := clusterIdentity
+		if vnet.IdentityRef != nil {
+			vnetIdentity = vnet.IdentityRef
+		}
+		vnetASOSecret, err := asos.createSecretFromClusterIdentity(ctx, aso.GetASOVnetSecretName(cluster.GetName()), vnetIdentity, cluster, vnet.SubscriptionID)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		vnetASOSecret.OwnerReferences = []metav1.OwnerReference{owner}
+
+		if err := reconcileAzureSecret(ctx, asos.Client, owner, vnetASOSecret, cluster.GetName()); err != nil {
+			asos.Recorder.Event(cluster, corev1.EventTypeWarning, "Error reconciling ASO secret", err.Error())
+			return ctrl.Result{}, errors.Wrap(err, "failed to reconcile ASO secret of the virtual network")
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (asos *ASOSecretReconciler) createSecretFromClusterIdentity(ctx context.Context, clusterIdentity *corev1.ObjectReference, cluster *clusterv1.Cluster, azureClient scope.AzureClients) (*corev1.Secret, error) {
+func (asos *ASOSecretReconciler) createSecretFromClusterIdentity(ctx context.Context, name string, clusterIdentity *corev1.ObjectReference, cluster *clusterv1.Cluster, subscriptionID string) (*corev1.Secret, error) {
 	newASOSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      aso.GetASOSecretName(cluster.GetName()),
+			Name:      name,
 			Namespace: cluster.GetNamespace(),
 			Labels: map[string]string{
 				cluster.GetName(): string(infrav1.ResourceLifecycleOwned),
 			},
 		},
 		Data: map[string][]byte{
-			asoconfig.AzureSubscriptionID: []byte(azureClient.SubscriptionID()),
+			asoconfig.AzureSubscriptionID: []byte(subscriptionID),
 		},
 	}
 
@@ -22,6 +22,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -354,6 +355,91 @@ func TestASOSecretReconcile(t *testing.T) {
 	}
 }
 
+func TestASOSecretReconcileVnetSubscription(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	clusterIdentityRef := &corev1.ObjectReference{Name: "my-azure-cluster-identity", Namespace: "default"}
+	vnetIdentityRef := &corev1.ObjectReference{Name: "my-vnet-identity", Namespace: "default"}
+
+	cases := map[string]struct {
+		vnet          infrav1.VnetSpec
+		vnetASOSecret map[string][]byte
+	}{
+		"should not create a secret for a virtual network in the subscription of the cluster": {
+			vnet: infrav1.VnetSpec{SubscriptionID: "123"},
+		},
+		"should create a secret with the identity of the cluster for a virtual network in another subscription": {
+			vnet: infrav1.VnetSpec{SubscriptionID: "456"},
+			vnetASOSecret: map[string][]byte{
+				"AZURE_SUBSCRIPTION_ID": []byte("456"),
+				"AZURE_TENANT_ID":       []byte("fooTenant"),
+				"AZURE_CLIENT_ID":       []byte("fooClient"),
+				"AUTH_MODE":             []byte("workloadidentity"),
+			},
+		},
+		"should create a secret with the identity of the virtual network for a virtual network in another subscription": {
+			vnet: infrav1.VnetSpec{SubscriptionID: "456", IdentityRef: vnetIdentityRef},
+			vnetASOSecret: map[string][]byte{
+				"AZURE_SUBSCRIPTION_ID": []byte("456"),
+				"AZURE_TENANT_ID":       []byte("fooTenant"),
+				"AZURE_CLIENT_ID":       []byte("vnetClient"),
+				"AUTH_MODE":             []byte("workloadidentity"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			azureCluster := getASOAzureCluster(func(c *infrav1.AzureCluster) {
+				c.Spec.IdentityRef = clusterIdentityRef
+				c.Spec.NetworkSpec.Vnet = tc.vnet
+			})
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+				azureCluster,
+				getASOAzureClusterIdentity(func(identity *infrav1.AzureClusterIdentity) {
+					identity.Spec.Type = infrav1.WorkloadIdentity
+				}),
+				getASOAzureClusterIdentity(func(identity *infrav1.AzureClusterIdentity) {
+					identity.Name = vnetIdentityRef.Name
+					identity.Spec.Type = infrav1.WorkloadIdentity
+					identity.Spec.ClientID = "vnetClient"
+				}),
+				getASOCluster(),
+			).Build()
+
+			reconciler := &ASOSecretReconciler{
+				Client:          kubeClient,
+				Recorder:        record.NewFakeRecorder(128),
+				CredentialCache: azure.NewCredentialCache(),
+			}
+
+			_, err := reconciler.Reconcile(t.Context(), ctrl.Request{
+				NamespacedName: client.ObjectKeyFromObject(azureCluster),
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			clusterASOSecret := &corev1.Secret{}
+			g.Expect(kubeClient.Get(t.Context(), types.NamespacedName{Namespace: "default", Name: "my-cluster-aso-secret"}, clusterASOSecret)).To(Succeed())
+			g.Expect(clusterASOSecret.Data).To(HaveKeyWithValue("AZURE_SUBSCRIPTION_ID", []byte("123")))
+
+			vnetASOSecret := &corev1.Secret{}
+			vnetASOSecretErr := kubeClient.Get(t.Context(), types.NamespacedName{Namespace: "default", Name: "my-cluster-aso-vnet-secret"}, vnetASOSecret)
+			if tc.vnetASOSecret == nil {
+				g.Expect(apierrors.IsNotFound(vnetASOSecretErr)).To(BeTrue())
+				return
+			}
+			g.Expect(vnetASOSecretErr).NotTo(HaveOccurred())
+			g.Expect(vnetASOSecret.Data).To(Equal(tc.vnetASOSecret))
+			g.Expect(vnetASOSecret.OwnerReferences).To(HaveLen(1))
+			g.Expect(vnetASOSecret.OwnerReferences[0].Name).To(Equal(azureCluster.Name))
+		})
+	}
+}
+
 func getASOCluster(changes ...func(*clusterv1.Cluster)) *clusterv1.Cluster {
 	input := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
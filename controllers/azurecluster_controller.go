@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
@@ -39,8 +41,11 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -99,6 +104,13 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 				predicates.ResourceHasFilterLabel(mgr.GetScheme(), log, acr.WatchFilterValue),
 			),
 		).
+		// Add a watch on the IPAddressClaims of the AzureClusters to resume their reconciliation once allocated.
+		Owns(&ipamv1.IPAddressClaim{}).
+		// Add a watch on AzureSecurityRuleSets to roll out changes to their rules to the AzureClusters referencing them.
+		Watches(
+			&infrav1.AzureSecurityRuleSet{},
+			handler.EnqueueRequestsFromMapFunc(AzureSecurityRuleSetToAzureClustersMapper(ctx, mgr.GetClient(), log)),
+		).
 		Complete(r)
 }
 
@@ -107,6 +119,9 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates;azuremachinetemplates/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities;azureclusteridentities/status,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuresecurityrulesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;
 // +kubebuilder:rbac:groups=resources.azure.com,resources=resourcegroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=resources.azure.com,resources=resourcegroups/status,verbs=get;list;watch
@@ -138,6 +153,7 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 		return reconcile.Result{}, err
 	}
+	ctx = audit.WithRequester(ctx, infrav1.GroupVersion.WithKind(infrav1.AzureClusterKind), azureCluster)
 
 	// Fetch the Cluster.
 	cluster, err := util.GetOwnerCluster(ctx, acr.Client, azureCluster.ObjectMeta)
@@ -195,6 +211,20 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return acr.reconcileDelete(ctx, clusterScope)
 	}
 
+	// Wait for the existing resources to be adopted before reconciling them.
+	if azureCluster.GetAnnotations()[adoptAnnotation] == adoptAnnotationValue {
+		log.Info("AzureCluster is waiting for its resources to be adopted. Won't reconcile normally")
+		return reconcile.Result{}, nil
+	}
+
+	// Estimate the cost of the cluster infrastructure from its spec, so it is known before anything is created.
+	azureCluster.Status.EstimatedMonthlyCost = cost.Update(cost.NewKey(azureCluster, infrav1.AzureClusterKind, clusterScope.ClusterName()), clusterScope.CostResources())
+
+	// Compute planned changes instead of applying them when plan mode is requested.
+	if azureCluster.GetAnnotations()[azure.PlanAnnotation] == "true" {
+		return acr.reconcilePlan(ctx, clusterScope)
+	}
+
 	// Handle non-deleted clusters
 	return acr.reconcileNormal(ctx, clusterScope)
 }
@@ -205,6 +235,8 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 
 	log.Info("Reconciling AzureCluster")
 	azureCluster := clusterScope.AzureCluster
+	// Planned changes are only meaningful while in plan mode.
+	azureCluster.Status.PlannedChanges = nil
 
 	// Register our finalizer immediately to avoid orphaning Azure resources on delete
 	needsPatch := controllerutil.AddFinalizer(azureCluster, infrav1.ClusterFinalizer)
@@ -216,11 +248,24 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		}
 	}
 
+	// The CIDR blocks allocated from IPAM pools are needed by the specs of the services, so they are allocated first.
+	allocated, err := acr.reconcileIPAM(ctx, azureCluster, clusterScope.ClusterName())
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to allocate CIDR blocks from IPAM pools")
+	}
+	if !allocated {
+		v1beta1conditions.MarkFalse(azureCluster, infrav1.NetworkInfrastructureReadyCondition, infrav1.WaitingForIPAMAllocationReason, clusterv1beta1.ConditionSeverityInfo, "Waiting for the CIDR blocks to be allocated from the IPAM pools")
+		return reconcile.Result{}, nil
+	}
+
 	acs, err := acr.createAzureClusterService(clusterScope)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
 	}
 
+	// Scan for drift before reconciling the services, since reconciling may update drifted resources.
+	acr.reconcileDrift(ctx, clusterScope, acs)
+
 	if err := acs.Reconcile(ctx); err != nil {
 		// Handle terminal & transient errors
 		var reconcileError azure.ReconcileError
@@ -269,6 +314,74 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 	azureCluster.Status.Ready = true
 	v1beta1conditions.MarkTrue(azureCluster, infrav1.NetworkInfrastructureReadyCondition)
 
+	if detection := azureCluster.Spec.DriftDetection; detection != nil {
+		return reconcile.Result{RequeueAfter: max(nextDriftScan(detection, azureCluster.Status.Drift, time.Now()), time.Second)}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileDrift scans the Azure resources of a ready AzureCluster for drift when drift detection is enabled and the
+// last scan is older than its interval. A failed scan is reported and does not prevent reconciliation.
+func (acr *AzureClusterReconciler) reconcileDrift(ctx context.Context, clusterScope *scope.ClusterScope, acs *azureClusterService) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureClusterReconciler.reconcileDrift")
+	defer done()
+
+	azureCluster := clusterScope.AzureCluster
+	detection := azureCluster.Spec.DriftDetection
+	if detection == nil {
+		azureCluster.Status.Drift = nil
+		return
+	}
+	if !azureCluster.Status.Ready || nextDriftScan(detection, azureCluster.Status.Drift, time.Now()) > 0 {
+		return
+	}
+
+	status, err := scanDrift(ctx, acr.Recorder, azureCluster, detection, azureCluster.Status.Drift, acs.DetectDrift)
+	if err != nil {
+		log.Error(err, "failed to detect drift of AzureCluster")
+	}
+	status.UnscannedServices = unscannedServices(acs.services)
+	azureCluster.Status.Drift = status
+}
+
+func (acr *AzureClusterReconciler) reconcilePlan(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureClusterReconciler.reconcilePlan")
+	defer done()
+
+	log.Info("Reconciling AzureCluster plan")
+	azureCluster := clusterScope.AzureCluster
+
+	acs, err := acr.createAzureClusterService(clusterScope)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to create a new azureClusterService")
+	}
+
+	changes, err := acs.Plan(ctx)
+	if err != nil {
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+			log.V(2).Info(fmt.Sprintf("transient failure to plan AzureCluster, retrying: %s", reconcileError.Error()))
+			return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+		}
+		wrappedErr := errors.Wrap(err, "failed to plan cluster services")
+		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "ClusterPlanFailed", "%s", wrappedErr.Error())
+		return reconcile.Result{}, wrappedErr
+	}
+
+	azureCluster.Status.PlannedChanges = changes
+
+	var creates, updates int
+	for _, change := range changes {
+		switch change.Action {
+		case infrav1.ResourceChangeActionCreate:
+			creates++
+		case infrav1.ResourceChangeActionUpdate:
+			updates++
+		}
+	}
+	acr.Recorder.Eventf(azureCluster, corev1.EventTypeNormal, "ClusterPlanned", "Reconciliation would create %d and update %d Azure resources", creates, updates)
+
 	return reconcile.Result{}, nil
 }
 
@@ -326,6 +439,8 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 
 	// Cluster is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(azureCluster, infrav1.ClusterFinalizer)
+	futures.Untrack(azureCluster)
+	cost.Forget(cost.NewKey(azureCluster, infrav1.AzureClusterKind, clusterScope.ClusterName()))
 
 	if azureCluster.Spec.IdentityRef != nil {
 		// Cluster is deleted so remove the identity finalizer.
@@ -242,6 +242,70 @@ func TestAzureClusterReconcileNormal(t *testing.T) {
 	}
 }
 
+func TestAzureClusterReconcilePlan(t *testing.T) {
+	planned := []infrav1.ResourceChange{
+		{ServiceName: "loadbalancers", ResourceName: "my-lb", ResourceGroup: "my-rg", Action: infrav1.ResourceChangeActionCreate},
+		{ServiceName: "routetables", ResourceName: "my-rt", ResourceGroup: "my-rg", Action: infrav1.ResourceChangeActionUpdate, Diff: "some diff"},
+	}
+
+	cases := map[string]struct {
+		plan            func(context.Context) ([]infrav1.ResourceChange, error)
+		expectedResult  reconcile.Result
+		expectedErr     string
+		expectedChanges []infrav1.ResourceChange
+	}{
+		"should record planned changes": {
+			plan: func(context.Context) ([]infrav1.ResourceChange, error) {
+				return planned, nil
+			},
+			expectedChanges: planned,
+		},
+		"should requeue if transient error is received": {
+			plan: func(context.Context) ([]infrav1.ResourceChange, error) {
+				return nil, azure.WithTransientError(errors.New("failed to plan"), 10*time.Second)
+			},
+			expectedResult: reconcile.Result{RequeueAfter: 10 * time.Second},
+		},
+		"should return error for general failures": {
+			plan: func(context.Context) ([]infrav1.ResourceChange, error) {
+				return nil, errors.New("foo error")
+			},
+			expectedErr: "failed to plan cluster services",
+		},
+	}
+
+	for name, c := range cases {
+		tc := c
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			reconciler, clusterScope, err := getClusterReconcileInputs(TestClusterReconcileInput{
+				createAzureClusterService: func(cs *scope.ClusterScope) (*azureClusterService, error) {
+					return getDefaultAzureClusterService(func(acs *azureClusterService) {
+						acs.scope = cs
+						acs.Reconcile = func(context.Context) error {
+							return errors.New("reconcile should not be called in plan mode")
+						}
+						acs.Plan = tc.plan
+					}), nil
+				},
+				cache: &scope.ClusterCache{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			result, err := reconciler.reconcilePlan(t.Context(), clusterScope)
+			g.Expect(result).To(Equal(tc.expectedResult))
+			if tc.expectedErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedErr))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(clusterScope.AzureCluster.Status.PlannedChanges).To(Equal(tc.expectedChanges))
+			g.Expect(clusterScope.AzureCluster.Status.Ready).To(BeFalse())
+		})
+	}
+}
+
 func TestAzureClusterReconcilePaused(t *testing.T) {
 	g := NewWithT(t)
 
@@ -444,6 +508,9 @@ func getDefaultAzureClusterService(changes ...func(*azureClusterService)) *azure
 		Pause: func(ctx context.Context) error {
 			return nil
 		},
+		Plan: func(ctx context.Context) ([]infrav1.ResourceChange, error) {
+			return nil, nil
+		},
 	}
 
 	for _, change := range changes {
@@ -0,0 +1,129 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ipamAllocation is a network of an AzureCluster whose CIDR blocks are allocated from an IPAM pool.
+type ipamAllocation struct {
+	// name identifies the network in the name of its IPAddressClaim.
+	name       string
+	poolRef    *corev1.TypedLocalObjectReference
+	cidrBlocks *[]string
+}
+
+// ipamAllocations returns the vnet and subnets of an AzureCluster whose CIDR blocks are still to be allocated from
+// their IPAM pool.
+func ipamAllocations(azureCluster *infrav1.AzureCluster) []ipamAllocation {
+	var allocations []ipamAllocation
+	networkSpec := &azureCluster.Spec.NetworkSpec
+	if networkSpec.Vnet.IsIPAMAllocationPending() {
+		allocations = append(allocations, ipamAllocation{
+			name:       "vnet",
+			poolRef:    networkSpec.Vnet.IPAMPoolRef,
+			cidrBlocks: &networkSpec.Vnet.CIDRBlocks,
+		})
+	}
+	subnets := make([]*infrav1.SubnetSpec, 0, len(networkSpec.Subnets)+3)
+	for i := range networkSpec.Subnets {
+		subnets = append(subnets, &networkSpec.Subnets[i])
+	}
+	if bastion := azureCluster.Spec.BastionSpec.AzureBastion; bastion != nil {
+		subnets = append(subnets, &bastion.Subnet)
+	}
+	if ingress := networkSpec.Ingress; ingress != nil {
+		subnets = append(subnets, &ingress.Subnet)
+	}
+	if firewall := networkSpec.Firewall; firewall != nil {
+		subnets = append(subnets, &firewall.Subnet)
+	}
+	for _, subnet := range subnets {
+		if subnet.IsIPAMAllocationPending() {
+			allocations = append(allocations, ipamAllocation{
+				name:       subnet.Name,
+				poolRef:    subnet.IPAMPoolRef,
+				cidrBlocks: &subnet.CIDRBlocks,
+			})
+		}
+	}
+	return allocations
+}
+
+// ipamClaimName returns the name of the IPAddressClaim of a network of an AzureCluster.
+func ipamClaimName(azureClusterName, networkName string) string {
+	return azure.GetNormalizedKubernetesName(azureClusterName + "-" + networkName)
+}
+
+// reconcileIPAM allocates the CIDR blocks of the vnet and subnets of an AzureCluster which reference an IPAM pool by
+// claiming an IPAddress from the pool for each of them. The address and prefix of the IPAddress, which must be a network
+// prefix, become the CIDR block of the network. It returns false while an allocation is pending, and when CIDR blocks were allocated so that the
+// AzureCluster is patched and defaulted with them, e.g. the private IP of its API server LB, before its resources are
+// reconciled.
+func (acr *AzureClusterReconciler) reconcileIPAM(ctx context.Context, azureCluster *infrav1.AzureCluster, clusterName string) (bool, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureClusterReconciler.reconcileIPAM")
+	defer done()
+
+	allocations := ipamAllocations(azureCluster)
+	for _, allocation := range allocations {
+		claimName := ipamClaimName(azureCluster.Name, allocation.name)
+		address, err := reconcileIPAddressClaim(ctx, acr.Client, azureCluster, claimName, clusterName, acr.WatchFilterValue, allocation.poolRef)
+		if err != nil {
+			return false, err
+		}
+		if address == nil {
+			log.V(4).Info("waiting for IPAddressClaim to be allocated", "claim", claimName)
+			continue
+		}
+		cidr, err := ipAddressCIDR(address)
+		if err != nil {
+			return false, err
+		}
+		*allocation.cidrBlocks = []string{cidr}
+		log.V(2).Info("allocated CIDR block from IPAM pool", "network", allocation.name, "cidr", cidr)
+	}
+	return len(allocations) == 0, nil
+}
+
+// ipAddressCIDR returns the CIDR block of the network of an IPAddress. It fails when the IPAddress is a host address
+// rather than a network prefix, since several networks claiming host addresses from the same pool would otherwise get
+// the same CIDR block.
+func ipAddressCIDR(address *ipamv1.IPAddress) (string, error) {
+	addr, err := netip.ParseAddr(address.Spec.Address)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse address of IPAddress %s", address.Name)
+	}
+	prefix, err := addr.Prefix(int(ptr.Deref(address.Spec.Prefix, int32(addr.BitLen()))))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse prefix of IPAddress %s", address.Name)
+	}
+	if prefix.Addr() != addr {
+		return "", errors.Errorf("IPAddress %s is the host address %s of %s rather than a network prefix, the IPAM pool must allocate network prefixes", address.Name, addr, prefix)
+	}
+	return prefix.String(), nil
+}
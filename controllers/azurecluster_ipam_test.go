@@ -0,0 +1,244 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestReconcileIPAM(t *testing.T) {
+	s := runtime.NewScheme()
+	sb := runtime.NewSchemeBuilder(
+		infrav1.AddToScheme,
+		ipamv1.AddToScheme,
+	)
+	NewGomegaWithT(t).Expect(sb.AddToScheme(s)).To(Succeed())
+
+	poolRef := &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     "subnet-pool",
+	}
+	newAzureCluster := func() *infrav1.AzureCluster {
+		return &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-azure-cluster",
+				Namespace: "default",
+				UID:       "azure-cluster-uid",
+			},
+			Spec: infrav1.AzureClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}},
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetNode, Name: "node-subnet"},
+							IPAMPoolRef:     poolRef,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("claim is created and the allocation is pending", func(t *testing.T) {
+		g := NewWithT(t)
+		c := fakeclient.NewClientBuilder().WithScheme(s).Build()
+		r := &AzureClusterReconciler{Client: c, WatchFilterValue: "my-filter"}
+		azureCluster := newAzureCluster()
+
+		allocated, err := r.reconcileIPAM(t.Context(), azureCluster, "my-cluster")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(allocated).To(BeFalse())
+		g.Expect(azureCluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks).To(BeEmpty())
+
+		claim := &ipamv1.IPAddressClaim{}
+		g.Expect(c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "my-azure-cluster-node-subnet"}, claim)).To(Succeed())
+		g.Expect(claim.Spec.ClusterName).To(Equal("my-cluster"))
+		g.Expect(claim.Spec.PoolRef).To(Equal(ipamv1.IPPoolReference{
+			APIGroup: "ipam.cluster.x-k8s.io",
+			Kind:     "InClusterIPPool",
+			Name:     "subnet-pool",
+		}))
+		g.Expect(claim.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "my-cluster"))
+		g.Expect(claim.Labels).To(HaveKeyWithValue(clusterv1.WatchLabel, "my-filter"))
+		g.Expect(claim.OwnerReferences).To(HaveLen(1))
+		g.Expect(claim.OwnerReferences[0].Name).To(Equal("my-azure-cluster"))
+	})
+
+	t.Run("CIDR block is set from the allocated address", func(t *testing.T) {
+		g := NewWithT(t)
+		claim := &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-azure-cluster-node-subnet", Namespace: "default"},
+			Status: ipamv1.IPAddressClaimStatus{
+				AddressRef: ipamv1.IPAddressReference{Name: "node-subnet-address"},
+			},
+		}
+		address := &ipamv1.IPAddress{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-subnet-address", Namespace: "default"},
+			Spec: ipamv1.IPAddressSpec{
+				Address: "10.1.0.0",
+				Prefix:  ptr.To[int32](16),
+			},
+		}
+		c := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(claim, address).Build()
+		r := &AzureClusterReconciler{Client: c}
+		azureCluster := newAzureCluster()
+
+		allocated, err := r.reconcileIPAM(t.Context(), azureCluster, "my-cluster")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(allocated).To(BeFalse())
+		g.Expect(azureCluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks).To(Equal([]string{"10.1.0.0/16"}))
+
+		allocated, err = r.reconcileIPAM(t.Context(), azureCluster, "my-cluster")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(allocated).To(BeTrue())
+	})
+
+	t.Run("subnets claiming network prefixes from the same pool get distinct CIDR blocks", func(t *testing.T) {
+		g := NewWithT(t)
+		claims, addresses := sharedPoolAllocations("10.1.0.0", "10.1.1.0", 24)
+		c := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(append(claims, addresses...)...).Build()
+		r := &AzureClusterReconciler{Client: c}
+		azureCluster := newAzureCluster()
+		azureCluster.Spec.NetworkSpec.Subnets = append(azureCluster.Spec.NetworkSpec.Subnets, infrav1.SubnetSpec{
+			SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane, Name: "cp-subnet"},
+			IPAMPoolRef:     poolRef,
+		})
+
+		_, err := r.reconcileIPAM(t.Context(), azureCluster, "my-cluster")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(azureCluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks).To(Equal([]string{"10.1.0.0/24"}))
+		g.Expect(azureCluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks).To(Equal([]string{"10.1.1.0/24"}))
+	})
+
+	t.Run("subnets claiming host addresses from the same pool are rejected", func(t *testing.T) {
+		g := NewWithT(t)
+		claims, addresses := sharedPoolAllocations("10.1.0.4", "10.1.0.5", 16)
+		c := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(append(claims, addresses...)...).Build()
+		r := &AzureClusterReconciler{Client: c}
+		azureCluster := newAzureCluster()
+		azureCluster.Spec.NetworkSpec.Subnets = append(azureCluster.Spec.NetworkSpec.Subnets, infrav1.SubnetSpec{
+			SubnetClassSpec: infrav1.SubnetClassSpec{Role: infrav1.SubnetControlPlane, Name: "cp-subnet"},
+			IPAMPoolRef:     poolRef,
+		})
+
+		_, err := r.reconcileIPAM(t.Context(), azureCluster, "my-cluster")
+		g.Expect(err).To(MatchError(ContainSubstring("rather than a network prefix")))
+		g.Expect(azureCluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks).To(BeEmpty())
+		g.Expect(azureCluster.Spec.NetworkSpec.Subnets[1].CIDRBlocks).To(BeEmpty())
+	})
+
+	t.Run("no IPAM pools", func(t *testing.T) {
+		g := NewWithT(t)
+		c := fakeclient.NewClientBuilder().WithScheme(s).Build()
+		r := &AzureClusterReconciler{Client: c}
+		azureCluster := newAzureCluster()
+		azureCluster.Spec.NetworkSpec.Subnets[0].IPAMPoolRef = nil
+
+		allocated, err := r.reconcileIPAM(t.Context(), azureCluster, "my-cluster")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(allocated).To(BeTrue())
+	})
+}
+
+// sharedPoolAllocations returns the allocated claims of the node and control plane subnets of "my-azure-cluster" and the
+// addresses allocated for them from the same pool.
+func sharedPoolAllocations(nodeAddress, cpAddress string, prefix int32) (claims, addresses []client.Object) {
+	for subnet, address := range map[string]string{"node-subnet": nodeAddress, "cp-subnet": cpAddress} {
+		claims = append(claims, &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-azure-cluster-" + subnet, Namespace: "default"},
+			Status: ipamv1.IPAddressClaimStatus{
+				AddressRef: ipamv1.IPAddressReference{Name: subnet + "-address"},
+			},
+		})
+		addresses = append(addresses, &ipamv1.IPAddress{
+			ObjectMeta: metav1.ObjectMeta{Name: subnet + "-address", Namespace: "default"},
+			Spec: ipamv1.IPAddressSpec{
+				Address: address,
+				Prefix:  ptr.To(prefix),
+			},
+		})
+	}
+	return claims, addresses
+}
+
+func TestIPAddressCIDR(t *testing.T) {
+	tests := []struct {
+		name    string
+		address ipamv1.IPAddressSpec
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "network address",
+			address: ipamv1.IPAddressSpec{Address: "10.1.0.0", Prefix: ptr.To[int32](16)},
+			want:    "10.1.0.0/16",
+		},
+		{
+			name:    "host address",
+			address: ipamv1.IPAddressSpec{Address: "10.1.2.3", Prefix: ptr.To[int32](24)},
+			wantErr: true,
+		},
+		{
+			name:    "address without prefix",
+			address: ipamv1.IPAddressSpec{Address: "10.1.2.3"},
+			want:    "10.1.2.3/32",
+		},
+		{
+			name:    "IPv6 network address",
+			address: ipamv1.IPAddressSpec{Address: "2001:1234:5678:9abd::", Prefix: ptr.To[int32](64)},
+			want:    "2001:1234:5678:9abd::/64",
+		},
+		{
+			name:    "invalid address",
+			address: ipamv1.IPAddressSpec{Address: "not-an-address", Prefix: ptr.To[int32](16)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid prefix",
+			address: ipamv1.IPAddressSpec{Address: "10.1.0.0", Prefix: ptr.To[int32](33)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := ipAddressCIDR(&ipamv1.IPAddress{Spec: tc.address})
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(got).To(Equal(tc.want))
+			}
+		})
+	}
+}
@@ -22,8 +22,12 @@ import (
 	"github.com/pkg/errors"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/azurefirewalls"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
@@ -46,11 +50,13 @@ type azureClusterService struct {
 	scope *scope.ClusterScope
 	// services is the list of services that are reconciled by this controller.
 	// The order of the services is important as it determines the order in which the services are reconciled.
-	services  []azure.ServiceReconciler
-	skuCache  *resourceskus.Cache
-	Reconcile func(context.Context) error
-	Pause     func(context.Context) error
-	Delete    func(context.Context) error
+	services    []azure.ServiceReconciler
+	skuCache    *resourceskus.Cache
+	Reconcile   func(context.Context) error
+	Pause       func(context.Context) error
+	Delete      func(context.Context) error
+	Plan        func(context.Context) ([]infrav1.ResourceChange, error)
+	DetectDrift func(context.Context, bool) ([]infrav1.ResourceDrift, error)
 }
 
 // newAzureClusterService populates all the services based on input scope.
@@ -59,6 +65,10 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating a NewCache")
 	}
+	asgSvc, err := applicationsecuritygroups.New(scope)
+	if err != nil {
+		return nil, err
+	}
 	securityGroupsSvc, err := securitygroups.New(scope)
 	if err != nil {
 		return nil, err
@@ -83,27 +93,40 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 	if err != nil {
 		return nil, err
 	}
+	applicationGatewaysSvc, err := applicationgateways.New(scope)
+	if err != nil {
+		return nil, err
+	}
+	azureFirewallsSvc, err := azurefirewalls.New(scope)
+	if err != nil {
+		return nil, err
+	}
 	acs := &azureClusterService{
 		scope: scope,
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
 			virtualnetworks.New(scope),
+			asgSvc,
 			securityGroupsSvc,
 			routeTablesSvc,
 			publicIPsSvc,
 			natgateways.New(scope),
 			subnets.New(scope),
+			azureFirewallsSvc,
 			vnetPeeringsSvc,
 			loadbalancersSvc,
 			privateDNSSvc,
 			privateendpoints.New(scope),
 			bastionhosts.New(scope),
+			applicationGatewaysSvc,
 		},
 		skuCache: skuCache,
 	}
 	acs.Reconcile = acs.reconcile
 	acs.Pause = acs.pause
 	acs.Delete = acs.delete
+	acs.Plan = acs.plan
+	acs.DetectDrift = acs.detectDrift
 
 	return acs, nil
 }
@@ -123,7 +146,7 @@ func (s *azureClusterService) reconcile(ctx context.Context) error {
 	}
 
 	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
+		if err := azure.ReconcileService(ctx, service); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
 		}
 	}
@@ -141,7 +164,7 @@ func (s *azureClusterService) pause(ctx context.Context) error {
 		if !ok {
 			continue
 		}
-		if err := pauser.Pause(ctx); err != nil {
+		if err := azure.PauseService(ctx, service.Name(), pauser); err != nil {
 			return errors.Wrapf(err, "failed to pause AzureCluster service %s", service.Name())
 		}
 	}
@@ -149,19 +172,91 @@ func (s *azureClusterService) pause(ctx context.Context) error {
 	return nil
 }
 
+// Plan computes the changes that reconciling all the services would make, without making them.
+// Services which do not implement azure.Planner are skipped.
+func (s *azureClusterService) plan(ctx context.Context) ([]infrav1.ResourceChange, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Plan")
+	defer done()
+
+	if err := s.setFailureDomainsForLocation(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to get availability zones")
+	}
+	if s.scope.ControlPlaneEnabled() {
+		apiinternal.SetDefaultAzureClusterBackendPoolName(s.scope.AzureCluster)
+		s.scope.SetDNSName()
+		s.scope.SetControlPlaneSecurityRules()
+	}
+
+	var changes []infrav1.ResourceChange
+	for _, service := range s.services {
+		planner, ok := service.(azure.Planner)
+		if !ok {
+			continue
+		}
+		serviceChanges, err := planner.Plan(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan AzureCluster service %s", service.Name())
+		}
+		changes = append(changes, serviceChanges...)
+	}
+
+	return changes, nil
+}
+
+// detectDrift returns the resources of all the services which have drifted from their desired state, and corrects
+// them when correct is true. Services which do not implement azure.DriftDetector are skipped, and reported in the
+// drift status by unscannedServices.
+func (s *azureClusterService) detectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.DetectDrift")
+	defer done()
+
+	if s.scope.ControlPlaneEnabled() {
+		apiinternal.SetDefaultAzureClusterBackendPoolName(s.scope.AzureCluster)
+		s.scope.SetDNSName()
+		s.scope.SetControlPlaneSecurityRules()
+	}
+
+	var drifts []infrav1.ResourceDrift
+	for _, service := range s.services {
+		detector, ok := service.(azure.DriftDetector)
+		if !ok {
+			continue
+		}
+		serviceDrifts, err := detector.DetectDrift(ctx, correct)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to detect drift of AzureCluster service %s", service.Name())
+		}
+		drifts = append(drifts, serviceDrifts...)
+	}
+
+	return drifts, nil
+}
+
 // Delete reconciles all the services in a predetermined order.
 func (s *azureClusterService) delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Delete")
 	defer done()
 
-	if !ShouldDeleteIndividualResources(ctx, s.scope) {
+	if !ShouldDeleteIndividualResources(ctx, s.scope) && !isVnetOutsideResourceGroup(s.scope) {
 		// If the resource group is managed, delete it.
+		// An existing private DNS zone is not part of the resource group, so the API server record and the vnet links
+		// of the cluster need to be explicitly deleted from it.
+		if s.scope.ExistingPrivateDNSZoneID() != "" {
+			privateDNSSvc, err := s.getService(privatedns.ServiceName)
+			if err != nil {
+				return errors.Wrap(err, "failed to get private dns service")
+			}
+			if err := azure.DeleteService(ctx, privateDNSSvc); err != nil {
+				return errors.Wrap(err, "failed to delete private dns records and links")
+			}
+		}
+
 		// We need to explicitly delete vnet peerings, as it is not part of the resource group.
 		vnetPeeringsSvc, err := s.getService(vnetpeerings.ServiceName)
 		if err != nil {
 			return errors.Wrap(err, "failed to get vnet peerings service")
 		}
-		if err := vnetPeeringsSvc.Delete(ctx); err != nil {
+		if err := azure.DeleteService(ctx, vnetPeeringsSvc); err != nil {
 			return errors.Wrap(err, "failed to delete peerings")
 		}
 
@@ -171,14 +266,15 @@ func (s *azureClusterService) delete(ctx context.Context) error {
 		}
 
 		// Delete the entire resource group directly.
-		if err := groupSvc.Delete(ctx); err != nil {
+		if err := azure.DeleteService(ctx, groupSvc); err != nil {
 			return errors.Wrap(err, "failed to delete resource group")
 		}
 	} else {
-		// If the resource group is not managed we need to delete resources inside the group one by one.
+		// If the resource group is not managed, or if the vnet and its resources are outside of it, we need to delete
+		// resources one by one.
 		// services are deleted in reverse order from the order in which they are reconciled.
 		for i := len(s.services) - 1; i >= 0; i-- {
-			if err := s.services[i].Delete(ctx); err != nil {
+			if err := azure.DeleteService(ctx, s.services[i]); err != nil {
 				return errors.Wrapf(err, "failed to delete AzureCluster service %s", s.services[i].Name())
 			}
 		}
@@ -187,6 +283,16 @@ func (s *azureClusterService) delete(ctx context.Context) error {
 	return nil
 }
 
+// isVnetOutsideResourceGroup returns true if the vnet is in another subscription or resource group than the cluster. Its
+// network security groups and route tables are then in its resource group too, so they are not deleted along with the
+// resource group of the cluster.
+func isVnetOutsideResourceGroup(scope *scope.ClusterScope) bool {
+	if scope.VnetSubscriptionID() != scope.SubscriptionID() {
+		return true
+	}
+	return scope.Vnet().ResourceGroup != "" && scope.Vnet().ResourceGroup != scope.ResourceGroup()
+}
+
 // setFailureDomainsForLocation sets the AzureCluster Status failure domains based on which Azure Availability Zones are available in the cluster location.
 // Note that this is not done in a webhook as it requires API calls to fetch the availability zones.
 func (s *azureClusterService) setFailureDomainsForLocation(ctx context.Context) error {
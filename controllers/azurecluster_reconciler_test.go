@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -63,7 +64,7 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 				gomock.InOrder(
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("two"))
+					two.Name().Return("two").Times(2))
 			},
 		},
 	}
@@ -80,6 +81,9 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
@@ -154,6 +158,9 @@ func TestAzureClusterServicePause(t *testing.T) {
 			svcThreeMock := newPausingServiceReconciler()
 
 			tc.expect(svcOneMock, svcTwoMock, svcThreeMock)
+			svcOneMock.MockServiceReconciler.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.MockServiceReconciler.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.MockServiceReconciler.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureClusterService{
 				services: []azure.ServiceReconciler{
@@ -174,6 +181,89 @@ func TestAzureClusterServicePause(t *testing.T) {
 	}
 }
 
+func TestAzureClusterServicePlan(t *testing.T) {
+	type planningServiceReconciler struct {
+		*mock_azure.MockServiceReconciler
+		*mock_azure.MockPlanner
+	}
+
+	cases := map[string]struct {
+		expectedError   string
+		expectedChanges []infrav1.ResourceChange
+		expect          func(one planningServiceReconciler, two planningServiceReconciler, three *mock_azure.MockServiceReconcilerMockRecorder)
+	}{
+		"changes from planning services are collected in order": {
+			expectedChanges: []infrav1.ResourceChange{
+				{ServiceName: "one", ResourceName: "a", Action: infrav1.ResourceChangeActionCreate},
+				{ServiceName: "two", ResourceName: "b", Action: infrav1.ResourceChangeActionNone},
+			},
+			expect: func(one planningServiceReconciler, two planningServiceReconciler, _ *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					one.MockPlanner.EXPECT().Plan(gomockinternal.AContext()).Return([]infrav1.ResourceChange{
+						{ServiceName: "one", ResourceName: "a", Action: infrav1.ResourceChangeActionCreate},
+					}, nil),
+					two.MockPlanner.EXPECT().Plan(gomockinternal.AContext()).Return([]infrav1.ResourceChange{
+						{ServiceName: "two", ResourceName: "b", Action: infrav1.ResourceChangeActionNone},
+					}, nil))
+			},
+		},
+		"service plan fails": {
+			expectedError: "failed to plan AzureCluster service two: some error happened",
+			expect: func(one planningServiceReconciler, two planningServiceReconciler, _ *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					one.MockPlanner.EXPECT().Plan(gomockinternal.AContext()).Return(nil, nil),
+					two.MockPlanner.EXPECT().Plan(gomockinternal.AContext()).Return(nil, errors.New("some error happened")),
+					two.MockServiceReconciler.EXPECT().Name().Return("two"))
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			newPlanningServiceReconciler := func() planningServiceReconciler {
+				return planningServiceReconciler{
+					mock_azure.NewMockServiceReconciler(mockCtrl),
+					mock_azure.NewMockPlanner(mockCtrl),
+				}
+			}
+			svcOneMock := newPlanningServiceReconciler()
+			svcTwoMock := newPlanningServiceReconciler()
+			// The third service does not implement azure.Planner and is skipped.
+			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+
+			tc.expect(svcOneMock, svcTwoMock, svcThreeMock.EXPECT())
+
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					Cluster:      &clusterv1.Cluster{},
+					AzureCluster: &infrav1.AzureCluster{},
+				},
+				services: []azure.ServiceReconciler{
+					svcOneMock,
+					svcTwoMock,
+					svcThreeMock,
+				},
+				skuCache: resourceskus.NewStaticCache([]armcompute.ResourceSKU{}, ""),
+			}
+
+			changes, err := s.plan(t.Context())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(changes).To(Equal(tc.expectedChanges))
+			}
+		})
+	}
+}
+
 func TestAzureClusterServiceDelete(t *testing.T) {
 	clusterName := "cluster"
 	azClusterName := "azCluster"
@@ -320,7 +410,7 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 				gomock.InOrder(
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("two"))
+					two.Name().Return("two").Times(2))
 			},
 		},
 	}
@@ -339,6 +429,11 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			groupsMock.EXPECT().Name().Return(groups.ServiceName).AnyTimes()
+			vnetpeeringsMock.EXPECT().Name().Return(vnetpeerings.ServiceName).AnyTimes()
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 			c := tc.clientBuilder(g)
 
 			s := &azureClusterService{
@@ -386,3 +481,136 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureClusterServiceDeleteOutsideManagedResourceGroup(t *testing.T) {
+	clusterName := "cluster"
+	azClusterName := "azCluster"
+	namespace := "ns"
+	resourceGroup := "rg"
+
+	cases := map[string]struct {
+		networkSpec infrav1.NetworkSpec
+		expect      func(grp, vpr, sg, dns *mock_azure.MockServiceReconcilerMockRecorder)
+	}{
+		"private DNS zone managed along with the resource group": {
+			networkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{ResourceGroup: resourceGroup},
+			},
+			expect: func(grp, vpr, _, _ *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"existing private DNS zone": {
+			networkSpec: infrav1.NetworkSpec{
+				Vnet:             infrav1.VnetSpec{ResourceGroup: resourceGroup},
+				PrivateDNSZoneID: "/subscriptions/123/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/example.com",
+			},
+			expect: func(grp, vpr, _, dns *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					dns.Delete(gomockinternal.AContext()).Return(nil),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"vnet in another subscription": {
+			networkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{ResourceGroup: "vnet-rg", SubscriptionID: "456"},
+			},
+			expect: func(grp, vpr, sg, dns *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					dns.Delete(gomockinternal.AContext()).Return(nil),
+					sg.Delete(gomockinternal.AContext()).Return(nil),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"vnet in another resource group": {
+			networkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{ResourceGroup: "vnet-rg"},
+			},
+			expect: func(grp, vpr, sg, dns *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					dns.Delete(gomockinternal.AContext()).Return(nil),
+					sg.Delete(gomockinternal.AContext()).Return(nil),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			groupsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+			vnetpeeringsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+			securityGroupsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+			privateDNSMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+
+			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), securityGroupsMock.EXPECT(), privateDNSMock.EXPECT())
+			groupsMock.EXPECT().Name().Return(groups.ServiceName).AnyTimes()
+			vnetpeeringsMock.EXPECT().Name().Return(vnetpeerings.ServiceName).AnyTimes()
+			securityGroupsMock.EXPECT().Name().Return("securitygroups").AnyTimes()
+			privateDNSMock.EXPECT().Name().Return(privatedns.ServiceName).AnyTimes()
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(asoresourcesv1.AddToScheme(scheme)).To(Succeed())
+			rg := &asoresourcesv1.ResourceGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceGroup,
+					Namespace: namespace,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion:         infrav1.GroupVersion.String(),
+							Kind:               infrav1.AzureClusterKind,
+							Name:               azClusterName,
+							Controller:         ptr.To(true),
+							BlockOwnerDeletion: ptr.To(true),
+						},
+					},
+					Annotations: map[string]string{
+						asoannotations.ReconcilePolicy: string(asoannotations.ReconcilePolicyManage),
+					},
+				},
+			}
+
+			s := &azureClusterService{
+				scope: &scope.ClusterScope{
+					Client: fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(rg).Build(),
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      azClusterName,
+							Namespace: namespace,
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: resourceGroup,
+							NetworkSpec:   tc.networkSpec,
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              clusterName,
+							Namespace:         namespace,
+							DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						},
+					},
+				},
+				services: []azure.ServiceReconciler{
+					groupsMock,
+					vnetpeeringsMock,
+					securityGroupsMock,
+					privateDNSMock,
+				},
+				skuCache: resourceskus.NewStaticCache([]armcompute.ResourceSKU{}, ""),
+			}
+
+			g.Expect(s.delete(t.Context())).To(Succeed())
+		})
+	}
+}
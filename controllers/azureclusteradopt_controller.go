@@ -0,0 +1,262 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/adoption"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// adoptedBootstrapSecretSuffix is the suffix of the name of the placeholder bootstrap data secret of an adopted machine.
+const adoptedBootstrapSecretSuffix = "-adopted-bootstrap"
+
+// AzureClusterAdoptReconciler adopts the Azure resources of a self-managed cluster into an AzureCluster.
+type AzureClusterAdoptReconciler struct {
+	client.Client
+	Recorder        record.EventRecorder
+	Timeouts        reconciler.Timeouts
+	CredentialCache azure.CredentialCache
+
+	newAdoptionClient func(azure.Authorizer) (adoption.Client, error)
+}
+
+// NewAzureClusterAdoptReconciler returns a new AzureClusterAdoptReconciler instance.
+func NewAzureClusterAdoptReconciler(client client.Client, recorder record.EventRecorder, timeouts reconciler.Timeouts, credCache azure.CredentialCache) *AzureClusterAdoptReconciler {
+	return &AzureClusterAdoptReconciler{
+		Client:          client,
+		Recorder:        recorder,
+		Timeouts:        timeouts,
+		CredentialCache: credCache,
+		newAdoptionClient: func(auth azure.Authorizer) (adoption.Client, error) {
+			return adoption.NewClient(auth)
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AzureClusterAdoptReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager, options controller.Options) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named("azureclusteradopt").
+		WithOptions(options).
+		For(&infrav1.AzureCluster{}).
+		WithEventFilter(predicate.Funcs{
+			CreateFunc: func(ev event.CreateEvent) bool {
+				return ev.Object.GetAnnotations()[adoptAnnotation] == adoptAnnotationValue
+			},
+			// The owner reference to the Cluster may be set after the annotation, so every update of an
+			// AzureCluster waiting to be adopted is reconciled.
+			UpdateFunc: func(ev event.UpdateEvent) bool {
+				return ev.ObjectNew.GetAnnotations()[adoptAnnotation] == adoptAnnotationValue
+			},
+			DeleteFunc:  func(_ event.DeleteEvent) bool { return false },
+			GenericFunc: func(_ event.GenericEvent) bool { return false },
+		}).
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachines,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+
+// Reconcile discovers the Azure resources of the self-managed cluster in the resource group of an AzureCluster
+// annotated for adoption, tags them as owned by the cluster and describes them with the AzureCluster and new
+// AzureMachines.
+func (r *AzureClusterAdoptReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeouts.DefaultedLoopTimeout())
+	defer cancel()
+
+	ctx, log, done := tele.StartSpanWithLogger(ctx,
+		"controllers.AzureClusterAdoptReconciler.Reconcile",
+		tele.KVP("namespace", req.Namespace),
+		tele.KVP("name", req.Name),
+		tele.KVP("kind", infrav1.AzureClusterKind),
+	)
+	defer done()
+
+	azureCluster := &infrav1.AzureCluster{}
+	err := r.Get(ctx, req.NamespacedName, azureCluster)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if azureCluster.GetAnnotations()[adoptAnnotation] != adoptAnnotationValue || !azureCluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, azureCluster.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		log.Info("Cluster Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	// The scope is only used to authenticate and is not closed, so that it does not patch the AzureCluster.
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Client:          r.Client,
+		Cluster:         cluster,
+		AzureCluster:    azureCluster,
+		Timeouts:        r.Timeouts,
+		CredentialCache: r.CredentialCache,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create scope")
+	}
+
+	adoptionClient, err := r.newAdoptionClient(clusterScope)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create adoption client")
+	}
+
+	log.Info("discovering resources to adopt", "resourceGroup", clusterScope.ResourceGroup())
+	inventory, err := adoption.Discover(ctx, adoptionClient, clusterScope.ResourceGroup(), azureCluster.Spec.NetworkSpec.Vnet.Name, clusterScope.APIServerFrontendPort())
+	if err != nil {
+		err = errors.Wrap(err, "failed to discover resources to adopt")
+		r.Recorder.Event(azureCluster, corev1.EventTypeWarning, "AdoptionFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if err := r.adopt(ctx, adoptionClient, cluster, azureCluster, inventory); err != nil {
+		r.Recorder.Event(azureCluster, corev1.EventTypeWarning, "AdoptionFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(azureCluster, corev1.EventTypeNormal, "Adopted", "Adopted the resources in resource group %s and %d machines", inventory.ResourceGroup, len(inventory.Machines))
+
+	return ctrl.Result{}, nil
+}
+
+// adopt tags the resources in the inventory as owned by the cluster, creates a Machine and an AzureMachine for each
+// adoptable virtual machine and finally updates the AzureCluster to describe the resources. Creating the objects is
+// idempotent so that a failed adoption can be retried.
+func (r *AzureClusterAdoptReconciler) adopt(ctx context.Context, adoptionClient adoption.Client, cluster *clusterv1.Cluster, azureCluster *infrav1.AzureCluster, inventory *adoption.Inventory) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureClusterAdoptReconciler.adopt")
+	defer done()
+
+	tags := infrav1.Tags{infrav1.ClusterTagKey(cluster.Name): string(infrav1.ResourceLifecycleOwned)}
+	for _, id := range inventory.ResourceIDs() {
+		if err := adoptionClient.MergeTags(ctx, id, tags); err != nil {
+			return errors.Wrapf(err, "failed to tag resource %s", id)
+		}
+	}
+
+	for name, reason := range inventory.Skipped {
+		log.Info("not adopting virtual machine", "name", name, "reason", reason)
+		r.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "VirtualMachineNotAdopted", "Virtual machine %s cannot be adopted: %s", name, reason)
+	}
+
+	for _, machine := range inventory.Machines {
+		if err := r.createMachine(ctx, cluster, azureCluster, machine); err != nil {
+			return errors.Wrapf(err, "failed to create machine %s", machine.Name)
+		}
+	}
+
+	before := azureCluster.DeepCopy()
+	inventory.ApplyTo(&azureCluster.Spec)
+	azureCluster.Annotations[adoptAnnotation] = adoptedAnnotationValue
+	if err := r.Patch(ctx, azureCluster, client.MergeFrom(before)); err != nil {
+		return errors.Wrap(err, "failed to update AzureCluster")
+	}
+
+	return nil
+}
+
+// createMachine creates the AzureMachine and the Machine of an adopted virtual machine. The Machine refers to a
+// placeholder bootstrap data secret since the virtual machine is already bootstrapped.
+func (r *AzureClusterAdoptReconciler) createMachine(ctx context.Context, cluster *clusterv1.Cluster, azureCluster *infrav1.AzureCluster, machine adoption.Machine) error {
+	labels := map[string]string{clusterv1.ClusterNameLabel: cluster.Name}
+	if machine.ControlPlane {
+		labels[clusterv1.MachineControlPlaneLabel] = ""
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: azureCluster.Namespace,
+			Name:      machine.Name + adoptedBootstrapSecretSuffix,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+		},
+		Type: clusterv1.ClusterSecretType,
+		Data: map[string][]byte{
+			"value":  {},
+			"format": []byte("cloud-config"),
+		},
+	}
+	if err := r.Create(ctx, secret); client.IgnoreAlreadyExists(err) != nil {
+		return err
+	}
+
+	azureMachine := &infrav1.AzureMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: azureCluster.Namespace,
+			Name:      machine.Name,
+			Labels:    labels,
+		},
+		Spec: machine.Spec,
+	}
+	if err := r.Create(ctx, azureMachine); client.IgnoreAlreadyExists(err) != nil {
+		return err
+	}
+
+	capiMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: azureCluster.Namespace,
+			Name:      machine.Name,
+			Labels:    labels,
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: cluster.Name,
+			Bootstrap: clusterv1.Bootstrap{
+				DataSecretName: ptr.To(secret.Name),
+			},
+			InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+				APIGroup: infrav1.GroupVersion.Group,
+				Kind:     infrav1.AzureMachineKind,
+				Name:     azureMachine.Name,
+			},
+			FailureDomain: machine.FailureDomain,
+		},
+	}
+	if err := r.Create(ctx, capiMachine); client.IgnoreAlreadyExists(err) != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/adoption"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/adoption/mock_adoption"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+)
+
+func newAdoptTestObjects() (*clusterv1.Cluster, *infrav1.AzureCluster, *adoption.Inventory) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-cluster",
+			Namespace:   "default",
+			Annotations: map[string]string{adoptAnnotation: adoptAnnotationValue},
+		},
+		Spec: infrav1.AzureClusterSpec{ResourceGroup: "my-rg"},
+	}
+	inventory := &adoption.Inventory{
+		ResourceGroup: "my-rg",
+		VirtualNetwork: armnetwork.VirtualNetwork{
+			ID:   ptr.To("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"),
+			Name: ptr.To("my-vnet"),
+		},
+		Machines: []adoption.Machine{
+			{
+				Name:          "cp-0",
+				ControlPlane:  true,
+				FailureDomain: "1",
+				Spec:          infrav1.AzureMachineSpec{VMSize: "Standard_D2s_v3"},
+				ResourceIDs:   []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/cp-0"},
+			},
+			{
+				Name:        "node-0",
+				Spec:        infrav1.AzureMachineSpec{VMSize: "Standard_D2s_v3"},
+				ResourceIDs: []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/node-0"},
+			},
+		},
+		Skipped: map[string]string{"stray": "virtual machine has no network interfaces"},
+	}
+	return cluster, azureCluster, inventory
+}
+
+func TestAzureClusterAdoptReconcilerAdopt(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	s := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(s)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(s)).To(Succeed())
+	g.Expect(corev1.AddToScheme(s)).To(Succeed())
+
+	cluster, azureCluster, inventory := newAdoptTestObjects()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(cluster, azureCluster).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &AzureClusterAdoptReconciler{Client: c, Recorder: recorder}
+
+	mockCtrl := gomock.NewController(t)
+	adoptionClient := mock_adoption.NewMockClient(mockCtrl)
+	ownedTags := infrav1.Tags{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned"}
+	for _, id := range inventory.ResourceIDs() {
+		adoptionClient.EXPECT().MergeTags(gomockinternal.AContext(), id, ownedTags).Return(nil)
+	}
+
+	g.Expect(r.adopt(ctx, adoptionClient, cluster, azureCluster, inventory)).To(Succeed())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("Virtual machine stray cannot be adopted")))
+
+	updated := &infrav1.AzureCluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(azureCluster), updated)).To(Succeed())
+	g.Expect(updated.Annotations).To(HaveKeyWithValue(adoptAnnotation, adoptedAnnotationValue))
+	g.Expect(updated.Spec.NetworkSpec.Vnet.Name).To(Equal("my-vnet"))
+	g.Expect(updated.Spec.NetworkSpec.Vnet.ResourceGroup).To(Equal("my-rg"))
+
+	for _, m := range inventory.Machines {
+		key := types.NamespacedName{Namespace: "default", Name: m.Name}
+
+		azureMachine := &infrav1.AzureMachine{}
+		g.Expect(c.Get(ctx, key, azureMachine)).To(Succeed())
+		g.Expect(azureMachine.Spec).To(Equal(m.Spec))
+		g.Expect(azureMachine.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "my-cluster"))
+
+		machine := &clusterv1.Machine{}
+		g.Expect(c.Get(ctx, key, machine)).To(Succeed())
+		g.Expect(machine.Spec.ClusterName).To(Equal("my-cluster"))
+		g.Expect(machine.Spec.FailureDomain).To(Equal(m.FailureDomain))
+		g.Expect(machine.Spec.InfrastructureRef.Kind).To(Equal(infrav1.AzureMachineKind))
+		g.Expect(machine.Spec.Bootstrap.DataSecretName).To(Equal(ptr.To(m.Name + adoptedBootstrapSecretSuffix)))
+		if m.ControlPlane {
+			g.Expect(machine.Labels).To(HaveKey(clusterv1.MachineControlPlaneLabel))
+		} else {
+			g.Expect(machine.Labels).NotTo(HaveKey(clusterv1.MachineControlPlaneLabel))
+		}
+
+		secret := &corev1.Secret{}
+		g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: m.Name + adoptedBootstrapSecretSuffix}, secret)).To(Succeed())
+		g.Expect(secret.Data).To(HaveKey("value"))
+	}
+}
+
+func TestAzureClusterAdoptReconcilerAdoptTagFailure(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	s := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(s)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(s)).To(Succeed())
+	g.Expect(corev1.AddToScheme(s)).To(Succeed())
+
+	cluster, azureCluster, inventory := newAdoptTestObjects()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(cluster, azureCluster).Build()
+	r := &AzureClusterAdoptReconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+
+	mockCtrl := gomock.NewController(t)
+	adoptionClient := mock_adoption.NewMockClient(mockCtrl)
+	adoptionClient.EXPECT().MergeTags(gomockinternal.AContext(), gomock.Any(), gomock.Any()).Return(errors.New("forbidden"))
+
+	err := r.adopt(ctx, adoptionClient, cluster, azureCluster, inventory)
+	g.Expect(err).To(MatchError(ContainSubstring("forbidden")))
+
+	// Nothing is created and the AzureCluster is still waiting to be adopted, so that adoption is retried.
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "cp-0"}, &clusterv1.Machine{})).NotTo(Succeed())
+	updated := &infrav1.AzureCluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(azureCluster), updated)).To(Succeed())
+	g.Expect(updated.Annotations).To(HaveKeyWithValue(adoptAnnotation, adoptAnnotationValue))
+}
+
+func TestAzureClusterAdoptReconcileWithoutAnnotation(t *testing.T) {
+	g := NewWithT(t)
+
+	s := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(s)).To(Succeed())
+
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(azureCluster).Build()
+	r := NewAzureClusterAdoptReconciler(c, record.NewFakeRecorder(10), reconciler.Timeouts{}, nil)
+
+	result, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(azureCluster)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+}
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/utils/ptr"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
@@ -40,8 +42,11 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -120,6 +125,8 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 				predicates.ResourceHasFilterLabel(mgr.GetScheme(), log, amr.WatchFilterValue),
 			),
 		).
+		// watch for the allocation of the private IP addresses of the AzureMachines
+		Owns(&ipamv1.IPAddressClaim{}).
 		Complete(r)
 }
 
@@ -128,6 +135,8 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 
 // Reconcile idempotently gets, creates, and updates a machine.
 func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -152,6 +161,7 @@ func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 		return reconcile.Result{}, err
 	}
+	ctx = audit.WithRequester(ctx, infrav1.GroupVersion.WithKind(infrav1.AzureMachineKind), azureMachine)
 
 	// Fetch the Machine.
 	machine, err := util.GetOwnerMachine(ctx, amr.Client, azureMachine.ObjectMeta)
@@ -286,6 +296,8 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to init machine scope cache")
 	}
 
+	machineScope.AzureMachine.Status.EstimatedMonthlyCost = cost.Update(cost.NewKey(machineScope.AzureMachine, infrav1.AzureMachineKind, machineScope.ClusterName()), machineScope.CostResources())
+
 	// Mark the AzureMachine as failed if the identities are not ready.
 	cond := v1beta1conditions.Get(machineScope.AzureMachine, infrav1.VMIdentitiesReadyCondition)
 	if cond != nil && cond.Status == corev1.ConditionFalse && cond.Reason == infrav1.UserAssignedIdentityMissingReason {
@@ -295,11 +307,26 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.New("VM identities are not ready")
 	}
 
+	// The private IP addresses allocated from IPAM pools are needed by the specs of the network interfaces, so they are
+	// allocated first.
+	allocated, err := amr.reconcileIPAddresses(ctx, machineScope.AzureMachine, machineScope.ClusterName())
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to allocate private IP addresses from IPAM pools")
+	}
+	if !allocated {
+		log.Info("Private IP addresses are not allocated yet")
+		v1beta1conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, infrav1.WaitingForIPAddressAllocationReason, clusterv1beta1.ConditionSeverityInfo, "")
+		return reconcile.Result{}, nil
+	}
+
 	ams, err := amr.createAzureMachineService(machineScope)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to create azure machine service")
 	}
 
+	// Scan for drift before reconciling the services, since reconciling may update drifted resources.
+	amr.reconcileDrift(ctx, machineScope, clusterScope, ams)
+
 	if err := ams.Reconcile(ctx); err != nil {
 		// This means that a VM was created and managed by this controller, but is not present anymore.
 		// In this case, we mark it as failed and leave it to MHC for remediation
@@ -338,10 +365,40 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 	}
 
 	machineScope.SetReady()
+	machineScope.SetBastionConnection(clusterScope.AzureBastionID())
+
+	if detection := clusterScope.AzureCluster.Spec.DriftDetection; detection != nil {
+		return reconcile.Result{RequeueAfter: max(nextDriftScan(detection, machineScope.AzureMachine.Status.Drift, time.Now()), time.Second)}, nil
+	}
 
 	return reconcile.Result{}, nil
 }
 
+// reconcileDrift scans the Azure resources of a ready AzureMachine for drift when drift detection is enabled on its
+// AzureCluster and the last scan is older than its interval. A failed scan is reported and does not prevent
+// reconciliation.
+func (amr *AzureMachineReconciler) reconcileDrift(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope, ams *azureMachineService) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.reconcileDrift")
+	defer done()
+
+	azureMachine := machineScope.AzureMachine
+	detection := clusterScope.AzureCluster.Spec.DriftDetection
+	if detection == nil {
+		azureMachine.Status.Drift = nil
+		return
+	}
+	if !azureMachine.Status.Ready || nextDriftScan(detection, azureMachine.Status.Drift, time.Now()) > 0 {
+		return
+	}
+
+	status, err := scanDrift(ctx, amr.Recorder, azureMachine, detection, azureMachine.Status.Drift, ams.DetectDrift)
+	if err != nil {
+		log.Error(err, "failed to detect drift of AzureMachine")
+	}
+	status.UnscannedServices = unscannedServices(ams.services)
+	azureMachine.Status.Drift = status
+}
+
 func (amr *AzureMachineReconciler) reconcilePause(ctx context.Context, machineScope *scope.MachineScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachine.reconcilePause")
 	defer done()
@@ -399,9 +456,16 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 		log.Info("Skipping AzureMachine Deletion; will delete whole resource group.")
 	}
 
+	// The network interfaces are deleted, so their private IP addresses can be released back to their IPAM pools.
+	if err := amr.releaseIPAddresses(ctx, machineScope.AzureMachine); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to release private IP addresses")
+	}
+
 	// we're done deleting this AzureMachine so remove the finalizer.
 	log.Info("Removing finalizer from AzureMachine")
 	controllerutil.RemoveFinalizer(machineScope.AzureMachine, infrav1.MachineFinalizer)
+	futures.Untrack(machineScope.AzureMachine)
+	cost.Forget(cost.NewKey(machineScope.AzureMachine, infrav1.AzureMachineKind, machineScope.ClusterName()))
 
 	return reconcile.Result{}, nil
 }
@@ -0,0 +1,73 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// reconcileIPAddresses allocates the static private IP addresses of the network interfaces of an AzureMachine which
+// reference an IPAM pool by claiming an IPAddress from the pool for each of them. The IPAddressClaims are named after
+// the network interfaces. It returns false while an allocation is pending.
+func (amr *AzureMachineReconciler) reconcileIPAddresses(ctx context.Context, azureMachine *infrav1.AzureMachine, clusterName string) (bool, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.reconcileIPAddresses")
+	defer done()
+
+	allocated := true
+	isMultiNIC := len(azureMachine.Spec.NetworkInterfaces) > 1
+	for i := range azureMachine.Spec.NetworkInterfaces {
+		nic := &azureMachine.Spec.NetworkInterfaces[i]
+		if !nic.IsIPAMAllocationPending() {
+			continue
+		}
+		claimName := azure.GenerateNICName(azureMachine.Name, isMultiNIC, i)
+		address, err := reconcileIPAddressClaim(ctx, amr.Client, azureMachine, claimName, clusterName, amr.WatchFilterValue, nic.IPAMPoolRef)
+		if err != nil {
+			return false, err
+		}
+		if address == nil {
+			log.V(4).Info("waiting for IPAddressClaim to be allocated", "claim", claimName)
+			allocated = false
+			continue
+		}
+		nic.PrivateIPAddress = address.Spec.Address
+		log.V(2).Info("allocated private IP address from IPAM pool", "networkInterface", claimName, "address", nic.PrivateIPAddress)
+	}
+	return allocated, nil
+}
+
+// releaseIPAddresses deletes the IPAddressClaims of the network interfaces of an AzureMachine which reference an IPAM
+// pool to release their private IP addresses once the network interfaces are deleted.
+func (amr *AzureMachineReconciler) releaseIPAddresses(ctx context.Context, azureMachine *infrav1.AzureMachine) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.releaseIPAddresses")
+	defer done()
+
+	isMultiNIC := len(azureMachine.Spec.NetworkInterfaces) > 1
+	for i, nic := range azureMachine.Spec.NetworkInterfaces {
+		if nic.IPAMPoolRef == nil {
+			continue
+		}
+		if err := deleteIPAddressClaim(ctx, amr.Client, azureMachine.Namespace, azure.GenerateNICName(azureMachine.Name, isMultiNIC, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
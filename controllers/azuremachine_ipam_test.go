@@ -0,0 +1,135 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestReconcileIPAddresses(t *testing.T) {
+	s := runtime.NewScheme()
+	sb := runtime.NewSchemeBuilder(
+		infrav1.AddToScheme,
+		ipamv1.AddToScheme,
+	)
+	NewGomegaWithT(t).Expect(sb.AddToScheme(s)).To(Succeed())
+
+	newAzureMachine := func() *infrav1.AzureMachine {
+		return &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-machine",
+				Namespace: "default",
+				UID:       "azure-machine-uid",
+			},
+			Spec: infrav1.AzureMachineSpec{
+				NetworkInterfaces: []infrav1.NetworkInterface{
+					{
+						SubnetName:       "node-subnet",
+						PrivateIPConfigs: 1,
+						IPAMPoolRef: &corev1.TypedLocalObjectReference{
+							APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+							Kind:     "InClusterIPPool",
+							Name:     "node-pool",
+						},
+					},
+					{
+						SubnetName:       "other-subnet",
+						PrivateIPConfigs: 1,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("claim is created and the allocation is pending", func(t *testing.T) {
+		g := NewWithT(t)
+		c := fakeclient.NewClientBuilder().WithScheme(s).Build()
+		r := &AzureMachineReconciler{Client: c}
+		azureMachine := newAzureMachine()
+
+		allocated, err := r.reconcileIPAddresses(t.Context(), azureMachine, "my-cluster")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(allocated).To(BeFalse())
+		g.Expect(azureMachine.Spec.NetworkInterfaces[0].PrivateIPAddress).To(BeEmpty())
+
+		claim := &ipamv1.IPAddressClaim{}
+		g.Expect(c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "my-machine-nic-0"}, claim)).To(Succeed())
+		g.Expect(claim.Spec.ClusterName).To(Equal("my-cluster"))
+		g.Expect(claim.Spec.PoolRef.Name).To(Equal("node-pool"))
+		g.Expect(claim.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "my-cluster"))
+		g.Expect(claim.OwnerReferences).To(HaveLen(1))
+
+		g.Expect(c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "my-machine-nic-1"}, &ipamv1.IPAddressClaim{})).NotTo(Succeed())
+	})
+
+	t.Run("private IP address is set from the allocated address", func(t *testing.T) {
+		g := NewWithT(t)
+		claim := &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-machine-nic-0", Namespace: "default"},
+			Status: ipamv1.IPAddressClaimStatus{
+				AddressRef: ipamv1.IPAddressReference{Name: "my-machine-address"},
+			},
+		}
+		address := &ipamv1.IPAddress{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-machine-address", Namespace: "default"},
+			Spec: ipamv1.IPAddressSpec{
+				Address: "10.1.0.10",
+				Prefix:  ptr.To[int32](24),
+			},
+		}
+		c := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(claim, address).Build()
+		r := &AzureMachineReconciler{Client: c}
+		azureMachine := newAzureMachine()
+
+		allocated, err := r.reconcileIPAddresses(t.Context(), azureMachine, "my-cluster")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(allocated).To(BeTrue())
+		g.Expect(azureMachine.Spec.NetworkInterfaces[0].PrivateIPAddress).To(Equal("10.1.0.10"))
+		g.Expect(azureMachine.Spec.NetworkInterfaces[1].PrivateIPAddress).To(BeEmpty())
+	})
+
+	t.Run("claims are released", func(t *testing.T) {
+		g := NewWithT(t)
+		claim := &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-machine-nic-0", Namespace: "default"},
+		}
+		c := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(claim).Build()
+		r := &AzureMachineReconciler{Client: c}
+		azureMachine := newAzureMachine()
+		azureMachine.Spec.NetworkInterfaces[0].PrivateIPAddress = "10.1.0.10"
+
+		g.Expect(r.releaseIPAddresses(t.Context(), azureMachine)).To(Succeed())
+		err := c.Get(t.Context(), client.ObjectKeyFromObject(claim), &ipamv1.IPAddressClaim{})
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		// Releasing the claims again is a no-op.
+		g.Expect(r.releaseIPAddresses(t.Context(), azureMachine)).To(Succeed())
+	})
+}
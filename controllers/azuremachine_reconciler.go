@@ -21,6 +21,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/availabilitysets"
@@ -42,11 +43,12 @@ type azureMachineService struct {
 	scope *scope.MachineScope
 	// services is the list of services to be reconciled.
 	// The order of the services is important as it determines the order in which the services are reconciled.
-	services  []azure.ServiceReconciler
-	skuCache  *resourceskus.Cache
-	Reconcile func(context.Context) error
-	Pause     func(context.Context) error
-	Delete    func(context.Context) error
+	services    []azure.ServiceReconciler
+	skuCache    *resourceskus.Cache
+	Reconcile   func(context.Context) error
+	Pause       func(context.Context) error
+	Delete      func(context.Context) error
+	DetectDrift func(context.Context, bool) ([]infrav1.ResourceDrift, error)
 }
 
 // newAzureMachineService populates all the services based on input scope.
@@ -79,7 +81,7 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating tags service")
 	}
-	virtualmachinesSvc, err := virtualmachines.New(machineScope)
+	virtualmachinesSvc, err := virtualmachines.New(machineScope, cache)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed creating virtualmachines service")
 	}
@@ -109,6 +111,7 @@ func newAzureMachineService(machineScope *scope.MachineScope) (*azureMachineServ
 	ams.Reconcile = ams.reconcile
 	ams.Pause = ams.pause
 	ams.Delete = ams.delete
+	ams.DetectDrift = ams.detectDrift
 
 	return ams, nil
 }
@@ -126,7 +129,7 @@ func (s *azureMachineService) reconcile(ctx context.Context) error {
 	}
 
 	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
+		if err := azure.ReconcileService(ctx, service); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureMachine service %s", service.Name())
 		}
 	}
@@ -144,7 +147,7 @@ func (s *azureMachineService) pause(ctx context.Context) error {
 		if !ok {
 			continue
 		}
-		if err := pauser.Pause(ctx); err != nil {
+		if err := azure.PauseService(ctx, service.Name(), pauser); err != nil {
 			return errors.Wrapf(err, "failed to pause AzureMachine service %s", service.Name())
 		}
 	}
@@ -152,6 +155,35 @@ func (s *azureMachineService) pause(ctx context.Context) error {
 	return nil
 }
 
+// detectDrift returns the resources of all the services which have drifted from their desired state, and corrects
+// them when correct is true. Services which do not implement azure.DriftDetector are skipped, and reported in the
+// drift status by unscannedServices.
+func (s *azureMachineService) detectDrift(ctx context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureMachineService.detectDrift")
+	defer done()
+
+	apiinternal.SetDefaultAzureMachineSpecNetworkInterfaces(&s.scope.AzureMachine.Spec)
+
+	if err := s.scope.SetSubnetName(); err != nil {
+		return nil, errors.Wrap(err, "failed defaulting subnet name")
+	}
+
+	var drifts []infrav1.ResourceDrift
+	for _, service := range s.services {
+		detector, ok := service.(azure.DriftDetector)
+		if !ok {
+			continue
+		}
+		serviceDrifts, err := detector.DetectDrift(ctx, correct)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to detect drift of AzureMachine service %s", service.Name())
+		}
+		drifts = append(drifts, serviceDrifts...)
+	}
+
+	return drifts, nil
+}
+
 // delete deletes all the services in a predetermined order.
 func (s *azureMachineService) delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureMachineService.delete")
@@ -159,7 +191,7 @@ func (s *azureMachineService) delete(ctx context.Context) error {
 
 	// Delete services in reverse order of creation.
 	for i := len(s.services) - 1; i >= 0; i-- {
-		if err := s.services[i].Delete(ctx); err != nil {
+		if err := azure.DeleteService(ctx, s.services[i]); err != nil {
 			return errors.Wrapf(err, "failed to delete AzureMachine service %s", s.services[i].Name())
 		}
 	}
@@ -53,7 +53,7 @@ func TestAzureMachineServiceReconcile(t *testing.T) {
 				gomock.InOrder(
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("foo"))
+					two.Name().Return("foo").Times(2))
 			},
 		},
 	}
@@ -70,6 +70,9 @@ func TestAzureMachineServiceReconcile(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachineService{
 				scope: &scope.MachineScope{
@@ -152,6 +155,9 @@ func TestAzureMachineServicePause(t *testing.T) {
 			svcThreeMock := newPausingServiceReconciler()
 
 			tc.expect(svcOneMock, svcTwoMock, svcThreeMock)
+			svcOneMock.MockServiceReconciler.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.MockServiceReconciler.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.MockServiceReconciler.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachineService{
 				services: []azure.ServiceReconciler{
@@ -192,7 +198,7 @@ func TestAzureMachineServiceDelete(t *testing.T) {
 				gomock.InOrder(
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("test-service-two"))
+					two.Name().Return("test-service-two").Times(2))
 			},
 		},
 	}
@@ -209,6 +215,9 @@ func TestAzureMachineServiceDelete(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachineService{
 				scope: &scope.MachineScope{
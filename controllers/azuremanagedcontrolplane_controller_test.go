@@ -314,6 +314,7 @@ func TestAzureManagedControlPlaneReconcileNormal(t *testing.T) {
 		ctrlr := gomock.NewController(t)
 		svcr := mock_azure.NewMockServiceReconciler(ctrlr)
 		svcr.EXPECT().Reconcile(gomock.Any()).Return(nil)
+		svcr.EXPECT().Name().Return("service").AnyTimes()
 
 		return &azureManagedControlPlaneService{
 			kubeclient: scope.Client,
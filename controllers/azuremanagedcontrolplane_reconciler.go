@@ -75,7 +75,7 @@ func (r *azureManagedControlPlaneService) Reconcile(ctx context.Context) error {
 	defer done()
 
 	for _, service := range r.services {
-		if err := service.Reconcile(ctx); err != nil {
+		if err := azure.ReconcileService(ctx, service); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureManagedControlPlane service %s", service.Name())
 		}
 	}
@@ -97,7 +97,7 @@ func (r *azureManagedControlPlaneService) Pause(ctx context.Context) error {
 		if !ok {
 			continue
 		}
-		if err := pauser.Pause(ctx); err != nil {
+		if err := azure.PauseService(ctx, service.Name(), pauser); err != nil {
 			return errors.Wrapf(err, "failed to pause AzureManagedControlPlane service %s", service.Name())
 		}
 	}
@@ -112,7 +112,7 @@ func (r *azureManagedControlPlaneService) Delete(ctx context.Context) error {
 
 	// Delete services in reverse order of creation.
 	for i := len(r.services) - 1; i >= 0; i-- {
-		if err := r.services[i].Delete(ctx); err != nil {
+		if err := azure.DeleteService(ctx, r.services[i]); err != nil {
 			return errors.Wrapf(err, "failed to delete AzureManagedControlPlane service %s", r.services[i].Name())
 		}
 	}
@@ -77,6 +77,9 @@ func TestAzureManagedControlPlaneServicePause(t *testing.T) {
 			svcThreeMock := newPausingServiceReconciler()
 
 			tc.expect(svcOneMock, svcTwoMock, svcThreeMock)
+			svcOneMock.MockServiceReconciler.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.MockServiceReconciler.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.MockServiceReconciler.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureManagedControlPlaneService{
 				services: []azure.ServiceReconciler{
@@ -0,0 +1,105 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// defaultDriftScanInterval is the time between two drift scans when DriftDetection does not set it.
+const defaultDriftScanInterval = 10 * time.Minute
+
+// driftScanInterval returns the time between two drift scans configured by detection.
+func driftScanInterval(detection *infrav1.DriftDetection) time.Duration {
+	if detection.Interval == nil || detection.Interval.Duration <= 0 {
+		return defaultDriftScanInterval
+	}
+	return detection.Interval.Duration
+}
+
+// nextDriftScan returns the time until the next drift scan, which is zero when a scan is due now.
+func nextDriftScan(detection *infrav1.DriftDetection, status *infrav1.DriftStatus, now time.Time) time.Duration {
+	if status == nil || status.LastScanTime == nil {
+		return 0
+	}
+	return max(status.LastScanTime.Add(driftScanInterval(detection)).Sub(now), 0)
+}
+
+// scanDrift runs detect with the policy of detection and reports the drifted resources it finds with an event on
+// obj and the drift metrics. It returns the status to record the scan in. When detect fails, the returned status keeps
+// the resources of previous so that the scan is retried after the interval rather than on every reconciliation.
+func scanDrift(ctx context.Context, recorder record.EventRecorder, obj runtime.Object, detection *infrav1.DriftDetection, previous *infrav1.DriftStatus, detect func(context.Context, bool) ([]infrav1.ResourceDrift, error)) (*infrav1.DriftStatus, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.scanDrift")
+	defer done()
+
+	correct := detection.Policy == infrav1.DriftPolicyAutoCorrect
+	drifts, err := detect(ctx, correct)
+	if err != nil {
+		status := &infrav1.DriftStatus{LastScanTime: ptr.To(metav1.Now())}
+		if previous != nil {
+			status.Resources = previous.Resources
+		}
+		recorder.Eventf(obj, corev1.EventTypeWarning, "DriftDetectionFailed", "Failed to detect drift: %s", err.Error())
+		return status, err
+	}
+
+	var drifted, corrected []string
+	for _, drift := range drifts {
+		metrics.ObserveDrift(drift.ServiceName, drift.Corrected)
+		drifted = append(drifted, drift.ResourceName)
+		if drift.Corrected {
+			corrected = append(corrected, drift.ResourceName)
+		}
+	}
+	if len(drifted) > 0 {
+		log.Info("detected drifted Azure resources", "resources", drifted, "corrected", corrected)
+		recorder.Eventf(obj, corev1.EventTypeWarning, "DriftDetected", "Azure resources have drifted from their desired state: %s", strings.Join(drifted, ", "))
+	}
+	if len(corrected) > 0 {
+		recorder.Eventf(obj, corev1.EventTypeNormal, "DriftCorrected", "Azure resources were updated to their desired state: %s", strings.Join(corrected, ", "))
+	}
+
+	return &infrav1.DriftStatus{
+		LastScanTime: ptr.To(metav1.Now()),
+		Resources:    drifts,
+	}, nil
+}
+
+// unscannedServices returns the names of the services which do not implement azure.DriftDetector, so that the drift
+// status reports which resources are not scanned rather than silently skipping them.
+func unscannedServices(services []azure.ServiceReconciler) []string {
+	var unscanned []string
+	for _, service := range services {
+		if _, ok := service.(azure.DriftDetector); !ok {
+			unscanned = append(unscanned, service.Name())
+		}
+	}
+	return unscanned
+}
@@ -0,0 +1,177 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+)
+
+func TestNextDriftScan(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		detection *infrav1.DriftDetection
+		status    *infrav1.DriftStatus
+		expected  time.Duration
+	}{
+		{
+			name:      "never scanned",
+			detection: &infrav1.DriftDetection{},
+			expected:  0,
+		},
+		{
+			name:      "scanned within the default interval",
+			detection: &infrav1.DriftDetection{},
+			status:    &infrav1.DriftStatus{LastScanTime: &metav1.Time{Time: now.Add(-4 * time.Minute)}},
+			expected:  6 * time.Minute,
+		},
+		{
+			name:      "scanned within a custom interval",
+			detection: &infrav1.DriftDetection{Interval: &metav1.Duration{Duration: time.Hour}},
+			status:    &infrav1.DriftStatus{LastScanTime: &metav1.Time{Time: now.Add(-30 * time.Minute)}},
+			expected:  30 * time.Minute,
+		},
+		{
+			name:      "scan is overdue",
+			detection: &infrav1.DriftDetection{Interval: &metav1.Duration{Duration: time.Minute}},
+			status:    &infrav1.DriftStatus{LastScanTime: &metav1.Time{Time: now.Add(-time.Hour)}},
+			expected:  0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(nextDriftScan(tc.detection, tc.status, now)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestScanDrift(t *testing.T) {
+	drift := infrav1.ResourceDrift{ServiceName: "securitygroups", ResourceName: "node-nsg", ResourceGroup: "my-rg", Diff: "-"}
+	previous := &infrav1.DriftStatus{Resources: []infrav1.ResourceDrift{drift}}
+
+	tests := []struct {
+		name              string
+		policy            infrav1.DriftPolicy
+		detect            func(context.Context, bool) ([]infrav1.ResourceDrift, error)
+		expectErr         bool
+		expectedResources []infrav1.ResourceDrift
+		expectedEvents    []string
+	}{
+		{
+			name:   "no drift",
+			policy: infrav1.DriftPolicyReportOnly,
+			detect: func(_ context.Context, _ bool) ([]infrav1.ResourceDrift, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:   "drift is reported",
+			policy: infrav1.DriftPolicyReportOnly,
+			detect: func(_ context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+				if correct {
+					return nil, errors.New("drift must not be corrected")
+				}
+				return []infrav1.ResourceDrift{drift}, nil
+			},
+			expectedResources: []infrav1.ResourceDrift{drift},
+			expectedEvents:    []string{"Warning DriftDetected Azure resources have drifted from their desired state: node-nsg"},
+		},
+		{
+			name:   "drift is corrected",
+			policy: infrav1.DriftPolicyAutoCorrect,
+			detect: func(_ context.Context, correct bool) ([]infrav1.ResourceDrift, error) {
+				corrected := drift
+				corrected.Corrected = correct
+				return []infrav1.ResourceDrift{corrected}, nil
+			},
+			expectedResources: []infrav1.ResourceDrift{{ServiceName: "securitygroups", ResourceName: "node-nsg", ResourceGroup: "my-rg", Diff: "-", Corrected: true}},
+			expectedEvents: []string{
+				"Warning DriftDetected Azure resources have drifted from their desired state: node-nsg",
+				"Normal DriftCorrected Azure resources were updated to their desired state: node-nsg",
+			},
+		},
+		{
+			name:   "detection fails",
+			policy: infrav1.DriftPolicyReportOnly,
+			detect: func(_ context.Context, _ bool) ([]infrav1.ResourceDrift, error) {
+				return nil, errors.New("foo")
+			},
+			expectErr:         true,
+			expectedResources: previous.Resources,
+			expectedEvents:    []string{"Warning DriftDetectionFailed Failed to detect drift: foo"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			recorder := record.NewFakeRecorder(10)
+
+			status, err := scanDrift(t.Context(), recorder, &infrav1.AzureCluster{}, &infrav1.DriftDetection{Policy: tc.policy}, previous, tc.detect)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(status).NotTo(BeNil())
+			g.Expect(status.LastScanTime).NotTo(BeNil())
+			g.Expect(status.Resources).To(Equal(tc.expectedResources))
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			g.Expect(events).To(Equal(tc.expectedEvents))
+		})
+	}
+}
+
+// driftDetectingService is a service which implements azure.DriftDetector.
+type driftDetectingService struct {
+	*mock_azure.MockServiceReconciler
+}
+
+func (driftDetectingService) DetectDrift(_ context.Context, _ bool) ([]infrav1.ResourceDrift, error) {
+	return nil, nil
+}
+
+func TestUnscannedServices(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+
+	scanned := mock_azure.NewMockServiceReconciler(mockCtrl)
+	scanned.EXPECT().Name().Return("securitygroups").AnyTimes()
+	unscanned := mock_azure.NewMockServiceReconciler(mockCtrl)
+	unscanned.EXPECT().Name().Return("virtualnetworks").AnyTimes()
+
+	services := []azure.ServiceReconciler{driftDetectingService{scanned}, unscanned}
+	g.Expect(unscannedServices(services)).To(Equal([]string{"virtualnetworks"}))
+	g.Expect(unscannedServices([]azure.ServiceReconciler{driftDetectingService{scanned}})).To(BeEmpty())
+}
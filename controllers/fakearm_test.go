@@ -0,0 +1,498 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	asonetworkv1api20201101 "github.com/Azure/azure-service-operator/v2/api/network/v1api20201101"
+	asonetworkv1api20220701 "github.com/Azure/azure-service-operator/v2/api/network/v1api20220701"
+	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
+	asoannotations "github.com/Azure/azure-service-operator/v2/pkg/common/annotations"
+	"github.com/Azure/azure-service-operator/v2/pkg/genruntime/conditions"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	apiinternal "sigs.k8s.io/cluster-api-provider-azure/internal/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
+)
+
+const (
+	// fakeASOFinalizer lets the fake ASO controller observe the deletion of ASO resources.
+	fakeASOFinalizer = "test.infrastructure.cluster.x-k8s.io/fake-aso"
+	// fakeASOSyncedAnnotation is updated on an AzureCluster when the fake ASO controller changes the status of one of
+	// its ASO resources, so the AzureCluster is reconciled without waiting for its requeue interval.
+	fakeASOSyncedAnnotation = "test.infrastructure.cluster.x-k8s.io/fake-aso-synced"
+)
+
+// fakeASOKind is a kind of ASO resource created for AzureClusters.
+type fakeASOKind struct {
+	gvk schema.GroupVersionKind
+	// owner is the kind of the owner of the resources, or nil if they are owned by the subscription.
+	owner *schema.GroupVersionKind
+	// path is the path of the ID of a resource relative to the ID of its owner, without the resource name.
+	path string
+}
+
+var (
+	asoResourceGroupGVK  = asoresourcesv1.GroupVersion.WithKind("ResourceGroup")
+	asoVirtualNetworkGVK = asonetworkv1api20201101.GroupVersion.WithKind("VirtualNetwork")
+
+	fakeASOKinds = []fakeASOKind{
+		{gvk: asoResourceGroupGVK, path: "/resourceGroups"},
+		{gvk: asoVirtualNetworkGVK, owner: &asoResourceGroupGVK, path: "/providers/Microsoft.Network/virtualNetworks"},
+		{gvk: asonetworkv1api20201101.GroupVersion.WithKind("VirtualNetworksSubnet"), owner: &asoVirtualNetworkGVK, path: "/subnets"},
+		{gvk: asonetworkv1api20220701.GroupVersion.WithKind("NatGateway"), owner: &asoResourceGroupGVK, path: "/providers/Microsoft.Network/natGateways"},
+	}
+)
+
+// fakeASO emulates the ASO controllers for the ASO resources of a cluster. Resources with the "manage" reconcile
+// policy are created in and deleted from a fake Resource Manager, and reported as ready with their spec as status.
+// Resources with the "skip" policy are reported as not found until they exist in the fake Resource Manager.
+type fakeASO struct {
+	srv            *fakearm.Server
+	subscriptionID string
+	clusterName    string
+}
+
+func (f *fakeASO) sync(ctx context.Context) {
+	for _, kind := range fakeASOKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(kind.gvk.GroupVersion().WithKind(kind.gvk.Kind + "List"))
+		if err := testEnv.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: f.clusterName}); err != nil {
+			GinkgoWriter.Printf("failed to list %s resources: %v\n", kind.gvk.Kind, err)
+			continue
+		}
+		for i := range list.Items {
+			if err := f.syncResource(ctx, kind, &list.Items[i]); err != nil {
+				GinkgoWriter.Printf("failed to sync %s %s: %v\n", kind.gvk.Kind, list.Items[i].GetName(), err)
+			}
+		}
+	}
+}
+
+func (f *fakeASO) syncResource(ctx context.Context, kind fakeASOKind, obj *unstructured.Unstructured) error {
+	id, err := f.resourceID(ctx, kind, obj)
+	if err != nil {
+		return err
+	}
+	managed := obj.GetAnnotations()[asoannotations.ReconcilePolicy] == string(asoannotations.ReconcilePolicyManage)
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		if managed && id != "" {
+			f.srv.DeleteResource(id)
+		}
+		controllerutil.RemoveFinalizer(obj, fakeASOFinalizer)
+		if err := testEnv.Update(ctx, obj); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		return f.requeueOwner(ctx, obj)
+	}
+	if controllerutil.AddFinalizer(obj, fakeASOFinalizer) {
+		return testEnv.Update(ctx, obj)
+	}
+	if id == "" {
+		// The owner of the resource is not created yet.
+		return nil
+	}
+
+	spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return err
+	}
+	_, exists := f.srv.Resource(id)
+	notFound := !managed && !exists
+	if ready := asoReadyCondition(obj); ready != nil && ready["observedGeneration"] == obj.GetGeneration() {
+		if ready["status"] == string(metav1.ConditionTrue) || (notFound && ready["reason"] == conditions.ReasonAzureResourceNotFound.Name) {
+			return nil
+		}
+	}
+
+	ready := map[string]any{
+		"type":               string(conditions.ConditionTypeReady),
+		"observedGeneration": obj.GetGeneration(),
+		"lastTransitionTime": time.Now().UTC().Format(time.RFC3339),
+	}
+	status := map[string]any{}
+	if notFound {
+		ready["status"] = string(metav1.ConditionFalse)
+		ready["severity"] = string(conditions.ConditionSeverityWarning)
+		ready["reason"] = conditions.ReasonAzureResourceNotFound.Name
+		ready["message"] = "The Azure resource was not found"
+	} else {
+		if managed {
+			if err := f.srv.SetResource(id, asoARMResource(spec)); err != nil {
+				return err
+			}
+		}
+		status = asoProperties(spec)
+		status["id"] = id
+		ready["status"] = string(metav1.ConditionTrue)
+	}
+	status["conditions"] = []any{ready}
+	if err := unstructured.SetNestedMap(obj.Object, status, "status"); err != nil {
+		return err
+	}
+	if err := testEnv.Status().Update(ctx, obj); err != nil {
+		return err
+	}
+	return f.requeueOwner(ctx, obj)
+}
+
+// resourceID returns the ID of the Azure resource of obj, or an empty string if the Azure resource of its owner does
+// not exist yet.
+func (f *fakeASO) resourceID(ctx context.Context, kind fakeASOKind, obj *unstructured.Unstructured) (string, error) {
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "azureName")
+	if name == "" {
+		name = obj.GetName()
+	}
+	ownerID := "/subscriptions/" + f.subscriptionID
+	if kind.owner != nil {
+		ownerName, _, _ := unstructured.NestedString(obj.Object, "spec", "owner", "name")
+		owner := &unstructured.Unstructured{}
+		owner.SetGroupVersionKind(*kind.owner)
+		if err := testEnv.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: ownerName}, owner); err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		ownerID, _, _ = unstructured.NestedString(owner.Object, "status", "id")
+		if ownerID == "" {
+			return "", nil
+		}
+	}
+	return ownerID + kind.path + "/" + name, nil
+}
+
+// requeueOwner updates an annotation of the AzureCluster owning obj, so it is reconciled right away.
+func (f *fakeASO) requeueOwner(ctx context.Context, obj *unstructured.Unstructured) error {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil || ref.Kind != infrav1.AzureClusterKind {
+		return nil
+	}
+	azureCluster := &infrav1.AzureCluster{}
+	if err := testEnv.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: ref.Name}, azureCluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	patch := client.MergeFrom(azureCluster.DeepCopy())
+	if azureCluster.Annotations == nil {
+		azureCluster.Annotations = map[string]string{}
+	}
+	azureCluster.Annotations[fakeASOSyncedAnnotation] = strconv.FormatInt(time.Now().UnixNano(), 10)
+	return client.IgnoreNotFound(testEnv.Patch(ctx, azureCluster, patch))
+}
+
+// asoReadyCondition returns the Ready condition of an ASO resource, if it has one.
+func asoReadyCondition(obj *unstructured.Unstructured) map[string]any {
+	conds, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, cond := range conds {
+		if c, ok := cond.(map[string]any); ok && c["type"] == string(conditions.ConditionTypeReady) {
+			return c
+		}
+	}
+	return nil
+}
+
+// asoProperties returns the fields of the spec of an ASO resource which describe its Azure resource.
+func asoProperties(spec map[string]any) map[string]any {
+	properties := make(map[string]any, len(spec))
+	for k, v := range spec {
+		switch k {
+		case "owner", "azureName", "operatorSpec":
+		default:
+			properties[k] = v
+		}
+	}
+	return properties
+}
+
+// asoARMResource returns the Resource Manager representation of the Azure resource of an ASO resource.
+func asoARMResource(spec map[string]any) map[string]any {
+	properties := asoProperties(spec)
+	resource := map[string]any{"properties": properties}
+	for _, k := range []string{"location", "tags"} {
+		if v, ok := properties[k]; ok {
+			resource[k] = v
+			delete(properties, k)
+		}
+	}
+	return resource
+}
+
+var _ = Describe("Reconciling against a fake Resource Manager", func() {
+	const subscriptionID = "123"
+
+	var (
+		ctx = context.Background()
+		srv *fakearm.Server
+	)
+
+	BeforeEach(func() {
+		srv = fakearm.NewServer()
+		DeferCleanup(srv.Close)
+		// The reconcilers of the manager send their Resource Manager requests to the fake server.
+		Expect(os.Setenv(azure.ResourceManagerEndpointEnvVar, srv.URL)).To(Succeed())
+		DeferCleanup(os.Unsetenv, azure.ResourceManagerEndpointEnvVar)
+	})
+
+	It("should create and delete the Azure resources of an AzureCluster and an AzureMachine", func() {
+		name := test.RandomName("fakearm", 10)
+
+		By("seeding the resource SKUs of the location")
+		skusID := "/subscriptions/" + subscriptionID + "/providers/Microsoft.Compute/skus/"
+		Expect(srv.SetResource(skusID+"Standard_D2s_v3", map[string]any{
+			"resourceType": string(resourceskus.VirtualMachines),
+			"locations":    []any{location},
+			"capabilities": []any{
+				map[string]any{"name": resourceskus.VCPUs, "value": "2"},
+				map[string]any{"name": resourceskus.MemoryGB, "value": "8"},
+			},
+		})).To(Succeed())
+		Expect(srv.SetResource(skusID+string(armcompute.AvailabilitySetSKUTypesAligned), map[string]any{
+			"resourceType": string(resourceskus.AvailabilitySets),
+			"locations":    []any{location},
+			"capabilities": []any{
+				map[string]any{"name": resourceskus.MaximumPlatformFaultDomainCount, "value": "2"},
+			},
+		})).To(Succeed())
+
+		asoCtx, stopASO := context.WithCancel(ctx)
+		DeferCleanup(stopASO)
+		aso := &fakeASO{srv: srv, subscriptionID: subscriptionID, clusterName: name}
+		go wait.UntilWithContext(asoCtx, aso.sync, 100*time.Millisecond)
+
+		By("creating the identity, the Cluster and the AzureCluster")
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-identity", Namespace: namespace},
+			Data:       map[string][]byte{scope.AzureSecretKey: []byte("fake-client-secret")},
+		}
+		Expect(testEnv.Create(ctx, secret)).To(Succeed())
+		identity := &infrav1.AzureClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: infrav1.AzureClusterIdentitySpec{
+				Type:              infrav1.ServicePrincipal,
+				ClientID:          "fake-client-id",
+				TenantID:          "fake-tenant-id",
+				ClientSecret:      corev1.SecretReference{Name: secret.Name, Namespace: namespace},
+				AllowedNamespaces: &infrav1.AllowedNamespaces{NamespaceList: []string{namespace}},
+			},
+		}
+		Expect(testEnv.Create(ctx, identity)).To(Succeed())
+		bootstrapSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-bootstrap", Namespace: namespace},
+			Data:       map[string][]byte{"value": []byte("#cloud-config")},
+		}
+		Expect(testEnv.Create(ctx, bootstrapSecret)).To(Succeed())
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+					APIGroup: infrav1.GroupVersion.Group,
+					Kind:     infrav1.AzureClusterKind,
+					Name:     name,
+				},
+			},
+		}
+		Expect(testEnv.Create(ctx, cluster)).To(Succeed())
+		DeferCleanup(func() {
+			for _, obj := range []client.Object{cluster, bootstrapSecret, identity, secret} {
+				Expect(client.IgnoreNotFound(testEnv.Delete(ctx, obj))).To(Succeed())
+			}
+		})
+
+		azureCluster := &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       clusterv1.ClusterKind,
+					Name:       cluster.Name,
+					UID:        cluster.UID,
+				}},
+			},
+			Spec: infrav1.AzureClusterSpec{
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					SubscriptionID: subscriptionID,
+					Location:       location,
+					IdentityRef: &corev1.ObjectReference{
+						Name:      identity.Name,
+						Namespace: namespace,
+						Kind:      infrav1.AzureClusterIdentityKind,
+					},
+				},
+				ControlPlaneEnabled: true,
+			},
+		}
+		apiinternal.SetDefaultsAzureCluster(azureCluster)
+		Expect(testEnv.Create(ctx, azureCluster)).To(Succeed())
+
+		By("waiting for the AzureCluster to be ready")
+		Eventually(func(g Gomega) {
+			g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(azureCluster), azureCluster)).To(Succeed())
+			g.Expect(azureCluster.Status.Ready).To(BeTrue())
+		}, 2*time.Minute, time.Second).Should(Succeed())
+
+		resourceGroup := azureCluster.Spec.ResourceGroup
+		networkSpec := azureCluster.Spec.NetworkSpec
+		vnet := networkSpec.Vnet
+		ids := []string{
+			azure.ResourceGroupID(subscriptionID, resourceGroup),
+			azure.VNetID(subscriptionID, vnet.ResourceGroup, vnet.Name),
+			azure.PublicIPID(subscriptionID, resourceGroup, networkSpec.APIServerLB.FrontendIPs[0].PublicIP.Name),
+			fmt.Sprintf("%s/providers/Microsoft.Network/loadBalancers/%s", azure.ResourceGroupID(subscriptionID, resourceGroup), networkSpec.APIServerLB.Name),
+		}
+		for _, subnet := range networkSpec.Subnets {
+			ids = append(ids, azure.SubnetID(subscriptionID, vnet.ResourceGroup, vnet.Name, subnet.Name))
+			if subnet.SecurityGroup.Name != "" {
+				ids = append(ids, azure.SecurityGroupID(subscriptionID, resourceGroup, subnet.SecurityGroup.Name))
+			}
+			if subnet.RouteTable.Name != "" {
+				ids = append(ids, azure.RouteTableID(subscriptionID, resourceGroup, subnet.RouteTable.Name))
+			}
+			if subnet.NatGateway.Name != "" {
+				ids = append(ids, azure.NatGatewayID(subscriptionID, resourceGroup, subnet.NatGateway.Name))
+			}
+		}
+		for _, id := range ids {
+			_, ok := srv.Resource(id)
+			Expect(ok).To(BeTrue(), "resource %s was not created", id)
+		}
+		Expect(azureCluster.Spec.ControlPlaneEndpoint.Host).NotTo(BeEmpty())
+
+		By("marking the infrastructure of the Cluster as provisioned")
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+		Expect(testEnv.Status().Patch(ctx, cluster, patch)).To(Succeed())
+		Eventually(func(g Gomega) {
+			g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+			g.Expect(cluster.Status.Initialization.InfrastructureProvisioned).To(HaveValue(BeTrue()))
+		}).Should(Succeed())
+
+		By("creating the Machine and the AzureMachine")
+		const machineDeployment = "md-0"
+		machine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:           name,
+					clusterv1.MachineDeploymentNameLabel: machineDeployment,
+				},
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: name,
+				Bootstrap:   clusterv1.Bootstrap{DataSecretName: ptr.To(bootstrapSecret.Name)},
+				InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+					APIGroup: infrav1.GroupVersion.Group,
+					Kind:     infrav1.AzureMachineKind,
+					Name:     name,
+				},
+				Version: "v1.33.0",
+			},
+		}
+		Expect(testEnv.Create(ctx, machine)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(testEnv.Delete(ctx, machine))).To(Succeed())
+		})
+		azureMachine := &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: name},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Machine",
+					Name:       machine.Name,
+					UID:        machine.UID,
+				}},
+			},
+			Spec: infrav1.AzureMachineSpec{
+				VMSize: "Standard_D2s_v3",
+				Image: &infrav1.Image{
+					Marketplace: &infrav1.AzureMarketplaceImage{
+						ImagePlan: infrav1.ImagePlan{Publisher: "cncf-upstream", Offer: "capi", SKU: "ubuntu-2404-gen1"},
+						Version:   "latest",
+					},
+				},
+				OSDisk: infrav1.OSDisk{
+					OSType:      azure.LinuxOS,
+					DiskSizeGB:  ptr.To[int32](30),
+					ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: "Premium_LRS"},
+					CachingType: "None",
+				},
+			},
+		}
+		Expect(apiinternal.SetDefaultsAzureMachine(azureMachine, testEnv)).To(Succeed())
+		Expect(testEnv.Create(ctx, azureMachine)).To(Succeed())
+
+		By("waiting for the AzureMachine to be ready")
+		Eventually(func(g Gomega) {
+			g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(azureMachine), azureMachine)).To(Succeed())
+			g.Expect(azureMachine.Status.Ready).To(BeTrue())
+		}, time.Minute, time.Second).Should(Succeed())
+
+		vmID := azure.VMID(subscriptionID, resourceGroup, name)
+		nicID := azure.NetworkInterfaceID(subscriptionID, resourceGroup, azure.GenerateNICName(name, false, 0))
+		availabilitySetID := azure.AvailabilitySetID(subscriptionID, resourceGroup, azure.GenerateAvailabilitySetName(name, machineDeployment))
+		for _, id := range []string{vmID, nicID, availabilitySetID} {
+			_, ok := srv.Resource(id)
+			Expect(ok).To(BeTrue(), "resource %s was not created", id)
+		}
+		Expect(ptr.Deref(azureMachine.Spec.ProviderID, "")).To(HaveSuffix(vmID))
+
+		By("deleting the AzureMachine")
+		Expect(testEnv.Delete(ctx, azureMachine)).To(Succeed())
+		Eventually(func(g Gomega) {
+			err := testEnv.Get(ctx, client.ObjectKeyFromObject(azureMachine), &infrav1.AzureMachine{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}, time.Minute, time.Second).Should(Succeed())
+		for _, id := range []string{vmID, nicID, availabilitySetID} {
+			_, ok := srv.Resource(id)
+			Expect(ok).To(BeFalse(), "resource %s was not deleted", id)
+		}
+
+		By("deleting the AzureCluster")
+		Expect(testEnv.Delete(ctx, azureCluster)).To(Succeed())
+		Eventually(func(g Gomega) {
+			err := testEnv.Get(ctx, client.ObjectKeyFromObject(azureCluster), &infrav1.AzureCluster{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}, 2*time.Minute, time.Second).Should(Succeed())
+		for _, id := range ids {
+			_, ok := srv.Resource(id)
+			Expect(ok).To(BeFalse(), "resource %s was not deleted", id)
+		}
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -790,6 +791,41 @@ func AzureManagedControlPlaneToAzureManagedMachinePoolsMapper(_ context.Context,
 	}, nil
 }
 
+// AzureSecurityRuleSetToAzureClustersMapper creates a mapping handler to transform an AzureSecurityRuleSet into the
+// AzureClusters in its namespace whose subnets reference it.
+func AzureSecurityRuleSetToAzureClustersMapper(_ context.Context, c client.Client, log logr.Logger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultMappingTimeout)
+		defer cancel()
+
+		ruleSet, ok := o.(*infrav1.AzureSecurityRuleSet)
+		if !ok {
+			log.Error(errors.Errorf("expected an AzureSecurityRuleSet, got %T instead", o), "failed to map AzureSecurityRuleSet")
+			return nil
+		}
+
+		log := log.WithValues("AzureSecurityRuleSet", ruleSet.Name, "Namespace", ruleSet.Namespace)
+
+		azureClusters := &infrav1.AzureClusterList{}
+		if err := c.List(ctx, azureClusters, client.InNamespace(ruleSet.Namespace)); err != nil {
+			log.Error(err, "failed to list AzureClusters")
+			return nil
+		}
+
+		var requests []ctrl.Request
+		for _, azureCluster := range azureClusters.Items {
+			for _, subnet := range azureCluster.Spec.NetworkSpec.Subnets {
+				if slices.Contains(subnet.SecurityGroup.SecurityRuleSets, ruleSet.Name) {
+					requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&azureCluster)})
+					break
+				}
+			}
+		}
+
+		return requests
+	}
+}
+
 // AzureManagedClusterToAzureManagedControlPlaneMapper creates a mapping handler to transform AzureManagedClusters into
 // AzureManagedControlPlane. The transform requires AzureManagedCluster to map to the owning Cluster, then from the
 // Cluster, collect the control plane infrastructure reference.
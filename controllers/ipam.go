@@ -0,0 +1,87 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// reconcileIPAddressClaim creates or updates an IPAddressClaim owned by an object for an IP address from an IPAM pool.
+// It returns the IPAddress allocated for the claim, or nil while the allocation is pending.
+func reconcileIPAddressClaim(ctx context.Context, c client.Client, owner client.Object, name, clusterName, watchFilterValue string, poolRef *corev1.TypedLocalObjectReference) (*ipamv1.IPAddress, error) {
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+		},
+	}
+	if _, err := controllerutil.CreateOrPatch(ctx, c, claim, func() error {
+		if claim.Labels == nil {
+			claim.Labels = make(map[string]string)
+		}
+		claim.Labels[clusterv1.ClusterNameLabel] = clusterName
+		if watchFilterValue != "" {
+			claim.Labels[clusterv1.WatchLabel] = watchFilterValue
+		}
+		claim.Spec.ClusterName = clusterName
+		claim.Spec.PoolRef = ipamv1.IPPoolReference{
+			Name:     poolRef.Name,
+			Kind:     poolRef.Kind,
+			APIGroup: ptr.Deref(poolRef.APIGroup, ""),
+		}
+		return controllerutil.SetControllerReference(owner, claim, c.Scheme())
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to reconcile IPAddressClaim %s", claim.Name)
+	}
+
+	if claim.Status.AddressRef.Name == "" {
+		return nil, nil
+	}
+	address := &ipamv1.IPAddress{}
+	key := client.ObjectKey{Namespace: claim.Namespace, Name: claim.Status.AddressRef.Name}
+	if err := c.Get(ctx, key, address); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get IPAddress %s", key.Name)
+	}
+	return address, nil
+}
+
+// deleteIPAddressClaim deletes an IPAddressClaim to release its IP address back to its IPAM pool.
+func deleteIPAddressClaim(ctx context.Context, c client.Client, namespace, name string) error {
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	if err := c.Delete(ctx, claim); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete IPAddressClaim %s", name)
+	}
+	return nil
+}
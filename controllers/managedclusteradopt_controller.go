@@ -32,12 +32,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 const (
-	adoptAnnotation      = "sigs.k8s.io/cluster-api-provider-azure-adopt"
-	adoptAnnotationValue = "true"
+	adoptAnnotation        = azure.AdoptAnnotation
+	adoptAnnotationValue   = "true"
+	adoptedAnnotationValue = "adopted"
 )
 
 // ManagedClusterAdoptReconciler adopts ASO ManagedCluster resources into a CAPI Cluster.
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
@@ -33,6 +34,10 @@ const (
 	// i.e. gradually scale down the old AzureMachinePoolMachines and scale up the new ones.
 	RollingUpdateAzureMachinePoolDeploymentStrategyType AzureMachinePoolDeploymentStrategyType = "RollingUpdate"
 
+	// BlueGreenAzureMachinePoolDeploymentStrategyType replaces AzureMachinePoolMachines with older models by creating
+	// a second VMSS with the latest model, and retiring the old VMSS in one step once every new instance is a Ready node.
+	BlueGreenAzureMachinePoolDeploymentStrategyType AzureMachinePoolDeploymentStrategyType = "BlueGreen"
+
 	// OldestDeletePolicyType will delete machines with the oldest creation date first.
 	OldestDeletePolicyType AzureMachinePoolDeletePolicyType = "Oldest"
 	// NewestDeletePolicyType will delete machines with the newest creation date first.
@@ -189,9 +194,9 @@ type (
 
 	// AzureMachinePoolDeploymentStrategy describes how to replace existing machines with new ones.
 	AzureMachinePoolDeploymentStrategy struct {
-		// Type of deployment. Currently the only supported strategy is RollingUpdate
+		// Type of deployment. Allowed values are RollingUpdate and BlueGreen.
 		// +optional
-		// +kubebuilder:validation:Enum=RollingUpdate
+		// +kubebuilder:validation:Enum=RollingUpdate;BlueGreen
 		// +kubebuilder:default=RollingUpdate
 		Type AzureMachinePoolDeploymentStrategyType `json:"type,omitempty"`
 
@@ -199,6 +204,11 @@ type (
 		// MachineDeploymentStrategyType = RollingUpdate.
 		// +optional
 		RollingUpdate *MachineRollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+
+		// Blue/green deployment config params. Present only if
+		// MachineDeploymentStrategyType = BlueGreen.
+		// +optional
+		BlueGreen *MachineBlueGreenDeployment `json:"blueGreen,omitempty"`
 	}
 
 	// AzureMachinePoolDeletePolicyType is the type of DeletePolicy employed to select machines to be deleted during an
@@ -247,6 +257,139 @@ type (
 		// +kubebuilder:validation:Enum=Random;Newest;Oldest
 		// +kubebuilder:default:=Oldest
 		DeletePolicy AzureMachinePoolDeletePolicyType `json:"deletePolicy,omitempty"`
+
+		// HealthGate holds back the rolling update after each batch until the machines running the latest model
+		// are healthy. When nil, the rolling update proceeds as soon as new machines are Ready.
+		// +optional
+		HealthGate *MachinePoolRolloutHealthGate `json:"healthGate,omitempty"`
+	}
+
+	// MachinePoolRolloutHealthGate describes the checks that machines running the latest model must pass before a
+	// rolling update replaces more machines.
+	MachinePoolRolloutHealthGate struct {
+		// UnhealthyNodeConditions are node condition types that fail the gate when their status is True, for
+		// example MemoryPressure or NetworkUnavailable. The node must always be Ready.
+		// +optional
+		UnhealthyNodeConditions []corev1.NodeConditionType `json:"unhealthyNodeConditions,omitempty"`
+
+		// ReadinessLabel is a node label, as `key` or `key=value`, that nodes running the latest model must carry
+		// before the gate passes.
+		// +optional
+		ReadinessLabel string `json:"readinessLabel,omitempty"`
+
+		// ExternalGateRef references an object in the namespace of the AzureMachinePool whose status condition
+		// must be True before the gate passes, for example a resource recording the result of a Prometheus query.
+		// The CAPZ manager must be allowed to get its kind, e.g. by a ClusterRole labeled
+		// cluster.x-k8s.io/aggregate-to-capz-manager: "true", which is aggregated into the CAPZ manager role.
+		// +optional
+		ExternalGateRef *RolloutGateReference `json:"externalGateRef,omitempty"`
+
+		// ProgressDeadline is how long the gate may fail before the rollout is paused.
+		// Defaults to 10m.
+		// +optional
+		// +kubebuilder:default:="10m"
+		ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+
+		// AutoRollback reverts the VMSS model to the image of the last model that passed the gate when the
+		// rollout is paused. The rolled back model is not rolled out again until the AzureMachinePool changes.
+		// +optional
+		AutoRollback bool `json:"autoRollback,omitempty"`
+	}
+
+	// RolloutGateReference references an object whose status condition gates a rollout.
+	RolloutGateReference struct {
+		// APIVersion of the referenced object.
+		// +kubebuilder:validation:MinLength=1
+		APIVersion string `json:"apiVersion"`
+
+		// Kind of the referenced object.
+		// +kubebuilder:validation:MinLength=1
+		Kind string `json:"kind"`
+
+		// Name of the referenced object.
+		// +kubebuilder:validation:MinLength=1
+		Name string `json:"name"`
+
+		// ConditionType is the type of the status condition that must be True.
+		// Defaults to Ready.
+		// +optional
+		// +kubebuilder:default:="Ready"
+		ConditionType string `json:"conditionType,omitempty"`
+	}
+
+	// MachineBlueGreenDeployment is used to control the desired behavior of a blue/green deployment.
+	MachineBlueGreenDeployment struct {
+		// ReadyTimeout is how long to wait for every instance of the new VMSS to become a Ready node. If the new
+		// VMSS is not healthy by then, the rollout is rolled back: the new VMSS is drained and deleted, and the
+		// old VMSS keeps serving the pool until the model changes again.
+		// Defaults to 20m.
+		// +optional
+		// +kubebuilder:default:="20m"
+		ReadyTimeout *metav1.Duration `json:"readyTimeout,omitempty"`
+	}
+
+	// AzureMachinePoolBlueGreenStatus tracks the VMSSs of an AzureMachinePool using the BlueGreen deployment strategy.
+	AzureMachinePoolBlueGreenStatus struct {
+		// ActiveScaleSetName is the name of the VMSS currently serving the pool. When empty, the VMSS is named
+		// after the AzureMachinePool.
+		// +optional
+		ActiveScaleSetName string `json:"activeScaleSetName,omitempty"`
+
+		// PendingScaleSetName is the name of the VMSS being brought up with the latest model.
+		// +optional
+		PendingScaleSetName string `json:"pendingScaleSetName,omitempty"`
+
+		// RolloutStartTime is when the pending VMSS was created.
+		// +optional
+		RolloutStartTime *metav1.Time `json:"rolloutStartTime,omitempty"`
+
+		// ModelHash identifies the VMSS model being rolled out to the pending VMSS.
+		// +optional
+		ModelHash string `json:"modelHash,omitempty"`
+
+		// RetiringScaleSetName is the name of a VMSS whose machines are being drained, either because the
+		// rollout completed or because it was rolled back. It is deleted once none of its instances remain.
+		// +optional
+		RetiringScaleSetName string `json:"retiringScaleSetName,omitempty"`
+
+		// RolledBackModelHash identifies the most recent model that was rolled back. A model with this hash is
+		// not rolled out again.
+		// +optional
+		RolledBackModelHash string `json:"rolledBackModelHash,omitempty"`
+	}
+
+	// AzureMachinePoolRolloutStatus tracks the health gate of a rolling update.
+	AzureMachinePoolRolloutStatus struct {
+		// ModelHash identifies the VMSS model being rolled out. It is empty when no rollout is in progress.
+		// +optional
+		ModelHash string `json:"modelHash,omitempty"`
+
+		// GateFailingSince is when the health gate started failing for the model being rolled out.
+		// +optional
+		GateFailingSince *metav1.Time `json:"gateFailingSince,omitempty"`
+
+		// Paused is true when the health gate failed for longer than its progress deadline. The rollout resumes
+		// once the gate passes again.
+		// +optional
+		Paused bool `json:"paused,omitempty"`
+
+		// Message describes why the health gate is failing.
+		// +optional
+		Message string `json:"message,omitempty"`
+
+		// LastHealthyModelHash identifies the most recent model that every machine of the pool ran while passing
+		// the health gate.
+		// +optional
+		LastHealthyModelHash string `json:"lastHealthyModelHash,omitempty"`
+
+		// LastHealthyImage is the image of the last healthy model. A rollback restores it.
+		// +optional
+		LastHealthyImage *infrav1.Image `json:"lastHealthyImage,omitempty"`
+
+		// RolledBackModelHash identifies the model that was rolled back. A model with this hash is not rolled out
+		// again.
+		// +optional
+		RolledBackModelHash string `json:"rolledBackModelHash,omitempty"`
 	}
 
 	// AzureMachinePoolStatus defines the observed state of AzureMachinePool.
@@ -266,6 +409,19 @@ type (
 		// +optional
 		LastReconciledReplicas *int32 `json:"lastReconciledReplicas,omitempty"`
 
+		// BlueGreen tracks the VMSSs of the pool when the BlueGreen deployment strategy is used.
+		// +optional
+		BlueGreen *AzureMachinePoolBlueGreenStatus `json:"blueGreen,omitempty"`
+
+		// Rollout tracks the health gate of a rolling update when one is configured.
+		// +optional
+		Rollout *AzureMachinePoolRolloutStatus `json:"rollout,omitempty"`
+
+		// EstimatedMonthlyCost is the estimated monthly cost of the VMs of the pool and their disks at the desired
+		// replica count. It is only populated when cost estimation is enabled in the controller manager.
+		// +optional
+		EstimatedMonthlyCost *infrav1.CostEstimate `json:"estimatedMonthlyCost,omitempty"`
+
 		// Instances is the VM instance status for each VM in the VMSS
 		// +optional
 		Instances []*AzureMachinePoolInstanceStatus `json:"instances,omitempty"`
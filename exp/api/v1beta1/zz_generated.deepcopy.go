@@ -22,6 +22,7 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	apiv1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -55,6 +56,25 @@ func (in *AzureMachinePool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolBlueGreenStatus) DeepCopyInto(out *AzureMachinePoolBlueGreenStatus) {
+	*out = *in
+	if in.RolloutStartTime != nil {
+		in, out := &in.RolloutStartTime, &out.RolloutStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolBlueGreenStatus.
+func (in *AzureMachinePoolBlueGreenStatus) DeepCopy() *AzureMachinePoolBlueGreenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolBlueGreenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePoolDeploymentStrategy) DeepCopyInto(out *AzureMachinePoolDeploymentStrategy) {
 	*out = *in
@@ -63,6 +83,11 @@ func (in *AzureMachinePoolDeploymentStrategy) DeepCopyInto(out *AzureMachinePool
 		*out = new(MachineRollingUpdateDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(MachineBlueGreenDeployment)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolDeploymentStrategy.
@@ -325,6 +350,30 @@ func (in *AzureMachinePoolMachineTemplate) DeepCopy() *AzureMachinePoolMachineTe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolRolloutStatus) DeepCopyInto(out *AzureMachinePoolRolloutStatus) {
+	*out = *in
+	if in.GateFailingSince != nil {
+		in, out := &in.GateFailingSince, &out.GateFailingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHealthyImage != nil {
+		in, out := &in.LastHealthyImage, &out.LastHealthyImage
+		*out = new(apiv1beta1.Image)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolRolloutStatus.
+func (in *AzureMachinePoolRolloutStatus) DeepCopy() *AzureMachinePoolRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePoolSpec) DeepCopyInto(out *AzureMachinePoolSpec) {
 	*out = *in
@@ -382,6 +431,21 @@ func (in *AzureMachinePoolStatus) DeepCopyInto(out *AzureMachinePoolStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(AzureMachinePoolBlueGreenStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(AzureMachinePoolRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EstimatedMonthlyCost != nil {
+		in, out := &in.EstimatedMonthlyCost, &out.EstimatedMonthlyCost
+		*out = new(apiv1beta1.CostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Instances != nil {
 		in, out := &in.Instances, &out.Instances
 		*out = make([]*AzureMachinePoolInstanceStatus, len(*in))
@@ -437,6 +501,56 @@ func (in *AzureMachinePoolStatus) DeepCopy() *AzureMachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineBlueGreenDeployment) DeepCopyInto(out *MachineBlueGreenDeployment) {
+	*out = *in
+	if in.ReadyTimeout != nil {
+		in, out := &in.ReadyTimeout, &out.ReadyTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineBlueGreenDeployment.
+func (in *MachineBlueGreenDeployment) DeepCopy() *MachineBlueGreenDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineBlueGreenDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRolloutHealthGate) DeepCopyInto(out *MachinePoolRolloutHealthGate) {
+	*out = *in
+	if in.UnhealthyNodeConditions != nil {
+		in, out := &in.UnhealthyNodeConditions, &out.UnhealthyNodeConditions
+		*out = make([]v1.NodeConditionType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalGateRef != nil {
+		in, out := &in.ExternalGateRef, &out.ExternalGateRef
+		*out = new(RolloutGateReference)
+		**out = **in
+	}
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRolloutHealthGate.
+func (in *MachinePoolRolloutHealthGate) DeepCopy() *MachinePoolRolloutHealthGate {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRolloutHealthGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineRollingUpdateDeployment) DeepCopyInto(out *MachineRollingUpdateDeployment) {
 	*out = *in
@@ -450,6 +564,11 @@ func (in *MachineRollingUpdateDeployment) DeepCopyInto(out *MachineRollingUpdate
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(MachinePoolRolloutHealthGate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineRollingUpdateDeployment.
@@ -461,3 +580,18 @@ func (in *MachineRollingUpdateDeployment) DeepCopy() *MachineRollingUpdateDeploy
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutGateReference) DeepCopyInto(out *RolloutGateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutGateReference.
+func (in *RolloutGateReference) DeepCopy() *RolloutGateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutGateReference)
+	in.DeepCopyInto(out)
+	return out
+}
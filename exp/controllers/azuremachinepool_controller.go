@@ -42,10 +42,13 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -200,6 +203,7 @@ func (ampr *AzureMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.
 		}
 		return reconcile.Result{}, err
 	}
+	ctx = audit.WithRequester(ctx, infrav1exp.GroupVersion.WithKind(infrav1.AzureMachinePoolKind), azMachinePool)
 
 	// Fetch the CAPI MachinePool.
 	machinePool, err := infracontroller.GetOwnerMachinePool(ctx, ampr.Client, azMachinePool.ObjectMeta)
@@ -331,6 +335,8 @@ func (ampr *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, mac
 		return reconcile.Result{}, errors.Wrap(err, "failed to init machinepool scope cache")
 	}
 
+	machinePoolScope.AzureMachinePool.Status.EstimatedMonthlyCost = cost.Update(cost.NewKey(machinePoolScope.AzureMachinePool, infrav1.AzureMachinePoolKind, machinePoolScope.ClusterName()), machinePoolScope.CostResources())
+
 	ams, err := ampr.createAzureMachinePoolService(machinePoolScope)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed creating a newAzureMachinePoolService")
@@ -452,5 +458,7 @@ func (ampr *AzureMachinePoolReconciler) reconcileDelete(ctx context.Context, mac
 	// Delete succeeded, remove finalizer
 	log.V(4).Info("removing finalizer for AzureMachinePool")
 	controllerutil.RemoveFinalizer(machinePoolScope.AzureMachinePool, clusterv1.MachinePoolFinalizer)
+	futures.Untrack(machinePoolScope.AzureMachinePool)
+	cost.Forget(cost.NewKey(machinePoolScope.AzureMachinePool, infrav1.AzureMachinePoolKind, machinePoolScope.ClusterName()))
 	return reconcile.Result{}, nil
 }
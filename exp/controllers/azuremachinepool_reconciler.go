@@ -81,7 +81,7 @@ func (s *azureMachinePoolService) Reconcile(ctx context.Context) error {
 	}
 
 	for _, service := range s.services {
-		if err := service.Reconcile(ctx); err != nil {
+		if err := azure.ReconcileService(ctx, service); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureMachinePool service %s", service.Name())
 		}
 	}
@@ -99,7 +99,7 @@ func (s *azureMachinePoolService) Pause(ctx context.Context) error {
 		if !ok {
 			continue
 		}
-		if err := pauser.Pause(ctx); err != nil {
+		if err := azure.PauseService(ctx, service.Name(), pauser); err != nil {
 			return errors.Wrapf(err, "failed to pause AzureMachinePool service %s", service.Name())
 		}
 	}
@@ -114,7 +114,7 @@ func (s *azureMachinePoolService) Delete(ctx context.Context) error {
 
 	// Delete services in reverse order of creation.
 	for i := len(s.services) - 1; i >= 0; i-- {
-		if err := s.services[i].Delete(ctx); err != nil {
+		if err := azure.DeleteService(ctx, s.services[i]); err != nil {
 			return errors.Wrapf(err, "failed to delete AzureMachinePool service %s", s.services[i].Name())
 		}
 	}
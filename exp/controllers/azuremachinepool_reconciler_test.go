@@ -54,7 +54,7 @@ func TestAzureMachinePoolServiceReconcile(t *testing.T) {
 				gomock.InOrder(
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("foo"))
+					two.Name().Return("foo").Times(2))
 			},
 		},
 	}
@@ -71,6 +71,9 @@ func TestAzureMachinePoolServiceReconcile(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachinePoolService{
 				scope: &scope.MachinePoolScope{
@@ -155,6 +158,9 @@ func TestAzureMachinePoolServicePause(t *testing.T) {
 			svcThreeMock := newPausingServiceReconciler()
 
 			tc.expect(svcOneMock, svcTwoMock, svcThreeMock)
+			svcOneMock.MockServiceReconciler.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.MockServiceReconciler.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.MockServiceReconciler.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachinePoolService{
 				services: []azure.ServiceReconciler{
@@ -195,7 +201,7 @@ func TestAzureMachinePoolServiceDelete(t *testing.T) {
 				gomock.InOrder(
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(errors.New("some error happened")),
-					two.Name().Return("test-service-two"))
+					two.Name().Return("test-service-two").Times(2))
 			},
 		},
 	}
@@ -212,6 +218,9 @@ func TestAzureMachinePoolServiceDelete(t *testing.T) {
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
 			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			svcOneMock.EXPECT().Name().Return("one").AnyTimes()
+			svcTwoMock.EXPECT().Name().Return("two").AnyTimes()
+			svcThreeMock.EXPECT().Name().Return("three").AnyTimes()
 
 			s := &azureMachinePoolService{
 				scope: &scope.MachinePoolScope{
@@ -45,7 +45,9 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesetvms"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
+	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -153,6 +155,7 @@ func (ampmr *AzureMachinePoolMachineController) Reconcile(ctx context.Context, r
 		}
 		return reconcile.Result{}, err
 	}
+	ctx = audit.WithRequester(ctx, infrav1exp.GroupVersion.WithKind(infrav1exp.AzureMachinePoolMachineKind), azureMachine)
 	logger.V(2).Info("Fetching cluster for AzureMachinePoolMachine", "ampm", azureMachine.Name)
 
 	// Fetch the Cluster.
@@ -182,6 +185,7 @@ func (ampmr *AzureMachinePoolMachineController) Reconcile(ctx context.Context, r
 		if apierrors.IsNotFound(err) {
 			logger.Info("AzureMachinePool not found error missing, removing finalizer", "azureMachinePoolMachine", azureMachine.Name)
 			controllerutil.RemoveFinalizer(azureMachine, infrav1exp.AzureMachinePoolMachineFinalizer)
+			futures.Untrack(azureMachine)
 			return reconcile.Result{}, ampmr.Client.Update(ctx, azureMachine)
 		}
 		return reconcile.Result{}, err
@@ -189,6 +193,7 @@ func (ampmr *AzureMachinePoolMachineController) Reconcile(ctx context.Context, r
 	if azureMachinePool == nil {
 		logger.Info("AzureMachinePool not found error missing, removing finalizer", "azureMachinePoolMachine", azureMachine.Name)
 		controllerutil.RemoveFinalizer(azureMachine, infrav1exp.AzureMachinePoolMachineFinalizer)
+		futures.Untrack(azureMachine)
 		return reconcile.Result{}, ampmr.Client.Update(ctx, azureMachine)
 	}
 
@@ -216,6 +221,7 @@ func (ampmr *AzureMachinePoolMachineController) Reconcile(ctx context.Context, r
 	case !azureMachinePool.ObjectMeta.DeletionTimestamp.IsZero():
 		logger.Info("AzureMachinePool is being deleted, removing finalizer")
 		controllerutil.RemoveFinalizer(azureMachine, infrav1exp.AzureMachinePoolMachineFinalizer)
+		futures.Untrack(azureMachine)
 		return reconcile.Result{}, ampmr.Client.Update(ctx, azureMachine)
 	default:
 		logger.Info("Waiting for Machine Controller to set OwnerRef on AzureMachinePoolMachine")
@@ -328,6 +334,7 @@ func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Cont
 		log.Info("Skipping VMSS VM deletion as the whole resource group is being deleted")
 
 		controllerutil.RemoveFinalizer(machineScope.AzureMachinePoolMachine, infrav1exp.AzureMachinePoolMachineFinalizer)
+		futures.Untrack(machineScope.AzureMachinePoolMachine)
 		return reconcile.Result{}, nil
 	}
 
@@ -335,6 +342,7 @@ func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Cont
 		log.Info("Skipping VMSS VM deletion as VMSS delete will delete individual instances")
 
 		controllerutil.RemoveFinalizer(machineScope.AzureMachinePoolMachine, infrav1exp.AzureMachinePoolMachineFinalizer)
+		futures.Untrack(machineScope.AzureMachinePoolMachine)
 		return reconcile.Result{}, nil
 	}
 
@@ -387,7 +395,7 @@ func (r *azureMachinePoolMachineReconciler) Reconcile(ctx context.Context) error
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureMachinePoolMachineReconciler.Reconcile")
 	defer done()
 
-	if err := r.scalesetVMsService.Reconcile(ctx); err != nil {
+	if err := azure.ReconcileService(ctx, r.scalesetVMsService); err != nil {
 		return errors.Wrap(err, "failed to reconcile scalesetVMs")
 	}
 
@@ -417,12 +425,13 @@ func (r *azureMachinePoolMachineReconciler) Delete(ctx context.Context) error {
 		}
 	}()
 
-	if err := r.scalesetVMsService.Delete(ctx); err != nil {
+	if err := azure.DeleteService(ctx, r.scalesetVMsService); err != nil {
 		return errors.Wrap(err, "failed to reconcile scalesetVMs")
 	}
 
 	// no long running operation, so we are finished deleting the resource. Remove the finalizer.
 	controllerutil.RemoveFinalizer(r.Scope.AzureMachinePoolMachine, infrav1exp.AzureMachinePoolMachineFinalizer)
+	futures.Untrack(r.Scope.AzureMachinePoolMachine)
 
 	return nil
 }
@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/binary"
 	"fmt"
+	"net/netip"
 
 	"k8s.io/utils/ptr"
 
@@ -42,6 +44,18 @@ const (
 	DefaultAzureBastionSubnetName = "AzureBastionSubnet"
 	// DefaultAzureBastionSubnetRole is the default Subnet role for AzureBastion.
 	DefaultAzureBastionSubnetRole = infrav1.SubnetBastion
+	// DefaultIngressSubnetCIDR is the default Subnet CIDR for the ingress Application Gateway.
+	DefaultIngressSubnetCIDR = "10.255.254.0/24"
+	// DefaultIngressSubnetRole is the default Subnet role for the ingress Application Gateway.
+	DefaultIngressSubnetRole = infrav1.SubnetIngress
+	// DefaultIngressCapacity is the default number of instances of the ingress Application Gateway.
+	DefaultIngressCapacity = 2
+	// DefaultAzureFirewallSubnetCIDR is the default Subnet CIDR for the Azure Firewall.
+	DefaultAzureFirewallSubnetCIDR = "10.255.253.0/26"
+	// DefaultAzureFirewallSubnetName is the default Subnet Name for the Azure Firewall.
+	DefaultAzureFirewallSubnetName = "AzureFirewallSubnet"
+	// DefaultAzureFirewallSubnetRole is the default Subnet role for the Azure Firewall.
+	DefaultAzureFirewallSubnetRole = infrav1.SubnetFirewall
 	// DefaultInternalLBIPAddress is the default internal load balancer ip address.
 	DefaultInternalLBIPAddress = "10.0.0.100"
 	// DefaultOutboundRuleIdleTimeoutInMinutes is the default for IdleTimeoutInMinutes for the load balancer.
@@ -61,6 +75,8 @@ func SetDefaultsAzureCluster(c *infrav1.AzureCluster) {
 func setDefaultAzureClusterNetworkSpec(c *infrav1.AzureCluster) {
 	setDefaultAzureClusterVnet(c)
 	setDefaultAzureClusterBastion(c)
+	setDefaultAzureClusterIngress(c)
+	setDefaultAzureClusterFirewall(c)
 	setDefaultAzureClusterSubnets(c)
 	setDefaultAzureClusterVnetPeering(c)
 	if c.Spec.ControlPlaneEnabled {
@@ -97,7 +113,10 @@ func setDefaultAzureClusterVnet(c *infrav1.AzureCluster) {
 	if c.Spec.NetworkSpec.Vnet.Name == "" {
 		c.Spec.NetworkSpec.Vnet.Name = generateVnetName(c.ObjectMeta.Name)
 	}
-	VnetClassSpecSetDefaults(&c.Spec.NetworkSpec.Vnet.VnetClassSpec)
+	// The CIDR blocks of a vnet with an IPAM pool are allocated from the pool by the controller.
+	if c.Spec.NetworkSpec.Vnet.IPAMPoolRef == nil {
+		VnetClassSpecSetDefaults(&c.Spec.NetworkSpec.Vnet.VnetClassSpec)
+	}
 }
 
 // setDefaultAzureClusterSubnets ensures a fully populated, default subnet configuration
@@ -140,7 +159,7 @@ func setDefaultAzureClusterSubnets(c *infrav1.AzureCluster) {
 		nodeSubnetCounter++
 		anyNodeSubnetFound = true
 		// Set has sensible defaults for this existing node subnet.
-		setDefaultSubnetSpecNodeSubnet(&subnet, c.ObjectMeta.Name, nodeSubnetCounter)
+		setDefaultSubnetSpecNodeSubnet(&subnet, c.ObjectMeta.Name, nodeSubnetCounter, c.Spec.NetworkSpec.Firewall == nil)
 		// Because there can be multiple node subnets, we have to update any changes
 		// after applying defaults to the explicit item at the current index.
 		c.Spec.NetworkSpec.Subnets[i] = subnet
@@ -162,11 +181,14 @@ func setDefaultAzureClusterSubnets(c *infrav1.AzureCluster) {
 			RouteTable: infrav1.RouteTable{
 				Name: generateNodeRouteTableName(c.ObjectMeta.Name),
 			},
-			NatGateway: infrav1.NatGateway{
+		}
+		// The egress traffic of the node subnets goes through the Azure Firewall when one is configured.
+		if c.Spec.NetworkSpec.Firewall == nil {
+			nodeSubnet.NatGateway = infrav1.NatGateway{
 				NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
 					Name: generateNatGatewayName(c.ObjectMeta.Name),
 				},
-			},
+			}
 		}
 		c.Spec.NetworkSpec.Subnets = append(c.Spec.NetworkSpec.Subnets, nodeSubnet)
 	}
@@ -182,8 +204,11 @@ func setDefaultAzureClusterSubnets(c *infrav1.AzureCluster) {
 	// nodes. For overlay CNIs (e.g. single-stack VXLAN) the route table carries
 	// no pod routes, so sharing it is a no-op. We copy the node subnet's actual
 	// route table name (rather than the generated default) so this also works
-	// when a custom node route table name is configured.
-	if c.Spec.ControlPlaneEnabled {
+	// when a custom node route table name is configured. This is skipped when
+	// an Azure Firewall is configured: the default route it adds to the node
+	// route table would send the replies of the control plane nodes to the
+	// API server load balancer through the firewall.
+	if c.Spec.ControlPlaneEnabled && c.Spec.NetworkSpec.Firewall == nil {
 		if cpSubnet, err := c.Spec.NetworkSpec.GetSubnet(infrav1.SubnetControlPlane); err == nil && cpSubnet.RouteTable.Name == "" {
 			if rtName := nodeRouteTableName(c); rtName != "" {
 				cpSubnet.RouteTable.Name = rtName
@@ -206,11 +231,14 @@ func nodeRouteTableName(c *infrav1.AzureCluster) string {
 }
 
 // setDefaultSubnetSpecNodeSubnet sets default values for a node SubnetSpec.
-func setDefaultSubnetSpecNodeSubnet(s *infrav1.SubnetSpec, clusterName string, index int) {
+// A NAT gateway is only defaulted when natGateway is true.
+func setDefaultSubnetSpecNodeSubnet(s *infrav1.SubnetSpec, clusterName string, index int, natGateway bool) {
 	if s.Name == "" {
 		s.Name = withIndex(generateNodeSubnetName(clusterName), index)
 	}
-	SubnetClassSpecSetDefaults(&s.SubnetClassSpec, fmt.Sprintf(DefaultNodeSubnetCIDRPattern, index))
+	if s.IPAMPoolRef == nil {
+		SubnetClassSpecSetDefaults(&s.SubnetClassSpec, fmt.Sprintf(DefaultNodeSubnetCIDRPattern, index))
+	}
 
 	if s.SecurityGroup.Name == "" {
 		s.SecurityGroup.Name = generateNodeSecurityGroupName(clusterName)
@@ -224,7 +252,7 @@ func setDefaultSubnetSpecNodeSubnet(s *infrav1.SubnetSpec, clusterName string, i
 	// NAT gateway only supports the use of IPv4 public IP addresses for outbound connectivity.
 	// So default use the NAT gateway for outbound traffic in IPv4 cluster instead of loadbalancer.
 	// We assume that if the ID is set, the subnet already exists so we shouldn't add a NAT gateway.
-	if !s.IsIPv6Enabled() && s.ID == "" {
+	if natGateway && !s.IsIPv6Enabled() && s.ID == "" {
 		if s.NatGateway.Name == "" {
 			s.NatGateway.Name = withIndex(generateNatGatewayName(clusterName), index)
 		}
@@ -232,6 +260,11 @@ func setDefaultSubnetSpecNodeSubnet(s *infrav1.SubnetSpec, clusterName string, i
 			s.NatGateway.NatGatewayIP.Name = generateNatGatewayIPName(s.NatGateway.Name)
 		}
 	}
+	// A NAT gateway explicitly associated with a dual-stack subnet handles its IPv4 outbound traffic with an IPv4
+	// public IP, while the IPv6 outbound traffic goes through the node outbound load balancer.
+	if s.IsIPv6Enabled() && s.IsNatGatewayEnabled() && s.ID == "" && s.NatGateway.NatGatewayIP.Name == "" {
+		s.NatGateway.NatGatewayIP.Name = generateNatGatewayIPName(s.NatGateway.Name)
+	}
 }
 
 // setDefaultSubnetSpecControlPlaneSubnet sets default values for a control plane SubnetSpec.
@@ -240,7 +273,9 @@ func setDefaultSubnetSpecControlPlaneSubnet(s *infrav1.SubnetSpec, clusterName s
 		s.Name = generateControlPlaneSubnetName(clusterName)
 	}
 
-	SubnetClassSpecSetDefaults(&s.SubnetClassSpec, DefaultControlPlaneSubnetCIDR)
+	if s.IPAMPoolRef == nil {
+		SubnetClassSpecSetDefaults(&s.SubnetClassSpec, DefaultControlPlaneSubnetCIDR)
+	}
 
 	if s.SecurityGroup.Name == "" {
 		s.SecurityGroup.Name = generateControlPlaneSecurityGroupName(clusterName)
@@ -267,7 +302,9 @@ func setDefaultSubnetSpecClusterSubnet(s *infrav1.SubnetSpec, clusterName string
 			s.NatGateway.NatGatewayIP.Name = generateNatGatewayIPName(s.NatGateway.Name)
 		}
 	}
-	SubnetClassSpecSetDefaults(&s.SubnetClassSpec, DefaultClusterSubnetCIDR)
+	if s.IPAMPoolRef == nil {
+		SubnetClassSpecSetDefaults(&s.SubnetClassSpec, DefaultClusterSubnetCIDR)
+	}
 	SecurityGroupClassSetDefaults(&s.SecurityGroup.SecurityGroupClass)
 }
 
@@ -321,11 +358,11 @@ func setDefaultAzureClusterAPIServerLB(c *infrav1.AzureCluster) {
 				}
 			}
 			// if no private IP is found, we should create a default internal LB IP
-			if !privateIPFound {
+			if !privateIPFound && !isControlPlaneSubnetIPAMAllocationPending(c) {
 				privateIP := infrav1.FrontendIP{
 					Name: generatePrivateIPConfigName(lb.Name),
 					FrontendIPClass: infrav1.FrontendIPClass{
-						PrivateIPAddress: DefaultInternalLBIPAddress,
+						PrivateIPAddress: defaultInternalLBIPAddress(c),
 					},
 				}
 				lb.FrontendIPs = append(lb.FrontendIPs, privateIP)
@@ -335,20 +372,56 @@ func setDefaultAzureClusterAPIServerLB(c *infrav1.AzureCluster) {
 		if lb.Name == "" {
 			lb.Name = generateInternalLBName(c.ObjectMeta.Name)
 		}
-		if len(lb.FrontendIPs) == 0 {
+		// The private IP of an internal LB is defaulted by the controller once the CIDR blocks of the control plane
+		// subnet are allocated from its IPAM pool.
+		if len(lb.FrontendIPs) == 0 && !isControlPlaneSubnetIPAMAllocationPending(c) {
 			lb.FrontendIPs = []infrav1.FrontendIP{
 				{
 					Name: generateFrontendIPConfigName(lb.Name),
 					FrontendIPClass: infrav1.FrontendIPClass{
-						PrivateIPAddress: DefaultInternalLBIPAddress,
+						PrivateIPAddress: defaultInternalLBIPAddress(c),
 					},
 				},
 			}
 		}
 	}
+	if pls := lb.PrivateLinkService; pls != nil && pls.Name == "" {
+		pls.Name = generatePrivateLinkServiceName(lb.Name)
+	}
 	setDefaultAzureClusterAPIServerLBBackendPoolName(c)
 }
 
+// defaultInternalLBIPAddress returns the default private IP of the API server LB. When the CIDR blocks of the control
+// plane subnet are allocated from an IPAM pool, it is the address at the same offset in the subnet as
+// DefaultInternalLBIPAddress in DefaultControlPlaneSubnetCIDR.
+func defaultInternalLBIPAddress(c *infrav1.AzureCluster) string {
+	subnet, err := c.Spec.NetworkSpec.GetControlPlaneSubnet()
+	if err != nil || subnet.IPAMPoolRef == nil || len(subnet.CIDRBlocks) == 0 {
+		return DefaultInternalLBIPAddress
+	}
+	prefix, err := netip.ParsePrefix(subnet.CIDRBlocks[0])
+	if err != nil || !prefix.Addr().Is4() {
+		return DefaultInternalLBIPAddress
+	}
+	defaultAddr := netip.MustParseAddr(DefaultInternalLBIPAddress).As4()
+	defaultNetwork := netip.MustParsePrefix(DefaultControlPlaneSubnetCIDR).Addr().As4()
+	offset := binary.BigEndian.Uint32(defaultAddr[:]) - binary.BigEndian.Uint32(defaultNetwork[:])
+	network := prefix.Masked().Addr().As4()
+	var addr [4]byte
+	binary.BigEndian.PutUint32(addr[:], binary.BigEndian.Uint32(network[:])+offset)
+	if !prefix.Contains(netip.AddrFrom4(addr)) {
+		return DefaultInternalLBIPAddress
+	}
+	return netip.AddrFrom4(addr).String()
+}
+
+// isControlPlaneSubnetIPAMAllocationPending returns true if the CIDR blocks of the control plane subnet of an
+// AzureCluster are still to be allocated from an IPAM pool.
+func isControlPlaneSubnetIPAMAllocationPending(c *infrav1.AzureCluster) bool {
+	subnet, err := c.Spec.NetworkSpec.GetControlPlaneSubnet()
+	return err == nil && subnet.IsIPAMAllocationPending()
+}
+
 // setDefaultAzureClusterNodeOutboundLB sets the default values for the NodeOutboundLB.
 func setDefaultAzureClusterNodeOutboundLB(c *infrav1.AzureCluster) {
 	if c.Spec.NetworkSpec.NodeOutboundLB == nil {
@@ -465,6 +538,23 @@ func setDefaultAzureClusterOutboundLBFrontendIPs(c *infrav1.AzureCluster, lb *in
 			}
 		}
 	}
+
+	// The IPv6 frontend IPs follow the IPv4 ones.
+	ipv6Count := int(ptr.Deref(lb.IPv6FrontendIPsCount, 0))
+	for i := 0; i < ipv6Count; i++ {
+		frontendIP := infrav1.FrontendIP{
+			Name: generateIPv6Name(generateFrontendIPConfigName(lb.Name)),
+			PublicIP: &infrav1.PublicIPSpec{
+				Name:      generateIPv6Name(generatePublicIPName(c.ObjectMeta.Name)),
+				IPVersion: infrav1.IPv6,
+			},
+		}
+		if ipv6Count > 1 {
+			frontendIP.Name = withIndex(frontendIP.Name, i+1)
+			frontendIP.PublicIP.Name = withIndex(frontendIP.PublicIP.Name, i+1)
+		}
+		lb.FrontendIPs = append(lb.FrontendIPs, frontendIP)
+	}
 }
 
 // setDefaultAzureClusterBastion sets default values for an AzureCluster's bastion configuration.
@@ -477,7 +567,7 @@ func setDefaultAzureClusterBastion(c *infrav1.AzureCluster) {
 		if c.Spec.BastionSpec.AzureBastion.Subnet.Name == "" {
 			c.Spec.BastionSpec.AzureBastion.Subnet.Name = DefaultAzureBastionSubnetName
 		}
-		if len(c.Spec.BastionSpec.AzureBastion.Subnet.CIDRBlocks) == 0 {
+		if len(c.Spec.BastionSpec.AzureBastion.Subnet.CIDRBlocks) == 0 && c.Spec.BastionSpec.AzureBastion.Subnet.IPAMPoolRef == nil {
 			c.Spec.BastionSpec.AzureBastion.Subnet.CIDRBlocks = []string{DefaultAzureBastionSubnetCIDR}
 		}
 		if c.Spec.BastionSpec.AzureBastion.Subnet.Role == "" {
@@ -490,6 +580,68 @@ func setDefaultAzureClusterBastion(c *infrav1.AzureCluster) {
 	}
 }
 
+// setDefaultAzureClusterIngress sets default values for an AzureCluster's ingress configuration.
+func setDefaultAzureClusterIngress(c *infrav1.AzureCluster) {
+	ingress := c.Spec.NetworkSpec.Ingress
+	if ingress == nil {
+		return
+	}
+	if ingress.Name == "" {
+		ingress.Name = generateIngressApplicationGatewayName(c.ObjectMeta.Name)
+	}
+	if ingress.SKU == "" {
+		ingress.SKU = infrav1.StandardV2ApplicationGatewaySKU
+	}
+	if ingress.Capacity == 0 {
+		ingress.Capacity = DefaultIngressCapacity
+	}
+	// Ensure defaults for the Subnet settings.
+	if ingress.Subnet.Name == "" {
+		ingress.Subnet.Name = generateIngressSubnetName(c.ObjectMeta.Name)
+	}
+	if len(ingress.Subnet.CIDRBlocks) == 0 && ingress.Subnet.IPAMPoolRef == nil {
+		ingress.Subnet.CIDRBlocks = []string{DefaultIngressSubnetCIDR}
+	}
+	if ingress.Subnet.Role == "" {
+		ingress.Subnet.Role = DefaultIngressSubnetRole
+	}
+	// Ensure defaults for the PublicIP settings.
+	if ingress.PublicIP.Name == "" {
+		ingress.PublicIP.Name = generateIngressPublicIPName(c.ObjectMeta.Name)
+	}
+}
+
+// setDefaultAzureClusterFirewall sets default values for an AzureCluster's firewall configuration.
+func setDefaultAzureClusterFirewall(c *infrav1.AzureCluster) {
+	firewall := c.Spec.NetworkSpec.Firewall
+	if firewall == nil {
+		return
+	}
+	if firewall.Name == "" {
+		firewall.Name = generateAzureFirewallName(c.ObjectMeta.Name)
+	}
+	if firewall.SKUTier == "" {
+		firewall.SKUTier = infrav1.StandardAzureFirewallSKUTier
+	}
+	if firewall.PolicyName == "" {
+		firewall.PolicyName = generateAzureFirewallPolicyName(c.ObjectMeta.Name)
+	}
+	// Ensure defaults for the Subnet settings.
+	if firewall.Subnet.Name == "" {
+		firewall.Subnet.Name = DefaultAzureFirewallSubnetName
+	}
+	if len(firewall.Subnet.CIDRBlocks) == 0 && firewall.Subnet.IPAMPoolRef == nil {
+		firewall.Subnet.CIDRBlocks = []string{DefaultAzureFirewallSubnetCIDR}
+	}
+	if firewall.Subnet.Role == "" {
+		firewall.Subnet.Role = DefaultAzureFirewallSubnetRole
+	}
+	// Ensure defaults for the PublicIP settings.
+	if firewall.PublicIP.Name == "" {
+		firewall.PublicIP.Name = generateAzureFirewallPublicIPName(c.ObjectMeta.Name)
+	}
+}
+
 // setDefaultLoadBalancerClassSpecAPIServerLB sets default values for an API server LoadBalancerClassSpec.
 func setDefaultLoadBalancerClassSpecAPIServerLB(lb *infrav1.LoadBalancerClassSpec) {
 	if lb.Type == "" {
@@ -552,6 +704,36 @@ func generateAzureBastionPublicIPName(clusterName string) string {
 	return fmt.Sprintf("%s-azure-bastion-pip", clusterName)
 }
 
+// generateIngressApplicationGatewayName generates an ingress application gateway name, based on the cluster name.
+func generateIngressApplicationGatewayName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "appgw")
+}
+
+// generateIngressSubnetName generates an ingress subnet name, based on the cluster name.
+func generateIngressSubnetName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "ingress-subnet")
+}
+
+// generateIngressPublicIPName generates an ingress application gateway public ip name, based on the cluster name.
+func generateIngressPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-appgw-pip", clusterName)
+}
+
+// generateAzureFirewallName generates an azure firewall name, based on the cluster name.
+func generateAzureFirewallName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "azfw")
+}
+
+// generateAzureFirewallPolicyName generates an azure firewall policy name, based on the cluster name.
+func generateAzureFirewallPolicyName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "azfw-policy")
+}
+
+// generateAzureFirewallPublicIPName generates an azure firewall public ip name, based on the cluster name.
+func generateAzureFirewallPublicIPName(clusterName string) string {
+	return fmt.Sprintf("%s-azfw-pip", clusterName)
+}
+
 // generateClusterSecurityGroupName generates a security group name, based on the cluster name.
 func generateClusterSecurityGroupName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "nsg")
@@ -607,6 +789,11 @@ func generatePrivateIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd-internal-ip")
 }
 
+// generatePrivateLinkServiceName generates the name of the private link service of a load balancer.
+func generatePrivateLinkServiceName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "pls")
+}
+
 // generateNodeOutboundIPName generates a public IP name, based on the cluster name.
 func generateNodeOutboundIPName(clusterName string) string {
 	return fmt.Sprintf("pip-%s-node-outbound", clusterName)
@@ -632,6 +819,11 @@ func generateNatGatewayIPName(natGatewayName string) string {
 	return fmt.Sprintf("pip-%s", natGatewayName)
 }
 
+// generateIPv6Name generates the name of the IPv6 counterpart of a generated name.
+func generateIPv6Name(name string) string {
+	return fmt.Sprintf("%s-ipv6", name)
+}
+
 // withIndex appends the index as suffix to a generated name.
 func withIndex(name string, n int) string {
 	return fmt.Sprintf("%s-%d", name, n)
@@ -21,6 +21,8 @@ import (
 	"reflect"
 	"testing"
 
+	. "github.com/onsi/gomega"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/component-base/featuregate"
@@ -871,6 +873,74 @@ func TestSubnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dual-stack node subnet with a NAT gateway",
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       "control-plane",
+									CIDRBlocks: []string{"10.0.0.0/16", "2001:beef::1/64"},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"10.1.0.0/16", "2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								NatGateway: infrav1.NatGateway{
+									NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: "my-natgw"},
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       infrav1.SubnetControlPlane,
+									CIDRBlocks: []string{"10.0.0.0/16", "2001:beef::1/64"},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: infrav1.SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    infrav1.RouteTable{Name: "cluster-test-node-routetable"},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       infrav1.SubnetNode,
+									CIDRBlocks: []string{"10.1.0.0/16", "2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: infrav1.SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    infrav1.RouteTable{Name: "cluster-test-node-routetable"},
+								NatGateway: infrav1.NatGateway{
+									NatGatewayIP: infrav1.PublicIPSpec{
+										Name: "pip-my-natgw",
+									},
+									NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: "my-natgw"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "subnets with custom security group",
 			cluster: &infrav1.AzureCluster{
@@ -1216,6 +1286,92 @@ func TestSubnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no subnets with firewall",
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       infrav1.SubnetControlPlane,
+									CIDRBlocks: []string{DefaultControlPlaneSubnetCIDR},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: infrav1.SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       infrav1.SubnetNode,
+									CIDRBlocks: []string{DefaultNodeSubnetCIDR},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: infrav1.SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    infrav1.RouteTable{Name: "cluster-test-node-routetable"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "node subnet with firewall",
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role: infrav1.SubnetNode,
+									Name: "my-node-subnet",
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       infrav1.SubnetNode,
+									CIDRBlocks: []string{"10.1.0.0/16"},
+									Name:       "my-node-subnet",
+								},
+								SecurityGroup: infrav1.SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    infrav1.RouteTable{Name: "cluster-test-node-routetable"},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -1593,6 +1749,58 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "internal lb with private link service",
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: &infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Internal,
+							},
+							PrivateLinkService: &infrav1.PrivateLinkServiceSpec{
+								AllowedSubscriptions: []string{"00000000-0000-0000-0000-000000000000"},
+							},
+						},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: &infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "cluster-test-internal-lb-frontEnd",
+									FrontendIPClass: infrav1.FrontendIPClass{
+										PrivateIPAddress: DefaultInternalLBIPAddress,
+									},
+								},
+							},
+							BackendPool: infrav1.BackendPool{
+								Name: "cluster-test-internal-lb-backendPool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								SKU:                  infrav1.SKUStandard,
+								Type:                 infrav1.Internal,
+								IdleTimeoutInMinutes: ptr.To[int32](DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+							Name: "cluster-test-internal-lb",
+							PrivateLinkService: &infrav1.PrivateLinkServiceSpec{
+								Name:                 "cluster-test-internal-lb-pls",
+								AllowedSubscriptions: []string{"00000000-0000-0000-0000-000000000000"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:        "with custom backend pool name with feature gate API Server ILB enabled",
 			featureGate: feature.APIServerILB,
@@ -1971,6 +2179,144 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv6 frontend IP on a dual-stack node subnet",
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{IPv6FrontendIPsCount: ptr.To[int32](1)},
+						APIServerLB:    &infrav1.LoadBalancerSpec{LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Public}},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"10.1.0.0/16", "2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Role:       "node",
+									CIDRBlocks: []string{"10.1.0.0/16", "2001:beea::1/64"},
+									Name:       "cluster-test-node-subnet",
+								},
+							},
+						},
+						APIServerLB: &infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							Name: "cluster-test",
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "cluster-test-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "pip-cluster-test-node-outbound",
+									},
+								},
+								{
+									Name: "cluster-test-frontEnd-ipv6",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:      "pip-cluster-test-node-outbound-ipv6",
+										IPVersion: infrav1.IPv6,
+									},
+								},
+							},
+							BackendPool: infrav1.BackendPool{
+								Name: "cluster-test-outboundBackendPool",
+							},
+							FrontendIPsCount:     ptr.To[int32](1),
+							IPv6FrontendIPsCount: ptr.To[int32](1),
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								SKU:                  infrav1.SKUStandard,
+								Type:                 infrav1.Public,
+								IdleTimeoutInMinutes: ptr.To[int32](DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple IPv6 frontend IPs",
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: &infrav1.LoadBalancerSpec{LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{Type: infrav1.Public}},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							FrontendIPsCount:     ptr.To[int32](0),
+							IPv6FrontendIPsCount: ptr.To[int32](2),
+						},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ControlPlaneEnabled: true,
+					NetworkSpec: infrav1.NetworkSpec{
+						APIServerLB: &infrav1.LoadBalancerSpec{
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							Name: "cluster-test",
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "cluster-test-frontEnd-ipv6-1",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:      "pip-cluster-test-node-outbound-ipv6-1",
+										IPVersion: infrav1.IPv6,
+									},
+								},
+								{
+									Name: "cluster-test-frontEnd-ipv6-2",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:      "pip-cluster-test-node-outbound-ipv6-2",
+										IPVersion: infrav1.IPv6,
+									},
+								},
+							},
+							BackendPool: infrav1.BackendPool{
+								Name: "cluster-test-outboundBackendPool",
+							},
+							FrontendIPsCount:     ptr.To[int32](0),
+							IPv6FrontendIPsCount: ptr.To[int32](2),
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								SKU:                  infrav1.SKUStandard,
+								Type:                 infrav1.Public,
+								IdleTimeoutInMinutes: ptr.To[int32](DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "IPv6 enabled on 1 of 2 node subnets",
 			cluster: &infrav1.AzureCluster{
@@ -2900,3 +3246,317 @@ func TestBastionDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *infrav1.AzureCluster
+		output  *infrav1.AzureCluster
+	}{
+		"no ingress set": {
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{},
+			},
+		},
+		"ingress enabled with no settings": {
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Ingress: &infrav1.IngressSpec{},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Ingress: &infrav1.IngressSpec{
+							Name:     "foo-appgw",
+							SKU:      infrav1.StandardV2ApplicationGatewaySKU,
+							Capacity: DefaultIngressCapacity,
+							Subnet: infrav1.SubnetSpec{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									CIDRBlocks: []string{DefaultIngressSubnetCIDR},
+									Role:       DefaultIngressSubnetRole,
+									Name:       "foo-ingress-subnet",
+								},
+							},
+							PublicIP: infrav1.PublicIPSpec{
+								Name: "foo-appgw-pip",
+							},
+						},
+					},
+				},
+			},
+		},
+		"ingress enabled with settings": {
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Ingress: &infrav1.IngressSpec{
+							Name:        "my-appgw",
+							SKU:         infrav1.WAFV2ApplicationGatewaySKU,
+							Capacity:    3,
+							WAFPolicyID: "my-waf-policy-id",
+							Subnet: infrav1.SubnetSpec{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/24"},
+									Name:       "my-appgw-subnet",
+								},
+							},
+							PublicIP: infrav1.PublicIPSpec{
+								Name:    "my-appgw-pip",
+								DNSName: "my-appgw.example.com",
+							},
+						},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Ingress: &infrav1.IngressSpec{
+							Name:        "my-appgw",
+							SKU:         infrav1.WAFV2ApplicationGatewaySKU,
+							Capacity:    3,
+							WAFPolicyID: "my-waf-policy-id",
+							Subnet: infrav1.SubnetSpec{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/24"},
+									Role:       DefaultIngressSubnetRole,
+									Name:       "my-appgw-subnet",
+								},
+							},
+							PublicIP: infrav1.PublicIPSpec{
+								Name:    "my-appgw-pip",
+								DNSName: "my-appgw.example.com",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			setDefaultAzureClusterIngress(c.cluster)
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestFirewallDefault(t *testing.T) {
+	cases := map[string]struct {
+		cluster *infrav1.AzureCluster
+		output  *infrav1.AzureCluster
+	}{
+		"no firewall set": {
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{},
+			},
+		},
+		"firewall enabled with no settings": {
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{
+							Name:       "foo-azfw",
+							SKUTier:    infrav1.StandardAzureFirewallSKUTier,
+							PolicyName: "foo-azfw-policy",
+							Subnet: infrav1.SubnetSpec{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									CIDRBlocks: []string{DefaultAzureFirewallSubnetCIDR},
+									Role:       DefaultAzureFirewallSubnetRole,
+									Name:       DefaultAzureFirewallSubnetName,
+								},
+							},
+							PublicIP: infrav1.PublicIPSpec{
+								Name: "foo-azfw-pip",
+							},
+						},
+					},
+				},
+			},
+		},
+		"firewall enabled with settings": {
+			cluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{
+							Name:            "my-azfw",
+							SKUTier:         infrav1.PremiumAzureFirewallSKUTier,
+							PolicyName:      "my-azfw-policy",
+							AdditionalFQDNs: []string{"*.example.com"},
+							Subnet: infrav1.SubnetSpec{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/26"},
+								},
+							},
+							PublicIP: infrav1.PublicIPSpec{
+								Name: "my-azfw-pip",
+							},
+						},
+					},
+				},
+			},
+			output: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Firewall: &infrav1.FirewallSpec{
+							Name:            "my-azfw",
+							SKUTier:         infrav1.PremiumAzureFirewallSKUTier,
+							PolicyName:      "my-azfw-policy",
+							AdditionalFQDNs: []string{"*.example.com"},
+							Subnet: infrav1.SubnetSpec{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									CIDRBlocks: []string{"10.10.0.0/26"},
+									Role:       DefaultAzureFirewallSubnetRole,
+									Name:       DefaultAzureFirewallSubnetName,
+								},
+							},
+							PublicIP: infrav1.PublicIPSpec{
+								Name: "my-azfw-pip",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name := range cases {
+		c := cases[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			setDefaultAzureClusterFirewall(c.cluster)
+			if !reflect.DeepEqual(c.cluster, c.output) {
+				expected, _ := json.MarshalIndent(c.output, "", "\t")
+				actual, _ := json.MarshalIndent(c.cluster, "", "\t")
+				t.Errorf("Expected %s, got %s", string(expected), string(actual))
+			}
+		})
+	}
+}
+
+func TestIPAMDefaults(t *testing.T) {
+	pool := &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     "cidr-pool",
+	}
+	newCluster := func(controlPlaneCIDRs []string) *infrav1.AzureCluster {
+		return &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo",
+			},
+			Spec: infrav1.AzureClusterSpec{
+				ControlPlaneEnabled: true,
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet: infrav1.VnetSpec{
+						IPAMPoolRef: pool,
+					},
+					Subnets: infrav1.Subnets{
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role:       infrav1.SubnetControlPlane,
+								CIDRBlocks: controlPlaneCIDRs,
+							},
+							IPAMPoolRef: pool,
+						},
+						{
+							SubnetClassSpec: infrav1.SubnetClassSpec{
+								Role: infrav1.SubnetNode,
+							},
+							IPAMPoolRef: pool,
+						},
+					},
+					APIServerLB: &infrav1.LoadBalancerSpec{
+						LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+							Type: infrav1.Internal,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("CIDR blocks and private IP wait for the allocation", func(t *testing.T) {
+		g := NewWithT(t)
+		cluster := newCluster(nil)
+		SetDefaultsAzureCluster(cluster)
+		g.Expect(cluster.Spec.NetworkSpec.Vnet.CIDRBlocks).To(BeEmpty())
+		for _, subnet := range cluster.Spec.NetworkSpec.Subnets {
+			g.Expect(subnet.CIDRBlocks).To(BeEmpty())
+		}
+		g.Expect(cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs).To(BeEmpty())
+	})
+
+	t.Run("private IP is defaulted in the allocated control plane subnet", func(t *testing.T) {
+		g := NewWithT(t)
+		cluster := newCluster([]string{"10.42.8.0/24"})
+		SetDefaultsAzureCluster(cluster)
+		g.Expect(cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs).To(HaveLen(1))
+		g.Expect(cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs[0].PrivateIPAddress).To(Equal("10.42.8.100"))
+	})
+
+	t.Run("private IP falls back to the default outside a small allocated control plane subnet", func(t *testing.T) {
+		g := NewWithT(t)
+		cluster := newCluster([]string{"10.42.8.0/26"})
+		SetDefaultsAzureCluster(cluster)
+		g.Expect(cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs[0].PrivateIPAddress).To(Equal(DefaultInternalLBIPAddress))
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/blang/semver"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -107,6 +109,11 @@ func validateNetwork(amp *infrav1exp.AzureMachinePool) error {
 	if (amp.Spec.Template.NetworkInterfaces != nil) && len(amp.Spec.Template.NetworkInterfaces) > 0 && amp.Spec.Template.SubnetName != "" { //nolint:staticcheck
 		return errors.New("cannot set both NetworkInterfaces and machine SubnetName")
 	}
+	for _, networkInterface := range amp.Spec.Template.NetworkInterfaces {
+		if networkInterface.PrivateIPAddress != "" || networkInterface.IPAMPoolRef != nil {
+			return errors.New("static private IP addresses are not supported by the network interfaces of AzureMachinePools")
+		}
+	}
 	return nil
 }
 
@@ -172,12 +179,42 @@ func validateStrategy(amp *infrav1exp.AzureMachinePool) func() error {
 				maxUnavailable.Type == intstr.Int && maxUnavailable.IntVal == 0 {
 				return errors.New("rolling update strategy MaxUnavailable must not be 0 if MaxSurge is 0")
 			}
+
+			if gate := rollingUpdateStrategy.HealthGate; gate != nil {
+				if err := validateRolloutHealthGate(gate); err != nil {
+					return err
+				}
+			}
+		}
+
+		if amp.Spec.Strategy.Type == infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType && amp.Spec.Strategy.BlueGreen != nil {
+			if readyTimeout := amp.Spec.Strategy.BlueGreen.ReadyTimeout; readyTimeout != nil && readyTimeout.Duration <= 0 {
+				return errors.New("blue/green strategy ReadyTimeout must be greater than 0")
+			}
 		}
 
 		return nil
 	}
 }
 
+func validateRolloutHealthGate(gate *infrav1exp.MachinePoolRolloutHealthGate) error {
+	if deadline := gate.ProgressDeadline; deadline != nil && deadline.Duration <= 0 {
+		return errors.New("rolling update health gate ProgressDeadline must be greater than 0")
+	}
+
+	if gate.ReadinessLabel != "" {
+		key, value, _ := strings.Cut(gate.ReadinessLabel, "=")
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return errors.Errorf("rolling update health gate ReadinessLabel key %q is invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return errors.Errorf("rolling update health gate ReadinessLabel value %q is invalid: %s", value, strings.Join(errs, "; "))
+		}
+	}
+
+	return nil
+}
+
 func validateSystemAssignedIdentity(amp *infrav1exp.AzureMachinePool, old runtime.Object) func() error {
 	return func() error {
 		var oldRole string
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	guuid "github.com/google/uuid"
@@ -197,6 +198,70 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name: "azuremachinepool with valid rolling update health gate",
+			amp: createMachinePoolWithStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+				Type: infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType,
+				RollingUpdate: &infrav1exp.MachineRollingUpdateDeployment{
+					MaxSurge:       &one,
+					MaxUnavailable: &zero,
+					HealthGate: &infrav1exp.MachinePoolRolloutHealthGate{
+						ReadinessLabel:   "example.com/ready=true",
+						ProgressDeadline: &metav1.Duration{Duration: 5 * time.Minute},
+						AutoRollback:     true,
+					},
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with zero rolling update health gate progress deadline",
+			amp: createMachinePoolWithStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+				Type: infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType,
+				RollingUpdate: &infrav1exp.MachineRollingUpdateDeployment{
+					MaxSurge:       &one,
+					MaxUnavailable: &zero,
+					HealthGate: &infrav1exp.MachinePoolRolloutHealthGate{
+						ProgressDeadline: &metav1.Duration{},
+					},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with invalid rolling update health gate readiness label",
+			amp: createMachinePoolWithStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+				Type: infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType,
+				RollingUpdate: &infrav1exp.MachineRollingUpdateDeployment{
+					MaxSurge:       &one,
+					MaxUnavailable: &zero,
+					HealthGate: &infrav1exp.MachinePoolRolloutHealthGate{
+						ReadinessLabel: "not a label",
+					},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with valid blue/green configuration",
+			amp: createMachinePoolWithStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+				Type: infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+				BlueGreen: &infrav1exp.MachineBlueGreenDeployment{
+					ReadyTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with zero blue/green ready timeout",
+			amp: createMachinePoolWithStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+				Type: infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+				BlueGreen: &infrav1exp.MachineBlueGreenDeployment{
+					ReadyTimeout: &metav1.Duration{},
+				},
+			}),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with valid legacy network configuration",
 			amp:     createMachinePoolWithNetworkConfig("testSubnet", []infrav1.NetworkInterface{}),
@@ -212,6 +277,11 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet"}}),
 			wantErr: false,
 		},
+		{
+			name:    "azuremachinepool with a static private IP address",
+			amp:     createMachinePoolWithNetworkConfig("", []infrav1.NetworkInterface{{SubnetName: "testSubnet", PrivateIPAddress: "10.0.0.10"}}),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with Flexible orchestration mode",
 			amp:     createMachinePoolWithOrchestrationMode(armcompute.OrchestrationModeFlexible),
@@ -27,6 +27,9 @@ import (
 	goruntime "runtime"
 	"strings"
 
+	asonetworkv1api20201101 "github.com/Azure/azure-service-operator/v2/api/network/v1api20201101"
+	asonetworkv1api20220701 "github.com/Azure/azure-service-operator/v2/api/network/v1api20220701"
+	asoresourcesv1 "github.com/Azure/azure-service-operator/v2/api/resources/v1api20200601"
 	"github.com/go-logr/logr"
 	"github.com/onsi/ginkgo/v2"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,6 +39,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -44,6 +48,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/fakearm"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/record"
 )
 
@@ -58,8 +63,12 @@ func init() {
 	// Calculate the scheme.
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
+	utilruntime.Must(ipamv1.AddToScheme(scheme))
 	utilruntime.Must(infrav1.AddToScheme(scheme))
 	utilruntime.Must(infrav1exp.AddToScheme(scheme))
+	utilruntime.Must(asoresourcesv1.AddToScheme(scheme))
+	utilruntime.Must(asonetworkv1api20201101.AddToScheme(scheme))
+	utilruntime.Must(asonetworkv1api20220701.AddToScheme(scheme))
 
 	// Get the root of the current file to use in CRD paths.
 	_, filename, _, _ := goruntime.Caller(0) //nolint:dogsled // Ignore "declaration has 3 blank identifiers" check.
@@ -67,6 +76,8 @@ func init() {
 
 	crdPaths := []string{
 		filepath.Join(root, "config", "crd", "bases"),
+		// Minimal ASO CRDs accepting any spec and status, so the ASO resources of AzureClusters can be stored.
+		filepath.Join(root, "internal", "test", "env", "testdata", "aso"),
 	}
 
 	if capiPath := getFilePathToCAPICRDs(root); capiPath != "" {
@@ -95,6 +106,9 @@ type (
 
 // NewTestEnvironment creates a new environment spinning up a local api-server.
 //
+// The credentials of its CredentialCache return a fake token, so reconcilers never reach Microsoft Entra ID. Tests
+// point them at a fake Resource Manager with the AZURE_RESOURCE_MANAGER_ENDPOINT environment variable.
+//
 // This function should be called only once for each package you're running tests within,
 // usually the environment is initialized in a suite_test.go file within a `BeforeSuite` ginkgo block.
 func NewTestEnvironment() *TestEnvironment {
@@ -118,7 +132,7 @@ func NewTestEnvironment() *TestEnvironment {
 		Config:          mgr.GetConfig(),
 		LogRecorder:     logger,
 		Log:             logger,
-		CredentialCache: azure.NewCredentialCache(),
+		CredentialCache: fakearm.NewCredentialCache(),
 		doneMgr:         make(chan struct{}),
 	}
 }
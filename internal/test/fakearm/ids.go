@@ -0,0 +1,116 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// resourceRef describes the resource or collection addressed by a request path.
+type resourceRef struct {
+	// id is the resource ID, with the casing used in the request.
+	id string
+	// name is the resource name. It is empty for collections.
+	name string
+	// collection is true when the path addresses a list of resources rather than a single resource.
+	collection bool
+	// resourceGroup is the name of the resource group the resource belongs to, if any.
+	resourceGroup string
+	// parentName is the name of the parent resource of a nested resource.
+	parentName string
+
+	lowerID              string
+	lowerType            string
+	lowerScope           string
+	lowerResourceGroupID string
+	lowerParentID        string
+}
+
+// parseID parses an ARM resource or collection path such as
+// /subscriptions/{sub}/resourceGroups/{rg}/providers/Microsoft.Network/virtualNetworks/{vnet}/subnets.
+func parseID(path string) (resourceRef, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") || segments[1] == "" {
+		return resourceRef{}, errors.Errorf("invalid resource ID %q", path)
+	}
+	subscriptionScope := "/subscriptions/" + segments[1]
+	rest := segments[2:]
+
+	ref := resourceRef{}
+	scope := subscriptionScope
+	if len(rest) >= 1 && strings.EqualFold(rest[0], "resourceGroups") {
+		if len(rest) <= 2 {
+			ref.lowerType = "microsoft.resources/resourcegroups"
+			ref.lowerScope = strings.ToLower(subscriptionScope)
+			if len(rest) == 1 {
+				ref.collection = true
+				ref.id = subscriptionScope + "/resourceGroups"
+			} else {
+				ref.name = rest[1]
+				ref.resourceGroup = rest[1]
+				ref.id = subscriptionScope + "/resourceGroups/" + rest[1]
+				ref.lowerResourceGroupID = strings.ToLower(ref.id)
+			}
+			ref.lowerID = strings.ToLower(ref.id)
+			return ref, nil
+		}
+		ref.resourceGroup = rest[1]
+		scope = subscriptionScope + "/resourceGroups/" + rest[1]
+		ref.lowerResourceGroupID = strings.ToLower(scope)
+		rest = rest[2:]
+	}
+
+	// The remainder is providers/{namespace}/{type}[/{name}[/{type}[/{name}]...]].
+	if len(rest) < 3 || !strings.EqualFold(rest[0], "providers") {
+		return resourceRef{}, errors.Errorf("invalid resource ID %q", path)
+	}
+	namespace := rest[1]
+	typeParts := []string{namespace}
+	id := scope + "/providers/" + namespace
+	parentID := ""
+	parts := rest[2:]
+	for i := 0; i < len(parts); i += 2 {
+		typeParts = append(typeParts, parts[i])
+		if i > 0 {
+			parentID = id
+			ref.parentName = parts[i-1]
+		}
+		id += "/" + parts[i]
+		if i+1 < len(parts) {
+			id += "/" + parts[i+1]
+			ref.name = parts[i+1]
+		} else {
+			ref.collection = true
+			ref.name = ""
+		}
+	}
+
+	ref.id = id
+	ref.lowerID = strings.ToLower(id)
+	ref.lowerType = strings.ToLower(strings.Join(typeParts, "/"))
+	ref.lowerParentID = strings.ToLower(parentID)
+	if ref.collection {
+		// Resources in a collection are listed from the scope of their parent, or of their resource group.
+		ref.lowerScope = strings.ToLower(scope)
+		if parentID != "" {
+			ref.lowerScope = ref.lowerParentID
+		}
+	}
+	return ref, nil
+}
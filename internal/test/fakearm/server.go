@@ -0,0 +1,729 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakearm implements an in-process fake of the Azure Resource Manager API for offline testing of the
+// Azure SDK clients used by CAPZ.
+package fakearm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+const (
+	operationsPath = "/fakearm/operations/"
+	// tagsPath is the path of the tags of a resource, relative to the resource ID.
+	tagsPath = "/providers/microsoft.resources/tags/default"
+)
+
+// resourceTypes lists the supported resource types, keyed by their lower-cased name.
+var resourceTypes = map[string]string{
+	"microsoft.resources/resourcegroups":                        "Microsoft.Resources/resourceGroups",
+	"microsoft.network/virtualnetworks":                         "Microsoft.Network/virtualNetworks",
+	"microsoft.network/virtualnetworks/subnets":                 "Microsoft.Network/virtualNetworks/subnets",
+	"microsoft.network/networksecuritygroups":                   "Microsoft.Network/networkSecurityGroups",
+	"microsoft.network/routetables":                             "Microsoft.Network/routeTables",
+	"microsoft.network/natgateways":                             "Microsoft.Network/natGateways",
+	"microsoft.network/publicipaddresses":                       "Microsoft.Network/publicIPAddresses",
+	"microsoft.network/loadbalancers":                           "Microsoft.Network/loadBalancers",
+	"microsoft.network/networkinterfaces":                       "Microsoft.Network/networkInterfaces",
+	"microsoft.compute/availabilitysets":                        "Microsoft.Compute/availabilitySets",
+	"microsoft.compute/disks":                                   "Microsoft.Compute/disks",
+	"microsoft.compute/skus":                                    "Microsoft.Compute/skus",
+	"microsoft.compute/virtualmachines":                         "Microsoft.Compute/virtualMachines",
+	"microsoft.compute/virtualmachinescalesets":                 "Microsoft.Compute/virtualMachineScaleSets",
+	"microsoft.compute/virtualmachinescalesets/virtualmachines": "Microsoft.Compute/virtualMachineScaleSets/virtualMachines",
+}
+
+// readOnlyTypes lists the resource types which cannot be created directly, such as the ones managed by their parent.
+var readOnlyTypes = map[string]bool{
+	"microsoft.compute/skus":                                    true,
+	"microsoft.compute/virtualmachinescalesets/virtualmachines": true,
+}
+
+// synchronousTypes lists the resource types which are created, updated and deleted without long-running operations.
+var synchronousTypes = map[string]bool{
+	"microsoft.compute/availabilitysets": true,
+}
+
+// Server is a fake Azure Resource Manager. Resources are kept in memory and long-running operations complete after
+// a configurable number of polls. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	pollsUntilDone int
+
+	mu         sync.Mutex
+	resources  map[string]map[string]any
+	operations map[string]*operation
+	faults     []*fault
+	nextID     int
+	nextIP     int
+}
+
+// operation is a long-running operation.
+type operation struct {
+	pollsLeft int
+	complete  func()
+	failure   *Error
+}
+
+// fault is an error returned for the next request matching a method and resource ID.
+type fault struct {
+	method string
+	id     string
+	err    Error
+}
+
+// Error is an ARM error response.
+type Error struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithPollsUntilDone sets the number of times a long-running operation must be polled before it completes.
+func WithPollsUntilDone(polls int) Option {
+	return func(s *Server) {
+		s.pollsUntilDone = polls
+	}
+}
+
+// NewServer starts a new fake Resource Manager. Callers should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		pollsUntilDone: 1,
+		resources:      make(map[string]map[string]any),
+		operations:     make(map[string]*operation),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Authorizer returns an azure.Authorizer for subscriptionID whose requests are sent to the server with a fake token.
+func (s *Server) Authorizer(subscriptionID string) azure.CustomBaseURIAuthorizer {
+	return azure.WithBaseURI(&authorizer{subscriptionID: subscriptionID}, s.URL)
+}
+
+// Resource returns a copy of the resource with the given ID, if it exists.
+func (s *Server) Resource(id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return nil, false
+	}
+	return deepCopy(r), true
+}
+
+// SetResource creates or replaces the resource with the given ID, bypassing long-running operations. It is useful
+// to seed resources which exist before a test starts.
+func (s *Server) SetResource(id string, resource map[string]any) error {
+	ref, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(ref, deepCopy(resource), "Succeeded")
+	return nil
+}
+
+// DeleteResource deletes the resource with the given ID and all resources nested under it, bypassing long-running
+// operations. It is useful to emulate resources deleted by a controller other than the one under test.
+func (s *Server) DeleteResource(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteTree(strings.ToLower(id))
+}
+
+// InjectError makes the next request with method to the resource with the given ID fail with err.
+func (s *Server) InjectError(method, id string, err Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{method: method, id: strings.ToLower(id), err: err})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, operationsPath) {
+		s.pollOperation(w, strings.TrimPrefix(r.URL.Path, operationsPath))
+		return
+	}
+	if strings.HasSuffix(strings.ToLower(r.URL.Path), tagsPath) {
+		s.serveTags(w, r, r.URL.Path[:len(r.URL.Path)-len(tagsPath)])
+		return
+	}
+
+	ref, err := parseID(r.URL.Path)
+	if err != nil {
+		writeError(w, Error{StatusCode: http.StatusBadRequest, Code: "InvalidResourceId", Message: err.Error()})
+		return
+	}
+	if _, ok := resourceTypes[ref.lowerType]; !ok {
+		writeError(w, Error{StatusCode: http.StatusBadRequest, Code: "NoRegisteredProviderFound", Message: fmt.Sprintf("resource type %s is not supported", ref.lowerType)})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f := s.takeFault(r.Method, ref.lowerID); f != nil {
+		writeError(w, f.err)
+		return
+	}
+
+	switch {
+	case ref.collection && r.Method == http.MethodGet:
+		s.list(w, ref)
+	case ref.collection:
+		writeError(w, Error{StatusCode: http.StatusMethodNotAllowed, Code: "MethodNotAllowed", Message: r.Method + " is not supported on collections"})
+	case r.Method == http.MethodGet:
+		s.get(w, ref)
+	case r.Method == http.MethodPut:
+		s.put(w, r, ref)
+	case r.Method == http.MethodPatch:
+		s.patch(w, r, ref)
+	case r.Method == http.MethodDelete:
+		s.delete(w, r, ref)
+	default:
+		writeError(w, Error{StatusCode: http.StatusMethodNotAllowed, Code: "MethodNotAllowed", Message: r.Method + " is not supported"})
+	}
+}
+
+func (s *Server) takeFault(method, lowerID string) *fault {
+	for i, f := range s.faults {
+		if f.method == method && f.id == lowerID {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			return f
+		}
+	}
+	return nil
+}
+
+func (s *Server) get(w http.ResponseWriter, ref resourceRef) {
+	r, ok := s.resources[ref.lowerID]
+	if !ok {
+		writeError(w, notFound(ref))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.view(r))
+}
+
+func (s *Server) list(w http.ResponseWriter, ref resourceRef) {
+	values := []any{}
+	for _, id := range s.sortedIDs() {
+		r := s.resources[id]
+		if strings.EqualFold(r["type"].(string), resourceTypes[ref.lowerType]) && strings.HasPrefix(id, ref.lowerScope+"/") {
+			values = append(values, s.view(r))
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"value": values})
+}
+
+func (s *Server) put(w http.ResponseWriter, r *http.Request, ref resourceRef) {
+	if readOnlyTypes[ref.lowerType] {
+		writeError(w, Error{StatusCode: http.StatusMethodNotAllowed, Code: "MethodNotAllowed", Message: "resources of type " + ref.lowerType + " cannot be created directly"})
+		return
+	}
+	if err := s.checkParents(ref); err != nil {
+		writeError(w, *err)
+		return
+	}
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, Error{StatusCode: http.StatusBadRequest, Code: "InvalidRequestContent", Message: err.Error()})
+		return
+	}
+
+	_, exists := s.resources[ref.lowerID]
+	status, state := http.StatusCreated, "Creating"
+	if exists {
+		status, state = http.StatusOK, "Updating"
+	}
+	// The compute resource provider responds to PUT requests with 200 for both creates and updates.
+	if strings.HasPrefix(ref.lowerType, "microsoft.compute/") {
+		status = http.StatusOK
+	}
+
+	// Resource groups are also created synchronously, but they are deleted with a long-running operation.
+	if synchronousTypes[ref.lowerType] || ref.lowerType == "microsoft.resources/resourcegroups" {
+		resource := s.store(ref, body, "Succeeded")
+		s.afterCreateOrUpdate(ref, resource)
+		writeJSON(w, status, s.view(resource))
+		return
+	}
+
+	resource := s.store(ref, body, state)
+	w.Header().Set("Azure-AsyncOperation", s.newOperation(func() {
+		if current, ok := s.resources[ref.lowerID]; ok {
+			setProvisioningState(current, "Succeeded")
+			s.afterCreateOrUpdate(ref, current)
+		}
+	}))
+	writeJSON(w, status, s.view(resource))
+}
+
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, ref resourceRef) {
+	existing, ok := s.resources[ref.lowerID]
+	if !ok {
+		writeError(w, notFound(ref))
+		return
+	}
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, Error{StatusCode: http.StatusBadRequest, Code: "InvalidRequestContent", Message: err.Error()})
+		return
+	}
+	mergePatch(existing, body)
+	writeJSON(w, http.StatusOK, s.view(existing))
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, ref resourceRef) {
+	existing, ok := s.resources[ref.lowerID]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if synchronousTypes[ref.lowerType] {
+		s.deleteTree(ref.lowerID)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	setProvisioningState(existing, "Deleting")
+	opURL := s.newOperation(func() {
+		s.deleteTree(ref.lowerID)
+	})
+	w.Header().Set("Azure-AsyncOperation", opURL)
+	w.Header().Set("Location", opURL)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// serveTags serves the tags of the resource with the given ID, which Resource Manager exposes as an extension
+// resource of every resource.
+func (s *Server) serveTags(w http.ResponseWriter, r *http.Request, id string) {
+	ref, err := parseID(id)
+	if err != nil {
+		writeError(w, Error{StatusCode: http.StatusBadRequest, Code: "InvalidResourceId", Message: err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f := s.takeFault(r.Method, ref.lowerID+tagsPath); f != nil {
+		writeError(w, f.err)
+		return
+	}
+	resource, ok := s.resources[ref.lowerID]
+	if !ok {
+		writeError(w, notFound(ref))
+		return
+	}
+	tags, _ := resource["tags"].(map[string]any)
+	if tags == nil {
+		tags = map[string]any{}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPatch:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, Error{StatusCode: http.StatusBadRequest, Code: "InvalidRequestContent", Message: err.Error()})
+			return
+		}
+		props, _ := body["properties"].(map[string]any)
+		requested, _ := props["tags"].(map[string]any)
+		operation, _ := body["operation"].(string)
+		if r.Method == http.MethodPut {
+			operation = "Replace"
+		}
+		switch operation {
+		case "Merge":
+			for k, v := range requested {
+				tags[k] = v
+			}
+		case "Replace":
+			tags = requested
+		case "Delete":
+			for k := range requested {
+				delete(tags, k)
+			}
+		default:
+			writeError(w, Error{StatusCode: http.StatusBadRequest, Code: "InvalidTagsOperation", Message: fmt.Sprintf("tags operation %q is not supported", operation)})
+			return
+		}
+		resource["tags"] = tags
+	default:
+		writeError(w, Error{StatusCode: http.StatusMethodNotAllowed, Code: "MethodNotAllowed", Message: r.Method + " is not supported on tags"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":         ref.id + "/providers/Microsoft.Resources/tags/default",
+		"name":       "default",
+		"type":       "Microsoft.Resources/tags",
+		"properties": map[string]any{"tags": tags},
+	})
+}
+
+func (s *Server) pollOperation(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op, ok := s.operations[id]
+	if !ok {
+		writeError(w, Error{StatusCode: http.StatusNotFound, Code: "OperationNotFound", Message: "operation " + id + " not found"})
+		return
+	}
+	if op.pollsLeft > 0 {
+		op.pollsLeft--
+		writeJSON(w, http.StatusOK, map[string]any{"status": "InProgress"})
+		return
+	}
+	if op.complete != nil {
+		op.complete()
+		op.complete = nil
+	}
+	if op.failure != nil {
+		writeJSON(w, http.StatusOK, map[string]any{"status": "Failed", "error": op.failure})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"status": "Succeeded"})
+}
+
+// newOperation registers a long-running operation which calls complete once it is done, and returns its URL.
+// s.mu must be held.
+func (s *Server) newOperation(complete func()) string {
+	s.nextID++
+	id := fmt.Sprintf("op-%d", s.nextID)
+	s.operations[id] = &operation{pollsLeft: s.pollsUntilDone - 1, complete: complete}
+	return s.URL + operationsPath + id
+}
+
+// checkParents returns an error if the resource group or parent resource of ref does not exist. s.mu must be held.
+func (s *Server) checkParents(ref resourceRef) *Error {
+	if ref.lowerResourceGroupID != "" && ref.lowerResourceGroupID != ref.lowerID {
+		if _, ok := s.resources[ref.lowerResourceGroupID]; !ok {
+			return &Error{StatusCode: http.StatusNotFound, Code: "ResourceGroupNotFound", Message: fmt.Sprintf("Resource group '%s' could not be found.", ref.resourceGroup)}
+		}
+	}
+	if ref.lowerParentID != "" {
+		if _, ok := s.resources[ref.lowerParentID]; !ok {
+			return &Error{StatusCode: http.StatusNotFound, Code: "ParentResourceNotFound", Message: fmt.Sprintf("Can not perform requested operation on nested resource. Parent resource '%s' not found.", ref.parentName)}
+		}
+	}
+	return nil
+}
+
+// store creates or replaces the resource at ref with body. s.mu must be held.
+func (s *Server) store(ref resourceRef, body map[string]any, state string) map[string]any {
+	if body == nil {
+		body = map[string]any{}
+	}
+	body["id"] = ref.id
+	body["name"] = ref.name
+	body["type"] = resourceTypes[ref.lowerType]
+	if ref.lowerType == "microsoft.resources/resourcegroups" {
+		props, _ := body["properties"].(map[string]any)
+		if props == nil {
+			props = map[string]any{}
+			body["properties"] = props
+		}
+		props["provisioningState"] = state
+	} else {
+		setProvisioningState(body, state)
+	}
+	s.resources[ref.lowerID] = body
+	return body
+}
+
+// afterCreateOrUpdate sets the read-only properties Azure computes for some resource types. s.mu must be held.
+func (s *Server) afterCreateOrUpdate(ref resourceRef, resource map[string]any) {
+	props, _ := resource["properties"].(map[string]any)
+	switch ref.lowerType {
+	case "microsoft.network/virtualnetworks":
+		// Subnets defined inline are also created as child resources.
+		subnets, _ := props["subnets"].([]any)
+		for _, subnet := range subnets {
+			subnetMap, ok := subnet.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := subnetMap["name"].(string)
+			if name == "" {
+				continue
+			}
+			childRef, err := parseID(ref.id + "/subnets/" + name)
+			if err != nil {
+				continue
+			}
+			s.store(childRef, deepCopy(subnetMap), "Succeeded")
+		}
+		delete(props, "subnets")
+	case "microsoft.network/publicipaddresses":
+		if _, ok := props["ipAddress"]; !ok {
+			s.nextIP++
+			props["ipAddress"] = fmt.Sprintf("20.0.%d.%d", s.nextIP/256, s.nextIP%256)
+		}
+	case "microsoft.network/networkinterfaces":
+		configs, _ := props["ipConfigurations"].([]any)
+		for _, config := range configs {
+			configMap, ok := config.(map[string]any)
+			if !ok {
+				continue
+			}
+			configProps, _ := configMap["properties"].(map[string]any)
+			if configProps == nil {
+				configProps = map[string]any{}
+				configMap["properties"] = configProps
+			}
+			if _, ok := configProps["privateIPAddress"]; !ok {
+				s.nextIP++
+				configProps["privateIPAddress"] = fmt.Sprintf("10.0.%d.%d", s.nextIP/256, s.nextIP%256)
+			}
+		}
+	case "microsoft.compute/virtualmachines":
+		if _, ok := props["vmId"]; !ok {
+			s.nextID++
+			props["vmId"] = fmt.Sprintf("00000000-0000-0000-0000-%012d", s.nextID)
+		}
+	case "microsoft.compute/virtualmachinescalesets":
+		s.reconcileInstances(ref, resource)
+	}
+}
+
+// reconcileInstances creates or deletes scale set instances to match the capacity of a scale set. s.mu must be held.
+func (s *Server) reconcileInstances(ref resourceRef, vmss map[string]any) {
+	capacity := 0
+	if sku, ok := vmss["sku"].(map[string]any); ok {
+		if c, ok := sku["capacity"].(float64); ok {
+			capacity = int(c)
+		}
+	}
+	prefix := ref.lowerID + "/virtualmachines/"
+	existing := 0
+	for _, id := range s.sortedIDs() {
+		if !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		if existing >= capacity {
+			delete(s.resources, id)
+			continue
+		}
+		existing++
+	}
+	for i := 0; existing < capacity; i++ {
+		instanceID := fmt.Sprintf("%d", i)
+		childRef, err := parseID(ref.id + "/virtualMachines/" + instanceID)
+		if err != nil {
+			return
+		}
+		if _, ok := s.resources[childRef.lowerID]; ok {
+			continue
+		}
+		instance := s.store(childRef, map[string]any{
+			"instanceId": instanceID,
+			"location":   vmss["location"],
+			"properties": map[string]any{"latestModelApplied": true},
+		}, "Succeeded")
+		instance["name"] = ref.name + "_" + instanceID
+		existing++
+	}
+}
+
+// deleteTree deletes the resource with the given ID and all resources nested under it. s.mu must be held.
+func (s *Server) deleteTree(lowerID string) {
+	for id := range s.resources {
+		if id == lowerID || strings.HasPrefix(id, lowerID+"/") {
+			delete(s.resources, id)
+		}
+	}
+}
+
+// view returns the representation of a resource returned by the API. s.mu must be held.
+func (s *Server) view(resource map[string]any) map[string]any {
+	out := deepCopy(resource)
+	if strings.EqualFold(out["type"].(string), resourceTypes["microsoft.network/virtualnetworks"]) {
+		// Subnets are returned inline with their virtual network.
+		subnets := []any{}
+		prefix := strings.ToLower(out["id"].(string)) + "/subnets/"
+		for _, id := range s.sortedIDs() {
+			if strings.HasPrefix(id, prefix) {
+				subnets = append(subnets, deepCopy(s.resources[id]))
+			}
+		}
+		props, _ := out["properties"].(map[string]any)
+		if props == nil {
+			props = map[string]any{}
+			out["properties"] = props
+		}
+		props["subnets"] = subnets
+	}
+	return out
+}
+
+func (s *Server) sortedIDs() []string {
+	ids := make([]string, 0, len(s.resources))
+	for id := range s.resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func notFound(ref resourceRef) Error {
+	return Error{
+		StatusCode: http.StatusNotFound,
+		Code:       "ResourceNotFound",
+		Message:    fmt.Sprintf("The Resource '%s' under resource group '%s' was not found.", resourceTypes[ref.lowerType]+"/"+ref.name, ref.resourceGroup),
+	}
+}
+
+func setProvisioningState(resource map[string]any, state string) {
+	props, _ := resource["properties"].(map[string]any)
+	if props == nil {
+		props = map[string]any{}
+		resource["properties"] = props
+	}
+	props["provisioningState"] = state
+}
+
+func readBody(r *http.Request) (map[string]any, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]any{}
+	if len(data) == 0 {
+		return body, nil
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// mergePatch applies patch to target following JSON merge patch semantics.
+func mergePatch(target, patch map[string]any) {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		if patchMap, ok := v.(map[string]any); ok {
+			if targetMap, ok := target[k].(map[string]any); ok {
+				mergePatch(targetMap, patchMap)
+				continue
+			}
+		}
+		target[k] = v
+	}
+}
+
+func deepCopy(in map[string]any) map[string]any {
+	data, err := json.Marshal(in)
+	if err != nil {
+		panic(err)
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err Error) {
+	writeJSON(w, err.StatusCode, map[string]any{"error": err})
+}
+
+// authorizer is an azure.Authorizer with a static fake token.
+type authorizer struct {
+	subscriptionID string
+}
+
+func (a *authorizer) SubscriptionID() string   { return a.subscriptionID }
+func (a *authorizer) ClientID() string         { return "fake-client-id" }
+func (a *authorizer) ClientSecret() string     { return "fake-client-secret" }
+func (a *authorizer) CloudEnvironment() string { return azure.PublicCloudName }
+func (a *authorizer) TenantID() string         { return "fake-tenant-id" }
+func (a *authorizer) BaseURI() string          { return "" }
+func (a *authorizer) HashKey() string          { return "fakearm-" + a.subscriptionID }
+
+func (a *authorizer) Token() azcore.TokenCredential { return tokenCredential{} }
+
+// tokenCredential returns a fake token which never expires.
+type tokenCredential struct{}
+
+func (tokenCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "fake-token", ExpiresOn: time.Now().Add(24 * time.Hour)}, nil
+}
+
+// NewCredentialCache returns an azure.CredentialCache whose credentials all return a fake token, so controllers can
+// authenticate with any identity against the server without reaching Microsoft Entra ID.
+func NewCredentialCache() azure.CredentialCache {
+	return credentialCache{}
+}
+
+// credentialCache is an azure.CredentialCache of fake token credentials.
+type credentialCache struct{}
+
+func (credentialCache) GetOrStoreClientSecret(string, string, string, *azidentity.ClientSecretCredentialOptions) (azcore.TokenCredential, error) {
+	return tokenCredential{}, nil
+}
+
+func (credentialCache) GetOrStoreClientCert(string, string, []byte, []byte, *azidentity.ClientCertificateCredentialOptions) (azcore.TokenCredential, error) {
+	return tokenCredential{}, nil
+}
+
+func (credentialCache) GetOrStoreManagedIdentity(*azidentity.ManagedIdentityCredentialOptions) (azcore.TokenCredential, error) {
+	return tokenCredential{}, nil
+}
+
+func (credentialCache) GetOrStoreWorkloadIdentity(*azidentity.WorkloadIdentityCredentialOptions) (azcore.TokenCredential, error) {
+	return tokenCredential{}, nil
+}
+
+func (credentialCache) GetOrStoreUserAssignedManagedIdentityCredentials(context.Context, string, azcore.ClientOptions, *logr.Logger) (azcore.TokenCredential, error) {
+	return tokenCredential{}, nil
+}
@@ -0,0 +1,323 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakearm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+)
+
+const (
+	subscriptionID = "00000000-0000-0000-0000-000000000000"
+	resourceGroup  = "my-rg"
+	location       = "westus2"
+)
+
+var pollOpts = &runtime.PollUntilDoneOptions{Frequency: time.Millisecond}
+
+func newTestServer(t *testing.T, opts ...Option) (*Server, azure.Authorizer) {
+	t.Helper()
+	g := NewWithT(t)
+	srv := NewServer(opts...)
+	t.Cleanup(srv.Close)
+	auth := srv.Authorizer(subscriptionID)
+
+	clientOpts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	g.Expect(err).NotTo(HaveOccurred())
+	groups, err := armresources.NewResourceGroupsClient(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = groups.CreateOrUpdate(context.Background(), resourceGroup, armresources.ResourceGroup{Location: ptr.To(location)}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	return srv, auth
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	srv, auth := newTestServer(t, WithPollsUntilDone(3))
+
+	clientOpts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	g.Expect(err).NotTo(HaveOccurred())
+	network, err := armnetwork.NewClientFactory(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+	compute, err := armcompute.NewClientFactory(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	vnetPoller, err := network.NewVirtualNetworksClient().BeginCreateOrUpdate(ctx, resourceGroup, "my-vnet", armnetwork.VirtualNetwork{
+		Location: ptr.To(location),
+		Properties: &armnetwork.VirtualNetworkPropertiesFormat{
+			AddressSpace: &armnetwork.AddressSpace{AddressPrefixes: []*string{ptr.To("10.0.0.0/16")}},
+			Subnets: []*armnetwork.Subnet{{
+				Name:       ptr.To("control-plane"),
+				Properties: &armnetwork.SubnetPropertiesFormat{AddressPrefix: ptr.To("10.0.0.0/24")},
+			}},
+		},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	vnet, err := vnetPoller.PollUntilDone(ctx, pollOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*vnet.Properties.ProvisioningState).To(Equal(armnetwork.ProvisioningStateSucceeded))
+	g.Expect(vnet.Properties.Subnets).To(HaveLen(1))
+
+	subnetPoller, err := network.NewSubnetsClient().BeginCreateOrUpdate(ctx, resourceGroup, "my-vnet", "node", armnetwork.Subnet{
+		Properties: &armnetwork.SubnetPropertiesFormat{AddressPrefix: ptr.To("10.0.1.0/24")},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	subnet, err := subnetPoller.PollUntilDone(ctx, pollOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var subnets []*armnetwork.Subnet
+	pager := network.NewSubnetsClient().NewListPager(resourceGroup, "my-vnet", nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		subnets = append(subnets, page.Value...)
+	}
+	g.Expect(subnets).To(HaveLen(2))
+
+	nicPoller, err := network.NewInterfacesClient().BeginCreateOrUpdate(ctx, resourceGroup, "my-nic", armnetwork.Interface{
+		Location: ptr.To(location),
+		Properties: &armnetwork.InterfacePropertiesFormat{
+			IPConfigurations: []*armnetwork.InterfaceIPConfiguration{{
+				Name: ptr.To("pipConfig"),
+				Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+					Subnet: &armnetwork.Subnet{ID: subnet.ID},
+				},
+			}},
+		},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	nic, err := nicPoller.PollUntilDone(ctx, pollOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(nic.Properties.IPConfigurations[0].Properties.PrivateIPAddress).NotTo(BeNil())
+
+	vmPoller, err := compute.NewVirtualMachinesClient().BeginCreateOrUpdate(ctx, resourceGroup, "my-vm", armcompute.VirtualMachine{
+		Location: ptr.To(location),
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{VMSize: ptr.To(armcompute.VirtualMachineSizeTypesStandardD2SV3)},
+			NetworkProfile: &armcompute.NetworkProfile{
+				NetworkInterfaces: []*armcompute.NetworkInterfaceReference{{ID: nic.ID}},
+			},
+		},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	vm, err := vmPoller.PollUntilDone(ctx, pollOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*vm.Properties.ProvisioningState).To(Equal("Succeeded"))
+	g.Expect(vm.Properties.VMID).NotTo(BeNil())
+
+	vmssPoller, err := compute.NewVirtualMachineScaleSetsClient().BeginCreateOrUpdate(ctx, resourceGroup, "my-vmss", armcompute.VirtualMachineScaleSet{
+		Location: ptr.To(location),
+		SKU:      &armcompute.SKU{Name: ptr.To("Standard_D2s_v3"), Capacity: ptr.To[int64](2)},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = vmssPoller.PollUntilDone(ctx, pollOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var instances []*armcompute.VirtualMachineScaleSetVM
+	vmPager := compute.NewVirtualMachineScaleSetVMsClient().NewListPager(resourceGroup, "my-vmss", nil)
+	for vmPager.More() {
+		page, err := vmPager.NextPage(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		instances = append(instances, page.Value...)
+	}
+	g.Expect(instances).To(HaveLen(2))
+	g.Expect(*instances[0].InstanceID).To(Equal("0"))
+
+	groups, err := armresources.NewResourceGroupsClient(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+	deletePoller, err := groups.BeginDelete(ctx, resourceGroup, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = deletePoller.PollUntilDone(ctx, pollOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, found := srv.Resource(*vm.ID)
+	g.Expect(found).To(BeFalse())
+	_, err = network.NewVirtualNetworksClient().Get(ctx, resourceGroup, "my-vnet", nil)
+	g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+}
+
+func TestServiceClient(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	srv, auth := newTestServer(t)
+
+	client, err := publicips.NewClient(auth, 10*time.Second)
+	g.Expect(err).NotTo(HaveOccurred())
+	spec := &publicips.PublicIPSpec{Name: "my-pip", ResourceGroup: resourceGroup, ClusterName: "my-cluster", Location: location}
+	params, err := spec.Parameters(ctx, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	result, poller, err := client.CreateOrUpdateAsync(ctx, spec, "", params)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(poller).To(BeNil())
+	pip, ok := result.(armnetwork.PublicIPAddress)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(pip.Properties.IPAddress).NotTo(BeNil())
+
+	existing, err := client.Get(ctx, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*existing.(armnetwork.PublicIPAddress).Properties.IPAddress).To(Equal(*pip.Properties.IPAddress))
+
+	_, err = client.DeleteAsync(ctx, spec, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, found := srv.Resource(*pip.ID)
+	g.Expect(found).To(BeFalse())
+}
+
+func TestSynchronousResources(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	srv, auth := newTestServer(t)
+
+	clientOpts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	g.Expect(err).NotTo(HaveOccurred())
+	availabilitySets, err := armcompute.NewAvailabilitySetsClient(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	avset, err := availabilitySets.CreateOrUpdate(ctx, resourceGroup, "my-avset", armcompute.AvailabilitySet{
+		Location: ptr.To(location),
+		SKU:      &armcompute.SKU{Name: ptr.To(string(armcompute.AvailabilitySetSKUTypesAligned))},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, found := srv.Resource(*avset.ID)
+	g.Expect(found).To(BeTrue())
+
+	_, err = availabilitySets.Delete(ctx, resourceGroup, "my-avset", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, found = srv.Resource(*avset.ID)
+	g.Expect(found).To(BeFalse())
+}
+
+func TestResourceSKUs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	srv, auth := newTestServer(t)
+
+	id := "/subscriptions/" + subscriptionID + "/providers/Microsoft.Compute/skus/Standard_D2s_v3"
+	g.Expect(srv.SetResource(id, map[string]any{
+		"resourceType": "virtualMachines",
+		"locations":    []any{location},
+	})).To(Succeed())
+
+	clientOpts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	g.Expect(err).NotTo(HaveOccurred())
+	skus, err := armcompute.NewResourceSKUsClient(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var names []string
+	pager := skus.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		for _, sku := range page.Value {
+			names = append(names, *sku.Name)
+		}
+	}
+	g.Expect(names).To(ConsistOf("Standard_D2s_v3"))
+}
+
+func TestTags(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, auth := newTestServer(t)
+
+	clientOpts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	g.Expect(err).NotTo(HaveOccurred())
+	tags, err := armresources.NewTagsClient(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+	scope := "/subscriptions/" + subscriptionID + "/resourceGroups/" + resourceGroup
+
+	_, err = tags.UpdateAtScope(ctx, scope, armresources.TagsPatchResource{
+		Operation:  ptr.To(armresources.TagsPatchOperationMerge),
+		Properties: &armresources.Tags{Tags: map[string]*string{"a": ptr.To("1"), "b": ptr.To("2")}},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = tags.UpdateAtScope(ctx, scope, armresources.TagsPatchResource{
+		Operation:  ptr.To(armresources.TagsPatchOperationDelete),
+		Properties: &armresources.Tags{Tags: map[string]*string{"a": ptr.To("1")}},
+	}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	resp, err := tags.GetAtScope(ctx, scope, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Properties.Tags).To(Equal(map[string]*string{"b": ptr.To("2")}))
+
+	_, err = tags.GetAtScope(ctx, scope+"/providers/Microsoft.Network/virtualNetworks/missing", nil)
+	g.Expect(azure.ResourceNotFound(err)).To(BeTrue())
+}
+
+func TestInjectError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	srv, auth := newTestServer(t)
+
+	clientOpts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	g.Expect(err).NotTo(HaveOccurred())
+	disks, err := armcompute.NewDisksClient(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	id := "/subscriptions/" + subscriptionID + "/resourceGroups/" + resourceGroup + "/providers/Microsoft.Compute/disks/my-disk"
+	srv.InjectError(http.MethodPut, id, Error{StatusCode: http.StatusConflict, Code: "OperationNotAllowed", Message: "quota exceeded"})
+
+	disk := armcompute.Disk{Location: ptr.To(location)}
+	_, err = disks.BeginCreateOrUpdate(ctx, resourceGroup, "my-disk", disk, nil)
+	var respErr *azcore.ResponseError
+	g.Expect(err).To(BeAssignableToTypeOf(respErr))
+	g.Expect(err.(*azcore.ResponseError).ErrorCode).To(Equal("OperationNotAllowed"))
+
+	// The error is only returned once.
+	poller, err := disks.BeginCreateOrUpdate(ctx, resourceGroup, "my-disk", disk, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = poller.PollUntilDone(ctx, pollOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestMissingParents(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	auth := srv.Authorizer(subscriptionID)
+
+	clientOpts, err := azure.ARMClientOptionsForAuthorizer(auth)
+	g.Expect(err).NotTo(HaveOccurred())
+	network, err := armnetwork.NewClientFactory(subscriptionID, auth.Token(), clientOpts)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = network.NewSecurityGroupsClient().BeginCreateOrUpdate(ctx, resourceGroup, "my-nsg", armnetwork.SecurityGroup{Location: ptr.To(location)}, nil)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(*azcore.ResponseError).ErrorCode).To(Equal("ResourceGroupNotFound"))
+
+	g.Expect(srv.SetResource("/subscriptions/"+subscriptionID+"/resourceGroups/"+resourceGroup, map[string]any{"location": location})).To(Succeed())
+	_, err = network.NewSubnetsClient().BeginCreateOrUpdate(ctx, resourceGroup, "my-vnet", "my-subnet", armnetwork.Subnet{}, nil)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(*azcore.ResponseError).ErrorCode).To(Equal("ParentResourceNotFound"))
+}
@@ -21,17 +21,22 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"slices"
+	"strings"
 
 	valid "github.com/asaskevich/govalidator/v11"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8snet "k8s.io/utils/net"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	apiinternal "sigs.k8s.io/cluster-api-provider-azure/internal/api/v1beta1"
+	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 )
 
 const (
@@ -64,6 +69,11 @@ const (
 	privateEndpointRegex = `^[-\w\._]+$`
 	// resource ID Pattern.
 	resourceIDPattern = `(?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)`
+	// Names of the health probe and load-balancing rule built for the API server by the load balancer service.
+	apiServerLBProbeName = "HTTPSProbe"
+	apiServerLBRuleName  = "LBRuleHTTPS"
+	// defaultAPIServerPort is the port of the API server of a cluster which does not set one.
+	defaultAPIServerPort = 6443
 )
 
 var (
@@ -95,6 +105,8 @@ func validateAzureClusterSpec(c *infrav1.AzureCluster, old *infrav1.AzureCluster
 
 	allErrs = append(allErrs, validateNetworkSpec(c.Spec.ControlPlaneEnabled, c.Spec.NetworkSpec, oldNetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
 
+	allErrs = append(allErrs, validateNetworkSubscriptions(c.Spec.SubscriptionID, c.Namespace, c.Spec.NetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
+
 	var oldCloudProviderConfigOverrides *infrav1.CloudProviderConfigOverrides
 	if old != nil {
 		oldCloudProviderConfigOverrides = old.Spec.CloudProviderConfigOverrides
@@ -111,6 +123,19 @@ func validateAzureClusterSpec(c *infrav1.AzureCluster, old *infrav1.AzureCluster
 		allErrs = append(allErrs, err)
 	}
 
+	if err := validateIngressSpec(c.Spec.NetworkSpec.Ingress, field.NewPath("spec").Child("networkSpec").Child("ingress")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validateFirewallSpec(c.Spec.NetworkSpec.Firewall, c.Spec.NetworkSpec, field.NewPath("spec").Child("networkSpec").Child("firewall"))...)
+
+	allErrs = append(allErrs, validateLoadBalancerRules(c.Spec.NetworkSpec.APIServerLB, apiServerLBPorts(c),
+		field.NewPath("spec").Child("networkSpec").Child("apiServerLB"))...)
+	allErrs = append(allErrs, validateLoadBalancerRules(c.Spec.NetworkSpec.NodeOutboundLB, nil,
+		field.NewPath("spec").Child("networkSpec").Child("nodeOutboundLB"))...)
+
+	allErrs = append(allErrs, validateIPAMPoolRefs(c)...)
+
 	if err := validateIdentityRef(c.Spec.IdentityRef, field.NewPath("spec").Child("identityRef")); err != nil {
 		allErrs = append(allErrs, err)
 	}
@@ -142,9 +167,108 @@ func validateBastionSpec(bastionSpec infrav1.BastionSpec, fldPath *field.Path) *
 		return field.Invalid(fldPath.Child("sku"), bastionSpec.AzureBastion.Sku,
 			"sku must be Standard if tunneling is enabled")
 	}
+	if bastion := bastionSpec.AzureBastion; bastion != nil && bastion.Sku != infrav1.StandardBastionHostSku {
+		switch {
+		case bastion.ScaleUnits != nil:
+			return field.Invalid(fldPath.Child("sku"), bastion.Sku, "sku must be Standard if scale units are set")
+		case ptr.Deref(bastion.EnableIPConnect, false):
+			return field.Invalid(fldPath.Child("sku"), bastion.Sku, "sku must be Standard if IP connect is enabled")
+		case ptr.Deref(bastion.EnableShareableLink, false):
+			return field.Invalid(fldPath.Child("sku"), bastion.Sku, "sku must be Standard if shareable links are enabled")
+		case ptr.Deref(bastion.EnableFileCopy, false):
+			return field.Invalid(fldPath.Child("sku"), bastion.Sku, "sku must be Standard if file copy is enabled")
+		}
+	}
+	if bastionSpec.AzureBastion != nil && bastionSpec.AzureBastion.PublicIP.IsIPv6() {
+		return field.Forbidden(fldPath.Child("publicIP", "ipVersion"), "Azure Bastion only supports IPv4 public IPs")
+	}
+	return nil
+}
+
+// validateIngressSpec validates an IngressSpec.
+func validateIngressSpec(ingress *infrav1.IngressSpec, fldPath *field.Path) *field.Error {
+	if ingress != nil && ingress.WAFPolicyID != "" && ingress.SKU != infrav1.WAFV2ApplicationGatewaySKU {
+		return field.Invalid(fldPath.Child("sku"), ingress.SKU,
+			fmt.Sprintf("sku must be %s if a WAF policy is set", infrav1.WAFV2ApplicationGatewaySKU))
+	}
 	return nil
 }
 
+// validateFirewallSpec validates a FirewallSpec.
+func validateFirewallSpec(firewall *infrav1.FirewallSpec, networkSpec infrav1.NetworkSpec, fldPath *field.Path) field.ErrorList {
+	if firewall == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	// The egress of a cluster subnet goes through the firewall, which would also take the replies of the control
+	// plane nodes to the public API server load balancer and drop them.
+	if lb := networkSpec.APIServerLB; lb != nil && lb.Type == infrav1.Public {
+		for i, subnet := range networkSpec.Subnets {
+			if subnet.Role == infrav1.SubnetCluster {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "networkSpec", "subnets").Index(i).Child("role"),
+					"a firewall cannot be used with a cluster subnet and a public API server load balancer"))
+			}
+		}
+	}
+	// Azure requires the subnet of an Azure Firewall to be named AzureFirewallSubnet and to be at least a /26.
+	if firewall.Subnet.Name != apiinternal.DefaultAzureFirewallSubnetName {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "name"), firewall.Subnet.Name,
+			fmt.Sprintf("subnet name must be %s", apiinternal.DefaultAzureFirewallSubnetName)))
+	}
+	for i, cidr := range firewall.Subnet.CIDRBlocks {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr, "invalid CIDR format"))
+			continue
+		}
+		if ones, _ := subnet.Mask.Size(); ones > 26 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnet", "cidrBlocks").Index(i), cidr,
+				"subnet prefix must be /26 or larger"))
+		}
+	}
+	return allErrs
+}
+
+// validateIPAMPoolRefs validates the IPAM pools from which the CIDR blocks of the vnet and subnets of an AzureCluster
+// are allocated.
+func validateIPAMPoolRefs(c *infrav1.AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+	networkPath := field.NewPath("spec").Child("networkSpec")
+	allErrs = append(allErrs, validateIPAMPoolRef(c.Spec.NetworkSpec.Vnet.IPAMPoolRef, networkPath.Child("vnet").Child("ipamPoolRef"))...)
+	for i, subnet := range c.Spec.NetworkSpec.Subnets {
+		allErrs = append(allErrs, validateIPAMPoolRef(subnet.IPAMPoolRef, networkPath.Child("subnets").Index(i).Child("ipamPoolRef"))...)
+	}
+	if bastion := c.Spec.BastionSpec.AzureBastion; bastion != nil {
+		allErrs = append(allErrs, validateIPAMPoolRef(bastion.Subnet.IPAMPoolRef,
+			field.NewPath("spec").Child("bastionSpec").Child("azureBastion").Child("subnet").Child("ipamPoolRef"))...)
+	}
+	if ingress := c.Spec.NetworkSpec.Ingress; ingress != nil {
+		allErrs = append(allErrs, validateIPAMPoolRef(ingress.Subnet.IPAMPoolRef, networkPath.Child("ingress").Child("subnet").Child("ipamPoolRef"))...)
+	}
+	if firewall := c.Spec.NetworkSpec.Firewall; firewall != nil {
+		allErrs = append(allErrs, validateIPAMPoolRef(firewall.Subnet.IPAMPoolRef, networkPath.Child("firewall").Child("subnet").Child("ipamPoolRef"))...)
+	}
+	return allErrs
+}
+
+// validateIPAMPoolRef validates a reference to an IPAM pool.
+func validateIPAMPoolRef(poolRef *corev1.TypedLocalObjectReference, fldPath *field.Path) field.ErrorList {
+	if poolRef == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if ptr.Deref(poolRef.APIGroup, "") == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("apiGroup"), "apiGroup of the IPAM pool is required"))
+	}
+	if poolRef.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kind"), "kind of the IPAM pool is required"))
+	}
+	if poolRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name of the IPAM pool is required"))
+	}
+	return allErrs
+}
+
 // validateIdentityRef validates an IdentityRef.
 func validateIdentityRef(identityRef *corev1.ObjectReference, fldPath *field.Path) *field.Error {
 	if identityRef == nil {
@@ -175,6 +299,8 @@ func validateNetworkSpec(controlPlaneEnabled bool, networkSpec infrav1.NetworkSp
 		allErrs = append(allErrs, validateVnetPeerings(networkSpec.Vnet.Peerings, fldPath.Child("peerings"))...)
 	}
 
+	allErrs = append(allErrs, validateHubVnet(networkSpec.Vnet, fldPath.Child("vnet").Child("hub"))...)
+
 	var cidrBlocks []string
 	if controlPlaneEnabled {
 		controlPlaneSubnet, err := networkSpec.GetControlPlaneSubnet()
@@ -183,7 +309,24 @@ func validateNetworkSpec(controlPlaneEnabled bool, networkSpec infrav1.NetworkSp
 		}
 
 		cidrBlocks = controlPlaneSubnet.CIDRBlocks
-		allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
+		// The private IP of the API server LB is defaulted in the control plane subnet once its CIDR blocks are allocated
+		// from its IPAM pool, so the LB is validated after the allocation.
+		if !controlPlaneSubnet.IsIPAMAllocationPending() {
+			allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
+		}
+	}
+
+	if lb := networkSpec.ControlPlaneOutboundLB; lb != nil && (len(lb.AdditionalBackendPools) > 0 || len(lb.Probes) > 0 || len(lb.Rules) > 0) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneOutboundLB"),
+			"additional backend pools, probes and rules are only supported on the API server and node outbound load balancers"))
+	}
+	if lb := networkSpec.NodeOutboundLB; lb != nil && lb.PrivateLinkService != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeOutboundLB", "privateLinkService"),
+			"private link service is only supported on the API server load balancer"))
+	}
+	if lb := networkSpec.ControlPlaneOutboundLB; lb != nil && lb.PrivateLinkService != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneOutboundLB", "privateLinkService"),
+			"private link service is only supported on the API server load balancer"))
 	}
 
 	var needOutboundLB bool
@@ -193,6 +336,7 @@ func validateNetworkSpec(controlPlaneEnabled bool, networkSpec infrav1.NetworkSp
 			break
 		}
 	}
+	allErrs = append(allErrs, validateIPVersions(networkSpec, needOutboundLB, fldPath)...)
 	if needOutboundLB {
 		allErrs = append(allErrs, validateNodeOutboundLB(networkSpec.NodeOutboundLB, old.NodeOutboundLB, networkSpec.APIServerLB, fldPath.Child("nodeOutboundLB"))...)
 	}
@@ -205,6 +349,12 @@ func validateNetworkSpec(controlPlaneEnabled bool, networkSpec infrav1.NetworkSp
 	}
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, controlPlaneEnabled, lbType, fldPath.Child("privateDNSZoneName"))...)
 	allErrs = append(allErrs, validatePrivateDNSZoneResourceGroup(networkSpec.PrivateDNSZoneName, networkSpec.PrivateDNSZoneResourceGroup, fldPath.Child("privateDNSZoneResourceGroup"))...)
+	allErrs = append(allErrs, validatePrivateDNSZoneID(networkSpec.NetworkClassSpec, controlPlaneEnabled, lbType, fldPath.Child("privateDNSZoneID"))...)
+	allErrs = append(allErrs, validatePrivateDNSZoneIdentityRef(networkSpec.NetworkClassSpec, fldPath.Child("privateDNSZoneIdentityRef"))...)
+	if networkSpec.PrivateDNSZoneID != "" && ptr.Deref(networkSpec.PrivateDNSZone, infrav1.PrivateDNSZoneModeSystem) == infrav1.PrivateDNSZoneModeNone {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("privateDNSZoneID"), networkSpec.PrivateDNSZoneID,
+			"PrivateDNSZoneID cannot be used when PrivateDNSZone is None"))
+	}
 
 	if len(allErrs) == 0 {
 		return nil
@@ -259,7 +409,10 @@ func validateSubnets(controlPlaneEnabled bool, subnets infrav1.Subnets, vnet inf
 				allErrs = append(allErrs, err...)
 			}
 		}
-		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
+		// The CIDR blocks of the subnets are validated against the vnet once its CIDR blocks are allocated.
+		if !vnet.IsIPAMAllocationPending() {
+			allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
+		}
 
 		if len(subnet.ServiceEndpoints) > 0 {
 			allErrs = append(allErrs, validateServiceEndpoints(subnet.ServiceEndpoints, fldPath.Index(i).Child("serviceEndpoints"))...)
@@ -353,6 +506,93 @@ func validateVnetPeerings(peerings infrav1.VnetPeerings, fldPath *field.Path) fi
 	return allErrs
 }
 
+// validateHubVnet validates the hub virtual network of a virtual network.
+func validateHubVnet(vnet infrav1.VnetSpec, fldPath *field.Path) field.ErrorList {
+	hub := vnet.Hub
+	if hub == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if err := validateResourceGroup(hub.ResourceGroup, fldPath.Child("resourceGroup")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if hub.SubscriptionID == "" && hub.ResourceGroup == vnet.ResourceGroup && hub.Name == vnet.Name {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), hub.Name,
+			"hub virtual network cannot be the virtual network of the cluster"))
+	}
+	for _, peering := range vnet.Peerings {
+		if peering.ResourceGroup == hub.ResourceGroup && peering.RemoteVnetName == hub.Name {
+			allErrs = append(allErrs, field.Duplicate(fldPath, hub.ResourceGroup+"/"+hub.Name))
+		}
+	}
+	allErrs = append(allErrs, validateSubscriptionIdentityRef(hub.SubscriptionID, hub.IdentityRef, fldPath)...)
+	return allErrs
+}
+
+// validateNetworkSubscriptions validates the subscriptions and identities of the network resources of a cluster which
+// can be placed in other subscriptions than the cluster. All the resources in a subscription are managed with the same
+// identity, so they cannot reference different identities, and the resources in the subscription of the cluster are
+// managed with the identity of the cluster, so they cannot reference one.
+func validateNetworkSubscriptions(clusterSubscriptionID, namespace string, networkSpec infrav1.NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	vnetPath := fldPath.Child("vnet")
+	allErrs = append(allErrs, validateSubscriptionIdentityRef(networkSpec.Vnet.SubscriptionID, networkSpec.Vnet.IdentityRef, vnetPath)...)
+	for i, peering := range networkSpec.Vnet.Peerings {
+		allErrs = append(allErrs, validateSubscriptionIdentityRef(peering.SubscriptionID, peering.IdentityRef, vnetPath.Child("peerings").Index(i))...)
+	}
+
+	identities := make(map[string]string)
+	checkIdentity := func(subscriptionID string, identityRef *corev1.ObjectReference, identityPath *field.Path) {
+		if subscriptionID == "" || identityRef == nil {
+			return
+		}
+		if subscriptionID == clusterSubscriptionID {
+			allErrs = append(allErrs, field.Forbidden(identityPath,
+				fmt.Sprintf("subscription %s is the subscription of the cluster, which is managed with the identity of the cluster", subscriptionID)))
+			return
+		}
+		// Identities without a namespace are looked up in the namespace of the cluster.
+		identityNamespace := identityRef.Namespace
+		if identityNamespace == "" {
+			identityNamespace = namespace
+		}
+		identity := identityNamespace + "/" + identityRef.Name
+		if other, ok := identities[subscriptionID]; ok && other != identity {
+			allErrs = append(allErrs, field.Invalid(identityPath, identity,
+				fmt.Sprintf("subscription %s is already managed with identity %s", subscriptionID, other)))
+			return
+		}
+		identities[subscriptionID] = identity
+	}
+	checkIdentity(networkSpec.Vnet.SubscriptionID, networkSpec.Vnet.IdentityRef, vnetPath.Child("identityRef"))
+	for i, peering := range networkSpec.Vnet.Peerings {
+		checkIdentity(peering.SubscriptionID, peering.IdentityRef, vnetPath.Child("peerings").Index(i).Child("identityRef"))
+	}
+	if hub := networkSpec.Vnet.Hub; hub != nil {
+		checkIdentity(hub.SubscriptionID, hub.IdentityRef, vnetPath.Child("hub").Child("identityRef"))
+	}
+	if zoneID, err := azureutil.ParseResourceID(networkSpec.PrivateDNSZoneID); err == nil {
+		checkIdentity(zoneID.SubscriptionID, networkSpec.PrivateDNSZoneIdentityRef, fldPath.Child("privateDNSZoneIdentityRef"))
+	}
+	return allErrs
+}
+
+// validateSubscriptionIdentityRef validates the identity used to manage a network resource in another subscription.
+func validateSubscriptionIdentityRef(subscriptionID string, identityRef *corev1.ObjectReference, fldPath *field.Path) field.ErrorList {
+	if identityRef == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if subscriptionID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("subscriptionID"),
+			"subscriptionID is required when identityRef is set"))
+	}
+	if err := validateIdentityRef(identityRef, fldPath.Child("identityRef")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	return allErrs
+}
+
 // validateLoadBalancerName validates the Name of a Load Balancer.
 func validateLoadBalancerName(name string, fldPath *field.Path) *field.Error {
 	if success, _ := regexp.Match(loadBalancerRegex, []byte(name)); !success {
@@ -389,6 +629,14 @@ func validateSecurityRule(rule infrav1.SecurityRule, fldPath *field.Path) (allEr
 		allErrs = append(allErrs, field.Invalid(fldPath, rule.Source, "security rule cannot have both source and sources"))
 	}
 
+	if len(rule.SourceApplicationSecurityGroups) > 0 && (rule.Source != nil || rule.Sources != nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sourceApplicationSecurityGroups"), rule.SourceApplicationSecurityGroups, "security rule cannot have both source application security groups and source or sources"))
+	}
+
+	if len(rule.DestinationApplicationSecurityGroups) > 0 && rule.Destination != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("destinationApplicationSecurityGroups"), rule.DestinationApplicationSecurityGroups, "security rule cannot have both destination application security groups and destination"))
+	}
+
 	return allErrs
 }
 
@@ -495,6 +743,208 @@ func validateAPIServerLB(lb *infrav1.LoadBalancerSpec, old *infrav1.LoadBalancer
 			}
 		}
 	}
+
+	allErrs = append(allErrs, validatePrivateLinkService(lb, old, fldPath.Child("privateLinkService"))...)
+	return allErrs
+}
+
+// validatePrivateLinkService validates the private link service in front of the API server load balancer.
+func validatePrivateLinkService(lb *infrav1.LoadBalancerSpec, old *infrav1.LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	pls := lb.PrivateLinkService
+	var oldPLS *infrav1.PrivateLinkServiceSpec
+	if old != nil {
+		oldPLS = old.PrivateLinkService
+	}
+	if pls == nil {
+		if oldPLS != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "private link service should not be removed after AzureCluster creation."))
+		}
+		return allErrs
+	}
+
+	// A private link service can only be placed in front of an internal load balancer.
+	if lb.Type != infrav1.Internal && !feature.Gates.Enabled(feature.APIServerILB) {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"private link service requires an internal API server load balancer or the APIServerILB feature"))
+	}
+	if oldPLS != nil && oldPLS.Name != "" && oldPLS.Name != pls.Name {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "private link service name should not be modified after AzureCluster creation."))
+	}
+	if len(pls.AllowedSubscriptions) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("allowedSubscriptions"), "at least one subscription must be allowed"))
+	}
+	for i, subscription := range pls.AutoApprovedSubscriptions {
+		if !slices.Contains(pls.AllowedSubscriptions, subscription) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("autoApprovedSubscriptions").Index(i), subscription,
+				"auto-approved subscriptions must also be allowed"))
+		}
+	}
+	return allErrs
+}
+
+// apiServerLBPorts returns the frontend ports of the load-balancing rules CAPZ creates for the API server on the API
+// server load balancer: the port of the control plane endpoint, which defaults to the API server port 6443 of the
+// cluster until it is set, and the additional API server load balancer ports.
+func apiServerLBPorts(c *infrav1.AzureCluster) []int32 {
+	port := c.Spec.ControlPlaneEndpoint.Port
+	if port == 0 {
+		port = defaultAPIServerPort
+	}
+	ports := []int32{port}
+	for _, additionalPort := range c.Spec.NetworkSpec.AdditionalAPIServerLBPorts {
+		ports = append(ports, additionalPort.Port)
+	}
+	return ports
+}
+
+// validateLoadBalancerRules validates the additional backend pools, health probes and load-balancing rules of a load
+// balancer. apiServerPorts are the frontend ports of the API server rules of the load balancer, which its TCP rules
+// cannot use on the frontend IP of the API server.
+func validateLoadBalancerRules(lb *infrav1.LoadBalancerSpec, apiServerPorts []int32, fldPath *field.Path) field.ErrorList {
+	if lb == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+
+	pools := map[string]bool{lb.BackendPool.Name: true}
+	for i, pool := range lb.AdditionalBackendPools {
+		poolPath := fldPath.Child("additionalBackendPools").Index(i).Child("name")
+		switch {
+		case pool.Name == "":
+			allErrs = append(allErrs, field.Required(poolPath, "name of the backend pool is required"))
+		case pools[pool.Name]:
+			allErrs = append(allErrs, field.Duplicate(poolPath, pool.Name))
+		}
+		pools[pool.Name] = true
+	}
+
+	probes := make(map[string]bool, len(lb.Probes))
+	for i, probe := range lb.Probes {
+		probePath := fldPath.Child("probes").Index(i)
+		if probe.Name == apiServerLBProbeName {
+			allErrs = append(allErrs, field.Forbidden(probePath.Child("name"), fmt.Sprintf("%s is reserved for the API server probe", apiServerLBProbeName)))
+		}
+		switch probe.Protocol {
+		case infrav1.LoadBalancerProbeProtocolHTTP, infrav1.LoadBalancerProbeProtocolHTTPS:
+			if !strings.HasPrefix(probe.RequestPath, "/") {
+				allErrs = append(allErrs, field.Invalid(probePath.Child("requestPath"), probe.RequestPath,
+					"requestPath of HTTP and HTTPS probes must be an absolute path"))
+			}
+		default:
+			if probe.RequestPath != "" {
+				allErrs = append(allErrs, field.Forbidden(probePath.Child("requestPath"), "requestPath is only allowed for HTTP and HTTPS probes"))
+			}
+		}
+		probes[probe.Name] = true
+	}
+
+	frontendIPs := make(map[string]bool, len(lb.FrontendIPs))
+	for _, frontendIP := range lb.FrontendIPs {
+		frontendIPs[frontendIP.Name] = true
+	}
+	// The API server rules, like the rules without a frontend IP, use the first frontend IP of the load balancer.
+	apiServerFrontendIPName := ""
+	if len(lb.FrontendIPs) != 0 {
+		apiServerFrontendIPName = lb.FrontendIPs[0].Name
+	}
+	for i, rule := range lb.Rules {
+		rulePath := fldPath.Child("rules").Index(i)
+		if rule.Name == apiServerLBRuleName {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("name"), fmt.Sprintf("%s is reserved for the API server rule", apiServerLBRuleName)))
+		}
+		if rule.FrontendIPName != "" && !frontendIPs[rule.FrontendIPName] {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("frontendIPName"), rule.FrontendIPName))
+		}
+		if rule.BackendPoolName != "" && !pools[rule.BackendPoolName] {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("backendPoolName"), rule.BackendPoolName))
+		}
+		if rule.ProbeName != "" && !probes[rule.ProbeName] {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("probeName"), rule.ProbeName))
+		}
+		onAPIServerFrontendIP := rule.FrontendIPName == "" || rule.FrontendIPName == apiServerFrontendIPName
+		if onAPIServerFrontendIP && rule.Protocol != infrav1.LoadBalancerRuleProtocolUDP && slices.Contains(apiServerPorts, rule.FrontendPort) {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("frontendPort"),
+				fmt.Sprintf("port %d of the frontend IP of the API server is used by an API server rule", rule.FrontendPort)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIPVersions validates the IP versions of the virtual network, subnets and public IPs of a NetworkSpec against
+// the combinations supported by Azure: every network needs an IPv4 address space, IPv6 public IPs are only supported
+// for the outbound traffic of dual-stack nodes through the node outbound load balancer, and NAT gateways only support
+// IPv4. IPv6-only networks and IPv6 NAT gateway public IPs are rejected. IPv6-primary clusters cannot be expressed,
+// since the primary IP configuration of a network interface is always IPv4.
+func validateIPVersions(networkSpec infrav1.NetworkSpec, dualStackNodes bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if blocks := networkSpec.Vnet.CIDRBlocks; len(blocks) > 0 && !slices.ContainsFunc(blocks, k8snet.IsIPv4CIDRString) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("vnet", "cidrBlocks"),
+			"IPv6-only virtual networks are not supported by Azure, the virtual network needs an IPv4 CIDR block"))
+	}
+	for i, subnet := range networkSpec.Subnets {
+		if len(subnet.CIDRBlocks) > 0 && !slices.ContainsFunc(subnet.CIDRBlocks, k8snet.IsIPv4CIDRString) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("cidrBlocks"),
+				"IPv6-only subnets are not supported by Azure, the subnet needs an IPv4 CIDR block"))
+		}
+		if subnet.NatGateway.NatGatewayIP.IsIPv6() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("natGateway", "ip", "ipVersion"),
+				"NAT gateways only support IPv4 public IPs, the IPv6 outbound traffic of dual-stack nodes goes through the node outbound load balancer"))
+		}
+	}
+
+	lbs := []struct {
+		name string
+		lb   *infrav1.LoadBalancerSpec
+	}{
+		{name: "apiServerLB", lb: networkSpec.APIServerLB},
+		{name: "controlPlaneOutboundLB", lb: networkSpec.ControlPlaneOutboundLB},
+	}
+	for _, l := range lbs {
+		if l.lb == nil {
+			continue
+		}
+		if ptr.Deref(l.lb.IPv6FrontendIPsCount, 0) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(l.name, "ipv6FrontendIPsCount"),
+				"IPv6 frontend IPs are only supported on the node outbound load balancer"))
+		}
+		for i, frontendIP := range l.lb.FrontendIPs {
+			if frontendIP.PublicIP != nil && frontendIP.PublicIP.IsIPv6() {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child(l.name, "frontendIPs").Index(i).Child("publicIP", "ipVersion"),
+					"IPv6 public IPs are only supported on the node outbound load balancer"))
+			}
+		}
+	}
+	if networkSpec.Ingress != nil && networkSpec.Ingress.PublicIP.IsIPv6() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ingress", "publicIP", "ipVersion"),
+			"IPv6 public IPs are only supported on the node outbound load balancer"))
+	}
+	if networkSpec.Firewall != nil && networkSpec.Firewall.PublicIP.IsIPv6() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("firewall", "publicIP", "ipVersion"),
+			"IPv6 public IPs are only supported on the node outbound load balancer"))
+	}
+
+	if lb := networkSpec.NodeOutboundLB; lb != nil {
+		if count := ptr.Deref(lb.IPv6FrontendIPsCount, 0); count > MaxLoadBalancerOutboundIPs {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeOutboundLB", "ipv6FrontendIPsCount"), count,
+				fmt.Sprintf("Max front end ips allowed is %d", MaxLoadBalancerOutboundIPs)))
+		}
+		hasIPv6FrontendIPs := ptr.Deref(lb.IPv6FrontendIPsCount, 0) > 0 ||
+			slices.ContainsFunc(lb.FrontendIPs, func(ip infrav1.FrontendIP) bool { return ip.PublicIP != nil && ip.PublicIP.IsIPv6() })
+		if hasIPv6FrontendIPs {
+			if !dualStackNodes {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeOutboundLB", "ipv6FrontendIPsCount"),
+					"IPv6 frontend IPs require a dual-stack node subnet"))
+			}
+			if blocks := networkSpec.Vnet.CIDRBlocks; len(blocks) > 0 && !slices.ContainsFunc(blocks, k8snet.IsIPv6CIDRString) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("nodeOutboundLB", "ipv6FrontendIPsCount"),
+					"IPv6 frontend IPs require a dual-stack virtual network"))
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -588,6 +1038,51 @@ func validatePrivateDNSZoneName(privateDNSZoneName string, controlPlaneEnabled b
 	return allErrs
 }
 
+// validatePrivateDNSZoneID validates the PrivateDNSZoneID.
+// An existing private DNS Zone is referenced by its resource ID only, so its name and resource group cannot be set as well.
+func validatePrivateDNSZoneID(networkSpec infrav1.NetworkClassSpec, controlPlaneEnabled bool, apiserverLBType infrav1.LBType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if networkSpec.PrivateDNSZoneID == "" {
+		return allErrs
+	}
+	if controlPlaneEnabled && apiserverLBType != infrav1.Internal {
+		allErrs = append(allErrs, field.Invalid(fldPath, apiserverLBType,
+			"PrivateDNSZoneID is available only if APIServerLB.Type is Internal"))
+	}
+	if networkSpec.PrivateDNSZoneName != "" || networkSpec.PrivateDNSZoneResourceGroup != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"PrivateDNSZoneID cannot be used together with PrivateDNSZoneName or PrivateDNSZoneResourceGroup"))
+	}
+	parsed, err := azureutil.ParseResourceID(networkSpec.PrivateDNSZoneID)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, networkSpec.PrivateDNSZoneID, "must be a valid Azure resource ID"))
+	} else if !strings.EqualFold(parsed.ResourceType.String(), "Microsoft.Network/privateDnsZones") {
+		allErrs = append(allErrs, field.Invalid(fldPath, networkSpec.PrivateDNSZoneID, "must be a valid Azure Private DNS Zone resource ID"))
+	}
+
+	return allErrs
+}
+
+// validatePrivateDNSZoneIdentityRef validates the PrivateDNSZoneIdentityRef.
+// The identity can only be set for an existing private DNS zone referenced by PrivateDNSZoneID.
+func validatePrivateDNSZoneIdentityRef(networkSpec infrav1.NetworkClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if networkSpec.PrivateDNSZoneIdentityRef == nil {
+		return allErrs
+	}
+	if networkSpec.PrivateDNSZoneID == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"PrivateDNSZoneIdentityRef can only be used when PrivateDNSZoneID is provided"))
+	}
+	if err := validateIdentityRef(networkSpec.PrivateDNSZoneIdentityRef, fldPath); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	return allErrs
+}
+
 // validatePrivateDNSZoneResourceGroup validates the PrivateDNSZoneResourceGroup.
 // A private DNS Zone's resource group is valid as long as privateDNSZoneName is provided with the private dns resource group name.
 func validatePrivateDNSZoneResourceGroup(privateDNSZoneName string, privateDNSZoneResourceGroup string, fldPath *field.Path) field.ErrorList {
@@ -837,6 +1332,47 @@ func validatePrivateEndpointIPAddress(address string, cidrs []string, fldPath *f
 		fmt.Sprintf("Private Endpoint IP address needs to be in subnet range (%s)", cidrs))
 }
 
+// validateLoadBalancerRulesUpdate validates that the additional backend pools, health probes and load-balancing rules of
+// a load balancer are not removed. CAPZ only adds and updates them on the existing load balancer, as it cannot tell them
+// apart from the ones added by other controllers.
+func validateLoadBalancerRulesUpdate(oldLB, newLB *infrav1.LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	if oldLB == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	var newPools, newProbes, newRules []string
+	if newLB != nil {
+		for _, pool := range newLB.AdditionalBackendPools {
+			newPools = append(newPools, pool.Name)
+		}
+		for _, probe := range newLB.Probes {
+			newProbes = append(newProbes, probe.Name)
+		}
+		for _, rule := range newLB.Rules {
+			newRules = append(newRules, rule.Name)
+		}
+	}
+	for _, pool := range oldLB.AdditionalBackendPools {
+		if !slices.Contains(newPools, pool.Name) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalBackendPools"),
+				fmt.Sprintf("backend pool %s cannot be removed from the load balancer", pool.Name)))
+		}
+	}
+	for _, probe := range oldLB.Probes {
+		if !slices.Contains(newProbes, probe.Name) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("probes"),
+				fmt.Sprintf("health probe %s cannot be removed from the load balancer", probe.Name)))
+		}
+	}
+	for _, rule := range oldLB.Rules {
+		if !slices.Contains(newRules, rule.Name) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("rules"),
+				fmt.Sprintf("load-balancing rule %s cannot be removed from the load balancer", rule.Name)))
+		}
+	}
+	return allErrs
+}
+
 // validateAzureClusterSubnetUpdate validates a ClusterSpec.NetworkSpec.Subnets for immutability.
 func validateAzureClusterSubnetUpdate(c *infrav1.AzureCluster, old *infrav1.AzureCluster) field.ErrorList {
 	var allErrs field.ErrorList
@@ -877,6 +1413,12 @@ func validateAzureClusterSubnetUpdate(c *infrav1.AzureCluster, old *infrav1.Azur
 						c.Spec.NetworkSpec.Subnets[i].NatGateway.Name, "field is immutable"),
 				)
 			}
+			if !reflect.DeepEqual(subnet.IPAMPoolRef, oldSubnet.IPAMPoolRef) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "networkSpec", "subnets").Index(oldSubnetIndex[subnet.Name]).Child("IPAMPoolRef"),
+						c.Spec.NetworkSpec.Subnets[i].IPAMPoolRef, "field is immutable"),
+				)
+			}
 			if subnet.SecurityGroup.Name != oldSubnet.SecurityGroup.Name {
 				allErrs = append(allErrs,
 					field.Invalid(field.NewPath("spec", "networkSpec", "subnets").Index(oldSubnetIndex[subnet.Name]).Child("SecurityGroup").Child("Name"),
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/component-base/featuregate"
@@ -881,6 +882,39 @@ func TestValidateSecurityRule(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "security rule - valid application security groups",
+			validRule: infrav1.SecurityRule{
+				Name:                                 "allow_apiserver",
+				Description:                          "Allow K8s API Server",
+				Priority:                             4000,
+				SourceApplicationSecurityGroups:      []string{"my-cluster-node-asg"},
+				DestinationApplicationSecurityGroups: []string{"my-cluster-control-plane-asg"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - invalid source application security groups and source",
+			validRule: infrav1.SecurityRule{
+				Name:                            "allow_apiserver",
+				Description:                     "Allow K8s API Server",
+				Priority:                        4000,
+				Source:                          ptr.To("*"),
+				SourceApplicationSecurityGroups: []string{"my-cluster-node-asg"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid destination application security groups and destination",
+			validRule: infrav1.SecurityRule{
+				Name:                                 "allow_apiserver",
+				Description:                          "Allow K8s API Server",
+				Priority:                             4000,
+				Destination:                          ptr.To("*"),
+				DestinationApplicationSecurityGroups: []string{"my-cluster-control-plane-asg"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -1484,6 +1518,167 @@ func TestValidateAPIServerLB(t *testing.T) {
 		})
 	}
 }
+func TestValidatePrivateLinkService(t *testing.T) {
+	internalLB := func(pls *infrav1.PrivateLinkServiceSpec) *infrav1.LoadBalancerSpec {
+		return &infrav1.LoadBalancerSpec{
+			Name: "my-private-lb",
+			LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+				Type: infrav1.Internal,
+				SKU:  infrav1.SKUStandard,
+			},
+			PrivateLinkService: pls,
+		}
+	}
+	testcases := []struct {
+		name        string
+		featureGate featuregate.Feature
+		lb          *infrav1.LoadBalancerSpec
+		old         *infrav1.LoadBalancerSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "no private link service",
+			lb:      internalLB(nil),
+			wantErr: false,
+		},
+		{
+			name: "valid private link service",
+			lb: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name:                      "my-private-lb-pls",
+				AllowedSubscriptions:      []string{"123", "456"},
+				AutoApprovedSubscriptions: []string{"456"},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "private link service on a public LB",
+			lb: &infrav1.LoadBalancerSpec{
+				Name: "my-public-lb",
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+					Type: infrav1.Public,
+					SKU:  infrav1.SKUStandard,
+				},
+				PrivateLinkService: &infrav1.PrivateLinkServiceSpec{
+					Name:                 "my-public-lb-pls",
+					AllowedSubscriptions: []string{"123"},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "apiServerLB.privateLinkService",
+				BadValue: "",
+				Detail:   "private link service requires an internal API server load balancer or the APIServerILB feature",
+			},
+		},
+		{
+			name:        "private link service on a public LB with feature flag APIServerILB enabled",
+			featureGate: feature.APIServerILB,
+			lb: &infrav1.LoadBalancerSpec{
+				Name: "my-public-lb",
+				LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+					Type: infrav1.Public,
+					SKU:  infrav1.SKUStandard,
+				},
+				PrivateLinkService: &infrav1.PrivateLinkServiceSpec{
+					Name:                 "my-public-lb-pls",
+					AllowedSubscriptions: []string{"123"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "private link service without allowed subscriptions",
+			lb: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name: "my-private-lb-pls",
+			}),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueRequired",
+				Field:    "apiServerLB.privateLinkService.allowedSubscriptions",
+				BadValue: "",
+				Detail:   "at least one subscription must be allowed",
+			},
+		},
+		{
+			name: "auto-approved subscription which is not allowed",
+			lb: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name:                      "my-private-lb-pls",
+				AllowedSubscriptions:      []string{"123"},
+				AutoApprovedSubscriptions: []string{"456"},
+			}),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.privateLinkService.autoApprovedSubscriptions[0]",
+				BadValue: "456",
+				Detail:   "auto-approved subscriptions must also be allowed",
+			},
+		},
+		{
+			name: "private link service name changed",
+			old: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name:                 "my-private-lb-pls",
+				AllowedSubscriptions: []string{"123"},
+			}),
+			lb: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name:                 "my-other-pls",
+				AllowedSubscriptions: []string{"123"},
+			}),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "apiServerLB.privateLinkService.name",
+				BadValue: "",
+				Detail:   "private link service name should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "private link service removed",
+			old: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name:                 "my-private-lb-pls",
+				AllowedSubscriptions: []string{"123"},
+			}),
+			lb:      internalLB(nil),
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueForbidden",
+				Field:    "apiServerLB.privateLinkService",
+				BadValue: "",
+				Detail:   "private link service should not be removed after AzureCluster creation.",
+			},
+		},
+		{
+			name: "allowed subscriptions updated",
+			old: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name:                 "my-private-lb-pls",
+				AllowedSubscriptions: []string{"123"},
+			}),
+			lb: internalLB(&infrav1.PrivateLinkServiceSpec{
+				Name:                 "my-private-lb-pls",
+				AllowedSubscriptions: []string{"123", "456"},
+			}),
+			wantErr: false,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			if test.featureGate == feature.APIServerILB {
+				featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, test.featureGate, true)
+			}
+			err := validatePrivateLinkService(test.lb, test.old, field.NewPath("apiServerLB", "privateLinkService"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestPrivateDNSZoneName(t *testing.T) {
 	testcases := []struct {
 		name        string
@@ -1639,6 +1834,116 @@ func TestPrivateDNSZoneResourceGroup(t *testing.T) {
 	}
 }
 
+func TestPrivateDNSZoneID(t *testing.T) {
+	zoneID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.example.com"
+	testcases := []struct {
+		name        string
+		network     infrav1.NetworkSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "testEmptyPrivateDNSZoneID",
+			network: infrav1.NetworkSpec{
+				APIServerLB: apifixtures.CreateValidAPIServerInternalLB(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "testValidPrivateDNSZoneID",
+			network: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID: zoneID,
+				},
+				APIServerLB: apifixtures.CreateValidAPIServerInternalLB(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "testInvalidPrivateDNSZoneID",
+			network: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID: "not-a-resource-id",
+				},
+				APIServerLB: apifixtures.CreateValidAPIServerInternalLB(),
+			},
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZoneID",
+				BadValue: "not-a-resource-id",
+				Detail:   "must be a valid Azure resource ID",
+			},
+			wantErr: true,
+		},
+		{
+			name: "testPrivateDNSZoneIDOfOtherResourceType",
+			network: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/dns-rg/providers/Microsoft.Network/dnsZones/example.com",
+				},
+				APIServerLB: apifixtures.CreateValidAPIServerInternalLB(),
+			},
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZoneID",
+				BadValue: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/dns-rg/providers/Microsoft.Network/dnsZones/example.com",
+				Detail:   "must be a valid Azure Private DNS Zone resource ID",
+			},
+			wantErr: true,
+		},
+		{
+			name: "testPrivateDNSZoneIDWithPrivateDNSZoneName",
+			network: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID:   zoneID,
+					PrivateDNSZoneName: "good.dns.io",
+				},
+				APIServerLB: apifixtures.CreateValidAPIServerInternalLB(),
+			},
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "spec.networkSpec.privateDNSZoneID",
+				Detail: "PrivateDNSZoneID cannot be used together with PrivateDNSZoneName or PrivateDNSZoneResourceGroup",
+			},
+			wantErr: true,
+		},
+		{
+			name: "testPrivateDNSZoneIDWithPublicAPIServerLB",
+			network: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID: zoneID,
+				},
+				APIServerLB: &infrav1.LoadBalancerSpec{
+					Name: "my-lb",
+					LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+						Type: infrav1.Public,
+					},
+				},
+			},
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "spec.networkSpec.privateDNSZoneID",
+				BadValue: "Public",
+				Detail:   "PrivateDNSZoneID is available only if APIServerLB.Type is Internal",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			err := validatePrivateDNSZoneID(test.network.NetworkClassSpec, true, test.network.APIServerLB.Type, field.NewPath("spec", "networkSpec", "privateDNSZoneID"))
+			if test.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(test.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateNodeOutboundLB(t *testing.T) {
 	testcases := []struct {
 		name        string
@@ -2121,3 +2426,1044 @@ func TestClusterWithExtendedLocationInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBastionSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		bastion *infrav1.AzureBastion
+		wantErr bool
+	}{
+		{
+			name:    "no azure bastion",
+			bastion: nil,
+			wantErr: false,
+		},
+		{
+			name: "Basic with copy and paste disabled",
+			bastion: &infrav1.AzureBastion{
+				Sku:              infrav1.BasicBastionHostSku,
+				DisableCopyPaste: ptr.To(true),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Standard with all session settings",
+			bastion: &infrav1.AzureBastion{
+				Sku:                 infrav1.StandardBastionHostSku,
+				EnableTunneling:     true,
+				ScaleUnits:          ptr.To[int32](10),
+				EnableIPConnect:     ptr.To(true),
+				EnableShareableLink: ptr.To(true),
+				EnableFileCopy:      ptr.To(true),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Basic with tunneling",
+			bastion: &infrav1.AzureBastion{
+				Sku:             infrav1.BasicBastionHostSku,
+				EnableTunneling: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Basic with scale units",
+			bastion: &infrav1.AzureBastion{
+				Sku:        infrav1.BasicBastionHostSku,
+				ScaleUnits: ptr.To[int32](2),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Basic with IP connect",
+			bastion: &infrav1.AzureBastion{
+				Sku:             infrav1.BasicBastionHostSku,
+				EnableIPConnect: ptr.To(true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Basic with shareable links",
+			bastion: &infrav1.AzureBastion{
+				Sku:                 infrav1.BasicBastionHostSku,
+				EnableShareableLink: ptr.To(true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Basic with file copy",
+			bastion: &infrav1.AzureBastion{
+				Sku:            infrav1.BasicBastionHostSku,
+				EnableFileCopy: ptr.To(true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Basic with file copy disabled",
+			bastion: &infrav1.AzureBastion{
+				Sku:            infrav1.BasicBastionHostSku,
+				EnableFileCopy: ptr.To(false),
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			err := validateBastionSpec(infrav1.BastionSpec{AzureBastion: tc.bastion}, field.NewPath("spec", "bastionSpec"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidateIngressSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		ingress *infrav1.IngressSpec
+		wantErr bool
+	}{
+		{
+			name:    "no ingress",
+			ingress: nil,
+			wantErr: false,
+		},
+		{
+			name: "Standard_v2 without WAF policy",
+			ingress: &infrav1.IngressSpec{
+				SKU: infrav1.StandardV2ApplicationGatewaySKU,
+			},
+			wantErr: false,
+		},
+		{
+			name: "WAF_v2 with WAF policy",
+			ingress: &infrav1.IngressSpec{
+				SKU:         infrav1.WAFV2ApplicationGatewaySKU,
+				WAFPolicyID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/ApplicationGatewayWebApplicationFirewallPolicies/my-policy",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Standard_v2 with WAF policy",
+			ingress: &infrav1.IngressSpec{
+				SKU:         infrav1.StandardV2ApplicationGatewaySKU,
+				WAFPolicyID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/ApplicationGatewayWebApplicationFirewallPolicies/my-policy",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			err := validateIngressSpec(tc.ingress, field.NewPath("spec", "networkSpec", "ingress"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidateFirewallSpec(t *testing.T) {
+	clusterSubnetNetwork := func(lbType infrav1.LBType) infrav1.NetworkSpec {
+		networkSpec := apifixtures.CreateValidNetworkSpecWithClusterSubnet()
+		networkSpec.APIServerLB.Type = lbType
+		return networkSpec
+	}
+
+	tests := []struct {
+		name        string
+		firewall    *infrav1.FirewallSpec
+		networkSpec infrav1.NetworkSpec
+		wantErr     bool
+	}{
+		{
+			name:     "no firewall",
+			firewall: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "valid firewall subnet",
+			firewall: createValidFirewall(),
+			wantErr:  false,
+		},
+		{
+			name: "invalid firewall subnet name",
+			firewall: func() *infrav1.FirewallSpec {
+				firewall := createValidFirewall()
+				firewall.Subnet.Name = "my-firewall-subnet"
+				return firewall
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "firewall subnet smaller than /26",
+			firewall: func() *infrav1.FirewallSpec {
+				firewall := createValidFirewall()
+				firewall.Subnet.CIDRBlocks = []string{"10.255.253.0/27"}
+				return firewall
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid firewall subnet CIDR",
+			firewall: func() *infrav1.FirewallSpec {
+				firewall := createValidFirewall()
+				firewall.Subnet.CIDRBlocks = []string{"10.255.253.0"}
+				return firewall
+			}(),
+			wantErr: true,
+		},
+		{
+			name:        "firewall with a cluster subnet and an internal API server load balancer",
+			firewall:    createValidFirewall(),
+			networkSpec: clusterSubnetNetwork(infrav1.Internal),
+			wantErr:     false,
+		},
+		{
+			name:        "firewall with a cluster subnet and a public API server load balancer",
+			firewall:    createValidFirewall(),
+			networkSpec: clusterSubnetNetwork(infrav1.Public),
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			errs := validateFirewallSpec(tc.firewall, tc.networkSpec, field.NewPath("spec", "networkSpec", "firewall"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func createValidFirewall() *infrav1.FirewallSpec {
+	return &infrav1.FirewallSpec{
+		Name:       "my-cluster-azfw",
+		SKUTier:    infrav1.StandardAzureFirewallSKUTier,
+		PolicyName: "my-cluster-azfw-policy",
+		Subnet: infrav1.SubnetSpec{
+			SubnetClassSpec: infrav1.SubnetClassSpec{
+				Role:       infrav1.SubnetFirewall,
+				Name:       "AzureFirewallSubnet",
+				CIDRBlocks: []string{"10.255.253.0/26"},
+			},
+		},
+		PublicIP: infrav1.PublicIPSpec{
+			Name: "my-cluster-azfw-pip",
+		},
+	}
+}
+
+func TestValidateHubVnet(t *testing.T) {
+	tests := []struct {
+		name    string
+		vnet    infrav1.VnetSpec
+		wantErr bool
+	}{
+		{
+			name: "no hub",
+			vnet: infrav1.VnetSpec{
+				Name:          "my-vnet",
+				ResourceGroup: "my-rg",
+			},
+			wantErr: false,
+		},
+		{
+			name: "hub in another subscription with its own identity",
+			vnet: infrav1.VnetSpec{
+				Name:          "my-vnet",
+				ResourceGroup: "my-rg",
+				Hub: &infrav1.HubVnetSpec{
+					Name:           "hub-vnet",
+					ResourceGroup:  "hub-rg",
+					SubscriptionID: "456",
+					IdentityRef: &corev1.ObjectReference{
+						Kind: infrav1.AzureClusterIdentityKind,
+						Name: "hub-identity",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "hub is the virtual network of the cluster",
+			vnet: infrav1.VnetSpec{
+				Name:          "my-vnet",
+				ResourceGroup: "my-rg",
+				Hub: &infrav1.HubVnetSpec{
+					Name:          "my-vnet",
+					ResourceGroup: "my-rg",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hub is also a peering",
+			vnet: infrav1.VnetSpec{
+				Name:          "my-vnet",
+				ResourceGroup: "my-rg",
+				Peerings: infrav1.VnetPeerings{
+					{
+						VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+							ResourceGroup:  "hub-rg",
+							RemoteVnetName: "hub-vnet",
+						},
+					},
+				},
+				Hub: &infrav1.HubVnetSpec{
+					Name:          "hub-vnet",
+					ResourceGroup: "hub-rg",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hub identity is not an AzureClusterIdentity",
+			vnet: infrav1.VnetSpec{
+				Name:          "my-vnet",
+				ResourceGroup: "my-rg",
+				Hub: &infrav1.HubVnetSpec{
+					Name:          "hub-vnet",
+					ResourceGroup: "hub-rg",
+					IdentityRef: &corev1.ObjectReference{
+						Kind: "Secret",
+						Name: "hub-identity",
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			errs := validateHubVnet(tc.vnet, field.NewPath("spec", "networkSpec", "vnet", "hub"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNetworkSubscriptions(t *testing.T) {
+	zoneID := "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.example.com"
+	tests := []struct {
+		name    string
+		network infrav1.NetworkSpec
+		wantErr bool
+	}{
+		{
+			name: "virtual network in the subscription of the cluster",
+			network: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{Name: "my-vnet", ResourceGroup: "my-rg"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "virtual network and peering in other subscriptions with their own identities",
+			network: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:           "my-vnet",
+					ResourceGroup:  "my-rg",
+					SubscriptionID: "456",
+					IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity"},
+					Peerings: infrav1.VnetPeerings{
+						{
+							VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+								ResourceGroup:  "peer-rg",
+								RemoteVnetName: "peer-vnet",
+								SubscriptionID: "789",
+								IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "peer-identity"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "virtual network identity without subscription",
+			network: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:          "my-vnet",
+					ResourceGroup: "my-rg",
+					IdentityRef:   &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "peering identity is not an AzureClusterIdentity",
+			network: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:          "my-vnet",
+					ResourceGroup: "my-rg",
+					Peerings: infrav1.VnetPeerings{
+						{
+							VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+								ResourceGroup:  "peer-rg",
+								RemoteVnetName: "peer-vnet",
+								SubscriptionID: "789",
+								IdentityRef:    &corev1.ObjectReference{Kind: "Secret", Name: "peer-identity"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "virtual network and private DNS zone in the same subscription with different identities",
+			network: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID:          zoneID,
+					PrivateDNSZoneIdentityRef: &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "dns-identity"},
+				},
+				Vnet: infrav1.VnetSpec{
+					Name:           "my-vnet",
+					ResourceGroup:  "my-rg",
+					SubscriptionID: "456",
+					IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "virtual network in the subscription of the cluster with its own identity",
+			network: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:           "my-vnet",
+					ResourceGroup:  "my-rg",
+					SubscriptionID: "123",
+					IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "virtual network and hub in the same subscription with identities in different namespaces",
+			network: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:           "my-vnet",
+					ResourceGroup:  "my-rg",
+					SubscriptionID: "456",
+					IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity"},
+					Hub: &infrav1.HubVnetSpec{
+						Name:           "hub-vnet",
+						ResourceGroup:  "hub-rg",
+						SubscriptionID: "456",
+						IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity", Namespace: "other"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "virtual network and hub in the same subscription with the same identity",
+			network: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{
+					Name:           "my-vnet",
+					ResourceGroup:  "my-rg",
+					SubscriptionID: "456",
+					IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity"},
+					Hub: &infrav1.HubVnetSpec{
+						Name:           "hub-vnet",
+						ResourceGroup:  "hub-rg",
+						SubscriptionID: "456",
+						IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity", Namespace: "default"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "virtual network and private DNS zone in the same subscription with one identity",
+			network: infrav1.NetworkSpec{
+				NetworkClassSpec: infrav1.NetworkClassSpec{
+					PrivateDNSZoneID: zoneID,
+				},
+				Vnet: infrav1.VnetSpec{
+					Name:           "my-vnet",
+					ResourceGroup:  "my-rg",
+					SubscriptionID: "456",
+					IdentityRef:    &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "network-identity"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			errs := validateNetworkSubscriptions("123", "default", tc.network, field.NewPath("spec", "networkSpec"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePrivateDNSZoneIdentityRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		network infrav1.NetworkClassSpec
+		wantErr bool
+	}{
+		{
+			name:    "no identity",
+			network: infrav1.NetworkClassSpec{},
+			wantErr: false,
+		},
+		{
+			name: "identity for an existing private DNS zone",
+			network: infrav1.NetworkClassSpec{
+				PrivateDNSZoneID:          "/subscriptions/456/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.example.com",
+				PrivateDNSZoneIdentityRef: &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "dns-identity"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "identity without an existing private DNS zone",
+			network: infrav1.NetworkClassSpec{
+				PrivateDNSZoneIdentityRef: &corev1.ObjectReference{Kind: infrav1.AzureClusterIdentityKind, Name: "dns-identity"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			errs := validatePrivateDNSZoneIdentityRef(tc.network, field.NewPath("spec", "networkSpec", "privateDNSZoneIdentityRef"))
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateIPAMPoolRefs(t *testing.T) {
+	poolRef := &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     "subnet-pool",
+	}
+	tests := []struct {
+		name    string
+		cluster func() *infrav1.AzureCluster
+		wantErr bool
+	}{
+		{
+			name:    "no IPAM pools",
+			cluster: apifixtures.CreateValidCluster,
+			wantErr: false,
+		},
+		{
+			name: "subnet allocated from an IPAM pool",
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[0].IPAMPoolRef = poolRef
+				return cluster
+			},
+			wantErr: false,
+		},
+		{
+			name: "IPAM pool without an API group",
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.IPAMPoolRef = &corev1.TypedLocalObjectReference{Kind: "InClusterIPPool", Name: "vnet-pool"}
+				return cluster
+			},
+			wantErr: true,
+		},
+		{
+			name: "IPAM pool without a name",
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[1].IPAMPoolRef = &corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+					Kind:     "InClusterIPPool",
+				}
+				return cluster
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			errs := validateIPAMPoolRefs(tc.cluster())
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateLoadBalancerRulesUpdate(t *testing.T) {
+	oldLB := func() *infrav1.LoadBalancerSpec {
+		return &infrav1.LoadBalancerSpec{
+			Name:                   "my-lb",
+			AdditionalBackendPools: []infrav1.BackendPool{{Name: "my-extra-pool"}},
+			Probes:                 []infrav1.LoadBalancerProbe{{Name: "tcp-probe", Protocol: infrav1.LoadBalancerProbeProtocolTCP, Port: 9000}},
+			Rules:                  []infrav1.LoadBalancerRule{{Name: "tcp-rule", FrontendPort: 9000, ProbeName: "tcp-probe"}},
+		}
+	}
+	tests := []struct {
+		name        string
+		oldLB       *infrav1.LoadBalancerSpec
+		newLB       func() *infrav1.LoadBalancerSpec
+		expectedErr string
+	}{
+		{
+			name:  "no changes",
+			oldLB: oldLB(),
+			newLB: oldLB,
+		},
+		{
+			name:  "load balancer added",
+			oldLB: nil,
+			newLB: oldLB,
+		},
+		{
+			name:  "rule added",
+			oldLB: oldLB(),
+			newLB: func() *infrav1.LoadBalancerSpec {
+				lb := oldLB()
+				lb.Rules = append(lb.Rules, infrav1.LoadBalancerRule{Name: "other-rule", FrontendPort: 9001})
+				return lb
+			},
+		},
+		{
+			name:  "backend pool removed",
+			oldLB: oldLB(),
+			newLB: func() *infrav1.LoadBalancerSpec {
+				lb := oldLB()
+				lb.AdditionalBackendPools = nil
+				return lb
+			},
+			expectedErr: "lb.additionalBackendPools: Forbidden",
+		},
+		{
+			name:  "probe removed",
+			oldLB: oldLB(),
+			newLB: func() *infrav1.LoadBalancerSpec {
+				lb := oldLB()
+				lb.Probes = nil
+				return lb
+			},
+			expectedErr: "lb.probes: Forbidden",
+		},
+		{
+			name:  "rule renamed",
+			oldLB: oldLB(),
+			newLB: func() *infrav1.LoadBalancerSpec {
+				lb := oldLB()
+				lb.Rules[0].Name = "renamed-rule"
+				return lb
+			},
+			expectedErr: "lb.rules: Forbidden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			errs := validateLoadBalancerRulesUpdate(tc.oldLB, tc.newLB(), field.NewPath("lb"))
+			if tc.expectedErr != "" {
+				g.Expect(errs).To(HaveLen(1))
+				g.Expect(errs[0].Error()).To(ContainSubstring(tc.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNetworkSpecWithPendingIPAMAllocation(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := apifixtures.CreateValidCluster()
+	cluster.Spec.NetworkSpec.Vnet.IPAMPoolRef = &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     "vnet-pool",
+	}
+	cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = nil
+	for i := range cluster.Spec.NetworkSpec.Subnets {
+		cluster.Spec.NetworkSpec.Subnets[i].IPAMPoolRef = cluster.Spec.NetworkSpec.Vnet.IPAMPoolRef
+		cluster.Spec.NetworkSpec.Subnets[i].CIDRBlocks = nil
+	}
+	cluster.Spec.NetworkSpec.APIServerLB.Type = infrav1.Internal
+	cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs = nil
+
+	errs := validateNetworkSpec(cluster.Spec.ControlPlaneEnabled, cluster.Spec.NetworkSpec, infrav1.NetworkSpec{}, field.NewPath("spec").Child("networkSpec"))
+	g.Expect(errs).To(BeEmpty())
+}
+
+func TestValidateLoadBalancerRules(t *testing.T) {
+	newLB := func() *infrav1.LoadBalancerSpec {
+		return &infrav1.LoadBalancerSpec{
+			Name:        "my-lb",
+			BackendPool: infrav1.BackendPool{Name: "my-lb-backendPool"},
+			FrontendIPs: []infrav1.FrontendIP{{Name: "my-lb-frontEnd"}},
+			AdditionalBackendPools: []infrav1.BackendPool{
+				{Name: "my-extra-pool"},
+			},
+			Probes: []infrav1.LoadBalancerProbe{
+				{Name: "http-probe", Protocol: infrav1.LoadBalancerProbeProtocolHTTP, Port: 8080, RequestPath: "/healthz"},
+				{Name: "tcp-probe", Protocol: infrav1.LoadBalancerProbeProtocolTCP, Port: 9000},
+			},
+			Rules: []infrav1.LoadBalancerRule{
+				{Name: "http-rule", FrontendPort: 80, FrontendIPName: "my-lb-frontEnd", BackendPoolName: "my-extra-pool", ProbeName: "http-probe"},
+				{Name: "tcp-rule", FrontendPort: 9000, ProbeName: "tcp-probe"},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		lb          func() *infrav1.LoadBalancerSpec
+		expectedErr string
+	}{
+		{
+			name: "nil load balancer",
+			lb:   func() *infrav1.LoadBalancerSpec { return nil },
+		},
+		{
+			name: "valid probes, rules and backend pools",
+			lb:   newLB,
+		},
+		{
+			name: "backend pool without a name",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.AdditionalBackendPools = append(lb.AdditionalBackendPools, infrav1.BackendPool{})
+				return lb
+			},
+			expectedErr: "lb.additionalBackendPools[1].name: Required value",
+		},
+		{
+			name: "backend pool with the name of the default backend pool",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.AdditionalBackendPools[0].Name = "my-lb-backendPool"
+				lb.Rules[0].BackendPoolName = "my-lb-backendPool"
+				return lb
+			},
+			expectedErr: `lb.additionalBackendPools[0].name: Duplicate value: "my-lb-backendPool"`,
+		},
+		{
+			name: "probe with the name of the API server probe",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Probes[0].Name = "HTTPSProbe"
+				lb.Rules[0].ProbeName = "HTTPSProbe"
+				return lb
+			},
+			expectedErr: "lb.probes[0].name: Forbidden",
+		},
+		{
+			name: "HTTP probe without a request path",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Probes[0].RequestPath = ""
+				return lb
+			},
+			expectedErr: "lb.probes[0].requestPath: Invalid value",
+		},
+		{
+			name: "TCP probe with a request path",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Probes[1].RequestPath = "/healthz"
+				return lb
+			},
+			expectedErr: "lb.probes[1].requestPath: Forbidden",
+		},
+		{
+			name: "rule with the name of the API server rule",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Rules[1].Name = "LBRuleHTTPS"
+				return lb
+			},
+			expectedErr: "lb.rules[1].name: Forbidden",
+		},
+		{
+			name: "rule referencing an unknown frontend IP",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Rules[0].FrontendIPName = "unknown-frontEnd"
+				return lb
+			},
+			expectedErr: `lb.rules[0].frontendIPName: Not found: "unknown-frontEnd"`,
+		},
+		{
+			name: "rule referencing an unknown backend pool",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Rules[0].BackendPoolName = "unknown-pool"
+				return lb
+			},
+			expectedErr: `lb.rules[0].backendPoolName: Not found: "unknown-pool"`,
+		},
+		{
+			name: "rule referencing an unknown probe",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Rules[1].ProbeName = "unknown-probe"
+				return lb
+			},
+			expectedErr: `lb.rules[1].probeName: Not found: "unknown-probe"`,
+		},
+		{
+			name: "rule on the port of the API server",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Rules[1].FrontendPort = 6443
+				return lb
+			},
+			expectedErr: "lb.rules[1].frontendPort: Forbidden",
+		},
+		{
+			name: "rule on the port of the API server on the frontend IP of the API server",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Rules[0].FrontendPort = 6443
+				return lb
+			},
+			expectedErr: "lb.rules[0].frontendPort: Forbidden",
+		},
+		{
+			name: "UDP rule on the port of the API server",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.Rules[1].FrontendPort = 6443
+				lb.Rules[1].Protocol = infrav1.LoadBalancerRuleProtocolUDP
+				return lb
+			},
+		},
+		{
+			name: "rule on the port of the API server on another frontend IP",
+			lb: func() *infrav1.LoadBalancerSpec {
+				lb := newLB()
+				lb.FrontendIPs = append([]infrav1.FrontendIP{{Name: "my-lb-api-frontEnd"}}, lb.FrontendIPs...)
+				lb.Rules[0].FrontendPort = 6443
+				return lb
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			errs := validateLoadBalancerRules(tc.lb(), []int32{6443}, field.NewPath("lb"))
+			if tc.expectedErr != "" {
+				g.Expect(errs).To(HaveLen(1))
+				g.Expect(errs[0].Error()).To(ContainSubstring(tc.expectedErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNetworkSpecWithControlPlaneOutboundLBRules(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := apifixtures.CreateValidCluster()
+	cluster.Spec.NetworkSpec.ControlPlaneOutboundLB = &infrav1.LoadBalancerSpec{
+		Name:  "my-cp-outbound-lb",
+		Rules: []infrav1.LoadBalancerRule{{Name: "my-rule", FrontendPort: 443}},
+	}
+
+	errs := validateNetworkSpec(cluster.Spec.ControlPlaneEnabled, cluster.Spec.NetworkSpec, infrav1.NetworkSpec{}, field.NewPath("spec").Child("networkSpec"))
+	g.Expect(errs).To(ContainElement(And(
+		HaveField("Type", field.ErrorTypeForbidden),
+		HaveField("Field", "spec.networkSpec.controlPlaneOutboundLB"),
+		HaveField("Detail", ContainSubstring("additional backend pools, probes and rules")),
+	)))
+}
+
+func TestValidateIPVersions(t *testing.T) {
+	dualStackNetworkSpec := func() infrav1.NetworkSpec {
+		networkSpec := apifixtures.CreateValidNetworkSpec()
+		networkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"}
+		networkSpec.Subnets[0].CIDRBlocks = []string{"10.0.0.0/16", "2001:1234:5678:9abc::/64"}
+		networkSpec.Subnets[1].CIDRBlocks = []string{"10.1.0.0/16", "2001:1234:5678:9abd::/64"}
+		networkSpec.NodeOutboundLB.FrontendIPs = []infrav1.FrontendIP{
+			{Name: "frontEnd", PublicIP: &infrav1.PublicIPSpec{Name: "pip-node-outbound"}},
+			{Name: "frontEnd-ipv6", PublicIP: &infrav1.PublicIPSpec{Name: "pip-node-outbound-ipv6", IPVersion: infrav1.IPv6}},
+		}
+		networkSpec.NodeOutboundLB.IPv6FrontendIPsCount = ptr.To[int32](1)
+		return networkSpec
+	}
+	tests := []struct {
+		name          string
+		networkSpec   func() infrav1.NetworkSpec
+		expectedField string
+	}{
+		{
+			name:        "IPv4 cluster",
+			networkSpec: apifixtures.CreateValidNetworkSpec,
+		},
+		{
+			name:        "dual-stack cluster with IPv6 outbound",
+			networkSpec: dualStackNetworkSpec,
+		},
+		{
+			name: "IPv6-only virtual network",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.Vnet.CIDRBlocks = []string{"2001:1234:5678:9a00::/56"}
+				return networkSpec
+			},
+			expectedField: "networkSpec.vnet.cidrBlocks",
+		},
+		{
+			name: "IPv6-only subnet",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.Subnets[1].CIDRBlocks = []string{"2001:1234:5678:9abd::/64"}
+				return networkSpec
+			},
+			expectedField: "networkSpec.subnets[1].cidrBlocks",
+		},
+		{
+			name: "NAT gateway with an IPv6 public IP",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.Subnets[1].NatGateway = infrav1.NatGateway{
+					NatGatewayIP:        infrav1.PublicIPSpec{Name: "pip-natgw", IPVersion: infrav1.IPv6},
+					NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: "natgw"},
+				}
+				return networkSpec
+			},
+			expectedField: "networkSpec.subnets[1].natGateway.ip.ipVersion",
+		},
+		{
+			name: "API server LB with an IPv6 public IP",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.APIServerLB.FrontendIPs[0].PublicIP.IPVersion = infrav1.IPv6
+				return networkSpec
+			},
+			expectedField: "networkSpec.apiServerLB.frontendIPs[0].publicIP.ipVersion",
+		},
+		{
+			name: "API server LB with IPv6 frontend IPs",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.APIServerLB.IPv6FrontendIPsCount = ptr.To[int32](1)
+				return networkSpec
+			},
+			expectedField: "networkSpec.apiServerLB.ipv6FrontendIPsCount",
+		},
+		{
+			name: "IPv6 frontend IPs without dual-stack node subnets",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.Subnets[1].CIDRBlocks = []string{"10.1.0.0/16"}
+				return networkSpec
+			},
+			expectedField: "networkSpec.nodeOutboundLB.ipv6FrontendIPsCount",
+		},
+		{
+			name: "too many IPv6 frontend IPs",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.NodeOutboundLB.IPv6FrontendIPsCount = ptr.To[int32](17)
+				return networkSpec
+			},
+			expectedField: "networkSpec.nodeOutboundLB.ipv6FrontendIPsCount",
+		},
+		{
+			name: "IPv6 frontend IPs without a dual-stack virtual network",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/8"}
+				return networkSpec
+			},
+			expectedField: "networkSpec.nodeOutboundLB.ipv6FrontendIPsCount",
+		},
+		{
+			name: "IPv6 frontend IPs count without dual-stack node subnets",
+			networkSpec: func() infrav1.NetworkSpec {
+				networkSpec := dualStackNetworkSpec()
+				networkSpec.Subnets[1].CIDRBlocks = []string{"10.1.0.0/16"}
+				networkSpec.NodeOutboundLB.FrontendIPs = networkSpec.NodeOutboundLB.FrontendIPs[:1]
+				return networkSpec
+			},
+			expectedField: "networkSpec.nodeOutboundLB.ipv6FrontendIPsCount",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+			networkSpec := tc.networkSpec()
+			var dualStackNodes bool
+			for _, subnet := range networkSpec.Subnets {
+				if subnet.Role == infrav1.SubnetNode && subnet.IsIPv6Enabled() {
+					dualStackNodes = true
+				}
+			}
+			errs := validateIPVersions(networkSpec, dualStackNodes, field.NewPath("networkSpec"))
+			if tc.expectedField != "" {
+				g.Expect(errs).To(HaveLen(1))
+				g.Expect(errs[0].Field).To(Equal(tc.expectedField))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateNetworkSpecWithNodeOutboundLBPrivateLinkService(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := apifixtures.CreateValidCluster()
+	cluster.Spec.NetworkSpec.NodeOutboundLB = &infrav1.LoadBalancerSpec{
+		Name:               "my-node-outbound-lb",
+		PrivateLinkService: &infrav1.PrivateLinkServiceSpec{Name: "my-pls", AllowedSubscriptions: []string{"123"}},
+	}
+
+	errs := validateNetworkSpec(cluster.Spec.ControlPlaneEnabled, cluster.Spec.NetworkSpec, infrav1.NetworkSpec{}, field.NewPath("spec").Child("networkSpec"))
+	g.Expect(errs).To(ContainElement(And(
+		HaveField("Type", field.ErrorTypeForbidden),
+		HaveField("Field", "spec.networkSpec.nodeOutboundLB.privateLinkService"),
+		HaveField("Detail", ContainSubstring("only supported on the API server load balancer")),
+	)))
+}
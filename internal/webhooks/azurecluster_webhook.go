@@ -19,19 +19,24 @@ package webhooks
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	apiinternal "sigs.k8s.io/cluster-api-provider-azure/internal/api/v1beta1"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
 )
 
 // SetupWebhookWithManager sets up and registers the webhook with the manager.
 func (w *AzureClusterWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w.client = mgr.GetClient()
+
 	return ctrl.NewWebhookManagedBy(mgr, &infrav1.AzureCluster{}).
 		WithValidator(w).
 		WithDefaulter(w).
@@ -42,7 +47,9 @@ func (w *AzureClusterWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azurecluster,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azureclusters,versions=v1beta1,name=default.azurecluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 // AzureClusterWebhook implements validating and mutating webhook for AzureCluster.
-type AzureClusterWebhook struct{}
+type AzureClusterWebhook struct {
+	client client.Client
+}
 
 var _ admission.Validator[*infrav1.AzureCluster] = &AzureClusterWebhook{}
 var _ admission.Defaulter[*infrav1.AzureCluster] = &AzureClusterWebhook{}
@@ -54,14 +61,21 @@ func (*AzureClusterWebhook) Default(_ context.Context, c *infrav1.AzureCluster)
 }
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (*AzureClusterWebhook) ValidateCreate(_ context.Context, c *infrav1.AzureCluster) (admission.Warnings, error) {
-	return validateAzureCluster(c, nil)
+func (w *AzureClusterWebhook) ValidateCreate(ctx context.Context, c *infrav1.AzureCluster) (admission.Warnings, error) {
+	return w.validate(ctx, c, nil)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (*AzureClusterWebhook) ValidateUpdate(_ context.Context, old, c *infrav1.AzureCluster) (admission.Warnings, error) {
+func (w *AzureClusterWebhook) ValidateUpdate(ctx context.Context, old, c *infrav1.AzureCluster) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
+	// The update which completes the adoption of an AzureCluster replaces its network and control plane endpoint by
+	// the adopted resources, so the fields it rewrites are compared to themselves while all the others are validated.
+	// Only the update setting the annotation to "adopted", as the adoption controller does, completes the adoption.
+	if old.GetAnnotations()[azure.AdoptAnnotation] == "true" && c.GetAnnotations()[azure.AdoptAnnotation] == "adopted" {
+		old = withAdoptedFields(old, c)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "resourceGroup"),
 		old.Spec.ResourceGroup,
@@ -128,6 +142,13 @@ func (*AzureClusterWebhook) ValidateUpdate(_ context.Context, old, c *infrav1.Az
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "networkSpec", "privateDNSZoneID"),
+		old.Spec.NetworkSpec.PrivateDNSZoneID,
+		c.Spec.NetworkSpec.PrivateDNSZoneID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "networkSpec", "privateDNSZone"),
 		old.Spec.NetworkSpec.PrivateDNSZone,
@@ -135,14 +156,129 @@ func (*AzureClusterWebhook) ValidateUpdate(_ context.Context, old, c *infrav1.Az
 		allErrs = append(allErrs, err)
 	}
 
-	// Allow enabling azure bastion but avoid disabling it.
-	if old.Spec.BastionSpec.AzureBastion != nil && !reflect.DeepEqual(old.Spec.BastionSpec.AzureBastion, c.Spec.BastionSpec.AzureBastion) {
+	// Allow enabling azure bastion and updating its session settings but avoid disabling or replacing it.
+	if old.Spec.BastionSpec.AzureBastion != nil && !reflect.DeepEqual(withoutBastionSessionSettings(old.Spec.BastionSpec.AzureBastion), withoutBastionSessionSettings(c.Spec.BastionSpec.AzureBastion)) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "bastionSpec", "azureBastion"),
 				c.Spec.BastionSpec.AzureBastion, "azure bastion cannot be removed from a cluster"),
 		)
 	}
 
+	// Allow adding ingress but avoid removing it or replacing its application gateway, subnet or public IP.
+	if old.Spec.NetworkSpec.Ingress != nil {
+		if c.Spec.NetworkSpec.Ingress == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "ingress"),
+					c.Spec.NetworkSpec.Ingress, "ingress cannot be removed from a cluster"),
+			)
+		} else {
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "ingress", "name"),
+				old.Spec.NetworkSpec.Ingress.Name,
+				c.Spec.NetworkSpec.Ingress.Name); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "ingress", "subnet"),
+				old.Spec.NetworkSpec.Ingress.Subnet,
+				c.Spec.NetworkSpec.Ingress.Subnet); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "ingress", "publicIP"),
+				old.Spec.NetworkSpec.Ingress.PublicIP,
+				c.Spec.NetworkSpec.Ingress.PublicIP); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "networkSpec", "vnet", "ipamPoolRef"),
+		old.Spec.NetworkSpec.Vnet.IPAMPoolRef,
+		c.Spec.NetworkSpec.Vnet.IPAMPoolRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("spec", "networkSpec", "vnet", "subscriptionID"),
+		old.Spec.NetworkSpec.Vnet.SubscriptionID,
+		c.Spec.NetworkSpec.Vnet.SubscriptionID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	// Allow adding a hub virtual network but avoid removing it or replacing it by another one.
+	if old.Spec.NetworkSpec.Vnet.Hub != nil {
+		if c.Spec.NetworkSpec.Vnet.Hub == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "vnet", "hub"),
+					c.Spec.NetworkSpec.Vnet.Hub, "hub virtual network cannot be removed from a cluster"),
+			)
+		} else {
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "vnet", "hub", "name"),
+				old.Spec.NetworkSpec.Vnet.Hub.Name,
+				c.Spec.NetworkSpec.Vnet.Hub.Name); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "vnet", "hub", "resourceGroup"),
+				old.Spec.NetworkSpec.Vnet.Hub.ResourceGroup,
+				c.Spec.NetworkSpec.Vnet.Hub.ResourceGroup); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "vnet", "hub", "subscriptionID"),
+				old.Spec.NetworkSpec.Vnet.Hub.SubscriptionID,
+				c.Spec.NetworkSpec.Vnet.Hub.SubscriptionID); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
+	// Allow adding a firewall but avoid removing it or replacing its Azure Firewall, policy, subnet or public IP.
+	if old.Spec.NetworkSpec.Firewall != nil {
+		if c.Spec.NetworkSpec.Firewall == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "networkSpec", "firewall"),
+					c.Spec.NetworkSpec.Firewall, "firewall cannot be removed from a cluster"),
+			)
+		} else {
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "firewall", "name"),
+				old.Spec.NetworkSpec.Firewall.Name,
+				c.Spec.NetworkSpec.Firewall.Name); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "firewall", "policyName"),
+				old.Spec.NetworkSpec.Firewall.PolicyName,
+				c.Spec.NetworkSpec.Firewall.PolicyName); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "firewall", "subnet"),
+				old.Spec.NetworkSpec.Firewall.Subnet,
+				c.Spec.NetworkSpec.Firewall.Subnet); err != nil {
+				allErrs = append(allErrs, err)
+			}
+			if err := webhookutils.ValidateImmutable(
+				field.NewPath("spec", "networkSpec", "firewall", "publicIP"),
+				old.Spec.NetworkSpec.Firewall.PublicIP,
+				c.Spec.NetworkSpec.Firewall.PublicIP); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
+	// Allow enabling application security groups but avoid disabling them.
+	if old.Spec.NetworkSpec.ApplicationSecurityGroupsEnabled && !c.Spec.NetworkSpec.ApplicationSecurityGroupsEnabled {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "networkSpec", "applicationSecurityGroupsEnabled"),
+				c.Spec.NetworkSpec.ApplicationSecurityGroupsEnabled, "application security groups cannot be disabled once enabled"),
+		)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("spec", "networkSpec", "controlPlaneOutboundLB"),
 		old.Spec.NetworkSpec.ControlPlaneOutboundLB,
@@ -187,16 +323,66 @@ func (*AzureClusterWebhook) ValidateUpdate(_ context.Context, old, c *infrav1.Az
 		}
 	}
 
+	// Allow adding backend pools, health probes and load-balancing rules to the load balancers but avoid removing them,
+	// as they are not removed from the existing load balancers.
+	allErrs = append(allErrs, validateLoadBalancerRulesUpdate(old.Spec.NetworkSpec.APIServerLB, c.Spec.NetworkSpec.APIServerLB,
+		field.NewPath("spec", "networkSpec", "apiServerLB"))...)
+	allErrs = append(allErrs, validateLoadBalancerRulesUpdate(old.Spec.NetworkSpec.NodeOutboundLB, c.Spec.NetworkSpec.NodeOutboundLB,
+		field.NewPath("spec", "networkSpec", "nodeOutboundLB"))...)
+
 	allErrs = append(allErrs, validateAzureClusterSubnetUpdate(c, old)...)
 
 	if len(allErrs) == 0 {
-		return validateAzureCluster(c, old)
+		return w.validate(ctx, c, old)
 	}
 
 	return nil, apierrors.NewInvalid(infrav1.GroupVersion.WithKind(infrav1.AzureClusterKind).GroupKind(), c.Name, allErrs)
 }
 
+// withAdoptedFields returns a copy of old with the fields which adoption rewrites taken from adopted: the virtual
+// network name, ID, resource group and CIDR blocks, the subnets, the API server load balancer and the control plane
+// endpoint.
+func withAdoptedFields(old, adopted *infrav1.AzureCluster) *infrav1.AzureCluster {
+	old = old.DeepCopy()
+	vnet, adoptedVnet := &old.Spec.NetworkSpec.Vnet, adopted.Spec.NetworkSpec.Vnet
+	vnet.Name = adoptedVnet.Name
+	vnet.ID = adoptedVnet.ID
+	vnet.ResourceGroup = adoptedVnet.ResourceGroup
+	vnet.CIDRBlocks = slices.Clone(adoptedVnet.CIDRBlocks)
+	old.Spec.NetworkSpec.Subnets = adopted.Spec.NetworkSpec.Subnets.DeepCopy()
+	old.Spec.NetworkSpec.APIServerLB = adopted.Spec.NetworkSpec.APIServerLB.DeepCopy()
+	old.Spec.ControlPlaneEndpoint = adopted.Spec.ControlPlaneEndpoint
+	return old
+}
+
+// withoutBastionSessionSettings returns a copy of an Azure Bastion without the settings which can be updated once the
+// Azure Bastion Host is created.
+func withoutBastionSessionSettings(bastion *infrav1.AzureBastion) *infrav1.AzureBastion {
+	if bastion == nil {
+		return nil
+	}
+	bastion = bastion.DeepCopy()
+	bastion.ScaleUnits = nil
+	bastion.EnableIPConnect = nil
+	bastion.EnableShareableLink = nil
+	bastion.DisableCopyPaste = nil
+	bastion.EnableFileCopy = nil
+	return bastion
+}
+
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
 func (*AzureClusterWebhook) ValidateDelete(_ context.Context, _ *infrav1.AzureCluster) (admission.Warnings, error) {
 	return nil, nil
 }
+
+// validate validates the AzureCluster and the AzureSecurityRuleSets referenced by its subnets.
+func (w *AzureClusterWebhook) validate(ctx context.Context, c, old *infrav1.AzureCluster) (admission.Warnings, error) {
+	warnings, err := validateAzureCluster(c, old)
+	if err != nil {
+		return warnings, err
+	}
+	if allErrs := validateSecurityRuleSetReferences(ctx, w.client, c); len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(infrav1.GroupVersion.WithKind(infrav1.AzureClusterKind).GroupKind(), c.Name, allErrs)
+	}
+	return warnings, nil
+}
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -148,6 +150,100 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			}(),
 			wantErr: true,
 		},
+		{
+			name: "azurecluster waiting to be adopted can replace its control plane endpoint and API server load balancer",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "true"}
+				cluster.Spec.ControlPlaneEndpoint = clusterv1beta1.APIEndpoint{
+					Host: "apiserver.example.com",
+					Port: 8443,
+				}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "adopted"}
+				cluster.Spec.ControlPlaneEndpoint = clusterv1beta1.APIEndpoint{
+					Host: "apiserver.example.io",
+					Port: 6443,
+				}
+				cluster.Spec.NetworkSpec.APIServerLB.Name = "existing-lb"
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azurecluster waiting to be adopted cannot replace its control plane endpoint before the adoption completes",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "true"}
+				cluster.Spec.ControlPlaneEndpoint = clusterv1beta1.APIEndpoint{
+					Host: "apiserver.example.com",
+					Port: 8443,
+				}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "true"}
+				cluster.Spec.ControlPlaneEndpoint = clusterv1beta1.APIEndpoint{
+					Host: "apiserver.example.io",
+					Port: 6443,
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "adoption of an azurecluster cannot change the fields it does not rewrite",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "true"}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "adopted"}
+				cluster.Spec.NetworkSpec.PrivateDNSZoneName = "example.internal"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azurecluster waiting to be adopted cannot replace its vnet by removing the adopt annotation",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "true"}
+				cluster.Spec.NetworkSpec.Vnet.Tags = infrav1.Tags{infrav1.ClusterTagKey(cluster.Name): string(infrav1.ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.0.0.0/8"}
+				cluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{"10.0.0.0/16"}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Tags = infrav1.Tags{infrav1.ClusterTagKey(cluster.Name): string(infrav1.ResourceLifecycleOwned)}
+				cluster.Spec.NetworkSpec.Vnet.Name = "other-vnet"
+				cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"172.16.0.0/12"}
+				cluster.Spec.NetworkSpec.Subnets[0].CIDRBlocks = []string{"172.16.0.0/16"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "azurecluster waiting to be adopted cannot change its resource group",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Annotations = map[string]string{azure.AdoptAnnotation: "true"}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.ResourceGroup = "other-rg"
+				return cluster
+			}(),
+			wantErr: true,
+		},
 		{
 			name:       "azurecluster with no control plane endpoint - valid spec",
 			oldCluster: apifixtures.CreateValidCluster(),
@@ -300,6 +396,16 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name:       "azurecluster privateDNSZoneID is immutable",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.PrivateDNSZoneID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/dns-rg/providers/Microsoft.Network/privateDnsZones/privatelink.example.com"
+				return cluster
+			}(),
+			wantErr: true,
+		},
 		{
 			name: "control plane outbound lb is immutable",
 			oldCluster: &infrav1.AzureCluster{
@@ -318,6 +424,263 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:       "application security groups can be enabled",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.ApplicationSecurityGroupsEnabled = true
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "application security groups cannot be disabled",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.ApplicationSecurityGroupsEnabled = true
+				return cluster
+			}(),
+			cluster: apifixtures.CreateValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "azure bastion session settings can be changed",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &infrav1.AzureBastion{Name: "my-bastion", Sku: infrav1.StandardBastionHostSku}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &infrav1.AzureBastion{
+					Name:                "my-bastion",
+					Sku:                 infrav1.StandardBastionHostSku,
+					ScaleUnits:          ptr.To[int32](4),
+					EnableIPConnect:     ptr.To(true),
+					EnableShareableLink: ptr.To(true),
+					DisableCopyPaste:    ptr.To(true),
+					EnableFileCopy:      ptr.To(true),
+				}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "azure bastion name is immutable",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &infrav1.AzureBastion{Name: "my-bastion"}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.BastionSpec.AzureBastion = &infrav1.AzureBastion{Name: "my-bastion-new"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "ingress can be added",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{Name: "my-appgw"}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "ingress cannot be removed",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{Name: "my-appgw"}
+				return cluster
+			}(),
+			cluster: apifixtures.CreateValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "ingress capacity can be changed",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{Name: "my-appgw", Capacity: 2}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{Name: "my-appgw", Capacity: 4}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "ingress name is immutable",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{Name: "my-appgw"}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{Name: "my-appgw-new"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "ingress subnet is immutable",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{
+					Name: "my-appgw",
+					Subnet: infrav1.SubnetSpec{
+						SubnetClassSpec: infrav1.SubnetClassSpec{
+							Name:       "ingress-subnet",
+							CIDRBlocks: []string{"10.255.254.0/24"},
+						},
+					},
+				}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Ingress = &infrav1.IngressSpec{
+					Name: "my-appgw",
+					Subnet: infrav1.SubnetSpec{
+						SubnetClassSpec: infrav1.SubnetClassSpec{
+							Name:       "ingress-subnet",
+							CIDRBlocks: []string{"10.255.253.0/24"},
+						},
+					},
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "hub virtual network can be added",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Hub = &infrav1.HubVnetSpec{Name: "hub-vnet", ResourceGroup: "hub-rg"}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "hub virtual network cannot be removed",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Hub = &infrav1.HubVnetSpec{Name: "hub-vnet", ResourceGroup: "hub-rg"}
+				return cluster
+			}(),
+			cluster: apifixtures.CreateValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "hub virtual network subscription is immutable",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Hub = &infrav1.HubVnetSpec{Name: "hub-vnet", ResourceGroup: "hub-rg"}
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.Hub = &infrav1.HubVnetSpec{Name: "hub-vnet", ResourceGroup: "hub-rg", SubscriptionID: "456"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "virtual network subscription is immutable",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.SubscriptionID = "456"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "virtual network IPAM pool is immutable",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Vnet.IPAMPoolRef = &corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+					Kind:     "InClusterIPPool",
+					Name:     "vnet-pool",
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name:       "firewall can be added",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "firewall cannot be removed",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				return cluster
+			}(),
+			cluster: apifixtures.CreateValidCluster(),
+			wantErr: true,
+		},
+		{
+			name: "firewall additional FQDNs can be changed",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				cluster.Spec.NetworkSpec.Firewall.AdditionalFQDNs = []string{"example.com"}
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "firewall policy name is immutable",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				cluster.Spec.NetworkSpec.Firewall.PolicyName = "my-cluster-azfw-policy-new"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "firewall subnet is immutable",
+			oldCluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				return cluster
+			}(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Firewall = createValidFirewall()
+				cluster.Spec.NetworkSpec.Firewall.Subnet.CIDRBlocks = []string{"10.255.252.0/26"}
+				return cluster
+			}(),
+			wantErr: true,
+		},
 		{
 			name: "natGateway name is immutable",
 			oldCluster: func() *infrav1.AzureCluster {
@@ -452,6 +815,20 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			}(),
 			wantErr: true,
 		},
+		{
+			name:       "subnet IPAM pool is immutable",
+			oldCluster: apifixtures.CreateValidCluster(),
+			cluster: func() *infrav1.AzureCluster {
+				cluster := apifixtures.CreateValidCluster()
+				cluster.Spec.NetworkSpec.Subnets[0].IPAMPoolRef = &corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+					Kind:     "InClusterIPPool",
+					Name:     "subnet-pool",
+				}
+				return cluster
+			}(),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -68,6 +68,8 @@ func validateAzureClusterTemplateSpec(c *infrav1.AzureClusterTemplate) field.Err
 
 	allErrs = append(allErrs, validateAzureClusterTemplatePrivateDNSZoneResourceGroup(c)...)
 
+	allErrs = append(allErrs, validateAzureClusterTemplatePrivateDNSZoneID(c)...)
+
 	return allErrs
 }
 
@@ -196,3 +198,25 @@ func validateAzureClusterTemplatePrivateDNSZoneResourceGroup(c *infrav1.AzureClu
 
 	return allErrs
 }
+
+// validateAzureClusterTemplatePrivateDNSZoneID validates the private DNS zone ID of an AzureClusterTemplate.
+func validateAzureClusterTemplatePrivateDNSZoneID(c *infrav1.AzureClusterTemplate) field.ErrorList {
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("privateDNSZoneID")
+	networkSpec := c.Spec.Template.Spec.NetworkSpec
+
+	allErrs = append(allErrs, validatePrivateDNSZoneID(
+		networkSpec.NetworkClassSpec,
+		true,
+		networkSpec.APIServerLB.Type,
+		fldPath,
+	)...)
+
+	allErrs = append(allErrs, validatePrivateDNSZoneIdentityRef(
+		networkSpec.NetworkClassSpec,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("privateDNSZoneIdentityRef"),
+	)...)
+
+	return allErrs
+}
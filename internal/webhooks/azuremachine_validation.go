@@ -19,6 +19,7 @@ package webhooks
 import (
 	"encoding/base64"
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
@@ -83,6 +84,14 @@ func validateAzureMachineSpec(spec infrav1.AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateSpotEvictionRecovery(spec.SpotEvictionRecovery, spec.SpotVMOptions, spec.OSDisk, field.NewPath("spotEvictionRecovery")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateCapacityFallback(spec.CapacityFallback, spec.VMSize, spec.SpotEvictionRecovery, field.NewPath("capacityFallback")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	return allErrs
 }
 
@@ -96,13 +105,18 @@ func ValidateNetwork(subnetName string, acceleratedNetworking *bool, networkInte
 		return field.ErrorList{field.Invalid(fldPath, networkInterfaces, "cannot set both networkInterfaces and machine acceleratedNetworking")}
 	}
 
-	for _, nic := range networkInterfaces {
+	allErrs := field.ErrorList{}
+	for i, nic := range networkInterfaces {
 		if nic.PrivateIPConfigs < 1 {
 			return field.ErrorList{field.Invalid(fldPath, networkInterfaces, "number of privateIPConfigs per interface must be at least 1")}
 		}
+		if nic.PrivateIPAddress != "" && net.ParseIP(nic.PrivateIPAddress) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("privateIPAddress"), nic.PrivateIPAddress, "privateIPAddress must be a valid IP address"))
+		}
+		allErrs = append(allErrs, validateIPAMPoolRef(nic.IPAMPoolRef, fldPath.Index(i).Child("ipamPoolRef"))...)
 	}
 
-	return field.ErrorList{}
+	return allErrs
 }
 
 // ValidateSSHKey validates an SSHKey.
@@ -458,3 +472,57 @@ func ValidateVMExtensions(disableExtensionOperations *bool, vmExtensions []infra
 
 	return allErrs
 }
+
+// ValidateSpotEvictionRecovery validates the spot eviction recovery configuration.
+func ValidateSpotEvictionRecovery(recovery *infrav1.SpotEvictionRecovery, spotVMOptions *infrav1.SpotVMOptions, osDisk infrav1.OSDisk, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if recovery == nil {
+		return allErrs
+	}
+
+	if spotVMOptions == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "spotEvictionRecovery requires spotVMOptions to be set"))
+	} else if ptr.Deref(spotVMOptions.EvictionPolicy, infrav1.SpotEvictionPolicyDeallocate) != infrav1.SpotEvictionPolicyDeallocate {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "spotEvictionRecovery requires the Deallocate eviction policy"))
+	}
+
+	for i, size := range recovery.FallbackVMSizes {
+		if size == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("fallbackVMSizes").Index(i), "VM size must not be empty"))
+		}
+	}
+
+	if recovery.RegularPriorityAfterEvictions != nil && osDisk.DiffDiskSettings != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("regularPriorityAfterEvictions"), "VMs with an ephemeral OS disk cannot be recreated with regular priority"))
+	}
+
+	return allErrs
+}
+
+// ValidateCapacityFallback validates the capacity fallback configuration.
+func ValidateCapacityFallback(fallback *infrav1.CapacityFallback, vmSize string, recovery *infrav1.SpotEvictionRecovery, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if fallback == nil {
+		return allErrs
+	}
+
+	// Retrying the allocation deletes the disks of the failed VM, which hold the state of a VM recreated from its disks.
+	if recovery != nil && recovery.RegularPriorityAfterEvictions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "capacityFallback cannot be combined with spotEvictionRecovery.regularPriorityAfterEvictions"))
+	}
+
+	sizes := map[string]bool{vmSize: true}
+	for i, size := range fallback.VMSizes {
+		switch {
+		case size == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("vmSizes").Index(i), "VM size must not be empty"))
+		case sizes[size]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("vmSizes").Index(i), size))
+		}
+		sizes[size] = true
+	}
+
+	return allErrs
+}
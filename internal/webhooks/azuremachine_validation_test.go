@@ -27,6 +27,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
@@ -877,6 +878,57 @@ func TestAzureMachine_ValidateNetwork(t *testing.T) {
 			}},
 			wantErr: true,
 		},
+		{
+			name:                  "valid config with a static private IP address",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []infrav1.NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				PrivateIPAddress: "10.0.0.10",
+			}},
+			wantErr: false,
+		},
+		{
+			name:                  "invalid config with a malformed static private IP address",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []infrav1.NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				PrivateIPAddress: "10.0.0.300",
+			}},
+			wantErr: true,
+		},
+		{
+			name:                  "valid config with an IPAM pool",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []infrav1.NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				IPAMPoolRef: &corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+					Kind:     "InClusterIPPool",
+					Name:     "node-pool",
+				},
+			}},
+			wantErr: false,
+		},
+		{
+			name:                  "invalid config with an IPAM pool without a kind",
+			subnetName:            "",
+			acceleratedNetworking: nil,
+			networkInterfaces: []infrav1.NetworkInterface{{
+				SubnetName:       "subnet1",
+				PrivateIPConfigs: 1,
+				IPAMPoolRef: &corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+					Name:     "node-pool",
+				},
+			}},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -1124,3 +1176,165 @@ func TestAzureMachine_ValidateConfidentialCompute(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureMachine_ValidateSpotEvictionRecovery(t *testing.T) {
+	tests := []struct {
+		name          string
+		recovery      *infrav1.SpotEvictionRecovery
+		spotVMOptions *infrav1.SpotVMOptions
+		osDisk        infrav1.OSDisk
+		wantErr       bool
+	}{
+		{
+			name:    "empty is valid",
+			wantErr: false,
+		},
+		{
+			name: "valid with the default eviction policy",
+			recovery: &infrav1.SpotEvictionRecovery{
+				FallbackVMSizes:               []string{"Standard_D4s_v3"},
+				RegularPriorityAfterEvictions: ptr.To[int32](3),
+			},
+			spotVMOptions: &infrav1.SpotVMOptions{},
+			wantErr:       false,
+		},
+		{
+			name:     "valid with the Deallocate eviction policy",
+			recovery: &infrav1.SpotEvictionRecovery{},
+			spotVMOptions: &infrav1.SpotVMOptions{
+				EvictionPolicy: ptr.To(infrav1.SpotEvictionPolicyDeallocate),
+			},
+			wantErr: false,
+		},
+		{
+			name:     "invalid without spotVMOptions",
+			recovery: &infrav1.SpotEvictionRecovery{},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid with the Delete eviction policy",
+			recovery: &infrav1.SpotEvictionRecovery{},
+			spotVMOptions: &infrav1.SpotVMOptions{
+				EvictionPolicy: ptr.To(infrav1.SpotEvictionPolicyDelete),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid with an empty fallback VM size",
+			recovery: &infrav1.SpotEvictionRecovery{
+				FallbackVMSizes: []string{"Standard_D4s_v3", ""},
+			},
+			spotVMOptions: &infrav1.SpotVMOptions{},
+			wantErr:       true,
+		},
+		{
+			name: "valid with an ephemeral OS disk without regular priority",
+			recovery: &infrav1.SpotEvictionRecovery{
+				FallbackVMSizes: []string{"Standard_D4s_v3"},
+			},
+			spotVMOptions: &infrav1.SpotVMOptions{},
+			osDisk: infrav1.OSDisk{
+				DiffDiskSettings: &infrav1.DiffDiskSettings{Option: "Local"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid with an ephemeral OS disk and regular priority",
+			recovery: &infrav1.SpotEvictionRecovery{
+				RegularPriorityAfterEvictions: ptr.To[int32](1),
+			},
+			spotVMOptions: &infrav1.SpotVMOptions{},
+			osDisk: infrav1.OSDisk{
+				DiffDiskSettings: &infrav1.DiffDiskSettings{Option: "Local"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := ValidateSpotEvictionRecovery(test.recovery, test.spotVMOptions, test.osDisk, field.NewPath("spotEvictionRecovery"))
+			if test.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateCapacityFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		fallback *infrav1.CapacityFallback
+		recovery *infrav1.SpotEvictionRecovery
+		wantErr  bool
+	}{
+		{
+			name:    "empty is valid",
+			wantErr: false,
+		},
+		{
+			name: "valid with failure domains and VM sizes",
+			fallback: &infrav1.CapacityFallback{
+				AcrossFailureDomains: true,
+				VMSizes:              []string{"Standard_D4s_v3", "Standard_E4s_v3"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid with an empty VM size",
+			fallback: &infrav1.CapacityFallback{
+				VMSizes: []string{""},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid with a duplicate VM size",
+			fallback: &infrav1.CapacityFallback{
+				VMSizes: []string{"Standard_D4s_v3", "Standard_D4s_v3"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid with the VM size of the machine",
+			fallback: &infrav1.CapacityFallback{
+				VMSizes: []string{"Standard_D2s_v3"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid with spot eviction recovery which restarts the VM",
+			fallback: &infrav1.CapacityFallback{
+				AcrossFailureDomains: true,
+			},
+			recovery: &infrav1.SpotEvictionRecovery{
+				FallbackVMSizes: []string{"Standard_D4s_v3"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid with spot eviction recovery which recreates the VM from its disks",
+			fallback: &infrav1.CapacityFallback{
+				AcrossFailureDomains: true,
+			},
+			recovery: &infrav1.SpotEvictionRecovery{
+				RegularPriorityAfterEvictions: ptr.To[int32](2),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := ValidateCapacityFallback(test.fallback, "Standard_D2s_v3", test.recovery, field.NewPath("capacityFallback"))
+			if test.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -162,6 +162,20 @@ func (mw *AzureMachineWebhook) ValidateUpdate(_ context.Context, old, m *infrav1
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "SpotEvictionRecovery"),
+		old.Spec.SpotEvictionRecovery,
+		m.Spec.SpotEvictionRecovery); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "CapacityFallback"),
+		old.Spec.CapacityFallback,
+		m.Spec.CapacityFallback); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "SecurityProfile"),
 		old.Spec.SecurityProfile,
@@ -187,6 +201,13 @@ func (mw *AzureMachineWebhook) ValidateUpdate(_ context.Context, old, m *infrav1
 			old.Spec.NetworkInterfaces[0].SubnetName = m.Spec.NetworkInterfaces[0].SubnetName
 		}
 
+		// The reconciler will populate the PrivateIPAddress of the interfaces whose IP address is allocated from an IPAM pool.
+		for i := range old.Spec.NetworkInterfaces {
+			if i < len(m.Spec.NetworkInterfaces) && old.Spec.NetworkInterfaces[i].IsIPAMAllocationPending() {
+				old.Spec.NetworkInterfaces[i].PrivateIPAddress = m.Spec.NetworkInterfaces[i].PrivateIPAddress
+			}
+		}
+
 		// Enforce immutability for all other changes to NetworkInterfaces.
 		if !reflect.DeepEqual(m.Spec.NetworkInterfaces, old.Spec.NetworkInterfaces) {
 			allErrs = append(allErrs,
@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -700,6 +701,60 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.SpotEvictionRecovery is immutable",
+			oldMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					SpotEvictionRecovery: &infrav1.SpotEvictionRecovery{
+						FallbackVMSizes: []string{"Standard_D2s_v3"},
+					},
+				},
+			},
+			newMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					SpotEvictionRecovery: &infrav1.SpotEvictionRecovery{
+						FallbackVMSizes: []string{"Standard_D4s_v3"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.SpotEvictionRecovery is immutable",
+			oldMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					SpotEvictionRecovery: &infrav1.SpotEvictionRecovery{
+						FallbackVMSizes: []string{"Standard_D2s_v3"},
+					},
+				},
+			},
+			newMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					SpotEvictionRecovery: &infrav1.SpotEvictionRecovery{
+						FallbackVMSizes: []string{"Standard_D2s_v3"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.CapacityFallback is immutable",
+			oldMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					CapacityFallback: &infrav1.CapacityFallback{
+						AcrossFailureDomains: true,
+					},
+				},
+			},
+			newMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					CapacityFallback: &infrav1.CapacityFallback{
+						AcrossFailureDomains: false,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.SecurityProfile is immutable",
 			oldMachine: &infrav1.AzureMachine{
@@ -938,6 +993,34 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "validTest: private IP address allocated from an IPAM pool can be set",
+			oldMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: "subnet", IPAMPoolRef: &corev1.TypedLocalObjectReference{Kind: "InClusterIPPool", Name: "node-pool"}}},
+				},
+			},
+			newMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: "subnet", IPAMPoolRef: &corev1.TypedLocalObjectReference{Kind: "InClusterIPPool", Name: "node-pool"}, PrivateIPAddress: "10.0.0.10"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: static private IP address is immutable",
+			oldMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: "subnet", PrivateIPAddress: "10.0.0.10"}},
+				},
+			},
+			newMachine: &infrav1.AzureMachine{
+				Spec: infrav1.AzureMachineSpec{
+					NetworkInterfaces: []infrav1.NetworkInterface{{SubnetName: "subnet", PrivateIPAddress: "10.0.0.11"}},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.capacityReservationGroupID is immutable",
 			oldMachine: &infrav1.AzureMachine{
@@ -86,6 +86,9 @@ func (*AzureMachineTemplateWebhook) ValidateCreate(_ context.Context, r *infrav1
 		if networkInterface.PrivateIPConfigs < 1 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces", "privateIPConfigs"), r.Spec.Template.Spec.NetworkInterfaces[i].PrivateIPConfigs, "networkInterface privateIPConfigs must be set to a minimum value of 1"))
 		}
+		if networkInterface.PrivateIPAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("AzureMachineTemplate", "spec", "template", "spec", "networkInterfaces").Index(i).Child("privateIPAddress"), "static private IP addresses cannot be shared by the machines of a template, use ipamPoolRef instead"))
+		}
 	}
 
 	if ptr.Deref(r.Spec.Template.Spec.DisableExtensionOperations, false) && len(r.Spec.Template.Spec.VMExtensions) > 0 {
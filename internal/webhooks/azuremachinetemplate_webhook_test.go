@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -244,6 +245,40 @@ func TestAzureMachineTemplate_ValidateCreate(t *testing.T) {
 			),
 			wantErr: false,
 		},
+		{
+			name: "azuremachinetemplate with network interfaces and a static private IP address",
+			machineTemplate: createAzureMachineTemplateFromMachine(
+				createMachineWithNetworkConfig(
+					"",
+					nil,
+					[]infrav1.NetworkInterface{
+						{SubnetName: "subnet1", PrivateIPConfigs: 1, PrivateIPAddress: "10.0.0.10"},
+					},
+				),
+			),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinetemplate with network interfaces and an IPAM pool",
+			machineTemplate: createAzureMachineTemplateFromMachine(
+				createMachineWithNetworkConfig(
+					"",
+					nil,
+					[]infrav1.NetworkInterface{
+						{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+							IPAMPoolRef: &corev1.TypedLocalObjectReference{
+								APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+								Kind:     "InClusterIPPool",
+								Name:     "node-pool",
+							},
+						},
+					},
+				),
+			),
+			wantErr: false,
+		},
 	}
 
 	for _, test := range tests {
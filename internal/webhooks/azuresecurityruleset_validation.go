@@ -0,0 +1,181 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// securityRuleSource is a list of security rules merged into a security group, described by where it is defined.
+type securityRuleSource struct {
+	description string
+	rules       infrav1.SecurityRules
+}
+
+// validateAzureSecurityRuleSet validates the rules of an AzureSecurityRuleSet, and that they do not conflict with the
+// other rules of the security groups of the AzureClusters referencing it.
+func validateAzureSecurityRuleSet(ctx context.Context, cli client.Client, r *infrav1.AzureSecurityRuleSet) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "securityRules")
+
+	for i, rule := range r.Spec.SecurityRules {
+		allErrs = append(allErrs, validateSecurityRule(rule, fldPath.Index(i))...)
+		for _, conflict := range securityRuleConflicts(infrav1.SecurityRules{rule}, []securityRuleSource{{
+			description: fmt.Sprintf("AzureSecurityRuleSet %s", r.Name),
+			rules:       r.Spec.SecurityRules[:i],
+		}}) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), rule.Name, conflict))
+		}
+	}
+	if len(allErrs) > 0 || cli == nil {
+		return allErrs
+	}
+
+	clusters, err := referencingAzureClusters(ctx, cli, r)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	for _, cluster := range clusters {
+		for _, subnet := range cluster.Spec.NetworkSpec.Subnets {
+			if !slices.Contains(subnet.SecurityGroup.SecurityRuleSets, r.Name) {
+				continue
+			}
+			others := []securityRuleSource{subnetSecurityRuleSource(cluster.Name, subnet)}
+			for _, name := range subnet.SecurityGroup.SecurityRuleSets {
+				if name == r.Name {
+					continue
+				}
+				ruleSet, err := getSecurityRuleSet(ctx, cli, r.Namespace, name)
+				if err != nil {
+					return append(allErrs, field.InternalError(fldPath, err))
+				}
+				if ruleSet != nil {
+					others = append(others, ruleSetSecurityRuleSource(ruleSet))
+				}
+			}
+			for _, conflict := range securityRuleConflicts(r.Spec.SecurityRules, others) {
+				allErrs = append(allErrs, field.Invalid(fldPath, r.Name, conflict))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// referencingAzureClusters returns the AzureClusters in the namespace of an AzureSecurityRuleSet with a subnet
+// referencing it.
+func referencingAzureClusters(ctx context.Context, cli client.Client, r *infrav1.AzureSecurityRuleSet) ([]infrav1.AzureCluster, error) {
+	clusters := &infrav1.AzureClusterList{}
+	if err := cli.List(ctx, clusters, client.InNamespace(r.Namespace)); err != nil {
+		return nil, err
+	}
+	var referencing []infrav1.AzureCluster
+	for _, cluster := range clusters.Items {
+		if slices.ContainsFunc(cluster.Spec.NetworkSpec.Subnets, func(subnet infrav1.SubnetSpec) bool {
+			return slices.Contains(subnet.SecurityGroup.SecurityRuleSets, r.Name)
+		}) {
+			referencing = append(referencing, cluster)
+		}
+	}
+	return referencing, nil
+}
+
+// validateSecurityRuleSetReferences validates that the rules of the AzureSecurityRuleSets referenced by the subnets
+// of an AzureCluster do not conflict with each other nor with the rules of the subnets. AzureSecurityRuleSets which
+// do not exist yet are ignored.
+func validateSecurityRuleSetReferences(ctx context.Context, cli client.Client, c *infrav1.AzureCluster) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "networkSpec", "subnets")
+
+	for i, subnet := range c.Spec.NetworkSpec.Subnets {
+		if len(subnet.SecurityGroup.SecurityRuleSets) == 0 || cli == nil {
+			continue
+		}
+		ruleSetsPath := fldPath.Index(i).Child("securityGroup", "securityRuleSets")
+		sources := []securityRuleSource{subnetSecurityRuleSource(c.Name, subnet)}
+		for j, name := range subnet.SecurityGroup.SecurityRuleSets {
+			ruleSet, err := getSecurityRuleSet(ctx, cli, c.Namespace, name)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(ruleSetsPath.Index(j), err))
+				continue
+			}
+			if ruleSet == nil {
+				continue
+			}
+			for _, conflict := range securityRuleConflicts(ruleSet.Spec.SecurityRules, sources) {
+				allErrs = append(allErrs, field.Invalid(ruleSetsPath.Index(j), name, conflict))
+			}
+			sources = append(sources, ruleSetSecurityRuleSource(ruleSet))
+		}
+	}
+
+	return allErrs
+}
+
+// securityRuleConflicts returns a message for each rule which has the same name, or the same priority and
+// direction, as a rule of another source merged into the same security group.
+func securityRuleConflicts(rules infrav1.SecurityRules, others []securityRuleSource) []string {
+	var conflicts []string
+	for _, rule := range rules {
+		for _, other := range others {
+			for _, otherRule := range other.rules {
+				switch {
+				case strings.EqualFold(rule.Name, otherRule.Name):
+					conflicts = append(conflicts, fmt.Sprintf("security rule %s has the same name as a security rule of %s", rule.Name, other.description))
+				case rule.Priority == otherRule.Priority && rule.Direction == otherRule.Direction:
+					conflicts = append(conflicts, fmt.Sprintf("security rule %s has the same %s priority %d as security rule %s of %s", rule.Name, rule.Direction, rule.Priority, otherRule.Name, other.description))
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+func subnetSecurityRuleSource(clusterName string, subnet infrav1.SubnetSpec) securityRuleSource {
+	return securityRuleSource{
+		description: fmt.Sprintf("subnet %s of AzureCluster %s", subnet.Name, clusterName),
+		rules:       subnet.SecurityGroup.SecurityRules,
+	}
+}
+
+func ruleSetSecurityRuleSource(ruleSet *infrav1.AzureSecurityRuleSet) securityRuleSource {
+	return securityRuleSource{
+		description: fmt.Sprintf("AzureSecurityRuleSet %s", ruleSet.Name),
+		rules:       ruleSet.Spec.SecurityRules,
+	}
+}
+
+// getSecurityRuleSet returns the AzureSecurityRuleSet with the given name, or nil when it does not exist.
+func getSecurityRuleSet(ctx context.Context, cli client.Client, namespace, name string) (*infrav1.AzureSecurityRuleSet, error) {
+	ruleSet := &infrav1.AzureSecurityRuleSet{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, ruleSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ruleSet, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// SetupWebhookWithManager sets up and registers the webhook with the manager.
+func (w *AzureSecurityRuleSetWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w.client = mgr.GetClient()
+
+	return ctrl.NewWebhookManagedBy(mgr, &infrav1.AzureSecurityRuleSet{}).
+		WithValidator(w).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuresecurityruleset,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azuresecurityrulesets,versions=v1beta1,name=validation.azuresecurityruleset.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AzureSecurityRuleSetWebhook implements a validating webhook for AzureSecurityRuleSet.
+type AzureSecurityRuleSetWebhook struct {
+	client client.Client
+}
+
+var _ admission.Validator[*infrav1.AzureSecurityRuleSet] = &AzureSecurityRuleSetWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (w *AzureSecurityRuleSetWebhook) ValidateCreate(ctx context.Context, r *infrav1.AzureSecurityRuleSet) (admission.Warnings, error) {
+	return w.validate(ctx, r)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (w *AzureSecurityRuleSetWebhook) ValidateUpdate(ctx context.Context, _, r *infrav1.AzureSecurityRuleSet) (admission.Warnings, error) {
+	return w.validate(ctx, r)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+// AzureSecurityRuleSets referenced by AzureClusters cannot be deleted, as their security groups could not be
+// reconciled anymore.
+func (w *AzureSecurityRuleSetWebhook) ValidateDelete(ctx context.Context, r *infrav1.AzureSecurityRuleSet) (admission.Warnings, error) {
+	if w.client == nil {
+		return nil, nil
+	}
+	clusters, err := referencingAzureClusters(ctx, w.client, r)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	return nil, apierrors.NewForbidden(infrav1.GroupVersion.WithResource("azuresecurityrulesets").GroupResource(), r.Name,
+		errors.Errorf("AzureSecurityRuleSet is referenced by AzureClusters %s", strings.Join(names, ", ")))
+}
+
+func (w *AzureSecurityRuleSetWebhook) validate(ctx context.Context, r *infrav1.AzureSecurityRuleSet) (admission.Warnings, error) {
+	if allErrs := validateAzureSecurityRuleSet(ctx, w.client, r); len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(infrav1.GroupVersion.WithKind(infrav1.AzureSecurityRuleSetKind).GroupKind(), r.Name, allErrs)
+	}
+	return nil, nil
+}
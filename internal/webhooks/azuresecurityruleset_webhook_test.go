@@ -0,0 +1,226 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func securityRuleSetRule(name string, priority int32) infrav1.SecurityRule {
+	return infrav1.SecurityRule{
+		Name:      name,
+		Protocol:  infrav1.SecurityGroupProtocolTCP,
+		Direction: infrav1.SecurityRuleDirectionInbound,
+		Priority:  priority,
+		Action:    infrav1.SecurityRuleActionAllow,
+	}
+}
+
+func securityRuleSet(name string, rules ...infrav1.SecurityRule) *infrav1.AzureSecurityRuleSet {
+	return &infrav1.AzureSecurityRuleSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       infrav1.AzureSecurityRuleSetSpec{SecurityRules: rules},
+	}
+}
+
+func securityRuleSetCluster(ruleSets []string, rules ...infrav1.SecurityRule) *infrav1.AzureCluster {
+	return &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-cluster"},
+		Spec: infrav1.AzureClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{{
+					SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet", Role: infrav1.SubnetNode},
+					SecurityGroup: infrav1.SecurityGroup{
+						Name: "node-nsg",
+						SecurityGroupClass: infrav1.SecurityGroupClass{
+							SecurityRules:    rules,
+							SecurityRuleSets: ruleSets,
+						},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestAzureSecurityRuleSet_Validate(t *testing.T) {
+	outboundRule := securityRuleSetRule("allow_out", 500)
+	outboundRule.Direction = infrav1.SecurityRuleDirectionOutbound
+
+	tests := []struct {
+		name    string
+		ruleSet *infrav1.AzureSecurityRuleSet
+		objects []client.Object
+		wantErr bool
+	}{
+		{
+			name:    "valid rule set",
+			ruleSet: securityRuleSet("shared", securityRuleSetRule("allow_http", 500), securityRuleSetRule("allow_https", 501)),
+		},
+		{
+			name:    "rule priority out of range",
+			ruleSet: securityRuleSet("shared", securityRuleSetRule("allow_http", 50)),
+			wantErr: true,
+		},
+		{
+			name:    "rules with the same priority",
+			ruleSet: securityRuleSet("shared", securityRuleSetRule("allow_http", 500), securityRuleSetRule("allow_https", 500)),
+			wantErr: true,
+		},
+		{
+			name:    "rules with the same priority in different directions",
+			ruleSet: securityRuleSet("shared", securityRuleSetRule("allow_http", 500), outboundRule),
+		},
+		{
+			name:    "rule conflicts with a rule of a subnet referencing the rule set",
+			ruleSet: securityRuleSet("shared", securityRuleSetRule("allow_http", 500)),
+			objects: []client.Object{securityRuleSetCluster([]string{"shared"}, securityRuleSetRule("allow_ssh", 500))},
+			wantErr: true,
+		},
+		{
+			name:    "rule conflicts with a rule of another rule set referenced by the same subnet",
+			ruleSet: securityRuleSet("shared", securityRuleSetRule("allow_http", 500)),
+			objects: []client.Object{
+				securityRuleSetCluster([]string{"other", "shared"}),
+				securityRuleSet("other", securityRuleSetRule("allow_http", 600)),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "rule conflicts with a rule of a subnet not referencing the rule set",
+			ruleSet: securityRuleSet("shared", securityRuleSetRule("allow_http", 500)),
+			objects: []client.Object{securityRuleSetCluster([]string{"other"}, securityRuleSetRule("allow_ssh", 500))},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			w := &AzureSecurityRuleSetWebhook{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build(),
+			}
+
+			_, err := w.ValidateCreate(t.Context(), tc.ruleSet)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateSecurityRuleSetReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *infrav1.AzureCluster
+		objects []client.Object
+		wantErr bool
+	}{
+		{
+			name:    "no rule sets referenced",
+			cluster: securityRuleSetCluster(nil, securityRuleSetRule("allow_ssh", 500)),
+		},
+		{
+			name:    "referenced rule set does not exist yet",
+			cluster: securityRuleSetCluster([]string{"shared"}, securityRuleSetRule("allow_ssh", 500)),
+		},
+		{
+			name:    "referenced rule set without conflicts",
+			cluster: securityRuleSetCluster([]string{"shared"}, securityRuleSetRule("allow_ssh", 500)),
+			objects: []client.Object{securityRuleSet("shared", securityRuleSetRule("allow_http", 600))},
+		},
+		{
+			name:    "referenced rule set with the same priority as a subnet rule",
+			cluster: securityRuleSetCluster([]string{"shared"}, securityRuleSetRule("allow_ssh", 500)),
+			objects: []client.Object{securityRuleSet("shared", securityRuleSetRule("allow_http", 500))},
+			wantErr: true,
+		},
+		{
+			name:    "referenced rule sets with the same rule name",
+			cluster: securityRuleSetCluster([]string{"shared", "other"}),
+			objects: []client.Object{
+				securityRuleSet("shared", securityRuleSetRule("allow_http", 500)),
+				securityRuleSet("other", securityRuleSetRule("allow_http", 600)),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+
+			errs := validateSecurityRuleSetReferences(t.Context(), cli, tc.cluster)
+			if tc.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureSecurityRuleSet_ValidateDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []client.Object
+		wantErr bool
+	}{
+		{
+			name: "rule set not referenced",
+		},
+		{
+			name:    "rule set not referenced by the subnets of an AzureCluster",
+			objects: []client.Object{securityRuleSetCluster([]string{"other"})},
+		},
+		{
+			name:    "rule set referenced by an AzureCluster",
+			objects: []client.Object{securityRuleSetCluster([]string{"other", "shared"})},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			w := &AzureSecurityRuleSetWebhook{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build(),
+			}
+
+			_, err := w.ValidateDelete(t.Context(), securityRuleSet("shared", securityRuleSetRule("allow_http", 500)))
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("my-cluster"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
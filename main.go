@@ -42,6 +42,7 @@ import (
 	"k8s.io/utils/ptr"
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ipamv1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	capifeature "sigs.k8s.io/cluster-api/feature"
@@ -55,6 +56,8 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	infrav1controllersexp "sigs.k8s.io/cluster-api-provider-azure/exp/controllers"
@@ -62,6 +65,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/internal/asomigration"
 	expwebhooks "sigs.k8s.io/cluster-api-provider-azure/internal/exp/webhooks"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/webhooks"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/audit"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
 	"sigs.k8s.io/cluster-api-provider-azure/util/components"
@@ -79,6 +83,7 @@ func init() {
 	_ = infrav1.AddToScheme(scheme)
 	_ = infrav1exp.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
+	_ = ipamv1.AddToScheme(scheme)
 	_ = bootstrapv1.AddToScheme(scheme)
 	_ = asoresourcesv1.AddToScheme(scheme)
 	_ = asocontainerservicev1api20250801.AddToScheme(scheme)
@@ -113,6 +118,11 @@ var (
 	managerOptions                     = flags.ManagerOptions{}
 	timeouts                           reconciler.Timeouts
 	enableTracing                      bool
+	enableAzureAPIBudget               bool
+	auditSinks                         []string
+	auditLogPath                       string
+	auditOTLPEndpoint                  string
+	costPriceSheetPath                 string
 	disableControllersOrWebhooks       []string
 )
 
@@ -254,6 +264,37 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Enable tracing to the opentelemetry-collector service in the same namespace.",
 	)
 
+	fs.BoolVar(
+		&enableAzureAPIBudget,
+		"enable-azure-api-budget",
+		false,
+		"Enable a client-side budget of Azure API requests per subscription and resource provider, which delays requests before Azure Resource Manager throttles them.",
+	)
+
+	fs.StringSliceVar(&auditSinks,
+		"audit-sinks",
+		[]string{},
+		"Comma-separated list of sinks to record every PUT, PATCH and DELETE of an Azure resource to. The list can contain the following values: events,file,otlp",
+	)
+
+	fs.StringVar(&auditLogPath,
+		"audit-log-path",
+		"",
+		"Path of the JSON lines file audit records are appended to when the file audit sink is enabled.",
+	)
+
+	fs.StringVar(&auditOTLPEndpoint,
+		"audit-otlp-endpoint",
+		"opentelemetry-collector:4317",
+		"Endpoint of the OTLP collector audit records are exported to when the otlp audit sink is enabled.",
+	)
+
+	fs.StringVar(&costPriceSheetPath,
+		"cost-price-sheet",
+		"",
+		"Path of a YAML price sheet used to estimate the monthly cost of AzureClusters, AzureMachines and AzureMachinePools. Cost estimation is disabled when empty.",
+	)
+
 	fs.StringVar(&deprecatedAzureBootrapConfigGVK,
 		"bootstrap-config-gvk",
 		"",
@@ -386,6 +427,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	if enableAzureAPIBudget {
+		ratelimit.SetDefaultBudgeter(ratelimit.NewBudgeter(ratelimit.DefaultConfig()))
+	}
+
+	if len(auditSinks) > 0 {
+		if err := registerAuditSinks(ctx, mgr); err != nil {
+			setupLog.Error(err, "unable to initialize audit sinks")
+			os.Exit(1)
+		}
+	}
+
+	if costPriceSheetPath != "" {
+		sheet, err := cost.LoadPriceSheet(costPriceSheetPath)
+		if err != nil {
+			setupLog.Error(err, "unable to load cost price sheet")
+			os.Exit(1)
+		}
+		cost.SetDefaultEstimator(cost.NewEstimator(sheet))
+	}
+
 	registerControllers(ctx, mgr)
 
 	registerWebhooks(mgr)
@@ -398,6 +459,51 @@ func main() {
 	}
 }
 
+// registerAuditSinks configures the sinks every mutation of an Azure resource is recorded to.
+func registerAuditSinks(ctx context.Context, mgr manager.Manager) error {
+	var sinks audit.MultiSink
+	for _, name := range auditSinks {
+		switch name {
+		case "events":
+			sinks = append(sinks, audit.NewEventSink(mgr.GetEventRecorderFor("azure-audit")))
+		case "file":
+			if auditLogPath == "" {
+				return fmt.Errorf("--audit-log-path is required by the file audit sink")
+			}
+			sink, err := audit.NewFileSink(auditLogPath)
+			if err != nil {
+				return err
+			}
+			go func() {
+				<-ctx.Done()
+				if err := sink.Close(); err != nil {
+					setupLog.Error(err, "failed to close audit log")
+				}
+			}()
+			sinks = append(sinks, sink)
+		case "otlp":
+			sink, err := audit.NewOTLPSink(ctx, auditOTLPEndpoint)
+			if err != nil {
+				return err
+			}
+			// Give the exporter 5 seconds to flush pending records when the context closes.
+			go func() { //nolint:gosec // G118: a fresh context is created below because ctx is already cancelled here.
+				<-ctx.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := sink.Shutdown(ctx); err != nil {
+					setupLog.Error(err, "failed to shut down audit exporter")
+				}
+			}()
+			sinks = append(sinks, sink)
+		default:
+			return fmt.Errorf("invalid audit-sinks value %s", name)
+		}
+	}
+	audit.SetDefaultSink(sinks)
+	return nil
+}
+
 func registerControllers(ctx context.Context, mgr manager.Manager) {
 	credCache := azure.NewCredentialCache()
 
@@ -430,6 +536,16 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	if err := controllers.NewAzureClusterAdoptReconciler(
+		mgr.GetClient(),
+		mgr.GetEventRecorderFor("azureclusteradopt-reconciler"),
+		timeouts,
+		credCache,
+	).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: azureClusterConcurrency}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AzureClusterAdopt")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.AzureJSONTemplateReconciler{
 		Client:           mgr.GetClient(),
 		Recorder:         mgr.GetEventRecorderFor("azurejsontemplate-reconciler"),
@@ -672,6 +788,11 @@ func registerWebhooks(mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	if err := (&webhooks.AzureSecurityRuleSetWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "AzureSecurityRuleSet")
+		os.Exit(1)
+	}
+
 	if feature.Gates.Enabled(capifeature.MachinePool) {
 		if err := (&expwebhooks.AzureMachinePoolWebhook{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "AzureMachinePool")
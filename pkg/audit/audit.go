@@ -0,0 +1,204 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every mutation CAPZ makes to Azure resources and ships the records to a pluggable sink.
+package audit
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Operation is the kind of mutation made to an Azure resource.
+type Operation string
+
+const (
+	// OperationPut creates or replaces a resource.
+	OperationPut Operation = "PUT"
+	// OperationPatch updates part of a resource.
+	OperationPatch Operation = "PATCH"
+	// OperationDelete deletes a resource.
+	OperationDelete Operation = "DELETE"
+	// OperationPost invokes an action on a resource, e.g. starting a VM.
+	OperationPost Operation = "POST"
+)
+
+// Result is the outcome of a mutation.
+type Result string
+
+const (
+	// ResultSucceeded means the mutation completed successfully.
+	ResultSucceeded Result = "Succeeded"
+	// ResultInProgress means the mutation was accepted and is still in progress.
+	ResultInProgress Result = "InProgress"
+	// ResultFailed means the mutation failed.
+	ResultFailed Result = "Failed"
+	// ResultNotFound means the resource to delete did not exist, so nothing was changed.
+	ResultNotFound Result = "NotFound"
+)
+
+// Record describes a single mutation of an Azure resource.
+type Record struct {
+	// Time is when the mutation was recorded.
+	Time time.Time `json:"time"`
+	// Operation is the kind of mutation.
+	Operation Operation `json:"operation"`
+	// ServiceName is the name of the CAPZ service which made the mutation.
+	ServiceName string `json:"serviceName"`
+	// ResourceID is the Azure resource ID, if known.
+	ResourceID string `json:"resourceID,omitempty"`
+	// ResourceGroup is the resource group of the resource, if any.
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// ResourceName is the name of the resource. For resources managed by ASO it is the name of the ASO resource.
+	ResourceName string `json:"resourceName"`
+	// Requester is the Kubernetes object whose reconciliation made the mutation.
+	Requester *corev1.ObjectReference `json:"requester,omitempty"`
+	// CorrelationID is the correlation ID sent to Azure with the request.
+	CorrelationID string `json:"correlationID,omitempty"`
+	// Result is the outcome of the mutation.
+	Result Result `json:"result"`
+	// Error is the error returned by the mutation, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink stores or forwards audit records.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+var (
+	defaultSinkMu sync.RWMutex
+	defaultSink   Sink
+)
+
+// SetDefaultSink sets the sink audit records are written to. A nil sink disables auditing.
+func SetDefaultSink(s Sink) {
+	defaultSinkMu.Lock()
+	defer defaultSinkMu.Unlock()
+	defaultSink = s
+}
+
+// DefaultSink returns the sink audit records are written to, or nil when auditing is disabled.
+func DefaultSink() Sink {
+	defaultSinkMu.RLock()
+	defer defaultSinkMu.RUnlock()
+	return defaultSink
+}
+
+// Enabled returns true when audit records are written to a sink.
+func Enabled() bool {
+	return DefaultSink() != nil
+}
+
+// Log completes record with the time, correlation ID and requester from ctx and writes it to the default sink.
+// Failing to write a record is logged but does not fail the mutation it describes.
+func Log(ctx context.Context, record Record) {
+	sink := DefaultSink()
+	if sink == nil {
+		return
+	}
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "audit.Log")
+	defer done()
+
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	if record.CorrelationID == "" {
+		if corrID, ok := tele.CorrIDFromCtx(ctx); ok {
+			record.CorrelationID = string(corrID)
+		}
+	}
+	if record.Requester == nil {
+		record.Requester = RequesterFromContext(ctx)
+	}
+	if err := sink.Write(ctx, record); err != nil {
+		log.Error(err, "failed to write audit record", "operation", record.Operation, "resource", record.ResourceName, "service", record.ServiceName)
+	}
+}
+
+// ResultOf returns the Result of a mutation which returned err. inProgress should be true when the mutation was
+// accepted by Azure but has not completed yet.
+func ResultOf(err error, inProgress bool) (Result, string) {
+	switch {
+	case inProgress:
+		return ResultInProgress, ""
+	case err != nil:
+		return ResultFailed, err.Error()
+	default:
+		return ResultSucceeded, ""
+	}
+}
+
+type requesterKey struct{}
+
+// WithRequester returns a copy of ctx which attributes the mutations made with it to obj.
+func WithRequester(ctx context.Context, gvk schema.GroupVersionKind, obj client.Object) context.Context {
+	return context.WithValue(ctx, requesterKey{}, ObjectReference(gvk, obj))
+}
+
+// RequesterFromContext returns the object mutations made with ctx are attributed to, if any.
+func RequesterFromContext(ctx context.Context) *corev1.ObjectReference {
+	ref, ok := ctx.Value(requesterKey{}).(*corev1.ObjectReference)
+	if !ok {
+		return nil
+	}
+	return ref.DeepCopy()
+}
+
+// ObjectReference returns a reference to obj, which has the given GroupVersionKind.
+func ObjectReference(gvk schema.GroupVersionKind, obj client.Object) *corev1.ObjectReference {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return &corev1.ObjectReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}
+}
+
+// ResourceID returns the value of the ID field of an Azure SDK resource model, or the empty string if it has none.
+func ResourceID(resource any) string {
+	v := reflect.ValueOf(resource)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	id := v.FieldByName("ID")
+	switch {
+	case !id.IsValid():
+		return ""
+	case id.Kind() == reflect.String:
+		return id.String()
+	case id.Kind() == reflect.Pointer && !id.IsNil() && id.Elem().Kind() == reflect.String:
+		return id.Elem().String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// memorySink stores records in memory.
+type memorySink struct {
+	mu      sync.Mutex
+	records []Record
+	err     error
+}
+
+func (s *memorySink) Write(_ context.Context, r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, r)
+	return s.err
+}
+
+func TestLog(t *testing.T) {
+	g := NewWithT(t)
+
+	// Nothing is recorded without a sink.
+	g.Expect(Enabled()).To(BeFalse())
+	Log(context.Background(), Record{Operation: OperationPut})
+
+	sink := &memorySink{}
+	SetDefaultSink(sink)
+	defer SetDefaultSink(nil)
+	g.Expect(Enabled()).To(BeTrue())
+
+	ctx, _, done := tele.StartSpanWithLogger(context.Background(), "test")
+	defer done()
+	corrID, ok := tele.CorrIDFromCtx(ctx)
+	g.Expect(ok).To(BeTrue())
+
+	gvk := schema.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta1", Kind: "AzureCluster"}
+	ctx = WithRequester(ctx, gvk, &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-cluster", UID: "1234"}})
+
+	Log(ctx, Record{Operation: OperationDelete, ServiceName: "publicips", ResourceName: "my-pip", Result: ResultSucceeded})
+	g.Expect(sink.records).To(HaveLen(1))
+	record := sink.records[0]
+	g.Expect(record.Time).NotTo(BeZero())
+	g.Expect(record.CorrelationID).To(Equal(string(corrID)))
+	g.Expect(record.Requester).NotTo(BeNil())
+	g.Expect(record.Requester.APIVersion).To(Equal("infrastructure.cluster.x-k8s.io/v1beta1"))
+	g.Expect(record.Requester.Kind).To(Equal("AzureCluster"))
+	g.Expect(record.Requester.Name).To(Equal("my-cluster"))
+	g.Expect(string(record.Requester.UID)).To(Equal("1234"))
+
+	// Errors writing a record are not returned to the caller.
+	sink.err = errors.New("disk full")
+	Log(ctx, Record{Operation: OperationPut})
+	g.Expect(sink.records).To(HaveLen(2))
+}
+
+func TestResultOf(t *testing.T) {
+	g := NewWithT(t)
+
+	result, message := ResultOf(nil, false)
+	g.Expect(result).To(Equal(ResultSucceeded))
+	g.Expect(message).To(BeEmpty())
+
+	result, message = ResultOf(nil, true)
+	g.Expect(result).To(Equal(ResultInProgress))
+	g.Expect(message).To(BeEmpty())
+
+	result, message = ResultOf(errors.New("conflict"), false)
+	g.Expect(result).To(Equal(ResultFailed))
+	g.Expect(message).To(Equal("conflict"))
+}
+
+func TestResourceID(t *testing.T) {
+	id := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-pip"
+	tests := []struct {
+		name     string
+		resource any
+		expected string
+	}{
+		{name: "nil", resource: nil, expected: ""},
+		{name: "SDK model", resource: armnetwork.PublicIPAddress{ID: ptr.To(id)}, expected: id},
+		{name: "pointer to SDK model", resource: &armnetwork.PublicIPAddress{ID: ptr.To(id)}, expected: id},
+		{name: "nil ID", resource: armnetwork.PublicIPAddress{}, expected: ""},
+		{name: "nil pointer", resource: (*armnetwork.PublicIPAddress)(nil), expected: ""},
+		{name: "string ID", resource: struct{ ID string }{ID: id}, expected: id},
+		{name: "no ID", resource: "my-pip", expected: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ResourceID(tc.resource)).To(Equal(tc.expected))
+		})
+	}
+}
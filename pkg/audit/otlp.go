@@ -0,0 +1,115 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	"sigs.k8s.io/cluster-api-provider-azure/version"
+)
+
+// OTLPSink emits records as OpenTelemetry log records.
+type OTLPSink struct {
+	logger   otellog.Logger
+	shutdown func(context.Context) error
+}
+
+// NewOTLPSink returns an OTLPSink which exports records over gRPC to the OTLP collector at endpoint.
+// Callers should call Shutdown to flush pending records.
+func NewOTLPSink(ctx context.Context, endpoint string) (*OTLPSink, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String("capz"),
+			attribute.String("exporter", "otlp"),
+			attribute.String("version", version.Get().String()),
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create opentelemetry resource")
+	}
+
+	exporter, err := otlploggrpc.New(ctx,
+		otlploggrpc.WithInsecure(),
+		otlploggrpc.WithEndpoint(endpoint),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create otlp log exporter")
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+	)
+	sink := NewLoggerSink(provider)
+	sink.shutdown = provider.Shutdown
+	return sink, nil
+}
+
+// NewLoggerSink returns an OTLPSink which emits records with a logger from provider.
+func NewLoggerSink(provider otellog.LoggerProvider) *OTLPSink {
+	return &OTLPSink{
+		logger:   provider.Logger("sigs.k8s.io/cluster-api-provider-azure/pkg/audit"),
+		shutdown: func(context.Context) error { return nil },
+	}
+}
+
+// Write implements Sink.
+func (s *OTLPSink) Write(ctx context.Context, r Record) error {
+	var rec otellog.Record
+	rec.SetTimestamp(r.Time)
+	rec.SetEventName("capz.azure.mutation")
+	rec.SetSeverity(otellog.SeverityInfo)
+	if r.Result == ResultFailed {
+		rec.SetSeverity(otellog.SeverityWarn)
+	}
+	rec.SetBody(otellog.StringValue(string(r.Operation) + " " + r.ResourceName + ": " + string(r.Result)))
+	rec.AddAttributes(
+		otellog.String("operation", string(r.Operation)),
+		otellog.String("service", r.ServiceName),
+		otellog.String("resource.id", r.ResourceID),
+		otellog.String("resource.group", r.ResourceGroup),
+		otellog.String("resource.name", r.ResourceName),
+		otellog.String("correlation_id", r.CorrelationID),
+		otellog.String("result", string(r.Result)),
+	)
+	if r.Error != "" {
+		rec.AddAttributes(otellog.String("error", r.Error))
+	}
+	if r.Requester != nil {
+		rec.AddAttributes(
+			otellog.String("requester.kind", r.Requester.Kind),
+			otellog.String("requester.namespace", r.Requester.Namespace),
+			otellog.String("requester.name", r.Requester.Name),
+			otellog.String("requester.uid", string(r.Requester.UID)),
+		)
+	}
+	s.logger.Emit(ctx, rec)
+	return nil
+}
+
+// Shutdown flushes pending records and stops the exporter.
+func (s *OTLPSink) Shutdown(ctx context.Context) error {
+	return s.shutdown(ctx)
+}
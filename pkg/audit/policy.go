@@ -0,0 +1,62 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type captureKey struct{}
+
+// capture holds the ID of the last resource mutated with a context.
+type capture struct {
+	mu sync.Mutex
+	id string
+}
+
+// WithResourceIDCapture returns a copy of ctx which records the ID of the resource mutated by requests sent with it,
+// and a function returning that ID. The ID is only recorded by clients whose pipeline includes Policy.
+func WithResourceIDCapture(ctx context.Context) (context.Context, func() string) {
+	c := &capture{}
+	return context.WithValue(ctx, captureKey{}, c), func() string {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.id
+	}
+}
+
+// Policy is an Azure SDK pipeline policy which records the resource ID of PUT, PATCH and DELETE requests in
+// contexts created by WithResourceIDCapture.
+type Policy struct{}
+
+// Do implements policy.Policy.
+func (Policy) Do(req *policy.Request) (*http.Response, error) {
+	raw := req.Raw()
+	switch raw.Method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		if c, ok := raw.Context().Value(captureKey{}).(*capture); ok {
+			c.mu.Lock()
+			c.id = raw.URL.Path
+			c.mu.Unlock()
+		}
+	}
+	return req.Next()
+}
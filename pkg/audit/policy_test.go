@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	. "github.com/onsi/gomega"
+)
+
+func TestPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	pipeline := runtime.NewPipeline("audit", "v0.0.0", runtime.PipelineOptions{PerCall: []policy.Policy{Policy{}}}, &policy.ClientOptions{})
+
+	tests := []struct {
+		method   string
+		expected string
+	}{
+		{method: http.MethodGet, expected: ""},
+		{method: http.MethodPut, expected: "/subscriptions/123/resourceGroups/my-rg"},
+		{method: http.MethodPatch, expected: "/subscriptions/123/resourceGroups/my-rg"},
+		{method: http.MethodDelete, expected: "/subscriptions/123/resourceGroups/my-rg"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.method, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx, capturedID := WithResourceIDCapture(context.Background())
+			req, err := runtime.NewRequest(ctx, tc.method, srv.URL+"/subscriptions/123/resourceGroups/my-rg")
+			g.Expect(err).NotTo(HaveOccurred())
+			_, err = pipeline.Do(req)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(capturedID()).To(Equal(tc.expected))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+)
+
+// MultiSink writes records to each of its sinks.
+type MultiSink []Sink
+
+// Write implements Sink.
+func (m MultiSink) Write(ctx context.Context, record Record) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Write(ctx, record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// EventSink emits records as Kubernetes events on their requester. Records without a requester are dropped.
+type EventSink struct {
+	Recorder record.EventRecorder
+}
+
+// NewEventSink returns an EventSink which emits events with recorder.
+func NewEventSink(recorder record.EventRecorder) *EventSink {
+	return &EventSink{Recorder: recorder}
+}
+
+// Write implements Sink.
+func (s *EventSink) Write(_ context.Context, r Record) error {
+	if r.Requester == nil {
+		return nil
+	}
+	eventType := corev1.EventTypeNormal
+	if r.Result == ResultFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	resource := r.ResourceID
+	if resource == "" {
+		resource = r.ResourceName
+	}
+	message := fmt.Sprintf("%s %s (service: %s, correlation ID: %s): %s", r.Operation, resource, r.ServiceName, r.CorrelationID, r.Result)
+	if r.Error != "" {
+		message += ": " + r.Error
+	}
+	s.Recorder.Event(r.Requester, eventType, "AzureResource"+string(r.Result), message)
+	return nil
+}
+
+// FileSink appends records to a file as JSON lines.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens path for appending, creating it if it does not exist, and returns a FileSink writing to it.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // the path is set by the operator.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open audit log %s", path)
+	}
+	return &FileSink{file: f}, nil
+}
+
+// Write implements Sink.
+func (s *FileSink) Write(_ context.Context, r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit record")
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write audit record")
+	}
+	return nil
+}
+
+// Close closes the underlying file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,146 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var testRecord = Record{
+	Time:          time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	Operation:     OperationPut,
+	ServiceName:   "publicips",
+	ResourceID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-pip",
+	ResourceGroup: "my-rg",
+	ResourceName:  "my-pip",
+	Requester:     &corev1.ObjectReference{Kind: "AzureCluster", Namespace: "default", Name: "my-cluster"},
+	CorrelationID: "abc",
+	Result:        ResultSucceeded,
+}
+
+func TestMultiSink(t *testing.T) {
+	g := NewWithT(t)
+	first, second := &memorySink{err: errors.New("unavailable")}, &memorySink{}
+
+	err := MultiSink{first, second}.Write(context.Background(), testRecord)
+	g.Expect(err).To(MatchError(ContainSubstring("unavailable")))
+	g.Expect(first.records).To(HaveLen(1))
+	g.Expect(second.records).To(HaveLen(1))
+}
+
+func TestEventSink(t *testing.T) {
+	g := NewWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	sink := NewEventSink(recorder)
+
+	g.Expect(sink.Write(context.Background(), testRecord)).To(Succeed())
+	g.Expect(recorder.Events).To(Receive(Equal("Normal AzureResourceSucceeded PUT " + testRecord.ResourceID + " (service: publicips, correlation ID: abc): Succeeded")))
+
+	failed := testRecord
+	failed.Result = ResultFailed
+	failed.Error = "quota exceeded"
+	g.Expect(sink.Write(context.Background(), failed)).To(Succeed())
+	g.Expect(recorder.Events).To(Receive(HaveSuffix("Failed: quota exceeded")))
+
+	// Records without a requester have nothing to attach an event to.
+	noRequester := testRecord
+	noRequester.Requester = nil
+	g.Expect(sink.Write(context.Background(), noRequester)).To(Succeed())
+	g.Expect(recorder.Events).NotTo(Receive())
+}
+
+func TestFileSink(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := NewFileSink(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sink.Write(context.Background(), testRecord)).To(Succeed())
+	g.Expect(sink.Close()).To(Succeed())
+
+	// Records are appended to an existing file.
+	sink, err = NewFileSink(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	deleted := testRecord
+	deleted.Operation = OperationDelete
+	g.Expect(sink.Write(context.Background(), deleted)).To(Succeed())
+	g.Expect(sink.Close()).To(Succeed())
+
+	f, err := os.Open(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		g.Expect(json.Unmarshal(scanner.Bytes(), &r)).To(Succeed())
+		records = append(records, r)
+	}
+	g.Expect(records).To(Equal([]Record{testRecord, deleted}))
+}
+
+// memoryExporter is an sdklog.Exporter which stores records in memory.
+type memoryExporter struct {
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+func TestOTLPSink(t *testing.T) {
+	g := NewWithT(t)
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	sink := NewLoggerSink(provider)
+
+	g.Expect(sink.Write(context.Background(), testRecord)).To(Succeed())
+	g.Expect(sink.Shutdown(context.Background())).To(Succeed())
+
+	g.Expect(exporter.records).To(HaveLen(1))
+	r := exporter.records[0]
+	g.Expect(r.Timestamp()).To(Equal(testRecord.Time))
+	g.Expect(r.Severity()).To(Equal(otellog.SeverityInfo))
+	attrs := map[string]string{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.AsString()
+		return true
+	})
+	g.Expect(attrs).To(HaveKeyWithValue("operation", "PUT"))
+	g.Expect(attrs).To(HaveKeyWithValue("resource.id", testRecord.ResourceID))
+	g.Expect(attrs).To(HaveKeyWithValue("correlation_id", "abc"))
+	g.Expect(attrs).To(HaveKeyWithValue("requester.name", "my-cluster"))
+}
@@ -22,6 +22,10 @@ import (
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"sigs.k8s.io/cluster-api-provider-azure/azure/cost"
+	azuremetrics "sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/ratelimit"
 )
 
 // RegisterMetrics enables prometheus metrics for OpenTelemetry.
@@ -35,5 +39,9 @@ func RegisterMetrics() error {
 	meterProvider := metric.NewMeterProvider(metric.WithReader(exporter))
 	otel.SetMeterProvider(meterProvider)
 
+	metrics.Registry.MustRegister(ratelimit.Collectors()...)
+	metrics.Registry.MustRegister(cost.Collectors()...)
+	metrics.Registry.MustRegister(azuremetrics.Collectors()...)
+
 	return nil
 }
@@ -22,3 +22,9 @@ import "fmt"
 func GetASOSecretName(clusterOwner string) string {
 	return fmt.Sprintf("%s-aso-secret", clusterOwner)
 }
+
+// GetASOVnetSecretName formats the name of the ASO Secret created by the capz controller for a virtual network in
+// another subscription than the cluster.
+func GetASOVnetSecretName(clusterOwner string) string {
+	return fmt.Sprintf("%s-aso-vnet-secret", clusterOwner)
+}
@@ -18,6 +18,7 @@ package futures
 
 import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
 )
 
 // Setter interface defines methods that an object should implement in order to
@@ -54,8 +55,8 @@ func Set(to Setter, future *infrav1.Future) {
 	to.SetFutures(futures)
 }
 
-// Delete deletes the specified future.
-func Delete(to Setter, name, service, futureType string) {
+// Delete deletes the specified future and stops counting it as a long-running operation in flight in subscriptionID.
+func Delete(to Setter, subscriptionID, name, service, futureType string) {
 	if to == nil || name == "" || service == "" || futureType == "" {
 		return
 	}
@@ -63,6 +64,7 @@ func Delete(to Setter, name, service, futureType string) {
 	futures := to.GetFutures()
 	for i, f := range futures {
 		if f.Name == name && f.ServiceName == service && f.Type == futureType {
+			metrics.UntrackLongRunningOperation(longRunningOperation(to, subscriptionID, f))
 			futures = append(futures[:i], futures[i+1:]...)
 			break
 		}
@@ -70,3 +72,32 @@ func Delete(to Setter, name, service, futureType string) {
 
 	to.SetFutures(futures)
 }
+
+// Track counts future of owner as a long-running operation in flight in subscriptionID. Tracking a future again, e.g.
+// once it is resumed from the state stored in owner after a restart, does not count it twice.
+func Track(owner Getter, subscriptionID string, future *infrav1.Future) {
+	if owner == nil || future == nil {
+		return
+	}
+	metrics.TrackLongRunningOperation(longRunningOperation(owner, subscriptionID, *future))
+}
+
+// Untrack stops counting the futures of from as long-running operations in flight. It is called once from is deleted,
+// since its futures are neither resumed nor deleted afterwards.
+func Untrack(from Getter) {
+	if from == nil {
+		return
+	}
+	metrics.UntrackLongRunningOperations(string(from.GetUID()))
+}
+
+func longRunningOperation(owner Getter, subscriptionID string, f infrav1.Future) metrics.LongRunningOperation {
+	return metrics.LongRunningOperation{
+		Owner:          string(owner.GetUID()),
+		SubscriptionID: subscriptionID,
+		ResourceGroup:  f.ResourceGroup,
+		Name:           f.Name,
+		Service:        f.ServiceName,
+		Type:           f.Type,
+	}
+}
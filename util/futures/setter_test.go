@@ -20,8 +20,10 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/metrics"
 )
 
 func TestSet(t *testing.T) {
@@ -105,13 +107,93 @@ func TestDelete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			Delete(tt.to, tt.future, testService, fakeFutureType)
+			Delete(tt.to, "sub", tt.future, testService, fakeFutureType)
 
 			g.Expect(tt.to.GetFutures()).To(Equal(tt.want))
 		})
 	}
 }
 
+func TestDeleteUntracksLongRunningOperation(t *testing.T) {
+	g := NewWithT(t)
+	testService := "test-delete-untrack"
+	a := fakeFuture("a", testService)
+	b := fakeFuture("b", testService)
+	to := setterWithFutures(infrav1.Futures{a, b})
+	Track(to, "sub", &a)
+	Track(to, "sub", &b)
+	// Resuming a future does not count it twice.
+	Track(to, "sub", &a)
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(Equal(float64(2)))
+
+	Delete(to, "sub", "a", testService, fakeFutureType)
+
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(Equal(float64(1)))
+}
+
+func TestTrackOwners(t *testing.T) {
+	g := NewWithT(t)
+	testService := "test-track-owners"
+	// Two owners with the state of an operation on a resource with the same name in the same resource group.
+	a := fakeFuture("a", testService)
+	owner := setterWithFutures(infrav1.Futures{a})
+	owner.SetUID("owner-uid")
+	other := setterWithFutures(infrav1.Futures{a})
+	other.SetUID("other-uid")
+	Track(owner, "sub", &a)
+	Track(other, "sub", &a)
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(Equal(float64(2)))
+
+	Delete(owner, "sub", "a", testService, fakeFutureType)
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(Equal(float64(1)))
+
+	Untrack(owner)
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(Equal(float64(1)))
+
+	Untrack(other)
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(BeZero())
+}
+
+func TestUntrack(t *testing.T) {
+	g := NewWithT(t)
+	testService := "test-untrack"
+	a := fakeFuture("a", testService)
+	b := fakeFuture("b", testService)
+	owner := setterWithFutures(infrav1.Futures{a, b})
+	owner.SetUID("untrack-owner-uid")
+	Track(owner, "sub", &a)
+	Track(owner, "sub-2", &b)
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(Equal(float64(2)))
+
+	// The owner is deleted with its futures.
+	Untrack(owner)
+
+	g.Expect(longRunningOperationsInFlight(g, testService)).To(BeZero())
+}
+
+// longRunningOperationsInFlight returns the value of the long-running operations gauge for service.
+func longRunningOperationsInFlight(g *WithT, service string) float64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.Collectors()...)
+	families, err := registry.Gather()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var inFlight float64
+	for _, family := range families {
+		if family.GetName() != "capz_long_running_operations" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "service" && label.GetValue() == service {
+					inFlight += metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return inFlight
+}
+
 func setterWithFutures(futures infrav1.Futures) Setter {
 	obj := &infrav1.AzureCluster{}
 	obj.SetFutures(futures)